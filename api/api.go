@@ -0,0 +1,28 @@
+// Package api embeds the OpenAPI specification of the service.
+package api
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Spec is the OpenAPI 3 specification in YAML.
+//
+//go:embed openapi.yml
+var Spec []byte
+
+// SpecJSON returns Spec converted to JSON.
+func SpecJSON() ([]byte, error) {
+	var doc map[string]any
+	if err := yaml.Unmarshal(Spec, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI spec: %w", err)
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert OpenAPI spec to JSON: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/admin"
+	"github.com/mishasvintus/avito_backend_internship/internal/config"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+)
+
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	db, err := repository.NewPostgresDB(cfg.Database.DSN(), repository.PoolOptions{
+		MaxOpenConns:     cfg.Database.MaxOpenConns,
+		MaxIdleConns:     cfg.Database.MaxIdleConns,
+		ConnMaxLifetime:  cfg.Database.ConnMaxLifetime,
+		StatementTimeout: cfg.Database.StatementTimeout,
+	})
+	if err != nil {
+		log.Fatalf("Failed to connect to database %s: %v", cfg.Database.RedactedDSN(), err)
+	}
+	defer func() { _ = db.Close() }()
+
+	strategy, err := service.ParseStrategy(cfg.Assignment.Strategy)
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+
+	reviewerAssigner := service.NewReviewerAssigner().
+		WithCapacityFallback(cfg.Assignment.CapacityFallback).
+		WithStrategy(strategy)
+	prService := service.NewPRService(db, reviewerAssigner).
+		WithRetryPolicy(service.RetryPolicy{Attempts: cfg.Retry.Attempts, BaseDelay: cfg.Retry.BaseDelay})
+
+	services := admin.Services{
+		Teams: service.NewTeamService(db, prService),
+		PRs:   prService,
+		Stats: service.NewStatsService(db, service.NewSystemClock()).WithQueryTimeout(cfg.Stats.QueryTimeout),
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	err = admin.Run(ctx, os.Args[1:], services, os.Stdout, os.Stderr)
+	stop()
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+	case errors.Is(err, admin.ErrUsage):
+		log.Print(err)
+		_ = db.Close()
+		os.Exit(2)
+	default:
+		log.Print(err)
+		_ = db.Close()
+		os.Exit(1)
+	}
+}
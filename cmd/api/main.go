@@ -2,19 +2,34 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
-	"os"
 	"os/signal"
 	"syscall"
-	"time"
+	// Digest schedules name IANA time zones; the runtime image has no zoneinfo.
+	_ "time/tzdata"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/mishasvintus/avito_backend_internship/internal/config"
 	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/integration"
+	"github.com/mishasvintus/avito_backend_internship/internal/lifecycle"
+	"github.com/mishasvintus/avito_backend_internship/internal/metrics"
+	"github.com/mishasvintus/avito_backend_internship/internal/middleware"
+	"github.com/mishasvintus/avito_backend_internship/internal/queue"
 	"github.com/mishasvintus/avito_backend_internship/internal/repository"
 	"github.com/mishasvintus/avito_backend_internship/internal/router"
+	"github.com/mishasvintus/avito_backend_internship/internal/server"
 	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/internal/tracing"
 )
 
 func main() {
@@ -23,51 +38,255 @@ func main() {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
-	db, err := repository.NewPostgresDB(cfg.Database.DSN())
+	var tracerProvider *sdktrace.TracerProvider
+	if cfg.Tracing.Enabled {
+		tracerProvider, err = tracing.Setup(context.Background())
+		if err != nil {
+			log.Fatalf("Failed to set up tracing: %v", err)
+		}
+		log.Println("Exporting traces over OTLP")
+	}
+
+	log.Printf("Connecting to database %s", cfg.Database.RedactedDSN())
+	poolOptions := repository.PoolOptions{
+		MaxOpenConns:     cfg.Database.MaxOpenConns,
+		MaxIdleConns:     cfg.Database.MaxIdleConns,
+		ConnMaxLifetime:  cfg.Database.ConnMaxLifetime,
+		StatementTimeout: cfg.Database.StatementTimeout,
+	}
+	db, err := repository.NewPostgresDB(cfg.Database.DSN(), poolOptions)
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
-	defer func() { _ = db.Close() }()
+	if cfg.Database.SchemaCheck != config.SchemaCheckOff {
+		checkSchema(db, cfg.Database.SchemaCheck == config.SchemaCheckStrict)
+	}
 
-	reviewerAssigner := service.NewReviewerAssigner()
-	prService := service.NewPRService(db, reviewerAssigner)
+	var replica *sql.DB
+	if cfg.Database.ReplicaDSN != "" {
+		// The replica is not pinged here: reads fall back to the primary until it answers.
+		replica, err = repository.OpenPostgresDB(cfg.Database.ReplicaDSN, poolOptions)
+		if err != nil {
+			log.Fatalf("Failed to open database replica: %v", err)
+		}
+		log.Println("Routing read-only queries to the database replica")
+	}
+	dbRouter := repository.NewDBRouter(db, replica)
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collectors.NewGoCollector())
+	var poolCollector *metrics.DBPoolCollector
+	if cfg.Database.StatsInterval > 0 {
+		poolCollector = metrics.NewDBPoolCollector(db, registry, cfg.Database.StatsInterval, slog.Default())
+	}
+
+	strategy, err := service.ParseStrategy(cfg.Assignment.Strategy)
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+
+	reviewerAssigner := service.NewReviewerAssigner().
+		WithCapacityFallback(cfg.Assignment.CapacityFallback).
+		WithStrategy(strategy)
+	if cfg.Assignment.LogDecisions {
+		reviewerAssigner.WithDecisionLogger(slog.Default())
+	}
+	prService := service.NewPRService(db, reviewerAssigner).
+		WithRetryPolicy(service.RetryPolicy{Attempts: cfg.Retry.Attempts, BaseDelay: cfg.Retry.BaseDelay}).
+		WithReassignLimit(cfg.Assignment.ReassignLimit).
+		WithAuthorCreateLimit(cfg.Assignment.AuthorCreateLimit).
+		WithDBRouter(dbRouter)
 	teamService := service.NewTeamService(db, prService)
-	userService := service.NewUserService(db)
-	statsService := service.NewStatsService(db)
+	userService := service.NewUserService(db, prService)
+	clock := service.NewSystemClock()
+	webhookService := service.NewWebhookService(db)
+	webhookSender := service.NewWebhookSender(webhookService).
+		WithHTTPClient(&http.Client{Timeout: cfg.Webhook.Timeout}).
+		WithRetryPolicy(service.RetryPolicy{Attempts: cfg.Webhook.MaxAttempts, BaseDelay: cfg.Webhook.RetryBaseDelay})
+	slackNotifier := service.NewSlackNotifier(teamService).
+		WithHTTPClient(&http.Client{Timeout: cfg.Webhook.Timeout})
+	outboxDispatcher := service.NewOutboxDispatcher(
+		db, cfg.Outbox.PollInterval, cfg.Outbox.BatchSize, cfg.Outbox.MaxAttempts,
+	).WithPublisher(webhookSender).WithPublisher(slackNotifier)
+	var natsProducer *queue.NATSProducer
+	if len(cfg.Queue.Servers) > 0 {
+		natsProducer = queue.NewNATSProducer(cfg.Queue.Servers, cfg.Queue.Subject).WithTimeout(cfg.Queue.Timeout)
+		if cfg.Queue.CredentialsFile != "" {
+			natsProducer.WithCredentials(cfg.Queue.CredentialsFile)
+		}
+		if cfg.Queue.NKeySeedFile != "" {
+			natsProducer.WithNKeySeed(cfg.Queue.NKeySeedFile)
+		}
+		outboxDispatcher.WithPublisher(service.NewQueuePublisher(natsProducer))
+	}
+	statsService := service.NewStatsService(db, clock).
+		WithQueryTimeout(cfg.Stats.QueryTimeout).
+		WithDBRouter(dbRouter)
+	if cfg.Stats.CacheTTL > 0 {
+		statsService.WithCache(service.NewStatsCache(cfg.Stats.CacheTTL, clock, prService.DataVersion()))
+	}
+	var coverageCollector *metrics.CoverageCollector
+	if cfg.Stats.CoverageInterval > 0 {
+		coverageCollector = metrics.NewCoverageCollector(statsService, registry, cfg.Stats.CoverageInterval, slog.Default())
+	}
 
-	teamHandler := handler.NewTeamHandler(teamService)
+	teamHandler := handler.NewTeamHandler(teamService).
+		WithLegacyTeamExistsStatus(cfg.Server.LegacyTeamExistsStatus)
 	userHandler := handler.NewUserHandler(userService)
 	prHandler := handler.NewPRHandler(prService)
-	statsHandler := handler.NewStatsHandler(statsService)
+	statsHandler := handler.NewStatsHandler(statsService).
+		WithCacheTTL(cfg.Stats.CacheTTL).
+		WithAnonymization([]byte(cfg.Stats.AnonymizeKey), cfg.Stats.Anonymize)
+	webhookHandler := handler.NewWebhookHandler(webhookService)
+	auditHandler := handler.NewAuditHandler(service.NewAuditService(db))
+	orgService := service.NewOrgService(db)
+	orgHandler := handler.NewOrgHandler(orgService)
+	integrationHandler := handler.NewIntegrationHandler(service.NewIntegrationService(db, prService)).
+		WithGitLabToken(cfg.Integrations.GitLabWebhookToken)
+	var gitHubSync *service.GitHubSyncService
+	if cfg.Integrations.GitHubToken != "" {
+		gitHubClient := integration.NewGitHubRESTClient(cfg.Integrations.GitHubToken, cfg.Integrations.GitHubOrg).
+			WithBaseURL(cfg.Integrations.GitHubAPIURL)
+		gitHubSync = service.NewGitHubSyncService(db, prService, gitHubClient, cfg.Integrations.GitHubOrg).
+			WithInterval(cfg.Integrations.GitHubSyncInterval)
+		integrationHandler.WithGitHubSync(gitHubSync)
+	}
+
+	// A nil *sdktrace.TracerProvider would make a non-nil interface and enable the middleware.
+	var routerTracer trace.TracerProvider
+	if tracerProvider != nil {
+		routerTracer = tracerProvider
+	}
 
-	r := router.SetupRoutes(teamHandler, userHandler, prHandler, statsHandler)
+	var circuitBreaker *middleware.CircuitBreaker
+	if cfg.Database.BreakerOpenTimeout > 0 {
+		circuitBreaker = middleware.NewCircuitBreaker(cfg.Database.BreakerThreshold, cfg.Database.BreakerOpenTimeout, clock)
+	}
 
-	addr := fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port)
-	srv := &http.Server{
-		Addr:    addr,
-		Handler: r,
+	var rateLimiter *middleware.RateLimiter
+	if cfg.RateLimit.Rate > 0 {
+		rateLimiter = middleware.NewRateLimiter(cfg.RateLimit.Rate, cfg.RateLimit.Burst, clock)
 	}
 
-	go func() {
-		log.Printf("Server starting on %s", addr)
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Failed to start server: %v", err)
+	var latencyBudget *middleware.LatencyBudget
+	if cfg.Server.LatencyBudget > 0 {
+		latencyBudget = middleware.NewLatencyBudget(cfg.Server.LatencyBudget, cfg.Server.RouteLatencyBudgets, registry, slog.Default())
+	}
+
+	r, err := router.SetupRoutes(router.Options{
+		Mode:                cfg.Server.GinMode,
+		TrustedProxies:      cfg.Server.TrustedProxies,
+		MaxBodyBytes:        cfg.Server.MaxBodyBytes,
+		RequestTimeout:      cfg.Server.RequestTimeout,
+		GzipMinSize:         cfg.Server.GzipMinSize,
+		DisableLegacyRoutes: !cfg.Server.LegacyRoutes,
+		DocsUI:              cfg.Server.DocsUI,
+		RateLimiter:         rateLimiter,
+		AdminAPIKeys:        cfg.Auth.AdminAPIKeys,
+		TracerProvider:      routerTracer,
+		Metrics:             promhttp.HandlerFor(registry, promhttp.HandlerOpts{}),
+		CircuitBreaker:      circuitBreaker,
+		Orgs:                orgService,
+		LatencyBudget:       latencyBudget,
+		CORS: middleware.CORSOptions{
+			AllowedOrigins: cfg.CORS.AllowedOrigins,
+			AllowedMethods: cfg.CORS.AllowedMethods,
+			AllowedHeaders: cfg.CORS.AllowedHeaders,
+			MaxAge:         cfg.CORS.MaxAge,
+		},
+	}, teamHandler, userHandler, prHandler, statsHandler, webhookHandler, integrationHandler, auditHandler, orgHandler)
+	if err != nil {
+		log.Fatalf("Failed to set up routes: %v", err)
+	}
+
+	// Components stop in reverse order: the HTTP server drains first, then the workers,
+	// then the connections they use, and buffered spans are flushed last.
+	timeout := cfg.Server.ShutdownTimeout
+	components := lifecycle.New()
+	if tracerProvider != nil {
+		components.Add("tracing", lifecycle.OnStop(tracerProvider.Shutdown), timeout)
+	}
+	components.Add("database", lifecycle.Closer(db), timeout)
+	if replica != nil {
+		components.Add("database replica", lifecycle.Closer(replica), timeout)
+	}
+	if natsProducer != nil {
+		components.Add("nats producer", lifecycle.Closer(natsProducer), timeout)
+	}
+	components.Add("outbox dispatcher", lifecycle.Worker(outboxDispatcher.Run), timeout)
+	if cfg.Escalation.SLA > 0 {
+		escalationWorker := service.NewEscalationWorker(
+			db, prService, clock,
+			cfg.Escalation.Interval, cfg.Escalation.SLA, cfg.Escalation.BatchSize,
+		)
+		components.Add("escalation worker", lifecycle.Worker(escalationWorker.Run), timeout)
+	}
+	if cfg.Digest.CheckInterval > 0 {
+		digestScheduler := service.NewDigestScheduler(db, prService, clock, cfg.Digest.CheckInterval)
+		components.Add("digest scheduler", lifecycle.Worker(digestScheduler.Run), timeout)
+	}
+	if gitHubSync != nil && cfg.Integrations.GitHubSyncInterval > 0 {
+		components.Add("github sync", lifecycle.Worker(gitHubSync.Run), timeout)
+	}
+	if poolCollector != nil {
+		components.Add("db pool collector", lifecycle.Worker(poolCollector.Run), timeout)
+	}
+	if coverageCollector != nil {
+		components.Add("coverage collector", lifecycle.Worker(coverageCollector.Run), timeout)
+	}
+
+	addr := fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port)
+	srv := server.New(addr, r)
+	if cfg.Server.TLSEnabled() {
+		srv.WithTLS(cfg.Server.TLSCertFile, cfg.Server.TLSKeyFile)
+		if cfg.Server.TLSRedirectPort != "" {
+			srv.WithRedirect(fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.TLSRedirectPort))
 		}
-	}()
+	}
+	components.Add("http server", srv, timeout)
+
+	if err := components.Start(context.Background()); err != nil {
+		log.Fatalf("Failed to start server: %v", err)
+	}
+	if cfg.Server.TLSEnabled() {
+		log.Printf("Server listening on %s (HTTPS)", srv.Addr())
+	} else {
+		log.Printf("Server listening on %s", srv.Addr())
+	}
+	if addr := srv.RedirectAddr(); addr != "" {
+		log.Printf("Redirecting HTTP on %s to HTTPS", addr)
+	}
 
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+	quit, stopSignals := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	if err := components.Wait(quit); err != nil {
+		log.Printf("Server failed: %v", err)
+	}
+	stopSignals()
 
 	log.Println("Shutting down server...")
 
-	// Graceful shutdown with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	if err := srv.Shutdown(ctx); err != nil {
+	if err := components.Stop(); err != nil {
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
 
 	log.Println("Server exited")
 }
+
+// checkSchema logs the tables, columns, constraints and indexes missing from the database.
+// In strict mode a missing item, or a failure to inspect the schema, stops the process.
+func checkSchema(db *sql.DB, strict bool) {
+	missing, err := repository.VerifySchema(db)
+	switch {
+	case err != nil && strict:
+		log.Fatalf("Failed to verify database schema: %v", err)
+	case err != nil:
+		slog.Warn("Failed to verify database schema", "error", err)
+	case len(missing) == 0:
+		log.Printf("Database schema matches migration %03d", repository.SchemaMigration)
+	case strict:
+		slog.Error("Database schema is missing required items", "migration", repository.SchemaMigration, "missing", missing)
+		log.Fatalf("Refusing to start: database schema is behind migration %03d, apply the migrations first", repository.SchemaMigration)
+	default:
+		slog.Warn("Database schema is missing required items", "migration", repository.SchemaMigration, "missing", missing)
+	}
+}
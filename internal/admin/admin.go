@@ -0,0 +1,95 @@
+// Package admin implements the operational subcommands of the admin CLI on top of the service layer.
+package admin
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+)
+
+// ErrUsage is returned when the command line is invalid; the details have been written to stderr.
+var ErrUsage = errors.New("invalid usage")
+
+// Services are the service layer dependencies of the commands.
+type Services struct {
+	Teams *service.TeamService
+	PRs   *service.PRService
+	Stats *service.StatsService
+}
+
+// command is a subcommand; run receives the arguments following its name.
+type command struct {
+	summary string
+	run     func(ctx context.Context, svc Services, args []string, stdout, stderr io.Writer) error
+}
+
+var commands = map[string]command{
+	"seed":      {summary: "create demo teams, users and pull requests", run: runSeed},
+	"rebalance": {summary: "redistribute open review assignments of a team evenly", run: runRebalance},
+	"stats":     {summary: "print assignment statistics as a table or JSON", run: runStats},
+}
+
+// Run executes the subcommand named by args[0], writing its report to stdout
+// and usage information to stderr.
+func Run(ctx context.Context, args []string, svc Services, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		printUsage(stderr)
+		return fmt.Errorf("%w: no command given", ErrUsage)
+	}
+	if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printUsage(stdout)
+		return nil
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		printUsage(stderr)
+		return fmt.Errorf("%w: unknown command %q", ErrUsage, args[0])
+	}
+	return cmd.run(ctx, svc, args[1:], stdout, stderr)
+}
+
+// printUsage lists the available commands.
+func printUsage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	_, _ = fmt.Fprintln(w, "Usage: admin <command> [flags]")
+	_, _ = fmt.Fprintln(w, "\nCommands:")
+	for _, name := range names {
+		_, _ = fmt.Fprintf(w, "  %-10s %s\n", name, commands[name].summary)
+	}
+	_, _ = fmt.Fprintln(w, "\nRun 'admin <command> -h' for the flags of a command.")
+}
+
+// newFlagSet returns a flag set for the named command with the --dry-run flag every command accepts.
+func newFlagSet(name string, stderr io.Writer) (*flag.FlagSet, *bool) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	dryRun := fs.Bool("dry-run", false, "report what would be done without changing any data")
+	return fs, dryRun
+}
+
+// parseFlags parses args and rejects positional arguments.
+// flag.ErrHelp is returned as is so that -h is not reported as a failure.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %s: %v", ErrUsage, fs.Name(), err)
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("%w: %s: unexpected arguments: %s", ErrUsage, fs.Name(), strings.Join(fs.Args(), " "))
+	}
+	return nil
+}
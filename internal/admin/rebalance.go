@@ -0,0 +1,57 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+)
+
+// Rebalance redistributes the open review assignments of the team and writes the moves to out.
+func Rebalance(ctx context.Context, svc Services, teamName string, opts service.RebalanceOptions, out io.Writer) ([]service.RebalanceMove, error) {
+	moves, err := svc.PRs.RebalanceTeam(ctx, teamName, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rebalance team %s: %w", teamName, err)
+	}
+
+	if len(moves) == 0 {
+		_, _ = fmt.Fprintf(out, "team %s is already balanced\n", teamName)
+		return moves, nil
+	}
+
+	tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "REPOSITORY\tPULL REQUEST\tFROM\tTO")
+	for _, m := range moves {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", m.PR.RepositoryName, m.PR.PullRequestID, m.From, m.To)
+	}
+	if err := tw.Flush(); err != nil {
+		return nil, err
+	}
+
+	verb := "moved"
+	if opts.DryRun {
+		verb = "dry run: would move"
+	}
+	_, _ = fmt.Fprintf(out, "%s %d assignments\n", verb, len(moves))
+	return moves, nil
+}
+
+func runRebalance(ctx context.Context, svc Services, args []string, stdout, stderr io.Writer) error {
+	fs, dryRun := newFlagSet("rebalance", stderr)
+	teamName := fs.String("team", "", "name of the team to rebalance (required)")
+	maxMoves := fs.Int("max-moves", 0, "maximum number of moved assignments, 0 for no limit")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *teamName == "" {
+		return fmt.Errorf("%w: rebalance: --team is required", ErrUsage)
+	}
+	if *maxMoves < 0 {
+		return fmt.Errorf("%w: rebalance: --max-moves must not be negative", ErrUsage)
+	}
+
+	_, err := Rebalance(ctx, svc, *teamName, service.RebalanceOptions{DryRun: *dryRun, MaxMoves: *maxMoves}, stdout)
+	return err
+}
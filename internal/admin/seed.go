@@ -0,0 +1,121 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+)
+
+// prefixPattern keeps generated IDs within the entity ID format of the API.
+var prefixPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,50}$`)
+
+// SeedOptions sizes the demo data created by Seed.
+type SeedOptions struct {
+	// Prefix starts every team, user, repository and pull request ID.
+	Prefix string
+	Teams  int
+	// Users and PRs are counts per team.
+	Users  int
+	PRs    int
+	DryRun bool
+}
+
+// SeedResult counts what Seed created. Existing teams and PRs are left as they are
+// and not counted, so seeding twice with the same options creates nothing the second time.
+type SeedResult struct {
+	Teams int
+	Users int
+	PRs   int
+}
+
+// Seed creates opts.Teams teams of opts.Users active users each, and opts.PRs open pull requests
+// per team authored by its members in turn, with reviewers assigned as for any new PR.
+// A dry run only counts what would be created.
+func Seed(ctx context.Context, svc Services, opts SeedOptions, out io.Writer) (SeedResult, error) {
+	var result SeedResult
+
+	for i := 1; i <= opts.Teams; i++ {
+		t := &domain.Team{TeamName: fmt.Sprintf("%s-team-%d", opts.Prefix, i)}
+		for j := 1; j <= opts.Users; j++ {
+			id := fmt.Sprintf("%s-t%d-u%d", opts.Prefix, i, j)
+			t.Members = append(t.Members, domain.TeamMember{UserID: id, Username: id, IsActive: true})
+		}
+
+		if opts.DryRun {
+			_, _ = fmt.Fprintf(out, "would create team %s with %d users and %d pull requests\n", t.TeamName, opts.Users, opts.PRs)
+			result.Teams++
+			result.Users += opts.Users
+			result.PRs += opts.PRs
+			continue
+		}
+
+		_, outcome, err := svc.Teams.CreateTeam(ctx, t, service.CreateTeamOptions{
+			ConflictPolicy: service.ConflictMove,
+			IfExists:       service.IfExistsUpdate,
+		})
+		if err != nil {
+			return result, fmt.Errorf("failed to create team %s: %w", t.TeamName, err)
+		}
+		_, _ = fmt.Fprintf(out, "team %s: %s\n", t.TeamName, outcome)
+		if outcome == service.TeamCreated {
+			result.Teams++
+			result.Users += opts.Users
+		}
+
+		for k := 1; k <= opts.PRs; k++ {
+			key := domain.PRKey{
+				RepositoryName: opts.Prefix + "-repo",
+				PullRequestID:  fmt.Sprintf("%s-t%d-pr%d", opts.Prefix, i, k),
+			}
+			author := t.Members[(k-1)%len(t.Members)].UserID
+			created, err := svc.PRs.CreatePR(ctx, key, "Demo change "+key.PullRequestID, author, nil, domain.PRDetails{})
+			if errors.Is(err, service.ErrPRExists) {
+				continue
+			}
+			if err != nil {
+				return result, fmt.Errorf("failed to create pull request %s: %w", key, err)
+			}
+			_, _ = fmt.Fprintf(out, "pull request %s: created, reviewers %v\n", key, created.AssignedReviewersIDs)
+			result.PRs++
+		}
+	}
+
+	return result, nil
+}
+
+func runSeed(ctx context.Context, svc Services, args []string, stdout, stderr io.Writer) error {
+	fs, dryRun := newFlagSet("seed", stderr)
+	opts := SeedOptions{}
+	fs.StringVar(&opts.Prefix, "prefix", "demo", "prefix of the generated IDs")
+	fs.IntVar(&opts.Teams, "teams", 3, "number of teams")
+	fs.IntVar(&opts.Users, "users", 5, "number of users per team")
+	fs.IntVar(&opts.PRs, "prs", 10, "number of pull requests per team")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	opts.DryRun = *dryRun
+
+	if !prefixPattern.MatchString(opts.Prefix) {
+		return fmt.Errorf("%w: seed: --prefix must be 1-50 letters, digits, '.', '_' or '-'", ErrUsage)
+	}
+	if opts.Teams < 1 || opts.Users < 1 || opts.PRs < 0 {
+		return fmt.Errorf("%w: seed: --teams and --users must be positive and --prs not negative", ErrUsage)
+	}
+
+	result, err := Seed(ctx, svc, opts, stdout)
+	if err != nil {
+		return err
+	}
+
+	verb := "seeded"
+	if opts.DryRun {
+		verb = "dry run: would seed"
+	}
+	_, _ = fmt.Fprintf(stdout, "%s %d teams, %d users, %d pull requests\n", verb, result.Teams, result.Users, result.PRs)
+	return nil
+}
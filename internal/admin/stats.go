@@ -0,0 +1,65 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/stats"
+)
+
+// Output formats of the stats command.
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+)
+
+// Stats writes all-time statistics to out, either as tables or as the JSON body of GET /stats.
+func Stats(ctx context.Context, svc Services, format string, out io.Writer) error {
+	statistics, err := svc.Stats.GetStatistics(ctx, stats.Period{})
+	if err != nil {
+		return fmt.Errorf("failed to get statistics: %w", err)
+	}
+	response := handler.NewStatisticsResponse(statistics)
+
+	if format == FormatJSON {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(response)
+	}
+
+	tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "pull requests\t%d\n", response.Overall.TotalPRs)
+	_, _ = fmt.Fprintf(tw, "merged\t%d\n", response.Overall.MergedPRs)
+	_, _ = fmt.Fprintf(tw, "assignments\t%d\n", response.Overall.TotalAssignments)
+	_, _ = fmt.Fprintf(tw, "users\t%d\n", response.Overall.TotalUsers)
+	_, _ = fmt.Fprintf(tw, "teams\t%d\n", response.Overall.TotalTeams)
+
+	_, _ = fmt.Fprintln(tw, "\nREVIEWER\tASSIGNED\tOPEN\tOPEN LOAD\tMERGED")
+	for _, r := range response.ReviewerStats {
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\n", r.UserID, r.Count, r.OpenCount, r.OpenLoad, r.MergedCount)
+	}
+
+	_, _ = fmt.Fprintln(tw, "\nTEAM\tACTIVE USERS\tMIN\tMAX\tMEAN\tSTDDEV")
+	for _, t := range response.Distribution.Teams {
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%.2f\t%.2f\n", t.TeamName, t.ActiveUsers, t.Min, t.Max, t.Mean, t.StdDev)
+	}
+	return tw.Flush()
+}
+
+// runStats accepts --dry-run like every command; the command never changes data.
+func runStats(ctx context.Context, svc Services, args []string, stdout, stderr io.Writer) error {
+	fs, _ := newFlagSet("stats", stderr)
+	format := fs.String("format", FormatTable, "output format: table or json")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *format != FormatTable && *format != FormatJSON {
+		return fmt.Errorf("%w: stats: unknown format %q", ErrUsage, *format)
+	}
+
+	return Stats(ctx, svc, *format, stdout)
+}
@@ -2,22 +2,78 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"net"
+	"net/url"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 // Config holds all application configuration.
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
+	Server       ServerConfig
+	Database     DatabaseConfig
+	Escalation   EscalationConfig
+	Digest       DigestConfig
+	Assignment   AssignmentConfig
+	Retry        RetryConfig
+	Stats        StatsConfig
+	RateLimit    RateLimitConfig
+	CORS         CORSConfig
+	Auth         AuthConfig
+	Outbox       OutboxConfig
+	Queue        QueueConfig
+	Webhook      WebhookConfig
+	Integrations IntegrationsConfig
+	Tracing      TracingConfig
 }
 
 // ServerConfig contains HTTP server settings.
 type ServerConfig struct {
 	Host string
 	Port string
+	// ShutdownTimeout bounds stopping each component: draining in-flight requests, each worker, each connection.
+	ShutdownTimeout time.Duration
+	// GinMode is the gin mode: debug, release or test.
+	GinMode string
+	// TrustedProxies are the proxy IPs or CIDRs allowed to set X-Forwarded-For.
+	TrustedProxies []string
+	// MaxBodyBytes caps the size of request bodies.
+	MaxBodyBytes int64
+	// GzipMinSize is the smallest response compressed with gzip; zero disables compression.
+	GzipMinSize int
+	// LegacyRoutes keeps the deprecated unversioned aliases of the /api/v1 routes.
+	LegacyRoutes bool
+	// LegacyTeamExistsStatus answers POST /team/add for an existing team with 400 instead of 409,
+	// for clients not yet updated to the conflict status. It will be removed in the next release.
+	LegacyTeamExistsStatus bool
+	// DocsUI serves Swagger UI at /docs.
+	DocsUI bool
+	// RequestTimeout bounds how long a request may take; zero disables the limit.
+	RequestTimeout time.Duration
+	// TLSCertFile and TLSKeyFile are PEM files of the certificate chain and private key;
+	// when set the API is served over HTTPS with HTTP/2.
+	TLSCertFile string
+	TLSKeyFile  string
+	// TLSRedirectPort, when set, is a plain HTTP port redirecting every request to HTTPS.
+	TLSRedirectPort string
+	// LatencyBudget is how long a request may take before it is logged and counted as slow;
+	// zero disables slow request tracking.
+	LatencyBudget time.Duration
+	// RouteLatencyBudgets override LatencyBudget for routes named without the /api/v1 prefix.
+	RouteLatencyBudgets map[string]time.Duration
+}
+
+// TLSEnabled reports whether the API is served over HTTPS.
+func (c *ServerConfig) TLSEnabled() bool {
+	return c.TLSCertFile != ""
 }
 
 // DatabaseConfig contains PostgreSQL connection settings.
@@ -28,76 +84,726 @@ type DatabaseConfig struct {
 	Password string
 	DBName   string
 	SSLMode  string
+	// URL is the DATABASE_URL the settings above were parsed from; empty when they come from DB_* variables.
+	URL string
+
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	// StatementTimeout makes PostgreSQL cancel any statement running longer.
+	StatementTimeout time.Duration
+	// ReplicaDSN points to a read replica serving read-only queries; empty sends them to the primary.
+	ReplicaDSN string
+	// BreakerThreshold is the number of consecutive requests failing to reach the database
+	// after which the circuit breaker opens.
+	BreakerThreshold int
+	// BreakerOpenTimeout is how long the open breaker fails requests fast before probing the
+	// database again; zero disables the breaker.
+	BreakerOpenTimeout time.Duration
+	// StatsInterval is how often the pool statistics are sampled into /metrics; zero disables it.
+	StatsInterval time.Duration
+	// SchemaCheck is how the schema is verified at startup: off, warn (log missing items)
+	// or strict (log them and refuse to start).
+	SchemaCheck string
+}
+
+// EscalationConfig contains settings of the overdue review escalation worker.
+// The worker is disabled when SLA is zero.
+type EscalationConfig struct {
+	Interval  time.Duration
+	SLA       time.Duration
+	BatchSize int
+}
+
+// DigestConfig contains settings of the scheduler sending team review digests.
+// The scheduler is disabled when CheckInterval is zero.
+type DigestConfig struct {
+	// CheckInterval is how often due digests are looked for; it bounds how late a digest goes out.
+	CheckInterval time.Duration
+}
+
+// AssignmentConfig contains reviewer selection settings.
+type AssignmentConfig struct {
+	// CapacityFallback assigns the least-loaded teammates when everyone is at capacity.
+	CapacityFallback bool
+	// Strategy is the reviewer selection strategy name ("random" or "weighted").
+	Strategy string
+	// ReassignLimit is how many reviewer replacements a PR may go through before manual
+	// reassigns require an admin's force flag.
+	ReassignLimit int
+	// AuthorCreateLimit is how many pull requests an author may create within a rolling hour;
+	// zero disables the limit. Admins are not limited.
+	AuthorCreateLimit int
+	// LogDecisions logs the candidates, exclusions and outcome of every reviewer selection.
+	LogDecisions bool
+}
+
+// RetryConfig controls retries of transactions failing with serialization failures or deadlocks.
+type RetryConfig struct {
+	// Attempts is the total number of tries per transaction.
+	Attempts int
+	// BaseDelay is the pause before the first retry; it doubles with every further retry.
+	BaseDelay time.Duration
+}
+
+// RateLimitConfig contains per-client request rate limits.
+// Rate limiting is disabled when Rate is zero.
+type RateLimitConfig struct {
+	// Rate is the sustained number of requests per second allowed per client.
+	Rate float64
+	// Burst is the number of requests a client may make at once.
+	Burst int
+}
+
+// CORSConfig contains cross-origin settings for browser clients.
+// CORS is disabled when AllowedOrigins is empty.
+type CORSConfig struct {
+	AllowedOrigins []string
+	AllowedMethods []string
+	AllowedHeaders []string
+	// MaxAge is how long browsers may cache a preflight response.
+	MaxAge time.Duration
+}
+
+// AuthConfig contains API key settings.
+// Admin endpoints reject every request when AdminAPIKeys is empty.
+type AuthConfig struct {
+	AdminAPIKeys []string
+}
+
+// StatsConfig contains statistics endpoint settings.
+type StatsConfig struct {
+	// CacheTTL is how long GET /stats results are reused; zero disables the cache.
+	CacheTTL time.Duration
+	// QueryTimeout bounds the GET /stats query.
+	QueryTimeout time.Duration
+	// Anonymize replaces user IDs and names with pseudonyms unless a request sets anonymize=false.
+	Anonymize bool
+	// AnonymizeKey keys the pseudonym hash; when empty a random key is used per process.
+	AnonymizeKey string
+	// CoverageInterval is how often reviewer coverage is sampled into /metrics; zero disables it.
+	CoverageInterval time.Duration
+}
+
+// OutboxConfig contains settings of the event outbox dispatcher.
+type OutboxConfig struct {
+	// PollInterval is how often pending events are looked up.
+	PollInterval time.Duration
+	// BatchSize caps how many events are published per poll.
+	BatchSize int
+	// MaxAttempts is how many times an event is published before it is given up on.
+	MaxAttempts int
+}
+
+// QueueConfig contains settings of publishing events to NATS JetStream.
+// Publishing is disabled while Servers is empty.
+type QueueConfig struct {
+	// Servers are the NATS server URLs, tried in order.
+	Servers []string
+	// Subject is the subject events are published to; a JetStream stream must be bound to it.
+	Subject string
+	// Timeout bounds connecting and publishing a single event.
+	Timeout time.Duration
+	// CredentialsFile is a .creds file with the JWT and NKEY seed to authenticate with; empty means none.
+	CredentialsFile string
+	// NKeySeedFile is a file with the NKEY seed to authenticate with; empty means none.
+	NKeySeedFile string
+}
+
+// WebhookConfig contains webhook delivery settings.
+type WebhookConfig struct {
+	// Timeout bounds a single delivery attempt.
+	Timeout time.Duration
+	// MaxAttempts is the total number of tries per delivery.
+	MaxAttempts int
+	// RetryBaseDelay is the pause before the first retry; it doubles with every further retry.
+	RetryBaseDelay time.Duration
+}
+
+// IntegrationsConfig contains settings of the VCS integrations.
+// An integration rejects every request while its token is empty.
+type IntegrationsConfig struct {
+	// GitLabWebhookToken is the secret token configured for the webhook in GitLab.
+	GitLabWebhookToken string
+	// GitHubToken is the token the team sync reads the organization's teams with.
+	GitHubToken string
+	// GitHubOrg is the GitHub organization whose teams are synced.
+	GitHubOrg string
+	// GitHubAPIURL is the root of the GitHub REST API.
+	GitHubAPIURL string
+	// GitHubSyncInterval is how often the teams are synced in the background; zero disables it.
+	GitHubSyncInterval time.Duration
+}
+
+// TracingConfig contains OpenTelemetry settings. Export itself (endpoint, headers, timeout),
+// sampling and the service name are configured by the standard OTEL_* variables read by the SDK.
+type TracingConfig struct {
+	// Enabled is set when OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT
+	// is set and OTEL_SDK_DISABLED is not true.
+	Enabled bool
 }
 
 // Load reads configuration from environment variables.
-// Returns error if required variables are not set.
+// Non-secret settings fall back to defaults; DB_USER, DB_PASSWORD and DB_NAME are required
+// unless DATABASE_URL provides the connection settings.
+// Returns a single error listing every missing or invalid variable.
 func Load() (*Config, error) {
 	_ = godotenv.Load()
 
-	serverHost, err := getRequiredEnv("SERVER_HOST")
-	if err != nil {
-		return nil, err
+	// Every problem is collected so that a misconfigured deployment reports them all at once.
+	var errs []error
+	collect := func(err error) {
+		if err != nil {
+			errs = append(errs, err)
+		}
 	}
 
-	serverPort, err := getRequiredEnv("SERVER_PORT")
-	if err != nil {
-		return nil, err
+	serverHost := getEnv("SERVER_HOST", "0.0.0.0")
+	serverPort := getEnv("SERVER_PORT", "8080")
+	collect(validatePort("SERVER_PORT", serverPort))
+
+	tlsCertFile := getEnv("TLS_CERT_FILE", "")
+	tlsKeyFile := getEnv("TLS_KEY_FILE", "")
+	if (tlsCertFile == "") != (tlsKeyFile == "") {
+		collect(fmt.Errorf("environment variables TLS_CERT_FILE and TLS_KEY_FILE must be set together"))
+	}
+	tlsRedirectPort := getEnv("TLS_REDIRECT_PORT", "")
+	if tlsRedirectPort != "" {
+		collect(validatePort("TLS_REDIRECT_PORT", tlsRedirectPort))
+		if tlsCertFile == "" {
+			collect(fmt.Errorf("environment variable TLS_REDIRECT_PORT requires TLS_CERT_FILE and TLS_KEY_FILE"))
+		}
+		if tlsRedirectPort == serverPort {
+			collect(fmt.Errorf("environment variable TLS_REDIRECT_PORT must differ from SERVER_PORT"))
+		}
 	}
 
-	dbHost, err := getRequiredEnv("DB_HOST")
-	if err != nil {
-		return nil, err
+	shutdownTimeout, err := getDurationEnv("SHUTDOWN_TIMEOUT", 5*time.Second)
+	collect(err)
+	if err == nil && shutdownTimeout == 0 {
+		collect(fmt.Errorf("environment variable SHUTDOWN_TIMEOUT must be positive"))
 	}
 
-	dbPort, err := getRequiredEnv("DB_PORT")
-	if err != nil {
-		return nil, err
+	ginMode := getEnv("GIN_MODE", "release")
+	if !slices.Contains(ginModes, ginMode) {
+		collect(fmt.Errorf("environment variable GIN_MODE must be one of %s, got %q", strings.Join(ginModes, ", "), ginMode))
 	}
 
-	dbUser, err := getRequiredEnv("DB_USER")
-	if err != nil {
-		return nil, err
+	trustedProxies, err := getProxiesEnv("TRUSTED_PROXIES")
+	collect(err)
+
+	maxBodyBytes, err := getIntEnv("MAX_BODY_BYTES", 1<<20)
+	collect(err)
+
+	requestTimeout, err := getDurationEnv("REQUEST_TIMEOUT", 5*time.Second)
+	collect(err)
+
+	latencyBudget, err := getDurationEnv("LATENCY_BUDGET", 300*time.Millisecond)
+	collect(err)
+
+	routeLatencyBudgets, err := getDurationMapEnv("LATENCY_BUDGET_ROUTES")
+	collect(err)
+
+	legacyRoutes, err := getBoolEnv("LEGACY_ROUTES", true)
+	collect(err)
+
+	legacyTeamExistsStatus, err := getBoolEnv("LEGACY_TEAM_EXISTS_STATUS", false)
+	collect(err)
+
+	docsUI, err := getBoolEnv("DOCS_UI", false)
+	collect(err)
+
+	gzipEnabled, err := getBoolEnv("GZIP_ENABLED", true)
+	collect(err)
+
+	gzipMinSize, err := getIntEnv("GZIP_MIN_SIZE", 1024)
+	collect(err)
+	if !gzipEnabled {
+		gzipMinSize = 0
 	}
 
-	dbPassword, err := getRequiredEnv("DB_PASSWORD")
-	if err != nil {
-		return nil, err
+	// DATABASE_URL, when set, takes precedence over the discrete DB_* connection variables.
+	var database DatabaseConfig
+	if databaseURL := os.Getenv("DATABASE_URL"); databaseURL != "" {
+		database, err = parseDatabaseURL(databaseURL)
+		collect(err)
+	} else {
+		database.Host = getEnv("DB_HOST", "localhost")
+		database.Port = getEnv("DB_PORT", "5432")
+		collect(validatePort("DB_PORT", database.Port))
+
+		database.User, err = getRequiredEnv("DB_USER")
+		collect(err)
+
+		database.Password, err = getRequiredEnv("DB_PASSWORD")
+		collect(err)
+
+		database.DBName, err = getRequiredEnv("DB_NAME")
+		collect(err)
+
+		database.SSLMode = getEnv("DB_SSLMODE", "disable")
+		collect(validateSSLMode("DB_SSLMODE", database.SSLMode))
 	}
 
-	dbName, err := getRequiredEnv("DB_NAME")
-	if err != nil {
-		return nil, err
+	dbMaxOpenConns, err := getIntEnv("DB_MAX_OPEN_CONNS", 25)
+	collect(err)
+
+	dbMaxIdleConns, err := getIntEnv("DB_MAX_IDLE_CONNS", min(25, dbMaxOpenConns))
+	collect(err)
+	if dbMaxOpenConns > 0 && dbMaxIdleConns > dbMaxOpenConns {
+		collect(fmt.Errorf("environment variable DB_MAX_IDLE_CONNS must not exceed DB_MAX_OPEN_CONNS"))
 	}
 
-	dbSSLMode, err := getRequiredEnv("DB_SSLMODE")
-	if err != nil {
-		return nil, err
+	dbConnMaxLifetime, err := getDurationEnv("DB_CONN_MAX_LIFETIME", 5*time.Minute)
+	collect(err)
+
+	dbStatementTimeoutMs, err := getIntEnv("DB_STATEMENT_TIMEOUT_MS", 30000)
+	collect(err)
+
+	dbStatsInterval, err := getDurationEnv("DB_STATS_INTERVAL", 5*time.Second)
+	collect(err)
+
+	dbBreakerThreshold, err := getIntEnv("DB_BREAKER_THRESHOLD", 5)
+	collect(err)
+
+	dbBreakerOpenTimeout, err := getDurationEnv("DB_BREAKER_OPEN_TIMEOUT", 10*time.Second)
+	collect(err)
+
+	dbSchemaCheck := getEnv("DB_SCHEMA_CHECK", SchemaCheckOff)
+	if !slices.Contains(schemaCheckModes, dbSchemaCheck) {
+		collect(fmt.Errorf("environment variable DB_SCHEMA_CHECK must be one of %s, got %q",
+			strings.Join(schemaCheckModes, ", "), dbSchemaCheck))
+	}
+
+	retryAttempts, err := getIntEnv("DB_RETRY_ATTEMPTS", 3)
+	collect(err)
+
+	retryBaseDelay, err := getDurationEnv("DB_RETRY_BASE_DELAY", 20*time.Millisecond)
+	collect(err)
+
+	escalationInterval, err := getDurationEnv("ESCALATION_INTERVAL", time.Minute)
+	collect(err)
+	if err == nil && escalationInterval == 0 {
+		collect(fmt.Errorf("environment variable ESCALATION_INTERVAL must be positive"))
+	}
+
+	escalationSLA, err := getDurationEnv("ESCALATION_SLA", 0)
+	collect(err)
+
+	escalationBatchSize, err := getIntEnv("ESCALATION_BATCH_SIZE", 50)
+	collect(err)
+
+	digestCheckInterval, err := getDurationEnv("DIGEST_CHECK_INTERVAL", time.Minute)
+	collect(err)
+
+	capacityFallback, err := getBoolEnv("ASSIGNMENT_CAPACITY_FALLBACK", true)
+	collect(err)
+
+	strategy := getEnv("ASSIGNMENT_STRATEGY", "random")
+
+	reassignLimit, err := getIntEnv("REASSIGN_LIMIT", 10)
+	collect(err)
+
+	authorCreateLimit, err := getIntEnv("PR_CREATE_LIMIT_PER_HOUR", 0)
+	collect(err)
+
+	logDecisions, err := getBoolEnv("LOG_ASSIGNMENT_DECISIONS", false)
+	collect(err)
+
+	statsCacheTTL, err := getDurationEnv("STATS_CACHE_TTL", 30*time.Second)
+	collect(err)
+
+	statsQueryTimeout, err := getDurationEnv("STATS_QUERY_TIMEOUT", 5*time.Second)
+	collect(err)
+	if err == nil && statsQueryTimeout == 0 {
+		collect(fmt.Errorf("environment variable STATS_QUERY_TIMEOUT must be positive"))
+	}
+
+	statsAnonymize, err := getBoolEnv("STATS_ANONYMIZE", false)
+	collect(err)
+
+	statsCoverageInterval, err := getDurationEnv("STATS_COVERAGE_INTERVAL", time.Minute)
+	collect(err)
+
+	rateLimit, err := getFloatEnv("RATE_LIMIT_RPS", 0)
+	collect(err)
+	if err == nil && rateLimit < 0 {
+		collect(fmt.Errorf("environment variable RATE_LIMIT_RPS must not be negative"))
+	}
+
+	rateLimitBurst, err := getIntEnv("RATE_LIMIT_BURST", max(1, int(math.Ceil(rateLimit))))
+	collect(err)
+	if err == nil && rateLimitBurst < 1 {
+		collect(fmt.Errorf("environment variable RATE_LIMIT_BURST must be positive"))
+	}
+
+	corsOrigins := getListEnv("CORS_ALLOWED_ORIGINS", nil)
+	collect(validateOrigins("CORS_ALLOWED_ORIGINS", corsOrigins))
+	corsMethods := getListEnv("CORS_ALLOWED_METHODS", []string{"GET", "POST", "DELETE", "OPTIONS"})
+	corsHeaders := getListEnv("CORS_ALLOWED_HEADERS", []string{"Content-Type", "X-Request-ID", "X-API-Key"})
+
+	corsMaxAge, err := getDurationEnv("CORS_MAX_AGE", 10*time.Minute)
+	collect(err)
+
+	outboxPollInterval, err := getDurationEnv("OUTBOX_POLL_INTERVAL", time.Second)
+	collect(err)
+	if err == nil && outboxPollInterval == 0 {
+		collect(fmt.Errorf("environment variable OUTBOX_POLL_INTERVAL must be positive"))
+	}
+
+	outboxBatchSize, err := getIntEnv("OUTBOX_BATCH_SIZE", 100)
+	collect(err)
+	if err == nil && outboxBatchSize < 1 {
+		collect(fmt.Errorf("environment variable OUTBOX_BATCH_SIZE must be positive"))
+	}
+
+	outboxMaxAttempts, err := getIntEnv("OUTBOX_MAX_ATTEMPTS", 10)
+	collect(err)
+	if err == nil && outboxMaxAttempts < 1 {
+		collect(fmt.Errorf("environment variable OUTBOX_MAX_ATTEMPTS must be positive"))
+	}
+
+	natsServers := getListEnv("NATS_SERVERS", nil)
+	collect(validateNATSServers("NATS_SERVERS", natsServers))
+	natsSubject := getEnv("NATS_SUBJECT", "pr_reviewer.events")
+	if strings.ContainsAny(natsSubject, " \t*>") || strings.HasPrefix(natsSubject, ".") || strings.HasSuffix(natsSubject, ".") {
+		collect(fmt.Errorf("environment variable NATS_SUBJECT must be a subject without wildcards, got %q", natsSubject))
+	}
+
+	natsTimeout, err := getDurationEnv("NATS_TIMEOUT", 5*time.Second)
+	collect(err)
+	if err == nil && natsTimeout == 0 {
+		collect(fmt.Errorf("environment variable NATS_TIMEOUT must be positive"))
+	}
+
+	natsCredentialsFile := getEnv("NATS_CREDENTIALS_FILE", "")
+	natsNKeySeedFile := getEnv("NATS_NKEY_SEED_FILE", "")
+	if natsCredentialsFile != "" && natsNKeySeedFile != "" {
+		collect(fmt.Errorf("environment variables NATS_CREDENTIALS_FILE and NATS_NKEY_SEED_FILE cannot be set together"))
+	}
+
+	webhookTimeout, err := getDurationEnv("WEBHOOK_TIMEOUT", 5*time.Second)
+	collect(err)
+	if err == nil && webhookTimeout == 0 {
+		collect(fmt.Errorf("environment variable WEBHOOK_TIMEOUT must be positive"))
+	}
+
+	webhookMaxAttempts, err := getIntEnv("WEBHOOK_MAX_ATTEMPTS", 5)
+	collect(err)
+	if err == nil && webhookMaxAttempts < 1 {
+		collect(fmt.Errorf("environment variable WEBHOOK_MAX_ATTEMPTS must be positive"))
+	}
+
+	webhookRetryBaseDelay, err := getDurationEnv("WEBHOOK_RETRY_BASE_DELAY", time.Second)
+	collect(err)
+
+	gitHubToken := os.Getenv("GITHUB_TOKEN")
+	gitHubOrg := os.Getenv("GITHUB_ORG")
+	if (gitHubToken == "") != (gitHubOrg == "") {
+		collect(fmt.Errorf("environment variables GITHUB_TOKEN and GITHUB_ORG must be set together"))
+	}
+
+	gitHubSyncInterval, err := getDurationEnv("GITHUB_SYNC_INTERVAL", 0)
+	collect(err)
+	if err == nil && gitHubSyncInterval > 0 && gitHubToken == "" {
+		collect(fmt.Errorf("environment variable GITHUB_SYNC_INTERVAL requires GITHUB_TOKEN and GITHUB_ORG"))
 	}
 
+	otelDisabled, err := getBoolEnv("OTEL_SDK_DISABLED", false)
+	collect(err)
+	tracingEnabled := !otelDisabled &&
+		(os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != "")
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid configuration: %w", errors.Join(errs...))
+	}
+
+	database.MaxOpenConns = dbMaxOpenConns
+	database.MaxIdleConns = dbMaxIdleConns
+	database.ConnMaxLifetime = dbConnMaxLifetime
+	database.StatementTimeout = time.Duration(dbStatementTimeoutMs) * time.Millisecond
+	database.StatsInterval = dbStatsInterval
+	database.ReplicaDSN = getEnv("DB_REPLICA_DSN", "")
+	database.BreakerThreshold = dbBreakerThreshold
+	database.BreakerOpenTimeout = dbBreakerOpenTimeout
+	database.SchemaCheck = dbSchemaCheck
+
 	cfg := &Config{
 		Server: ServerConfig{
-			Host: serverHost,
-			Port: serverPort,
+			Host:            serverHost,
+			Port:            serverPort,
+			ShutdownTimeout: shutdownTimeout,
+			GinMode:         ginMode,
+			TrustedProxies:  trustedProxies,
+			MaxBodyBytes:    int64(maxBodyBytes),
+			RequestTimeout:  requestTimeout,
+			LegacyRoutes:    legacyRoutes,
+			DocsUI:          docsUI,
+			GzipMinSize:     gzipMinSize,
+			TLSCertFile:     tlsCertFile,
+			TLSKeyFile:      tlsKeyFile,
+			TLSRedirectPort: tlsRedirectPort,
+
+			LatencyBudget:          latencyBudget,
+			RouteLatencyBudgets:    routeLatencyBudgets,
+			LegacyTeamExistsStatus: legacyTeamExistsStatus,
+		},
+		Database: database,
+
+		Escalation: EscalationConfig{
+			Interval:  escalationInterval,
+			SLA:       escalationSLA,
+			BatchSize: escalationBatchSize,
+		},
+		Digest: DigestConfig{
+			CheckInterval: digestCheckInterval,
+		},
+		Assignment: AssignmentConfig{
+			CapacityFallback:  capacityFallback,
+			Strategy:          strategy,
+			ReassignLimit:     reassignLimit,
+			AuthorCreateLimit: authorCreateLimit,
+			LogDecisions:      logDecisions,
+		},
+		Retry: RetryConfig{
+			Attempts:  retryAttempts,
+			BaseDelay: retryBaseDelay,
 		},
-		Database: DatabaseConfig{
-			Host:     dbHost,
-			Port:     dbPort,
-			User:     dbUser,
-			Password: dbPassword,
-			DBName:   dbName,
-			SSLMode:  dbSSLMode,
+		Stats: StatsConfig{
+			CacheTTL:         statsCacheTTL,
+			QueryTimeout:     statsQueryTimeout,
+			Anonymize:        statsAnonymize,
+			AnonymizeKey:     os.Getenv("STATS_ANONYMIZE_KEY"),
+			CoverageInterval: statsCoverageInterval,
+		},
+		RateLimit: RateLimitConfig{
+			Rate:  rateLimit,
+			Burst: rateLimitBurst,
+		},
+		CORS: CORSConfig{
+			AllowedOrigins: corsOrigins,
+			AllowedMethods: corsMethods,
+			AllowedHeaders: corsHeaders,
+			MaxAge:         corsMaxAge,
+		},
+		Auth: AuthConfig{
+			AdminAPIKeys: getListEnv("ADMIN_API_KEYS", nil),
+		},
+		Outbox: OutboxConfig{
+			PollInterval: outboxPollInterval,
+			BatchSize:    outboxBatchSize,
+			MaxAttempts:  outboxMaxAttempts,
+		},
+		Queue: QueueConfig{
+			Servers: natsServers,
+			Subject:         natsSubject,
+			Timeout:         natsTimeout,
+			CredentialsFile: natsCredentialsFile,
+			NKeySeedFile:    natsNKeySeedFile,
+		},
+		Webhook: WebhookConfig{
+			Timeout:        webhookTimeout,
+			MaxAttempts:    webhookMaxAttempts,
+			RetryBaseDelay: webhookRetryBaseDelay,
+		},
+		Integrations: IntegrationsConfig{
+			GitLabWebhookToken: os.Getenv("GITLAB_WEBHOOK_TOKEN"),
+			GitHubToken:        gitHubToken,
+			GitHubOrg:          gitHubOrg,
+			GitHubAPIURL:       getEnv("GITHUB_API_URL", "https://api.github.com"),
+			GitHubSyncInterval: gitHubSyncInterval,
+		},
+		Tracing: TracingConfig{
+			Enabled: tracingEnabled,
 		},
 	}
 
 	return cfg, nil
 }
 
-// DSN returns PostgreSQL connection string.
+// sslModes are the sslmode values accepted by lib/pq.
+var sslModes = []string{"disable", "require", "verify-ca", "verify-full"}
+
+// ginModes are the accepted GIN_MODE values.
+var ginModes = []string{"debug", "release", "test"}
+
+// DB_SCHEMA_CHECK modes.
+const (
+	SchemaCheckOff    = "off"
+	SchemaCheckWarn   = "warn"
+	SchemaCheckStrict = "strict"
+)
+
+// schemaCheckModes are the accepted DB_SCHEMA_CHECK values.
+var schemaCheckModes = []string{SchemaCheckOff, SchemaCheckWarn, SchemaCheckStrict}
+
+// getProxiesEnv reads an optional comma-separated list of IPs or CIDRs.
+// Returns nil if the variable is not set.
+func getProxiesEnv(key string) ([]string, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil, nil
+	}
+	var proxies []string
+	for _, proxy := range strings.Split(value, ",") {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		if net.ParseIP(proxy) == nil {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				return nil, fmt.Errorf("environment variable %s must list IPs or CIDRs, got %q", key, proxy)
+			}
+		}
+		proxies = append(proxies, proxy)
+	}
+	return proxies, nil
+}
+
+// getDurationMapEnv reads an optional comma-separated list of name=duration pairs,
+// e.g. "/stats/export=2s,/team/import=1s". Returns nil if the variable is not set.
+func getDurationMapEnv(key string) (map[string]time.Duration, error) {
+	items := getListEnv(key, nil)
+	if len(items) == 0 {
+		return nil, nil
+	}
+	durations := make(map[string]time.Duration, len(items))
+	for _, item := range items {
+		name, value, ok := strings.Cut(item, "=")
+		name = strings.TrimSpace(name)
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if !ok || name == "" || err != nil || d < 0 {
+			return nil, fmt.Errorf("environment variable %s must list name=duration pairs, got %q", key, item)
+		}
+		durations[name] = d
+	}
+	return durations, nil
+}
+
+// getListEnv reads an optional comma-separated list, dropping blank items.
+// Returns defaultValue if the variable is not set.
+func getListEnv(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// validateOrigins checks that every origin is "*" or an http(s) scheme://host[:port] without a path.
+func validateOrigins(key string, origins []string) error {
+	for _, origin := range origins {
+		if origin == "*" {
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+			u.Path != "" || u.RawQuery != "" || u.User != nil {
+			return fmt.Errorf("environment variable %s must list origins like https://example.com, got %q", key, origin)
+		}
+	}
+	return nil
+}
+
+// validateNATSServers checks that every server is a nats:// or tls:// URL with a host.
+func validateNATSServers(key string, servers []string) error {
+	for _, server := range servers {
+		u, err := url.Parse(server)
+		if err != nil || (u.Scheme != "nats" && u.Scheme != "tls") || u.Host == "" {
+			// The URL may carry credentials, so it is not echoed.
+			return fmt.Errorf("environment variable %s must list URLs like nats://localhost:4222", key)
+		}
+	}
+	return nil
+}
+
+// validatePort checks that value is a TCP port number.
+func validatePort(key, value string) error {
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		return fmt.Errorf("environment variable %s must be a port between 1 and 65535, got %q", key, value)
+	}
+	return nil
+}
+
+// validateSSLMode checks that value is an sslmode lib/pq supports.
+func validateSSLMode(key, value string) error {
+	if !slices.Contains(sslModes, value) {
+		return fmt.Errorf("environment variable %s must be one of %s, got %q", key, strings.Join(sslModes, ", "), value)
+	}
+	return nil
+}
+
+// parseDatabaseURL reads connection settings from a postgres:// URL.
+// Other query parameters, such as connect_timeout, stay in the URL and are passed to the driver.
+// Errors never include the URL itself, since it carries the password.
+func parseDatabaseURL(raw string) (DatabaseConfig, error) {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "postgres" && u.Scheme != "postgresql") {
+		return DatabaseConfig{}, fmt.Errorf("environment variable DATABASE_URL must be a postgres:// URL")
+	}
+
+	password, _ := u.User.Password()
+	c := DatabaseConfig{
+		Host:     u.Hostname(),
+		Port:     u.Port(),
+		User:     u.User.Username(),
+		Password: password,
+		DBName:   strings.TrimPrefix(u.Path, "/"),
+		SSLMode:  u.Query().Get("sslmode"),
+		URL:      raw,
+	}
+	if c.Port == "" {
+		c.Port = "5432"
+	}
+	if c.SSLMode == "" {
+		// lib/pq's default when sslmode is omitted.
+		c.SSLMode = "require"
+	}
+
+	var errs []error
+	if c.Host == "" || c.User == "" || c.DBName == "" {
+		errs = append(errs, fmt.Errorf("environment variable DATABASE_URL must include user, host and database name"))
+	}
+	if err := validatePort("DATABASE_URL port", c.Port); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateSSLMode("DATABASE_URL sslmode", c.SSLMode); err != nil {
+		errs = append(errs, err)
+	}
+	return c, errors.Join(errs...)
+}
+
+// DSN returns PostgreSQL connection string: DATABASE_URL as given, or key/value pairs built from DB_* variables.
 func (c *DatabaseConfig) DSN() string {
+	if c.URL != "" {
+		return c.URL
+	}
+	return c.keyValueDSN(c.Password)
+}
+
+// RedactedDSN returns DSN with the password masked, for logging.
+func (c *DatabaseConfig) RedactedDSN() string {
+	if c.URL != "" {
+		u, err := url.Parse(c.URL)
+		if err != nil {
+			return "postgres://xxxxx"
+		}
+		return u.Redacted()
+	}
+	return c.keyValueDSN("xxxxx")
+}
+
+func (c *DatabaseConfig) keyValueDSN(password string) string {
 	return fmt.Sprintf(
 		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
-		c.Host, c.Port, c.User, c.Password, c.DBName, c.SSLMode,
+		c.Host, c.Port, c.User, password, c.DBName, c.SSLMode,
 	)
 }
 
@@ -109,3 +815,68 @@ func getRequiredEnv(key string) (string, error) {
 	}
 	return value, nil
 }
+
+// getEnv reads optional environment variable.
+// Returns defaultValue if the variable is not set.
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// getDurationEnv reads optional duration environment variable (e.g. "30s", "24h").
+// Returns defaultValue if the variable is not set.
+func getDurationEnv(key string, defaultValue time.Duration) (time.Duration, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("environment variable %s must be a non-negative duration, got %q", key, value)
+	}
+	return d, nil
+}
+
+// getIntEnv reads optional positive integer environment variable.
+// Returns defaultValue if the variable is not set.
+func getIntEnv(key string, defaultValue int) (int, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("environment variable %s must be a positive integer, got %q", key, value)
+	}
+	return n, nil
+}
+
+// getFloatEnv reads optional positive number environment variable.
+// Returns defaultValue if the variable is not set.
+func getFloatEnv(key string, defaultValue float64) (float64, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || !(f > 0) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("environment variable %s must be a positive number, got %q", key, value)
+	}
+	return f, nil
+}
+
+// getBoolEnv reads optional boolean environment variable.
+// Returns defaultValue if the variable is not set.
+func getBoolEnv(key string, defaultValue bool) (bool, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("environment variable %s must be a boolean, got %q", key, value)
+	}
+	return b, nil
+}
@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+// DateLayout is the format of calendar dates in requests and responses.
+const DateLayout = "2006-01-02"
+
+// Absence is a period (inclusive on both ends) when a user must not be assigned reviews.
+type Absence struct {
+	UserID   string    `json:"user_id" db:"user_id"`
+	FromDate time.Time `json:"from_date" db:"from_date"`
+	ToDate   time.Time `json:"to_date" db:"to_date"`
+}
+
+// Covers reports whether the absence includes the calendar date of t.
+func (a Absence) Covers(t time.Time) bool {
+	day := t.Format(DateLayout)
+	return a.FromDate.Format(DateLayout) <= day && day <= a.ToDate.Format(DateLayout)
+}
@@ -0,0 +1,122 @@
+package domain
+
+import "time"
+
+// AssignmentAction describes why a reviewer assignment changed.
+type AssignmentAction string
+
+// Assignment action constants.
+const (
+	ActionReassign  AssignmentAction = "REASSIGN"
+	ActionEscalate  AssignmentAction = "ESCALATE"
+	ActionAbsence   AssignmentAction = "ABSENCE"
+	ActionReopen    AssignmentAction = "REOPEN"
+	ActionClose     AssignmentAction = "CLOSE"
+	ActionRebalance AssignmentAction = "REBALANCE"
+)
+
+// ReviewerSource describes why a reviewer was assigned to a pull request.
+type ReviewerSource string
+
+// Reviewer source constants.
+const (
+	// SourceAuto is a reviewer picked from the author's team, on creation or as a replacement.
+	SourceAuto ReviewerSource = "auto"
+	// SourceRequired is a reviewer the PR's creator asked for or a code owner of its paths.
+	SourceRequired ReviewerSource = "required"
+	// SourceFallback is a member of the fallback team filling a slot the author's team could not.
+	SourceFallback ReviewerSource = "fallback"
+	// SourceRebalance is a reviewer moved onto the PR by team rebalancing.
+	SourceRebalance ReviewerSource = "rebalance"
+	// SourceEscalation is a reviewer replacing one who missed the review SLA.
+	SourceEscalation ReviewerSource = "escalation"
+)
+
+// SourceFor returns the source of a reviewer brought in by a replacement with the given action.
+func SourceFor(action AssignmentAction) ReviewerSource {
+	switch action {
+	case ActionEscalate:
+		return SourceEscalation
+	case ActionRebalance:
+		return SourceRebalance
+	default:
+		return SourceAuto
+	}
+}
+
+// AssignmentEvent is a single entry of a pull request's assignment history.
+type AssignmentEvent struct {
+	RepositoryName string           `json:"repository_name" db:"repository_name"`
+	PullRequestID  string           `json:"pull_request_id" db:"pull_request_id"`
+	Action         AssignmentAction `json:"action" db:"action"`
+	OldUserID      string           `json:"old_user_id,omitempty" db:"old_user_id"`
+	NewUserID      string           `json:"new_user_id,omitempty" db:"new_user_id"`
+	// Decision is how the new reviewer was picked; nil for events not picking one.
+	Decision  *AssignmentDecision `json:"decision,omitempty" db:"decision"`
+	CreatedAt *time.Time          `json:"created_at,omitempty" db:"created_at"`
+}
+
+// AssignmentDecision is a snapshot of one reviewer selection: the strategy and preferred tags,
+// the candidates it chose from, the users left out before choosing and the reviewers chosen.
+// CapacityFallback is set when everyone was at capacity and the least-loaded users were chosen instead.
+type AssignmentDecision struct {
+	Strategy         string              `json:"strategy"`
+	Tags             []string            `json:"tags,omitempty"`
+	Candidates       []DecisionCandidate `json:"candidates"`
+	Excluded         []DecisionExclusion `json:"excluded,omitempty"`
+	CapacityFallback bool                `json:"capacity_fallback,omitempty"`
+	Selected         []string            `json:"selected"`
+}
+
+// DecisionCandidate is a user eligible for a selection, with the load and weight it was judged by.
+type DecisionCandidate struct {
+	UserID string  `json:"id"`
+	Load   int     `json:"load"`
+	Weight float64 `json:"weight"`
+}
+
+// ExclusionReason tells why a user was left out of a selection.
+type ExclusionReason string
+
+// Exclusion reason constants.
+const (
+	ExcludedAuthor     ExclusionReason = "author"
+	ExcludedAssigned   ExclusionReason = "assigned"
+	ExcludedAtCapacity ExclusionReason = "at_capacity"
+)
+
+// DecisionExclusion is a user left out of a selection.
+type DecisionExclusion struct {
+	UserID string          `json:"id"`
+	Reason ExclusionReason `json:"reason"`
+}
+
+// ReviewerAssignment is one reviewer's assignment to a pull request.
+// HoursOpen and Overdue are derived data, set by Measure.
+type ReviewerAssignment struct {
+	UserID     string
+	Source     ReviewerSource
+	AssignedAt time.Time
+	Approved   bool
+	// ReviewSLAHours is the review SLA of the pull request's team; 0 means none.
+	ReviewSLAHours int
+	// HoursOpen is the number of full hours the review has waited; nil unless the pull request is open.
+	HoursOpen *int
+	// Overdue tells whether the review is still pending past the SLA.
+	Overdue bool
+}
+
+// Measure sets HoursOpen and Overdue as of now for a pull request in the given status.
+// A review is overdue while the pull request is open, the reviewer has not approved it
+// and more than ReviewSLAHours hours have passed since the assignment.
+func (a *ReviewerAssignment) Measure(now time.Time, status PRStatus) {
+	a.HoursOpen, a.Overdue = nil, false
+	if status != StatusOpen {
+		return
+	}
+
+	waited := max(now.Sub(a.AssignedAt), 0)
+	hours := int(waited / time.Hour)
+	a.HoursOpen = &hours
+	a.Overdue = !a.Approved && a.ReviewSLAHours > 0 && waited > time.Duration(a.ReviewSLAHours)*time.Hour
+}
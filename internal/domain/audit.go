@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// AuditAction names an administrative action recorded in the audit log.
+type AuditAction string
+
+// Audit action constants.
+const (
+	AuditTeamDeactivate    AuditAction = "team.deactivate"
+	AuditTeamRename        AuditAction = "team.rename"
+	AuditUserErase         AuditAction = "user.erase"
+	AuditPRForceMerge      AuditAction = "pr.force_merge"
+	AuditWebhookCreate     AuditAction = "webhook.create"
+	AuditWebhookDelete     AuditAction = "webhook.delete"
+	AuditOrgCreate         AuditAction = "org.create"
+	AuditTeamsSync         AuditAction = "teams.sync"
+	AuditUserReassignAll   AuditAction = "user.reassign_all"
+	AuditReviewersBackfill AuditAction = "reviewers.backfill"
+)
+
+// AuditEntry records who performed an administrative action on which object.
+// Actor identifies the caller without revealing its credentials; RequestID is empty
+// for actions performed outside of an HTTP request. OrgID is the organization the action was performed in.
+type AuditEntry struct {
+	ID        int64       `json:"id" db:"audit_id"`
+	OrgID     string      `json:"org_id" db:"org_id"`
+	Actor     string      `json:"actor" db:"actor"`
+	Action    AuditAction `json:"action" db:"action"`
+	Target    string      `json:"target" db:"target"`
+	RequestID string      `json:"request_id" db:"request_id"`
+	CreatedAt time.Time   `json:"created_at" db:"created_at"`
+}
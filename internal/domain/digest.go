@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// DigestSchedule sends a team a daily digest of its members' pending reviews at Hour:00 in Timezone.
+type DigestSchedule struct {
+	TeamName string `json:"team_name" db:"team_name"`
+	Hour     int    `json:"hour" db:"hour"`
+	// Timezone is an IANA time zone name, e.g. "Europe/Moscow".
+	Timezone string `json:"timezone" db:"timezone"`
+	// LastSentAt is when the digest was last sent, or when the schedule was set if it has not been sent since.
+	LastSentAt time.Time `json:"last_sent_at" db:"last_sent_at"`
+}
+
+// Due reports whether the digest should be sent at now, that is whether the latest Hour:00 in Timezone
+// at or before now comes after LastSentAt. A digest missed while nobody checked is sent once, late.
+func (s DigestSchedule) Due(now time.Time) (bool, error) {
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return false, fmt.Errorf("digest of team %s: %w", s.TeamName, err)
+	}
+
+	local := now.In(loc)
+	slot := time.Date(local.Year(), local.Month(), local.Day(), s.Hour, 0, 0, 0, loc)
+	if slot.After(local) {
+		slot = time.Date(local.Year(), local.Month(), local.Day()-1, s.Hour, 0, 0, 0, loc)
+	}
+	return s.LastSentAt.Before(slot), nil
+}
+
+// ReviewDigest is the payload of a review.digest event: the pending reviews of a team's active members.
+type ReviewDigest struct {
+	TeamName  string           `json:"team_name"`
+	Reviewers []DigestReviewer `json:"reviewers"`
+}
+
+// DigestReviewer lists one member's pending reviews, oldest first.
+type DigestReviewer struct {
+	UserID   string         `json:"user_id"`
+	Username string         `json:"username"`
+	Reviews  []DigestReview `json:"reviews"`
+}
+
+// DigestReview is a pending review measured against the review SLA of the pull request's team.
+type DigestReview struct {
+	RepositoryName  string    `json:"repository_name"`
+	PullRequestID   string    `json:"pull_request_id"`
+	PullRequestName string    `json:"pull_request_name"`
+	AuthorID        string    `json:"author_id"`
+	ExternalURL     *string   `json:"external_url,omitempty"`
+	AssignedAt      time.Time `json:"assigned_at"`
+	HoursOpen       int       `json:"hours_open"`
+	Overdue         bool      `json:"overdue"`
+}
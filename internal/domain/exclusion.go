@@ -0,0 +1,7 @@
+package domain
+
+// Exclusion forbids a reviewer from being assigned to an author's pull requests.
+type Exclusion struct {
+	ReviewerID string `json:"reviewer_id" db:"reviewer_id"`
+	AuthorID   string `json:"author_id" db:"author_id"`
+}
@@ -0,0 +1,8 @@
+package domain
+
+// ExternalLogin maps a user's login at a VCS provider to the user.
+type ExternalLogin struct {
+	Provider string `json:"provider" db:"provider"`
+	Login    string `json:"external_login" db:"external_login"`
+	UserID   string `json:"user_id" db:"user_id"`
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// DefaultOrgID is the organization of requests that do not name one and of data created before
+// organizations were introduced.
+const DefaultOrgID = "default"
+
+// Organization is a tenant; teams, users, pull requests and webhooks belong to exactly one,
+// and their ids only need to be unique within it.
+type Organization struct {
+	OrgID     string    `json:"org_id" db:"org_id"`
+	Name      string    `json:"name" db:"name"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,40 @@
+package domain
+
+import "strings"
+
+// OwnershipRule makes the owner of a path prefix a required reviewer of the team's pull requests
+// changing paths under it. Exactly one of OwnerUserID and OwnerTeamName is set; an owning team
+// is represented by one of its active members.
+type OwnershipRule struct {
+	TeamName      string `json:"team_name" db:"team_name"`
+	PathPrefix    string `json:"path_prefix" db:"path_prefix"`
+	OwnerUserID   string `json:"owner_user_id,omitempty" db:"owner_user_id"`
+	OwnerTeamName string `json:"owner_team_name,omitempty" db:"owner_team_name"`
+}
+
+// NormalizePath trims surrounding slashes, so "/internal/service/" and "internal/service" name the same path.
+// The empty path is the repository root.
+func NormalizePath(path string) string {
+	return strings.Trim(path, "/")
+}
+
+// Covers reports whether path lies under the rule's prefix, that is equals it or continues it after a slash.
+// The empty prefix covers every path.
+func (r OwnershipRule) Covers(path string) bool {
+	path = NormalizePath(path)
+	if r.PathPrefix == "" || path == r.PathPrefix {
+		return true
+	}
+	return strings.HasPrefix(path, r.PathPrefix+"/")
+}
+
+// MatchOwnershipRule returns the rule with the longest prefix covering path, or nil if none covers it.
+func MatchOwnershipRule(rules []OwnershipRule, path string) *OwnershipRule {
+	var best *OwnershipRule
+	for i := range rules {
+		if rules[i].Covers(path) && (best == nil || len(rules[i].PathPrefix) > len(best.PathPrefix)) {
+			best = &rules[i]
+		}
+	}
+	return best
+}
@@ -0,0 +1,55 @@
+package domain
+
+// PRSize is a rough size hint of a pull request; the empty size means none was given.
+type PRSize string
+
+// PR size constants, from smallest to largest.
+const (
+	SizeXS PRSize = "XS"
+	SizeS  PRSize = "S"
+	SizeM  PRSize = "M"
+	SizeL  PRSize = "L"
+	SizeXL PRSize = "XL"
+)
+
+// PRSizes lists the valid sizes from smallest to largest.
+var PRSizes = []PRSize{SizeXS, SizeS, SizeM, SizeL, SizeXL}
+
+// sizeWeights is how many open reviews of an unsized PR one open review of each size counts as.
+var sizeWeights = map[PRSize]int{
+	SizeXS: 1,
+	SizeS:  2,
+	SizeM:  3,
+	SizeL:  5,
+	SizeXL: 8,
+}
+
+// sizeUpperBounds are the exclusive upper bounds of lines changed for each size but the largest.
+var sizeUpperBounds = []struct {
+	size  PRSize
+	lines int
+}{
+	{SizeXS, 10},
+	{SizeS, 50},
+	{SizeM, 250},
+	{SizeL, 1000},
+}
+
+// SizeForLines returns the size of a pull request changing the given number of lines.
+func SizeForLines(lines int) PRSize {
+	for _, b := range sizeUpperBounds {
+		if lines < b.lines {
+			return b.size
+		}
+	}
+	return SizeXL
+}
+
+// Weight returns the load an open review of a PR of this size puts on its reviewer.
+// A PR without a size weighs as much as an XS one, so it counts as a single review.
+func (s PRSize) Weight() int {
+	if w, ok := sizeWeights[s]; ok {
+		return w
+	}
+	return 1
+}
@@ -13,6 +13,7 @@ type PRStatus string
 const (
 	StatusOpen   PRStatus = "OPEN"
 	StatusMerged PRStatus = "MERGED"
+	StatusClosed PRStatus = "CLOSED"
 )
 
 // NewPRStatus creates a new PRStatus with validation.
@@ -20,14 +21,14 @@ const (
 func NewPRStatus(s string) (PRStatus, error) {
 	status := PRStatus(s)
 	if !status.IsValid() {
-		return "", fmt.Errorf("invalid PR status: %s (must be one of: %s, %s)", s, StatusOpen, StatusMerged)
+		return "", fmt.Errorf("invalid PR status: %s (must be one of: %s, %s, %s)", s, StatusOpen, StatusMerged, StatusClosed)
 	}
 	return status, nil
 }
 
 // IsValid checks if the status is valid.
 func (s PRStatus) IsValid() bool {
-	return s == StatusOpen || s == StatusMerged
+	return s == StatusOpen || s == StatusMerged || s == StatusClosed
 }
 
 // Scan implements sql.Scanner interface for automatic validation when reading from database.
@@ -62,23 +63,80 @@ func (s PRStatus) Value() (driver.Value, error) {
 	return string(s), nil
 }
 
+// PRKey identifies a pull request. Pull request ids are unique only within a repository;
+// the empty repository name is the default repository.
+type PRKey struct {
+	RepositoryName string
+	PullRequestID  string
+}
+
+// String formats the key as repository/id, or just the id in the default repository.
+func (k PRKey) String() string {
+	if k.RepositoryName == "" {
+		return k.PullRequestID
+	}
+	return k.RepositoryName + "/" + k.PullRequestID
+}
+
 // PullRequest represents a pull request with assigned reviewers.
 type PullRequest struct {
-	PullRequestID        string     `json:"pull_request_id" db:"pull_request_id"`
-	PullRequestName      string     `json:"pull_request_name" db:"pull_request_name"`
-	AuthorID             string     `json:"author_id" db:"author_id"`
-	TeamName             string     `json:"team_name" db:"team_name"`
-	Status               PRStatus   `json:"status" db:"status"`
-	AssignedReviewersIDs []string   `json:"assigned_reviewers"`
-	CreatedAt            *time.Time `json:"createdAt,omitempty" db:"created_at"`
-	MergedAt             *time.Time `json:"mergedAt,omitempty" db:"merged_at"`
+	RepositoryName  string   `json:"repository_name" db:"repository_name"`
+	PullRequestID   string   `json:"pull_request_id" db:"pull_request_id"`
+	PullRequestName string   `json:"pull_request_name" db:"pull_request_name"`
+	AuthorID        string   `json:"author_id" db:"author_id"`
+	TeamName        string   `json:"team_name" db:"team_name"`
+	Status          PRStatus `json:"status" db:"status"`
+	// AssignedReviewersIDs is ordered by assignment time, then by user ID.
+	AssignedReviewersIDs []string `json:"assigned_reviewers"`
+	// ApprovedReviewersIDs is the subset of AssignedReviewersIDs that approved the PR.
+	ApprovedReviewersIDs []string `json:"approved_reviewers,omitempty"`
+	// RequiredReviewersIDs is the subset of AssignedReviewersIDs named as required on creation.
+	// Filled only by pr.GetOpenByTeam.
+	RequiredReviewersIDs []string `json:"-"`
+	// Assignments are the assignments of AssignedReviewersIDs, in the same order. Filled only by pr.Get and pr.GetByAuthor.
+	Assignments []ReviewerAssignment `json:"-"`
+	CreatedAt   *time.Time           `json:"createdAt,omitempty" db:"created_at"`
+	MergedAt    *time.Time           `json:"mergedAt,omitempty" db:"merged_at"`
+	// MergedBy is empty unless the merge named the user who made it.
+	MergedBy string     `json:"merged_by,omitempty" db:"merged_by"`
+	ClosedAt *time.Time `json:"closedAt,omitempty" db:"closed_at"`
+	// Description and ExternalURL are nil when the client did not provide them.
+	Description *string `json:"description,omitempty" db:"description"`
+	ExternalURL *string `json:"external_url,omitempty" db:"external_url"`
+	// Size and LinesChanged are the size hint given on creation; Size is derived from LinesChanged
+	// when only the latter was given, and both are unset when neither was.
+	Size         PRSize `json:"size,omitempty" db:"size"`
+	LinesChanged *int   `json:"lines_changed,omitempty" db:"lines_changed"`
+	// Tags are the areas of expertise the PR touches, sorted; reviewers sharing one are preferred.
+	Tags []string `json:"tags,omitempty"`
+	// ReassignmentCount is the number of times a reviewer of the PR was replaced.
+	ReassignmentCount int `json:"reassignment_count" db:"reassignment_count"`
+}
+
+// Key returns the key identifying the pull request.
+func (p *PullRequest) Key() PRKey {
+	return PRKey{RepositoryName: p.RepositoryName, PullRequestID: p.PullRequestID}
+}
+
+// PRDetails is the optional pull request metadata supplied on creation.
+type PRDetails struct {
+	Description  *string
+	ExternalURL  *string
+	Size         PRSize
+	LinesChanged *int
+	Tags         []string
+	// ChangedPaths are matched against the ownership rules of the author's team; they are not stored.
+	ChangedPaths []string
 }
 
 // PullRequestShort is a lightweight version of PullRequest for lists.
 type PullRequestShort struct {
+	RepositoryName  string   `json:"repository_name"`
 	PullRequestID   string   `json:"pull_request_id"`
 	PullRequestName string   `json:"pull_request_name"`
 	AuthorID        string   `json:"author_id"`
 	TeamName        string   `json:"team_name"`
 	Status          PRStatus `json:"status"`
+	// Assignment is the assignment of the reviewer the list was requested for. Filled only by pr.GetByUser and pr.GetPendingByTeam.
+	Assignment *ReviewerAssignment `json:"-"`
 }
@@ -2,13 +2,61 @@ package domain
 
 // Team represents a group of users.
 type Team struct {
-	TeamName string       `json:"team_name" db:"team_name"`
-	Members  []TeamMember `json:"members"`
+	TeamName string `json:"team_name" db:"team_name"`
+	// AssignmentStrategy is the name of the reviewer selection strategy used for the team's PRs.
+	AssignmentStrategy string `json:"assignment_strategy" db:"assignment_strategy"`
+	// RequireApprovals is how many reviewer approvals a PR of the team needs before it can be merged; 0 disables the check.
+	RequireApprovals int `json:"require_approvals" db:"require_approvals"`
+	// ReviewSLAHours is how many hours a review of the team's PRs may wait before it is overdue; 0 means no SLA.
+	ReviewSLAHours int `json:"review_sla_hours" db:"review_sla_hours"`
+	// SlackWebhookURL is the Slack incoming webhook the team's notifications are posted to; empty disables them.
+	// Like a webhook secret, it is never returned by the API.
+	SlackWebhookURL string `json:"-" db:"slack_webhook_url"`
+	// ReviewerCount is how many reviewers a new PR of the team gets.
+	ReviewerCount int `json:"reviewer_count" db:"reviewer_count"`
+	// FallbackTeamName is the team whose members fill the reviewer slots the team's own members cannot; empty means none.
+	FallbackTeamName string       `json:"fallback_team_name,omitempty" db:"fallback_team_name"`
+	Members          []TeamMember `json:"members"`
+}
+
+// Settings returns the team's settings.
+func (t *Team) Settings() TeamSettings {
+	return TeamSettings{
+		AssignmentStrategy: t.AssignmentStrategy,
+		ReviewerCount:      t.ReviewerCount,
+		RequireApprovals:   t.RequireApprovals,
+		ReviewSLAHours:     t.ReviewSLAHours,
+		SlackWebhookURL:    t.SlackWebhookURL,
+		FallbackTeamName:   t.FallbackTeamName,
+	}
+}
+
+// DefaultReviewerCount is how many reviewers a new PR gets when its team has not set a reviewer count.
+const DefaultReviewerCount = 2
+
+// MaxReviewerCount is the largest reviewer count a team may set.
+const MaxReviewerCount = 5
+
+// TeamSettings are the effective team-level settings: the team's own values, with defaults for those it has not set.
+// See Team for the meaning of each field.
+type TeamSettings struct {
+	AssignmentStrategy string
+	ReviewerCount      int
+	RequireApprovals   int
+	ReviewSLAHours     int
+	SlackWebhookURL    string
+	FallbackTeamName   string
 }
 
 // TeamMember represents a user within a team.
 type TeamMember struct {
-	UserID   string `json:"user_id" db:"user_id"`
-	Username string `json:"username" db:"username"`
+	UserID   string `json:"user_id" db:"user_id" binding:"required,entity_id"`
+	Username string `json:"username" db:"username" binding:"required,max=300"`
 	IsActive bool   `json:"is_active" db:"is_active"`
+	// MaxOpenReviews and AssignmentWeight of an existing user are kept when omitted; for a new
+	// user they default to no limit and DefaultAssignmentWeight.
+	MaxOpenReviews   *int    `json:"max_open_reviews,omitempty" db:"max_open_reviews" binding:"omitempty,min=0"`
+	AssignmentWeight float64 `json:"assignment_weight,omitempty" db:"assignment_weight" binding:"omitempty,gt=0"`
+	// Tags are the member's areas of expertise. Nil leaves the tags of an existing user unchanged.
+	Tags []string `json:"tags,omitempty" binding:"omitempty,max=20,unique,dive,tag"`
 }
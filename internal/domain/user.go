@@ -1,10 +1,40 @@
 // Package domain contains business entities.
 package domain
 
+import "time"
+
 // User represents a team member.
+// TeamName is the user's primary team; further memberships are stored separately.
 type User struct {
-	UserID   string `json:"user_id" db:"user_id"`
-	Username string `json:"username" db:"username"`
-	TeamName string `json:"team_name" db:"team_name"`
-	IsActive bool   `json:"is_active" db:"is_active"`
+	UserID         string `json:"user_id" db:"user_id"`
+	Username       string `json:"username" db:"username"`
+	TeamName       string `json:"team_name" db:"team_name"`
+	IsActive       bool   `json:"is_active" db:"is_active"`
+	MaxOpenReviews *int   `json:"max_open_reviews,omitempty" db:"max_open_reviews"`
+	// AssignmentWeight is the relative chance of being picked by the weighted strategy.
+	AssignmentWeight float64 `json:"assignment_weight" db:"assignment_weight"`
+	// Tags are the user's areas of expertise, sorted. Filled only by team and candidate queries.
+	Tags []string `json:"tags,omitempty"`
+	// OpenReviews is the number of OPEN PRs the user currently reviews.
+	// Filled only by candidate queries.
+	OpenReviews int `json:"-" db:"open_reviews"`
+	// OpenReviewLoad is OpenReviews weighted by the size of each PR (see PRSize.Weight).
+	// Filled only by candidate queries.
+	OpenReviewLoad int `json:"-" db:"open_review_load"`
+	// LastAssignedAt is when the user was last assigned a review (nil if never).
+	// Filled only by candidate queries.
+	LastAssignedAt *time.Time `json:"-" db:"last_assigned_at"`
+	// Erased reports that the user's personal data was erased. Filled only by Get.
+	Erased bool `json:"-" db:"erased"`
+}
+
+// ErasedUsername replaces the username of a user whose personal data was erased.
+const ErasedUsername = "deleted user"
+
+// DefaultAssignmentWeight is used when no weight is specified.
+const DefaultAssignmentWeight = 1.0
+
+// AtCapacity reports whether the user cannot take another review.
+func (u User) AtCapacity() bool {
+	return u.MaxOpenReviews != nil && u.OpenReviews >= *u.MaxOpenReviews
 }
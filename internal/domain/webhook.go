@@ -0,0 +1,44 @@
+package domain
+
+import "time"
+
+// Webhook is a subscription to assignment events.
+// Deliveries to URL are signed with Secret, which is never returned by the API.
+type Webhook struct {
+	ID        int64     `json:"id" db:"webhook_id"`
+	URL       string    `json:"url" db:"url"`
+	Secret    string    `json:"-" db:"secret"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// EventType names an event delivered to webhooks.
+type EventType string
+
+// Event type constants.
+const (
+	EventPRCreated          EventType = "pr.created"
+	EventReviewerAssigned   EventType = "reviewer.assigned"
+	EventReviewerReassigned EventType = "reviewer.reassigned"
+	EventPRMerged           EventType = "pr.merged"
+	EventReviewDigest       EventType = "review.digest"
+)
+
+// Event is the JSON payload of a webhook delivery.
+// ReviewerID is set for reviewer events; ReplacedReviewerID and Reason only for reviewer.reassigned.
+// OrgID is the organization of the pull request. ReviewerIDs is set only for pr.merged and lists
+// the reviewers assigned at the moment of the merge, whose reviews are no longer needed.
+// review.digest concerns a team rather than a pull request: only Digest is set, and the pull request fields are empty.
+type Event struct {
+	ID                 string           `json:"id"`
+	Type               EventType        `json:"event"`
+	OccurredAt         time.Time        `json:"occurred_at"`
+	OrgID              string           `json:"org_id"`
+	RepositoryName     string           `json:"repository_name"`
+	PullRequestID      string           `json:"pull_request_id"`
+	PullRequest        *PullRequest     `json:"pull_request,omitempty"`
+	ReviewerID         string           `json:"reviewer_id,omitempty"`
+	ReplacedReviewerID string           `json:"replaced_reviewer_id,omitempty"`
+	Reason             AssignmentAction `json:"reason,omitempty"`
+	ReviewerIDs        []string         `json:"reviewer_ids,omitempty"`
+	Digest             *ReviewDigest    `json:"digest,omitempty"`
+}
@@ -0,0 +1,32 @@
+package domain
+
+// TeamWorkload is the open review workload of a team's active members, together with
+// how well the team's own open pull requests are staffed.
+type TeamWorkload struct {
+	TeamName string
+	Members  []MemberWorkload
+	// OpenPRs counts the team's open pull requests; UnassignedPRs those without reviewers and
+	// UnderAssignedPRs those with fewer than ReviewerCount, the team's reviewer count.
+	OpenPRs          int
+	UnassignedPRs    int
+	UnderAssignedPRs int
+	ReviewerCount    int
+}
+
+// OpenAssignments returns the number of open assignments of all members.
+func (w *TeamWorkload) OpenAssignments() int {
+	total := 0
+	for _, m := range w.Members {
+		total += len(m.PullRequests)
+	}
+	return total
+}
+
+// MemberWorkload is the open review workload of a team member.
+// PullRequests are the open pull requests the member reviews, of any team, oldest assignment first;
+// each carries the member's assignment.
+type MemberWorkload struct {
+	UserID       string
+	Username     string
+	PullRequests []PullRequestShort
+}
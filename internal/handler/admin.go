@@ -0,0 +1,5 @@
+package handler
+
+// AdminContextKey is the context key set to true for requests authenticated with an admin API key.
+// Authentication middleware sets it; handlers read it for admin-only options.
+const AdminContextKey = "admin"
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/pagination"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/audit"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+)
+
+// defaultAuditPageSize is the page size of GET /admin/audit when limit is omitted.
+const defaultAuditPageSize = 50
+
+// AuditHandler handles audit log HTTP requests.
+type AuditHandler struct {
+	auditService AuditServiceInterface
+}
+
+// NewAuditHandler creates a new audit handler.
+func NewAuditHandler(auditService AuditServiceInterface) *AuditHandler {
+	return &AuditHandler{auditService: auditService}
+}
+
+// ListAudit handles GET /admin/audit.
+// Optional from and to (RFC3339) bound the entry time, actor selects one caller;
+// limit and cursor page through the entries, newest first. before_id is still accepted
+// instead of cursor for clients that have not moved to cursors.
+func (h *AuditHandler) ListAudit(c *gin.Context) {
+	period, ok := parsePeriod(c)
+	if !ok {
+		return
+	}
+
+	limit := defaultAuditPageSize
+	if raw := c.Query("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > service.MaxAuditPageSize {
+			BadRequest(c, "limit must be an integer between 1 and "+strconv.Itoa(service.MaxAuditPageSize))
+			return
+		}
+		limit = n
+	}
+
+	var beforeID int64
+	if raw := c.Query("before_id"); raw != "" {
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || id < 1 {
+			BadRequest(c, "before_id must be a positive integer")
+			return
+		}
+		beforeID = id
+	}
+	after, ok := parseCursorQuery(c)
+	if !ok {
+		return
+	}
+	if after != nil {
+		if _, err := strconv.ParseInt(after.ID, 10, 64); err != nil {
+			BadRequest(c, "cursor must be a next_cursor of a previous page")
+			return
+		}
+		if beforeID != 0 {
+			BadRequest(c, "cursor and before_id cannot be combined")
+			return
+		}
+	}
+
+	page, err := h.auditService.ListAudit(c.Request.Context(), audit.Filter{
+		From:     period.From,
+		To:       period.To,
+		Actor:    c.Query("actor"),
+		After:    after,
+		BeforeID: beforeID,
+		Limit:    limit,
+	})
+	if err != nil {
+		InternalError(c, err.Error())
+		return
+	}
+
+	response := AuditLogResponse{Page: pagination.Map(page, domainToAuditEntryResponse)}
+	response.Entries = response.Items
+	if page.HasMore && after == nil {
+		response.NextBeforeID = page.Items[len(page.Items)-1].ID
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// domainToAuditEntryResponse converts domain.AuditEntry to AuditEntryResponse.
+func domainToAuditEntryResponse(e domain.AuditEntry) AuditEntryResponse {
+	return AuditEntryResponse{
+		ID:        e.ID,
+		OrgID:     e.OrgID,
+		Actor:     e.Actor,
+		Action:    string(e.Action),
+		Target:    e.Target,
+		RequestID: e.RequestID,
+		CreatedAt: e.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
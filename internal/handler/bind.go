@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/pagination"
+)
+
+// bindJSON decodes the request body into obj and validates its binding tags.
+// Unknown fields are rejected so that misspelt keys fail instead of being silently dropped.
+// On failure it writes the error response and returns false: 413 PAYLOAD_TOO_LARGE when the
+// body exceeds the limit set by middleware.BodyLimit, 400 VALIDATION_ERROR with details when
+// a binding rule fails, plain 400 otherwise.
+func bindJSON(c *gin.Context, obj any) bool {
+	if c.Request.Body == nil {
+		BadRequest(c, "invalid request body")
+		return false
+	}
+
+	decoder := json.NewDecoder(c.Request.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(obj); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			Error(c, ErrorPayloadTooLarge, fmt.Sprintf("request body exceeds %d bytes", tooLarge.Limit), http.StatusRequestEntityTooLarge)
+			return false
+		}
+		// encoding/json reports unknown fields only as a formatted message.
+		if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+			BadRequest(c, "unknown field "+field)
+			return false
+		}
+		BadRequest(c, "invalid request body")
+		return false
+	}
+
+	if err := binding.Validator.ValidateStruct(obj); err != nil {
+		if details, ok := validationDetails(err); ok {
+			ValidationError(c, details)
+			return false
+		}
+		BadRequest(c, "invalid request body")
+		return false
+	}
+	return true
+}
+
+// parseCursorQuery parses the optional cursor query parameter; nil means it was omitted.
+func parseCursorQuery(c *gin.Context) (*pagination.Cursor, bool) {
+	raw := c.Query("cursor")
+	if raw == "" {
+		return nil, true
+	}
+	cursor, err := pagination.Decode(raw)
+	if err != nil {
+		BadRequest(c, "cursor must be a next_cursor of a previous page")
+		return nil, false
+	}
+	return cursor, true
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// circuitOpen is the circuit breaker state in which the database is considered unavailable.
+const circuitOpen = "open"
+
+// HealthResponse is returned by GET /health.
+// CircuitBreaker is the state of the database circuit breaker, omitted when there is none.
+type HealthResponse struct {
+	Status         string `json:"status"`
+	CircuitBreaker string `json:"circuit_breaker,omitempty"`
+}
+
+// HealthHandler reports whether the service can serve requests.
+type HealthHandler struct {
+	circuitState func() string
+}
+
+// NewHealthHandler creates a health handler. circuitState returns the state of the database
+// circuit breaker ("closed", "open" or "half_open"); nil means there is no breaker.
+func NewHealthHandler(circuitState func() string) *HealthHandler {
+	return &HealthHandler{circuitState: circuitState}
+}
+
+// Health handles GET /health: 200 with status "ok", or 503 with status "degraded"
+// while the database circuit breaker is open.
+func (h *HealthHandler) Health(c *gin.Context) {
+	if h.circuitState == nil {
+		c.JSON(http.StatusOK, HealthResponse{Status: "ok"})
+		return
+	}
+	state := h.circuitState()
+	if state == circuitOpen {
+		c.JSON(http.StatusServiceUnavailable, HealthResponse{Status: "degraded", CircuitBreaker: state})
+		return
+	}
+	c.JSON(http.StatusOK, HealthResponse{Status: "ok", CircuitBreaker: state})
+}
@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/integration"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+)
+
+// IntegrationHandler handles webhooks of VCS providers, the login mapping they use
+// and the GitHub team sync.
+type IntegrationHandler struct {
+	integrationService IntegrationServiceInterface
+	gitHubSync         GitHubSyncServiceInterface
+	gitLabToken        string
+}
+
+// NewIntegrationHandler creates a new integration handler.
+// The GitLab webhook rejects every request until WithGitLabToken sets its token.
+func NewIntegrationHandler(integrationService IntegrationServiceInterface) *IntegrationHandler {
+	return &IntegrationHandler{integrationService: integrationService}
+}
+
+// WithGitLabToken sets the secret token GitLab sends in the X-Gitlab-Token header.
+func (h *IntegrationHandler) WithGitLabToken(token string) *IntegrationHandler {
+	h.gitLabToken = token
+	return h
+}
+
+// WithGitHubSync sets the service the GitHub team sync endpoint runs;
+// without it the endpoint responds 503 SERVICE_UNAVAILABLE.
+func (h *IntegrationHandler) WithGitHubSync(gitHubSync GitHubSyncServiceInterface) *IntegrationHandler {
+	h.gitHubSync = gitHubSync
+	return h
+}
+
+// MapLogin handles POST /integrations/logins.
+// The route is restricted to admins.
+func (h *IntegrationHandler) MapLogin(c *gin.Context) {
+	var req MapLoginRequest
+
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	login := &domain.ExternalLogin{Provider: req.Provider, Login: req.ExternalLogin, UserID: req.UserID}
+	if err := h.integrationService.MapLogin(c.Request.Context(), login); err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			NotFound(c, "user not found")
+			return
+		}
+		InternalError(c, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"external_login": login})
+}
+
+// GitLabWebhook handles POST /integrations/gitlab/webhook.
+// Merge Request Hook events opening, merging or closing a merge request are applied to the
+// pull request; other events are acknowledged and ignored.
+func (h *IntegrationHandler) GitLabWebhook(c *gin.Context) {
+	token := c.GetHeader(integration.GitLabTokenHeader)
+	if h.gitLabToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(h.gitLabToken)) != 1 {
+		Error(c, ErrorUnauthorized, "invalid "+integration.GitLabTokenHeader, http.StatusUnauthorized)
+		return
+	}
+
+	if c.GetHeader(integration.GitLabEventHeader) != integration.GitLabMergeRequestEvent {
+		c.JSON(http.StatusOK, gin.H{"message": "event ignored"})
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			Error(c, ErrorPayloadTooLarge, fmt.Sprintf("request body exceeds %d bytes", tooLarge.Limit), http.StatusRequestEntityTooLarge)
+			return
+		}
+		BadRequest(c, "invalid request body")
+		return
+	}
+
+	cmd, err := integration.ParseGitLabMergeRequest(body)
+	if err != nil {
+		if errors.Is(err, integration.ErrIgnoredEvent) {
+			c.JSON(http.StatusOK, gin.H{"message": "event ignored"})
+			return
+		}
+		BadRequest(c, err.Error())
+		return
+	}
+
+	h.apply(c, cmd)
+}
+
+// apply executes a command of any provider and writes the resulting pull request.
+func (h *IntegrationHandler) apply(c *gin.Context, cmd integration.Command) {
+	pr, err := h.integrationService.Apply(c.Request.Context(), cmd)
+	if err != nil {
+		if errors.Is(err, service.ErrUnknownExternalLogin) {
+			NotFound(c, err.Error())
+			return
+		}
+		if errors.Is(err, service.ErrPRAuthorNotFound) {
+			NotFound(c, "author or team not found")
+			return
+		}
+		if errors.Is(err, service.ErrReviewerNotFound) {
+			Error(c, ErrorReviewerNotFound, err.Error(), http.StatusNotFound)
+			return
+		}
+		var limited *service.AuthorRateLimitError
+		if errors.As(err, &limited) {
+			AuthorRateLimited(c, limited)
+			return
+		}
+		if errors.Is(err, service.ErrPRNotFound) {
+			NotFound(c, "pull request not found")
+			return
+		}
+		if errors.Is(err, service.ErrPRMerged) {
+			Conflict(c, ErrorPRMerged, "cannot close merged PR")
+			return
+		}
+		if errors.Is(err, service.ErrPRClosed) {
+			Conflict(c, ErrorPRClosed, "cannot merge closed PR")
+			return
+		}
+		InternalError(c, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, WebhookEventResponse{
+		PR: domainToPRResponse(pr),
+	})
+}
+
+// SyncGitHubTeams handles POST /integrations/github/syncTeams.
+// The route is restricted to admins.
+func (h *IntegrationHandler) SyncGitHubTeams(c *gin.Context) {
+	if h.gitHubSync == nil {
+		Error(c, ErrorServiceUnavailable, service.ErrGitHubSyncDisabled.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	summary, err := h.gitHubSync.SyncTeams(c.Request.Context())
+	if err != nil {
+		if errors.Is(err, service.ErrGitHubSyncDisabled) {
+			Error(c, ErrorServiceUnavailable, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		if errors.Is(err, service.ErrGitHubUnavailable) {
+			Error(c, ErrorUpstream, err.Error(), http.StatusBadGateway)
+			return
+		}
+		InternalError(c, err.Error())
+		return
+	}
+
+	skipped := make([]SkippedLoginResponse, 0, len(summary.Skipped))
+	for _, s := range summary.Skipped {
+		skipped = append(skipped, SkippedLoginResponse{TeamName: s.TeamName, Login: s.Login, Reason: s.Reason})
+	}
+	c.JSON(http.StatusOK, TeamSyncResponse{
+		TeamsCreated:     summary.TeamsCreated,
+		UsersCreated:     summary.UsersCreated,
+		UsersUpdated:     summary.UsersUpdated,
+		UsersDeactivated: summary.UsersDeactivated,
+		Skipped:          skipped,
+	})
+}
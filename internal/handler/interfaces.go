@@ -1,25 +1,105 @@
 package handler
 
 import (
+	"context"
+	"io"
+	"time"
+
 	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/integration"
+	"github.com/mishasvintus/avito_backend_internship/internal/pagination"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/audit"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
 )
 
 // TeamServiceInterface defines the interface for team operations.
 type TeamServiceInterface interface {
-	CreateTeam(teamName string, members []domain.TeamMember) error
-	GetTeam(teamName string) (*domain.Team, error)
-	DeactivateTeam(teamName string) error
+	CreateTeam(ctx context.Context, team *domain.Team, opts service.CreateTeamOptions) (*domain.Team, service.TeamOutcome, error)
+	GetTeam(ctx context.Context, teamName string) (*domain.Team, error)
+	UpdateTeam(ctx context.Context, teamName string, update service.TeamUpdate) (*domain.Team, error)
+	GetTeamSettings(ctx context.Context, teamName string) (*domain.TeamSettings, error)
+	GetTeamWorkload(ctx context.Context, teamName string) (*domain.TeamWorkload, error)
+	ImportTeams(ctx context.Context, r io.Reader) (*service.ImportSummary, error)
+	RenameTeam(ctx context.Context, oldName, newName string) (*domain.Team, error)
+	DeactivateTeam(ctx context.Context, teamName string) error
+	RebalanceTeam(ctx context.Context, teamName string, opts service.RebalanceOptions) ([]service.RebalanceMove, error)
+	SetOwnershipRule(ctx context.Context, rule domain.OwnershipRule) (*domain.OwnershipRule, error)
+	ListOwnershipRules(ctx context.Context, teamName string) ([]domain.OwnershipRule, error)
+	DeleteOwnershipRule(ctx context.Context, teamName, pathPrefix string) error
+	SetDigestSchedule(ctx context.Context, teamName string, hour int, timezone string) (*domain.DigestSchedule, error)
+	GetDigestSchedule(ctx context.Context, teamName string) (*domain.DigestSchedule, error)
+	DeleteDigestSchedule(ctx context.Context, teamName string) error
 }
 
 // UserServiceInterface defines the interface for user operations.
 type UserServiceInterface interface {
-	SetIsActive(userID string, isActive bool) (*domain.User, error)
-	GetUserReviews(userID string) ([]domain.PullRequestShort, error)
+	SetIsActive(ctx context.Context, userID string, isActive bool) (*domain.User, error)
+	SetIsActiveBatch(ctx context.Context, changes []service.ActivityChange) (*service.ActivityBatchResult, error)
+	SetCapacity(ctx context.Context, userID string, maxOpenReviews *int) (*domain.User, error)
+	SetTags(ctx context.Context, userID string, tags []string) (*domain.User, error)
+	EraseUser(ctx context.Context, userID string) (*domain.User, error)
+	SetAbsence(ctx context.Context, absence domain.Absence, reassignOpen bool) ([]service.ReassignResult, error)
+	ReassignAll(ctx context.Context, userID string, deactivate bool) ([]service.ReassignResult, error)
+	RemoveAbsence(ctx context.Context, userID string, fromDate *time.Time) error
+	AddExclusion(ctx context.Context, exclusion domain.Exclusion) error
+	RemoveExclusion(ctx context.Context, exclusion domain.Exclusion) error
+	GetUserReviews(ctx context.Context, userID string, repositoryName *string) ([]domain.PullRequestShort, error)
+	GetUserReviewCount(ctx context.Context, userID string, repositoryName *string) (pr.ReviewCount, error)
+	GetAuthoredPRs(ctx context.Context, userID string, filter pr.AuthoredFilter) (pagination.Page[domain.PullRequest], error)
 }
 
 // PRServiceInterface defines the interface for pull request operations.
 type PRServiceInterface interface {
-	CreatePR(prID, prName, authorID string) (*domain.PullRequest, error)
-	MergePR(prID string) (*domain.PullRequest, error)
-	ReassignPR(prID, oldReviewerID string) (*domain.PullRequest, string, error)
+	CreatePR(ctx context.Context, key domain.PRKey, prName, authorID string, requiredReviewers []string, details domain.PRDetails) (*domain.PullRequest, error)
+	GetPR(ctx context.Context, key domain.PRKey) (*domain.PullRequest, error)
+	MergePR(ctx context.Context, key domain.PRKey, opts service.MergeOptions) (*domain.PullRequest, error)
+	ApprovePR(ctx context.Context, key domain.PRKey, userID string) (*domain.PullRequest, error)
+	ClosePR(ctx context.Context, key domain.PRKey) (*domain.PullRequest, error)
+	ReopenPR(ctx context.Context, key domain.PRKey) (*domain.PullRequest, error)
+	ReassignPR(ctx context.Context, key domain.PRKey, oldReviewerID string, opts service.ReassignOptions) (*domain.PullRequest, string, error)
+	SuggestReviewers(ctx context.Context, authorID string, count int) (*service.ReviewerSuggestion, error)
+	BackfillReviewers(ctx context.Context, teamName string, opts service.BackfillOptions) (*service.BackfillResult, error)
+}
+
+// WebhookServiceInterface defines the interface for webhook subscription operations.
+type WebhookServiceInterface interface {
+	CreateWebhook(ctx context.Context, url, secret string) (*domain.Webhook, error)
+	DeleteWebhook(ctx context.Context, id int64) error
+}
+
+// IntegrationServiceInterface defines the interface for VCS integration operations.
+type IntegrationServiceInterface interface {
+	MapLogin(ctx context.Context, login *domain.ExternalLogin) error
+	Apply(ctx context.Context, cmd integration.Command) (*domain.PullRequest, error)
+}
+
+// GitHubSyncServiceInterface defines the interface for syncing teams from GitHub.
+type GitHubSyncServiceInterface interface {
+	SyncTeams(ctx context.Context) (*service.TeamSyncSummary, error)
 }
+
+// AuditServiceInterface defines the interface for reading the audit log.
+type AuditServiceInterface interface {
+	ListAudit(ctx context.Context, filter audit.Filter) (pagination.Page[domain.AuditEntry], error)
+}
+
+// OrgServiceInterface defines the interface for organization operations.
+type OrgServiceInterface interface {
+	CreateOrg(ctx context.Context, orgID, name string) (*domain.Organization, error)
+	ListOrgs(ctx context.Context) ([]domain.Organization, error)
+}
+
+// Compile-time check that the services implement the handler interfaces.
+var (
+	_ TeamServiceInterface  = (*service.TeamService)(nil)
+	_ UserServiceInterface  = (*service.UserService)(nil)
+	_ PRServiceInterface    = (*service.PRService)(nil)
+	_ StatsServiceInterface = (*service.StatsService)(nil)
+
+	_ WebhookServiceInterface     = (*service.WebhookService)(nil)
+	_ IntegrationServiceInterface = (*service.IntegrationService)(nil)
+	_ GitHubSyncServiceInterface  = (*service.GitHubSyncService)(nil)
+	_ AuditServiceInterface       = (*service.AuditService)(nil)
+	_ OrgServiceInterface         = (*service.OrgService)(nil)
+)
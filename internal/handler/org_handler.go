@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+)
+
+// OrgHandler handles organization HTTP requests.
+type OrgHandler struct {
+	orgService OrgServiceInterface
+}
+
+// NewOrgHandler creates a new organization handler.
+func NewOrgHandler(orgService OrgServiceInterface) *OrgHandler {
+	return &OrgHandler{orgService: orgService}
+}
+
+// CreateOrg handles POST /admin/orgs.
+func (h *OrgHandler) CreateOrg(c *gin.Context) {
+	var req CreateOrgRequest
+
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	org, err := h.orgService.CreateOrg(c.Request.Context(), req.OrgID, req.Name)
+	if err != nil {
+		if errors.Is(err, service.ErrOrgExists) {
+			Conflict(c, ErrorOrgExists, "org_id already exists")
+			return
+		}
+		InternalError(c, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"organization": domainToOrgResponse(*org)})
+}
+
+// ListOrgs handles GET /admin/orgs.
+func (h *OrgHandler) ListOrgs(c *gin.Context) {
+	orgs, err := h.orgService.ListOrgs(c.Request.Context())
+	if err != nil {
+		InternalError(c, err.Error())
+		return
+	}
+
+	response := make([]OrgResponse, 0, len(orgs))
+	for _, o := range orgs {
+		response = append(response, domainToOrgResponse(o))
+	}
+
+	c.JSON(http.StatusOK, gin.H{"organizations": response})
+}
+
+// domainToOrgResponse converts domain.Organization to OrgResponse.
+func domainToOrgResponse(o domain.Organization) OrgResponse {
+	return OrgResponse{
+		OrgID:     o.OrgID,
+		Name:      o.Name,
+		CreatedAt: o.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
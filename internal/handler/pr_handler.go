@@ -2,7 +2,9 @@ package handler
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -25,22 +27,56 @@ func NewPRHandler(prService PRServiceInterface) *PRHandler {
 func (h *PRHandler) CreatePR(c *gin.Context) {
 	var req CreatePRRequest
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		BadRequest(c, "invalid request body")
+	if !bindJSON(c, &req) {
 		return
 	}
 
-	pr, err := h.prService.CreatePR(req.PullRequestID, req.PullRequestName, req.AuthorID)
+	ctx := c.Request.Context()
+	if c.GetBool(AdminContextKey) {
+		ctx = service.SkipCreateLimit(ctx)
+	}
+	pr, err := h.prService.CreatePR(ctx, req.Key(), req.PullRequestName, req.AuthorID, req.RequiredReviewers, domain.PRDetails{
+		Description:  req.Description,
+		ExternalURL:  req.ExternalURL,
+		Size:         domain.PRSize(req.Size),
+		LinesChanged: req.LinesChanged,
+		Tags:         req.Tags,
+		ChangedPaths: req.ChangedPaths,
+	})
 	if err != nil {
 		if errors.Is(err, service.ErrPRExists) {
-			Conflict(c, ErrorPRExists, "PR id already exists")
+			body := ErrorBody{Code: ErrorPRExists, Message: "PR id already exists"}
+			if req.RepositoryName != "" {
+				body.Message = fmt.Sprintf("PR id already exists in repository %s", req.RepositoryName)
+			}
+			var exists *service.PRExistsError
+			if errors.As(err, &exists) {
+				body.ExistingPR = domainToPRResponse(exists.Existing)
+			}
+			c.JSON(http.StatusConflict, ErrorResponse{Error: body})
+			return
+		}
+		var limited *service.AuthorRateLimitError
+		if errors.As(err, &limited) {
+			AuthorRateLimited(c, limited)
 			return
 		}
 		if errors.Is(err, service.ErrPRAuthorNotFound) {
 			NotFound(c, "author or team not found")
 			return
 		}
-		if errors.Is(err, service.ErrInactiveReviewer) {
+		if errors.Is(err, service.ErrReviewerNotFound) {
+			Error(c, ErrorReviewerNotFound, err.Error(), http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, service.ErrRequiredReviewerNotFound) {
+			NotFound(c, err.Error())
+			return
+		}
+		if errors.Is(err, service.ErrInactiveReviewer) ||
+			errors.Is(err, service.ErrRequiredReviewerInactive) ||
+			errors.Is(err, service.ErrRequiredReviewerIsAuthor) ||
+			errors.Is(err, service.ErrTooManyRequiredReviewers) {
 			BadRequest(c, err.Error())
 			return
 		}
@@ -48,31 +84,164 @@ func (h *PRHandler) CreatePR(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, SuccessResponse{
+	c.JSON(http.StatusCreated, CreatePRResponse{
 		PR: domainToPRResponse(pr),
 	})
 }
 
+// GetPR handles GET /pullRequest/get.
+// repository_name defaults to the default (empty) repository.
+func (h *PRHandler) GetPR(c *gin.Context) {
+	prID := c.Query("pull_request_id")
+	if prID == "" {
+		BadRequest(c, "pull_request_id parameter is required")
+		return
+	}
+
+	pr, err := h.prService.GetPR(c.Request.Context(), domain.PRKey{RepositoryName: c.Query("repository_name"), PullRequestID: prID})
+	if err != nil {
+		if errors.Is(err, service.ErrPRNotFound) {
+			NotFound(c, "pull request not found")
+			return
+		}
+		InternalError(c, err.Error())
+		return
+	}
+
+	resp := domainToPRResponse(pr)
+	resp.Assignments = toAssignmentResponses(pr.Assignments)
+	c.JSON(http.StatusOK, GetPRResponse{
+		PR: resp,
+	})
+}
+
 // MergePR handles POST /pullRequest/merge.
 func (h *PRHandler) MergePR(c *gin.Context) {
 	var req MergePRRequest
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		BadRequest(c, "invalid request body")
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	if req.Force && !c.GetBool(AdminContextKey) {
+		Error(c, ErrorUnauthorized, "force merge requires an admin API key", http.StatusUnauthorized)
 		return
 	}
 
-	pr, err := h.prService.MergePR(req.PullRequestID)
+	pr, err := h.prService.MergePR(c.Request.Context(), req.Key(), service.MergeOptions{MergedBy: req.MergedBy, Force: req.Force})
 	if err != nil {
 		if errors.Is(err, service.ErrPRNotFound) {
 			NotFound(c, "pull request not found")
 			return
 		}
+		if errors.Is(err, service.ErrUserNotFound) {
+			NotFound(c, "user not found")
+			return
+		}
+		if errors.Is(err, service.ErrPRClosed) {
+			Conflict(c, ErrorPRClosed, "cannot merge closed PR")
+			return
+		}
+		var notApproved *service.NotApprovedError
+		if errors.As(err, &notApproved) {
+			c.JSON(http.StatusConflict, ErrorResponse{Error: ErrorBody{
+				Code:             ErrorNotApproved,
+				Message:          fmt.Sprintf("PR has %d of %d required approvals", notApproved.Approved, notApproved.Required),
+				MissingReviewers: notApproved.Missing,
+			}})
+			return
+		}
 		InternalError(c, err.Error())
 		return
 	}
 
-	c.JSON(http.StatusOK, SuccessResponse{
+	c.JSON(http.StatusOK, MergePRResponse{
+		PR: domainToPRResponse(pr),
+	})
+}
+
+// ApprovePR handles POST /pullRequest/approve.
+func (h *PRHandler) ApprovePR(c *gin.Context) {
+	var req ApprovePRRequest
+
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	pr, err := h.prService.ApprovePR(c.Request.Context(), req.Key(), req.UserID)
+	if err != nil {
+		if errors.Is(err, service.ErrPRNotFound) {
+			NotFound(c, "pull request not found")
+			return
+		}
+		if errors.Is(err, service.ErrReviewerNotAssigned) {
+			Conflict(c, ErrorNotAssigned, "reviewer is not assigned to this PR")
+			return
+		}
+		if errors.Is(err, service.ErrPRMerged) {
+			Conflict(c, ErrorPRMerged, "cannot approve merged PR")
+			return
+		}
+		if errors.Is(err, service.ErrPRClosed) {
+			Conflict(c, ErrorPRClosed, "cannot approve closed PR")
+			return
+		}
+		InternalError(c, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, ApprovePRResponse{
+		PR: domainToPRResponse(pr),
+	})
+}
+
+// ClosePR handles POST /pullRequest/close.
+func (h *PRHandler) ClosePR(c *gin.Context) {
+	var req ClosePRRequest
+
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	pr, err := h.prService.ClosePR(c.Request.Context(), req.Key())
+	if err != nil {
+		if errors.Is(err, service.ErrPRNotFound) {
+			NotFound(c, "pull request not found")
+			return
+		}
+		if errors.Is(err, service.ErrPRMerged) {
+			Conflict(c, ErrorPRMerged, "cannot close merged PR")
+			return
+		}
+		InternalError(c, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, ClosePRResponse{
+		PR: domainToPRResponse(pr),
+	})
+}
+
+// ReopenPR handles POST /pullRequest/reopen.
+// The route is restricted to admins.
+func (h *PRHandler) ReopenPR(c *gin.Context) {
+	var req ReopenPRRequest
+
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	pr, err := h.prService.ReopenPR(c.Request.Context(), req.Key())
+	if err != nil {
+		if errors.Is(err, service.ErrPRNotFound) {
+			NotFound(c, "pull request not found")
+			return
+		}
+		InternalError(c, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, ReopenPRResponse{
 		PR: domainToPRResponse(pr),
 	})
 }
@@ -81,12 +250,16 @@ func (h *PRHandler) MergePR(c *gin.Context) {
 func (h *PRHandler) ReassignPR(c *gin.Context) {
 	var req ReassignPRRequest
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		BadRequest(c, "invalid request body")
+	if !bindJSON(c, &req) {
 		return
 	}
 
-	pr, replacedBy, err := h.prService.ReassignPR(req.PullRequestID, req.OldUserID)
+	if req.Force && !c.GetBool(AdminContextKey) {
+		Error(c, ErrorUnauthorized, "force reassign requires an admin API key", http.StatusUnauthorized)
+		return
+	}
+
+	pr, replacedBy, err := h.prService.ReassignPR(c.Request.Context(), req.Key(), req.OldUserID, service.ReassignOptions{Force: req.Force})
 	if err != nil {
 		if errors.Is(err, service.ErrPRNotFound) || errors.Is(err, service.ErrPRAuthorNotFound) {
 			NotFound(c, "pull request or user not found")
@@ -96,6 +269,10 @@ func (h *PRHandler) ReassignPR(c *gin.Context) {
 			Conflict(c, ErrorPRMerged, "cannot reassign on merged PR")
 			return
 		}
+		if errors.Is(err, service.ErrPRClosed) {
+			Conflict(c, ErrorPRClosed, "cannot reassign on closed PR")
+			return
+		}
 		if errors.Is(err, service.ErrReviewerNotAssigned) {
 			Conflict(c, ErrorNotAssigned, "reviewer is not assigned to this PR")
 			return
@@ -104,8 +281,24 @@ func (h *PRHandler) ReassignPR(c *gin.Context) {
 			Conflict(c, ErrorNoCandidate, "no active replacement candidate in team")
 			return
 		}
+		if errors.Is(err, service.ErrReassignLimit) {
+			Conflict(c, ErrorReassignLimit, "PR reached the reassignment limit; an admin may force the reassign")
+			return
+		}
+		if errors.Is(err, service.ErrReviewerIsAuthor) {
+			Conflict(c, ErrorReviewerIsAuthor, err.Error())
+			return
+		}
+		if errors.Is(err, service.ErrReviewerAssigned) {
+			Conflict(c, ErrorAlreadyAssigned, err.Error())
+			return
+		}
 		if errors.Is(err, service.ErrInactiveReviewer) {
-			BadRequest(c, err.Error())
+			Conflict(c, ErrorReviewerInactive, err.Error())
+			return
+		}
+		if errors.Is(err, service.ErrReviewerNotFound) {
+			Error(c, ErrorReviewerNotFound, err.Error(), http.StatusNotFound)
 			return
 		}
 		InternalError(c, err.Error())
@@ -118,23 +311,159 @@ func (h *PRHandler) ReassignPR(c *gin.Context) {
 	})
 }
 
+// SuggestReviewers handles GET /pullRequest/suggestReviewers.
+// Nothing is written; the response shows who CreatePR would assign right now.
+func (h *PRHandler) SuggestReviewers(c *gin.Context) {
+	authorID := c.Query("author_id")
+	if authorID == "" {
+		BadRequest(c, "author_id parameter is required")
+		return
+	}
+
+	count := 2
+	if raw := c.Query("count"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			BadRequest(c, "count must be a positive integer")
+			return
+		}
+		count = n
+	}
+
+	suggestion, err := h.prService.SuggestReviewers(c.Request.Context(), authorID, count)
+	if err != nil {
+		if errors.Is(err, service.ErrPRAuthorNotFound) {
+			NotFound(c, "author not found")
+			return
+		}
+		InternalError(c, err.Error())
+		return
+	}
+
+	candidates := make([]CandidateResponse, len(suggestion.Candidates))
+	for i, u := range suggestion.Candidates {
+		candidates[i] = CandidateResponse{
+			UserID:         u.UserID,
+			Username:       u.Username,
+			OpenReviews:    u.OpenReviews,
+			OpenLoad:       u.OpenReviewLoad,
+			MaxOpenReviews: u.MaxOpenReviews,
+		}
+	}
+
+	c.JSON(http.StatusOK, SuggestReviewersResponse{
+		AuthorID:           suggestion.AuthorID,
+		TeamName:           suggestion.TeamName,
+		AssignmentStrategy: string(suggestion.Strategy),
+		Candidates:         candidates,
+		SuggestedReviewers: suggestion.Selected,
+	})
+}
+
+// BackfillReviewers handles POST /admin/backfillReviewers.
+func (h *PRHandler) BackfillReviewers(c *gin.Context) {
+	var req BackfillReviewersRequest
+
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	dryRun := req.DryRun
+	if raw := c.Query("dry_run"); !dryRun && raw != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(raw)
+		if err != nil {
+			BadRequest(c, "dry_run must be true or false")
+			return
+		}
+	}
+
+	result, err := h.prService.BackfillReviewers(c.Request.Context(), req.TeamName, service.BackfillOptions{DryRun: dryRun, MaxPRs: req.MaxPRs})
+	if err != nil {
+		if errors.Is(err, service.ErrTeamNotFound) {
+			NotFound(c, "team not found")
+			return
+		}
+		InternalError(c, err.Error())
+		return
+	}
+
+	response := BackfillReviewersResponse{
+		TeamName:     req.TeamName,
+		DryRun:       dryRun,
+		Touched:      result.Touched(),
+		Uncoverable:  result.Uncoverable(),
+		Remaining:    result.Remaining,
+		PullRequests: make([]BackfilledPRResponse, len(result.PRs)),
+	}
+	for i, p := range result.PRs {
+		added := p.Added
+		if added == nil {
+			added = []string{}
+		}
+		response.PullRequests[i] = BackfilledPRResponse{
+			RepositoryName:   p.PR.RepositoryName,
+			PullRequestID:    p.PR.PullRequestID,
+			AddedReviewers:   added,
+			MissingReviewers: p.Missing,
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // domainToPRResponse converts domain.PullRequest to PRResponse.
 func domainToPRResponse(pr *domain.PullRequest) *PRResponse {
 	resp := &PRResponse{
+		RepositoryName:    pr.RepositoryName,
 		PullRequestID:     pr.PullRequestID,
 		PullRequestName:   pr.PullRequestName,
 		AuthorID:          pr.AuthorID,
 		TeamName:          pr.TeamName,
 		Status:            string(pr.Status),
 		AssignedReviewers: pr.AssignedReviewersIDs,
+		ApprovedReviewers: pr.ApprovedReviewersIDs,
+		Description:       pr.Description,
+		ExternalURL:       pr.ExternalURL,
+		Size:              string(pr.Size),
+		LinesChanged:      pr.LinesChanged,
+		Tags:              pr.Tags,
+		ReassignmentCount: pr.ReassignmentCount,
+		Reviewers:         make([]ReviewerResponse, len(pr.Assignments)),
+	}
+	for i, a := range pr.Assignments {
+		resp.Reviewers[i] = ReviewerResponse{
+			UserID:     a.UserID,
+			Source:     string(a.Source),
+			AssignedAt: a.AssignedAt.UTC().Format(time.RFC3339),
+			Approved:   a.Approved,
+		}
 	}
 
 	if pr.CreatedAt != nil {
-		resp.CreatedAt = pr.CreatedAt.Format(time.RFC3339)
+		resp.CreatedAt = pr.CreatedAt.UTC().Format(time.RFC3339)
 	}
 	if pr.MergedAt != nil {
-		resp.MergedAt = pr.MergedAt.Format(time.RFC3339)
+		resp.MergedAt = pr.MergedAt.UTC().Format(time.RFC3339)
+	}
+	resp.MergedBy = pr.MergedBy
+	if pr.ClosedAt != nil {
+		resp.ClosedAt = pr.ClosedAt.UTC().Format(time.RFC3339)
 	}
 
 	return resp
 }
+
+// toAssignmentResponses converts measured reviewer assignments to response format.
+func toAssignmentResponses(assignments []domain.ReviewerAssignment) []AssignmentResponse {
+	resp := make([]AssignmentResponse, len(assignments))
+	for i, a := range assignments {
+		resp[i] = AssignmentResponse{
+			ReviewerID: a.UserID,
+			AssignedAt: a.AssignedAt.UTC().Format(time.RFC3339),
+			HoursOpen:  a.HoursOpen,
+			Overdue:    a.Overdue,
+		}
+	}
+	return resp
+}
@@ -3,36 +3,250 @@ package handler
 import "github.com/mishasvintus/avito_backend_internship/internal/domain"
 
 // CreatePRRequest represents request body for POST /pullRequest/create.
+// RepositoryName scopes PullRequestID; empty means the default repository.
+// A missing PullRequestID is generated by the service; an empty one is rejected.
+// RequiredReviewers are assigned before the automatically selected ones.
+// Description and ExternalURL are optional and stored as provided.
+// Size and LinesChanged are an optional size hint; without Size it is derived from LinesChanged.
+// Tags name the expertise areas the PR touches; reviewers sharing one of them are preferred.
+// ChangedPaths are matched against the team's ownership rules to add the owners as required reviewers.
 type CreatePRRequest struct {
-	PullRequestID   string `json:"pull_request_id" binding:"required"`
-	PullRequestName string `json:"pull_request_name" binding:"required"`
-	AuthorID        string `json:"author_id" binding:"required"`
+	RepositoryName    string   `json:"repository_name" binding:"max=255"`
+	PullRequestID     *string  `json:"pull_request_id" binding:"omitempty,entity_id"`
+	PullRequestName   string   `json:"pull_request_name" binding:"required,max=300"`
+	AuthorID          string   `json:"author_id" binding:"required,entity_id"`
+	RequiredReviewers []string `json:"required_reviewers" binding:"omitempty,dive,required,entity_id"`
+	Description       *string  `json:"description" binding:"omitempty,max=10000"`
+	ExternalURL       *string  `json:"external_url" binding:"omitempty,max=2048,http_url"`
+	Size              string   `json:"size" binding:"omitempty,oneof=XS S M L XL"`
+	LinesChanged      *int     `json:"lines_changed" binding:"omitempty,min=0"`
+	Tags              []string `json:"tags" binding:"omitempty,max=20,unique,dive,tag"`
+	ChangedPaths      []string `json:"changed_paths" binding:"omitempty,max=1000,dive,required,max=1024"`
+}
+
+// Key returns the key of the pull request to create; its PullRequestID is empty if it is to be generated.
+func (r CreatePRRequest) Key() domain.PRKey {
+	key := domain.PRKey{RepositoryName: r.RepositoryName}
+	if r.PullRequestID != nil {
+		key.PullRequestID = *r.PullRequestID
+	}
+	return key
 }
 
 // MergePRRequest represents request body for POST /pullRequest/merge.
+// MergedBy optionally names the user performing the merge.
+// Force skips the required approvals check and is accepted from admins only.
 type MergePRRequest struct {
-	PullRequestID string `json:"pull_request_id" binding:"required"`
+	RepositoryName string `json:"repository_name" binding:"max=255"`
+	PullRequestID  string `json:"pull_request_id" binding:"required,entity_id"`
+	MergedBy       string `json:"merged_by" binding:"omitempty,entity_id"`
+	Force          bool   `json:"force"`
+}
+
+// Key returns the key of the pull request to merge.
+func (r MergePRRequest) Key() domain.PRKey {
+	return domain.PRKey{RepositoryName: r.RepositoryName, PullRequestID: r.PullRequestID}
+}
+
+// ApprovePRRequest represents request body for POST /pullRequest/approve.
+type ApprovePRRequest struct {
+	RepositoryName string `json:"repository_name" binding:"max=255"`
+	PullRequestID  string `json:"pull_request_id" binding:"required,entity_id"`
+	UserID         string `json:"user_id" binding:"required,entity_id"`
+}
+
+// Key returns the key of the pull request to approve.
+func (r ApprovePRRequest) Key() domain.PRKey {
+	return domain.PRKey{RepositoryName: r.RepositoryName, PullRequestID: r.PullRequestID}
+}
+
+// ClosePRRequest represents request body for POST /pullRequest/close.
+type ClosePRRequest struct {
+	RepositoryName string `json:"repository_name" binding:"max=255"`
+	PullRequestID  string `json:"pull_request_id" binding:"required,entity_id"`
+}
+
+// Key returns the key of the pull request to close.
+func (r ClosePRRequest) Key() domain.PRKey {
+	return domain.PRKey{RepositoryName: r.RepositoryName, PullRequestID: r.PullRequestID}
+}
+
+// ReopenPRRequest represents request body for POST /pullRequest/reopen.
+type ReopenPRRequest struct {
+	RepositoryName string `json:"repository_name" binding:"max=255"`
+	PullRequestID  string `json:"pull_request_id" binding:"required,entity_id"`
+}
+
+// Key returns the key of the pull request to reopen.
+func (r ReopenPRRequest) Key() domain.PRKey {
+	return domain.PRKey{RepositoryName: r.RepositoryName, PullRequestID: r.PullRequestID}
 }
 
 // ReassignPRRequest represents request body for POST /pullRequest/reassign.
+// Force reassigns past the PR's reassignment limit and requires an admin API key.
 type ReassignPRRequest struct {
-	PullRequestID string `json:"pull_request_id" binding:"required"`
-	OldUserID     string `json:"old_user_id" binding:"required"`
+	RepositoryName string `json:"repository_name" binding:"max=255"`
+	PullRequestID  string `json:"pull_request_id" binding:"required,entity_id"`
+	OldUserID      string `json:"old_user_id" binding:"required,entity_id"`
+	Force          bool   `json:"force"`
+}
+
+// Key returns the key of the pull request to reassign.
+func (r ReassignPRRequest) Key() domain.PRKey {
+	return domain.PRKey{RepositoryName: r.RepositoryName, PullRequestID: r.PullRequestID}
 }
 
 // AddTeamRequest represents request body for POST /team/add.
+// AssignmentStrategy is optional and defaults to the service-wide strategy.
+// ConflictPolicy is "move" (default) or "reject" for members already in another team.
+// IfExists is "fail" (default), "ignore" or "update"; it may also be passed as a query parameter.
 type AddTeamRequest struct {
-	TeamName string              `json:"team_name" binding:"required"`
-	Members  []domain.TeamMember `json:"members" binding:"required"`
+	TeamName           string              `json:"team_name" binding:"required,max=300"`
+	AssignmentStrategy string              `json:"assignment_strategy"`
+	ConflictPolicy     string              `json:"conflict_policy" binding:"omitempty,oneof=move reject"`
+	IfExists           string              `json:"if_exists" binding:"omitempty,oneof=fail ignore update"`
+	Members            []domain.TeamMember `json:"members" binding:"required,max=200,unique=UserID,dive"`
+}
+
+// UpdateTeamRequest represents request body for POST /team/update.
+// At least one of AssignmentStrategy, RequireApprovals, ReviewSLAHours and SlackWebhookURL must be set;
+// omitted ones stay unchanged. ReviewSLAHours 0 removes the review SLA,
+// an empty SlackWebhookURL disables the team's Slack notifications.
+type UpdateTeamRequest struct {
+	TeamName           string  `json:"team_name" binding:"required,max=300"`
+	AssignmentStrategy string  `json:"assignment_strategy"`
+	RequireApprovals   *int    `json:"require_approvals" binding:"omitempty,min=0"`
+	ReviewSLAHours     *int    `json:"review_sla_hours" binding:"omitempty,min=0,max=8760"`
+	SlackWebhookURL    *string `json:"slack_webhook_url" binding:"omitempty,max=2048"`
+}
+
+// UpdateTeamSettingsRequest represents request body for POST /team/settings.
+// Omitted settings keep their values; reviewer_count 0, review_sla_hours 0 and an empty
+// slack_webhook_url or fallback_team_name reset the setting to its default.
+type UpdateTeamSettingsRequest struct {
+	TeamName           string  `json:"team_name" binding:"required,max=300"`
+	AssignmentStrategy string  `json:"assignment_strategy" binding:"max=32"`
+	ReviewerCount      *int    `json:"reviewer_count" binding:"omitempty,min=0,max=5"`
+	RequireApprovals   *int    `json:"require_approvals" binding:"omitempty,min=0"`
+	ReviewSLAHours     *int    `json:"review_sla_hours" binding:"omitempty,min=0,max=8760"`
+	SlackWebhookURL    *string `json:"slack_webhook_url" binding:"omitempty,max=2048"`
+	FallbackTeamName   *string `json:"fallback_team_name" binding:"omitempty,max=300"`
+}
+
+// SetOwnershipRuleRequest represents request body for POST /team/ownership.
+// Exactly one of OwnerUserID and OwnerTeamName must be set; PathPrefix "/" covers the whole repository.
+type SetOwnershipRuleRequest struct {
+	TeamName      string `json:"team_name" binding:"required,max=300"`
+	PathPrefix    string `json:"path_prefix" binding:"required,max=1024"`
+	OwnerUserID   string `json:"owner_user_id" binding:"omitempty,entity_id"`
+	OwnerTeamName string `json:"owner_team_name" binding:"max=300"`
+}
+
+// SetDigestScheduleRequest represents request body for POST /team/digest.
+// The digest is sent daily at Hour:00 in Timezone, an IANA time zone name.
+type SetDigestScheduleRequest struct {
+	TeamName string `json:"team_name" binding:"required,max=300"`
+	Hour     *int   `json:"hour" binding:"required,min=0,max=23"`
+	Timezone string `json:"timezone" binding:"required,max=64"`
+}
+
+// RenameTeamRequest represents request body for POST /team/rename.
+type RenameTeamRequest struct {
+	OldName string `json:"old_name" binding:"required,max=300"`
+	NewName string `json:"new_name" binding:"required,max=300"`
 }
 
 // DeactivateTeamRequest represents request body for POST /team/deactivate.
 type DeactivateTeamRequest struct {
-	TeamName string `json:"team_name" binding:"required"`
+	TeamName string `json:"team_name" binding:"required,max=300"`
+}
+
+// RebalanceTeamRequest represents request body for POST /team/rebalance.
+// MaxMoves limits the number of moved assignments; omitted means no limit.
+// DryRun may also be passed as a query parameter.
+type RebalanceTeamRequest struct {
+	TeamName string `json:"team_name" binding:"required,max=300"`
+	MaxMoves int    `json:"max_moves" binding:"omitempty,min=1"`
+	DryRun   bool   `json:"dry_run"`
+}
+
+// BackfillReviewersRequest represents request body for POST /admin/backfillReviewers.
+// An empty TeamName covers all teams; MaxPRs limits the number of processed PRs, omitted means no limit.
+// DryRun may also be passed as a query parameter.
+type BackfillReviewersRequest struct {
+	TeamName string `json:"team_name" binding:"max=300"`
+	MaxPRs   int    `json:"max_prs" binding:"omitempty,min=1"`
+	DryRun   bool   `json:"dry_run"`
 }
 
 // SetIsActiveRequest represents request body for POST /users/setIsActive.
 type SetIsActiveRequest struct {
-	UserID   string `json:"user_id" binding:"required"`
+	UserID   string `json:"user_id" binding:"required,entity_id"`
 	IsActive *bool  `json:"is_active" binding:"required"`
 }
+
+// SetIsActiveBatchRequest represents request body for POST /users/setIsActiveBatch.
+type SetIsActiveBatchRequest struct {
+	Users []SetIsActiveRequest `json:"users" binding:"required,min=1,max=200,dive"`
+}
+
+// EraseUserRequest represents request body for POST /users/erase.
+type EraseUserRequest struct {
+	UserID string `json:"user_id" binding:"required,entity_id"`
+}
+
+// SetCapacityRequest represents request body for POST /users/setCapacity.
+// A null or missing max_open_reviews removes the limit.
+type SetCapacityRequest struct {
+	UserID         string `json:"user_id" binding:"required,entity_id"`
+	MaxOpenReviews *int   `json:"max_open_reviews" binding:"omitempty,min=0"`
+}
+
+// SetTagsRequest represents request body for POST /users/setTags.
+// Tags replace the user's expertise tags; an empty or missing list removes them all.
+type SetTagsRequest struct {
+	UserID string   `json:"user_id" binding:"required,entity_id"`
+	Tags   []string `json:"tags" binding:"max=20,unique,dive,tag"`
+}
+
+// SetAbsenceRequest represents request body for POST /users/setAbsence.
+// Dates use the YYYY-MM-DD format; both ends are inclusive.
+type SetAbsenceRequest struct {
+	UserID       string `json:"user_id" binding:"required,entity_id"`
+	FromDate     string `json:"from_date" binding:"required"`
+	ToDate       string `json:"to_date" binding:"required"`
+	ReassignOpen bool   `json:"reassign_open"`
+}
+
+// ReassignAllRequest represents request body for POST /users/reassignAll.
+// Deactivate deactivates the user once their reviews are moved.
+type ReassignAllRequest struct {
+	UserID     string `json:"user_id" binding:"required,entity_id"`
+	Deactivate bool   `json:"deactivate"`
+}
+
+// ExclusionRequest represents request body for POST /users/addExclusion and /users/removeExclusion.
+type ExclusionRequest struct {
+	ReviewerID string `json:"reviewer_id" binding:"required,entity_id"`
+	AuthorID   string `json:"author_id" binding:"required,entity_id"`
+}
+
+// CreateWebhookRequest represents request body for POST /webhooks.
+// Secret keys the HMAC signature of every delivery.
+type CreateWebhookRequest struct {
+	URL    string `json:"url" binding:"required,max=2048,http_url"`
+	Secret string `json:"secret" binding:"required,max=255"`
+}
+
+// CreateOrgRequest represents request body for POST /admin/orgs.
+type CreateOrgRequest struct {
+	OrgID string `json:"org_id" binding:"required,org_id"`
+	Name  string `json:"name" binding:"required,max=255"`
+}
+
+// MapLoginRequest represents request body for POST /integrations/logins.
+type MapLoginRequest struct {
+	Provider      string `json:"provider" binding:"required,oneof=gitlab github"`
+	ExternalLogin string `json:"external_login" binding:"required,max=255"`
+	UserID        string `json:"user_id" binding:"required,entity_id"`
+}
@@ -1,69 +1,356 @@
 package handler
 
 import (
+	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/pagination"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
 )
 
 // ErrorCode represents error codes from OpenAPI spec.
 type ErrorCode string
 
 const (
-	ErrorTeamExists  ErrorCode = "TEAM_EXISTS"
-	ErrorPRExists    ErrorCode = "PR_EXISTS"
-	ErrorPRMerged    ErrorCode = "PR_MERGED"
-	ErrorNotAssigned ErrorCode = "NOT_ASSIGNED"
-	ErrorNoCandidate ErrorCode = "NO_CANDIDATE"
-	ErrorNotFound    ErrorCode = "NOT_FOUND"
+	ErrorTeamExists      ErrorCode = "TEAM_EXISTS"
+	ErrorPRExists        ErrorCode = "PR_EXISTS"
+	ErrorPRMerged        ErrorCode = "PR_MERGED"
+	ErrorPRClosed        ErrorCode = "PR_CLOSED"
+	ErrorNotAssigned     ErrorCode = "NOT_ASSIGNED"
+	ErrorNotApproved     ErrorCode = "NOT_APPROVED"
+	ErrorNoCandidate     ErrorCode = "NO_CANDIDATE"
+	ErrorReassignLimit   ErrorCode = "REASSIGN_LIMIT"
+	ErrorNotFound        ErrorCode = "NOT_FOUND"
+	ErrorTooLarge        ErrorCode = "TOO_LARGE"
+	ErrorTimeout         ErrorCode = "TIMEOUT"
+	ErrorInternal        ErrorCode = "INTERNAL"
+	ErrorPayloadTooLarge ErrorCode = "PAYLOAD_TOO_LARGE"
+	ErrorRateLimited     ErrorCode = "RATE_LIMITED"
+	ErrorValidation      ErrorCode = "VALIDATION_ERROR"
+	ErrorUserInOtherTeam ErrorCode = "USER_IN_OTHER_TEAM"
+	ErrorUnauthorized    ErrorCode = "UNAUTHORIZED"
+	ErrorOrgExists       ErrorCode = "ORG_EXISTS"
+	// ErrorAuthorRateLimited is returned when an author exceeds the pull request creation limit.
+	ErrorAuthorRateLimited ErrorCode = "AUTHOR_RATE_LIMITED"
+	// ErrorServiceUnavailable is returned while the database circuit breaker is open
+	// and for integrations that are not configured.
+	ErrorServiceUnavailable ErrorCode = "SERVICE_UNAVAILABLE"
+	// ErrorReviewerNotFound is returned when a reviewer picked for a new pull request is deleted
+	// before the pull request is stored.
+	ErrorReviewerNotFound ErrorCode = "REVIEWER_NOT_FOUND"
+	// ErrorUpstream is returned when a request to a VCS provider fails.
+	ErrorUpstream ErrorCode = "UPSTREAM_ERROR"
+	// ErrorReviewerIsAuthor, ErrorAlreadyAssigned and ErrorReviewerInactive are returned when the
+	// replacement picked by a reassign turned out to be the author, already assigned or inactive.
+	ErrorReviewerIsAuthor ErrorCode = "REVIEWER_IS_AUTHOR"
+	ErrorAlreadyAssigned  ErrorCode = "ALREADY_ASSIGNED"
+	ErrorReviewerInactive ErrorCode = "REVIEWER_INACTIVE"
+	// ErrorUserErased is returned when a team lists a member whose user was erased.
+	ErrorUserErased ErrorCode = "USER_ERASED"
 )
 
 // ErrorResponse represents error response structure.
 type ErrorResponse struct {
-	Error struct {
-		Code    ErrorCode `json:"code"`
-		Message string    `json:"message"`
-	} `json:"error"`
+	Error ErrorBody `json:"error"`
+}
+
+// ErrorBody is the error object of ErrorResponse.
+// Details lists the failed fields of a VALIDATION_ERROR,
+// MissingReviewers the reviewers whose approval a NOT_APPROVED merge lacks,
+// ExistingPR the pull request a PR_EXISTS create conflicted with,
+// Usage the author's creations counted by an AUTHOR_RATE_LIMITED create.
+type ErrorBody struct {
+	Code             ErrorCode         `json:"code"`
+	Message          string            `json:"message"`
+	Details          []FieldError      `json:"details,omitempty"`
+	MissingReviewers []string          `json:"missing_reviewers,omitempty"`
+	ExistingPR       *PRResponse       `json:"existing_pr,omitempty"`
+	Usage            *CreateLimitUsage `json:"usage,omitempty"`
+}
+
+// CreateLimitUsage is how many pull requests an author created within the last hour against the limit.
+type CreateLimitUsage struct {
+	Limit             int `json:"limit"`
+	Used              int `json:"used"`
+	RetryAfterSeconds int `json:"retry_after_seconds"`
 }
 
-// SuccessResponse represents success response structure.
-type SuccessResponse struct {
-	Team *TeamResponse `json:"team,omitempty"`
-	User *UserResponse `json:"user,omitempty"`
-	PR   *PRResponse   `json:"pr,omitempty"`
+// FieldError describes one request field that failed validation.
+// Field is the JSON path of the value, e.g. "members[1].user_id".
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// AddTeamResponse is returned by POST /team/add.
+// Result is "created", "updated" or "unchanged".
+type AddTeamResponse struct {
+	Team   *TeamResponse `json:"team"`
+	Result string        `json:"result"`
+}
+
+// UpdateTeamResponse is returned by POST /team/update.
+type UpdateTeamResponse struct {
+	Team *TeamResponse `json:"team"`
+}
+
+// RenameTeamResponse is returned by POST /team/rename.
+type RenameTeamResponse struct {
+	Team *TeamResponse `json:"team"`
 }
 
 // TeamResponse wraps team data.
 type TeamResponse struct {
-	TeamName string       `json:"team_name"`
-	Members  []TeamMember `json:"members"`
+	TeamName           string `json:"team_name"`
+	AssignmentStrategy string `json:"assignment_strategy"`
+	RequireApprovals   int    `json:"require_approvals"`
+	ReviewSLAHours     int    `json:"review_sla_hours"`
+	// SlackNotifications tells whether a Slack webhook is set; the URL itself is not returned.
+	SlackNotifications bool                 `json:"slack_notifications"`
+	Settings           TeamSettingsResponse `json:"settings"`
+	Members            []TeamMember         `json:"members"`
+}
+
+// TeamSettingsResponse represents a team's effective settings, defaults included, in response.
+type TeamSettingsResponse struct {
+	AssignmentStrategy string `json:"assignment_strategy"`
+	ReviewerCount      int    `json:"reviewer_count"`
+	RequireApprovals   int    `json:"require_approvals"`
+	ReviewSLAHours     int    `json:"review_sla_hours"`
+	// SlackNotifications tells whether a Slack webhook is set; the URL itself is not returned.
+	SlackNotifications bool   `json:"slack_notifications"`
+	FallbackTeamName   string `json:"fallback_team_name,omitempty"`
+}
+
+// TeamWorkloadResponse is returned by GET /team/workload.
+type TeamWorkloadResponse struct {
+	TeamName string                   `json:"team_name"`
+	Members  []MemberWorkloadResponse `json:"members"`
+	Totals   TeamWorkloadTotals       `json:"totals"`
+}
+
+// MemberWorkloadResponse represents an active member's open reviews, oldest assignment first.
+type MemberWorkloadResponse struct {
+	UserID          string            `json:"user_id"`
+	Username        string            `json:"username"`
+	OpenAssignments int               `json:"open_assignments"`
+	PullRequests    []PRShortResponse `json:"pull_requests"`
+}
+
+// TeamWorkloadTotals sums up the members' open reviews and counts the team's open PRs
+// without reviewers and with fewer than the team's reviewer count.
+type TeamWorkloadTotals struct {
+	ActiveMembers    int `json:"active_members"`
+	OpenAssignments  int `json:"open_assignments"`
+	OpenPRs          int `json:"open_prs"`
+	UnassignedPRs    int `json:"unassigned_prs"`
+	UnderAssignedPRs int `json:"under_assigned_prs"`
+	ReviewerCount    int `json:"reviewer_count"`
+}
+
+// RebalanceTeamResponse lists the assignments moved by POST /team/rebalance,
+// or the ones that would be moved in a dry run.
+type RebalanceTeamResponse struct {
+	TeamName string                  `json:"team_name"`
+	DryRun   bool                    `json:"dry_run"`
+	Moves    []RebalanceMoveResponse `json:"moves"`
+}
+
+// RebalanceMoveResponse represents one moved review assignment in response.
+type RebalanceMoveResponse struct {
+	RepositoryName string `json:"repository_name"`
+	PullRequestID  string `json:"pull_request_id"`
+	FromUserID     string `json:"from_user_id"`
+	ToUserID       string `json:"to_user_id"`
+}
+
+// BackfillReviewersResponse lists the under-covered PRs processed by POST /admin/backfillReviewers.
+// Touched counts PRs that got a reviewer, Uncoverable those still short of reviewers,
+// Remaining the under-covered PRs left over because of max_prs.
+type BackfillReviewersResponse struct {
+	TeamName     string                 `json:"team_name,omitempty"`
+	DryRun       bool                   `json:"dry_run"`
+	Touched      int                    `json:"touched"`
+	Uncoverable  int                    `json:"uncoverable"`
+	Remaining    int                    `json:"remaining"`
+	PullRequests []BackfilledPRResponse `json:"pull_requests"`
+}
+
+// BackfilledPRResponse represents one processed PR in response.
+type BackfilledPRResponse struct {
+	RepositoryName   string   `json:"repository_name"`
+	PullRequestID    string   `json:"pull_request_id"`
+	AddedReviewers   []string `json:"added_reviewers"`
+	MissingReviewers int      `json:"missing_reviewers"`
+}
+
+// OwnershipRuleResponse represents a code ownership rule in response.
+type OwnershipRuleResponse struct {
+	PathPrefix    string `json:"path_prefix"`
+	OwnerUserID   string `json:"owner_user_id,omitempty"`
+	OwnerTeamName string `json:"owner_team_name,omitempty"`
+}
+
+// OwnershipRulesResponse lists a team's code ownership rules ordered by path prefix.
+type OwnershipRulesResponse struct {
+	TeamName string                  `json:"team_name"`
+	Rules    []OwnershipRuleResponse `json:"rules"`
+}
+
+// DigestScheduleResponse represents a team's digest schedule in response.
+type DigestScheduleResponse struct {
+	TeamName   string `json:"team_name"`
+	Hour       int    `json:"hour"`
+	Timezone   string `json:"timezone"`
+	LastSentAt string `json:"last_sent_at"`
+}
+
+// ImportTeamsResponse wraps team import summary.
+type ImportTeamsResponse struct {
+	TeamsCreated int                      `json:"teams_created"`
+	UsersCreated int                      `json:"users_created"`
+	UsersUpdated int                      `json:"users_updated"`
+	UsersMoved   int                      `json:"users_moved"`
+	Errors       []ImportRowErrorResponse `json:"errors"`
+}
+
+// ImportRowErrorResponse represents a skipped import line in response.
+type ImportRowErrorResponse struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
 }
 
 // TeamMember represents a team member in response.
 type TeamMember struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
+	UserID           string   `json:"user_id"`
+	Username         string   `json:"username"`
+	IsActive         bool     `json:"is_active"`
+	MaxOpenReviews   *int     `json:"max_open_reviews,omitempty"`
+	AssignmentWeight float64  `json:"assignment_weight"`
+	Tags             []string `json:"tags,omitempty"`
 }
 
 // UserResponse wraps user data.
 type UserResponse struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	UserID           string   `json:"user_id"`
+	Username         string   `json:"username"`
+	TeamName         string   `json:"team_name"`
+	IsActive         bool     `json:"is_active"`
+	MaxOpenReviews   *int     `json:"max_open_reviews,omitempty"`
+	AssignmentWeight float64  `json:"assignment_weight"`
+	Tags             []string `json:"tags,omitempty"`
+}
+
+// SetIsActiveResponse is returned by POST /users/setIsActive.
+type SetIsActiveResponse struct {
+	User *UserResponse `json:"user"`
+}
+
+// SetCapacityResponse is returned by POST /users/setCapacity.
+type SetCapacityResponse struct {
+	User *UserResponse `json:"user"`
+}
+
+// SetTagsResponse is returned by POST /users/setTags.
+type SetTagsResponse struct {
+	User *UserResponse `json:"user"`
+}
+
+// EraseUserResponse is returned by POST /users/erase.
+type EraseUserResponse struct {
+	User *UserResponse `json:"user"`
+}
+
+// SetIsActiveBatchResponse wraps batch is_active update response.
+type SetIsActiveBatchResponse struct {
+	Users  []UserResponse           `json:"users"`
+	Errors []BatchItemErrorResponse `json:"errors"`
+}
+
+// BatchItemErrorResponse represents a skipped batch item in response.
+type BatchItemErrorResponse struct {
+	UserID  string `json:"user_id"`
+	Message string `json:"message"`
 }
 
 // PRResponse wraps pull request data.
+// AssignedReviewers and ApprovedReviewers are ordered by assignment time, then by user_id.
+// AssignedReviewers is superseded by Reviewers and is kept for one release.
 type PRResponse struct {
+	RepositoryName    string   `json:"repository_name"`
 	PullRequestID     string   `json:"pull_request_id"`
 	PullRequestName   string   `json:"pull_request_name"`
 	AuthorID          string   `json:"author_id"`
 	TeamName          string   `json:"team_name"`
 	Status            string   `json:"status"`
 	AssignedReviewers []string `json:"assigned_reviewers"`
+	ApprovedReviewers []string `json:"approved_reviewers,omitempty"`
 	CreatedAt         string   `json:"createdAt,omitempty"`
 	MergedAt          string   `json:"mergedAt,omitempty"`
+	MergedBy          string   `json:"merged_by,omitempty"`
+	ClosedAt          string   `json:"closedAt,omitempty"`
+	Description       *string  `json:"description,omitempty"`
+	ExternalURL       *string  `json:"external_url,omitempty"`
+	Size              string   `json:"size,omitempty"`
+	LinesChanged      *int     `json:"lines_changed,omitempty"`
+	Tags              []string `json:"tags,omitempty"`
+	ReassignmentCount int      `json:"reassignment_count"`
+	// Reviewers are the assigned reviewers in the order of AssignedReviewers, with why each was chosen.
+	Reviewers []ReviewerResponse `json:"reviewers"`
+	// Assignments is returned only by GET /pullRequest/get and GET /users/getAuthored.
+	Assignments []AssignmentResponse `json:"assignments,omitempty"`
+}
+
+// ReviewerResponse represents an assigned reviewer in response.
+type ReviewerResponse struct {
+	UserID     string `json:"user_id"`
+	Source     string `json:"source"`
+	AssignedAt string `json:"assigned_at"`
+	Approved   bool   `json:"approved"`
+}
+
+// AssignmentResponse represents a reviewer's assignment measured against the team's review SLA.
+// HoursOpen is omitted unless the PR is open.
+type AssignmentResponse struct {
+	ReviewerID string `json:"reviewer_id"`
+	AssignedAt string `json:"assigned_at"`
+	HoursOpen  *int   `json:"hours_open,omitempty"`
+	Overdue    bool   `json:"overdue"`
+}
+
+// CreatePRResponse is returned by POST /pullRequest/create.
+type CreatePRResponse struct {
+	PR *PRResponse `json:"pr"`
+}
+
+// GetPRResponse is returned by GET /pullRequest/get.
+type GetPRResponse struct {
+	PR *PRResponse `json:"pr"`
+}
+
+// MergePRResponse is returned by POST /pullRequest/merge.
+type MergePRResponse struct {
+	PR *PRResponse `json:"pr"`
+}
+
+// ApprovePRResponse is returned by POST /pullRequest/approve.
+type ApprovePRResponse struct {
+	PR *PRResponse `json:"pr"`
+}
+
+// ClosePRResponse is returned by POST /pullRequest/close.
+type ClosePRResponse struct {
+	PR *PRResponse `json:"pr"`
+}
+
+// ReopenPRResponse is returned by POST /pullRequest/reopen.
+type ReopenPRResponse struct {
+	PR *PRResponse `json:"pr"`
 }
 
 // ReassignResponse wraps reassign response.
@@ -72,42 +359,166 @@ type ReassignResponse struct {
 	ReplacedBy string      `json:"replaced_by"`
 }
 
+// SuggestReviewersResponse wraps suggest reviewers response.
+type SuggestReviewersResponse struct {
+	AuthorID           string              `json:"author_id"`
+	TeamName           string              `json:"team_name"`
+	AssignmentStrategy string              `json:"assignment_strategy"`
+	Candidates         []CandidateResponse `json:"candidates"`
+	SuggestedReviewers []string            `json:"suggested_reviewers"`
+}
+
+// CandidateResponse represents an eligible reviewer in response.
+// OpenLoad is OpenReviews weighted by PR size.
+type CandidateResponse struct {
+	UserID         string `json:"user_id"`
+	Username       string `json:"username"`
+	OpenReviews    int    `json:"open_reviews"`
+	OpenLoad       int    `json:"open_load"`
+	MaxOpenReviews *int   `json:"max_open_reviews"`
+}
+
+// AbsenceResponse represents an absence window in response.
+type AbsenceResponse struct {
+	UserID   string `json:"user_id"`
+	FromDate string `json:"from_date"`
+	ToDate   string `json:"to_date"`
+}
+
+// ReassignResultResponse represents one moved review in response.
+// When no replacement candidate was available ReplacedBy is omitted and Error is NO_CANDIDATE.
+type ReassignResultResponse struct {
+	RepositoryName string    `json:"repository_name"`
+	PullRequestID  string    `json:"pull_request_id"`
+	ReplacedBy     string    `json:"replaced_by,omitempty"`
+	Error          ErrorCode `json:"error,omitempty"`
+}
+
+// SetAbsenceResponse wraps set absence response.
+// ReassignError is set when moving the open reviews stopped midway; Reassigned then lists the
+// reviews handled before it, and the rest stay with the user.
+type SetAbsenceResponse struct {
+	Absence       AbsenceResponse          `json:"absence"`
+	Reassigned    []ReassignResultResponse `json:"reassigned"`
+	ReassignError string                   `json:"reassign_error,omitempty"`
+}
+
+// ReassignAllResponse wraps reassign all response.
+type ReassignAllResponse struct {
+	UserID      string                   `json:"user_id"`
+	Deactivated bool                     `json:"deactivated"`
+	Reassigned  []ReassignResultResponse `json:"reassigned"`
+}
+
+// ExclusionResponse represents a reviewer exclusion in response.
+type ExclusionResponse struct {
+	ReviewerID string `json:"reviewer_id"`
+	AuthorID   string `json:"author_id"`
+}
+
+// WebhookResponse represents a webhook in response; the secret is never returned.
+type WebhookResponse struct {
+	ID        int64  `json:"id"`
+	URL       string `json:"url"`
+	CreatedAt string `json:"created_at"`
+}
+
 // GetReviewResponse wraps get review response.
 type GetReviewResponse struct {
 	UserID       string            `json:"user_id"`
 	PullRequests []PRShortResponse `json:"pull_requests"`
 }
 
-// PRShortResponse represents short PR in response.
+// GetReviewCountResponse is returned by GET /users/getReviewCount.
+type GetReviewCountResponse struct {
+	UserID       string `json:"user_id"`
+	OpenCount    int    `json:"open_count"`
+	OverdueCount int    `json:"overdue_count"`
+}
+
+// GetAuthoredResponse wraps get authored response: a page of the user's pull requests.
+// PullRequests repeats Items, and NextOffset is set when more pull requests follow a page
+// requested without a cursor; both are deprecated and kept for clients paging by offset.
+type GetAuthoredResponse struct {
+	UserID string `json:"user_id"`
+	pagination.Page[PRResponse]
+	PullRequests []PRResponse `json:"pull_requests"`
+	NextOffset   int          `json:"next_offset,omitempty"`
+}
+
+// PRShortResponse represents short PR in response, with the user's assignment measured
+// against the team's review SLA. HoursOpen is omitted unless the PR is open.
 type PRShortResponse struct {
+	RepositoryName  string `json:"repository_name"`
 	PullRequestID   string `json:"pull_request_id"`
 	PullRequestName string `json:"pull_request_name"`
 	AuthorID        string `json:"author_id"`
 	TeamName        string `json:"team_name"`
 	Status          string `json:"status"`
+	AssignedAt      string `json:"assigned_at,omitempty"`
+	HoursOpen       *int   `json:"hours_open,omitempty"`
+	Overdue         bool   `json:"overdue"`
 }
 
 // StatisticsResponse wraps statistics response.
 type StatisticsResponse struct {
 	Overall struct {
 		TotalPRs         int64 `json:"total_prs"`
+		MergedPRs        int64 `json:"merged_prs"`
 		TotalAssignments int64 `json:"total_assignments"`
 		TotalUsers       int64 `json:"total_users"`
 		TotalTeams       int64 `json:"total_teams"`
 	} `json:"overall"`
 	ReviewerStats []ReviewerStatResponse `json:"reviewer_stats"`
 	AuthorStats   []AuthorStatResponse   `json:"author_stats"`
+	MergerStats   []MergerStatResponse   `json:"merger_stats"`
+	Distribution  DistributionResponse   `json:"distribution"`
+}
+
+// DistributionResponse represents open assignment distribution in response.
+type DistributionResponse struct {
+	Overall LoadDistributionResponse       `json:"overall"`
+	Teams   []TeamLoadDistributionResponse `json:"teams"`
+}
+
+// LoadDistributionResponse represents open assignments per active user summary in response.
+type LoadDistributionResponse struct {
+	ActiveUsers int     `json:"active_users"`
+	Min         int64   `json:"min"`
+	Max         int64   `json:"max"`
+	Mean        float64 `json:"mean"`
+	Median      float64 `json:"median"`
+	StdDev      float64 `json:"stddev"`
+}
+
+// TeamLoadDistributionResponse represents one team's distribution in response.
+// OverdueAssignments counts pending reviews of the team's open PRs past the team's review SLA.
+type TeamLoadDistributionResponse struct {
+	TeamName string `json:"team_name"`
+	LoadDistributionResponse
+	OverdueAssignments int64 `json:"overdue_assignments"`
 }
 
 // ReviewerStatResponse represents reviewer statistics in response.
+// Count is the total of OpenCount and MergedCount; OpenLoad is OpenCount weighted by PR size.
 type ReviewerStatResponse struct {
+	UserID      string `json:"user_id"`
+	Username    string `json:"username"`
+	Count       int64  `json:"count"`
+	OpenCount   int64  `json:"open_count"`
+	OpenLoad    int64  `json:"open_load"`
+	MergedCount int64  `json:"merged_count"`
+}
+
+// AuthorStatResponse represents author statistics in response.
+type AuthorStatResponse struct {
 	UserID   string `json:"user_id"`
 	Username string `json:"username"`
 	Count    int64  `json:"count"`
 }
 
-// AuthorStatResponse represents author statistics in response.
-type AuthorStatResponse struct {
+// MergerStatResponse represents the number of PRs a user merged in response.
+type MergerStatResponse struct {
 	UserID   string `json:"user_id"`
 	Username string `json:"username"`
 	Count    int64  `json:"count"`
@@ -116,13 +527,7 @@ type AuthorStatResponse struct {
 // Error sends error response.
 func Error(c *gin.Context, code ErrorCode, message string, statusCode int) {
 	c.JSON(statusCode, ErrorResponse{
-		Error: struct {
-			Code    ErrorCode `json:"code"`
-			Message string    `json:"message"`
-		}{
-			Code:    code,
-			Message: message,
-		},
+		Error: ErrorBody{Code: code, Message: message},
 	})
 }
 
@@ -139,25 +544,172 @@ func Conflict(c *gin.Context, code ErrorCode, message string) {
 // BadRequest sends 400 error.
 func BadRequest(c *gin.Context, message string) {
 	c.JSON(http.StatusBadRequest, ErrorResponse{
-		Error: struct {
-			Code    ErrorCode `json:"code"`
-			Message string    `json:"message"`
-		}{
-			Code:    "",
-			Message: message,
-		},
+		Error: ErrorBody{Code: "", Message: message},
 	})
 }
 
+// ValidationError sends 400 VALIDATION_ERROR with the failed fields.
+func ValidationError(c *gin.Context, details []FieldError) {
+	c.JSON(http.StatusBadRequest, ErrorResponse{
+		Error: ErrorBody{Code: ErrorValidation, Message: "invalid request body", Details: details},
+	})
+}
+
+// AuthorRateLimited sends 429 AUTHOR_RATE_LIMITED with the author's usage and a Retry-After header.
+func AuthorRateLimited(c *gin.Context, limited *service.AuthorRateLimitError) {
+	retryAfter := max(1, int(math.Ceil(limited.RetryAfter.Seconds())))
+	c.Header("Retry-After", strconv.Itoa(retryAfter))
+	c.JSON(http.StatusTooManyRequests, ErrorResponse{Error: ErrorBody{
+		Code:    ErrorAuthorRateLimited,
+		Message: fmt.Sprintf("author created %d of %d pull requests allowed per hour", limited.Used, limited.Limit),
+		Usage:   &CreateLimitUsage{Limit: limited.Limit, Used: limited.Used, RetryAfterSeconds: retryAfter},
+	}})
+}
+
 // InternalError sends 500 error.
 func InternalError(c *gin.Context, message string) {
 	c.JSON(http.StatusInternalServerError, ErrorResponse{
-		Error: struct {
-			Code    ErrorCode `json:"code"`
-			Message string    `json:"message"`
-		}{
-			Code:    "",
-			Message: message,
-		},
+		Error: ErrorBody{Code: "", Message: message},
 	})
 }
+
+// ExportResponse wraps user load export in JSON format.
+type ExportResponse struct {
+	Users []UserLoadResponse `json:"users"`
+}
+
+// UserLoadResponse represents one user's review load in response.
+type UserLoadResponse struct {
+	UserID           string `json:"user_id"`
+	Username         string `json:"username"`
+	Team             string `json:"team"`
+	IsActive         bool   `json:"is_active"`
+	OpenAssignments  int64  `json:"open_assignments"`
+	TotalAssignments int64  `json:"total_assignments"`
+	AuthoredPRs      int64  `json:"authored_prs"`
+}
+
+// ThroughputResponse represents PR throughput time series in response.
+type ThroughputResponse struct {
+	Bucket   string                    `json:"bucket"`
+	From     string                    `json:"from"`
+	To       string                    `json:"to"`
+	TeamName string                    `json:"team_name,omitempty"`
+	Buckets  []ThroughputPointResponse `json:"buckets"`
+}
+
+// ThroughputPointResponse represents one bucket of the throughput series.
+type ThroughputPointResponse struct {
+	Start   string `json:"start"`
+	Created int64  `json:"created"`
+	Merged  int64  `json:"merged"`
+}
+
+// LeaderboardResponse represents top reviewers and authors in response.
+type LeaderboardResponse struct {
+	Period    string               `json:"period"`
+	Since     string               `json:"since,omitempty"`
+	Reviewers []RankedUserResponse `json:"reviewers"`
+	Authors   []RankedUserResponse `json:"authors"`
+}
+
+// CoverageResponse represents reviewer coverage of open pull requests in response.
+type CoverageResponse struct {
+	CoverageCountsResponse
+	Teams []TeamCoverageResponse `json:"teams"`
+}
+
+// TeamCoverageResponse represents reviewer coverage of one team's open pull requests in response.
+type TeamCoverageResponse struct {
+	TeamName       string `json:"team_name"`
+	ReviewerTarget int    `json:"reviewer_target"`
+	CoverageCountsResponse
+}
+
+// CoverageCountsResponse counts open pull requests with and without enough reviewers in response.
+type CoverageCountsResponse struct {
+	OpenPRs         int64               `json:"open_prs"`
+	CoveredPRs      int64               `json:"covered_prs"`
+	UnderCoveredPRs int64               `json:"under_covered_prs"`
+	Shortfall       []ShortfallResponse `json:"shortfall"`
+}
+
+// ShortfallResponse is the number of open pull requests missing the same number of reviewers.
+type ShortfallResponse struct {
+	MissingReviewers int   `json:"missing_reviewers"`
+	PRs              int64 `json:"prs"`
+}
+
+// RankedUserResponse represents a leaderboard entry in response.
+type RankedUserResponse struct {
+	Rank     int    `json:"rank"`
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	Count    int64  `json:"count"`
+}
+
+// UserStatisticsResponse represents a single user's statistics in response.
+// AvgTimeToMergeSeconds is null until a PR the user reviewed is merged;
+// CurrentAbsence is null when the user is not absent today.
+type UserStatisticsResponse struct {
+	UserID                string           `json:"user_id"`
+	Username              string           `json:"username"`
+	TeamName              string           `json:"team_name"`
+	IsActive              bool             `json:"is_active"`
+	OpenReviews           int64            `json:"open_reviews"`
+	CompletedReviews      int64            `json:"completed_reviews"`
+	AuthoredOpenPRs       int64            `json:"authored_open_prs"`
+	AuthoredMergedPRs     int64            `json:"authored_merged_prs"`
+	AvgTimeToMergeSeconds *float64         `json:"avg_time_to_merge_seconds"`
+	MaxOpenReviews        *int             `json:"max_open_reviews"`
+	AtCapacity            bool             `json:"at_capacity"`
+	CurrentAbsence        *AbsenceResponse `json:"current_absence"`
+}
+
+// AuditLogResponse is returned by GET /admin/audit: a page of audit entries.
+// Entries repeats Items, and NextBeforeID is set when more entries follow a page requested
+// without a cursor; both are deprecated and kept for clients paging by before_id.
+type AuditLogResponse struct {
+	pagination.Page[AuditEntryResponse]
+	Entries      []AuditEntryResponse `json:"entries"`
+	NextBeforeID int64                `json:"next_before_id,omitempty"`
+}
+
+// AuditEntryResponse represents an audit log entry in response.
+type AuditEntryResponse struct {
+	ID        int64  `json:"id"`
+	OrgID     string `json:"org_id"`
+	Actor     string `json:"actor"`
+	Action    string `json:"action"`
+	Target    string `json:"target"`
+	RequestID string `json:"request_id"`
+	CreatedAt string `json:"created_at"`
+}
+
+// OrgResponse represents an organization in response.
+type OrgResponse struct {
+	OrgID     string `json:"org_id"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"created_at"`
+}
+
+// WebhookEventResponse is returned by POST /integrations/gitlab/webhook for an applied event.
+type WebhookEventResponse struct {
+	PR *PRResponse `json:"pr"`
+}
+
+// TeamSyncResponse is returned by POST /integrations/github/syncTeams.
+type TeamSyncResponse struct {
+	TeamsCreated     []string               `json:"teams_created"`
+	UsersCreated     []string               `json:"users_created"`
+	UsersUpdated     []string               `json:"users_updated"`
+	UsersDeactivated []string               `json:"users_deactivated"`
+	Skipped          []SkippedLoginResponse `json:"skipped"`
+}
+
+// SkippedLoginResponse represents a GitHub team member the sync left out.
+type SkippedLoginResponse struct {
+	TeamName string `json:"team_name"`
+	Login    string `json:"login"`
+	Reason   string `json:"reason"`
+}
@@ -1,57 +1,147 @@
 package handler
 
 import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/stats"
 	"github.com/mishasvintus/avito_backend_internship/internal/service"
 )
 
 // StatsHandler handles statistics HTTP requests.
 type StatsHandler struct {
-	statsService StatsServiceInterface
+	statsService     StatsServiceInterface
+	cacheTTL         time.Duration
+	anonymizeKey     []byte
+	anonymizeDefault bool
 }
 
 // StatsServiceInterface defines the interface for statistics operations.
 type StatsServiceInterface interface {
-	GetStatistics() (*service.Statistics, error)
+	GetStatistics(ctx context.Context, period stats.Period) (*service.Statistics, error)
+	GetUserLoad(ctx context.Context) ([]stats.UserLoad, error)
+	GetThroughput(ctx context.Context, bucket stats.BucketSize, period stats.Period, teamName string) (*service.Throughput, error)
+	GetLeaderboard(ctx context.Context, period service.LeaderboardPeriod, limit int) (*service.Leaderboard, error)
+	GetUserStatistics(ctx context.Context, userID string) (*service.UserStatistics, error)
+	GetCoverage(ctx context.Context) (*service.Coverage, error)
 }
 
 // NewStatsHandler creates a new stats handler.
 func NewStatsHandler(statsService StatsServiceInterface) *StatsHandler {
-	return &StatsHandler{statsService: statsService}
+	return &StatsHandler{statsService: statsService, anonymizeKey: randomKey()}
+}
+
+// WithAnonymization sets the pseudonym key and whether responses are anonymized
+// when the request does not say otherwise. An empty key keeps the random per-process key.
+func (h *StatsHandler) WithAnonymization(key []byte, byDefault bool) *StatsHandler {
+	if len(key) > 0 {
+		h.anonymizeKey = key
+	}
+	h.anonymizeDefault = byDefault
+	return h
+}
+
+// WithCacheTTL advertises the statistics cache TTL to clients via Cache-Control on GET /stats.
+func (h *StatsHandler) WithCacheTTL(ttl time.Duration) *StatsHandler {
+	h.cacheTTL = ttl
+	return h
 }
 
 // GetStatistics handles GET /stats.
+// Optional from and to (RFC3339) limit PR, merge and assignment counts to that window.
 func (h *StatsHandler) GetStatistics(c *gin.Context) {
-	stats, err := h.statsService.GetStatistics()
+	period, ok := parsePeriod(c)
+	if !ok {
+		return
+	}
+	anonymize, ok := h.parseAnonymize(c)
+	if !ok {
+		return
+	}
+
+	stats, err := h.statsService.GetStatistics(c.Request.Context(), period)
 	if err != nil {
+		if errors.Is(err, service.ErrStatsTimeout) {
+			Error(c, ErrorTimeout, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
 		InternalError(c, err.Error())
 		return
 	}
 
+	if h.cacheTTL > 0 {
+		c.Header("Cache-Control", "max-age="+strconv.Itoa(int(h.cacheTTL.Seconds())))
+	} else {
+		c.Header("Cache-Control", "no-cache")
+	}
+
+	response := NewStatisticsResponse(stats)
+
+	if anonymize {
+		for i := range response.ReviewerStats {
+			rs := &response.ReviewerStats[i]
+			rs.UserID = h.pseudonym(rs.UserID)
+			rs.Username = rs.UserID
+		}
+		for i := range response.AuthorStats {
+			as := &response.AuthorStats[i]
+			as.UserID = h.pseudonym(as.UserID)
+			as.Username = as.UserID
+		}
+		for i := range response.MergerStats {
+			ms := &response.MergerStats[i]
+			ms.UserID = h.pseudonym(ms.UserID)
+			ms.Username = ms.UserID
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// NewStatisticsResponse converts service.Statistics to the GET /stats response body.
+func NewStatisticsResponse(stats *service.Statistics) StatisticsResponse {
 	response := StatisticsResponse{
 		Overall: struct {
 			TotalPRs         int64 `json:"total_prs"`
+			MergedPRs        int64 `json:"merged_prs"`
 			TotalAssignments int64 `json:"total_assignments"`
 			TotalUsers       int64 `json:"total_users"`
 			TotalTeams       int64 `json:"total_teams"`
 		}{
 			TotalPRs:         stats.Overall.TotalPRs,
+			MergedPRs:        stats.Overall.MergedPRs,
 			TotalAssignments: stats.Overall.TotalAssignments,
 			TotalUsers:       stats.Overall.TotalUsers,
 			TotalTeams:       stats.Overall.TotalTeams,
 		},
 		ReviewerStats: make([]ReviewerStatResponse, len(stats.ReviewerStats)),
 		AuthorStats:   make([]AuthorStatResponse, len(stats.AuthorStats)),
+		MergerStats:   make([]MergerStatResponse, len(stats.MergerStats)),
+		Distribution: DistributionResponse{
+			Overall: toLoadDistributionResponse(stats.Distribution.Overall),
+			Teams:   make([]TeamLoadDistributionResponse, len(stats.Distribution.Teams)),
+		},
 	}
 
 	for i, rs := range stats.ReviewerStats {
 		response.ReviewerStats[i] = ReviewerStatResponse{
-			UserID:   rs.UserID,
-			Username: rs.Username,
-			Count:    rs.Count,
+			UserID:      rs.UserID,
+			Username:    rs.Username,
+			Count:       rs.Count,
+			OpenCount:   rs.OpenCount,
+			OpenLoad:    rs.OpenLoad,
+			MergedCount: rs.MergedCount,
 		}
 	}
 
@@ -63,5 +153,351 @@ func (h *StatsHandler) GetStatistics(c *gin.Context) {
 		}
 	}
 
+	for i, ms := range stats.MergerStats {
+		response.MergerStats[i] = MergerStatResponse{
+			UserID:   ms.UserID,
+			Username: ms.Username,
+			Count:    ms.Count,
+		}
+	}
+
+	for i, td := range stats.Distribution.Teams {
+		response.Distribution.Teams[i] = TeamLoadDistributionResponse{
+			TeamName:                 td.TeamName,
+			LoadDistributionResponse: toLoadDistributionResponse(td.LoadDistribution),
+			OverdueAssignments:       td.OverdueAssignments,
+		}
+	}
+
+	return response
+}
+
+// toLoadDistributionResponse converts service.LoadDistribution to LoadDistributionResponse.
+func toLoadDistributionResponse(d service.LoadDistribution) LoadDistributionResponse {
+	return LoadDistributionResponse{
+		ActiveUsers: d.ActiveUsers,
+		Min:         d.Min,
+		Max:         d.Max,
+		Mean:        d.Mean,
+		Median:      d.Median,
+		StdDev:      d.StdDev,
+	}
+}
+
+// GetThroughput handles GET /stats/timeseries.
+// Returns PRs created and merged per day or week, optionally for a single team.
+func (h *StatsHandler) GetThroughput(c *gin.Context) {
+	bucket := stats.BucketSize(c.DefaultQuery("bucket", string(stats.BucketWeek)))
+	if bucket != stats.BucketDay && bucket != stats.BucketWeek {
+		BadRequest(c, "bucket must be day or week")
+		return
+	}
+
+	period, ok := parsePeriod(c)
+	if !ok {
+		return
+	}
+
+	throughput, err := h.statsService.GetThroughput(c.Request.Context(), bucket, period, c.Query("team_name"))
+	if err != nil {
+		if errors.Is(err, service.ErrTeamNotFound) {
+			NotFound(c, "team not found")
+			return
+		}
+		if errors.Is(err, service.ErrInvalidBucket) ||
+			errors.Is(err, service.ErrInvalidPeriod) ||
+			errors.Is(err, service.ErrTooManyBuckets) {
+			BadRequest(c, err.Error())
+			return
+		}
+		InternalError(c, err.Error())
+		return
+	}
+
+	points := make([]ThroughputPointResponse, len(throughput.Points))
+	for i, p := range throughput.Points {
+		points[i] = ThroughputPointResponse{
+			Start:   p.BucketStart.Format(time.DateOnly),
+			Created: p.Created,
+			Merged:  p.Merged,
+		}
+	}
+
+	c.JSON(http.StatusOK, ThroughputResponse{
+		Bucket:   string(throughput.Bucket),
+		From:     throughput.From.UTC().Format(time.RFC3339),
+		To:       throughput.To.UTC().Format(time.RFC3339),
+		TeamName: throughput.TeamName,
+		Buckets:  points,
+	})
+}
+
+// GetLeaderboard handles GET /stats/leaderboard.
+// Returns top reviewers by completed reviews and top authors by merged PRs.
+func (h *StatsHandler) GetLeaderboard(c *gin.Context) {
+	period := service.LeaderboardPeriod(c.DefaultQuery("period", string(service.LeaderboardMonth)))
+
+	limit := 10
+	if raw := c.Query("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > service.MaxLeaderboardLimit {
+			BadRequest(c, "limit must be an integer between 1 and "+strconv.Itoa(service.MaxLeaderboardLimit))
+			return
+		}
+		limit = n
+	}
+
+	anonymize, ok := h.parseAnonymize(c)
+	if !ok {
+		return
+	}
+
+	leaderboard, err := h.statsService.GetLeaderboard(c.Request.Context(), period, limit)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidLeaderboardPeriod) || errors.Is(err, service.ErrInvalidLimit) {
+			BadRequest(c, err.Error())
+			return
+		}
+		InternalError(c, err.Error())
+		return
+	}
+
+	response := LeaderboardResponse{
+		Period:    string(leaderboard.Period),
+		Reviewers: toRankedUserResponses(leaderboard.Reviewers),
+		Authors:   toRankedUserResponses(leaderboard.Authors),
+	}
+	if leaderboard.Since != nil {
+		response.Since = leaderboard.Since.UTC().Format(time.RFC3339)
+	}
+	if anonymize {
+		h.anonymizeRanked(response.Reviewers)
+		h.anonymizeRanked(response.Authors)
+	}
+
 	c.JSON(http.StatusOK, response)
 }
+
+// toRankedUserResponses converts leaderboard entries, numbering them from 1.
+func toRankedUserResponses(users []stats.RankedUser) []RankedUserResponse {
+	resp := make([]RankedUserResponse, len(users))
+	for i, u := range users {
+		resp[i] = RankedUserResponse{
+			Rank:     i + 1,
+			UserID:   u.UserID,
+			Username: u.Username,
+			Count:    u.Count,
+		}
+	}
+	return resp
+}
+
+// GetUserStatistics handles GET /stats/user.
+func (h *StatsHandler) GetUserStatistics(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		BadRequest(c, "user_id parameter is required")
+		return
+	}
+
+	st, err := h.statsService.GetUserStatistics(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			NotFound(c, "user not found")
+			return
+		}
+		InternalError(c, err.Error())
+		return
+	}
+
+	response := UserStatisticsResponse{
+		UserID:            st.User.UserID,
+		Username:          st.User.Username,
+		TeamName:          st.User.TeamName,
+		IsActive:          st.User.IsActive,
+		OpenReviews:       st.Activity.OpenReviews,
+		CompletedReviews:  st.Activity.CompletedReviews,
+		AuthoredOpenPRs:   st.Activity.AuthoredOpen,
+		AuthoredMergedPRs: st.Activity.AuthoredMerged,
+		MaxOpenReviews:    st.User.MaxOpenReviews,
+		AtCapacity:        st.AtCapacity,
+	}
+	if st.Activity.AvgTimeToMerge != nil {
+		seconds := st.Activity.AvgTimeToMerge.Seconds()
+		response.AvgTimeToMergeSeconds = &seconds
+	}
+	if a := st.CurrentAbsence; a != nil {
+		response.CurrentAbsence = &AbsenceResponse{
+			UserID:   a.UserID,
+			FromDate: a.FromDate.Format(domain.DateLayout),
+			ToDate:   a.ToDate.Format(domain.DateLayout),
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetCoverage handles GET /stats/coverage.
+// Returns how many open PRs have fewer reviewers than their team's reviewer_count, overall and per team.
+func (h *StatsHandler) GetCoverage(c *gin.Context) {
+	coverage, err := h.statsService.GetCoverage(c.Request.Context())
+	if err != nil {
+		InternalError(c, err.Error())
+		return
+	}
+
+	response := CoverageResponse{
+		CoverageCountsResponse: toCoverageCountsResponse(coverage.CoverageCounts),
+		Teams:                  make([]TeamCoverageResponse, len(coverage.Teams)),
+	}
+	for i, t := range coverage.Teams {
+		response.Teams[i] = TeamCoverageResponse{
+			TeamName:               t.TeamName,
+			ReviewerTarget:         t.ReviewerTarget,
+			CoverageCountsResponse: toCoverageCountsResponse(t.CoverageCounts),
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// toCoverageCountsResponse converts service.CoverageCounts to CoverageCountsResponse.
+func toCoverageCountsResponse(counts service.CoverageCounts) CoverageCountsResponse {
+	resp := CoverageCountsResponse{
+		OpenPRs:         counts.OpenPRs,
+		CoveredPRs:      counts.CoveredPRs,
+		UnderCoveredPRs: counts.UnderCoveredPRs,
+		Shortfall:       make([]ShortfallResponse, len(counts.Shortfall)),
+	}
+	for i, s := range counts.Shortfall {
+		resp.Shortfall[i] = ShortfallResponse{MissingReviewers: s.Missing, PRs: s.PRs}
+	}
+	return resp
+}
+
+// parseAnonymize reads the optional anonymize query parameter, falling back to the configured default.
+// Writes a 400 response and returns false if it is not a boolean.
+func (h *StatsHandler) parseAnonymize(c *gin.Context) (bool, bool) {
+	raw := c.Query("anonymize")
+	if raw == "" {
+		return h.anonymizeDefault, true
+	}
+	anonymize, err := strconv.ParseBool(raw)
+	if err != nil {
+		BadRequest(c, "anonymize must be true or false")
+		return false, false
+	}
+	return anonymize, true
+}
+
+// anonymizeRanked replaces user IDs and names of leaderboard entries with pseudonyms.
+func (h *StatsHandler) anonymizeRanked(users []RankedUserResponse) {
+	for i := range users {
+		users[i].UserID = h.pseudonym(users[i].UserID)
+		users[i].Username = users[i].UserID
+	}
+}
+
+// pseudonym returns a stable alias for userID: a truncated HMAC-SHA256 of it under the handler's key.
+// The same user gets the same alias in every section and every response while the key is unchanged.
+func (h *StatsHandler) pseudonym(userID string) string {
+	mac := hmac.New(sha256.New, h.anonymizeKey)
+	mac.Write([]byte(userID))
+	return "user-" + hex.EncodeToString(mac.Sum(nil))[:8]
+}
+
+// randomKey returns a fresh 32-byte key for pseudonyms.
+func randomKey() []byte {
+	key := make([]byte, 32)
+	_, _ = rand.Read(key)
+	return key
+}
+
+// parsePeriod reads optional from and to query parameters.
+// Writes a 400 response and returns false if they are malformed or from is after to.
+func parsePeriod(c *gin.Context) (stats.Period, bool) {
+	from, ok := parseTimeQuery(c, "from")
+	if !ok {
+		return stats.Period{}, false
+	}
+	to, ok := parseTimeQuery(c, "to")
+	if !ok {
+		return stats.Period{}, false
+	}
+
+	if from != nil && to != nil && from.After(*to) {
+		BadRequest(c, "from must not be after to")
+		return stats.Period{}, false
+	}
+
+	return stats.Period{From: from, To: to}, true
+}
+
+// parseTimeQuery parses an optional RFC3339 query parameter; nil means it was omitted.
+func parseTimeQuery(c *gin.Context, name string) (*time.Time, bool) {
+	raw := c.Query(name)
+	if raw == "" {
+		return nil, true
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		BadRequest(c, name+" must be an RFC3339 timestamp")
+		return nil, false
+	}
+	return &t, true
+}
+
+// exportColumns is the CSV header of GET /stats/export.
+var exportColumns = []string{"user_id", "username", "team", "is_active", "open_assignments", "total_assignments", "authored_prs"}
+
+// ExportStatistics handles GET /stats/export.
+// Returns one row per user as CSV (default) or JSON depending on the format parameter.
+func (h *StatsHandler) ExportStatistics(c *gin.Context) {
+	format := c.DefaultQuery("format", "csv")
+	if format != "csv" && format != "json" {
+		BadRequest(c, "format must be csv or json")
+		return
+	}
+
+	loads, err := h.statsService.GetUserLoad(c.Request.Context())
+	if err != nil {
+		InternalError(c, err.Error())
+		return
+	}
+
+	if format == "json" {
+		rows := make([]UserLoadResponse, len(loads))
+		for i, l := range loads {
+			rows[i] = UserLoadResponse{
+				UserID:           l.UserID,
+				Username:         l.Username,
+				Team:             l.TeamName,
+				IsActive:         l.IsActive,
+				OpenAssignments:  l.OpenAssignments,
+				TotalAssignments: l.TotalAssignments,
+				AuthoredPRs:      l.AuthoredPRs,
+			}
+		}
+		c.JSON(http.StatusOK, ExportResponse{Users: rows})
+		return
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="review_load.csv"`)
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	_ = w.Write(exportColumns)
+	for _, l := range loads {
+		_ = w.Write([]string{
+			l.UserID,
+			l.Username,
+			l.TeamName,
+			strconv.FormatBool(l.IsActive),
+			strconv.FormatInt(l.OpenAssignments, 10),
+			strconv.FormatInt(l.TotalAssignments, 10),
+			strconv.FormatInt(l.AuthoredPRs, 10),
+		})
+	}
+	w.Flush()
+}
@@ -2,74 +2,185 @@ package handler
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
 	"github.com/mishasvintus/avito_backend_internship/internal/service"
 )
 
 // TeamHandler handles team-related HTTP requests.
 type TeamHandler struct {
 	teamService TeamServiceInterface
+	// teamExistsStatus is the status of TEAM_EXISTS responses.
+	teamExistsStatus int
 }
 
 // NewTeamHandler creates a new team handler.
 func NewTeamHandler(teamService TeamServiceInterface) *TeamHandler {
-	return &TeamHandler{teamService: teamService}
+	return &TeamHandler{teamService: teamService, teamExistsStatus: http.StatusConflict}
+}
+
+// WithLegacyTeamExistsStatus answers POST /team/add for an existing team with 400 TEAM_EXISTS,
+// as before it became 409, for clients that have not been updated yet.
+func (h *TeamHandler) WithLegacyTeamExistsStatus(legacy bool) *TeamHandler {
+	h.teamExistsStatus = http.StatusConflict
+	if legacy {
+		h.teamExistsStatus = http.StatusBadRequest
+	}
+	return h
 }
 
 // AddTeam handles POST /team/add.
 func (h *TeamHandler) AddTeam(c *gin.Context) {
 	var req AddTeamRequest
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		BadRequest(c, "invalid request body")
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	policy, err := service.ParseConflictPolicy(req.ConflictPolicy)
+	if err != nil {
+		BadRequest(c, "unknown conflict_policy")
+		return
+	}
+
+	ifExists := req.IfExists
+	if ifExists == "" {
+		ifExists = c.Query("if_exists")
+	}
+	mode, err := service.ParseIfExists(ifExists)
+	if err != nil {
+		BadRequest(c, "unknown if_exists")
 		return
 	}
 
-	err := h.teamService.CreateTeam(req.TeamName, req.Members)
+	team, outcome, err := h.teamService.CreateTeam(c.Request.Context(), &domain.Team{
+		TeamName:           req.TeamName,
+		AssignmentStrategy: req.AssignmentStrategy,
+		Members:            req.Members,
+	}, service.CreateTeamOptions{ConflictPolicy: policy, IfExists: mode})
 	if err != nil {
 		if errors.Is(err, service.ErrTeamExists) {
-			Error(c, ErrorTeamExists, "team_name already exists", http.StatusBadRequest)
+			Error(c, ErrorTeamExists, "team_name already exists", h.teamExistsStatus)
+			return
+		}
+		var inOtherTeam *service.UserInOtherTeamError
+		if errors.As(err, &inOtherTeam) {
+			Conflict(c, ErrorUserInOtherTeam, fmt.Sprintf("user %s already belongs to team %s", inOtherTeam.UserID, inOtherTeam.TeamName))
+			return
+		}
+		var erased *service.UserErasedError
+		if errors.As(err, &erased) {
+			Conflict(c, ErrorUserErased, fmt.Sprintf("user %s is erased", erased.UserID))
+			return
+		}
+		var duplicate *service.DuplicateMemberError
+		if errors.As(err, &duplicate) {
+			ValidationError(c, []FieldError{{Field: "members", Rule: "unique", Message: "must not contain duplicate user_id values"}})
+			return
+		}
+		if errors.Is(err, service.ErrUnknownStrategy) {
+			BadRequest(c, "unknown assignment_strategy")
 			return
 		}
 		InternalError(c, err.Error())
 		return
 	}
 
-	team, err := h.teamService.GetTeam(req.TeamName)
+	status := http.StatusOK
+	if outcome == service.TeamCreated {
+		status = http.StatusCreated
+	}
+	c.JSON(status, AddTeamResponse{
+		Team:   domainToTeamResponse(team),
+		Result: string(outcome),
+	})
+}
+
+// GetTeam handles GET /team/get.
+func (h *TeamHandler) GetTeam(c *gin.Context) {
+	teamName := c.Query("team_name")
+	if teamName == "" {
+		BadRequest(c, "team_name parameter is required")
+		return
+	}
+
+	team, err := h.teamService.GetTeam(c.Request.Context(), teamName)
 	if err != nil {
-		InternalError(c, "failed to retrieve created team")
+		if errors.Is(err, service.ErrTeamNotFound) {
+			NotFound(c, "team not found")
+			return
+		}
+		InternalError(c, err.Error())
 		return
 	}
 
-	members := make([]TeamMember, len(team.Members))
-	for i, m := range team.Members {
-		members[i] = TeamMember{
-			UserID:   m.UserID,
-			Username: m.Username,
-			IsActive: m.IsActive,
+	c.JSON(http.StatusOK, domainToTeamResponse(team))
+}
+
+// UpdateTeam handles POST /team/update.
+func (h *TeamHandler) UpdateTeam(c *gin.Context) {
+	var req UpdateTeamRequest
+
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	if req.AssignmentStrategy == "" && req.RequireApprovals == nil && req.ReviewSLAHours == nil && req.SlackWebhookURL == nil {
+		ValidationError(c, []FieldError{{
+			Field:   "assignment_strategy",
+			Rule:    "required_without",
+			Message: "is required when require_approvals, review_sla_hours and slack_webhook_url are omitted",
+		}})
+		return
+	}
+	if req.SlackWebhookURL != nil && *req.SlackWebhookURL != "" && !isHTTPURL(*req.SlackWebhookURL) {
+		ValidationError(c, []FieldError{{
+			Field:   "slack_webhook_url",
+			Rule:    "http_url",
+			Message: "must be an absolute http or https URL",
+		}})
+		return
+	}
+
+	team, err := h.teamService.UpdateTeam(c.Request.Context(), req.TeamName, service.TeamUpdate{
+		AssignmentStrategy: req.AssignmentStrategy,
+		RequireApprovals:   req.RequireApprovals,
+		ReviewSLAHours:     req.ReviewSLAHours,
+		SlackWebhookURL:    req.SlackWebhookURL,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrUnknownStrategy) {
+			BadRequest(c, "unknown assignment_strategy")
+			return
+		}
+		if errors.Is(err, service.ErrTeamNotFound) {
+			NotFound(c, "team not found")
+			return
 		}
+		InternalError(c, err.Error())
+		return
 	}
 
-	c.JSON(http.StatusCreated, SuccessResponse{
-		Team: &TeamResponse{
-			TeamName: team.TeamName,
-			Members:  members,
-		},
+	c.JSON(http.StatusOK, UpdateTeamResponse{
+		Team: domainToTeamResponse(team),
 	})
 }
 
-// GetTeam handles GET /team/get.
-func (h *TeamHandler) GetTeam(c *gin.Context) {
+// GetTeamSettings handles GET /team/settings.
+func (h *TeamHandler) GetTeamSettings(c *gin.Context) {
 	teamName := c.Query("team_name")
 	if teamName == "" {
 		BadRequest(c, "team_name parameter is required")
 		return
 	}
 
-	team, err := h.teamService.GetTeam(teamName)
+	settings, err := h.teamService.GetTeamSettings(c.Request.Context(), teamName)
 	if err != nil {
 		if errors.Is(err, service.ErrTeamNotFound) {
 			NotFound(c, "team not found")
@@ -79,18 +190,362 @@ func (h *TeamHandler) GetTeam(c *gin.Context) {
 		return
 	}
 
-	members := make([]TeamMember, len(team.Members))
-	for i, m := range team.Members {
-		members[i] = TeamMember{
-			UserID:   m.UserID,
-			Username: m.Username,
-			IsActive: m.IsActive,
+	c.JSON(http.StatusOK, gin.H{"team_name": teamName, "settings": toTeamSettingsResponse(*settings)})
+}
+
+// GetTeamWorkload handles GET /team/workload.
+func (h *TeamHandler) GetTeamWorkload(c *gin.Context) {
+	teamName := c.Query("team_name")
+	if teamName == "" {
+		BadRequest(c, "team_name parameter is required")
+		return
+	}
+
+	workload, err := h.teamService.GetTeamWorkload(c.Request.Context(), teamName)
+	if err != nil {
+		if errors.Is(err, service.ErrTeamNotFound) {
+			NotFound(c, "team not found")
+			return
+		}
+		InternalError(c, err.Error())
+		return
+	}
+
+	members := make([]MemberWorkloadResponse, len(workload.Members))
+	for i, m := range workload.Members {
+		members[i] = MemberWorkloadResponse{
+			UserID:          m.UserID,
+			Username:        m.Username,
+			OpenAssignments: len(m.PullRequests),
+			PullRequests:    toPRShortResponses(m.PullRequests),
 		}
 	}
 
-	c.JSON(http.StatusOK, TeamResponse{
-		TeamName: team.TeamName,
+	c.JSON(http.StatusOK, TeamWorkloadResponse{
+		TeamName: workload.TeamName,
 		Members:  members,
+		Totals: TeamWorkloadTotals{
+			ActiveMembers:    len(workload.Members),
+			OpenAssignments:  workload.OpenAssignments(),
+			OpenPRs:          workload.OpenPRs,
+			UnassignedPRs:    workload.UnassignedPRs,
+			UnderAssignedPRs: workload.UnderAssignedPRs,
+			ReviewerCount:    workload.ReviewerCount,
+		},
+	})
+}
+
+// UpdateTeamSettings handles POST /team/settings.
+// Invalid values are reported per field; the settings are changed only if all of them are valid.
+func (h *TeamHandler) UpdateTeamSettings(c *gin.Context) {
+	var req UpdateTeamSettingsRequest
+
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	var fields []FieldError
+	if req.AssignmentStrategy != "" {
+		if _, err := service.ParseStrategy(req.AssignmentStrategy); err != nil {
+			fields = append(fields, FieldError{Field: "assignment_strategy", Rule: "oneof", Message: "unknown assignment strategy"})
+		}
+	}
+	if req.SlackWebhookURL != nil && *req.SlackWebhookURL != "" && !isHTTPURL(*req.SlackWebhookURL) {
+		fields = append(fields, FieldError{Field: "slack_webhook_url", Rule: "http_url", Message: "must be an absolute http or https URL"})
+	}
+	if req.FallbackTeamName != nil && *req.FallbackTeamName == req.TeamName {
+		fields = append(fields, FieldError{Field: "fallback_team_name", Rule: "nefield", Message: "must differ from team_name"})
+	}
+	if len(fields) > 0 {
+		ValidationError(c, fields)
+		return
+	}
+
+	team, err := h.teamService.UpdateTeam(c.Request.Context(), req.TeamName, service.TeamUpdate{
+		AssignmentStrategy: req.AssignmentStrategy,
+		ReviewerCount:      req.ReviewerCount,
+		RequireApprovals:   req.RequireApprovals,
+		ReviewSLAHours:     req.ReviewSLAHours,
+		SlackWebhookURL:    req.SlackWebhookURL,
+		FallbackTeamName:   req.FallbackTeamName,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrTeamNotFound) {
+			NotFound(c, "team not found")
+			return
+		}
+		if errors.Is(err, service.ErrFallbackTeamNotFound) {
+			NotFound(c, "fallback team not found")
+			return
+		}
+		InternalError(c, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"team_name": team.TeamName, "settings": toTeamSettingsResponse(team.Settings())})
+}
+
+// SetOwnershipRule handles POST /team/ownership.
+func (h *TeamHandler) SetOwnershipRule(c *gin.Context) {
+	var req SetOwnershipRuleRequest
+
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	if (req.OwnerUserID == "") == (req.OwnerTeamName == "") {
+		ValidationError(c, []FieldError{{
+			Field:   "owner_user_id",
+			Rule:    "required_without",
+			Message: "exactly one of owner_user_id and owner_team_name must be set",
+		}})
+		return
+	}
+
+	rule, err := h.teamService.SetOwnershipRule(c.Request.Context(), domain.OwnershipRule{
+		TeamName:      req.TeamName,
+		PathPrefix:    req.PathPrefix,
+		OwnerUserID:   req.OwnerUserID,
+		OwnerTeamName: req.OwnerTeamName,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrTeamNotFound) {
+			NotFound(c, "team not found")
+			return
+		}
+		if errors.Is(err, service.ErrOwnerTeamNotFound) {
+			NotFound(c, "owner team not found")
+			return
+		}
+		if errors.Is(err, service.ErrUserNotFound) {
+			NotFound(c, "owner user not found")
+			return
+		}
+		InternalError(c, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"rule": toOwnershipRuleResponse(*rule)})
+}
+
+// ListOwnershipRules handles GET /team/ownership.
+func (h *TeamHandler) ListOwnershipRules(c *gin.Context) {
+	teamName := c.Query("team_name")
+	if teamName == "" {
+		BadRequest(c, "team_name parameter is required")
+		return
+	}
+
+	rules, err := h.teamService.ListOwnershipRules(c.Request.Context(), teamName)
+	if err != nil {
+		if errors.Is(err, service.ErrTeamNotFound) {
+			NotFound(c, "team not found")
+			return
+		}
+		InternalError(c, err.Error())
+		return
+	}
+
+	resp := OwnershipRulesResponse{TeamName: teamName, Rules: make([]OwnershipRuleResponse, len(rules))}
+	for i, r := range rules {
+		resp.Rules[i] = toOwnershipRuleResponse(r)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeleteOwnershipRule handles DELETE /team/ownership.
+func (h *TeamHandler) DeleteOwnershipRule(c *gin.Context) {
+	teamName := c.Query("team_name")
+	if teamName == "" {
+		BadRequest(c, "team_name parameter is required")
+		return
+	}
+	pathPrefix, ok := c.GetQuery("path_prefix")
+	if !ok {
+		BadRequest(c, "path_prefix parameter is required")
+		return
+	}
+
+	if err := h.teamService.DeleteOwnershipRule(c.Request.Context(), teamName, pathPrefix); err != nil {
+		if errors.Is(err, service.ErrOwnershipRuleNotFound) {
+			NotFound(c, "ownership rule not found")
+			return
+		}
+		InternalError(c, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "ownership rule removed successfully"})
+}
+
+// SetDigestSchedule handles POST /team/digest.
+func (h *TeamHandler) SetDigestSchedule(c *gin.Context) {
+	var req SetDigestScheduleRequest
+
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	schedule, err := h.teamService.SetDigestSchedule(c.Request.Context(), req.TeamName, *req.Hour, req.Timezone)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidTimezone) {
+			ValidationError(c, []FieldError{{
+				Field:   "timezone",
+				Rule:    "timezone",
+				Message: "must be an IANA time zone name, e.g. Europe/Moscow",
+			}})
+			return
+		}
+		if errors.Is(err, service.ErrTeamNotFound) {
+			NotFound(c, "team not found")
+			return
+		}
+		InternalError(c, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"digest": toDigestScheduleResponse(schedule)})
+}
+
+// GetDigestSchedule handles GET /team/digest.
+func (h *TeamHandler) GetDigestSchedule(c *gin.Context) {
+	teamName := c.Query("team_name")
+	if teamName == "" {
+		BadRequest(c, "team_name parameter is required")
+		return
+	}
+
+	schedule, err := h.teamService.GetDigestSchedule(c.Request.Context(), teamName)
+	if err != nil {
+		if errors.Is(err, service.ErrDigestNotFound) {
+			NotFound(c, "digest schedule not found")
+			return
+		}
+		InternalError(c, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"digest": toDigestScheduleResponse(schedule)})
+}
+
+// DeleteDigestSchedule handles DELETE /team/digest.
+func (h *TeamHandler) DeleteDigestSchedule(c *gin.Context) {
+	teamName := c.Query("team_name")
+	if teamName == "" {
+		BadRequest(c, "team_name parameter is required")
+		return
+	}
+
+	if err := h.teamService.DeleteDigestSchedule(c.Request.Context(), teamName); err != nil {
+		if errors.Is(err, service.ErrDigestNotFound) {
+			NotFound(c, "digest schedule not found")
+			return
+		}
+		InternalError(c, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "digest schedule removed successfully"})
+}
+
+// toDigestScheduleResponse converts domain.DigestSchedule to DigestScheduleResponse.
+func toDigestScheduleResponse(s *domain.DigestSchedule) DigestScheduleResponse {
+	return DigestScheduleResponse{
+		TeamName:   s.TeamName,
+		Hour:       s.Hour,
+		Timezone:   s.Timezone,
+		LastSentAt: s.LastSentAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// toOwnershipRuleResponse converts domain.OwnershipRule to OwnershipRuleResponse.
+func toOwnershipRuleResponse(r domain.OwnershipRule) OwnershipRuleResponse {
+	return OwnershipRuleResponse{
+		PathPrefix:    r.PathPrefix,
+		OwnerUserID:   r.OwnerUserID,
+		OwnerTeamName: r.OwnerTeamName,
+	}
+}
+
+// maxImportFileSize limits the size of a team import upload.
+const maxImportFileSize = 1 << 20
+
+// ImportTeams handles POST /team/import.
+// Expects a multipart form with a CSV file in the "file" field.
+func (h *TeamHandler) ImportTeams(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportFileSize)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			Error(c, ErrorTooLarge, "file exceeds 1 MiB", http.StatusRequestEntityTooLarge)
+			return
+		}
+		BadRequest(c, "file is required")
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		InternalError(c, "failed to read uploaded file")
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	summary, err := h.teamService.ImportTeams(c.Request.Context(), file)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCSV) {
+			BadRequest(c, err.Error())
+			return
+		}
+		InternalError(c, err.Error())
+		return
+	}
+
+	rowErrors := make([]ImportRowErrorResponse, len(summary.Errors))
+	for i, e := range summary.Errors {
+		rowErrors[i] = ImportRowErrorResponse{Line: e.Line, Message: e.Message}
+	}
+
+	c.JSON(http.StatusOK, ImportTeamsResponse{
+		TeamsCreated: summary.TeamsCreated,
+		UsersCreated: summary.UsersCreated,
+		UsersUpdated: summary.UsersUpdated,
+		UsersMoved:   summary.UsersMoved,
+		Errors:       rowErrors,
+	})
+}
+
+// RenameTeam handles POST /team/rename.
+func (h *TeamHandler) RenameTeam(c *gin.Context) {
+	var req RenameTeamRequest
+
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	if req.NewName == req.OldName {
+		ValidationError(c, []FieldError{{Field: "new_name", Rule: "nefield", Message: "must differ from old_name"}})
+		return
+	}
+
+	team, err := h.teamService.RenameTeam(c.Request.Context(), req.OldName, req.NewName)
+	if err != nil {
+		if errors.Is(err, service.ErrTeamNotFound) {
+			NotFound(c, "team not found")
+			return
+		}
+		if errors.Is(err, service.ErrTeamExists) {
+			Conflict(c, ErrorTeamExists, "new_name already exists")
+			return
+		}
+		InternalError(c, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, RenameTeamResponse{
+		Team: domainToTeamResponse(team),
 	})
 }
 
@@ -98,12 +553,11 @@ func (h *TeamHandler) GetTeam(c *gin.Context) {
 func (h *TeamHandler) DeactivateTeam(c *gin.Context) {
 	var req DeactivateTeamRequest
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		BadRequest(c, "invalid request body")
+	if !bindJSON(c, &req) {
 		return
 	}
 
-	err := h.teamService.DeactivateTeam(req.TeamName)
+	err := h.teamService.DeactivateTeam(c.Request.Context(), req.TeamName)
 	if err != nil {
 		if errors.Is(err, service.ErrTeamNotFound) {
 			NotFound(c, "team not found")
@@ -115,3 +569,98 @@ func (h *TeamHandler) DeactivateTeam(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "team deactivated successfully"})
 }
+
+// RebalanceTeam handles POST /team/rebalance.
+func (h *TeamHandler) RebalanceTeam(c *gin.Context) {
+	var req RebalanceTeamRequest
+
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	dryRun := req.DryRun
+	if raw := c.Query("dry_run"); !dryRun && raw != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(raw)
+		if err != nil {
+			BadRequest(c, "dry_run must be true or false")
+			return
+		}
+	}
+
+	moves, err := h.teamService.RebalanceTeam(c.Request.Context(), req.TeamName, service.RebalanceOptions{DryRun: dryRun, MaxMoves: req.MaxMoves})
+	if err != nil {
+		if errors.Is(err, service.ErrTeamNotFound) {
+			NotFound(c, "team not found")
+			return
+		}
+		// The PRs changed between planning and applying the moves; nothing was moved.
+		if errors.Is(err, service.ErrPRMerged) {
+			Conflict(c, ErrorPRMerged, "a pull request was merged during rebalance, retry the request")
+			return
+		}
+		if errors.Is(err, service.ErrPRClosed) {
+			Conflict(c, ErrorPRClosed, "a pull request was closed during rebalance, retry the request")
+			return
+		}
+		if errors.Is(err, service.ErrReviewerNotAssigned) {
+			Conflict(c, ErrorNotAssigned, "a reviewer was reassigned during rebalance, retry the request")
+			return
+		}
+		InternalError(c, err.Error())
+		return
+	}
+
+	response := RebalanceTeamResponse{
+		TeamName: req.TeamName,
+		DryRun:   dryRun,
+		Moves:    make([]RebalanceMoveResponse, len(moves)),
+	}
+	for i, m := range moves {
+		response.Moves[i] = RebalanceMoveResponse{
+			RepositoryName: m.PR.RepositoryName,
+			PullRequestID:  m.PR.PullRequestID,
+			FromUserID:     m.From,
+			ToUserID:       m.To,
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// domainToTeamResponse converts a domain team to its response representation.
+func domainToTeamResponse(team *domain.Team) *TeamResponse {
+	members := make([]TeamMember, len(team.Members))
+	for i, m := range team.Members {
+		members[i] = TeamMember{
+			UserID:           m.UserID,
+			Username:         m.Username,
+			IsActive:         m.IsActive,
+			MaxOpenReviews:   m.MaxOpenReviews,
+			AssignmentWeight: m.AssignmentWeight,
+			Tags:             m.Tags,
+		}
+	}
+
+	return &TeamResponse{
+		TeamName:           team.TeamName,
+		AssignmentStrategy: team.AssignmentStrategy,
+		RequireApprovals:   team.RequireApprovals,
+		ReviewSLAHours:     team.ReviewSLAHours,
+		SlackNotifications: team.SlackWebhookURL != "",
+		Settings:           toTeamSettingsResponse(team.Settings()),
+		Members:            members,
+	}
+}
+
+// toTeamSettingsResponse converts domain.TeamSettings to TeamSettingsResponse.
+func toTeamSettingsResponse(s domain.TeamSettings) TeamSettingsResponse {
+	return TeamSettingsResponse{
+		AssignmentStrategy: s.AssignmentStrategy,
+		ReviewerCount:      s.ReviewerCount,
+		RequireApprovals:   s.RequireApprovals,
+		ReviewSLAHours:     s.ReviewSLAHours,
+		SlackNotifications: s.SlackWebhookURL != "",
+		FallbackTeamName:   s.FallbackTeamName,
+	}
+}
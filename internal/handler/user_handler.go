@@ -3,12 +3,20 @@ package handler
 import (
 	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/pagination"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
 	"github.com/mishasvintus/avito_backend_internship/internal/service"
 )
 
+// defaultAuthoredPageSize is the page size of GET /users/getAuthored when limit is omitted.
+const defaultAuthoredPageSize = 50
+
 // UserHandler handles user-related HTTP requests.
 type UserHandler struct {
 	userService UserServiceInterface
@@ -23,13 +31,160 @@ func NewUserHandler(userService UserServiceInterface) *UserHandler {
 func (h *UserHandler) SetIsActive(c *gin.Context) {
 	var req SetIsActiveRequest
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		BadRequest(c, "invalid request body")
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	user, err := h.userService.SetIsActive(c.Request.Context(), req.UserID, *req.IsActive)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			NotFound(c, "user not found")
+			return
+		}
+		InternalError(c, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, SetIsActiveResponse{
+		User: domainToUserResponse(user),
+	})
+}
+
+// SetIsActiveBatch handles POST /users/setIsActiveBatch.
+// Unknown users are reported in errors; the remaining items are applied.
+func (h *UserHandler) SetIsActiveBatch(c *gin.Context) {
+	var req SetIsActiveBatchRequest
+
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	changes := make([]service.ActivityChange, len(req.Users))
+	for i, item := range req.Users {
+		changes[i] = service.ActivityChange{UserID: item.UserID, IsActive: *item.IsActive}
+	}
+
+	result, err := h.userService.SetIsActiveBatch(c.Request.Context(), changes)
+	if err != nil {
+		InternalError(c, err.Error())
+		return
+	}
+
+	users := make([]UserResponse, len(result.Updated))
+	for i := range result.Updated {
+		users[i] = *domainToUserResponse(&result.Updated[i])
+	}
+	itemErrors := make([]BatchItemErrorResponse, len(result.Errors))
+	for i, e := range result.Errors {
+		itemErrors[i] = BatchItemErrorResponse{UserID: e.UserID, Message: e.Err.Error()}
+	}
+
+	c.JSON(http.StatusOK, SetIsActiveBatchResponse{
+		Users:  users,
+		Errors: itemErrors,
+	})
+}
+
+// SetCapacity handles POST /users/setCapacity.
+func (h *UserHandler) SetCapacity(c *gin.Context) {
+	var req SetCapacityRequest
+
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	user, err := h.userService.SetCapacity(c.Request.Context(), req.UserID, req.MaxOpenReviews)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			NotFound(c, "user not found")
+			return
+		}
+		InternalError(c, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, SetCapacityResponse{
+		User: domainToUserResponse(user),
+	})
+}
+
+// SetTags handles POST /users/setTags.
+func (h *UserHandler) SetTags(c *gin.Context) {
+	var req SetTagsRequest
+
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	user, err := h.userService.SetTags(c.Request.Context(), req.UserID, req.Tags)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			NotFound(c, "user not found")
+			return
+		}
+		InternalError(c, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, SetTagsResponse{
+		User: domainToUserResponse(user),
+	})
+}
+
+// EraseUser handles POST /users/erase.
+func (h *UserHandler) EraseUser(c *gin.Context) {
+	var req EraseUserRequest
+
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	user, err := h.userService.EraseUser(c.Request.Context(), req.UserID)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			NotFound(c, "user not found")
+			return
+		}
+		InternalError(c, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, EraseUserResponse{
+		User: domainToUserResponse(user),
+	})
+}
+
+// SetAbsence handles POST /users/setAbsence.
+func (h *UserHandler) SetAbsence(c *gin.Context) {
+	var req SetAbsenceRequest
+
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	fromDate, err := time.Parse(domain.DateLayout, req.FromDate)
+	if err != nil {
+		BadRequest(c, "from_date must be in YYYY-MM-DD format")
+		return
+	}
+	toDate, err := time.Parse(domain.DateLayout, req.ToDate)
+	if err != nil {
+		BadRequest(c, "to_date must be in YYYY-MM-DD format")
 		return
 	}
 
-	user, err := h.userService.SetIsActive(req.UserID, *req.IsActive)
+	absence := domain.Absence{UserID: req.UserID, FromDate: fromDate, ToDate: toDate}
+	results, err := h.userService.SetAbsence(c.Request.Context(), absence, req.ReassignOpen)
+	var incomplete *service.ReassignIncompleteError
+	if errors.As(err, &incomplete) {
+		// The absence is saved and the reviews listed are already moved; report how far it got.
+		results, err = incomplete.Reassigned, nil
+	}
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidAbsence) {
+			BadRequest(c, "from_date must not be after to_date")
+			return
+		}
 		if errors.Is(err, service.ErrUserNotFound) {
 			NotFound(c, "user not found")
 			return
@@ -38,16 +193,127 @@ func (h *UserHandler) SetIsActive(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, SuccessResponse{
-		User: &UserResponse{
-			UserID:   user.UserID,
-			Username: user.Username,
-			TeamName: user.TeamName,
-			IsActive: user.IsActive,
+	c.JSON(http.StatusOK, SetAbsenceResponse{
+		Absence: AbsenceResponse{
+			UserID:   absence.UserID,
+			FromDate: req.FromDate,
+			ToDate:   req.ToDate,
 		},
+		Reassigned:    toReassignResultResponses(results),
+		ReassignError: reassignErrorOf(incomplete),
+	})
+}
+
+// reassignErrorOf returns the message of the error that stopped a reassignment, or an empty string.
+func reassignErrorOf(incomplete *service.ReassignIncompleteError) string {
+	if incomplete == nil {
+		return ""
+	}
+	return incomplete.Err.Error()
+}
+
+// ReassignAll handles POST /users/reassignAll.
+func (h *UserHandler) ReassignAll(c *gin.Context) {
+	var req ReassignAllRequest
+
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	results, err := h.userService.ReassignAll(c.Request.Context(), req.UserID, req.Deactivate)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			NotFound(c, "user not found")
+			return
+		}
+		InternalError(c, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, ReassignAllResponse{
+		UserID:      req.UserID,
+		Deactivated: req.Deactivate,
+		Reassigned:  toReassignResultResponses(results),
 	})
 }
 
+// RemoveAbsence handles DELETE /users/setAbsence.
+// Removes the absence starting at from_date, or all user's absences when from_date is omitted.
+func (h *UserHandler) RemoveAbsence(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		BadRequest(c, "user_id parameter is required")
+		return
+	}
+
+	var fromDate *time.Time
+	if raw := c.Query("from_date"); raw != "" {
+		parsed, err := time.Parse(domain.DateLayout, raw)
+		if err != nil {
+			BadRequest(c, "from_date must be in YYYY-MM-DD format")
+			return
+		}
+		fromDate = &parsed
+	}
+
+	if err := h.userService.RemoveAbsence(c.Request.Context(), userID, fromDate); err != nil {
+		if errors.Is(err, service.ErrAbsenceNotFound) {
+			NotFound(c, "absence not found")
+			return
+		}
+		InternalError(c, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "absence removed successfully"})
+}
+
+// AddExclusion handles POST /users/addExclusion.
+func (h *UserHandler) AddExclusion(c *gin.Context) {
+	var req ExclusionRequest
+
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	err := h.userService.AddExclusion(c.Request.Context(), domain.Exclusion{ReviewerID: req.ReviewerID, AuthorID: req.AuthorID})
+	if err != nil {
+		if errors.Is(err, service.ErrSelfExclusion) {
+			BadRequest(c, err.Error())
+			return
+		}
+		if errors.Is(err, service.ErrUserNotFound) {
+			NotFound(c, "user not found")
+			return
+		}
+		InternalError(c, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"exclusion": ExclusionResponse{ReviewerID: req.ReviewerID, AuthorID: req.AuthorID}})
+}
+
+// RemoveExclusion handles POST /users/removeExclusion.
+func (h *UserHandler) RemoveExclusion(c *gin.Context) {
+	var req ExclusionRequest
+
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	err := h.userService.RemoveExclusion(c.Request.Context(), domain.Exclusion{ReviewerID: req.ReviewerID, AuthorID: req.AuthorID})
+	if err != nil {
+		if errors.Is(err, service.ErrExclusionNotFound) {
+			NotFound(c, "exclusion not found")
+			return
+		}
+		InternalError(c, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "exclusion removed successfully"})
+}
+
 // GetReview handles GET /users/getReview.
 func (h *UserHandler) GetReview(c *gin.Context) {
 	userID := c.Query("user_id")
@@ -56,26 +322,162 @@ func (h *UserHandler) GetReview(c *gin.Context) {
 		return
 	}
 
-	prs, err := h.userService.GetUserReviews(userID)
+	var repositoryName *string
+	if name, ok := c.GetQuery("repository_name"); ok {
+		repositoryName = &name
+	}
+
+	prs, err := h.userService.GetUserReviews(c.Request.Context(), userID, repositoryName)
+	if err != nil {
+		InternalError(c, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, GetReviewResponse{
+		UserID:       userID,
+		PullRequests: toPRShortResponses(prs),
+	})
+}
+
+// GetReviewCount handles GET /users/getReviewCount.
+// Like GetReview, an unknown user is not an error: it has no reviews, so both counts are zero.
+func (h *UserHandler) GetReviewCount(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		BadRequest(c, "user_id parameter is required")
+		return
+	}
+
+	var repositoryName *string
+	if name, ok := c.GetQuery("repository_name"); ok {
+		repositoryName = &name
+	}
+
+	count, err := h.userService.GetUserReviewCount(c.Request.Context(), userID, repositoryName)
 	if err != nil {
 		InternalError(c, err.Error())
 		return
 	}
 
-	// Convert domain.PullRequestShort to response format
-	prResponses := make([]PRShortResponse, len(prs))
+	c.JSON(http.StatusOK, GetReviewCountResponse{
+		UserID:       userID,
+		OpenCount:    count.Open,
+		OverdueCount: count.Overdue,
+	})
+}
+
+// toPRShortResponses converts domain.PullRequestShort to response format, with the assignment if it is set.
+func toPRShortResponses(prs []domain.PullRequestShort) []PRShortResponse {
+	resp := make([]PRShortResponse, len(prs))
 	for i, p := range prs {
-		prResponses[i] = PRShortResponse{
+		resp[i] = PRShortResponse{
+			RepositoryName:  p.RepositoryName,
 			PullRequestID:   p.PullRequestID,
 			PullRequestName: p.PullRequestName,
 			AuthorID:        p.AuthorID,
 			TeamName:        p.TeamName,
 			Status:          string(p.Status),
 		}
+		if a := p.Assignment; a != nil {
+			resp[i].AssignedAt = a.AssignedAt.UTC().Format(time.RFC3339)
+			resp[i].HoursOpen = a.HoursOpen
+			resp[i].Overdue = a.Overdue
+		}
 	}
+	return resp
+}
 
-	c.JSON(http.StatusOK, GetReviewResponse{
-		UserID:       userID,
-		PullRequests: prResponses,
-	})
+// GetAuthored handles GET /users/getAuthored.
+// Optional status selects one PR status; limit and cursor page through the PRs, newest first.
+// offset is still accepted instead of cursor for clients that have not moved to cursors.
+func (h *UserHandler) GetAuthored(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		BadRequest(c, "user_id parameter is required")
+		return
+	}
+
+	var filter pr.AuthoredFilter
+	if raw, ok := c.GetQuery("status"); ok {
+		status, err := domain.NewPRStatus(raw)
+		if err != nil {
+			BadRequest(c, err.Error())
+			return
+		}
+		filter.Status = &status
+	}
+
+	filter.Limit = defaultAuthoredPageSize
+	if raw := c.Query("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > service.MaxAuthoredPageSize {
+			BadRequest(c, "limit must be an integer between 1 and "+strconv.Itoa(service.MaxAuthoredPageSize))
+			return
+		}
+		filter.Limit = n
+	}
+	if raw := c.Query("offset"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			BadRequest(c, "offset must be a non-negative integer")
+			return
+		}
+		filter.Offset = n
+	}
+	after, ok := parseCursorQuery(c)
+	if !ok {
+		return
+	}
+	if after != nil && filter.Offset > 0 {
+		BadRequest(c, "cursor and offset cannot be combined")
+		return
+	}
+	filter.After = after
+
+	page, err := h.userService.GetAuthoredPRs(c.Request.Context(), userID, filter)
+	if err != nil {
+		InternalError(c, err.Error())
+		return
+	}
+
+	response := GetAuthoredResponse{UserID: userID, Page: pagination.Map(page, func(p domain.PullRequest) PRResponse {
+		resp := domainToPRResponse(&p)
+		resp.Assignments = toAssignmentResponses(p.Assignments)
+		return *resp
+	})}
+	response.PullRequests = response.Items
+	if page.HasMore && after == nil {
+		response.NextOffset = filter.Offset + filter.Limit
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// domainToUserResponse converts domain.User to UserResponse.
+func domainToUserResponse(user *domain.User) *UserResponse {
+	return &UserResponse{
+		UserID:           user.UserID,
+		Username:         user.Username,
+		TeamName:         user.TeamName,
+		IsActive:         user.IsActive,
+		MaxOpenReviews:   user.MaxOpenReviews,
+		AssignmentWeight: user.AssignmentWeight,
+		Tags:             user.Tags,
+	}
+}
+
+// toReassignResultResponses converts service reassign results to response format.
+func toReassignResultResponses(results []service.ReassignResult) []ReassignResultResponse {
+	resp := make([]ReassignResultResponse, len(results))
+	for i, r := range results {
+		resp[i] = ReassignResultResponse{
+			RepositoryName: r.PR.RepositoryName,
+			PullRequestID:  r.PR.PullRequestID,
+			ReplacedBy:     r.ReplacedBy,
+		}
+		if r.ReplacedBy == "" {
+			resp[i].Error = ErrorNoCandidate
+		}
+	}
+	return resp
 }
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// entityIDPattern matches user, team member and pull request ids as published in the OpenAPI spec.
+var entityIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,100}$`)
+
+// orgIDPattern matches organization ids as published in the OpenAPI spec.
+var orgIDPattern = regexp.MustCompile(`^[a-z0-9_-]{1,64}$`)
+
+// tagPattern matches expertise tags of users and pull requests.
+var tagPattern = regexp.MustCompile(`^[a-z0-9_-]{1,64}$`)
+
+// init registers the custom rules on gin's validator and makes it report JSON field names.
+func init() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	v.RegisterTagNameFunc(jsonFieldName)
+	if err := v.RegisterValidation("entity_id", func(fl validator.FieldLevel) bool {
+		return entityIDPattern.MatchString(fl.Field().String())
+	}); err != nil {
+		panic(err)
+	}
+	if err := v.RegisterValidation("org_id", func(fl validator.FieldLevel) bool {
+		return orgIDPattern.MatchString(fl.Field().String())
+	}); err != nil {
+		panic(err)
+	}
+	if err := v.RegisterValidation("tag", func(fl validator.FieldLevel) bool {
+		return tagPattern.MatchString(fl.Field().String())
+	}); err != nil {
+		panic(err)
+	}
+}
+
+// jsonFieldName names struct fields by their json tag in validation errors.
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// isHTTPURL reports whether s passes the http_url rule.
+func isHTTPURL(s string) bool {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	return ok && v.Var(s, "http_url") == nil
+}
+
+// validationDetails converts validator errors to FieldErrors; ok is false for other errors.
+func validationDetails(err error) ([]FieldError, bool) {
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		return nil, false
+	}
+
+	details := make([]FieldError, len(errs))
+	for i, fe := range errs {
+		// Namespace starts with the request type name, e.g. "AddTeamRequest.members[1].user_id".
+		_, field, _ := strings.Cut(fe.Namespace(), ".")
+		details[i] = FieldError{Field: field, Rule: fe.Tag(), Message: validationMessage(fe)}
+	}
+	return details, true
+}
+
+// validationMessage describes a failed rule in words.
+func validationMessage(fe validator.FieldError) string {
+	isCollection := fe.Kind() == reflect.Slice || fe.Kind() == reflect.Array || fe.Kind() == reflect.Map
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "entity_id":
+		return "must be 1-100 characters of letters, digits, '.', '_' or '-'"
+	case "org_id", "tag":
+		return "must be 1-64 characters of lowercase letters, digits, '_' or '-'"
+	case "max":
+		if isCollection {
+			return fmt.Sprintf("must have at most %s items", fe.Param())
+		}
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("must be at most %s characters", fe.Param())
+		}
+		return fmt.Sprintf("must be at most %s", fe.Param())
+	case "min":
+		if isCollection {
+			return fmt.Sprintf("must have at least %s items", fe.Param())
+		}
+		return fmt.Sprintf("must be at least %s", fe.Param())
+	case "gt":
+		return fmt.Sprintf("must be greater than %s", fe.Param())
+	case "http_url":
+		return "must be an absolute http or https URL"
+	case "oneof":
+		return "must be one of " + strings.Join(strings.Fields(fe.Param()), ", ")
+	case "unique":
+		if fe.Param() == "" {
+			return "must not contain duplicates"
+		}
+		param := fe.Param()
+		if elem := fe.Type().Elem(); elem.Kind() == reflect.Struct {
+			if field, ok := elem.FieldByName(param); ok {
+				param = jsonFieldName(field)
+			}
+		}
+		return fmt.Sprintf("must not contain duplicate %s values", param)
+	default:
+		return fmt.Sprintf("failed the %s rule", fe.Tag())
+	}
+}
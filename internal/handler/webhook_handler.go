@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+)
+
+// WebhookHandler handles webhook subscription HTTP requests.
+type WebhookHandler struct {
+	webhookService WebhookServiceInterface
+}
+
+// NewWebhookHandler creates a new webhook handler.
+func NewWebhookHandler(webhookService WebhookServiceInterface) *WebhookHandler {
+	return &WebhookHandler{webhookService: webhookService}
+}
+
+// CreateWebhook handles POST /webhooks.
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	var req CreateWebhookRequest
+
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	webhook, err := h.webhookService.CreateWebhook(c.Request.Context(), req.URL, req.Secret)
+	if err != nil {
+		InternalError(c, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"webhook": domainToWebhookResponse(webhook)})
+}
+
+// DeleteWebhook handles DELETE /webhooks.
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	raw := c.Query("id")
+	if raw == "" {
+		BadRequest(c, "id parameter is required")
+		return
+	}
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || id < 1 {
+		BadRequest(c, "id must be a positive integer")
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhook(c.Request.Context(), id); err != nil {
+		if errors.Is(err, service.ErrWebhookNotFound) {
+			NotFound(c, "webhook not found")
+			return
+		}
+		InternalError(c, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "webhook removed successfully"})
+}
+
+// domainToWebhookResponse converts domain.Webhook to WebhookResponse.
+func domainToWebhookResponse(w *domain.Webhook) WebhookResponse {
+	return WebhookResponse{
+		ID:        w.ID,
+		URL:       w.URL,
+		CreatedAt: w.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
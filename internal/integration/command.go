@@ -0,0 +1,62 @@
+// Package integration translates pull request webhooks of VCS providers into provider-neutral commands.
+// Each provider adapter only parses its own payloads into a Command; the service executes commands
+// of every provider the same way, so adding a provider means adding a parser.
+// The package also holds the GitHub client the team membership sync reads organization teams with.
+package integration
+
+import (
+	"errors"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+)
+
+// Action is the pull request change a Command asks for.
+type Action string
+
+// Action constants.
+const (
+	ActionOpen  Action = "open"
+	ActionMerge Action = "merge"
+	ActionClose Action = "close"
+)
+
+var (
+	// ErrIgnoredEvent is returned by parsers for valid events that map to no command, e.g. pushes to an open pull request.
+	ErrIgnoredEvent = errors.New("event is not handled")
+	// ErrInvalidPayload is returned by parsers for payloads that cannot be translated.
+	ErrInvalidPayload = errors.New("invalid webhook payload")
+)
+
+// maxTitleLength is the longest pull request name the service stores.
+const maxTitleLength = 255
+
+// Command is a pull request change reported by a VCS provider.
+// Logins are the provider's ones; the service maps them to users through the external login table.
+type Command struct {
+	Provider string
+	Action   Action
+	Key      domain.PRKey
+	// Title, Description and ExternalURL are only used by ActionOpen.
+	Title       string
+	Description *string
+	ExternalURL *string
+	// ActorLogin made the change: the author for ActionOpen and the merger for ActionMerge.
+	ActorLogin string
+}
+
+// truncateTitle shortens title to maxTitleLength characters.
+func truncateTitle(title string) string {
+	runes := []rune(title)
+	if len(runes) <= maxTitleLength {
+		return title
+	}
+	return string(runes[:maxTitleLength])
+}
+
+// optional returns nil for an empty s.
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
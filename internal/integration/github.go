@@ -0,0 +1,153 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"time"
+)
+
+// ProviderGitHub names GitHub in external logins.
+const ProviderGitHub = "github"
+
+// DefaultGitHubAPIURL is the base URL of the public GitHub REST API.
+const DefaultGitHubAPIURL = "https://api.github.com"
+
+// gitHubPageSize is the number of items requested per page, the maximum the API allows.
+const gitHubPageSize = 100
+
+// GitHubTeam is a team of a GitHub organization with the logins of its direct and nested members.
+type GitHubTeam struct {
+	Slug    string
+	Name    string
+	Members []string
+}
+
+// GitHubClient lists the teams of the configured GitHub organization.
+// GitHubRESTClient implements it against the REST API.
+type GitHubClient interface {
+	ListTeams(ctx context.Context) ([]GitHubTeam, error)
+}
+
+// GitHubRESTClient is the GitHubClient calling the GitHub REST API with a token allowed to read
+// the organization's teams (read:org).
+type GitHubRESTClient struct {
+	baseURL string
+	token   string
+	org     string
+	client  *http.Client
+}
+
+// NewGitHubRESTClient creates a client for the organization's teams on the public GitHub API.
+func NewGitHubRESTClient(token, org string) *GitHubRESTClient {
+	return &GitHubRESTClient{
+		baseURL: DefaultGitHubAPIURL,
+		token:   token,
+		org:     org,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// WithBaseURL points the client to another API root, e.g. of GitHub Enterprise Server.
+func (c *GitHubRESTClient) WithBaseURL(baseURL string) *GitHubRESTClient {
+	c.baseURL = baseURL
+	return c
+}
+
+// WithHTTPClient sets the client used for requests; its Timeout bounds each request.
+func (c *GitHubRESTClient) WithHTTPClient(client *http.Client) *GitHubRESTClient {
+	c.client = client
+	return c
+}
+
+// gitHubTeam is the part of a team object the client uses.
+type gitHubTeam struct {
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+// gitHubMember is the part of a user object the client uses.
+type gitHubMember struct {
+	Login string `json:"login"`
+}
+
+// ListTeams returns every team of the organization with its members, following pagination.
+func (c *GitHubRESTClient) ListTeams(ctx context.Context) ([]GitHubTeam, error) {
+	var teams []gitHubTeam
+	if err := c.getAll(ctx, fmt.Sprintf("/orgs/%s/teams", url.PathEscape(c.org)), func(body []byte) (int, error) {
+		var page []gitHubTeam
+		if err := json.Unmarshal(body, &page); err != nil {
+			return 0, err
+		}
+		teams = append(teams, page...)
+		return len(page), nil
+	}); err != nil {
+		return nil, err
+	}
+
+	result := make([]GitHubTeam, 0, len(teams))
+	for _, t := range teams {
+		members := make([]string, 0)
+		path := fmt.Sprintf("/orgs/%s/teams/%s/members", url.PathEscape(c.org), url.PathEscape(t.Slug))
+		if err := c.getAll(ctx, path, func(body []byte) (int, error) {
+			var page []gitHubMember
+			if err := json.Unmarshal(body, &page); err != nil {
+				return 0, err
+			}
+			for _, m := range page {
+				members = append(members, m.Login)
+			}
+			return len(page), nil
+		}); err != nil {
+			return nil, err
+		}
+		result = append(result, GitHubTeam{Slug: t.Slug, Name: t.Name, Members: members})
+	}
+	return result, nil
+}
+
+// nextLink matches the URL of the next page in a Link response header.
+var nextLink = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// getAll requests path and every following page named by the Link header, passing each body to
+// decode, which returns the number of items on the page.
+func (c *GitHubRESTClient) getAll(ctx context.Context, path string, decode func(body []byte) (int, error)) error {
+	next := fmt.Sprintf("%s%s?per_page=%d", c.baseURL, path, gitHubPageSize)
+	for next != "" {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, next, nil)
+		if err != nil {
+			return fmt.Errorf("failed to build GitHub request: %w", err)
+		}
+		req.Header.Set("Accept", "application/vnd.github+json")
+		req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+		if c.token != "" {
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		}
+
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return fmt.Errorf("GitHub request %s failed: %w", path, err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read GitHub response %s: %w", path, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("GitHub request %s responded %s", path, resp.Status)
+		}
+		if _, err := decode(body); err != nil {
+			return fmt.Errorf("invalid GitHub response %s: %w", path, err)
+		}
+
+		next = ""
+		if m := nextLink.FindStringSubmatch(resp.Header.Get("Link")); m != nil {
+			next = m[1]
+		}
+	}
+	return nil
+}
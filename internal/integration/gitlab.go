@@ -0,0 +1,84 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+)
+
+// ProviderGitLab names GitLab in commands and external logins.
+const ProviderGitLab = "gitlab"
+
+// GitLab webhook headers.
+const (
+	// GitLabTokenHeader carries the secret token configured for the webhook in GitLab.
+	GitLabTokenHeader = "X-Gitlab-Token"
+	// GitLabEventHeader carries the event name, e.g. GitLabMergeRequestEvent.
+	GitLabEventHeader = "X-Gitlab-Event"
+)
+
+// GitLabMergeRequestEvent is the GitLabEventHeader value of merge request events.
+const GitLabMergeRequestEvent = "Merge Request Hook"
+
+// gitLabMergeRequestHook is the part of a GitLab Merge Request Hook payload the adapter uses.
+type gitLabMergeRequestHook struct {
+	ObjectKind string `json:"object_kind"`
+	User       struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+	ObjectAttributes struct {
+		IID         int64  `json:"iid"`
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		URL         string `json:"url"`
+		Action      string `json:"action"`
+	} `json:"object_attributes"`
+}
+
+// gitLabActions maps merge request actions to commands; other actions are ignored.
+var gitLabActions = map[string]Action{
+	"open":  ActionOpen,
+	"merge": ActionMerge,
+	"close": ActionClose,
+}
+
+// ParseGitLabMergeRequest translates a Merge Request Hook payload into a Command.
+// The pull request is keyed by the project path and the merge request IID; the user who
+// triggered the event is the author on open and the merger on merge.
+// Returns ErrIgnoredEvent for actions other than open, merge and close.
+func ParseGitLabMergeRequest(body []byte) (Command, error) {
+	var hook gitLabMergeRequestHook
+	if err := json.Unmarshal(body, &hook); err != nil {
+		return Command{}, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+	if hook.ObjectKind != "merge_request" {
+		return Command{}, fmt.Errorf("%w: object_kind %q is not merge_request", ErrInvalidPayload, hook.ObjectKind)
+	}
+
+	attrs := hook.ObjectAttributes
+	action, ok := gitLabActions[attrs.Action]
+	if !ok {
+		return Command{}, ErrIgnoredEvent
+	}
+	if attrs.IID <= 0 || hook.Project.PathWithNamespace == "" || hook.User.Username == "" {
+		return Command{}, fmt.Errorf("%w: merge request iid, project path and user are required", ErrInvalidPayload)
+	}
+
+	return Command{
+		Provider: ProviderGitLab,
+		Action:   action,
+		Key: domain.PRKey{
+			RepositoryName: hook.Project.PathWithNamespace,
+			PullRequestID:  strconv.FormatInt(attrs.IID, 10),
+		},
+		Title:       truncateTitle(attrs.Title),
+		Description: optional(attrs.Description),
+		ExternalURL: optional(attrs.URL),
+		ActorLogin:  hook.User.Username,
+	}, nil
+}
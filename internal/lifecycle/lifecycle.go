@@ -0,0 +1,181 @@
+// Package lifecycle starts the long-running components of the process in order and stops them
+// in reverse order, each within its own timeout.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Component is a part of the process with a start and a stop, such as a server or a worker.
+type Component interface {
+	// Start launches the component and returns once it runs; it must not block until the component ends.
+	Start(ctx context.Context) error
+	// Stop shuts the component down, giving up when ctx is done.
+	Stop(ctx context.Context) error
+}
+
+// Failing is implemented by components that can fail after starting, e.g. a server whose listener breaks.
+// A failure received on Errors is fatal: the manager reports it from Wait.
+type Failing interface {
+	Errors() <-chan error
+}
+
+// entry is a registered component.
+type entry struct {
+	name        string
+	component   Component
+	stopTimeout time.Duration
+}
+
+// Manager runs components registered with Add: Start starts them in registration order,
+// Wait blocks until a signal or the first fatal error, and Stop stops them in reverse order.
+type Manager struct {
+	entries []entry
+	started int
+
+	failed   chan error
+	failOnce sync.Once
+
+	stopOnce  sync.Once
+	stopError error
+}
+
+// New creates a manager without components.
+func New() *Manager {
+	return &Manager{failed: make(chan error, 1)}
+}
+
+// Add registers a component under name. Stop waits at most stopTimeout for it; components
+// registered later are stopped earlier, so dependencies such as the database are added first.
+func (m *Manager) Add(name string, c Component, stopTimeout time.Duration) *Manager {
+	m.entries = append(m.entries, entry{name: name, component: c, stopTimeout: stopTimeout})
+	return m
+}
+
+// Start starts the components in registration order. If one fails to start, the components
+// already started are stopped in reverse order and the start error is returned.
+func (m *Manager) Start(ctx context.Context) error {
+	for _, e := range m.entries {
+		if err := e.component.Start(ctx); err != nil {
+			startErr := fmt.Errorf("failed to start %s: %w", e.name, err)
+			return errors.Join(startErr, m.Stop())
+		}
+		m.started++
+
+		if f, ok := e.component.(Failing); ok {
+			go m.watch(e.name, f.Errors())
+		}
+	}
+	return nil
+}
+
+// watch forwards the first failure of a started component to Wait.
+func (m *Manager) watch(name string, errs <-chan error) {
+	err, ok := <-errs
+	if !ok || err == nil {
+		return
+	}
+	m.failOnce.Do(func() {
+		m.failed <- fmt.Errorf("%s failed: %w", name, err)
+	})
+}
+
+// Wait blocks until ctx is done, e.g. on SIGTERM, and returns nil, or until a started component
+// fails and returns its error. Either way the components keep running until Stop.
+func (m *Manager) Wait(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return nil
+	case err := <-m.failed:
+		return err
+	}
+}
+
+// Stop stops the started components in reverse registration order. Each gets its own stop timeout;
+// a component that has not stopped when it expires is left behind and the next one is stopped.
+// The errors of all components are joined. Calling Stop again returns the first result.
+func (m *Manager) Stop() error {
+	m.stopOnce.Do(func() {
+		var errs []error
+		for i := m.started - 1; i >= 0; i-- {
+			e := m.entries[i]
+			if err := stopWithin(e.component, e.stopTimeout); err != nil {
+				errs = append(errs, fmt.Errorf("failed to stop %s: %w", e.name, err))
+			}
+		}
+		m.stopError = errors.Join(errs...)
+	})
+	return m.stopError
+}
+
+// stopWithin stops c, returning the context error if it takes longer than timeout
+// even when c itself does not watch its context.
+func stopWithin(c Component, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- c.Stop(ctx) }()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Worker adapts a background loop to a Component. Start runs it in a goroutine;
+// Stop cancels its context and waits for it to return.
+func Worker(run func(ctx context.Context)) Component {
+	return &worker{run: run}
+}
+
+type worker struct {
+	run    func(ctx context.Context)
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func (w *worker) Start(context.Context) error {
+	// The worker outlives the start context; it runs until Stop.
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	w.done = make(chan struct{})
+	go func() {
+		defer close(w.done)
+		w.run(ctx)
+	}()
+	return nil
+}
+
+func (w *worker) Stop(ctx context.Context) error {
+	w.cancel()
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Closer adapts a resource, such as the database, to a Component closed on Stop.
+func Closer(c io.Closer) Component {
+	return OnStop(func(context.Context) error { return c.Close() })
+}
+
+// OnStop adapts a shutdown function, such as a tracer provider's Shutdown, to a Component
+// with nothing to start.
+func OnStop(stop func(ctx context.Context) error) Component {
+	return stopFunc(stop)
+}
+
+type stopFunc func(ctx context.Context) error
+
+func (f stopFunc) Start(context.Context) error { return nil }
+
+func (f stopFunc) Stop(ctx context.Context) error { return f(ctx) }
@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// CoverageSource counts, per organization, the open pull requests with fewer reviewers than their team's target.
+type CoverageSource interface {
+	UnderCoveredByOrg(ctx context.Context) (map[string]int64, error)
+}
+
+// CoverageCollector periodically copies the number of under-covered open pull requests
+// of every organization into a Prometheus gauge.
+type CoverageCollector struct {
+	source   CoverageSource
+	interval time.Duration
+	logger   *slog.Logger
+
+	underCovered *prometheus.GaugeVec
+}
+
+// NewCoverageCollector creates a collector sampling source every interval and registers its gauge
+// with reg. It panics if a gauge with the same name is already registered.
+func NewCoverageCollector(source CoverageSource, reg prometheus.Registerer, interval time.Duration, logger *slog.Logger) *CoverageCollector {
+	c := &CoverageCollector{
+		source:   source,
+		interval: interval,
+		logger:   logger,
+		underCovered: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "review",
+			Subsystem: "coverage",
+			Name:      "under_covered_pull_requests",
+			Help:      "Open pull requests with fewer reviewers than their team's reviewer count.",
+		}, []string{"org_id"}),
+	}
+	reg.MustRegister(c.underCovered)
+	return c
+}
+
+// Run samples the coverage right away and then every interval until ctx is cancelled.
+func (c *CoverageCollector) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		c.Sample(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sample updates the gauge from the current coverage once. Organizations that no longer exist
+// are dropped; on error the previous values are kept and the error is logged.
+func (c *CoverageCollector) Sample(ctx context.Context) {
+	counts, err := c.source.UnderCoveredByOrg(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			c.logger.Warn("Failed to sample reviewer coverage", "error", err)
+		}
+		return
+	}
+
+	c.underCovered.Reset()
+	for orgID, n := range counts {
+		c.underCovered.WithLabelValues(orgID).Set(float64(n))
+	}
+}
@@ -0,0 +1,93 @@
+// Package metrics exposes runtime measurements of the service as Prometheus metrics.
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Pool is the part of *sql.DB sampled by DBPoolCollector.
+type Pool interface {
+	Stats() sql.DBStats
+}
+
+// DBPoolCollector periodically copies the connection pool statistics into Prometheus gauges
+// and warns when requests had to wait for a free connection since the previous sample.
+type DBPoolCollector struct {
+	pool     Pool
+	interval time.Duration
+	logger   *slog.Logger
+
+	maxOpen      prometheus.Gauge
+	open         prometheus.Gauge
+	inUse        prometheus.Gauge
+	idle         prometheus.Gauge
+	waitCount    prometheus.Gauge
+	waitDuration prometheus.Gauge
+
+	lastWaitCount    int64
+	lastWaitDuration time.Duration
+}
+
+// NewDBPoolCollector creates a collector sampling pool every interval and registers its gauges
+// with reg. It panics if gauges with the same names are already registered.
+func NewDBPoolCollector(pool Pool, reg prometheus.Registerer, interval time.Duration, logger *slog.Logger) *DBPoolCollector {
+	gauge := func(name, help string) prometheus.Gauge {
+		return prometheus.NewGauge(prometheus.GaugeOpts{Namespace: "db", Subsystem: "pool", Name: name, Help: help})
+	}
+	c := &DBPoolCollector{
+		pool:         pool,
+		interval:     interval,
+		logger:       logger,
+		maxOpen:      gauge("max_open_connections", "Maximum number of open connections to the database."),
+		open:         gauge("open_connections", "Established connections, both in use and idle."),
+		inUse:        gauge("in_use_connections", "Connections currently in use."),
+		idle:         gauge("idle_connections", "Idle connections."),
+		waitCount:    gauge("wait_count", "Total number of connections waited for."),
+		waitDuration: gauge("wait_duration_seconds", "Total time blocked waiting for a new connection."),
+	}
+	reg.MustRegister(c.maxOpen, c.open, c.inUse, c.idle, c.waitCount, c.waitDuration)
+	return c
+}
+
+// Run samples the pool every interval until ctx is cancelled.
+func (c *DBPoolCollector) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.Sample()
+		}
+	}
+}
+
+// Sample updates the gauges from the current pool statistics once. A growing wait count means
+// the pool was exhausted since the previous sample and is logged as a warning.
+func (c *DBPoolCollector) Sample() {
+	stats := c.pool.Stats()
+	c.maxOpen.Set(float64(stats.MaxOpenConnections))
+	c.open.Set(float64(stats.OpenConnections))
+	c.inUse.Set(float64(stats.InUse))
+	c.idle.Set(float64(stats.Idle))
+	c.waitCount.Set(float64(stats.WaitCount))
+	c.waitDuration.Set(stats.WaitDuration.Seconds())
+
+	if waits := stats.WaitCount - c.lastWaitCount; waits > 0 {
+		c.logger.Warn("Database connection pool saturated",
+			"waits", waits,
+			"wait_duration", stats.WaitDuration-c.lastWaitDuration,
+			"in_use", stats.InUse,
+			"max_open", stats.MaxOpenConnections,
+		)
+	}
+	c.lastWaitCount = stats.WaitCount
+	c.lastWaitDuration = stats.WaitDuration
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+)
+
+// AnonymousActor is the audit actor of requests made without an API key.
+const AnonymousActor = "anonymous"
+
+// Audit attributes administrative actions performed while handling the request to its caller
+// and request id; services write the audit entries in the transactions of those actions.
+// It relies on RequestID and Authenticate running first.
+func Audit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actor := AnonymousActor
+		if apiKey := c.GetString(APIKeyContextKey); apiKey != "" {
+			actor = APIKeyActor(apiKey)
+		}
+		c.Request = c.Request.WithContext(service.WithAuditActor(c.Request.Context(), actor, GetRequestID(c)))
+		c.Next()
+	}
+}
+
+// APIKeyActor names an API key in the audit log by a prefix of its SHA-256 hash,
+// so the log identifies the key without storing it.
+func APIKeyActor(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return "api_key:" + hex.EncodeToString(sum[:6])
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+)
+
+// APIKeyHeader carries the caller's API key.
+const APIKeyHeader = "X-API-Key"
+
+// AdminContextKey is the context key set to true for requests authenticated with an admin API key.
+const AdminContextKey = handler.AdminContextKey
+
+// Authenticate checks the X-API-Key header against the admin API keys. A matching key is stored
+// under APIKeyContextKey and marks the request as admin; any other key is rejected with
+// 401 UNAUTHORIZED. Requests without the header pass through anonymously.
+func Authenticate(adminKeys []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(APIKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if !containsKey(adminKeys, key) {
+			handler.Error(c, handler.ErrorUnauthorized, "invalid API key", http.StatusUnauthorized)
+			c.Abort()
+			return
+		}
+		c.Set(APIKeyContextKey, key)
+		c.Set(AdminContextKey, true)
+		c.Next()
+	}
+}
+
+// RequireAdmin rejects requests not authenticated with an admin API key with 401 UNAUTHORIZED.
+// It relies on Authenticate running first.
+func RequireAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !c.GetBool(AdminContextKey) {
+			handler.Error(c, handler.ErrorUnauthorized, "admin API key required", http.StatusUnauthorized)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// containsKey compares key with every candidate in constant time.
+func containsKey(keys []string, key string) bool {
+	found := false
+	for _, k := range keys {
+		if subtle.ConstantTimeCompare([]byte(k), []byte(key)) == 1 {
+			found = true
+		}
+	}
+	return found
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+)
+
+// BodyLimit caps request bodies at maxBytes. Requests declaring a larger Content-Length are
+// rejected with 413 PAYLOAD_TOO_LARGE up front; other bodies are wrapped in http.MaxBytesReader
+// so that reading past the limit fails. A non-positive maxBytes disables the limit.
+func BodyLimit(maxBytes int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if maxBytes <= 0 || c.Request.Body == nil {
+			c.Next()
+			return
+		}
+		if c.Request.ContentLength > maxBytes {
+			handler.Error(c, handler.ErrorPayloadTooLarge,
+				fmt.Sprintf("request body exceeds %d bytes", maxBytes), http.StatusRequestEntityTooLarge)
+			c.Abort()
+			return
+		}
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
+		c.Next()
+	}
+}
@@ -0,0 +1,178 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+)
+
+// CircuitState is the state of a CircuitBreaker.
+type CircuitState string
+
+const (
+	// CircuitClosed lets all requests through.
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen rejects requests until the open timeout passes.
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen lets one probe request through at a time to test the database.
+	CircuitHalfOpen CircuitState = "half_open"
+)
+
+// Outcome is what a request admitted by a CircuitBreaker found out about the database.
+type Outcome int
+
+const (
+	// OutcomeSkipped means the request did not reach the database.
+	OutcomeSkipped Outcome = iota
+	// OutcomeSuccess means every statement of the request got an answer from the database.
+	OutcomeSuccess
+	// OutcomeFailure means the database was unavailable to at least one statement.
+	OutcomeFailure
+)
+
+// CircuitBreaker stops requests from waiting on an unavailable database.
+// After threshold consecutive requests failed to reach the database it opens and rejects requests
+// for openTimeout. Then it half-opens: a single probe request is let through, and its outcome
+// closes the breaker or opens it again. Requests that did not touch the database do not count.
+type CircuitBreaker struct {
+	threshold   int
+	openTimeout time.Duration
+	clock       service.Clock
+
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewCircuitBreaker creates a closed breaker opening after threshold consecutive failed requests
+// and half-opening openTimeout later.
+func NewCircuitBreaker(threshold int, openTimeout time.Duration, clock service.Clock) *CircuitBreaker {
+	return &CircuitBreaker{
+		threshold:   threshold,
+		openTimeout: openTimeout,
+		clock:       clock,
+		state:       CircuitClosed,
+	}
+}
+
+// State returns the current state. An open breaker whose timeout has passed reports CircuitHalfOpen.
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitOpen && b.clock.Now().Sub(b.openedAt) >= b.openTimeout {
+		return CircuitHalfOpen
+	}
+	return b.state
+}
+
+// Allow reports whether a request may proceed; if not, it also returns how long until the breaker
+// half-opens. Every admitted request must be followed by Record.
+func (b *CircuitBreaker) Allow() (bool, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		elapsed := b.clock.Now().Sub(b.openedAt)
+		if elapsed < b.openTimeout {
+			return false, b.openTimeout - elapsed
+		}
+		b.state = CircuitHalfOpen
+		b.probing = true
+		return true, 0
+	case CircuitHalfOpen:
+		if b.probing {
+			return false, 0
+		}
+		b.probing = true
+		return true, 0
+	default:
+		return true, 0
+	}
+}
+
+// Record reports the outcome of an admitted request.
+func (b *CircuitBreaker) Record(outcome Outcome) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch outcome {
+	case OutcomeSuccess:
+		if b.state != CircuitClosed {
+			log.Println("Database is reachable again, closing the circuit breaker")
+		}
+		b.state = CircuitClosed
+		b.failures = 0
+		b.probing = false
+	case OutcomeFailure:
+		b.failures++
+		if b.state == CircuitHalfOpen || (b.state == CircuitClosed && b.failures >= b.threshold) {
+			log.Printf("Database unavailable, failing requests fast for %s", b.openTimeout)
+			b.state = CircuitOpen
+			b.openedAt = b.clock.Now()
+		}
+		b.probing = false
+	default:
+		// A probe that did not reach the database proves nothing; let the next request probe.
+		b.probing = false
+	}
+}
+
+// Breaker rejects requests with 503 SERVICE_UNAVAILABLE and a Retry-After header in whole
+// seconds while the breaker is open, and records whether admitted requests could reach the
+// database, as reported by the repository statements they ran. A nil breaker disables it.
+func Breaker(breaker *CircuitBreaker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if breaker == nil {
+			c.Next()
+			return
+		}
+
+		ok, wait := breaker.Allow()
+		if !ok {
+			c.Header("Retry-After", strconv.Itoa(max(1, int(math.Ceil(wait.Seconds())))))
+			handler.Error(c, handler.ErrorServiceUnavailable, "database unavailable", http.StatusServiceUnavailable)
+			c.Abort()
+			return
+		}
+
+		var reached, failed atomic.Bool
+		ctx := repository.WithObserver(c.Request.Context(), func(err error) {
+			switch {
+			case errors.Is(err, context.Canceled):
+				// The client went away; the database was not asked.
+			case repository.IsUnavailable(err):
+				failed.Store(true)
+			default:
+				reached.Store(true)
+			}
+		})
+		c.Request = c.Request.WithContext(ctx)
+
+		outcome := OutcomeSkipped
+		defer func() { breaker.Record(outcome) }()
+		c.Next()
+
+		switch {
+		case failed.Load():
+			outcome = OutcomeFailure
+		case reached.Load():
+			outcome = OutcomeSuccess
+		}
+	}
+}
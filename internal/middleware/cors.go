@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CORSOptions configures cross-origin access for browser clients.
+type CORSOptions struct {
+	// AllowedOrigins lists origins (scheme://host[:port]) allowed to call the API; "*" allows any.
+	// Empty disables CORS: no headers are added and preflights are not answered.
+	AllowedOrigins []string
+	// AllowedMethods are announced in preflight responses.
+	AllowedMethods []string
+	// AllowedHeaders are the request headers announced in preflight responses.
+	AllowedHeaders []string
+	// MaxAge is how long browsers may cache a preflight response.
+	MaxAge time.Duration
+}
+
+// CORS adds CORS headers for configured origins and answers preflight requests with 204 on any path.
+// Disallowed origins get no CORS headers, so browsers block the response.
+func CORS(opts CORSOptions) gin.HandlerFunc {
+	if len(opts.AllowedOrigins) == 0 {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	}
+
+	anyOrigin := slices.Contains(opts.AllowedOrigins, "*")
+	methods := strings.Join(opts.AllowedMethods, ", ")
+	headers := strings.Join(opts.AllowedHeaders, ", ")
+	maxAge := strconv.Itoa(int(opts.MaxAge.Seconds()))
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			c.Next()
+			return
+		}
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+
+		c.Writer.Header().Add("Vary", "Origin")
+		allowed := anyOrigin || slices.Contains(opts.AllowedOrigins, origin)
+		if allowed {
+			if anyOrigin {
+				c.Header("Access-Control-Allow-Origin", "*")
+			} else {
+				c.Header("Access-Control-Allow-Origin", origin)
+			}
+			c.Header("Access-Control-Expose-Headers", RequestIDHeader+", "+ServerTimingHeader)
+		}
+
+		if !preflight {
+			c.Next()
+			return
+		}
+		if allowed {
+			c.Header("Access-Control-Allow-Methods", methods)
+			c.Header("Access-Control-Allow-Headers", headers)
+			c.Header("Access-Control-Max-Age", maxAge)
+		}
+		c.AbortWithStatus(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Gzip compresses responses of at least minSize bytes for clients that accept gzip.
+// Smaller responses are sent as is. Requests to excludedPaths are never compressed.
+// A non-positive minSize disables compression.
+func Gzip(minSize int, excludedPaths ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if minSize <= 0 || slices.Contains(excludedPaths, c.Request.URL.Path) {
+			c.Next()
+			return
+		}
+
+		// Caches must key compressible responses by Accept-Encoding even when this one is sent plain.
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+		if !acceptsGzip(c.GetHeader("Accept-Encoding")) {
+			c.Next()
+			return
+		}
+
+		w := &gzipWriter{ResponseWriter: c.Writer, minSize: minSize}
+		c.Writer = w
+		defer func() {
+			c.Writer = w.ResponseWriter
+			w.finish()
+		}()
+		c.Next()
+	}
+}
+
+// acceptsGzip reports whether an Accept-Encoding header allows gzip with a non-zero quality.
+func acceptsGzip(header string) bool {
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if coding = strings.TrimSpace(coding); coding != "gzip" && coding != "*" {
+			continue
+		}
+		q, ok := strings.CutPrefix(strings.TrimSpace(params), "q=")
+		if !ok {
+			return true
+		}
+		quality, err := strconv.ParseFloat(q, 64)
+		return err == nil && quality > 0
+	}
+	return false
+}
+
+// gzipWriter buffers the response until it reaches minSize, then switches to gzip.
+// Responses that stay below minSize are written uncompressed by finish.
+type gzipWriter struct {
+	gin.ResponseWriter
+
+	minSize int
+	buf     []byte
+	gz      *gzip.Writer
+	plain   bool
+}
+
+func (w *gzipWriter) Write(b []byte) (int, error) {
+	switch {
+	case w.gz != nil:
+		return w.gz.Write(b)
+	case w.plain:
+		return w.ResponseWriter.Write(b)
+	}
+
+	w.buf = append(w.buf, b...)
+	if len(w.buf) < w.minSize {
+		return len(b), nil
+	}
+
+	buffered := w.buf
+	w.buf = nil
+	if w.Header().Get("Content-Encoding") != "" {
+		// The handler encoded the body itself.
+		w.plain = true
+	} else {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Header().Del("Content-Length")
+		w.gz = gzip.NewWriter(w.ResponseWriter)
+	}
+	if _, err := w.Write(buffered); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+func (w *gzipWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *gzipWriter) Written() bool {
+	return len(w.buf) > 0 || w.ResponseWriter.Written()
+}
+
+// Flush sends compressed data written so far; a response still below minSize stays buffered.
+func (w *gzipWriter) Flush() {
+	if w.gz != nil {
+		_ = w.gz.Flush()
+	}
+	if w.gz != nil || w.plain {
+		w.ResponseWriter.Flush()
+	}
+}
+
+// finish completes the gzip stream or writes a small buffered body uncompressed.
+func (w *gzipWriter) finish() {
+	if w.gz != nil {
+		_ = w.gz.Close()
+		return
+	}
+	if len(w.buf) > 0 {
+		_, _ = w.ResponseWriter.Write(w.buf)
+	}
+}
@@ -0,0 +1,144 @@
+package middleware
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ServerTimingHeader reports how long the server took to produce the response.
+const ServerTimingHeader = "Server-Timing"
+
+// LatencyBudget is the time a request to a route may take before it counts as slow.
+// Routes are named by their path without the API version prefix, e.g. "/stats/export",
+// so a budget covers the versioned route and its legacy alias alike.
+type LatencyBudget struct {
+	defaultBudget time.Duration
+	routes        map[string]time.Duration
+	logger        *slog.Logger
+
+	slowRequests *prometheus.CounterVec
+}
+
+// NewLatencyBudget creates a budget of defaultBudget for every route except those in routes,
+// and registers the http_slow_requests_total counter with reg. It panics if a counter with the
+// same name is already registered.
+func NewLatencyBudget(defaultBudget time.Duration, routes map[string]time.Duration, reg prometheus.Registerer, logger *slog.Logger) *LatencyBudget {
+	b := &LatencyBudget{
+		defaultBudget: defaultBudget,
+		routes:        routes,
+		logger:        logger,
+		slowRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "http",
+			Name:      "slow_requests_total",
+			Help:      "Requests that took longer than the latency budget of their route.",
+		}, []string{"route"}),
+	}
+	reg.MustRegister(b.slowRequests)
+	return b
+}
+
+// Budget returns the latency budget of route.
+func (b *LatencyBudget) Budget(route string) time.Duration {
+	if d, ok := b.routes[route]; ok {
+		return d
+	}
+	return b.defaultBudget
+}
+
+// Latency measures every request and sends the total duration in the Server-Timing header,
+// e.g. "total;dur=12.5". Requests to a route taking longer than its budget are logged as warnings
+// with the request id and parameters and counted in http_slow_requests_total. Routes are looked up
+// with prefix removed from the path. A nil budget only sets the header; requests matching no route
+// are never counted.
+func Latency(budget *LatencyBudget, prefix string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		w := &serverTimingWriter{ResponseWriter: c.Writer, start: start}
+		c.Writer = w
+		defer func() { c.Writer = w.ResponseWriter }()
+
+		c.Next()
+
+		// Responses without a body are written by gin after the middleware returns.
+		w.stamp()
+		elapsed := time.Since(start)
+
+		if budget == nil || c.FullPath() == "" {
+			return
+		}
+		route := strings.TrimPrefix(c.FullPath(), prefix)
+		limit := budget.Budget(route)
+		if limit <= 0 || elapsed <= limit {
+			return
+		}
+		budget.slowRequests.WithLabelValues(route).Inc()
+		budget.logger.Warn("slow request",
+			slog.String("request_id", GetRequestID(c)),
+			slog.String("method", c.Request.Method),
+			slog.String("route", route),
+			slog.Any("params", requestParams(c)),
+			slog.Int("status", c.Writer.Status()),
+			slog.Float64("duration_ms", milliseconds(elapsed)),
+			slog.Float64("budget_ms", milliseconds(limit)),
+		)
+	}
+}
+
+// requestParams returns the path and query parameters of the request; repeated query
+// parameters keep their first value.
+func requestParams(c *gin.Context) map[string]string {
+	params := make(map[string]string)
+	for key, values := range c.Request.URL.Query() {
+		params[key] = values[0]
+	}
+	for _, p := range c.Params {
+		params[p.Key] = p.Value
+	}
+	return params
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// serverTimingWriter adds the Server-Timing header just before the response headers are sent.
+type serverTimingWriter struct {
+	gin.ResponseWriter
+
+	start   time.Time
+	stamped bool
+}
+
+// stamp sets the header with the time elapsed so far, unless it is set or the headers are sent.
+func (w *serverTimingWriter) stamp() {
+	if w.stamped || w.ResponseWriter.Written() {
+		return
+	}
+	w.stamped = true
+	w.Header().Set(ServerTimingHeader, fmt.Sprintf("total;dur=%.1f", milliseconds(time.Since(w.start))))
+}
+
+func (w *serverTimingWriter) WriteHeaderNow() {
+	w.stamp()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *serverTimingWriter) Write(b []byte) (int, error) {
+	w.stamp()
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *serverTimingWriter) WriteString(s string) (int, error) {
+	w.stamp()
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *serverTimingWriter) Flush() {
+	w.stamp()
+	w.ResponseWriter.Flush()
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+)
+
+// OrgHeader names the organization a request operates on; without it the request operates
+// on domain.DefaultOrgID.
+const OrgHeader = "X-Org-ID"
+
+// OrgResolver reports whether an organization exists.
+type OrgResolver interface {
+	OrgExists(ctx context.Context, orgID string) (bool, error)
+}
+
+// Org scopes the request to the organization named by the X-Org-ID header, so every statement
+// it runs reads and writes only that organization's data. An unknown organization is rejected
+// with 404 NOT_FOUND. With a nil resolver only the default organization is served.
+func Org(resolver OrgResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		orgID := c.GetHeader(OrgHeader)
+		if orgID == "" {
+			orgID = domain.DefaultOrgID
+		}
+
+		if orgID != domain.DefaultOrgID {
+			exists := false
+			if resolver != nil {
+				var err error
+				exists, err = resolver.OrgExists(c.Request.Context(), orgID)
+				if err != nil {
+					handler.InternalError(c, err.Error())
+					c.Abort()
+					return
+				}
+			}
+			if !exists {
+				handler.NotFound(c, "organization not found")
+				c.Abort()
+				return
+			}
+		}
+
+		c.Request = c.Request.WithContext(repository.WithOrg(c.Request.Context(), orgID))
+		c.Next()
+	}
+}
@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+)
+
+// APIKeyContextKey is the context key under which authentication stores the caller's API key.
+// RateLimit buckets such requests per key instead of per client IP.
+const APIKeyContextKey = "api_key"
+
+// RateLimiter is a set of token buckets, one per client key.
+// Each bucket holds up to burst tokens and refills at rate tokens per second.
+// Buckets idle long enough to refill completely are evicted, since a new bucket is identical.
+type RateLimiter struct {
+	rate  float64
+	burst float64
+	clock service.Clock
+
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+// tokenBucket is the state of one key at the time of its last request.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates a limiter allowing rate requests per second per key with bursts of burst.
+func NewRateLimiter(rate float64, burst int, clock service.Clock) *RateLimiter {
+	return &RateLimiter{
+		rate:      rate,
+		burst:     float64(burst),
+		clock:     clock,
+		buckets:   make(map[string]*tokenBucket),
+		lastSweep: clock.Now(),
+	}
+}
+
+// Allow takes a token from the key's bucket.
+// When the bucket is empty it returns false and how long until a token is available.
+func (l *RateLimiter) Allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+		return false, wait
+	}
+	b.tokens--
+	return true, 0
+}
+
+// Len returns the number of tracked keys.
+func (l *RateLimiter) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.buckets)
+}
+
+// sweep drops full buckets, at most once per refill period. Must be called with mu held.
+func (l *RateLimiter) sweep(now time.Time) {
+	refill := time.Duration(l.burst / l.rate * float64(time.Second))
+	if now.Sub(l.lastSweep) < refill {
+		return
+	}
+	for key, b := range l.buckets {
+		if now.Sub(b.last) >= refill {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+// RateLimit rejects requests over the limiter's rate with 429 RATE_LIMITED and a Retry-After
+// header in whole seconds. Requests are keyed by API key when authenticated, by client IP otherwise.
+// A nil limiter disables rate limiting.
+func RateLimit(limiter *RateLimiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if limiter == nil {
+			c.Next()
+			return
+		}
+
+		key := "ip:" + c.ClientIP()
+		if apiKey := c.GetString(APIKeyContextKey); apiKey != "" {
+			key = "key:" + apiKey
+		}
+
+		if ok, wait := limiter.Allow(key); !ok {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			handler.Error(c, handler.ErrorRateLimited, "rate limit exceeded", http.StatusTooManyRequests)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
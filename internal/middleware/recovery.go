@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"expvar"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+)
+
+// PanicsTotal counts requests that ended in a recovered panic; published as http_panics_total.
+var PanicsTotal = expvar.NewInt("http_panics_total")
+
+// Recovery recovers from panics in later handlers, logs the panic with the stack trace and
+// request id, counts it in PanicsTotal and responds with a 500 INTERNAL error.
+// Nothing is written if the handler had already started the response.
+func Recovery(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+			PanicsTotal.Add(1)
+			logger.Error("panic recovered",
+				slog.Any("panic", r),
+				slog.String("request_id", GetRequestID(c)),
+				slog.String("method", c.Request.Method),
+				slog.String("path", c.Request.URL.Path),
+				slog.String("stack", string(debug.Stack())),
+			)
+			c.Abort()
+			if !c.Writer.Written() {
+				handler.Error(c, handler.ErrorInternal, "internal server error", http.StatusInternalServerError)
+			}
+		}()
+		c.Next()
+	}
+}
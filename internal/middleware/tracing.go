@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/tracing"
+)
+
+// Tracing starts a server span per request, continuing the trace of an incoming traceparent header,
+// and stores it in the request context, so service and database spans become its children.
+// Spans are named after the method and route, e.g. "POST /api/v1/pullRequest/create".
+// A nil provider disables tracing.
+func Tracing(provider trace.TracerProvider) gin.HandlerFunc {
+	if provider == nil {
+		return func(c *gin.Context) { c.Next() }
+	}
+	return otelgin.Middleware(tracing.ServiceName,
+		otelgin.WithTracerProvider(provider),
+		otelgin.WithPropagators(tracing.Propagator()),
+	)
+}
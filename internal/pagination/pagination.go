@@ -0,0 +1,90 @@
+// Package pagination pages through lists ordered newest first by (created_at, id) with opaque cursors.
+// A cursor holds the sort key of the last item of a page, so the next page starts right after that item
+// however many rows were inserted or deleted in front of it, and is found through the index instead of
+// skipping rows like an offset.
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidCursor is returned for a cursor that was not produced by Encode.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor is the sort key of the last item of a page: its creation time and id.
+// The id is compared as the id expression of the query is, e.g. as a number for a serial column.
+type Cursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        string    `json:"id"`
+}
+
+// Encode returns the cursor as an opaque URL-safe string.
+func (c Cursor) Encode() string {
+	// Marshaling a time and a string cannot fail.
+	data, _ := json.Marshal(Cursor{CreatedAt: c.CreatedAt.UTC(), ID: c.ID})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Decode parses a cursor returned by Encode.
+// Returns ErrInvalidCursor if s is not one.
+func Decode(s string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.CreatedAt.IsZero() || c.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// Condition returns a WHERE condition selecting the rows that follow a cursor in the order
+// ORDER BY createdAt DESC, id DESC. The cursor is passed as parameters $n and $n+1, see Args;
+// without a cursor the condition selects every row.
+func Condition(createdAt, id string, n int) string {
+	return fmt.Sprintf("($%[3]d::TIMESTAMPTZ IS NULL OR %[1]s < $%[3]d OR (%[1]s = $%[3]d AND %[2]s < $%[4]d))",
+		createdAt, id, n, n+1)
+}
+
+// Args returns the query parameters of Condition for the cursor c, which may be nil.
+func Args(c *Cursor) []any {
+	if c == nil {
+		return []any{nil, nil}
+	}
+	return []any{c.CreatedAt.UTC(), c.ID}
+}
+
+// Page is the envelope of a list response. NextCursor is set when HasMore is.
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
+
+// NewPage makes a page of at most limit items from items, which were queried with a limit of
+// limit+1: an extra item means another page follows, starting after the cursor key returns
+// for the last item kept.
+func NewPage[T any](items []T, limit int, key func(T) Cursor) Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+	if len(items) <= limit {
+		return Page[T]{Items: items}
+	}
+	items = items[:limit]
+	return Page[T]{Items: items, NextCursor: key(items[limit-1]).Encode(), HasMore: true}
+}
+
+// Map converts the items of a page, keeping its cursor.
+func Map[T, U any](p Page[T], convert func(T) U) Page[U] {
+	items := make([]U, len(p.Items))
+	for i, item := range p.Items {
+		items[i] = convert(item)
+	}
+	return Page[U]{Items: items, NextCursor: p.NextCursor, HasMore: p.HasMore}
+}
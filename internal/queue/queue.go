@@ -0,0 +1,38 @@
+// Package queue sends messages to a message broker. Producer hides the broker, so the service
+// publishes events the same way whichever broker is configured; NATSProducer is the NATS JetStream one.
+package queue
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrClosed is returned by a producer used after Close.
+var ErrClosed = errors.New("producer is closed")
+
+// Message is a message for the broker.
+// Messages with the same Key are kept in the order they were sent; ID identifies the message
+// across redeliveries, so the broker can drop a duplicate.
+type Message struct {
+	Key   string
+	ID    string
+	Value []byte
+}
+
+// Producer sends messages to a broker.
+// Send returns only after the broker has stored the message, so an error means it may be lost.
+type Producer interface {
+	Send(ctx context.Context, msg Message) error
+	Close() error
+}
+
+// Compile-time interface checks.
+var _ Producer = (*NATSProducer)(nil)
+
+// errorOr returns err, or ctx's error if ctx ended first.
+func errorOr(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
@@ -0,0 +1,77 @@
+package absence
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+)
+
+// Create inserts a new absence window.
+func Create(exec repository.DBTX, a *domain.Absence) error {
+	exec = repository.Named(exec, "absence.Create")
+	query := `
+		INSERT INTO user_absences (user_id, from_date, to_date, org_id)
+		VALUES ($1, $2, $3, $4)
+	`
+	_, err := exec.Exec(query, a.UserID, a.FromDate.Format(domain.DateLayout), a.ToDate.Format(domain.DateLayout), repository.Org(exec))
+	if err != nil {
+		return fmt.Errorf("failed to create absence: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the user's absence starting at fromDate, or all user's absences when fromDate is nil.
+// Returns the number of removed absences.
+func Delete(exec repository.DBTX, userID string, fromDate *time.Time) (int64, error) {
+	exec = repository.Named(exec, "absence.Delete")
+	query := `DELETE FROM user_absences WHERE user_id = $1 AND org_id = $2`
+	args := []any{userID, repository.Org(exec)}
+	if fromDate != nil {
+		query += ` AND from_date = $3`
+		args = append(args, fromDate.Format(domain.DateLayout))
+	}
+
+	result, err := exec.Exec(query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete absence: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected, nil
+}
+
+// GetByUser returns all absences of a user ordered by start date.
+func GetByUser(exec repository.DBTX, userID string) ([]domain.Absence, error) {
+	exec = repository.Named(exec, "absence.GetByUser")
+	query := `
+		SELECT user_id, from_date, to_date
+		FROM user_absences
+		WHERE user_id = $1 AND org_id = $2
+		ORDER BY from_date
+	`
+	rows, err := exec.Query(query, userID, repository.Org(exec))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absences: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	absences := make([]domain.Absence, 0)
+	for rows.Next() {
+		var a domain.Absence
+		if err := rows.Scan(&a.UserID, &a.FromDate, &a.ToDate); err != nil {
+			return nil, fmt.Errorf("failed to scan absence: %w", err)
+		}
+		absences = append(absences, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return absences, nil
+}
@@ -0,0 +1,90 @@
+package audit
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/pagination"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+)
+
+// Insert appends an entry to the audit log and fills in its generated ID, organization and creation time.
+func Insert(exec repository.DBTX, e *domain.AuditEntry) error {
+	exec = repository.Named(exec, "audit.Insert")
+	query := `
+		INSERT INTO audit_log (actor, action, target, request_id, org_id)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING audit_id, created_at
+	`
+	e.OrgID = repository.Org(exec)
+	if err := exec.QueryRow(query, e.Actor, e.Action, e.Target, e.RequestID, e.OrgID).Scan(&e.ID, &e.CreatedAt); err != nil {
+		return fmt.Errorf("failed to record audit entry: %w", err)
+	}
+	return nil
+}
+
+// Filter selects audit entries. Nil bounds and an empty Actor are not applied.
+// From and To are inclusive. After continues a listing after the entry of Cursor; BeforeID selects
+// the entries below an ID instead and is kept only for older clients. Neither starts from the newest entry.
+type Filter struct {
+	From     *time.Time
+	To       *time.Time
+	Actor    string
+	After    *pagination.Cursor
+	BeforeID int64
+	Limit    int
+}
+
+// Cursor returns the cursor continuing a listing of List after e.
+func Cursor(e domain.AuditEntry) pagination.Cursor {
+	return pagination.Cursor{CreatedAt: e.CreatedAt, ID: strconv.FormatInt(e.ID, 10)}
+}
+
+// List returns at most f.Limit entries matching f, newest first. The audit log is shared by all
+// organizations and is not scoped to one.
+func List(exec repository.DBTX, f Filter) ([]domain.AuditEntry, error) {
+	exec = repository.Named(exec, "audit.List")
+	query := `
+		SELECT audit_id, org_id, actor, action, target, request_id, created_at
+		FROM audit_log
+		WHERE ($1::timestamptz IS NULL OR created_at >= $1)
+			AND ($2::timestamptz IS NULL OR created_at <= $2)
+			AND ($3 = '' OR actor = $3)
+			AND ($4 = 0 OR audit_id < $4)
+			AND ` + pagination.Condition("created_at", "audit_id", 6) + `
+		ORDER BY created_at DESC, audit_id DESC
+		LIMIT $5
+	`
+	args := append([]any{utcOrNil(f.From), utcOrNil(f.To), f.Actor, f.BeforeID, f.Limit}, pagination.Args(f.After)...)
+	rows, err := exec.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	entries := make([]domain.AuditEntry, 0)
+	for rows.Next() {
+		var e domain.AuditEntry
+		if err := rows.Scan(&e.ID, &e.OrgID, &e.Actor, &e.Action, &e.Target, &e.RequestID, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan audit entry: %w", err)
+		}
+		e.CreatedAt = e.CreatedAt.UTC()
+		entries = append(entries, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return entries, nil
+}
+
+// utcOrNil converts a bound to UTC, or returns nil if unset.
+func utcOrNil(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return t.UTC()
+}
@@ -3,25 +3,63 @@ package repository
 import (
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	_ "github.com/lib/pq" // PostgreSQL driver
 )
 
+// PoolOptions configures the connection pool and the server-side statement limit.
+type PoolOptions struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	// StatementTimeout makes PostgreSQL cancel any statement running longer; zero means no limit.
+	StatementTimeout time.Duration
+}
+
 // NewPostgresDB creates and returns a new PostgreSQL database connection.
-func NewPostgresDB(dsn string) (*sql.DB, error) {
-	db, err := sql.Open("postgres", dsn)
+func NewPostgresDB(dsn string, opts PoolOptions) (*sql.DB, error) {
+	db, err := OpenPostgresDB(dsn, opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open database connection: %w", err)
+		return nil, err
 	}
 
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(25)
-	db.SetConnMaxLifetime(5 * time.Minute)
-
 	if err = db.Ping(); err != nil {
+		_ = db.Close()
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
 	return db, nil
 }
+
+// OpenPostgresDB creates a connection pool without connecting, so a database that is down at
+// startup can be used once it comes up.
+func OpenPostgresDB(dsn string, opts PoolOptions) (*sql.DB, error) {
+	db, err := OpenTraced("postgres", withStatementTimeout(dsn, opts.StatementTimeout))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database connection: %w", err)
+	}
+
+	db.SetMaxOpenConns(opts.MaxOpenConns)
+	db.SetMaxIdleConns(opts.MaxIdleConns)
+	db.SetConnMaxLifetime(opts.ConnMaxLifetime)
+	return db, nil
+}
+
+// withStatementTimeout adds statement_timeout to the DSN. lib/pq sends unknown DSN parameters
+// to the server as run-time settings, so every pooled connection gets the limit.
+func withStatementTimeout(dsn string, timeout time.Duration) string {
+	if timeout <= 0 {
+		return dsn
+	}
+	ms := timeout.Milliseconds()
+	if strings.Contains(dsn, "://") {
+		sep := "?"
+		if strings.Contains(dsn, "?") {
+			sep = "&"
+		}
+		return fmt.Sprintf("%s%sstatement_timeout=%d", dsn, sep, ms)
+	}
+	return fmt.Sprintf("%s statement_timeout=%d", dsn, ms)
+}
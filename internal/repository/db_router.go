@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultReplicaCheckInterval is how long the result of a replica ping is trusted.
+	defaultReplicaCheckInterval = 5 * time.Second
+	// replicaPingTimeout bounds a replica ping so a hung replica does not stall reads.
+	replicaPingTimeout = time.Second
+)
+
+// DBRouter chooses the connection pool for read-only queries: the replica while it answers
+// pings, otherwise the primary. Writes and transactions always belong on the primary.
+// The replica is pinged by a read at most once per check interval; other reads meanwhile
+// use the result of the last ping.
+type DBRouter struct {
+	primary       *sql.DB
+	replica       *sql.DB
+	checkInterval time.Duration
+
+	mu        sync.Mutex
+	checking  bool
+	checkedAt time.Time
+	healthy   bool
+}
+
+// NewDBRouter creates a router over primary and replica. A nil replica sends all reads to primary.
+func NewDBRouter(primary, replica *sql.DB) *DBRouter {
+	return &DBRouter{primary: primary, replica: replica, checkInterval: defaultReplicaCheckInterval}
+}
+
+// WithCheckInterval sets how long the result of a replica ping is trusted; zero pings on every read.
+func (r *DBRouter) WithCheckInterval(interval time.Duration) *DBRouter {
+	r.checkInterval = interval
+	return r
+}
+
+// Reader returns the pool for a read-only query: the replica if it is healthy, otherwise the primary.
+func (r *DBRouter) Reader(ctx context.Context) *sql.DB {
+	if r.replica != nil && r.replicaHealthy(ctx) {
+		return r.replica
+	}
+	return r.primary
+}
+
+// replicaHealthy reports the result of the last replica ping, pinging again once it is older
+// than the check interval. Concurrent callers do not wait for a ping in progress.
+func (r *DBRouter) replicaHealthy(ctx context.Context) bool {
+	r.mu.Lock()
+	if r.checking || (!r.checkedAt.IsZero() && time.Since(r.checkedAt) < r.checkInterval) {
+		healthy := r.healthy
+		r.mu.Unlock()
+		return healthy
+	}
+	r.checking = true
+	wasHealthy := r.healthy || r.checkedAt.IsZero()
+	r.mu.Unlock()
+
+	// The ping outlives a cancelled request: its result is shared with the reads that follow.
+	pingCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), replicaPingTimeout)
+	err := r.replica.PingContext(pingCtx)
+	cancel()
+
+	r.mu.Lock()
+	r.checking = false
+	r.checkedAt = time.Now()
+	r.healthy = err == nil
+	r.mu.Unlock()
+
+	if err != nil && wasHealthy {
+		log.Printf("Database replica unavailable, reading from the primary: %v", err)
+	}
+	if err == nil && !wasHealthy {
+		log.Println("Database replica is back, reading from it again")
+	}
+	return err == nil
+}
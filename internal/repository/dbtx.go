@@ -1,6 +1,9 @@
 package repository
 
-import "database/sql"
+import (
+	"context"
+	"database/sql"
+)
 
 // DBTX is a common interface for *sql.DB and *sql.Tx.
 // Both types implement the same methods for executing SQL queries.
@@ -8,6 +11,7 @@ type DBTX interface {
 	Exec(query string, args ...any) (sql.Result, error)
 	Query(query string, args ...any) (*sql.Rows, error)
 	QueryRow(query string, args ...any) *sql.Row
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
 }
 
 // Compile-time check that *sql.DB and *sql.Tx implement DBTX.
@@ -0,0 +1,120 @@
+package digest
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+)
+
+// Set creates the team's digest schedule, or replaces its hour, timezone and LastSentAt.
+func Set(exec repository.DBTX, schedule *domain.DigestSchedule) error {
+	exec = repository.Named(exec, "digest.Set")
+	query := `
+		INSERT INTO team_digests (team_name, hour, timezone, last_sent_at, org_id)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (org_id, team_name)
+		DO UPDATE SET hour = EXCLUDED.hour, timezone = EXCLUDED.timezone, last_sent_at = EXCLUDED.last_sent_at
+	`
+	_, err := exec.Exec(query, schedule.TeamName, schedule.Hour, schedule.Timezone, schedule.LastSentAt.UTC(), repository.Org(exec))
+	if err != nil {
+		return fmt.Errorf("failed to set digest schedule: %w", err)
+	}
+	return nil
+}
+
+// Get returns the team's digest schedule.
+// Returns repository.ErrNotFound if the team has none.
+func Get(exec repository.DBTX, teamName string) (*domain.DigestSchedule, error) {
+	exec = repository.Named(exec, "digest.Get")
+	query := `SELECT team_name, hour, timezone, last_sent_at FROM team_digests WHERE team_name = $1 AND org_id = $2`
+	return get(exec, query, teamName)
+}
+
+// GetForUpdate returns the team's digest schedule and locks it until the end of the transaction,
+// so that a digest is compiled and marked sent by one instance at a time.
+// Returns repository.ErrNotFound if the team has none.
+func GetForUpdate(exec repository.DBTX, teamName string) (*domain.DigestSchedule, error) {
+	exec = repository.Named(exec, "digest.GetForUpdate")
+	query := `SELECT team_name, hour, timezone, last_sent_at FROM team_digests WHERE team_name = $1 AND org_id = $2 FOR UPDATE`
+	return get(exec, query, teamName)
+}
+
+func get(exec repository.DBTX, query, teamName string) (*domain.DigestSchedule, error) {
+	var s domain.DigestSchedule
+	err := exec.QueryRow(query, teamName, repository.Org(exec)).Scan(&s.TeamName, &s.Hour, &s.Timezone, &s.LastSentAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("digest of team %s: %w", teamName, repository.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get digest schedule: %w", err)
+	}
+	s.LastSentAt = s.LastSentAt.UTC()
+	return &s, nil
+}
+
+// Scheduled is a digest schedule with the organization of its team.
+type Scheduled struct {
+	OrgID    string
+	Schedule domain.DigestSchedule
+}
+
+// ListAll returns the digest schedules of all organizations ordered by organization and team.
+// Unlike other queries it spans all organizations; each schedule carries its own.
+func ListAll(exec repository.DBTX) ([]Scheduled, error) {
+	exec = repository.Named(exec, "digest.ListAll")
+	query := `
+		SELECT org_id, team_name, hour, timezone, last_sent_at
+		FROM team_digests
+		ORDER BY org_id, team_name
+	`
+	rows, err := exec.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list digest schedules: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var schedules []Scheduled
+	for rows.Next() {
+		var s Scheduled
+		if err := rows.Scan(&s.OrgID, &s.Schedule.TeamName, &s.Schedule.Hour, &s.Schedule.Timezone, &s.Schedule.LastSentAt); err != nil {
+			return nil, fmt.Errorf("failed to scan digest schedule: %w", err)
+		}
+		s.Schedule.LastSentAt = s.Schedule.LastSentAt.UTC()
+		schedules = append(schedules, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return schedules, nil
+}
+
+// MarkSent records that the team's digest was sent at sentAt.
+func MarkSent(exec repository.DBTX, teamName string, sentAt time.Time) error {
+	exec = repository.Named(exec, "digest.MarkSent")
+	query := `UPDATE team_digests SET last_sent_at = $1 WHERE team_name = $2 AND org_id = $3`
+	if _, err := exec.Exec(query, sentAt.UTC(), teamName, repository.Org(exec)); err != nil {
+		return fmt.Errorf("failed to mark digest sent: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the team's digest schedule.
+// Returns the number of removed schedules.
+func Delete(exec repository.DBTX, teamName string) (int64, error) {
+	exec = repository.Named(exec, "digest.Delete")
+	query := `DELETE FROM team_digests WHERE team_name = $1 AND org_id = $2`
+	result, err := exec.Exec(query, teamName, repository.Org(exec))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete digest schedule: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected, nil
+}
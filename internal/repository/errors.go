@@ -1,11 +1,25 @@
 package repository
 
 import (
+	"context"
+	"database/sql/driver"
 	"errors"
+	"io"
+	"net"
 
 	"github.com/lib/pq"
 )
 
+// Sentinel errors returned by the repository packages. Callers check them with errors.Is;
+// repositories wrap them with the identifier that was looked up.
+var (
+	// ErrNotFound is returned when the requested row does not exist
+	// or does not match the state an update expects.
+	ErrNotFound = errors.New("not found")
+	// ErrConflict is returned when an insert collides with an existing row.
+	ErrConflict = errors.New("already exists")
+)
+
 // IsUniqueViolation checks if the error is a PostgreSQL unique constraint violation.
 // PostgreSQL error code 23505 = unique_violation.
 func IsUniqueViolation(err error) bool {
@@ -25,3 +39,35 @@ func IsForeignKeyViolation(err error) bool {
 	}
 	return false
 }
+
+// IsRetryable checks if the error is a transient PostgreSQL failure after which
+// the whole transaction can be retried.
+// PostgreSQL error codes 40001 = serialization_failure, 40P01 = deadlock_detected.
+func IsRetryable(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == "40001" || pqErr.Code == "40P01"
+	}
+	return false
+}
+
+// IsUnavailable checks if the error means the database could not be reached or lost the
+// connection, rather than rejecting the statement: network errors, broken connections
+// and PostgreSQL errors of class 08 (connection_exception) and 57P01-57P03
+// (admin_shutdown, crash_shutdown, cannot_connect_now).
+// An expired context deadline is not: a slow statement says nothing about the connection.
+func IsUnavailable(err error) bool {
+	// context.DeadlineExceeded is a net.Error too, so it has to be ruled out first.
+	if err == nil || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code.Class() == "08" || pqErr.Code == "57P01" || pqErr.Code == "57P02" || pqErr.Code == "57P03"
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
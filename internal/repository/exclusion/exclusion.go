@@ -0,0 +1,50 @@
+package exclusion
+
+import (
+	"fmt"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+)
+
+// Create inserts a reviewer exclusion. Does nothing if it already exists.
+func Create(exec repository.DBTX, e *domain.Exclusion) error {
+	exec = repository.Named(exec, "exclusion.Create")
+	query := `
+		INSERT INTO reviewer_exclusions (reviewer_id, author_id, org_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (org_id, reviewer_id, author_id) DO NOTHING
+	`
+	_, err := exec.Exec(query, e.ReviewerID, e.AuthorID, repository.Org(exec))
+	if err != nil {
+		return fmt.Errorf("failed to create exclusion: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a reviewer exclusion.
+// Returns the number of removed exclusions.
+func Delete(exec repository.DBTX, e *domain.Exclusion) (int64, error) {
+	exec = repository.Named(exec, "exclusion.Delete")
+	query := `DELETE FROM reviewer_exclusions WHERE reviewer_id = $1 AND author_id = $2 AND org_id = $3`
+	result, err := exec.Exec(query, e.ReviewerID, e.AuthorID, repository.Org(exec))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete exclusion: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected, nil
+}
+
+// DeleteByUser removes every exclusion naming the user as reviewer or author.
+func DeleteByUser(exec repository.DBTX, userID string) error {
+	exec = repository.Named(exec, "exclusion.DeleteByUser")
+	query := `DELETE FROM reviewer_exclusions WHERE (reviewer_id = $1 OR author_id = $1) AND org_id = $2`
+	if _, err := exec.Exec(query, userID, repository.Org(exec)); err != nil {
+		return fmt.Errorf("failed to delete exclusions: %w", err)
+	}
+	return nil
+}
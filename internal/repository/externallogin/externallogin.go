@@ -0,0 +1,54 @@
+package externallogin
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+)
+
+// Set maps the provider login to the user, replacing an existing mapping of the login.
+// Returns repository.ErrNotFound if the user doesn't exist.
+func Set(exec repository.DBTX, l *domain.ExternalLogin) error {
+	exec = repository.Named(exec, "externallogin.Set")
+	query := `
+		INSERT INTO external_logins (provider, external_login, user_id, org_id)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (org_id, provider, external_login) DO UPDATE SET user_id = EXCLUDED.user_id
+	`
+	_, err := exec.Exec(query, l.Provider, l.Login, l.UserID, repository.Org(exec))
+	if err != nil {
+		if repository.IsForeignKeyViolation(err) {
+			return fmt.Errorf("user %s: %w", l.UserID, repository.ErrNotFound)
+		}
+		return fmt.Errorf("failed to set external login: %w", err)
+	}
+	return nil
+}
+
+// GetUserID returns the user the provider login is mapped to.
+// Returns repository.ErrNotFound if the login is not mapped.
+func GetUserID(exec repository.DBTX, provider, login string) (string, error) {
+	exec = repository.Named(exec, "externallogin.GetUserID")
+	var userID string
+	query := `SELECT user_id FROM external_logins WHERE provider = $1 AND external_login = $2 AND org_id = $3`
+	err := exec.QueryRow(query, provider, login, repository.Org(exec)).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fmt.Errorf("%s login %s: %w", provider, login, repository.ErrNotFound)
+		}
+		return "", fmt.Errorf("failed to get external login: %w", err)
+	}
+	return userID, nil
+}
+
+// DeleteByUser removes every provider login mapped to the user.
+func DeleteByUser(exec repository.DBTX, userID string) error {
+	exec = repository.Named(exec, "externallogin.DeleteByUser")
+	if _, err := exec.Exec(`DELETE FROM external_logins WHERE user_id = $1 AND org_id = $2`, userID, repository.Org(exec)); err != nil {
+		return fmt.Errorf("failed to delete external logins: %w", err)
+	}
+	return nil
+}
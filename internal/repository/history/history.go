@@ -0,0 +1,76 @@
+package history
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+)
+
+// Record appends an event to the assignment history.
+// Empty user IDs and a nil decision are stored as NULL.
+func Record(exec repository.DBTX, event *domain.AssignmentEvent) error {
+	exec = repository.Named(exec, "history.Record")
+	var decision any
+	if event.Decision != nil {
+		encoded, err := json.Marshal(event.Decision)
+		if err != nil {
+			return fmt.Errorf("failed to encode assignment decision: %w", err)
+		}
+		decision = encoded
+	}
+
+	query := `
+		INSERT INTO assignment_history (repository_name, pull_request_id, action, old_user_id, new_user_id, decision, org_id)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, $7)
+	`
+	_, err := exec.Exec(query, event.RepositoryName, event.PullRequestID, event.Action, event.OldUserID, event.NewUserID, decision, repository.Org(exec))
+	if err != nil {
+		return fmt.Errorf("failed to record assignment event: %w", err)
+	}
+	return nil
+}
+
+// GetByPR returns the assignment history of a pull request, oldest first.
+func GetByPR(exec repository.DBTX, key domain.PRKey) ([]domain.AssignmentEvent, error) {
+	exec = repository.Named(exec, "history.GetByPR")
+	query := `
+		SELECT repository_name, pull_request_id, action, old_user_id, new_user_id, decision, created_at
+		FROM assignment_history
+		WHERE repository_name = $1 AND pull_request_id = $2 AND org_id = $3
+		ORDER BY assignment_history_id
+	`
+	rows, err := exec.Query(query, key.RepositoryName, key.PullRequestID, repository.Org(exec))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assignment history: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	events := make([]domain.AssignmentEvent, 0)
+	for rows.Next() {
+		var e domain.AssignmentEvent
+		var oldUserID, newUserID sql.NullString
+		var decision []byte
+		if err := rows.Scan(&e.RepositoryName, &e.PullRequestID, &e.Action, &oldUserID, &newUserID, &decision, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan assignment event: %w", err)
+		}
+		e.OldUserID = oldUserID.String
+		e.NewUserID = newUserID.String
+		if decision != nil {
+			e.Decision = &domain.AssignmentDecision{}
+			if err := json.Unmarshal(decision, e.Decision); err != nil {
+				return nil, fmt.Errorf("failed to decode assignment decision: %w", err)
+			}
+		}
+		e.CreatedAt = repository.UTC(e.CreatedAt)
+		events = append(events, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,18 @@
+package repository
+
+import "context"
+
+type observerKey struct{}
+
+// WithObserver returns a context in which every statement run through WithContext, as well as
+// the begin and commit of WithTx, reports its error to fn, or nil when it succeeded.
+func WithObserver(ctx context.Context, fn func(err error)) context.Context {
+	return context.WithValue(ctx, observerKey{}, fn)
+}
+
+// observe reports err to the observer of ctx, if any.
+func observe(ctx context.Context, err error) {
+	if fn, ok := ctx.Value(observerKey{}).(func(error)); ok {
+		fn(err)
+	}
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+)
+
+// orgContextKey is the context key of the organization set by WithOrg.
+type orgContextKey struct{}
+
+// WithOrg returns a copy of ctx scoping the statements run with it to the organization orgID.
+// An empty orgID means domain.DefaultOrgID.
+func WithOrg(ctx context.Context, orgID string) context.Context {
+	return context.WithValue(ctx, orgContextKey{}, orgID)
+}
+
+// OrgFromContext returns the organization set by WithOrg, or domain.DefaultOrgID if there is none.
+func OrgFromContext(ctx context.Context) string {
+	if orgID, _ := ctx.Value(orgContextKey{}).(string); orgID != "" {
+		return orgID
+	}
+	return domain.DefaultOrgID
+}
+
+// Org returns the organization the statements of exec are scoped to: the one of the context exec
+// was bound to with WithContext, or domain.DefaultOrgID for an unbound exec.
+// Repository functions pass it with every statement touching organization data.
+func Org(exec DBTX) string {
+	if bound, ok := exec.(boundDB); ok {
+		return OrgFromContext(bound.ctx)
+	}
+	return domain.DefaultOrgID
+}
@@ -0,0 +1,67 @@
+package organization
+
+import (
+	"fmt"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+)
+
+// Create inserts a new organization and fills in its creation time.
+// Returns repository.ErrConflict if the organization already exists.
+func Create(exec repository.DBTX, o *domain.Organization) error {
+	exec = repository.Named(exec, "organization.Create")
+	query := `
+		INSERT INTO organizations (org_id, name)
+		VALUES ($1, $2)
+		RETURNING created_at
+	`
+	if err := exec.QueryRow(query, o.OrgID, o.Name).Scan(&o.CreatedAt); err != nil {
+		if repository.IsUniqueViolation(err) {
+			return fmt.Errorf("organization %s: %w", o.OrgID, repository.ErrConflict)
+		}
+		return fmt.Errorf("failed to create organization: %w", err)
+	}
+	return nil
+}
+
+// Exists checks if an organization exists.
+func Exists(exec repository.DBTX, orgID string) (bool, error) {
+	exec = repository.Named(exec, "organization.Exists")
+	var exists bool
+	query := `SELECT EXISTS(SELECT 1 FROM organizations WHERE org_id = $1)`
+	if err := exec.QueryRow(query, orgID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check organization existence: %w", err)
+	}
+	return exists, nil
+}
+
+// List returns all organizations ordered by ID.
+func List(exec repository.DBTX) ([]domain.Organization, error) {
+	exec = repository.Named(exec, "organization.List")
+	query := `
+		SELECT org_id, name, created_at
+		FROM organizations
+		ORDER BY org_id
+	`
+	rows, err := exec.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	orgs := make([]domain.Organization, 0)
+	for rows.Next() {
+		var o domain.Organization
+		if err := rows.Scan(&o.OrgID, &o.Name, &o.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan organization: %w", err)
+		}
+		orgs = append(orgs, o)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return orgs, nil
+}
@@ -0,0 +1,139 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+)
+
+// dispatcherLockKey is the PostgreSQL advisory lock key held while the outbox is being processed
+// ("outbox" in ASCII).
+const dispatcherLockKey = 0x6f7574626f78
+
+// Entry is a pending outbox row.
+// Event.ID and Event.OccurredAt are filled from the row's ID and creation time.
+type Entry struct {
+	ID       int64
+	Attempts int
+	Event    domain.Event
+}
+
+// Insert stores the event for publishing; call it in the transaction of the change the event describes.
+// The event's ID and OccurredAt are ignored and assigned by the outbox; its OrgID is set to the
+// organization of exec. The outbox itself is shared by all organizations.
+func Insert(exec repository.DBTX, event domain.Event) error {
+	exec = repository.Named(exec, "outbox.Insert")
+	event.OrgID = repository.Org(exec)
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	query := `
+		INSERT INTO event_outbox (event_type, repository_name, pull_request_id, payload)
+		VALUES ($1, $2, $3, $4)
+	`
+	_, err = exec.Exec(query, event.Type, event.RepositoryName, event.PullRequestID, payload)
+	if err != nil {
+		return fmt.Errorf("failed to insert outbox event: %w", err)
+	}
+	return nil
+}
+
+// GetPending returns up to limit events that are neither published nor given up on, oldest first.
+func GetPending(exec repository.DBTX, limit int) ([]Entry, error) {
+	exec = repository.Named(exec, "outbox.GetPending")
+	query := `
+		SELECT event_id, attempts, payload, created_at
+		FROM event_outbox
+		WHERE published_at IS NULL AND failed_at IS NULL
+		ORDER BY event_id
+		LIMIT $1
+	`
+	rows, err := exec.Query(query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var entries []Entry
+	for rows.Next() {
+		var e Entry
+		var payload []byte
+		var createdAt time.Time
+		if err := rows.Scan(&e.ID, &e.Attempts, &payload, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox event: %w", err)
+		}
+		if err := json.Unmarshal(payload, &e.Event); err != nil {
+			return nil, fmt.Errorf("failed to decode outbox event %d: %w", e.ID, err)
+		}
+		e.Event.ID = strconv.FormatInt(e.ID, 10)
+		e.Event.OccurredAt = createdAt.UTC()
+		entries = append(entries, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return entries, nil
+}
+
+// MarkPublished records that every publisher accepted the event.
+func MarkPublished(exec repository.DBTX, id int64) error {
+	exec = repository.Named(exec, "outbox.MarkPublished")
+	_, err := exec.Exec(`UPDATE event_outbox SET published_at = NOW() WHERE event_id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark event %d published: %w", id, err)
+	}
+	return nil
+}
+
+// MarkAttemptFailed counts a failed publishing attempt and keeps its error.
+// With giveUp the event is no longer returned by GetPending.
+func MarkAttemptFailed(exec repository.DBTX, id int64, lastError string, giveUp bool) error {
+	exec = repository.Named(exec, "outbox.MarkAttemptFailed")
+	query := `
+		UPDATE event_outbox
+		SET attempts = attempts + 1,
+			last_error = $2,
+			failed_at = CASE WHEN $3 THEN NOW() END
+		WHERE event_id = $1
+	`
+	_, err := exec.Exec(query, id, lastError, giveUp)
+	if err != nil {
+		return fmt.Errorf("failed to record attempt of event %d: %w", id, err)
+	}
+	return nil
+}
+
+// TryLock takes the session-level advisory lock that lets a single dispatcher process the outbox,
+// so that instances running side by side do not publish the same events out of order.
+// Returns ok = false if another session holds it; otherwise unlock must be called when done.
+func TryLock(ctx context.Context, db *sql.DB) (unlock func(), ok bool, err error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get connection: %w", err)
+	}
+
+	if err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1)`, dispatcherLockKey).Scan(&ok); err != nil {
+		_ = conn.Close()
+		return nil, false, fmt.Errorf("failed to take outbox lock: %w", err)
+	}
+	if !ok {
+		_ = conn.Close()
+		return nil, false, nil
+	}
+
+	return func() {
+		// Unlock with a fresh context: ctx may already be cancelled at shutdown.
+		_, _ = conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, dispatcherLockKey)
+		_ = conn.Close()
+	}, true, nil
+}
@@ -0,0 +1,70 @@
+package ownership
+
+import (
+	"fmt"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+)
+
+// Set creates the ownership rule, or replaces the owner of the team's rule for the same prefix.
+func Set(exec repository.DBTX, rule *domain.OwnershipRule) error {
+	exec = repository.Named(exec, "ownership.Set")
+	query := `
+		INSERT INTO ownership_rules (team_name, path_prefix, owner_user_id, owner_team_name, org_id)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), $5)
+		ON CONFLICT (org_id, team_name, path_prefix)
+		DO UPDATE SET owner_user_id = EXCLUDED.owner_user_id, owner_team_name = EXCLUDED.owner_team_name
+	`
+	_, err := exec.Exec(query, rule.TeamName, rule.PathPrefix, rule.OwnerUserID, rule.OwnerTeamName, repository.Org(exec))
+	if err != nil {
+		return fmt.Errorf("failed to set ownership rule: %w", err)
+	}
+	return nil
+}
+
+// ListByTeam returns the team's ownership rules ordered by path prefix.
+func ListByTeam(exec repository.DBTX, teamName string) ([]domain.OwnershipRule, error) {
+	exec = repository.Named(exec, "ownership.ListByTeam")
+	query := `
+		SELECT team_name, path_prefix, COALESCE(owner_user_id, ''), COALESCE(owner_team_name, '')
+		FROM ownership_rules
+		WHERE team_name = $1 AND org_id = $2
+		ORDER BY path_prefix
+	`
+	rows, err := exec.Query(query, teamName, repository.Org(exec))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ownership rules: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	rules := make([]domain.OwnershipRule, 0)
+	for rows.Next() {
+		var r domain.OwnershipRule
+		if err := rows.Scan(&r.TeamName, &r.PathPrefix, &r.OwnerUserID, &r.OwnerTeamName); err != nil {
+			return nil, fmt.Errorf("failed to scan ownership rule: %w", err)
+		}
+		rules = append(rules, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return rules, nil
+}
+
+// Delete removes the team's ownership rule for the prefix.
+// Returns the number of removed rules.
+func Delete(exec repository.DBTX, teamName, pathPrefix string) (int64, error) {
+	exec = repository.Named(exec, "ownership.Delete")
+	query := `DELETE FROM ownership_rules WHERE team_name = $1 AND path_prefix = $2 AND org_id = $3`
+	result, err := exec.Exec(query, teamName, pathPrefix, repository.Org(exec))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete ownership rule: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected, nil
+}
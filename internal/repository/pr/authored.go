@@ -0,0 +1,131 @@
+package pr
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/pagination"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+)
+
+// AuthoredFilter selects and pages the pull requests of an author.
+// A nil Status is not applied. After continues a listing after the pull request of AuthoredCursor;
+// Offset skips that many pull requests of the ordering instead and is kept only for older clients.
+type AuthoredFilter struct {
+	Status *domain.PRStatus
+	Limit  int
+	After  *pagination.Cursor
+	Offset int
+}
+
+// authoredID orders authored pull requests created at the same time; pull request ids contain no '/',
+// so it differs for every pull request.
+const authoredID = `p.repository_name || '/' || p.pull_request_id`
+
+// AuthoredCursor returns the cursor continuing a listing of GetByAuthor after p, which must be one of its results.
+func AuthoredCursor(p domain.PullRequest) pagination.Cursor {
+	return pagination.Cursor{CreatedAt: *p.CreatedAt, ID: p.RepositoryName + "/" + p.PullRequestID}
+}
+
+// GetByAuthor returns at most f.Limit pull requests authored by the user, newest first, with the fields
+// of pr.Get: assigned and approved reviewers and their assignments are in the same order.
+func GetByAuthor(exec repository.DBTX, authorID string, f AuthoredFilter) ([]domain.PullRequest, error) {
+	exec = repository.Named(exec, "pr.GetByAuthor")
+	// Assignment times are aggregated as epoch seconds, since pq scans
+	// arrays only into types implementing sql.Scanner.
+	query := `
+		SELECT p.repository_name, p.pull_request_id, p.pull_request_name, p.author_id, p.team_name, p.status,
+		       p.created_at, p.merged_at, p.merged_by, p.closed_at, p.description, p.external_url, p.size, p.lines_changed, p.reassignment_count,
+		       ARRAY(
+		           SELECT pt.tag FROM pr_tags pt
+		           WHERE pt.org_id = p.org_id AND pt.repository_name = p.repository_name AND pt.pull_request_id = p.pull_request_id
+		           ORDER BY pt.tag
+		       ),
+		       COALESCE(t.review_sla_hours, 0),
+		       COALESCE(array_agg(rev.user_id ORDER BY rev.assigned_at, rev.user_id) FILTER (WHERE rev.user_id IS NOT NULL), '{}'),
+		       COALESCE(array_agg(rev.source ORDER BY rev.assigned_at, rev.user_id) FILTER (WHERE rev.user_id IS NOT NULL), '{}'),
+		       COALESCE(array_agg(EXTRACT(EPOCH FROM rev.assigned_at) ORDER BY rev.assigned_at, rev.user_id) FILTER (WHERE rev.user_id IS NOT NULL), '{}'),
+		       COALESCE(array_agg(rev.approved_at IS NOT NULL ORDER BY rev.assigned_at, rev.user_id) FILTER (WHERE rev.user_id IS NOT NULL), '{}')
+		FROM pull_requests p
+		LEFT JOIN pr_reviewers rev ON rev.org_id = p.org_id AND rev.repository_name = p.repository_name AND rev.pull_request_id = p.pull_request_id
+		LEFT JOIN teams t ON t.org_id = p.org_id AND t.team_name = p.team_name
+		WHERE p.author_id = $1 AND p.org_id = $2 AND ($3::VARCHAR IS NULL OR p.status = $3)
+			AND ` + pagination.Condition("p.created_at", authoredID, 6) + `
+		GROUP BY p.org_id, p.repository_name, p.pull_request_id, t.review_sla_hours
+		ORDER BY p.created_at DESC, ` + authoredID + ` DESC
+		LIMIT $4 OFFSET $5
+	`
+	var status *string
+	if f.Status != nil {
+		s := string(*f.Status)
+		status = &s
+	}
+	args := append([]any{authorID, repository.Org(exec), status, f.Limit, f.Offset}, pagination.Args(f.After)...)
+	rows, err := exec.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get authored pull requests: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	prs := make([]domain.PullRequest, 0)
+	for rows.Next() {
+		var p domain.PullRequest
+		var mergedBy, size sql.NullString
+		var reviewSLAHours int
+		var reviewers, sources pq.StringArray
+		var assignedAt pq.Float64Array
+		var approved pq.BoolArray
+		if err := rows.Scan(&p.RepositoryName, &p.PullRequestID, &p.PullRequestName, &p.AuthorID, &p.TeamName, &p.Status,
+			&p.CreatedAt, &p.MergedAt, &mergedBy, &p.ClosedAt, &p.Description, &p.ExternalURL, &size, &p.LinesChanged, &p.ReassignmentCount,
+			pq.Array(&p.Tags), &reviewSLAHours, &reviewers, &sources, &assignedAt, &approved); err != nil {
+			return nil, fmt.Errorf("failed to scan pull request: %w", err)
+		}
+		p.MergedBy = mergedBy.String
+		p.Size = domain.PRSize(size.String)
+		p.CreatedAt, p.MergedAt, p.ClosedAt = repository.UTC(p.CreatedAt), repository.UTC(p.MergedAt), repository.UTC(p.ClosedAt)
+
+		p.AssignedReviewersIDs = []string(reviewers)
+		p.Assignments = make([]domain.ReviewerAssignment, len(reviewers))
+		for i, userID := range reviewers {
+			p.Assignments[i] = domain.ReviewerAssignment{
+				UserID:         userID,
+				Source:         domain.ReviewerSource(sources[i]),
+				AssignedAt:     time.UnixMicro(int64(math.Round(assignedAt[i] * 1e6))).UTC(),
+				Approved:       approved[i],
+				ReviewSLAHours: reviewSLAHours,
+			}
+			if approved[i] {
+				p.ApprovedReviewersIDs = append(p.ApprovedReviewersIDs, userID)
+			}
+		}
+		prs = append(prs, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return prs, nil
+}
+
+// CountCreatedSince returns how many pull requests the user authored at or after since and when the
+// oldest of them was created; the time is zero if there are none.
+func CountCreatedSince(exec repository.DBTX, authorID string, since time.Time) (int, time.Time, error) {
+	exec = repository.Named(exec, "pr.CountCreatedSince")
+	query := `
+		SELECT COUNT(*), MIN(created_at)
+		FROM pull_requests
+		WHERE org_id = $1 AND author_id = $2 AND created_at >= $3
+	`
+	var count int
+	var oldest sql.NullTime
+	if err := exec.QueryRow(query, repository.Org(exec), authorID, since).Scan(&count, &oldest); err != nil {
+		return 0, time.Time{}, fmt.Errorf("failed to count authored pull requests: %w", err)
+	}
+	return count, oldest.Time, nil
+}
@@ -1,34 +1,39 @@
 package pr
 
 import (
+	"database/sql"
 	"fmt"
 
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
 	"github.com/mishasvintus/avito_backend_internship/internal/repository"
 )
 
-// GetOpenPRsWithReviewersFromTeam returns open PRs that have at least one reviewer from the specified team.
-// Map: prID -> list of reviewer IDs from that team.
-func GetOpenPRsWithReviewersFromTeam(exec repository.DBTX, teamName string) (map[string][]string, error) {
+// GetOpenPRsWithReviewersFromTeam returns open PRs that have at least one reviewer who is a member of the specified team.
+// Map: PR key -> list of reviewer IDs from that team, in assignment order.
+func GetOpenPRsWithReviewersFromTeam(exec repository.DBTX, teamName string) (map[domain.PRKey][]string, error) {
+	exec = repository.Named(exec, "pr.GetOpenPRsWithReviewersFromTeam")
 	query := `
-		SELECT pr.pull_request_id, rev.user_id
+		SELECT pr.repository_name, pr.pull_request_id, rev.user_id
 		FROM pull_requests pr
-		JOIN pr_reviewers rev ON pr.pull_request_id = rev.pull_request_id
-		JOIN users u ON rev.user_id = u.user_id
-		WHERE pr.status = 'OPEN' AND u.team_name = $1
+		JOIN pr_reviewers rev ON pr.org_id = rev.org_id AND pr.repository_name = rev.repository_name AND pr.pull_request_id = rev.pull_request_id
+		JOIN team_memberships m ON rev.org_id = m.org_id AND rev.user_id = m.user_id
+		WHERE pr.status = 'OPEN' AND m.team_name = $1 AND m.org_id = $2
+		ORDER BY rev.assigned_at, rev.user_id
 	`
-	rows, err := exec.Query(query, teamName)
+	rows, err := exec.Query(query, teamName, repository.Org(exec))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get open PRs with reviewers from team: %w", err)
 	}
 	defer func() { _ = rows.Close() }()
 
-	byPR := make(map[string][]string)
+	byPR := make(map[domain.PRKey][]string)
 	for rows.Next() {
-		var prID, reviewerID string
-		if err := rows.Scan(&prID, &reviewerID); err != nil {
+		var key domain.PRKey
+		var reviewerID string
+		if err := rows.Scan(&key.RepositoryName, &key.PullRequestID, &reviewerID); err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
-		byPR[prID] = append(byPR[prID], reviewerID)
+		byPR[key] = append(byPR[key], reviewerID)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -37,3 +42,85 @@ func GetOpenPRsWithReviewersFromTeam(exec repository.DBTX, teamName string) (map
 
 	return byPR, nil
 }
+
+// GetOpenByTeam returns open PRs the team is responsible for, ordered by key,
+// with author, assigned and required reviewers filled in; reviewers are in the order of pr.Get.
+func GetOpenByTeam(exec repository.DBTX, teamName string) ([]domain.PullRequest, error) {
+	exec = repository.Named(exec, "pr.GetOpenByTeam")
+	query := `
+		SELECT pr.repository_name, pr.pull_request_id, pr.author_id, rev.user_id, COALESCE(rev.source = 'required', false)
+		FROM pull_requests pr
+		LEFT JOIN pr_reviewers rev ON pr.org_id = rev.org_id AND pr.repository_name = rev.repository_name AND pr.pull_request_id = rev.pull_request_id
+		WHERE pr.status = 'OPEN' AND pr.team_name = $1 AND pr.org_id = $2
+		ORDER BY pr.repository_name, pr.pull_request_id, rev.assigned_at, rev.user_id
+	`
+	rows, err := exec.Query(query, teamName, repository.Org(exec))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open PRs of team: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var prs []domain.PullRequest
+	for rows.Next() {
+		var p domain.PullRequest
+		var reviewerID sql.NullString
+		var required bool
+		if err := rows.Scan(&p.RepositoryName, &p.PullRequestID, &p.AuthorID, &reviewerID, &required); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		if n := len(prs); n == 0 || prs[n-1].Key() != p.Key() {
+			p.TeamName = teamName
+			p.Status = domain.StatusOpen
+			prs = append(prs, p)
+		}
+		if reviewerID.Valid {
+			last := &prs[len(prs)-1]
+			last.AssignedReviewersIDs = append(last.AssignedReviewersIDs, reviewerID.String)
+			if required {
+				last.RequiredReviewersIDs = append(last.RequiredReviewersIDs, reviewerID.String)
+			}
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return prs, nil
+}
+
+// GetUnderCovered returns the open PRs with fewer reviewers than their team's reviewer_count, oldest first;
+// teams without one, or no longer existing, use defaultTarget. An empty teamName covers all teams.
+func GetUnderCovered(exec repository.DBTX, teamName string, defaultTarget int) ([]domain.PRKey, error) {
+	exec = repository.Named(exec, "pr.GetUnderCovered")
+	query := `
+		SELECT p.repository_name, p.pull_request_id
+		FROM pull_requests p
+		LEFT JOIN teams t ON t.org_id = p.org_id AND t.team_name = p.team_name
+		LEFT JOIN pr_reviewers rev ON rev.org_id = p.org_id AND rev.repository_name = p.repository_name AND rev.pull_request_id = p.pull_request_id
+		WHERE p.org_id = $1 AND p.status = 'OPEN' AND ($2 = '' OR p.team_name = $2)
+		GROUP BY p.org_id, p.repository_name, p.pull_request_id, p.created_at, t.reviewer_count
+		HAVING COUNT(rev.user_id) < COALESCE(t.reviewer_count, $3)
+		ORDER BY p.created_at, p.repository_name, p.pull_request_id
+	`
+	rows, err := exec.Query(query, repository.Org(exec), teamName, defaultTarget)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get under-covered PRs: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	keys := make([]domain.PRKey, 0)
+	for rows.Next() {
+		var key domain.PRKey
+		if err := rows.Scan(&key.RepositoryName, &key.PullRequestID); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return keys, nil
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/lib/pq"
+
 	"github.com/mishasvintus/avito_backend_internship/internal/domain"
 	"github.com/mishasvintus/avito_backend_internship/internal/repository"
 )
@@ -13,40 +15,163 @@ import (
 // ErrReviewerNotAssigned is returned when the reviewer to replace is not assigned to the PR.
 var ErrReviewerNotAssigned = errors.New("reviewer is not assigned to this PR")
 
-// Create inserts a new pull request.
+// Create inserts a new pull request, created at pr.CreatedAt or, if nil, now.
+// Returns repository.ErrConflict if a pull request with the same ID exists in the same repository.
 func Create(exec repository.DBTX, pr *domain.PullRequest) error {
+	exec = repository.Named(exec, "pr.Create")
 	query := `
-		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, team_name, status, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO pull_requests (repository_name, pull_request_id, pull_request_name, author_id, team_name, status, created_at, description, external_url, size, lines_changed, org_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, ''), $11, $12)
 	`
-	now := time.Now()
-	_, err := exec.Exec(query, pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.TeamName, pr.Status, now)
+	createdAt := time.Now().UTC()
+	if pr.CreatedAt != nil {
+		createdAt = pr.CreatedAt.UTC()
+	}
+	_, err := exec.Exec(query, pr.RepositoryName, pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.TeamName, pr.Status, createdAt,
+		pr.Description, pr.ExternalURL, string(pr.Size), pr.LinesChanged, repository.Org(exec))
 	if err != nil {
+		if repository.IsUniqueViolation(err) {
+			return fmt.Errorf("pull request %s: %w", pr.Key(), repository.ErrConflict)
+		}
 		return fmt.Errorf("failed to create pull request: %w", err)
 	}
 	return nil
 }
 
-// InsertReviewer assigns a reviewer to a pull request.
-func InsertReviewer(exec repository.DBTX, prID, userID string) error {
-	query := `INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES ($1, $2)`
-	_, err := exec.Exec(query, prID, userID)
+// InsertTags adds expertise tags to a pull request.
+func InsertTags(exec repository.DBTX, key domain.PRKey, tags []string) error {
+	exec = repository.Named(exec, "pr.InsertTags")
+	query := `INSERT INTO pr_tags (repository_name, pull_request_id, tag, org_id) VALUES ($1, $2, $3, $4)`
+	for _, tag := range tags {
+		if _, err := exec.Exec(query, key.RepositoryName, key.PullRequestID, tag, repository.Org(exec)); err != nil {
+			return fmt.Errorf("failed to insert pull request tag: %w", err)
+		}
+	}
+	return nil
+}
+
+// InsertReviewer assigns a reviewer picked from the author's team to a pull request at assignedAt.
+func InsertReviewer(exec repository.DBTX, key domain.PRKey, userID string, assignedAt time.Time) error {
+	return InsertReviewerFrom(exec, key, userID, domain.SourceAuto, assignedAt)
+}
+
+// InsertRequiredReviewer assigns a reviewer the PR's creator asked for.
+// Unlike other reviewers, required ones are never moved by rebalancing.
+func InsertRequiredReviewer(exec repository.DBTX, key domain.PRKey, userID string, assignedAt time.Time) error {
+	return InsertReviewerFrom(exec, key, userID, domain.SourceRequired, assignedAt)
+}
+
+// InsertReviewerFrom assigns a reviewer to a pull request at assignedAt, recording why it was chosen.
+func InsertReviewerFrom(exec repository.DBTX, key domain.PRKey, userID string, source domain.ReviewerSource, assignedAt time.Time) error {
+	exec = repository.Named(exec, "pr.InsertReviewerFrom")
+	query := `INSERT INTO pr_reviewers (repository_name, pull_request_id, user_id, source, org_id, assigned_at) VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err := exec.Exec(query, key.RepositoryName, key.PullRequestID, userID, source, repository.Org(exec), assignedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert reviewer: %w", err)
+	}
+	return nil
+}
+
+// InsertActiveReviewers assigns reviewers[i] with sources[i] to a pull request at assignedAt in one statement,
+// skipping users who are not active or erased. The user rows are locked until the end of the transaction.
+// Returns how many reviewers were assigned; fewer than len(reviewers) means some were skipped.
+func InsertActiveReviewers(exec repository.DBTX, key domain.PRKey, reviewers []string, sources []domain.ReviewerSource, assignedAt time.Time) (int, error) {
+	exec = repository.Named(exec, "pr.InsertActiveReviewers")
+	sourceNames := make([]string, len(sources))
+	for i, source := range sources {
+		sourceNames[i] = string(source)
+	}
+	query := `
+		INSERT INTO pr_reviewers (repository_name, pull_request_id, user_id, source, org_id, assigned_at)
+		SELECT $1, $2, r.user_id, r.source, u.org_id, $6
+		FROM unnest($3::text[], $4::text[]) AS r(user_id, source)
+		JOIN users u ON u.org_id = $5 AND u.user_id = r.user_id
+		WHERE u.is_active = true AND u.erased_at IS NULL
+		FOR SHARE OF u
+	`
+	result, err := exec.Exec(query, key.RepositoryName, key.PullRequestID, pq.Array(reviewers), pq.Array(sourceNames), repository.Org(exec), assignedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert reviewers: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return int(rowsAffected), nil
+}
+
+// InsertActiveReviewer assigns a reviewer picked from the author's team at assignedAt, checking in the same statement
+// that the user is still active, not erased and not a member of excludedTeam (none if empty). The user row
+// is locked until the end of the transaction so that it cannot be deactivated before the assignment commits.
+// Returns repository.ErrNotFound if the user no longer qualifies.
+func InsertActiveReviewer(exec repository.DBTX, key domain.PRKey, userID, excludedTeam string, assignedAt time.Time) error {
+	exec = repository.Named(exec, "pr.InsertActiveReviewer")
+	query := `
+		INSERT INTO pr_reviewers (repository_name, pull_request_id, user_id, source, org_id, assigned_at)
+		SELECT $1, $2, u.user_id, $4, u.org_id, $7
+		FROM users u
+		WHERE u.user_id = $3 AND u.org_id = $5
+		  AND u.is_active = true
+		  AND u.erased_at IS NULL
+		  AND NOT EXISTS (
+			SELECT 1 FROM team_memberships e
+			WHERE e.org_id = u.org_id AND e.user_id = u.user_id AND e.team_name = $6
+		  )
+		FOR SHARE OF u
+	`
+	result, err := exec.Exec(query, key.RepositoryName, key.PullRequestID, userID, domain.SourceAuto, repository.Org(exec), excludedTeam, assignedAt)
 	if err != nil {
 		return fmt.Errorf("failed to insert reviewer: %w", err)
 	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("reviewer %s: %w", userID, repository.ErrNotFound)
+	}
 	return nil
 }
 
-// Get retrieves a pull request by ID with all assigned reviewers.
-func Get(exec repository.DBTX, prID string) (*domain.PullRequest, error) {
+// Lock locks the pull request row until the end of the transaction, so that reads of its
+// reviewers in the transaction are not raced by changes that lock the row first.
+// Returns repository.ErrNotFound if the pull request doesn't exist.
+func Lock(exec repository.DBTX, key domain.PRKey) error {
+	exec = repository.Named(exec, "pr.Lock")
+	query := `SELECT 1 FROM pull_requests WHERE repository_name = $1 AND pull_request_id = $2 AND org_id = $3 FOR UPDATE`
+	var one int
+	err := exec.QueryRow(query, key.RepositoryName, key.PullRequestID, repository.Org(exec)).Scan(&one)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("pull request %s: %w", key, repository.ErrNotFound)
+		}
+		return fmt.Errorf("failed to lock pull request: %w", err)
+	}
+	return nil
+}
+
+// Get retrieves a pull request by ID with all assigned reviewers, their assignments and approvals, and its tags.
+// Returns repository.ErrNotFound if the pull request doesn't exist.
+func Get(exec repository.DBTX, key domain.PRKey) (*domain.PullRequest, error) {
+	exec = repository.Named(exec, "pr.Get")
 	// Get PR details
 	query := `
-		SELECT pull_request_id, pull_request_name, author_id, team_name, status, created_at, merged_at
-		FROM pull_requests
-		WHERE pull_request_id = $1
+		SELECT repository_name, pull_request_id, pull_request_name, author_id, team_name, status, created_at, merged_at, merged_by, closed_at, description, external_url, size, lines_changed, reassignment_count,
+		       ARRAY(
+		           SELECT t.tag FROM pr_tags t
+		           WHERE t.org_id = p.org_id AND t.repository_name = p.repository_name AND t.pull_request_id = p.pull_request_id
+		           ORDER BY t.tag
+		       ),
+		       COALESCE((SELECT tm.review_sla_hours FROM teams tm WHERE tm.org_id = p.org_id AND tm.team_name = p.team_name), 0)
+		FROM pull_requests p
+		WHERE repository_name = $1 AND pull_request_id = $2 AND org_id = $3
 	`
+	orgID := repository.Org(exec)
 	var p domain.PullRequest
-	err := exec.QueryRow(query, prID).Scan(
+	var mergedBy, size sql.NullString
+	var reviewSLAHours int
+	err := exec.QueryRow(query, key.RepositoryName, key.PullRequestID, orgID).Scan(
+		&p.RepositoryName,
 		&p.PullRequestID,
 		&p.PullRequestName,
 		&p.AuthorID,
@@ -54,33 +179,52 @@ func Get(exec repository.DBTX, prID string) (*domain.PullRequest, error) {
 		&p.Status,
 		&p.CreatedAt,
 		&p.MergedAt,
+		&mergedBy,
+		&p.ClosedAt,
+		&p.Description,
+		&p.ExternalURL,
+		&size,
+		&p.LinesChanged,
+		&p.ReassignmentCount,
+		pq.Array(&p.Tags),
+		&reviewSLAHours,
 	)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, err
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("pull request %s: %w", key, repository.ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to get pull request: %w", err)
 	}
+	p.MergedBy = mergedBy.String
+	p.Size = domain.PRSize(size.String)
+	p.CreatedAt, p.MergedAt, p.ClosedAt = repository.UTC(p.CreatedAt), repository.UTC(p.MergedAt), repository.UTC(p.ClosedAt)
 
-	// Get assigned reviewers
+	// Get assigned reviewers in the order they were assigned; reviewers assigned together go by user_id
 	reviewersQuery := `
-		SELECT user_id
+		SELECT user_id, source, assigned_at, approved_at IS NOT NULL
 		FROM pr_reviewers
-		WHERE pull_request_id = $1
+		WHERE repository_name = $1 AND pull_request_id = $2 AND org_id = $3
+		ORDER BY assigned_at, user_id
 	`
-	rows, err := exec.Query(reviewersQuery, prID)
+	rows, err := exec.Query(reviewersQuery, key.RepositoryName, key.PullRequestID, orgID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get reviewers: %w", err)
 	}
 	defer func() { _ = rows.Close() }()
 
-	var reviewers []string
+	var reviewers, approved []string
+	var assignments []domain.ReviewerAssignment
 	for rows.Next() {
-		var reviewerID string
-		if err := rows.Scan(&reviewerID); err != nil {
+		a := domain.ReviewerAssignment{ReviewSLAHours: reviewSLAHours}
+		if err := rows.Scan(&a.UserID, &a.Source, &a.AssignedAt, &a.Approved); err != nil {
 			return nil, fmt.Errorf("failed to scan reviewer: %w", err)
 		}
-		reviewers = append(reviewers, reviewerID)
+		a.AssignedAt = a.AssignedAt.UTC()
+		reviewers = append(reviewers, a.UserID)
+		if a.Approved {
+			approved = append(approved, a.UserID)
+		}
+		assignments = append(assignments, a)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -88,19 +232,25 @@ func Get(exec repository.DBTX, prID string) (*domain.PullRequest, error) {
 	}
 
 	p.AssignedReviewersIDs = reviewers
+	p.ApprovedReviewersIDs = approved
+	p.Assignments = assignments
 	return &p, nil
 }
 
-// GetByUser retrieves all pull requests assigned to a user for review.
-func GetByUser(exec repository.DBTX, userID string) ([]domain.PullRequestShort, error) {
+// GetByUser retrieves all pull requests assigned to a user for review, each with the user's assignment.
+// A non-nil repositoryName limits the result to that repository.
+func GetByUser(exec repository.DBTX, userID string, repositoryName *string) ([]domain.PullRequestShort, error) {
+	exec = repository.Named(exec, "pr.GetByUser")
 	query := `
-		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.team_name, pr.status
+		SELECT pr.repository_name, pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.team_name, pr.status,
+		       rev.assigned_at, rev.approved_at IS NOT NULL, COALESCE(t.review_sla_hours, 0)
 		FROM pull_requests pr
-		JOIN pr_reviewers rev ON pr.pull_request_id = rev.pull_request_id
-		WHERE rev.user_id = $1
+		JOIN pr_reviewers rev ON pr.org_id = rev.org_id AND pr.repository_name = rev.repository_name AND pr.pull_request_id = rev.pull_request_id
+		LEFT JOIN teams t ON t.org_id = pr.org_id AND t.team_name = pr.team_name
+		WHERE rev.user_id = $1 AND rev.org_id = $3 AND ($2::VARCHAR IS NULL OR pr.repository_name = $2)
 		ORDER BY pr.created_at DESC
 	`
-	rows, err := exec.Query(query, userID)
+	rows, err := exec.Query(query, userID, repositoryName, repository.Org(exec))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user pull requests: %w", err)
 	}
@@ -108,10 +258,12 @@ func GetByUser(exec repository.DBTX, userID string) ([]domain.PullRequestShort,
 
 	var prs []domain.PullRequestShort
 	for rows.Next() {
-		var p domain.PullRequestShort
-		if err := rows.Scan(&p.PullRequestID, &p.PullRequestName, &p.AuthorID, &p.TeamName, &p.Status); err != nil {
+		p := domain.PullRequestShort{Assignment: &domain.ReviewerAssignment{UserID: userID}}
+		if err := rows.Scan(&p.RepositoryName, &p.PullRequestID, &p.PullRequestName, &p.AuthorID, &p.TeamName, &p.Status,
+			&p.Assignment.AssignedAt, &p.Assignment.Approved, &p.Assignment.ReviewSLAHours); err != nil {
 			return nil, fmt.Errorf("failed to scan pull request: %w", err)
 		}
+		p.Assignment.AssignedAt = p.Assignment.AssignedAt.UTC()
 		prs = append(prs, p)
 	}
 
@@ -122,16 +274,181 @@ func GetByUser(exec repository.DBTX, userID string) ([]domain.PullRequestShort,
 	return prs, nil
 }
 
-// UpdateStatusToMerged updates the pull request status to MERGED.
-// Returns sql.ErrNoRows if PR doesn't exist or already merged.
-func UpdateStatusToMerged(exec repository.DBTX, prID string) error {
+// ReviewCount is the number of open pull requests a user is assigned to review and how many of those
+// reviews are overdue: not approved and waiting longer than the review SLA of the pull request's team.
+type ReviewCount struct {
+	Open    int
+	Overdue int
+}
+
+// CountByUser counts the open pull requests assigned to a user for review as of now, without
+// fetching them. A non-nil repositoryName limits the count to that repository.
+// An unknown user yields zero counts.
+func CountByUser(exec repository.DBTX, userID string, repositoryName *string, now time.Time) (ReviewCount, error) {
+	exec = repository.Named(exec, "pr.CountByUser")
+	query := `
+		SELECT COUNT(*),
+		       COUNT(*) FILTER (WHERE rev.approved_at IS NULL AND t.review_sla_hours > 0
+		                          AND rev.assigned_at < $5::timestamptz - make_interval(hours => t.review_sla_hours))
+		FROM pull_requests pr
+		JOIN pr_reviewers rev ON pr.org_id = rev.org_id AND pr.repository_name = rev.repository_name AND pr.pull_request_id = rev.pull_request_id
+		LEFT JOIN teams t ON t.org_id = pr.org_id AND t.team_name = pr.team_name
+		WHERE rev.user_id = $1 AND rev.org_id = $3 AND pr.status = $4 AND ($2::VARCHAR IS NULL OR pr.repository_name = $2)
+	`
+	var c ReviewCount
+	err := exec.QueryRow(query, userID, repositoryName, repository.Org(exec), domain.StatusOpen, now).Scan(&c.Open, &c.Overdue)
+	if err != nil {
+		return ReviewCount{}, fmt.Errorf("failed to count user reviews: %w", err)
+	}
+	return c, nil
+}
+
+// PendingReview is an unapproved review assignment on an open pull request.
+// PullRequest.Assignment is the reviewer's assignment.
+type PendingReview struct {
+	Username    string
+	PullRequest domain.PullRequestShort
+	ExternalURL *string
+}
+
+// GetPendingByTeam returns the pending reviews of the team's active members, including those whose
+// primary team is another one, ordered by reviewer and then oldest first.
+func GetPendingByTeam(exec repository.DBTX, teamName string) ([]PendingReview, error) {
+	exec = repository.Named(exec, "pr.GetPendingByTeam")
+	query := `
+		SELECT u.user_id, u.username, p.repository_name, p.pull_request_id, p.pull_request_name, p.author_id, p.team_name, p.status,
+		       p.external_url, rev.assigned_at, COALESCE(t.review_sla_hours, 0)
+		FROM team_memberships m
+		JOIN users u ON u.org_id = m.org_id AND u.user_id = m.user_id
+		JOIN pr_reviewers rev ON rev.org_id = u.org_id AND rev.user_id = u.user_id
+		JOIN pull_requests p ON p.org_id = rev.org_id AND p.repository_name = rev.repository_name AND p.pull_request_id = rev.pull_request_id
+		LEFT JOIN teams t ON t.org_id = p.org_id AND t.team_name = p.team_name
+		WHERE m.team_name = $1 AND m.org_id = $2 AND u.is_active = true AND u.erased_at IS NULL
+		  AND p.status = $3 AND rev.approved_at IS NULL
+		ORDER BY u.user_id, rev.assigned_at, p.repository_name, p.pull_request_id
+	`
+	rows, err := exec.Query(query, teamName, repository.Org(exec), domain.StatusOpen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending reviews: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var reviews []PendingReview
+	for rows.Next() {
+		var r PendingReview
+		a := &domain.ReviewerAssignment{}
+		p := &r.PullRequest
+		if err := rows.Scan(&a.UserID, &r.Username, &p.RepositoryName, &p.PullRequestID, &p.PullRequestName, &p.AuthorID, &p.TeamName, &p.Status,
+			&r.ExternalURL, &a.AssignedAt, &a.ReviewSLAHours); err != nil {
+			return nil, fmt.Errorf("failed to scan pending review: %w", err)
+		}
+		a.AssignedAt = a.AssignedAt.UTC()
+		p.Assignment = a
+		reviews = append(reviews, r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return reviews, nil
+}
+
+// GetOpenIDsByReviewer returns keys of OPEN pull requests the user is assigned to review.
+func GetOpenIDsByReviewer(exec repository.DBTX, userID string) ([]domain.PRKey, error) {
+	exec = repository.Named(exec, "pr.GetOpenIDsByReviewer")
+	query := `
+		SELECT pr.repository_name, pr.pull_request_id
+		FROM pull_requests pr
+		JOIN pr_reviewers rev ON pr.org_id = rev.org_id AND pr.repository_name = rev.repository_name AND pr.pull_request_id = rev.pull_request_id
+		WHERE rev.user_id = $1 AND rev.org_id = $3 AND pr.status = $2
+		ORDER BY pr.created_at, pr.repository_name, pr.pull_request_id
+	`
+	rows, err := exec.Query(query, userID, domain.StatusOpen, repository.Org(exec))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open reviews: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var keys []domain.PRKey
+	for rows.Next() {
+		var key domain.PRKey
+		if err := rows.Scan(&key.RepositoryName, &key.PullRequestID); err != nil {
+			return nil, fmt.Errorf("failed to scan pull request id: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return keys, nil
+}
+
+// UpdateStatusToMerged updates the pull request status to MERGED at mergedAt, recording who merged it.
+// An empty mergedBy is stored as NULL.
+// Returns repository.ErrNotFound if PR doesn't exist or is not OPEN.
+func UpdateStatusToMerged(exec repository.DBTX, key domain.PRKey, mergedBy string, mergedAt time.Time) error {
+	exec = repository.Named(exec, "pr.UpdateStatusToMerged")
 	query := `
 		UPDATE pull_requests 
-		SET status = $1, merged_at = $2
-		WHERE pull_request_id = $3 AND status = $4
+		SET status = $1, merged_at = $2, merged_by = NULLIF($6, '')
+		WHERE repository_name = $3 AND pull_request_id = $4 AND status = $5 AND org_id = $7
+	`
+	result, err := exec.Exec(query, domain.StatusMerged, mergedAt.UTC(), key.RepositoryName, key.PullRequestID, domain.StatusOpen, mergedBy, repository.Org(exec))
+	if err != nil {
+		return fmt.Errorf("failed to update PR status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("open pull request %s: %w", key, repository.ErrNotFound)
+	}
+
+	return nil
+}
+
+// UpdateStatusToClosed updates the pull request status to CLOSED at closedAt.
+// Returns repository.ErrNotFound if PR doesn't exist or is not OPEN.
+func UpdateStatusToClosed(exec repository.DBTX, key domain.PRKey, closedAt time.Time) error {
+	exec = repository.Named(exec, "pr.UpdateStatusToClosed")
+	query := `
+		UPDATE pull_requests
+		SET status = $1, closed_at = $2
+		WHERE repository_name = $3 AND pull_request_id = $4 AND status = $5 AND org_id = $6
+	`
+	result, err := exec.Exec(query, domain.StatusClosed, closedAt.UTC(), key.RepositoryName, key.PullRequestID, domain.StatusOpen, repository.Org(exec))
+	if err != nil {
+		return fmt.Errorf("failed to update PR status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("open pull request %s: %w", key, repository.ErrNotFound)
+	}
+
+	return nil
+}
+
+// UpdateStatusToOpen reopens a MERGED or CLOSED pull request and clears merged_at, merged_by and closed_at.
+// Returns repository.ErrNotFound if PR doesn't exist or is already OPEN.
+func UpdateStatusToOpen(exec repository.DBTX, key domain.PRKey) error {
+	exec = repository.Named(exec, "pr.UpdateStatusToOpen")
+	query := `
+		UPDATE pull_requests
+		SET status = $1, merged_at = NULL, merged_by = NULL, closed_at = NULL
+		WHERE repository_name = $2 AND pull_request_id = $3 AND status <> $1 AND org_id = $4
 	`
-	now := time.Now()
-	result, err := exec.Exec(query, domain.StatusMerged, now, prID, domain.StatusOpen)
+	result, err := exec.Exec(query, domain.StatusOpen, key.RepositoryName, key.PullRequestID, repository.Org(exec))
 	if err != nil {
 		return fmt.Errorf("failed to update PR status: %w", err)
 	}
@@ -142,35 +459,71 @@ func UpdateStatusToMerged(exec repository.DBTX, prID string) error {
 	}
 
 	if rowsAffected == 0 {
-		return sql.ErrNoRows
+		return fmt.Errorf("finished pull request %s: %w", key, repository.ErrNotFound)
+	}
+
+	return nil
+}
+
+// RestartReviews resets assigned_at of every reviewer of the pull request to reopenedAt and drops
+// their approvals, so that reviews and review SLAs count from the moment it was reopened.
+func RestartReviews(exec repository.DBTX, key domain.PRKey, reopenedAt time.Time) error {
+	exec = repository.Named(exec, "pr.RestartReviews")
+	query := `UPDATE pr_reviewers SET assigned_at = $4, approved_at = NULL WHERE repository_name = $1 AND pull_request_id = $2 AND org_id = $3`
+	if _, err := exec.Exec(query, key.RepositoryName, key.PullRequestID, repository.Org(exec), reopenedAt); err != nil {
+		return fmt.Errorf("failed to restart reviews: %w", err)
+	}
+	return nil
+}
+
+// Approve records the reviewer's approval of the pull request at approvedAt. A repeated approval keeps the original time.
+// Returns ErrReviewerNotAssigned if userID is not assigned to this PR.
+func Approve(exec repository.DBTX, key domain.PRKey, userID string, approvedAt time.Time) error {
+	exec = repository.Named(exec, "pr.Approve")
+	query := `
+		UPDATE pr_reviewers SET approved_at = COALESCE(approved_at, $5)
+		WHERE repository_name = $1 AND pull_request_id = $2 AND user_id = $3 AND org_id = $4
+	`
+	result, err := exec.Exec(query, key.RepositoryName, key.PullRequestID, userID, repository.Org(exec), approvedAt)
+	if err != nil {
+		return fmt.Errorf("failed to approve pull request: %w", err)
 	}
 
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrReviewerNotAssigned
+	}
 	return nil
 }
 
 // DeleteReviewer removes a specific reviewer from a pull request.
-func DeleteReviewer(exec repository.DBTX, prID, userID string) error {
-	query := `DELETE FROM pr_reviewers WHERE pull_request_id = $1 AND user_id = $2`
-	_, err := exec.Exec(query, prID, userID)
+func DeleteReviewer(exec repository.DBTX, key domain.PRKey, userID string) error {
+	exec = repository.Named(exec, "pr.DeleteReviewer")
+	query := `DELETE FROM pr_reviewers WHERE repository_name = $1 AND pull_request_id = $2 AND user_id = $3 AND org_id = $4`
+	_, err := exec.Exec(query, key.RepositoryName, key.PullRequestID, userID, repository.Org(exec))
 	if err != nil {
 		return fmt.Errorf("failed to delete reviewer: %w", err)
 	}
 	return nil
 }
 
-// ReplaceReviewer atomically replaces oldReviewerID with newReviewerID for the given PR.
+// ReplaceReviewer atomically replaces oldReviewerID with newReviewerID, assigned from source at assignedAt, for the given PR.
 // Returns ErrReviewerNotAssigned if oldReviewerID was not assigned to this PR.
-func ReplaceReviewer(exec repository.DBTX, prID, oldReviewerID, newReviewerID string) error {
+func ReplaceReviewer(exec repository.DBTX, key domain.PRKey, oldReviewerID, newReviewerID string, source domain.ReviewerSource, assignedAt time.Time) error {
+	exec = repository.Named(exec, "pr.ReplaceReviewer")
 	query := `
 		WITH deleted AS (
 			DELETE FROM pr_reviewers
-			WHERE pull_request_id = $1 AND user_id = $2
+			WHERE repository_name = $1 AND pull_request_id = $2 AND user_id = $3 AND org_id = $5
 			RETURNING pull_request_id
 		)
-		INSERT INTO pr_reviewers (pull_request_id, user_id)
-		SELECT $1, $3 FROM deleted
+		INSERT INTO pr_reviewers (repository_name, pull_request_id, user_id, org_id, source, assigned_at)
+		SELECT $1, $2, $4, $5, $6, $7 FROM deleted
 	`
-	result, err := exec.Exec(query, prID, oldReviewerID, newReviewerID)
+	result, err := exec.Exec(query, key.RepositoryName, key.PullRequestID, oldReviewerID, newReviewerID, repository.Org(exec), source, assignedAt)
 	if err != nil {
 		return fmt.Errorf("failed to replace reviewer: %w", err)
 	}
@@ -181,17 +534,113 @@ func ReplaceReviewer(exec repository.DBTX, prID, oldReviewerID, newReviewerID st
 	return nil
 }
 
+// IncrementReassignmentCount bumps the reassignment counter of a pull request and returns its new value.
+// Returns repository.ErrNotFound if the pull request doesn't exist.
+func IncrementReassignmentCount(exec repository.DBTX, key domain.PRKey) (int, error) {
+	exec = repository.Named(exec, "pr.IncrementReassignmentCount")
+	query := `
+		UPDATE pull_requests SET reassignment_count = reassignment_count + 1
+		WHERE repository_name = $1 AND pull_request_id = $2 AND org_id = $3
+		RETURNING reassignment_count
+	`
+	var count int
+	err := exec.QueryRow(query, key.RepositoryName, key.PullRequestID, repository.Org(exec)).Scan(&count)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("pull request %s: %w", key, repository.ErrNotFound)
+		}
+		return 0, fmt.Errorf("failed to increment reassignment count: %w", err)
+	}
+	return count, nil
+}
+
 // GetStatus returns the status of a pull request.
-func GetStatus(exec repository.DBTX, prID string) (domain.PRStatus, error) {
+// Returns repository.ErrNotFound if the pull request doesn't exist.
+func GetStatus(exec repository.DBTX, key domain.PRKey) (domain.PRStatus, error) {
+	exec = repository.Named(exec, "pr.GetStatus")
 	var status domain.PRStatus
-	query := `SELECT status FROM pull_requests WHERE pull_request_id = $1`
-	err := exec.QueryRow(query, prID).Scan(&status)
+	query := `SELECT status FROM pull_requests WHERE repository_name = $1 AND pull_request_id = $2 AND org_id = $3`
+	err := exec.QueryRow(query, key.RepositoryName, key.PullRequestID, repository.Org(exec)).Scan(&status)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return "", err
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fmt.Errorf("pull request %s: %w", key, repository.ErrNotFound)
 		}
 		return "", fmt.Errorf("failed to get pull request status: %w", err)
 	}
 	return status, nil
 }
 
+// GetStatusAndAuthor returns the status and the author of a pull request.
+// Returns repository.ErrNotFound if the pull request doesn't exist.
+func GetStatusAndAuthor(exec repository.DBTX, key domain.PRKey) (domain.PRStatus, string, error) {
+	exec = repository.Named(exec, "pr.GetStatusAndAuthor")
+	var status domain.PRStatus
+	var authorID string
+	query := `SELECT status, author_id FROM pull_requests WHERE repository_name = $1 AND pull_request_id = $2 AND org_id = $3`
+	err := exec.QueryRow(query, key.RepositoryName, key.PullRequestID, repository.Org(exec)).Scan(&status, &authorID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", "", fmt.Errorf("pull request %s: %w", key, repository.ErrNotFound)
+		}
+		return "", "", fmt.Errorf("failed to get pull request status: %w", err)
+	}
+	return status, authorID, nil
+}
+
+// OverdueAssignment is a reviewer assignment on an open PR that exceeded the review SLA.
+type OverdueAssignment struct {
+	OrgID      string
+	PR         domain.PRKey
+	UserID     string
+	AssignedAt time.Time
+}
+
+// GetOverdueAssignments returns up to limit unapproved assignments on open PRs made before assignedBefore.
+// Assignments never attempted come first, oldest first, followed by the attempted ones, least recently
+// attempted first, so that assignments that cannot be escalated do not hold back newer ones.
+// Unlike other queries it spans all organizations; each assignment carries its own.
+func GetOverdueAssignments(exec repository.DBTX, assignedBefore time.Time, limit int) ([]OverdueAssignment, error) {
+	exec = repository.Named(exec, "pr.GetOverdueAssignments")
+	query := `
+		SELECT rev.org_id, rev.repository_name, rev.pull_request_id, rev.user_id, rev.assigned_at
+		FROM pr_reviewers rev
+		JOIN pull_requests pr ON pr.org_id = rev.org_id AND pr.repository_name = rev.repository_name AND pr.pull_request_id = rev.pull_request_id
+		WHERE pr.status = $1 AND rev.assigned_at < $2 AND rev.approved_at IS NULL
+		ORDER BY rev.escalation_attempted_at NULLS FIRST, rev.assigned_at, rev.repository_name, rev.pull_request_id, rev.user_id
+		LIMIT $3
+	`
+	rows, err := exec.Query(query, domain.StatusOpen, assignedBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get overdue assignments: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var assignments []OverdueAssignment
+	for rows.Next() {
+		var a OverdueAssignment
+		if err := rows.Scan(&a.OrgID, &a.PR.RepositoryName, &a.PR.PullRequestID, &a.UserID, &a.AssignedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan overdue assignment: %w", err)
+		}
+		assignments = append(assignments, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return assignments, nil
+}
+
+// MarkEscalationAttempted records that escalating the user's assignment on the pull request failed at at,
+// which moves the assignment behind untried ones in GetOverdueAssignments.
+func MarkEscalationAttempted(exec repository.DBTX, key domain.PRKey, userID string, at time.Time) error {
+	exec = repository.Named(exec, "pr.MarkEscalationAttempted")
+	query := `
+		UPDATE pr_reviewers SET escalation_attempted_at = $4
+		WHERE repository_name = $1 AND pull_request_id = $2 AND user_id = $3 AND org_id = $5
+	`
+	if _, err := exec.Exec(query, key.RepositoryName, key.PullRequestID, userID, at, repository.Org(exec)); err != nil {
+		return fmt.Errorf("failed to record escalation attempt: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"fmt"
+)
+
+// SchemaMigration is the latest migration in migrations/ that the expected schema below reflects.
+// Adding a migration means reviewing the lists and bumping it.
+const SchemaMigration = 35
+
+// tableColumns lists the columns of a table the code reads or writes by name.
+type tableColumns struct {
+	table   string
+	columns []string
+}
+
+// expectedTables are the tables and columns required by the repositories.
+var expectedTables = []tableColumns{
+	{"organizations", []string{"org_id", "name", "created_at"}},
+	{"teams", []string{
+		"org_id", "team_name", "assignment_strategy", "slack_webhook_url", "require_approvals",
+		"review_sla_hours", "reviewer_count", "fallback_team_name",
+	}},
+	{"users", []string{
+		"org_id", "user_id", "username", "team_name", "is_active", "max_open_reviews",
+		"assignment_weight", "erased_at",
+	}},
+	{"team_memberships", []string{"org_id", "user_id", "team_name", "is_primary"}},
+	{"pull_requests", []string{
+		"org_id", "repository_name", "pull_request_id", "pull_request_name", "author_id", "team_name",
+		"status", "created_at", "merged_at", "closed_at", "merged_by", "description", "external_url",
+		"reassignment_count", "size", "lines_changed",
+	}},
+	{"pr_reviewers", []string{
+		"org_id", "repository_name", "pull_request_id", "user_id", "assigned_at", "approved_at", "source",
+		"escalation_attempted_at",
+	}},
+	{"assignment_history", []string{
+		"org_id", "repository_name", "pull_request_id", "action", "old_user_id", "new_user_id",
+		"decision", "created_at",
+	}},
+	{"user_absences", []string{"org_id", "user_id", "from_date", "to_date"}},
+	{"reviewer_exclusions", []string{"org_id", "reviewer_id", "author_id"}},
+	{"user_tags", []string{"org_id", "user_id", "tag"}},
+	{"pr_tags", []string{"org_id", "repository_name", "pull_request_id", "tag"}},
+	{"ownership_rules", []string{"org_id", "team_name", "path_prefix", "owner_user_id", "owner_team_name"}},
+	{"team_digests", []string{"org_id", "team_name", "hour", "timezone", "last_sent_at"}},
+	{"external_logins", []string{"org_id", "provider", "external_login", "user_id"}},
+	{"webhooks", []string{"webhook_id", "org_id", "url", "secret", "created_at"}},
+	{"event_outbox", []string{
+		"event_id", "event_type", "repository_name", "pull_request_id", "payload", "created_at",
+		"attempts", "last_error", "published_at", "failed_at",
+	}},
+	{"audit_log", []string{"org_id", "actor", "action", "target", "request_id", "created_at"}},
+}
+
+// expectedConstraints are the keys that ON CONFLICT clauses and duplicate detection rely on,
+// and the foreign keys whose cascades clean up reviewers and history of deleted pull requests.
+var expectedConstraints = []string{
+	"teams_pkey",
+	"users_pkey",
+	"pull_requests_pkey",
+	"pr_reviewers_pr_user_key",
+	"pr_reviewers_pr_fkey",
+	"assignment_history_pr_fkey",
+	"team_memberships_pkey",
+	"reviewer_exclusions_pkey",
+	"external_logins_pkey",
+	"ownership_rules_pkey",
+	"team_digests_pkey",
+}
+
+// expectedIndexes are the indexes that enforce invariants or keep hot queries from scanning tables.
+var expectedIndexes = []string{
+	"idx_team_memberships_primary",
+	"idx_event_outbox_pending",
+	"idx_pull_requests_author_created_at",
+	"idx_assignment_history_pr",
+	"idx_pr_reviewers_user_id",
+}
+
+// VerifySchema compares the current schema of the database with the one the code expects
+// and returns the missing tables, columns, constraints and indexes, e.g. "column pr_reviewers.source".
+// An empty result means every expected item is present.
+func VerifySchema(exec DBTX) ([]string, error) {
+	exec = Named(exec, "repository.VerifySchema")
+	columns, err := schemaNames(exec, `
+		SELECT table_name || '.' || column_name FROM information_schema.columns
+		WHERE table_schema = current_schema()
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list columns: %w", err)
+	}
+	constraints, err := schemaNames(exec, `
+		SELECT constraint_name FROM information_schema.table_constraints
+		WHERE table_schema = current_schema()
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list constraints: %w", err)
+	}
+	indexes, err := schemaNames(exec, `SELECT indexname FROM pg_indexes WHERE schemaname = current_schema()`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list indexes: %w", err)
+	}
+	tables, err := schemaNames(exec, `
+		SELECT table_name FROM information_schema.tables
+		WHERE table_schema = current_schema()
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+
+	missing := make([]string, 0)
+	for _, t := range expectedTables {
+		if !tables[t.table] {
+			// The columns of a missing table are not listed one by one.
+			missing = append(missing, "table "+t.table)
+			continue
+		}
+		for _, c := range t.columns {
+			if !columns[t.table+"."+c] {
+				missing = append(missing, "column "+t.table+"."+c)
+			}
+		}
+	}
+	for _, name := range expectedConstraints {
+		if !constraints[name] {
+			missing = append(missing, "constraint "+name)
+		}
+	}
+	for _, name := range expectedIndexes {
+		if !indexes[name] {
+			missing = append(missing, "index "+name)
+		}
+	}
+	return missing, nil
+}
+
+// schemaNames runs a query returning one name per row and returns the names as a set.
+func schemaNames(exec DBTX, query string) (map[string]bool, error) {
+	rows, err := exec.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	names := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names[name] = true
+	}
+	return names, rows.Err()
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+)
+
+// SizeWeight returns an SQL expression evaluating to domain.PRSize.Weight of the size stored in column.
+func SizeWeight(column string) string {
+	var b strings.Builder
+	b.WriteString("CASE " + column)
+	for _, size := range domain.PRSizes {
+		fmt.Fprintf(&b, " WHEN '%s' THEN %d", size, size.Weight())
+	}
+	fmt.Fprintf(&b, " ELSE %d END", domain.PRSize("").Weight())
+	return b.String()
+}
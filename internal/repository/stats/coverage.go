@@ -0,0 +1,57 @@
+package stats
+
+import (
+	"fmt"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+)
+
+// CoverageRow is the number of a team's open pull requests missing the same number of reviewers.
+// Shortfall is 0 for pull requests with at least ReviewerTarget reviewers.
+type CoverageRow struct {
+	TeamName       string
+	ReviewerTarget int
+	Shortfall      int
+	PRs            int64
+}
+
+// GetCoverage counts the organization's open pull requests by team and by how many reviewers
+// they lack against the team's reviewer_count; teams without one, or no longer existing, use defaultTarget.
+// Rows are ordered by team name then shortfall.
+func GetCoverage(exec repository.DBTX, defaultTarget int) ([]CoverageRow, error) {
+	exec = repository.Named(exec, "stats.GetCoverage")
+	query := `
+		SELECT s.team_name, s.target, GREATEST(s.target - s.reviewers, 0) AS shortfall, COUNT(*)
+		FROM (
+			SELECT p.team_name, COALESCE(t.reviewer_count, $3) AS target, COUNT(rev.user_id) AS reviewers
+			FROM pull_requests p
+			LEFT JOIN teams t ON t.org_id = p.org_id AND t.team_name = p.team_name
+			LEFT JOIN pr_reviewers rev ON rev.org_id = p.org_id AND rev.repository_name = p.repository_name AND rev.pull_request_id = p.pull_request_id
+			WHERE p.org_id = $1 AND p.status = $2
+			GROUP BY p.org_id, p.repository_name, p.pull_request_id, p.team_name, t.reviewer_count
+		) s
+		GROUP BY s.team_name, s.target, shortfall
+		ORDER BY s.team_name, shortfall
+	`
+	rows, err := exec.Query(query, repository.Org(exec), domain.StatusOpen, defaultTarget)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviewer coverage: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	coverage := make([]CoverageRow, 0)
+	for rows.Next() {
+		var r CoverageRow
+		if err := rows.Scan(&r.TeamName, &r.ReviewerTarget, &r.Shortfall, &r.PRs); err != nil {
+			return nil, fmt.Errorf("failed to scan reviewer coverage: %w", err)
+		}
+		coverage = append(coverage, r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return coverage, nil
+}
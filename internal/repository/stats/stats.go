@@ -1,114 +1,422 @@
 package stats
 
 import (
+	"context"
+	"database/sql"
+	"encoding/json"
 	"fmt"
+	"time"
 
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
 	"github.com/mishasvintus/avito_backend_internship/internal/repository"
 )
 
 // ReviewerStat represents statistics for a reviewer.
+// Count is the total of OpenCount and MergedCount; OpenLoad is OpenCount weighted by PR size.
 type ReviewerStat struct {
-	UserID   string
-	Username string
-	Count    int64
+	UserID      string `json:"user_id"`
+	Username    string `json:"username"`
+	Count       int64  `json:"count"`
+	OpenCount   int64  `json:"open_count"`
+	OpenLoad    int64  `json:"open_load"`
+	MergedCount int64  `json:"merged_count"`
 }
 
 // AuthorStat represents statistics for an author.
 type AuthorStat struct {
-	UserID   string
-	Username string
-	Count    int64
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	Count    int64  `json:"count"`
+}
+
+// MergerStat represents the number of pull requests a user merged.
+type MergerStat struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	Count    int64  `json:"count"`
 }
 
 // OverallStats represents overall statistics.
 type OverallStats struct {
 	TotalPRs         int64
+	MergedPRs        int64
 	TotalAssignments int64
 	TotalUsers       int64
 	TotalTeams       int64
 }
 
-// GetReviewerStats returns statistics about reviewer assignments per user.
-func GetReviewerStats(exec repository.DBTX) ([]ReviewerStat, error) {
+// Period limits statistics to a time window. Both bounds are inclusive; a nil bound is open.
+type Period struct {
+	From *time.Time
+	To   *time.Time
+}
+
+// args returns the bounds as query parameters.
+func (p Period) args() (any, any) {
+	return utcOrNil(p.From), utcOrNil(p.To)
+}
+
+// utcOrNil converts t to UTC for a TIMESTAMPTZ parameter, or returns nil for a nil t.
+func utcOrNil(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return t.UTC()
+}
+
+// inPeriod returns a predicate limiting column to the window passed as parameters $1 and $2.
+func inPeriod(column string) string {
+	return fmt.Sprintf("($1::timestamptz IS NULL OR %[1]s >= $1) AND ($2::timestamptz IS NULL OR %[1]s <= $2)", column)
+}
+
+// Summary is everything GET /stats needs, fetched in one round trip.
+type Summary struct {
+	Overall     OverallStats
+	Reviewers   []ReviewerStat
+	Authors     []AuthorStat
+	Mergers     []MergerStat
+	MemberLoads []MemberLoad
+	TeamOverdue []TeamOverdue
+}
+
+// GetSummary returns overall, reviewer, author and merger statistics of the organization for the period
+// together with open assignments of active team members, in a single statement.
+// Reviewer counts cover assignments made within the period, author counts PRs created within it,
+// merger counts PRs merged within it, and the overall PR, merge and assignment counts are limited to it;
+// user and team counts, member loads and overdue reviews, which are counted as of now, are not.
+// Reviewers, authors and mergers are ordered by count descending then user ID; member loads by team then user ID,
+// overdue reviews by team.
+func GetSummary(ctx context.Context, exec repository.DBTX, period Period, now time.Time) (*Summary, error) {
+	exec = repository.Named(exec, "stats.GetSummary")
+	query := `
+		WITH reviewer_stats AS (
+			SELECT u.user_id, u.username, COUNT(rev.user_id) AS count,
+			       COUNT(rev.user_id) FILTER (WHERE p.status = $3) AS open_count,
+			       COALESCE(SUM(` + repository.SizeWeight("p.size") + `) FILTER (WHERE p.status = $3), 0) AS open_load,
+			       COUNT(rev.user_id) FILTER (WHERE p.status = $4) AS merged_count
+			FROM users u
+			LEFT JOIN pr_reviewers rev ON u.org_id = rev.org_id AND u.user_id = rev.user_id AND ` + inPeriod("rev.assigned_at") + `
+			LEFT JOIN pull_requests p ON p.org_id = rev.org_id AND p.repository_name = rev.repository_name AND p.pull_request_id = rev.pull_request_id
+			WHERE u.org_id = $5
+			GROUP BY u.user_id, u.username
+		),
+		author_stats AS (
+			SELECT u.user_id, u.username, COUNT(p.pull_request_id) AS count
+			FROM users u
+			LEFT JOIN pull_requests p ON u.org_id = p.org_id AND u.user_id = p.author_id AND ` + inPeriod("p.created_at") + `
+			WHERE u.org_id = $5
+			GROUP BY u.user_id, u.username
+		),
+		merger_stats AS (
+			SELECT u.user_id, u.username, COUNT(p.pull_request_id) AS count
+			FROM users u
+			LEFT JOIN pull_requests p ON u.org_id = p.org_id AND u.user_id = p.merged_by AND ` + inPeriod("p.merged_at") + `
+			WHERE u.org_id = $5
+			GROUP BY u.user_id, u.username
+		),
+		member_loads AS (
+			SELECT tm.team_name, u.user_id, COUNT(p.pull_request_id) AS open_assignments
+			FROM team_memberships tm
+			JOIN users u ON u.org_id = tm.org_id AND u.user_id = tm.user_id
+			LEFT JOIN pr_reviewers rev ON rev.org_id = u.org_id AND rev.user_id = u.user_id
+			LEFT JOIN pull_requests p ON p.org_id = rev.org_id AND p.repository_name = rev.repository_name AND p.pull_request_id = rev.pull_request_id AND p.status = $3
+			WHERE tm.org_id = $5 AND u.is_active = true
+			GROUP BY tm.team_name, u.user_id
+		),
+		team_overdue AS (
+			SELECT p.team_name, COUNT(*) AS overdue_assignments
+			FROM pr_reviewers rev
+			JOIN pull_requests p ON p.org_id = rev.org_id AND p.repository_name = rev.repository_name AND p.pull_request_id = rev.pull_request_id
+			JOIN teams t ON t.org_id = p.org_id AND t.team_name = p.team_name
+			WHERE rev.org_id = $5 AND p.status = $3 AND rev.approved_at IS NULL AND t.review_sla_hours > 0
+			  AND rev.assigned_at < $6::timestamptz - make_interval(hours => t.review_sla_hours)
+			GROUP BY p.team_name
+		)
+		SELECT
+			(SELECT COUNT(*) FROM pull_requests WHERE org_id = $5 AND ` + inPeriod("created_at") + `) AS total_prs,
+			(SELECT COUNT(*) FROM pull_requests WHERE org_id = $5 AND merged_at IS NOT NULL AND ` + inPeriod("merged_at") + `) AS merged_prs,
+			(SELECT COUNT(*) FROM pr_reviewers WHERE org_id = $5 AND ` + inPeriod("assigned_at") + `) AS total_assignments,
+			(SELECT COUNT(*) FROM users WHERE org_id = $5) AS total_users,
+			(SELECT COUNT(*) FROM teams WHERE org_id = $5) AS total_teams,
+			(SELECT COALESCE(json_agg(r ORDER BY r.count DESC, r.user_id), '[]') FROM reviewer_stats r) AS reviewers,
+			(SELECT COALESCE(json_agg(a ORDER BY a.count DESC, a.user_id), '[]') FROM author_stats a) AS authors,
+			(SELECT COALESCE(json_agg(mg ORDER BY mg.count DESC, mg.user_id), '[]') FROM merger_stats mg) AS mergers,
+			(SELECT COALESCE(json_agg(m ORDER BY m.team_name, m.user_id), '[]') FROM member_loads m) AS member_loads,
+			(SELECT COALESCE(json_agg(o ORDER BY o.team_name), '[]') FROM team_overdue o) AS team_overdue
+	`
+	from, to := period.args()
+
+	var summary Summary
+	var reviewers, authors, mergers, memberLoads, teamOverdue []byte
+	err := exec.QueryRowContext(ctx, query, from, to, domain.StatusOpen, domain.StatusMerged, repository.Org(exec), now.UTC()).Scan(
+		&summary.Overall.TotalPRs,
+		&summary.Overall.MergedPRs,
+		&summary.Overall.TotalAssignments,
+		&summary.Overall.TotalUsers,
+		&summary.Overall.TotalTeams,
+		&reviewers,
+		&authors,
+		&mergers,
+		&memberLoads,
+		&teamOverdue,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stats summary: %w", err)
+	}
+
+	if err := json.Unmarshal(reviewers, &summary.Reviewers); err != nil {
+		return nil, fmt.Errorf("failed to decode reviewer stats: %w", err)
+	}
+	if err := json.Unmarshal(authors, &summary.Authors); err != nil {
+		return nil, fmt.Errorf("failed to decode author stats: %w", err)
+	}
+	if err := json.Unmarshal(mergers, &summary.Mergers); err != nil {
+		return nil, fmt.Errorf("failed to decode merger stats: %w", err)
+	}
+	if err := json.Unmarshal(memberLoads, &summary.MemberLoads); err != nil {
+		return nil, fmt.Errorf("failed to decode member loads: %w", err)
+	}
+	if err := json.Unmarshal(teamOverdue, &summary.TeamOverdue); err != nil {
+		return nil, fmt.Errorf("failed to decode overdue reviews: %w", err)
+	}
+
+	return &summary, nil
+}
+
+// UserActivity is review and authoring activity of a single user.
+// AvgTimeToMerge is nil when none of the PRs the user reviewed has been merged.
+type UserActivity struct {
+	OpenReviews      int64
+	CompletedReviews int64
+	AuthoredOpen     int64
+	AuthoredMerged   int64
+	AvgTimeToMerge   *time.Duration
+}
+
+// GetUserActivity returns review and authoring counts of the user. A review is completed
+// once its PR is merged; time to merge is measured from PR creation.
+// An unknown user yields zero counts.
+func GetUserActivity(exec repository.DBTX, userID string) (*UserActivity, error) {
+	exec = repository.Named(exec, "stats.GetUserActivity")
+	query := `
+		SELECT
+			COUNT(*) FILTER (WHERE p.status = $2) AS open_reviews,
+			COUNT(*) FILTER (WHERE p.status = $3) AS completed_reviews,
+			(SELECT COUNT(*) FROM pull_requests WHERE author_id = $1 AND org_id = $4 AND status = $2) AS authored_open,
+			(SELECT COUNT(*) FROM pull_requests WHERE author_id = $1 AND org_id = $4 AND status = $3) AS authored_merged,
+			AVG(EXTRACT(EPOCH FROM p.merged_at - p.created_at)) FILTER (WHERE p.merged_at IS NOT NULL) AS avg_merge_seconds
+		FROM pr_reviewers rev
+		JOIN pull_requests p ON p.org_id = rev.org_id AND p.repository_name = rev.repository_name AND p.pull_request_id = rev.pull_request_id
+		WHERE rev.user_id = $1 AND rev.org_id = $4
+	`
+	var a UserActivity
+	var avgSeconds sql.NullFloat64
+	err := exec.QueryRow(query, userID, domain.StatusOpen, domain.StatusMerged, repository.Org(exec)).Scan(
+		&a.OpenReviews,
+		&a.CompletedReviews,
+		&a.AuthoredOpen,
+		&a.AuthoredMerged,
+		&avgSeconds,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user activity: %w", err)
+	}
+
+	if avgSeconds.Valid {
+		d := time.Duration(avgSeconds.Float64 * float64(time.Second))
+		a.AvgTimeToMerge = &d
+	}
+
+	return &a, nil
+}
+
+// RankedUser is a leaderboard entry.
+type RankedUser struct {
+	UserID   string
+	Username string
+	Count    int64
+}
+
+// MemberLoad is the number of open review assignments of an active team member.
+type MemberLoad struct {
+	TeamName        string `json:"team_name"`
+	UserID          string `json:"user_id"`
+	OpenAssignments int64  `json:"open_assignments"`
+}
+
+// TeamOverdue is the number of pending reviews of a team's open pull requests past the team's review SLA.
+type TeamOverdue struct {
+	TeamName           string `json:"team_name"`
+	OverdueAssignments int64  `json:"overdue_assignments"`
+}
+
+// BucketSize is the granularity of a time series; values are date_trunc units.
+type BucketSize string
+
+const (
+	BucketDay  BucketSize = "day"
+	BucketWeek BucketSize = "week"
+)
+
+// ThroughputPoint holds the number of PRs created and merged within one bucket.
+type ThroughputPoint struct {
+	BucketStart time.Time
+	Created     int64
+	Merged      int64
+}
+
+// UserLoad represents per-user review load.
+type UserLoad struct {
+	UserID           string
+	Username         string
+	TeamName         string
+	IsActive         bool
+	OpenAssignments  int64
+	TotalAssignments int64
+	AuthoredPRs      int64
+}
+
+// GetUserLoad returns review load for every user of the organization, ordered by team and user ID.
+func GetUserLoad(exec repository.DBTX) ([]UserLoad, error) {
+	exec = repository.Named(exec, "stats.GetUserLoad")
 	query := `
-		SELECT u.user_id, u.username, COUNT(pr.user_id) as assignment_count
+		SELECT u.user_id, u.username, u.team_name, u.is_active,
+		       COUNT(rev.user_id) FILTER (WHERE p.status = 'OPEN') AS open_assignments,
+		       COUNT(rev.user_id) AS total_assignments,
+		       (SELECT COUNT(*) FROM pull_requests a WHERE a.org_id = u.org_id AND a.author_id = u.user_id) AS authored_prs
 		FROM users u
-		LEFT JOIN pr_reviewers pr ON u.user_id = pr.user_id
-		GROUP BY u.user_id, u.username
-		ORDER BY assignment_count DESC, u.user_id
+		LEFT JOIN pr_reviewers rev ON rev.org_id = u.org_id AND rev.user_id = u.user_id
+		LEFT JOIN pull_requests p ON p.org_id = rev.org_id AND p.repository_name = rev.repository_name AND p.pull_request_id = rev.pull_request_id
+		WHERE u.org_id = $1
+		GROUP BY u.org_id, u.user_id, u.username, u.team_name, u.is_active
+		ORDER BY u.team_name, u.user_id
 	`
-	rows, err := exec.Query(query)
+	rows, err := exec.Query(query, repository.Org(exec))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get reviewer stats: %w", err)
+		return nil, fmt.Errorf("failed to get user load: %w", err)
 	}
 	defer func() { _ = rows.Close() }()
 
-	var stats []ReviewerStat
+	loads := make([]UserLoad, 0)
 	for rows.Next() {
-		var stat ReviewerStat
-		if err := rows.Scan(&stat.UserID, &stat.Username, &stat.Count); err != nil {
-			return nil, fmt.Errorf("failed to scan reviewer stat: %w", err)
+		var l UserLoad
+		if err := rows.Scan(&l.UserID, &l.Username, &l.TeamName, &l.IsActive, &l.OpenAssignments, &l.TotalAssignments, &l.AuthoredPRs); err != nil {
+			return nil, fmt.Errorf("failed to scan user load: %w", err)
 		}
-		stats = append(stats, stat)
+		loads = append(loads, l)
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("rows iteration error: %w", err)
 	}
 
-	return stats, nil
+	return loads, nil
 }
 
-// GetAuthorStats returns statistics about PRs created per author.
-func GetAuthorStats(exec repository.DBTX) ([]AuthorStat, error) {
+// GetThroughput returns PRs created and merged per bucket between from and to (inclusive),
+// one point per bucket including empty ones. Buckets are UTC days and weeks; weeks start on Monday.
+// An empty teamName counts PRs of all teams.
+func GetThroughput(exec repository.DBTX, bucket BucketSize, from, to time.Time, teamName string) ([]ThroughputPoint, error) {
+	exec = repository.Named(exec, "stats.GetThroughput")
 	query := `
-		SELECT u.user_id, u.username, COUNT(pr.pull_request_id) as pr_count
-		FROM users u
-		LEFT JOIN pull_requests pr ON u.user_id = pr.author_id
-		GROUP BY u.user_id, u.username
-		ORDER BY pr_count DESC, u.user_id
+		WITH buckets AS (
+			SELECT generate_series(
+				date_trunc($1::text, $2::timestamptz AT TIME ZONE 'UTC'),
+				date_trunc($1::text, $3::timestamptz AT TIME ZONE 'UTC'),
+				('1 ' || $1::text)::interval
+			) AS bucket_start
+		),
+		scoped AS (
+			SELECT created_at, merged_at
+			FROM pull_requests
+			WHERE org_id = $5 AND ($4::text = '' OR team_name = $4::text)
+		)
+		SELECT b.bucket_start,
+		       (SELECT COUNT(*) FROM scoped s
+		        WHERE s.created_at >= $2::timestamptz AND s.created_at <= $3::timestamptz
+		          AND date_trunc($1::text, s.created_at AT TIME ZONE 'UTC') = b.bucket_start) AS created,
+		       (SELECT COUNT(*) FROM scoped s
+		        WHERE s.merged_at >= $2::timestamptz AND s.merged_at <= $3::timestamptz
+		          AND date_trunc($1::text, s.merged_at AT TIME ZONE 'UTC') = b.bucket_start) AS merged
+		FROM buckets b
+		ORDER BY b.bucket_start
 	`
-	rows, err := exec.Query(query)
+	rows, err := exec.Query(query, string(bucket), from.UTC(), to.UTC(), teamName, repository.Org(exec))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get author stats: %w", err)
+		return nil, fmt.Errorf("failed to get throughput: %w", err)
 	}
 	defer func() { _ = rows.Close() }()
 
-	var stats []AuthorStat
+	points := make([]ThroughputPoint, 0)
 	for rows.Next() {
-		var stat AuthorStat
-		if err := rows.Scan(&stat.UserID, &stat.Username, &stat.Count); err != nil {
-			return nil, fmt.Errorf("failed to scan author stat: %w", err)
+		var p ThroughputPoint
+		if err := rows.Scan(&p.BucketStart, &p.Created, &p.Merged); err != nil {
+			return nil, fmt.Errorf("failed to scan throughput point: %w", err)
 		}
-		stats = append(stats, stat)
+		p.BucketStart = p.BucketStart.UTC()
+		points = append(points, p)
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("rows iteration error: %w", err)
 	}
 
-	return stats, nil
+	return points, nil
 }
 
-// GetOverallStats returns overall statistics.
-func GetOverallStats(exec repository.DBTX) (*OverallStats, error) {
+// GetTopReviewers returns users with the most assignments on PRs merged at or after since
+// (any time if since is nil), ties broken by user ID. Users without such reviews are omitted.
+func GetTopReviewers(exec repository.DBTX, since *time.Time, limit int) ([]RankedUser, error) {
+	exec = repository.Named(exec, "stats.GetTopReviewers")
 	query := `
-		SELECT 
-			(SELECT COUNT(*) FROM pull_requests) as total_prs,
-			(SELECT COUNT(*) FROM pr_reviewers) as total_assignments,
-			(SELECT COUNT(*) FROM users) as total_users,
-			(SELECT COUNT(*) FROM teams) as total_teams
+		SELECT u.user_id, u.username, COUNT(*) AS completed_reviews
+		FROM pr_reviewers rev
+		JOIN pull_requests p ON p.org_id = rev.org_id AND p.repository_name = rev.repository_name AND p.pull_request_id = rev.pull_request_id
+		JOIN users u ON u.org_id = rev.org_id AND u.user_id = rev.user_id
+		WHERE rev.org_id = $3 AND p.merged_at IS NOT NULL AND ($1::timestamptz IS NULL OR p.merged_at >= $1)
+		GROUP BY u.user_id, u.username
+		ORDER BY completed_reviews DESC, u.user_id
+		LIMIT $2
 	`
-	var stats OverallStats
-	err := exec.QueryRow(query).Scan(
-		&stats.TotalPRs,
-		&stats.TotalAssignments,
-		&stats.TotalUsers,
-		&stats.TotalTeams,
-	)
+	return queryRanked(exec, "top reviewers", query, utcOrNil(since), limit, repository.Org(exec))
+}
+
+// GetTopAuthors returns users with the most PRs merged at or after since
+// (any time if since is nil), ties broken by user ID. Users without merged PRs are omitted.
+func GetTopAuthors(exec repository.DBTX, since *time.Time, limit int) ([]RankedUser, error) {
+	exec = repository.Named(exec, "stats.GetTopAuthors")
+	query := `
+		SELECT u.user_id, u.username, COUNT(*) AS merged_prs
+		FROM pull_requests p
+		JOIN users u ON u.org_id = p.org_id AND u.user_id = p.author_id
+		WHERE p.org_id = $3 AND p.merged_at IS NOT NULL AND ($1::timestamptz IS NULL OR p.merged_at >= $1)
+		GROUP BY u.user_id, u.username
+		ORDER BY merged_prs DESC, u.user_id
+		LIMIT $2
+	`
+	return queryRanked(exec, "top authors", query, utcOrNil(since), limit, repository.Org(exec))
+}
+
+// queryRanked runs a leaderboard query and scans its rows.
+func queryRanked(exec repository.DBTX, what, query string, args ...any) ([]RankedUser, error) {
+	rows, err := exec.Query(query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get overall stats: %w", err)
+		return nil, fmt.Errorf("failed to get %s: %w", what, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	ranked := make([]RankedUser, 0)
+	for rows.Next() {
+		var r RankedUser
+		if err := rows.Scan(&r.UserID, &r.Username, &r.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", what, err)
+		}
+		ranked = append(ranked, r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
 	}
 
-	return &stats, nil
+	return ranked, nil
 }
@@ -0,0 +1,7 @@
+package repository
+
+// UserTags returns an SQL expression evaluating to the sorted tags of the user row aliased as alias.
+// Scan it with pq.Array.
+func UserTags(alias string) string {
+	return `ARRAY(SELECT ut.tag FROM user_tags ut WHERE ut.org_id = ` + alias + `.org_id AND ut.user_id = ` + alias + `.user_id ORDER BY ut.tag)`
+}
@@ -2,30 +2,293 @@ package team
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 
+	"github.com/lib/pq"
+
 	"github.com/mishasvintus/avito_backend_internship/internal/domain"
 	"github.com/mishasvintus/avito_backend_internship/internal/repository"
 )
 
-// Create inserts a new team.
+// Create inserts a new team with the default assignment strategy.
+// Returns repository.ErrConflict if the team already exists.
 func Create(exec repository.DBTX, teamName string) error {
-	query := `INSERT INTO teams (team_name) VALUES ($1)`
-	_, err := exec.Exec(query, teamName)
+	exec = repository.Named(exec, "team.Create")
+	query := `INSERT INTO teams (team_name, org_id) VALUES ($1, $2)`
+	_, err := exec.Exec(query, teamName, repository.Org(exec))
 	if err != nil {
+		if repository.IsUniqueViolation(err) {
+			return fmt.Errorf("team %s: %w", teamName, repository.ErrConflict)
+		}
+		return fmt.Errorf("failed to create team: %w", err)
+	}
+	return nil
+}
+
+// CreateWithStrategy inserts a new team with the given assignment strategy.
+// Returns repository.ErrConflict if the team already exists.
+func CreateWithStrategy(exec repository.DBTX, teamName, strategy string) error {
+	exec = repository.Named(exec, "team.CreateWithStrategy")
+	query := `INSERT INTO teams (team_name, assignment_strategy, org_id) VALUES ($1, $2, $3)`
+	_, err := exec.Exec(query, teamName, strategy, repository.Org(exec))
+	if err != nil {
+		if repository.IsUniqueViolation(err) {
+			return fmt.Errorf("team %s: %w", teamName, repository.ErrConflict)
+		}
 		return fmt.Errorf("failed to create team: %w", err)
 	}
 	return nil
 }
 
-// Get retrieves a team with all its members.
+// GetStrategy returns the team's assignment strategy.
+// Returns repository.ErrNotFound if the team doesn't exist.
+func GetStrategy(exec repository.DBTX, teamName string) (string, error) {
+	exec = repository.Named(exec, "team.GetStrategy")
+	var strategy string
+	query := `SELECT assignment_strategy FROM teams WHERE team_name = $1 AND org_id = $2`
+	err := exec.QueryRow(query, teamName, repository.Org(exec)).Scan(&strategy)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fmt.Errorf("team %s: %w", teamName, repository.ErrNotFound)
+		}
+		return "", fmt.Errorf("failed to get team strategy: %w", err)
+	}
+	return strategy, nil
+}
+
+// SetStrategy updates the team's assignment strategy.
+// Returns repository.ErrNotFound if the team doesn't exist.
+func SetStrategy(exec repository.DBTX, teamName, strategy string) error {
+	exec = repository.Named(exec, "team.SetStrategy")
+	query := `UPDATE teams SET assignment_strategy = $1 WHERE team_name = $2 AND org_id = $3`
+	result, err := exec.Exec(query, strategy, teamName, repository.Org(exec))
+	if err != nil {
+		return fmt.Errorf("failed to update team strategy: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("team %s: %w", teamName, repository.ErrNotFound)
+	}
+
+	return nil
+}
+
+// GetRequireApprovals returns how many approvals the team's pull requests need before merge.
+// Returns repository.ErrNotFound if the team doesn't exist.
+func GetRequireApprovals(exec repository.DBTX, teamName string) (int, error) {
+	exec = repository.Named(exec, "team.GetRequireApprovals")
+	var requireApprovals int
+	query := `SELECT require_approvals FROM teams WHERE team_name = $1 AND org_id = $2`
+	err := exec.QueryRow(query, teamName, repository.Org(exec)).Scan(&requireApprovals)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("team %s: %w", teamName, repository.ErrNotFound)
+		}
+		return 0, fmt.Errorf("failed to get team approval requirement: %w", err)
+	}
+	return requireApprovals, nil
+}
+
+// SetRequireApprovals updates how many approvals the team's pull requests need before merge.
+// Returns repository.ErrNotFound if the team doesn't exist.
+func SetRequireApprovals(exec repository.DBTX, teamName string, requireApprovals int) error {
+	exec = repository.Named(exec, "team.SetRequireApprovals")
+	query := `UPDATE teams SET require_approvals = $1 WHERE team_name = $2 AND org_id = $3`
+	result, err := exec.Exec(query, requireApprovals, teamName, repository.Org(exec))
+	if err != nil {
+		return fmt.Errorf("failed to update team approval requirement: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("team %s: %w", teamName, repository.ErrNotFound)
+	}
+
+	return nil
+}
+
+// GetReviewSLAHours returns how many hours a review of the team's pull requests may wait, or 0 if the team has no SLA.
+// Returns repository.ErrNotFound if the team doesn't exist.
+func GetReviewSLAHours(exec repository.DBTX, teamName string) (int, error) {
+	exec = repository.Named(exec, "team.GetReviewSLAHours")
+	var hours int
+	query := `SELECT review_sla_hours FROM teams WHERE team_name = $1 AND org_id = $2`
+	err := exec.QueryRow(query, teamName, repository.Org(exec)).Scan(&hours)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("team %s: %w", teamName, repository.ErrNotFound)
+		}
+		return 0, fmt.Errorf("failed to get team review SLA: %w", err)
+	}
+	return hours, nil
+}
+
+// SetReviewSLAHours updates how many hours a review of the team's pull requests may wait; 0 removes the SLA.
+// Returns repository.ErrNotFound if the team doesn't exist.
+func SetReviewSLAHours(exec repository.DBTX, teamName string, hours int) error {
+	exec = repository.Named(exec, "team.SetReviewSLAHours")
+	query := `UPDATE teams SET review_sla_hours = $1 WHERE team_name = $2 AND org_id = $3`
+	result, err := exec.Exec(query, hours, teamName, repository.Org(exec))
+	if err != nil {
+		return fmt.Errorf("failed to update team review SLA: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("team %s: %w", teamName, repository.ErrNotFound)
+	}
+
+	return nil
+}
+
+// SetReviewerCount updates how many reviewers a new pull request of the team gets; 0 restores the default.
+// Returns repository.ErrNotFound if the team doesn't exist.
+func SetReviewerCount(exec repository.DBTX, teamName string, count int) error {
+	exec = repository.Named(exec, "team.SetReviewerCount")
+	query := `UPDATE teams SET reviewer_count = NULLIF($1, 0) WHERE team_name = $2 AND org_id = $3`
+	result, err := exec.Exec(query, count, teamName, repository.Org(exec))
+	if err != nil {
+		return fmt.Errorf("failed to update team reviewer count: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("team %s: %w", teamName, repository.ErrNotFound)
+	}
+
+	return nil
+}
+
+// SetFallbackTeamName updates the team whose members fill the reviewer slots the team's own members cannot;
+// an empty name removes it.
+// Returns repository.ErrNotFound if the team doesn't exist.
+func SetFallbackTeamName(exec repository.DBTX, teamName, fallbackTeamName string) error {
+	exec = repository.Named(exec, "team.SetFallbackTeamName")
+	query := `UPDATE teams SET fallback_team_name = NULLIF($1, '') WHERE team_name = $2 AND org_id = $3`
+	result, err := exec.Exec(query, fallbackTeamName, teamName, repository.Org(exec))
+	if err != nil {
+		return fmt.Errorf("failed to update team fallback team: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("team %s: %w", teamName, repository.ErrNotFound)
+	}
+
+	return nil
+}
+
+// GetSettings returns the team's settings, with domain.DefaultReviewerCount if the team has not set a reviewer count.
+// Returns repository.ErrNotFound if the team doesn't exist.
+func GetSettings(exec repository.DBTX, teamName string) (*domain.TeamSettings, error) {
+	exec = repository.Named(exec, "team.GetSettings")
+	var (
+		settings        domain.TeamSettings
+		reviewerCount   sql.NullInt64
+		slackWebhookURL sql.NullString
+		fallback        sql.NullString
+	)
+	query := `
+		SELECT assignment_strategy, reviewer_count, require_approvals, review_sla_hours, slack_webhook_url, fallback_team_name
+		FROM teams
+		WHERE team_name = $1 AND org_id = $2
+	`
+	err := exec.QueryRow(query, teamName, repository.Org(exec)).Scan(
+		&settings.AssignmentStrategy, &reviewerCount, &settings.RequireApprovals, &settings.ReviewSLAHours, &slackWebhookURL, &fallback,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("team %s: %w", teamName, repository.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get team settings: %w", err)
+	}
+
+	settings.ReviewerCount = domain.DefaultReviewerCount
+	if reviewerCount.Valid {
+		settings.ReviewerCount = int(reviewerCount.Int64)
+	}
+	settings.SlackWebhookURL = slackWebhookURL.String
+	settings.FallbackTeamName = fallback.String
+	return &settings, nil
+}
+
+// GetSlackWebhookURL returns the team's Slack incoming webhook, or "" if none is set.
+// Returns repository.ErrNotFound if the team doesn't exist.
+func GetSlackWebhookURL(exec repository.DBTX, teamName string) (string, error) {
+	exec = repository.Named(exec, "team.GetSlackWebhookURL")
+	var url sql.NullString
+	query := `SELECT slack_webhook_url FROM teams WHERE team_name = $1 AND org_id = $2`
+	err := exec.QueryRow(query, teamName, repository.Org(exec)).Scan(&url)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fmt.Errorf("team %s: %w", teamName, repository.ErrNotFound)
+		}
+		return "", fmt.Errorf("failed to get team slack webhook: %w", err)
+	}
+	return url.String, nil
+}
+
+// SetSlackWebhookURL updates the team's Slack incoming webhook; an empty url removes it.
+// Returns repository.ErrNotFound if the team doesn't exist.
+func SetSlackWebhookURL(exec repository.DBTX, teamName, url string) error {
+	exec = repository.Named(exec, "team.SetSlackWebhookURL")
+	query := `UPDATE teams SET slack_webhook_url = NULLIF($1, '') WHERE team_name = $2 AND org_id = $3`
+	result, err := exec.Exec(query, url, teamName, repository.Org(exec))
+	if err != nil {
+		return fmt.Errorf("failed to update team slack webhook: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("team %s: %w", teamName, repository.ErrNotFound)
+	}
+
+	return nil
+}
+
+// Get retrieves a team with all its members, including those whose primary team is another one.
+// Erased users are left out.
+// Returns repository.ErrNotFound if the team doesn't exist.
 func Get(exec repository.DBTX, teamName string) (*domain.Team, error) {
+	exec = repository.Named(exec, "team.Get")
+	settings, err := GetSettings(exec, teamName)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
-		SELECT user_id, username, is_active
-		FROM users
-		WHERE team_name = $1
+		SELECT u.user_id, u.username, u.is_active, u.max_open_reviews, u.assignment_weight, ` + repository.UserTags("u") + `
+		FROM team_memberships m
+		JOIN users u ON u.org_id = m.org_id AND u.user_id = m.user_id
+		WHERE m.team_name = $1 AND m.org_id = $2 AND u.erased_at IS NULL
 	`
-	rows, err := exec.Query(query, teamName)
+	rows, err := exec.Query(query, teamName, repository.Org(exec))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get team members: %w", err)
 	}
@@ -34,7 +297,7 @@ func Get(exec repository.DBTX, teamName string) (*domain.Team, error) {
 	members := make([]domain.TeamMember, 0)
 	for rows.Next() {
 		var member domain.TeamMember
-		if err := rows.Scan(&member.UserID, &member.Username, &member.IsActive); err != nil {
+		if err := rows.Scan(&member.UserID, &member.Username, &member.IsActive, &member.MaxOpenReviews, &member.AssignmentWeight, pq.Array(&member.Tags)); err != nil {
 			return nil, fmt.Errorf("failed to scan team member: %w", err)
 		}
 		members = append(members, member)
@@ -44,40 +307,104 @@ func Get(exec repository.DBTX, teamName string) (*domain.Team, error) {
 		return nil, fmt.Errorf("rows iteration error: %w", err)
 	}
 
-	// If no members, check if team exists
-	if len(members) == 0 {
-		exists, err := Exists(exec, teamName)
-		if err != nil {
-			return nil, err
-		}
-		if !exists {
-			return nil, sql.ErrNoRows
-		}
-	}
-
 	return &domain.Team{
-		TeamName: teamName,
-		Members:  members,
+		TeamName:           teamName,
+		AssignmentStrategy: settings.AssignmentStrategy,
+		RequireApprovals:   settings.RequireApprovals,
+		ReviewSLAHours:     settings.ReviewSLAHours,
+		SlackWebhookURL:    settings.SlackWebhookURL,
+		ReviewerCount:      settings.ReviewerCount,
+		FallbackTeamName:   settings.FallbackTeamName,
+		Members:            members,
 	}, nil
 }
 
 // Exists checks if a team exists.
 func Exists(exec repository.DBTX, teamName string) (bool, error) {
+	exec = repository.Named(exec, "team.Exists")
 	var exists bool
-	query := `SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)`
-	err := exec.QueryRow(query, teamName).Scan(&exists)
+	query := `SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1 AND org_id = $2)`
+	err := exec.QueryRow(query, teamName, repository.Org(exec)).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("failed to check team existence: %w", err)
 	}
 	return exists, nil
 }
 
-// DeactivateAll deactivates all users in the team.
+// Rename changes the team's name. Users, memberships, pull requests, ownership rules and digests
+// follow through their foreign keys; teams using it as their fallback team are updated here.
+// Returns repository.ErrNotFound if the team doesn't exist and repository.ErrConflict if newName is taken.
+func Rename(exec repository.DBTX, oldName, newName string) error {
+	exec = repository.Named(exec, "team.Rename")
+	query := `UPDATE teams SET team_name = $1 WHERE team_name = $2 AND org_id = $3`
+	result, err := exec.Exec(query, newName, oldName, repository.Org(exec))
+	if err != nil {
+		if repository.IsUniqueViolation(err) {
+			return fmt.Errorf("team %s: %w", newName, repository.ErrConflict)
+		}
+		return fmt.Errorf("failed to rename team: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("team %s: %w", oldName, repository.ErrNotFound)
+	}
+
+	query = `UPDATE teams SET fallback_team_name = $1 WHERE fallback_team_name = $2 AND org_id = $3`
+	if _, err := exec.Exec(query, newName, oldName, repository.Org(exec)); err != nil {
+		return fmt.Errorf("failed to update fallback team references: %w", err)
+	}
+	return nil
+}
+
+// AddMember adds the user to the team. Does nothing if the user is already a member.
+func AddMember(exec repository.DBTX, teamName, userID string, isPrimary bool) error {
+	exec = repository.Named(exec, "team.AddMember")
+	query := `
+		INSERT INTO team_memberships (user_id, team_name, is_primary, org_id)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (org_id, user_id, team_name) DO NOTHING
+	`
+	_, err := exec.Exec(query, userID, teamName, isPrimary, repository.Org(exec))
+	if err != nil {
+		return fmt.Errorf("failed to add team member: %w", err)
+	}
+	return nil
+}
+
+// DeactivateAll deactivates all members of the team.
 func DeactivateAll(exec repository.DBTX, teamName string) error {
-	query := `UPDATE users SET is_active = false WHERE team_name = $1`
-	_, err := exec.Exec(query, teamName)
+	exec = repository.Named(exec, "team.DeactivateAll")
+	query := `
+		UPDATE users SET is_active = false
+		WHERE org_id = $2 AND user_id IN (SELECT user_id FROM team_memberships WHERE team_name = $1 AND org_id = $2)
+	`
+	_, err := exec.Exec(query, teamName, repository.Org(exec))
 	if err != nil {
 		return fmt.Errorf("failed to deactivate team: %w", err)
 	}
 	return nil
 }
+
+// RemoveMember removes the user's secondary membership in the team; the primary membership is kept.
+// Returns repository.ErrNotFound if the user has no secondary membership in the team.
+func RemoveMember(exec repository.DBTX, teamName, userID string) error {
+	exec = repository.Named(exec, "team.RemoveMember")
+	query := `DELETE FROM team_memberships WHERE team_name = $1 AND user_id = $2 AND org_id = $3 AND NOT is_primary`
+	result, err := exec.Exec(query, teamName, userID, repository.Org(exec))
+	if err != nil {
+		return fmt.Errorf("failed to remove team member: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("membership of %s in %s: %w", userID, teamName, repository.ErrNotFound)
+	}
+	return nil
+}
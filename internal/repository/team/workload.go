@@ -0,0 +1,104 @@
+package team
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+)
+
+// GetMemberWorkloads returns the active members of the team, including those whose primary team is
+// another one, ordered by user ID, each with the open pull requests they review.
+func GetMemberWorkloads(exec repository.DBTX, teamName string) ([]domain.MemberWorkload, error) {
+	exec = repository.Named(exec, "team.GetMemberWorkloads")
+	query := `
+		SELECT u.user_id, u.username, o.repository_name, o.pull_request_id, o.pull_request_name, o.author_id, o.team_name, o.status,
+		       o.assigned_at, COALESCE(o.approved, false), COALESCE(o.review_sla_hours, 0)
+		FROM team_memberships m
+		JOIN users u ON u.org_id = m.org_id AND u.user_id = m.user_id
+		LEFT JOIN (
+			SELECT rev.org_id, rev.user_id, p.repository_name, p.pull_request_id, p.pull_request_name, p.author_id, p.team_name, p.status,
+			       rev.assigned_at, rev.approved_at IS NOT NULL AS approved, t.review_sla_hours
+			FROM pr_reviewers rev
+			JOIN pull_requests p ON p.org_id = rev.org_id AND p.repository_name = rev.repository_name AND p.pull_request_id = rev.pull_request_id
+			LEFT JOIN teams t ON t.org_id = p.org_id AND t.team_name = p.team_name
+			WHERE p.status = $3
+		) o ON o.org_id = u.org_id AND o.user_id = u.user_id
+		WHERE m.team_name = $1 AND m.org_id = $2 AND u.is_active = true AND u.erased_at IS NULL
+		ORDER BY u.user_id, o.assigned_at, o.repository_name, o.pull_request_id
+	`
+	rows, err := exec.Query(query, teamName, repository.Org(exec), domain.StatusOpen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get member workloads: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	members := make([]domain.MemberWorkload, 0)
+	for rows.Next() {
+		var userID, username string
+		var repositoryName, pullRequestID, pullRequestName, authorID, prTeamName, status sql.NullString
+		var assignedAt sql.NullTime
+		a := &domain.ReviewerAssignment{}
+		if err := rows.Scan(&userID, &username, &repositoryName, &pullRequestID, &pullRequestName, &authorID, &prTeamName, &status,
+			&assignedAt, &a.Approved, &a.ReviewSLAHours); err != nil {
+			return nil, fmt.Errorf("failed to scan member workload: %w", err)
+		}
+		if n := len(members); n == 0 || members[n-1].UserID != userID {
+			members = append(members, domain.MemberWorkload{UserID: userID, Username: username, PullRequests: []domain.PullRequestShort{}})
+		}
+		if !pullRequestID.Valid {
+			continue
+		}
+
+		a.UserID = userID
+		a.AssignedAt = assignedAt.Time.UTC()
+		last := &members[len(members)-1]
+		last.PullRequests = append(last.PullRequests, domain.PullRequestShort{
+			RepositoryName:  repositoryName.String,
+			PullRequestID:   pullRequestID.String,
+			PullRequestName: pullRequestName.String,
+			AuthorID:        authorID.String,
+			TeamName:        prTeamName.String,
+			Status:          domain.PRStatus(status.String),
+			Assignment:      a,
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return members, nil
+}
+
+// PRStaffing counts the team's open pull requests by how many reviewers they have.
+type PRStaffing struct {
+	OpenPRs          int
+	UnassignedPRs    int
+	UnderAssignedPRs int
+}
+
+// GetPRStaffing counts the team's open pull requests, those without reviewers,
+// and those with at least one but fewer than reviewerCount reviewers.
+func GetPRStaffing(exec repository.DBTX, teamName string, reviewerCount int) (*PRStaffing, error) {
+	exec = repository.Named(exec, "team.GetPRStaffing")
+	query := `
+		SELECT COUNT(*),
+		       COUNT(*) FILTER (WHERE s.reviewers = 0),
+		       COUNT(*) FILTER (WHERE s.reviewers > 0 AND s.reviewers < $4)
+		FROM (
+			SELECT COUNT(rev.user_id) AS reviewers
+			FROM pull_requests p
+			LEFT JOIN pr_reviewers rev ON rev.org_id = p.org_id AND rev.repository_name = p.repository_name AND rev.pull_request_id = p.pull_request_id
+			WHERE p.team_name = $1 AND p.org_id = $2 AND p.status = $3
+			GROUP BY p.org_id, p.repository_name, p.pull_request_id
+		) s
+	`
+	var s PRStaffing
+	err := exec.QueryRow(query, teamName, repository.Org(exec), domain.StatusOpen, reviewerCount).Scan(&s.OpenPRs, &s.UnassignedPRs, &s.UnderAssignedPRs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request staffing: %w", err)
+	}
+	return &s, nil
+}
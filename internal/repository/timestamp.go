@@ -0,0 +1,13 @@
+package repository
+
+import "time"
+
+// UTC returns a TIMESTAMPTZ value in UTC, or nil for NULL. The driver returns timestamps in the
+// session time zone, which depends on the server configuration.
+func UTC(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	u := t.UTC()
+	return &u
+}
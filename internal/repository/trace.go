@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+
+	"github.com/XSAM/otelsql"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// contextExecutor is implemented by *sql.DB and *sql.Tx.
+type contextExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// boundDB runs every statement with a fixed context.
+type boundDB struct {
+	ctx  context.Context
+	exec contextExecutor
+}
+
+// statementNameKey is the context key of the statement name set by Named.
+type statementNameKey struct{}
+
+// WithContext returns a DBTX that runs every statement of exec with ctx, so statements are
+// cancelled with it and traced under its span.
+// exec is returned unchanged if it cannot take a context.
+func WithContext(ctx context.Context, exec DBTX) DBTX {
+	if bound, ok := exec.(boundDB); ok {
+		return boundDB{ctx: ctx, exec: bound.exec}
+	}
+	ce, ok := exec.(contextExecutor)
+	if !ok {
+		return exec
+	}
+	return boundDB{ctx: ctx, exec: ce}
+}
+
+// Named returns exec with its statements named name, e.g. "pr.GetStatus". On a database opened with
+// OpenTraced, the statements of a traced context become child spans of that name, and the rows they
+// return get a span named name + " rows" that ends when the rows are closed. Statements without a
+// name are not traced. Repository functions name their statements after themselves.
+// exec is returned unchanged if it cannot take a context.
+func Named(exec DBTX, name string) DBTX {
+	ctx := context.Background()
+	if bound, ok := exec.(boundDB); ok {
+		ctx = bound.ctx
+	}
+	return WithContext(context.WithValue(ctx, statementNameKey{}, name), exec)
+}
+
+func (b boundDB) Exec(query string, args ...any) (sql.Result, error) {
+	res, err := b.exec.ExecContext(b.ctx, query, args...)
+	observe(b.ctx, err)
+	return res, err
+}
+
+func (b boundDB) Query(query string, args ...any) (*sql.Rows, error) {
+	rows, err := b.exec.QueryContext(b.ctx, query, args...)
+	observe(b.ctx, err)
+	return rows, err
+}
+
+func (b boundDB) QueryRow(query string, args ...any) *sql.Row {
+	return b.QueryRowContext(b.ctx, query, args...)
+}
+
+func (b boundDB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	if name, ok := b.ctx.Value(statementNameKey{}).(string); ok {
+		ctx = context.WithValue(ctx, statementNameKey{}, name)
+	}
+	row := b.exec.QueryRowContext(ctx, query, args...)
+	observe(ctx, row.Err())
+	return row
+}
+
+// OpenTraced is sql.Open for a database whose named statements are traced, see Named.
+func OpenTraced(driverName, dsn string) (*sql.DB, error) {
+	return otelsql.Open(driverName, dsn,
+		otelsql.WithAttributes(semconv.DBSystemNamePostgreSQL),
+		otelsql.WithSpanNameFormatter(spanName),
+		otelsql.WithAttributesGetter(queryAttributes),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{
+			// db.statement is replaced by db.query.text from queryAttributes.
+			DisableQuery:         true,
+			OmitConnResetSession: true,
+			OmitConnPrepare:      true,
+			SpanFilter:           traced,
+		}),
+	)
+}
+
+// traced tells whether a span is wanted for the statement: it has to be named and run in a traced context.
+func traced(ctx context.Context, _ otelsql.Method, _ string, _ []driver.NamedValue) bool {
+	_, named := ctx.Value(statementNameKey{}).(string)
+	return named && trace.SpanFromContext(ctx).IsRecording()
+}
+
+// spanName names the span of a statement after the statement and the span of its rows after both.
+func spanName(ctx context.Context, method otelsql.Method, _ string) string {
+	name, _ := ctx.Value(statementNameKey{}).(string)
+	if method == otelsql.MethodRows {
+		return name + " rows"
+	}
+	return name
+}
+
+func queryAttributes(_ context.Context, method otelsql.Method, query string, _ []driver.NamedValue) []attribute.KeyValue {
+	if method == otelsql.MethodRows {
+		return nil
+	}
+	return []attribute.KeyValue{semconv.DBQueryText(query)}
+}
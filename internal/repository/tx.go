@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// WithTx runs fn inside a transaction on db.
+// The transaction is committed if fn returns nil and rolled back if fn returns an error or panics;
+// a panic is recovered and returned as an error. Errors from fn are returned unchanged.
+// fn gets the transaction bound to ctx with WithContext; cancelling ctx rolls it back.
+func WithTx(ctx context.Context, db *sql.DB, fn func(tx DBTX) error) (err error) {
+	tx, err := db.BeginTx(ctx, nil)
+	observe(ctx, err)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			_ = tx.Rollback()
+			err = fmt.Errorf("transaction aborted by panic: %v", r)
+		}
+	}()
+
+	if err := fn(WithContext(ctx, tx)); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	err = tx.Commit()
+	observe(ctx, err)
+	if err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -2,56 +2,127 @@ package user
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 
+	"github.com/lib/pq"
+
 	"github.com/mishasvintus/avito_backend_internship/internal/domain"
 	"github.com/mishasvintus/avito_backend_internship/internal/repository"
 )
 
-// Create inserts a new user.
+// Create inserts a new user and makes user.TeamName their primary team membership.
 func Create(exec repository.DBTX, user *domain.User) error {
+	exec = repository.Named(exec, "user.Create")
 	query := `
-		INSERT INTO users (user_id, username, team_name, is_active)
-		VALUES ($1, $2, $3, $4)
+		WITH created AS (
+			INSERT INTO users (user_id, username, team_name, is_active, max_open_reviews, assignment_weight, org_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING user_id, team_name, org_id
+		)
+		INSERT INTO team_memberships (user_id, team_name, is_primary, org_id)
+		SELECT user_id, team_name, true, org_id FROM created
 	`
-	_, err := exec.Exec(query, user.UserID, user.Username, user.TeamName, user.IsActive)
+	_, err := exec.Exec(query, user.UserID, user.Username, user.TeamName, user.IsActive, user.MaxOpenReviews, weightOrDefault(user.AssignmentWeight), repository.Org(exec))
 	if err != nil {
+		if repository.IsUniqueViolation(err) {
+			return fmt.Errorf("user %s: %w", user.UserID, repository.ErrConflict)
+		}
 		return fmt.Errorf("failed to create user: %w", err)
 	}
 	return nil
 }
 
-// Get retrieves a user by ID.
+// weightOrDefault replaces an unset weight with domain.DefaultAssignmentWeight.
+func weightOrDefault(weight float64) float64 {
+	if weight <= 0 {
+		return domain.DefaultAssignmentWeight
+	}
+	return weight
+}
+
+// Get retrieves a user by ID, erased users included.
+// Returns repository.ErrNotFound if the user doesn't exist.
 func Get(exec repository.DBTX, userID string) (*domain.User, error) {
+	exec = repository.Named(exec, "user.Get")
 	query := `
-		SELECT user_id, username, team_name, is_active
+		SELECT user_id, username, team_name, is_active, max_open_reviews, assignment_weight, erased_at IS NOT NULL
 		FROM users
-		WHERE user_id = $1
+		WHERE user_id = $1 AND org_id = $2
 	`
 	var u domain.User
-	err := exec.QueryRow(query, userID).Scan(
+	err := exec.QueryRow(query, userID, repository.Org(exec)).Scan(
 		&u.UserID,
 		&u.Username,
 		&u.TeamName,
 		&u.IsActive,
+		&u.MaxOpenReviews,
+		&u.AssignmentWeight,
+		&u.Erased,
 	)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, err
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("user %s: %w", userID, repository.ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 	return &u, nil
 }
 
-// Update updates user's team_name, username, and is_active.
+// FirstInactive returns the first of userIDs that is not an active user and whether that user exists,
+// or "" if all of them are active. Erased users are reported as not existing.
+func FirstInactive(exec repository.DBTX, userIDs []string) (string, bool, error) {
+	exec = repository.Named(exec, "user.FirstInactive")
+	query := `
+		SELECT r.user_id, u.user_id IS NOT NULL AND u.erased_at IS NULL
+		FROM unnest($1::text[]) WITH ORDINALITY AS r(user_id, n)
+		LEFT JOIN users u ON u.org_id = $2 AND u.user_id = r.user_id
+		WHERE u.is_active IS DISTINCT FROM true OR u.erased_at IS NOT NULL
+		ORDER BY r.n
+		LIMIT 1
+	`
+	var (
+		userID string
+		exists bool
+	)
+	err := exec.QueryRow(query, pq.Array(userIDs), repository.Org(exec)).Scan(&userID, &exists)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("failed to find inactive user: %w", err)
+	}
+	return userID, exists, nil
+}
+
+// LockActive locks the user row until the end of the transaction, so that the user cannot be
+// deactivated or erased before the transaction commits, and reports whether the user is active.
+// Erased users are not active. Returns repository.ErrNotFound if the user doesn't exist.
+func LockActive(exec repository.DBTX, userID string) (bool, error) {
+	exec = repository.Named(exec, "user.LockActive")
+	query := `SELECT is_active AND erased_at IS NULL FROM users WHERE user_id = $1 AND org_id = $2 FOR SHARE`
+	var active bool
+	err := exec.QueryRow(query, userID, repository.Org(exec)).Scan(&active)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, fmt.Errorf("user %s: %w", userID, repository.ErrNotFound)
+		}
+		return false, fmt.Errorf("failed to lock user: %w", err)
+	}
+	return active, nil
+}
+
+// Update updates user's username, is_active, max_open_reviews and assignment_weight.
+// The primary team is left unchanged.
+// Returns repository.ErrNotFound if the user doesn't exist or is erased.
 func Update(exec repository.DBTX, user *domain.User) error {
+	exec = repository.Named(exec, "user.Update")
 	query := `
 		UPDATE users 
-		SET username = $1, team_name = $2, is_active = $3
-		WHERE user_id = $4
+		SET username = $1, is_active = $2, max_open_reviews = $3, assignment_weight = $4
+		WHERE user_id = $5 AND org_id = $6 AND erased_at IS NULL
 	`
-	result, err := exec.Exec(query, user.Username, user.TeamName, user.IsActive, user.UserID)
+	result, err := exec.Exec(query, user.Username, user.IsActive, user.MaxOpenReviews, weightOrDefault(user.AssignmentWeight), user.UserID, repository.Org(exec))
 	if err != nil {
 		return fmt.Errorf("failed to update user: %w", err)
 	}
@@ -62,30 +133,35 @@ func Update(exec repository.DBTX, user *domain.User) error {
 	}
 
 	if rowsAffected == 0 {
-		return sql.ErrNoRows
+		return fmt.Errorf("user %s: %w", user.UserID, repository.ErrNotFound)
 	}
 
 	return nil
 }
 
 // SetIsActive updates the is_active status and returns the updated user.
+// An erased user can be deactivated but not activated.
+// Returns repository.ErrNotFound if the user doesn't exist, or is erased and isActive is set.
 func SetIsActive(exec repository.DBTX, userID string, isActive bool) (*domain.User, error) {
+	exec = repository.Named(exec, "user.SetIsActive")
 	query := `
 		UPDATE users 
 		SET is_active = $1 
-		WHERE user_id = $2 
-		RETURNING user_id, username, team_name, is_active
+		WHERE user_id = $2 AND org_id = $3 AND (erased_at IS NULL OR NOT $1)
+		RETURNING user_id, username, team_name, is_active, max_open_reviews, assignment_weight
 	`
 	var u domain.User
-	err := exec.QueryRow(query, isActive, userID).Scan(
+	err := exec.QueryRow(query, isActive, userID, repository.Org(exec)).Scan(
 		&u.UserID,
 		&u.Username,
 		&u.TeamName,
 		&u.IsActive,
+		&u.MaxOpenReviews,
+		&u.AssignmentWeight,
 	)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, err
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("user %s: %w", userID, repository.ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to update user status: %w", err)
 	}
@@ -93,55 +169,267 @@ func SetIsActive(exec repository.DBTX, userID string, isActive bool) (*domain.Us
 	return &u, nil
 }
 
-// GetActiveTeammates returns all active users from the same team, excluding the given user.
-func GetActiveTeammates(exec repository.DBTX, userID string) ([]domain.User, error) {
+// Erase replaces the username with domain.ErasedUsername, deactivates the user and marks them
+// erased, which keeps them out of candidate pools and team listings for good.
+// The user row stays so that authored PRs and history keep resolving. Erasing again keeps
+// the original erasure time. Returns the updated user.
+// Returns repository.ErrNotFound if the user doesn't exist.
+func Erase(exec repository.DBTX, userID string) (*domain.User, error) {
+	exec = repository.Named(exec, "user.Erase")
 	query := `
-		SELECT u2.user_id, u2.username, u2.team_name, u2.is_active
-		FROM users u1
-		JOIN users u2 ON u1.team_name = u2.team_name
-		WHERE u1.user_id = $1 
-		  AND u2.user_id != $1
-		  AND u2.is_active = true
+		UPDATE users
+		SET username = $1, is_active = false, erased_at = COALESCE(erased_at, NOW())
+		WHERE user_id = $2 AND org_id = $3
+		RETURNING user_id, username, team_name, is_active, max_open_reviews, assignment_weight
 	`
-	rows, err := exec.Query(query, userID)
+	var u domain.User
+	err := exec.QueryRow(query, domain.ErasedUsername, userID, repository.Org(exec)).Scan(
+		&u.UserID,
+		&u.Username,
+		&u.TeamName,
+		&u.IsActive,
+		&u.MaxOpenReviews,
+		&u.AssignmentWeight,
+	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get active teammates: %w", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("user %s: %w", userID, repository.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to erase user: %w", err)
+	}
+
+	return &u, nil
+}
+
+// SetPrimaryTeam makes teamName the user's primary team.
+// The previous primary team is kept as a secondary membership.
+// Returns repository.ErrNotFound if the user doesn't exist.
+func SetPrimaryTeam(exec repository.DBTX, userID, teamName string) error {
+	exec = repository.Named(exec, "user.SetPrimaryTeam")
+	orgID := repository.Org(exec)
+	result, err := exec.Exec(`UPDATE users SET team_name = $1 WHERE user_id = $2 AND org_id = $3`, teamName, userID, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to update primary team: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("user %s: %w", userID, repository.ErrNotFound)
+	}
+
+	if _, err := exec.Exec(`UPDATE team_memberships SET is_primary = false WHERE user_id = $1 AND org_id = $2 AND is_primary`, userID, orgID); err != nil {
+		return fmt.Errorf("failed to reset primary membership: %w", err)
+	}
+
+	query := `
+		INSERT INTO team_memberships (user_id, team_name, is_primary, org_id)
+		VALUES ($1, $2, true, $3)
+		ON CONFLICT (org_id, user_id, team_name) DO UPDATE SET is_primary = true
+	`
+	if _, err := exec.Exec(query, userID, teamName, orgID); err != nil {
+		return fmt.Errorf("failed to set primary membership: %w", err)
+	}
+
+	return nil
+}
+
+// SetMaxOpenReviews updates the review capacity and returns the updated user.
+// A nil limit removes the capacity restriction.
+// Returns repository.ErrNotFound if the user doesn't exist.
+func SetMaxOpenReviews(exec repository.DBTX, userID string, maxOpenReviews *int) (*domain.User, error) {
+	exec = repository.Named(exec, "user.SetMaxOpenReviews")
+	query := `
+		UPDATE users 
+		SET max_open_reviews = $1 
+		WHERE user_id = $2 AND org_id = $3
+		RETURNING user_id, username, team_name, is_active, max_open_reviews, assignment_weight
+	`
+	var u domain.User
+	err := exec.QueryRow(query, maxOpenReviews, userID, repository.Org(exec)).Scan(
+		&u.UserID,
+		&u.Username,
+		&u.TeamName,
+		&u.IsActive,
+		&u.MaxOpenReviews,
+		&u.AssignmentWeight,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("user %s: %w", userID, repository.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to update user capacity: %w", err)
+	}
+
+	return &u, nil
+}
+
+// GetTags returns the user's expertise tags, sorted.
+func GetTags(exec repository.DBTX, userID string) ([]string, error) {
+	exec = repository.Named(exec, "user.GetTags")
+	rows, err := exec.Query(`SELECT tag FROM user_tags WHERE user_id = $1 AND org_id = $2 ORDER BY tag`, userID, repository.Org(exec))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user tags: %w", err)
 	}
 	defer func() { _ = rows.Close() }()
 
-	var teammates []domain.User
+	tags := make([]string, 0)
 	for rows.Next() {
-		var u domain.User
-		if err := rows.Scan(&u.UserID, &u.Username, &u.TeamName, &u.IsActive); err != nil {
-			return nil, fmt.Errorf("failed to scan teammate: %w", err)
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, fmt.Errorf("failed to scan user tag: %w", err)
 		}
-		teammates = append(teammates, u)
+		tags = append(tags, tag)
 	}
-
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("rows iteration error: %w", err)
 	}
+	return tags, nil
+}
 
-	return teammates, nil
+// SetTags replaces the user's expertise tags.
+func SetTags(exec repository.DBTX, userID string, tags []string) error {
+	exec = repository.Named(exec, "user.SetTags")
+	orgID := repository.Org(exec)
+	if _, err := exec.Exec(`DELETE FROM user_tags WHERE user_id = $1 AND org_id = $2`, userID, orgID); err != nil {
+		return fmt.Errorf("failed to clear user tags: %w", err)
+	}
+
+	query := `INSERT INTO user_tags (user_id, tag, org_id) VALUES ($1, $2, $3)`
+	for _, tag := range tags {
+		if _, err := exec.Exec(query, userID, tag, orgID); err != nil {
+			return fmt.Errorf("failed to insert user tag: %w", err)
+		}
+	}
+	return nil
 }
 
-// GetActiveByTeam returns all active users in the given team.
+// openReviewsCount counts OPEN PRs reviewed by the user aliased as u.
+const openReviewsCount = `(
+	SELECT COUNT(*)
+	FROM pr_reviewers rev
+	JOIN pull_requests p ON p.org_id = rev.org_id AND p.repository_name = rev.repository_name AND p.pull_request_id = rev.pull_request_id
+	WHERE rev.org_id = u.org_id AND rev.user_id = u.user_id AND p.status = 'OPEN'
+)`
+
+// openReviewLoad sums the size weights of OPEN PRs reviewed by the user aliased as u.
+var openReviewLoad = `(
+	SELECT COALESCE(SUM(` + repository.SizeWeight("p.size") + `), 0)
+	FROM pr_reviewers rev
+	JOIN pull_requests p ON p.org_id = rev.org_id AND p.repository_name = rev.repository_name AND p.pull_request_id = rev.pull_request_id
+	WHERE rev.org_id = u.org_id AND rev.user_id = u.user_id AND p.status = 'OPEN'
+)`
+
+// lastAssignedAt returns when the user aliased as u was last assigned a review (NULL if never).
+const lastAssignedAt = `(
+	SELECT MAX(rev.assigned_at)
+	FROM pr_reviewers rev
+	WHERE rev.org_id = u.org_id AND rev.user_id = u.user_id
+)`
+
+// notAbsent excludes the user aliased as u if an absence window covers today.
+const notAbsent = `NOT EXISTS (
+	SELECT 1
+	FROM user_absences a
+	WHERE a.org_id = u.org_id AND a.user_id = u.user_id AND CURRENT_DATE BETWEEN a.from_date AND a.to_date
+)`
+
+// notExcludedFor excludes the user aliased as u if they must not review the author bound to the given placeholder.
+func notExcludedFor(authorParam string) string {
+	return `NOT EXISTS (
+	SELECT 1
+	FROM reviewer_exclusions x
+	WHERE x.org_id = u.org_id AND x.reviewer_id = u.user_id AND x.author_id = ` + authorParam + `
+)`
+}
+
+// candidateColumns are the columns scanned by scanCandidates.
+var candidateColumns = `u.user_id, u.username, u.team_name, u.is_active, u.max_open_reviews, u.assignment_weight, ` +
+	openReviewsCount + `, ` + openReviewLoad + `, ` + lastAssignedAt + `, ` + repository.UserTags("u")
+
+// GetActiveTeammates returns all active members of the given user's primary team, excluding the given user,
+// erased users, users who are absent today and users excluded from reviewing the given user.
+// Each user carries its current open review count, open review load, last assignment time and tags.
+func GetActiveTeammates(exec repository.DBTX, userID string) ([]domain.User, error) {
+	exec = repository.Named(exec, "user.GetActiveTeammates")
+	query := `
+		SELECT ` + candidateColumns + `
+		FROM users author
+		JOIN team_memberships m ON m.org_id = author.org_id AND m.team_name = author.team_name
+		JOIN users u ON u.org_id = m.org_id AND u.user_id = m.user_id
+		WHERE author.user_id = $1 AND author.org_id = $2
+		  AND u.user_id != $1
+		  AND u.is_active = true
+		  AND u.erased_at IS NULL
+		  AND ` + notAbsent + `
+		  AND ` + notExcludedFor("$1") + `
+	`
+	rows, err := exec.Query(query, userID, repository.Org(exec))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active teammates: %w", err)
+	}
+	return scanCandidates(rows)
+}
+
+// GetActiveByTeam returns all active, not erased members of the given team who are not absent today.
+// Each user carries its current open review count, open review load, last assignment time and tags.
 func GetActiveByTeam(exec repository.DBTX, teamName string) ([]domain.User, error) {
+	exec = repository.Named(exec, "user.GetActiveByTeam")
 	query := `
-		SELECT user_id, username, team_name, is_active
-		FROM users
-		WHERE team_name = $1 AND is_active = true
+		SELECT ` + candidateColumns + `
+		FROM team_memberships m
+		JOIN users u ON u.org_id = m.org_id AND u.user_id = m.user_id
+		WHERE m.team_name = $1 AND m.org_id = $2 AND u.is_active = true AND u.erased_at IS NULL AND ` + notAbsent + `
 	`
-	rows, err := exec.Query(query, teamName)
+	rows, err := exec.Query(query, teamName, repository.Org(exec))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get active users by team: %w", err)
 	}
+	return scanCandidates(rows)
+}
+
+// GetReassignCandidates is GetActiveByTeam without users excluded from reviewing the author.
+// The author is not filtered out; callers exclude them together with assigned reviewers.
+func GetReassignCandidates(exec repository.DBTX, teamName, authorID string) ([]domain.User, error) {
+	return GetReassignCandidatesOutside(exec, teamName, authorID, "")
+}
+
+// GetReassignCandidatesOutside is GetReassignCandidates without the members of excludedTeam,
+// such as a team being deactivated whose members also belong to teamName. An empty excludedTeam excludes no one.
+func GetReassignCandidatesOutside(exec repository.DBTX, teamName, authorID, excludedTeam string) ([]domain.User, error) {
+	exec = repository.Named(exec, "user.GetReassignCandidatesOutside")
+	query := `
+		SELECT ` + candidateColumns + `
+		FROM team_memberships m
+		JOIN users u ON u.org_id = m.org_id AND u.user_id = m.user_id
+		WHERE m.team_name = $1
+		  AND m.org_id = $3
+		  AND u.is_active = true
+		  AND u.erased_at IS NULL
+		  AND ` + notAbsent + `
+		  AND ` + notExcludedFor("$2") + `
+		  AND NOT EXISTS (
+			SELECT 1 FROM team_memberships e
+			WHERE e.org_id = u.org_id AND e.user_id = u.user_id AND e.team_name = $4
+		  )
+	`
+	rows, err := exec.Query(query, teamName, authorID, repository.Org(exec), excludedTeam)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reassign candidates: %w", err)
+	}
+	return scanCandidates(rows)
+}
+
+// scanCandidates reads rows selected with candidateColumns and closes them.
+func scanCandidates(rows *sql.Rows) ([]domain.User, error) {
 	defer func() { _ = rows.Close() }()
 
 	var users []domain.User
 	for rows.Next() {
 		var u domain.User
-		if err := rows.Scan(&u.UserID, &u.Username, &u.TeamName, &u.IsActive); err != nil {
+		if err := rows.Scan(&u.UserID, &u.Username, &u.TeamName, &u.IsActive, &u.MaxOpenReviews, &u.AssignmentWeight, &u.OpenReviews, &u.OpenReviewLoad, &u.LastAssignedAt, pq.Array(&u.Tags)); err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
 		}
 		users = append(users, u)
@@ -0,0 +1,72 @@
+package webhook
+
+import (
+	"fmt"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+)
+
+// Create inserts a webhook and fills in its generated ID and creation time.
+func Create(exec repository.DBTX, w *domain.Webhook) error {
+	exec = repository.Named(exec, "webhook.Create")
+	query := `
+		INSERT INTO webhooks (url, secret, org_id)
+		VALUES ($1, $2, $3)
+		RETURNING webhook_id, created_at
+	`
+	if err := exec.QueryRow(query, w.URL, w.Secret, repository.Org(exec)).Scan(&w.ID, &w.CreatedAt); err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a webhook.
+// Returns repository.ErrNotFound if it doesn't exist.
+func Delete(exec repository.DBTX, id int64) error {
+	exec = repository.Named(exec, "webhook.Delete")
+	result, err := exec.Exec(`DELETE FROM webhooks WHERE webhook_id = $1 AND org_id = $2`, id, repository.Org(exec))
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("webhook %d: %w", id, repository.ErrNotFound)
+	}
+	return nil
+}
+
+// List returns all webhooks of the organization, secrets included, ordered by ID.
+func List(exec repository.DBTX) ([]domain.Webhook, error) {
+	exec = repository.Named(exec, "webhook.List")
+	query := `
+		SELECT webhook_id, url, secret, created_at
+		FROM webhooks
+		WHERE org_id = $1
+		ORDER BY webhook_id
+	`
+	rows, err := exec.Query(query, repository.Org(exec))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	webhooks := make([]domain.Webhook, 0)
+	for rows.Next() {
+		var w domain.Webhook
+		if err := rows.Scan(&w.ID, &w.URL, &w.Secret, &w.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook: %w", err)
+		}
+		webhooks = append(webhooks, w)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return webhooks, nil
+}
@@ -1,36 +1,206 @@
 package router
 
 import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
 
+	"github.com/mishasvintus/avito_backend_internship/api"
 	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/middleware"
 )
 
-// SetupRoutes configures all API routes.
+// APIPrefix is the path prefix of the current API version.
+const APIPrefix = "/api/v1"
+
+// Options configures the gin engine.
+type Options struct {
+	// Mode is the gin mode: debug, release or test.
+	Mode string
+	// TrustedProxies lists proxy IPs or CIDRs whose X-Forwarded-For header is used to resolve
+	// the client IP. Empty means no proxy is trusted and the peer address is the client IP.
+	TrustedProxies []string
+	// MaxBodyBytes caps request bodies; larger requests get 413 PAYLOAD_TOO_LARGE.
+	MaxBodyBytes int64
+	// GzipMinSize is the smallest response compressed for clients accepting gzip; zero disables it.
+	GzipMinSize int
+	// RequestTimeout bounds request handling; slower requests get 504 TIMEOUT. Zero disables it.
+	RequestTimeout time.Duration
+	// CORS configures cross-origin access; no allowed origins disables it.
+	CORS middleware.CORSOptions
+	// DisableLegacyRoutes removes the deprecated unversioned aliases of the APIPrefix routes.
+	DisableLegacyRoutes bool
+	// DocsUI mounts Swagger UI at /docs. The specification is served at /openapi.json regardless.
+	DocsUI bool
+	// RateLimiter limits requests per client; nil disables rate limiting.
+	RateLimiter *middleware.RateLimiter
+	// AdminAPIKeys are the X-API-Key values accepted for admin endpoints; empty disables them.
+	AdminAPIKeys []string
+	// TracerProvider records a span per request; nil disables tracing.
+	TracerProvider trace.TracerProvider
+	// Metrics serves Prometheus metrics at /metrics; nil leaves the route out.
+	Metrics http.Handler
+	// CircuitBreaker fails API requests fast while the database is unavailable and is reported
+	// by /health; nil disables it.
+	CircuitBreaker *middleware.CircuitBreaker
+	// Orgs resolves the organizations named by the X-Org-ID header; nil serves only the default one.
+	Orgs middleware.OrgResolver
+	// LatencyBudget logs and counts requests slower than their route's budget; nil disables it.
+	// The Server-Timing header is sent regardless.
+	LatencyBudget *middleware.LatencyBudget
+}
+
+// SetupRoutes configures all API routes under APIPrefix and, unless disabled, their deprecated
+// unversioned aliases served by the same handlers.
+// Requests are logged with the client IP resolved through the trusted proxies.
+// API requests are scoped to the organization named by the X-Org-ID header.
 func SetupRoutes(
+	opts Options,
 	teamHandler *handler.TeamHandler,
 	userHandler *handler.UserHandler,
 	prHandler *handler.PRHandler,
 	statsHandler *handler.StatsHandler,
-) *gin.Engine {
-	r := gin.Default()
+	webhookHandler *handler.WebhookHandler,
+	integrationHandler *handler.IntegrationHandler,
+	auditHandler *handler.AuditHandler,
+	orgHandler *handler.OrgHandler,
+) (*gin.Engine, error) {
+	gin.SetMode(opts.Mode)
+
+	r := gin.New()
+	if err := r.SetTrustedProxies(opts.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+	// gin.Logger records c.ClientIP(), which honours X-Forwarded-For only from trusted proxies.
+	r.Use(
+		middleware.Tracing(opts.TracerProvider),
+		middleware.RequestID(),
+		middleware.Latency(opts.LatencyBudget, APIPrefix),
+		gin.Logger(),
+		middleware.Recovery(slog.Default()),
+		middleware.CORS(opts.CORS),
+		middleware.Authenticate(opts.AdminAPIKeys),
+		middleware.Audit(),
+		middleware.RateLimit(opts.RateLimiter),
+		middleware.BodyLimit(opts.MaxBodyBytes),
+		// /metrics is left to the Prometheus handler, which negotiates compression itself.
+		middleware.Gzip(opts.GzipMinSize, "/metrics"),
+		middleware.Timeout(opts.RequestTimeout),
+	)
 
+	spec, err := api.SpecJSON()
+	if err != nil {
+		return nil, err
+	}
+	docsHandler := handler.NewDocsHandler(spec)
+	r.GET("/openapi.json", docsHandler.OpenAPI)
+	if opts.DocsUI {
+		r.GET("/docs", docsHandler.SwaggerUI)
+	}
+
+	if opts.Metrics != nil {
+		r.GET("/metrics", gin.WrapH(opts.Metrics))
+	}
+
+	var circuitState func() string
+	if opts.CircuitBreaker != nil {
+		circuitState = func() string { return string(opts.CircuitBreaker.State()) }
+	}
+	r.GET("/health", handler.NewHealthHandler(circuitState).Health)
+
+	breaker := middleware.Breaker(opts.CircuitBreaker)
+	org := middleware.Org(opts.Orgs)
+	registerRoutes(r.Group(APIPrefix, breaker, org), teamHandler, userHandler, prHandler, statsHandler, webhookHandler, integrationHandler, auditHandler, orgHandler)
+	if !opts.DisableLegacyRoutes {
+		registerRoutes(r.Group("", middleware.Deprecated(APIPrefix), breaker, org), teamHandler, userHandler, prHandler, statsHandler, webhookHandler, integrationHandler, auditHandler, orgHandler)
+	}
+
+	return r, nil
+}
+
+// registerRoutes adds all API endpoints to g.
+func registerRoutes(
+	g *gin.RouterGroup,
+	teamHandler *handler.TeamHandler,
+	userHandler *handler.UserHandler,
+	prHandler *handler.PRHandler,
+	statsHandler *handler.StatsHandler,
+	webhookHandler *handler.WebhookHandler,
+	integrationHandler *handler.IntegrationHandler,
+	auditHandler *handler.AuditHandler,
+	orgHandler *handler.OrgHandler,
+) {
 	// Team endpoints
-	r.POST("/team/add", teamHandler.AddTeam)
-	r.GET("/team/get", teamHandler.GetTeam)
-	r.POST("/team/deactivate", teamHandler.DeactivateTeam)
+	g.POST("/team/add", teamHandler.AddTeam)
+	g.GET("/team/get", teamHandler.GetTeam)
+	g.POST("/team/update", teamHandler.UpdateTeam)
+	g.POST("/team/import", teamHandler.ImportTeams)
+	g.POST("/team/rename", teamHandler.RenameTeam)
+	g.POST("/team/deactivate", teamHandler.DeactivateTeam)
+	g.POST("/team/rebalance", teamHandler.RebalanceTeam)
+	g.POST("/team/ownership", teamHandler.SetOwnershipRule)
+	g.GET("/team/ownership", teamHandler.ListOwnershipRules)
+	g.DELETE("/team/ownership", teamHandler.DeleteOwnershipRule)
+	g.GET("/team/settings", teamHandler.GetTeamSettings)
+	g.POST("/team/settings", teamHandler.UpdateTeamSettings)
+	g.GET("/team/workload", teamHandler.GetTeamWorkload)
+	g.POST("/team/digest", teamHandler.SetDigestSchedule)
+	g.GET("/team/digest", teamHandler.GetDigestSchedule)
+	g.DELETE("/team/digest", teamHandler.DeleteDigestSchedule)
 
 	// User endpoints
-	r.POST("/users/setIsActive", userHandler.SetIsActive)
-	r.GET("/users/getReview", userHandler.GetReview)
+	g.POST("/users/setIsActive", userHandler.SetIsActive)
+	g.POST("/users/setIsActiveBatch", userHandler.SetIsActiveBatch)
+	g.POST("/users/setCapacity", userHandler.SetCapacity)
+	g.POST("/users/setTags", userHandler.SetTags)
+	g.POST("/users/erase", middleware.RequireAdmin(), userHandler.EraseUser)
+	g.POST("/users/setAbsence", userHandler.SetAbsence)
+	g.DELETE("/users/setAbsence", userHandler.RemoveAbsence)
+	g.POST("/users/reassignAll", middleware.RequireAdmin(), userHandler.ReassignAll)
+	g.POST("/users/addExclusion", userHandler.AddExclusion)
+	g.POST("/users/removeExclusion", userHandler.RemoveExclusion)
+	g.GET("/users/getReview", userHandler.GetReview)
+	g.GET("/users/getReviewCount", userHandler.GetReviewCount)
+	g.GET("/users/getAuthored", userHandler.GetAuthored)
 
 	// Pull Request endpoints
-	r.POST("/pullRequest/create", prHandler.CreatePR)
-	r.POST("/pullRequest/merge", prHandler.MergePR)
-	r.POST("/pullRequest/reassign", prHandler.ReassignPR)
+	g.POST("/pullRequest/create", prHandler.CreatePR)
+	g.GET("/pullRequest/get", prHandler.GetPR)
+	g.POST("/pullRequest/merge", prHandler.MergePR)
+	g.POST("/pullRequest/approve", prHandler.ApprovePR)
+	g.POST("/pullRequest/close", prHandler.ClosePR)
+	g.POST("/pullRequest/reopen", middleware.RequireAdmin(), prHandler.ReopenPR)
+	g.POST("/pullRequest/reassign", prHandler.ReassignPR)
+	g.GET("/pullRequest/suggestReviewers", prHandler.SuggestReviewers)
 
 	// Statistics endpoint
-	r.GET("/stats", statsHandler.GetStatistics)
+	g.GET("/stats", statsHandler.GetStatistics)
+	g.GET("/stats/export", statsHandler.ExportStatistics)
+	g.GET("/stats/timeseries", statsHandler.GetThroughput)
+	g.GET("/stats/leaderboard", statsHandler.GetLeaderboard)
+	g.GET("/stats/coverage", statsHandler.GetCoverage)
+	g.GET("/stats/user", statsHandler.GetUserStatistics)
+
+	// Webhook endpoints
+	g.POST("/webhooks", middleware.RequireAdmin(), webhookHandler.CreateWebhook)
+	g.DELETE("/webhooks", middleware.RequireAdmin(), webhookHandler.DeleteWebhook)
+
+	// VCS integration endpoints; provider webhooks authenticate with their own tokens
+	g.POST("/integrations/logins", middleware.RequireAdmin(), integrationHandler.MapLogin)
+	g.POST("/integrations/gitlab/webhook", integrationHandler.GitLabWebhook)
+	g.POST("/integrations/github/syncTeams", middleware.RequireAdmin(), integrationHandler.SyncGitHubTeams)
+
+	// Audit log endpoint
+	g.GET("/admin/audit", middleware.RequireAdmin(), auditHandler.ListAudit)
+
+	// Assignment maintenance endpoint
+	g.POST("/admin/backfillReviewers", middleware.RequireAdmin(), prHandler.BackfillReviewers)
 
-	return r
+	// Organization endpoints
+	g.POST("/admin/orgs", middleware.RequireAdmin(), orgHandler.CreateOrg)
+	g.GET("/admin/orgs", middleware.RequireAdmin(), orgHandler.ListOrgs)
 }
@@ -0,0 +1,178 @@
+// Package server runs the HTTP API as a lifecycle component.
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/lifecycle"
+)
+
+// Compile-time check that the server can be run by the lifecycle manager.
+var (
+	_ lifecycle.Component = (*Server)(nil)
+	_ lifecycle.Failing   = (*Server)(nil)
+)
+
+// Server owns the HTTP listener and, with TLS, the listener redirecting plain HTTP.
+type Server struct {
+	httpServer *http.Server
+	listener   net.Listener
+
+	// certFile and keyFile, when set, make the server serve HTTPS.
+	certFile string
+	keyFile  string
+	// redirectServer answers plain HTTP requests with redirects to the HTTPS listener.
+	redirectServer   *http.Server
+	redirectListener net.Listener
+
+	serveErr chan error
+}
+
+// New creates a server for handler on addr.
+func New(addr string, handler http.Handler) *Server {
+	return &Server{
+		httpServer: &http.Server{Addr: addr, Handler: handler},
+		serveErr:   make(chan error, 1),
+	}
+}
+
+// WithTLS makes the server serve HTTPS, with HTTP/2 enabled, using the PEM encoded
+// certificate chain and private key files.
+func (s *Server) WithTLS(certFile, keyFile string) *Server {
+	s.certFile = certFile
+	s.keyFile = keyFile
+	return s
+}
+
+// WithRedirect additionally listens on addr for plain HTTP and redirects every request
+// permanently to the same path on the HTTPS listener. It only takes effect together with WithTLS.
+func (s *Server) WithRedirect(addr string) *Server {
+	s.redirectServer = &http.Server{
+		Addr:              addr,
+		Handler:           http.HandlerFunc(s.redirectToHTTPS),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	return s
+}
+
+// TLSConfig is the TLS configuration of HTTPS servers: TLS 1.2 or newer, forward-secret AEAD
+// cipher suites only (TLS 1.3 suites are not configurable and all qualify), and HTTP/2 offered over ALPN.
+func TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		CipherSuites: []uint16{
+			tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+			tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+			tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256,
+			tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256,
+		},
+		NextProtos: []string{"h2", "http/1.1"},
+	}
+}
+
+// Start begins listening and serving in the background. Serving errors are reported on Errors.
+func (s *Server) Start(ctx context.Context) error {
+	tlsEnabled := s.certFile != ""
+	if tlsEnabled {
+		cert, err := tls.LoadX509KeyPair(s.certFile, s.keyFile)
+		if err != nil {
+			return fmt.Errorf("failed to load TLS certificate: %w", err)
+		}
+		s.httpServer.TLSConfig = TLSConfig()
+		s.httpServer.TLSConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	var lc net.ListenConfig
+	listener, err := lc.Listen(ctx, "tcp", s.httpServer.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.httpServer.Addr, err)
+	}
+	s.listener = listener
+
+	if tlsEnabled && s.redirectServer != nil {
+		s.redirectListener, err = lc.Listen(ctx, "tcp", s.redirectServer.Addr)
+		if err != nil {
+			_ = listener.Close()
+			return fmt.Errorf("failed to listen on %s: %w", s.redirectServer.Addr, err)
+		}
+		go s.serve(func() error { return s.redirectServer.Serve(s.redirectListener) })
+	}
+
+	if tlsEnabled {
+		// The certificate is already in TLSConfig, so no files are passed.
+		go s.serve(func() error { return s.httpServer.ServeTLS(listener, "", "") })
+	} else {
+		go s.serve(func() error { return s.httpServer.Serve(listener) })
+	}
+	return nil
+}
+
+// serve runs a serving loop and reports its failure on Errors; only the first failure is kept.
+func (s *Server) serve(run func() error) {
+	if err := run(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		select {
+		case s.serveErr <- err:
+		default:
+		}
+	}
+}
+
+// RedirectAddr returns the address of the HTTP to HTTPS redirect listener,
+// or an empty string when there is none.
+func (s *Server) RedirectAddr() string {
+	if s.redirectListener == nil {
+		return ""
+	}
+	return s.redirectListener.Addr().String()
+}
+
+// redirectToHTTPS redirects to the requested host, path and query on the HTTPS port.
+func (s *Server) redirectToHTTPS(w http.ResponseWriter, r *http.Request) {
+	host := r.Host
+	if h, _, err := net.SplitHostPort(r.Host); err == nil {
+		host = h
+	}
+	host = strings.Trim(host, "[]")
+	if _, port, err := net.SplitHostPort(s.Addr()); err == nil && port != "443" {
+		host = net.JoinHostPort(host, port)
+	} else if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusMovedPermanently)
+}
+
+// Addr returns the address the server listens on, useful when started on port 0.
+func (s *Server) Addr() string {
+	if s.listener == nil {
+		return s.httpServer.Addr
+	}
+	return s.listener.Addr().String()
+}
+
+// Errors reports a failure of the HTTP server after Start.
+func (s *Server) Errors() <-chan error {
+	return s.serveErr
+}
+
+// Stop stops accepting connections and waits for in-flight requests until ctx is done.
+func (s *Server) Stop(ctx context.Context) error {
+	var errs []error
+	if s.redirectListener != nil {
+		if err := s.redirectServer.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop HTTP redirects: %w", err))
+		}
+	}
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("failed to drain HTTP requests: %w", err))
+	}
+	return errors.Join(errs...)
+}
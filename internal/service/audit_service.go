@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/pagination"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/audit"
+)
+
+// SystemActor is recorded for administrative actions performed without an audit actor in the context,
+// e.g. from the admin CLI.
+const SystemActor = "system"
+
+// MaxAuditPageSize caps the number of audit entries returned at once.
+const MaxAuditPageSize = 100
+
+type auditActorKey struct{}
+
+// auditActor is the caller that administrative actions are attributed to.
+type auditActor struct {
+	name      string
+	requestID string
+}
+
+// WithAuditActor returns a context that attributes administrative actions to actor
+// within the request identified by requestID.
+func WithAuditActor(ctx context.Context, actor, requestID string) context.Context {
+	return context.WithValue(ctx, auditActorKey{}, auditActor{name: actor, requestID: requestID})
+}
+
+// withAuditActorName keeps the request of the actor stored in ctx but attributes actions to actor instead.
+func withAuditActorName(ctx context.Context, actor string) context.Context {
+	caller, _ := ctx.Value(auditActorKey{}).(auditActor)
+	return WithAuditActor(ctx, actor, caller.requestID)
+}
+
+// recordAudit appends an entry for action on target, attributed to the actor stored in ctx.
+// It is called inside the transaction of the action, so the entry is written only if the action is.
+func recordAudit(ctx context.Context, exec repository.DBTX, action domain.AuditAction, target string) error {
+	caller, ok := ctx.Value(auditActorKey{}).(auditActor)
+	if !ok {
+		caller = auditActor{name: SystemActor}
+	}
+	return audit.Insert(exec, &domain.AuditEntry{
+		Actor:     caller.name,
+		Action:    action,
+		Target:    target,
+		RequestID: caller.requestID,
+	})
+}
+
+// AuditService reads the audit log.
+type AuditService struct {
+	db *sql.DB
+}
+
+// NewAuditService creates a new audit service.
+func NewAuditService(db *sql.DB) *AuditService {
+	return &AuditService{db: db}
+}
+
+// ListAudit returns a page of audit entries matching filter, newest first.
+// Returns ErrInvalidLimit unless filter.Limit is between 1 and MaxAuditPageSize.
+func (s *AuditService) ListAudit(ctx context.Context, filter audit.Filter) (pagination.Page[domain.AuditEntry], error) {
+	ctx, span := startSpan(ctx, "AuditService.ListAudit")
+	defer span.End()
+	db := repository.WithContext(ctx, s.db)
+
+	if filter.Limit < 1 || filter.Limit > MaxAuditPageSize {
+		return pagination.Page[domain.AuditEntry]{}, ErrInvalidLimit
+	}
+
+	limit := filter.Limit
+	filter.Limit++
+	entries, err := audit.List(db, filter)
+	if err != nil {
+		return pagination.Page[domain.AuditEntry]{}, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+	return pagination.NewPage(entries, limit, audit.Cursor), nil
+}
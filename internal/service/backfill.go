@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+)
+
+// BackfillOptions controls PRService.BackfillReviewers.
+type BackfillOptions struct {
+	// DryRun picks the reviewers without assigning them. Each PR is planned on its own,
+	// so a dry run may pick a user for more reviews than their capacity allows.
+	DryRun bool
+	// MaxPRs caps the number of PRs processed; zero means no limit.
+	MaxPRs int
+}
+
+// BackfilledPR is an under-covered PR processed by a backfill: the reviewers added to it
+// and how many it still lacks afterwards.
+type BackfilledPR struct {
+	PR      domain.PRKey
+	Added   []string
+	Missing int
+}
+
+// BackfillResult lists the processed PRs, oldest first. Remaining is the number of under-covered PRs
+// left for a later run because of MaxPRs.
+type BackfillResult struct {
+	PRs       []BackfilledPR
+	Remaining int
+}
+
+// Touched returns the number of PRs that got at least one reviewer.
+func (r *BackfillResult) Touched() int {
+	n := 0
+	for _, p := range r.PRs {
+		if len(p.Added) > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// Uncoverable returns the number of processed PRs still short of reviewers.
+func (r *BackfillResult) Uncoverable() int {
+	n := 0
+	for _, p := range r.PRs {
+		if p.Missing > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// BackfillReviewers tops up open PRs with fewer reviewers than their team's reviewer count, of one team
+// or, if teamName is empty, of all teams, oldest first. Reviewers are picked from the PR's team as when
+// a reviewer is replenished. Each PR is topped up in its own transaction, writing a reviewer.assigned
+// event per added reviewer to the outbox; a run that added any reviewer is recorded in the audit log.
+func (s *PRService) BackfillReviewers(ctx context.Context, teamName string, opts BackfillOptions) (*BackfillResult, error) {
+	ctx, span := startSpan(ctx, "PRService.BackfillReviewers")
+	defer span.End()
+	db := repository.WithContext(ctx, s.db)
+
+	if teamName != "" {
+		exists, err := team.Exists(db, teamName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check team existence: %w", err)
+		}
+		if !exists {
+			return nil, ErrTeamNotFound
+		}
+	}
+
+	keys, err := pr.GetUnderCovered(db, teamName, domain.DefaultReviewerCount)
+	if err != nil {
+		return nil, err
+	}
+	result := &BackfillResult{PRs: make([]BackfilledPR, 0, len(keys))}
+	if opts.MaxPRs > 0 && len(keys) > opts.MaxPRs {
+		result.Remaining = len(keys) - opts.MaxPRs
+		keys = keys[:opts.MaxPRs]
+	}
+
+	for _, key := range keys {
+		var backfilled *BackfilledPR
+		if opts.DryRun {
+			backfilled, err = s.backfillPR(db, key, true)
+		} else {
+			err = s.retry.RunTx(ctx, s.db, func(tx repository.DBTX) error {
+				var err error
+				backfilled, err = s.backfillPR(tx, key, false)
+				return err
+			})
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to backfill %s: %w", key, err)
+		}
+		// Merged, closed or deleted since the lookup.
+		if backfilled != nil {
+			result.PRs = append(result.PRs, *backfilled)
+		}
+	}
+
+	if !opts.DryRun && result.Touched() > 0 {
+		target := "org:" + repository.OrgFromContext(ctx)
+		if teamName != "" {
+			target = "team:" + teamName
+		}
+		err = s.retry.RunTx(ctx, s.db, func(tx repository.DBTX) error {
+			return recordAudit(ctx, tx, domain.AuditReviewersBackfill, target)
+		})
+		if err != nil {
+			return nil, err
+		}
+		s.version.Bump()
+	}
+
+	return result, nil
+}
+
+// backfillPR tops up one PR through exec, or only picks the reviewers if dryRun is set.
+// Returns nil if the PR is no longer open.
+func (s *PRService) backfillPR(exec repository.DBTX, key domain.PRKey, dryRun bool) (*BackfilledPR, error) {
+	if !dryRun {
+		if err := pr.Lock(exec, key); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil, nil
+			}
+			return nil, err
+		}
+	}
+	pullRequest, err := pr.Get(exec, key)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get PR: %w", err)
+	}
+	if pullRequest.Status != domain.StatusOpen {
+		return nil, nil
+	}
+
+	decision, target, err := s.replenishment(exec, pullRequest, "")
+	if err != nil {
+		return nil, err
+	}
+	var added []string
+	if decision != nil {
+		added = decision.Selected
+	}
+	if !dryRun && len(added) > 0 {
+		if err := addReviewers(exec, key, added, s.clock.Now().UTC()); err != nil {
+			return nil, err
+		}
+		s.assigner.LogDecision(repository.Org(exec), key, "BACKFILL", decision)
+	}
+	return &BackfilledPR{
+		PR:      key,
+		Added:   added,
+		Missing: max(target-len(pullRequest.AssignedReviewersIDs)-len(added), 0),
+	}, nil
+}
@@ -0,0 +1,21 @@
+package service
+
+import "time"
+
+// Clock abstracts the current time so time-dependent logic can be tested.
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the Clock backed by time.Now.
+type systemClock struct{}
+
+// Now returns the current time in UTC.
+func (systemClock) Now() time.Time {
+	return time.Now().UTC()
+}
+
+// NewSystemClock returns a Clock that reports the real current time.
+func NewSystemClock() Clock {
+	return systemClock{}
+}
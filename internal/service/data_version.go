@@ -0,0 +1,19 @@
+package service
+
+import "sync/atomic"
+
+// DataVersion counts committed writes that affect statistics.
+// Caches remember the version they were filled at and treat any change as invalidation.
+type DataVersion struct {
+	n atomic.Uint64
+}
+
+// Bump records that data has changed.
+func (v *DataVersion) Bump() {
+	v.n.Add(1)
+}
+
+// Current returns the current version.
+func (v *DataVersion) Current() uint64 {
+	return v.n.Load()
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+)
+
+type readPrimaryKey struct{}
+
+// ReadPrimary returns a context in which read-only methods read from the primary database even
+// when a replica is configured. Use it for reads that must observe the caller's own writes,
+// since the replica may lag behind.
+func ReadPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readPrimaryKey{}, true)
+}
+
+// readDB returns the database for a read-only method: the router's reader unless there is
+// no router or ctx comes from ReadPrimary, in which case it is primary.
+func readDB(ctx context.Context, primary *sql.DB, router *repository.DBRouter) *sql.DB {
+	if force, _ := ctx.Value(readPrimaryKey{}).(bool); force || router == nil {
+		return primary
+	}
+	return router.Reader(ctx)
+}
@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/digest"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/outbox"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+)
+
+// DigestScheduler periodically sends the teams that set a digest schedule the pending reviews of their
+// active members as a review.digest event. A team whose members have nothing pending gets no event.
+type DigestScheduler struct {
+	db        *sql.DB
+	prService *PRService
+	clock     Clock
+	interval  time.Duration
+}
+
+// NewDigestScheduler creates a new digest scheduler.
+func NewDigestScheduler(db *sql.DB, prService *PRService, clock Clock, interval time.Duration) *DigestScheduler {
+	return &DigestScheduler{
+		db:        db,
+		prService: prService,
+		clock:     clock,
+		interval:  interval,
+	}
+}
+
+// Run ticks every interval until ctx is cancelled.
+func (w *DigestScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			sent, err := w.Tick(ctx)
+			if err != nil {
+				log.Printf("Digest tick failed: %v", err)
+			}
+			if sent > 0 {
+				log.Printf("Sent %d review digests", sent)
+			}
+		}
+	}
+}
+
+// Tick sends the digests of all organizations that are due once; its queries are cancelled with ctx.
+// Each digest is compiled and marked sent under a lock on its schedule, so instances ticking at the
+// same time send it once. A schedule with an unknown timezone is logged and skipped, and a team whose
+// digest fails is logged without holding up the others.
+// Returns the number of digests sent and the joined errors of the teams that failed.
+func (w *DigestScheduler) Tick(ctx context.Context) (int, error) {
+	schedules, err := digest.ListAll(repository.WithContext(ctx, w.db))
+	if err != nil {
+		return 0, fmt.Errorf("failed to list digest schedules: %w", err)
+	}
+
+	now := w.clock.Now()
+	sent := 0
+	var errs []error
+	for _, s := range schedules {
+		due, err := s.Schedule.Due(now)
+		if err != nil {
+			log.Printf("Skipping digest: %v", err)
+			continue
+		}
+		if !due {
+			continue
+		}
+
+		ok, err := w.send(repository.WithOrg(ctx, s.OrgID), s.Schedule.TeamName, now)
+		if err != nil {
+			err = fmt.Errorf("failed to send digest of team %s: %w", s.Schedule.TeamName, err)
+			log.Printf("Digest failed: %v", err)
+			errs = append(errs, err)
+			continue
+		}
+		if ok {
+			sent++
+		}
+	}
+
+	return sent, errors.Join(errs...)
+}
+
+// send compiles the team's digest and marks it sent at now, unless another instance got there first
+// or the schedule was removed since the lookup.
+// Reports whether a review.digest event was written to the outbox.
+func (w *DigestScheduler) send(ctx context.Context, teamName string, now time.Time) (bool, error) {
+	ctx, span := startSpan(ctx, "DigestScheduler.send")
+	defer span.End()
+
+	sent := false
+	err := w.prService.retry.RunTx(ctx, w.db, func(tx repository.DBTX) error {
+		sent = false
+		schedule, err := digest.GetForUpdate(tx, teamName)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil
+			}
+			return err
+		}
+		if due, err := schedule.Due(now); err != nil || !due {
+			return err
+		}
+
+		pending, err := pr.GetPendingByTeam(tx, teamName)
+		if err != nil {
+			return err
+		}
+		if len(pending) > 0 {
+			event := domain.Event{
+				Type:   domain.EventReviewDigest,
+				Digest: compileDigest(teamName, pending, now),
+			}
+			if err := outbox.Insert(tx, event); err != nil {
+				return err
+			}
+			sent = true
+		}
+		return digest.MarkSent(tx, teamName, now)
+	})
+	return sent, err
+}
+
+// compileDigest groups the pending reviews, ordered by reviewer, into a digest measured at now.
+func compileDigest(teamName string, pending []pr.PendingReview, now time.Time) *domain.ReviewDigest {
+	d := &domain.ReviewDigest{TeamName: teamName}
+	for _, p := range pending {
+		a := p.PullRequest.Assignment
+		a.Measure(now, p.PullRequest.Status)
+
+		if n := len(d.Reviewers); n == 0 || d.Reviewers[n-1].UserID != a.UserID {
+			d.Reviewers = append(d.Reviewers, domain.DigestReviewer{UserID: a.UserID, Username: p.Username})
+		}
+		reviewer := &d.Reviewers[len(d.Reviewers)-1]
+		reviewer.Reviews = append(reviewer.Reviews, domain.DigestReview{
+			RepositoryName:  p.PullRequest.RepositoryName,
+			PullRequestID:   p.PullRequest.PullRequestID,
+			PullRequestName: p.PullRequest.PullRequestName,
+			AuthorID:        p.PullRequest.AuthorID,
+			ExternalURL:     p.ExternalURL,
+			AssignedAt:      a.AssignedAt,
+			HoursOpen:       *a.HoursOpen,
+			Overdue:         a.Overdue,
+		})
+	}
+	return d
+}
+
+// SetDigestSchedule creates the team's digest schedule or replaces its hour and timezone.
+// The first digest goes out at the next Hour:00 in timezone, not for the hour that already passed today.
+func (s *TeamService) SetDigestSchedule(ctx context.Context, teamName string, hour int, timezone string) (*domain.DigestSchedule, error) {
+	ctx, span := startSpan(ctx, "TeamService.SetDigestSchedule")
+	defer span.End()
+
+	if hour < 0 || hour > 23 {
+		return nil, ErrInvalidDigestHour
+	}
+	// "" and "Local" would make the hour depend on the server's time zone.
+	if timezone == "" || timezone == "Local" {
+		return nil, ErrInvalidTimezone
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return nil, ErrInvalidTimezone
+	}
+
+	schedule := &domain.DigestSchedule{
+		TeamName:   teamName,
+		Hour:       hour,
+		Timezone:   timezone,
+		LastSentAt: s.prService.clock.Now(),
+	}
+	err := repository.WithTx(ctx, s.db, func(tx repository.DBTX) error {
+		exists, err := team.Exists(tx, teamName)
+		if err != nil {
+			return fmt.Errorf("failed to check team existence: %w", err)
+		}
+		if !exists {
+			return ErrTeamNotFound
+		}
+		return digest.Set(tx, schedule)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+// GetDigestSchedule returns the team's digest schedule.
+func (s *TeamService) GetDigestSchedule(ctx context.Context, teamName string) (*domain.DigestSchedule, error) {
+	ctx, span := startSpan(ctx, "TeamService.GetDigestSchedule")
+	defer span.End()
+	db := repository.WithContext(ctx, s.prService.reader(ctx))
+
+	schedule, err := digest.Get(db, teamName)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrDigestNotFound
+		}
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// DeleteDigestSchedule stops the team's digests.
+func (s *TeamService) DeleteDigestSchedule(ctx context.Context, teamName string) error {
+	ctx, span := startSpan(ctx, "TeamService.DeleteDigestSchedule")
+	defer span.End()
+	db := repository.WithContext(ctx, s.db)
+
+	deleted, err := digest.Delete(db, teamName)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrDigestNotFound
+	}
+	return nil
+}
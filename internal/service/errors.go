@@ -1,16 +1,224 @@
 package service
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+)
 
 var (
-	ErrTeamExists          = errors.New("team already exists")
-	ErrTeamNotFound        = errors.New("team not found")
-	ErrUserNotFound        = errors.New("user not found")
-	ErrPRAuthorNotFound    = errors.New("author not found")
-	ErrPRNotFound          = errors.New("pull request not found")
-	ErrPRExists            = errors.New("pull request already exists")
-	ErrPRMerged            = errors.New("cannot reassign merged pull request")
-	ErrReviewerNotAssigned = errors.New("user is not assigned to this pull request")
-	ErrNoCandidate         = errors.New("no candidates available for reassignment")
-	ErrInactiveReviewer    = errors.New("reviewer is not active")
+	ErrTeamExists            = errors.New("team already exists")
+	ErrTeamNotFound          = errors.New("team not found")
+	ErrUserNotFound          = errors.New("user not found")
+	ErrPRAuthorNotFound      = errors.New("author not found")
+	ErrPRNotFound            = errors.New("pull request not found")
+	ErrPRExists              = errors.New("pull request already exists")
+	ErrPRMerged              = errors.New("cannot reassign merged pull request")
+	ErrPRClosed              = errors.New("pull request is closed")
+	ErrReviewerNotAssigned   = errors.New("user is not assigned to this pull request")
+	ErrNotApproved           = errors.New("pull request lacks required approvals")
+	ErrNoCandidate           = errors.New("no candidates available for reassignment")
+	ErrInactiveReviewer      = errors.New("reviewer is not active")
+	ErrReviewerNotFound      = errors.New("reviewer not found")
+	ErrReviewerIsAuthor      = errors.New("author cannot review their own pull request")
+	ErrReviewerAssigned      = errors.New("reviewer is already assigned to this pull request")
+	ErrInvalidAbsence        = errors.New("absence must not end before it starts")
+	ErrAbsenceNotFound       = errors.New("absence not found")
+	ErrUnknownStrategy       = errors.New("unknown assignment strategy")
+	ErrUnknownConflictPolicy = errors.New("unknown conflict policy")
+	ErrUnknownIfExists       = errors.New("unknown if_exists mode")
+	ErrDuplicateMember       = errors.New("user is listed more than once")
+	ErrUserInOtherTeam       = errors.New("user already belongs to another team")
+	ErrUserErased            = errors.New("user is erased")
+
+	ErrRequiredReviewerNotFound = errors.New("required reviewer not found")
+	ErrRequiredReviewerInactive = errors.New("required reviewer is not active")
+	ErrRequiredReviewerIsAuthor = errors.New("author cannot be a required reviewer")
+	ErrTooManyRequiredReviewers = errors.New("too many required reviewers")
+
+	ErrReassignLimit = errors.New("pull request reached the reassignment limit")
+
+	ErrAuthorRateLimited = errors.New("author created too many pull requests")
+
+	ErrSelfExclusion     = errors.New("user cannot be excluded from reviewing themselves")
+	ErrExclusionNotFound = errors.New("exclusion not found")
+
+	ErrInvalidCSV = errors.New("invalid CSV")
+
+	ErrInvalidRequireApprovals = errors.New("require_approvals must not be negative")
+	ErrInvalidReviewSLA        = errors.New("review_sla_hours must not be negative")
+	ErrInvalidReviewerCount    = errors.New("reviewer_count is out of range")
+	ErrInvalidFallbackTeam     = errors.New("a team cannot be its own fallback team")
+	ErrFallbackTeamNotFound    = errors.New("fallback team not found")
+
+	ErrWebhookNotFound = errors.New("webhook not found")
+
+	ErrUnknownExternalLogin = errors.New("no user is mapped to the external login")
+
+	ErrInvalidBucket  = errors.New("bucket must be day or week")
+	ErrInvalidPeriod  = errors.New("from must not be after to")
+	ErrTooManyBuckets = errors.New("time range contains too many buckets")
+	ErrStatsTimeout   = errors.New("statistics query timed out")
+
+	ErrInvalidLeaderboardPeriod = errors.New("period must be 7d, 30d or all")
+	ErrInvalidLimit             = errors.New("limit is out of range")
+
+	ErrOrgExists = errors.New("organization already exists")
+
+	ErrInvalidOwner          = errors.New("exactly one of owner_user_id and owner_team_name must be set")
+	ErrOwnerTeamNotFound     = errors.New("owner team not found")
+	ErrOwnershipRuleNotFound = errors.New("ownership rule not found")
+
+	ErrGitHubSyncDisabled = errors.New("GitHub team sync is not configured")
+	ErrGitHubUnavailable  = errors.New("GitHub API request failed")
+
+	ErrInvalidDigestHour = errors.New("hour must be between 0 and 23")
+	ErrInvalidTimezone   = errors.New("unknown timezone")
+	ErrDigestNotFound    = errors.New("digest schedule not found")
 )
+
+// InactiveReviewerError reports which reviewer turned out to be inactive.
+// It matches ErrInactiveReviewer with errors.Is.
+type InactiveReviewerError struct {
+	UserID string
+}
+
+func (e *InactiveReviewerError) Error() string {
+	return ErrInactiveReviewer.Error() + ": " + e.UserID
+}
+
+// Unwrap returns ErrInactiveReviewer.
+func (e *InactiveReviewerError) Unwrap() error {
+	return ErrInactiveReviewer
+}
+
+// ReviewerNotFoundError reports which reviewer was deleted while being assigned.
+// It matches ErrReviewerNotFound with errors.Is.
+type ReviewerNotFoundError struct {
+	UserID string
+}
+
+func (e *ReviewerNotFoundError) Error() string {
+	return ErrReviewerNotFound.Error() + ": " + e.UserID
+}
+
+// Unwrap returns ErrReviewerNotFound.
+func (e *ReviewerNotFoundError) Unwrap() error {
+	return ErrReviewerNotFound
+}
+
+// PRExistsError carries the pull request a create conflicted with.
+// It matches ErrPRExists with errors.Is.
+type PRExistsError struct {
+	Existing *domain.PullRequest
+}
+
+func (e *PRExistsError) Error() string {
+	return ErrPRExists.Error() + ": " + e.Existing.Key().String()
+}
+
+// Unwrap returns ErrPRExists.
+func (e *PRExistsError) Unwrap() error {
+	return ErrPRExists
+}
+
+// AuthorRateLimitError reports an author's pull request creations within the last hour against the limit.
+// RetryAfter is how long until the oldest of them leaves the window.
+// It matches ErrAuthorRateLimited with errors.Is.
+type AuthorRateLimitError struct {
+	Limit      int
+	Used       int
+	RetryAfter time.Duration
+}
+
+func (e *AuthorRateLimitError) Error() string {
+	return fmt.Sprintf("%s: %d of %d per hour", ErrAuthorRateLimited, e.Used, e.Limit)
+}
+
+// Unwrap returns ErrAuthorRateLimited.
+func (e *AuthorRateLimitError) Unwrap() error {
+	return ErrAuthorRateLimited
+}
+
+// NotApprovedError reports how far a pull request is from its team's approval requirement.
+// Missing lists the assigned reviewers who have not approved, sorted by user ID.
+// It matches ErrNotApproved with errors.Is.
+type NotApprovedError struct {
+	Required int
+	Approved int
+	Missing  []string
+}
+
+func (e *NotApprovedError) Error() string {
+	return fmt.Sprintf("%s: %d of %d, missing %s", ErrNotApproved, e.Approved, e.Required, strings.Join(e.Missing, ", "))
+}
+
+// Unwrap returns ErrNotApproved.
+func (e *NotApprovedError) Unwrap() error {
+	return ErrNotApproved
+}
+
+// DuplicateMemberError reports which user is listed more than once in a team.
+// It matches ErrDuplicateMember with errors.Is.
+type DuplicateMemberError struct {
+	UserID string
+}
+
+func (e *DuplicateMemberError) Error() string {
+	return ErrDuplicateMember.Error() + ": " + e.UserID
+}
+
+// Unwrap returns ErrDuplicateMember.
+func (e *DuplicateMemberError) Unwrap() error {
+	return ErrDuplicateMember
+}
+
+// UserErasedError reports a member whose user was erased and so cannot be added or updated.
+// It matches ErrUserErased with errors.Is.
+type UserErasedError struct {
+	UserID string
+}
+
+func (e *UserErasedError) Error() string {
+	return ErrUserErased.Error() + ": " + e.UserID
+}
+
+// Unwrap returns ErrUserErased.
+func (e *UserErasedError) Unwrap() error {
+	return ErrUserErased
+}
+
+// UserInOtherTeamError reports a member whose primary team differs from the team being created.
+// It matches ErrUserInOtherTeam with errors.Is.
+type UserInOtherTeamError struct {
+	UserID   string
+	TeamName string
+}
+
+func (e *UserInOtherTeamError) Error() string {
+	return ErrUserInOtherTeam.Error() + ": " + e.UserID + " is in " + e.TeamName
+}
+
+// Unwrap returns ErrUserInOtherTeam.
+func (e *UserInOtherTeamError) Unwrap() error {
+	return ErrUserInOtherTeam
+}
+
+// ReassignIncompleteError is returned by SetAbsence when the absence was saved but moving the user's
+// open reviews stopped midway. Reassigned holds the results for the reviews handled before Err.
+type ReassignIncompleteError struct {
+	Reassigned []ReassignResult
+	Err        error
+}
+
+func (e *ReassignIncompleteError) Error() string {
+	return fmt.Sprintf("absence saved, reassignment stopped after %d reviews: %v", len(e.Reassigned), e.Err)
+}
+
+// Unwrap returns the error that stopped the reassignment.
+func (e *ReassignIncompleteError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+)
+
+// EscalationWorker periodically reassigns reviews that stayed pending longer than the SLA.
+// An assignment on an OPEN PR counts as pending until the reviewer approves it.
+type EscalationWorker struct {
+	db        *sql.DB
+	prService *PRService
+	clock     Clock
+	interval  time.Duration
+	sla       time.Duration
+	batchSize int
+}
+
+// NewEscalationWorker creates a new escalation worker.
+// batchSize caps how many assignments are escalated per tick.
+func NewEscalationWorker(
+	db *sql.DB,
+	prService *PRService,
+	clock Clock,
+	interval, sla time.Duration,
+	batchSize int,
+) *EscalationWorker {
+	return &EscalationWorker{
+		db:        db,
+		prService: prService,
+		clock:     clock,
+		interval:  interval,
+		sla:       sla,
+		batchSize: batchSize,
+	}
+}
+
+// Run ticks every interval until ctx is cancelled.
+func (w *EscalationWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			escalated, err := w.Tick(ctx)
+			if err != nil {
+				log.Printf("Escalation tick failed: %v", err)
+			}
+			if escalated > 0 {
+				log.Printf("Escalated %d overdue review assignments", escalated)
+			}
+		}
+	}
+}
+
+// Tick reassigns up to batchSize overdue assignments of all organizations once; its queries are cancelled with ctx.
+// Assignments without a replacement candidate are left in place and marked as attempted, so that
+// the next ticks take assignments not tried yet before retrying them.
+// Returns the number of assignments escalated.
+func (w *EscalationWorker) Tick(ctx context.Context) (int, error) {
+	db := repository.WithContext(ctx, w.db)
+
+	deadline := w.clock.Now().Add(-w.sla)
+	overdue, err := pr.GetOverdueAssignments(db, deadline, w.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find overdue assignments: %w", err)
+	}
+
+	escalated := 0
+	for _, a := range overdue {
+		orgCtx := repository.WithOrg(ctx, a.OrgID)
+		_, err := w.prService.reassignReviewer(orgCtx, a.PR, a.UserID, domain.ActionEscalate, false)
+		if err != nil {
+			// Without a usable candidate the assignment stays pending; it is moved behind untried ones
+			// so that it is retried without taking the place of newer overdue assignments.
+			if errors.Is(err, ErrNoCandidate) || isStaleCandidate(err) {
+				if err := pr.MarkEscalationAttempted(repository.WithContext(orgCtx, w.db), a.PR, a.UserID, w.clock.Now()); err != nil {
+					return escalated, err
+				}
+				continue
+			}
+			// The PR may have been merged, closed or reassigned since the lookup; skip it.
+			if errors.Is(err, ErrPRMerged) ||
+				errors.Is(err, ErrPRClosed) ||
+				errors.Is(err, ErrPRNotFound) ||
+				errors.Is(err, ErrReviewerNotAssigned) {
+				continue
+			}
+			return escalated, fmt.Errorf("failed to escalate %s on %s: %w", a.UserID, a.PR, err)
+		}
+		escalated++
+	}
+
+	return escalated, nil
+}
@@ -0,0 +1,324 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/integration"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/externallogin"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+)
+
+// SkipUserIDTaken is the reason a GitHub login is skipped when it is not mapped to a user
+// and a user with the login as ID already exists.
+const SkipUserIDTaken = "user_id_taken"
+
+// SkippedLogin is a member of a GitHub team the sync left out.
+type SkippedLogin struct {
+	TeamName string
+	Login    string
+	Reason   string
+}
+
+// TeamSyncSummary lists what a GitHub team sync changed; every list is sorted.
+// UsersUpdated holds users whose team memberships changed or who were reactivated.
+type TeamSyncSummary struct {
+	TeamsCreated     []string
+	UsersCreated     []string
+	UsersUpdated     []string
+	UsersDeactivated []string
+	Skipped          []SkippedLogin
+}
+
+// Changed reports whether the sync changed anything.
+func (s *TeamSyncSummary) Changed() bool {
+	return len(s.TeamsCreated)+len(s.UsersCreated)+len(s.UsersUpdated)+len(s.UsersDeactivated) > 0
+}
+
+// GitHubSyncService mirrors the teams of a GitHub organization: every GitHub team becomes
+// a team named after its slug whose members are the users its logins are mapped to.
+type GitHubSyncService struct {
+	db        *sql.DB
+	prService *PRService
+	client    integration.GitHubClient
+	org       string
+	interval  time.Duration
+}
+
+// NewGitHubSyncService creates a sync of the GitHub organization org read through client.
+// A nil client disables the sync.
+func NewGitHubSyncService(db *sql.DB, prService *PRService, client integration.GitHubClient, org string) *GitHubSyncService {
+	return &GitHubSyncService{db: db, prService: prService, client: client, org: org}
+}
+
+// WithInterval sets how often Run syncs the teams.
+func (s *GitHubSyncService) WithInterval(interval time.Duration) *GitHubSyncService {
+	s.interval = interval
+	return s
+}
+
+// Run syncs the teams of the default organization every interval until ctx is cancelled.
+func (s *GitHubSyncService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			summary, err := s.SyncTeams(ctx)
+			if err != nil {
+				log.Printf("GitHub team sync failed: %v", err)
+				continue
+			}
+			if summary.Changed() {
+				log.Printf("GitHub team sync: %d teams created, %d users created, %d updated, %d deactivated",
+					len(summary.TeamsCreated), len(summary.UsersCreated), len(summary.UsersUpdated), len(summary.UsersDeactivated))
+			}
+		}
+	}
+}
+
+// SyncTeams reconciles the teams with the GitHub organization in one transaction:
+//   - a GitHub team without a team of the same name creates one with the default strategy;
+//   - a login not mapped to a user creates a user with the login as ID and username and maps it;
+//     a login whose ID is already taken by an unmapped user is skipped;
+//   - a user's first GitHub team by name becomes their primary team unless they are already
+//     primary in one of their GitHub teams, they are added to the others and reactivated if needed;
+//   - a member missing from a synced GitHub team leaves it if they remain in another GitHub team
+//     or it is not their primary team, otherwise they are deactivated and their open reviews
+//     are released and refilled.
+//
+// Teams that do not exist on GitHub are left untouched. A change is recorded in the audit log.
+// Returns ErrGitHubSyncDisabled without a client and ErrGitHubUnavailable if GitHub can't be read.
+func (s *GitHubSyncService) SyncTeams(ctx context.Context) (*TeamSyncSummary, error) {
+	ctx, span := startSpan(ctx, "GitHubSyncService.SyncTeams")
+	defer span.End()
+
+	if s.client == nil {
+		return nil, ErrGitHubSyncDisabled
+	}
+	ghTeams, err := s.client.ListTeams(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGitHubUnavailable, err)
+	}
+	slices.SortFunc(ghTeams, func(a, b integration.GitHubTeam) int {
+		return strings.Compare(a.Slug, b.Slug)
+	})
+
+	var summary *TeamSyncSummary
+	err = s.prService.retry.RunTx(ctx, s.db, func(tx repository.DBTX) error {
+		summary = &TeamSyncSummary{
+			TeamsCreated:     make([]string, 0),
+			UsersCreated:     make([]string, 0),
+			UsersUpdated:     make([]string, 0),
+			UsersDeactivated: make([]string, 0),
+			Skipped:          make([]SkippedLogin, 0),
+		}
+		if err := s.reconcile(tx, ghTeams, summary); err != nil {
+			return err
+		}
+		if !summary.Changed() {
+			return nil
+		}
+		return recordAudit(ctx, tx, domain.AuditTeamsSync, integration.ProviderGitHub+":"+s.org)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if summary.Changed() {
+		s.prService.version.Bump()
+	}
+
+	slices.Sort(summary.UsersCreated)
+	slices.Sort(summary.UsersUpdated)
+	slices.Sort(summary.UsersDeactivated)
+	return summary, nil
+}
+
+// reconcile applies the GitHub teams, sorted by slug, and fills summary.
+func (s *GitHubSyncService) reconcile(tx repository.DBTX, ghTeams []integration.GitHubTeam, summary *TeamSyncSummary) error {
+	for _, t := range ghTeams {
+		exists, err := team.Exists(tx, t.Slug)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		if err := team.CreateWithStrategy(tx, t.Slug, string(s.prService.assigner.Strategy())); err != nil {
+			return fmt.Errorf("failed to create team: %w", err)
+		}
+		summary.TeamsCreated = append(summary.TeamsCreated, t.Slug)
+	}
+
+	// Resolve the logins; userTeams keeps each user's GitHub teams in slug order.
+	wanted := make(map[string]map[string]bool, len(ghTeams))
+	userTeams := make(map[string][]string)
+	var order []string
+	for _, t := range ghTeams {
+		wanted[t.Slug] = make(map[string]bool, len(t.Members))
+		for _, login := range t.Members {
+			userID, err := s.resolveMember(tx, t.Slug, login, summary)
+			if err != nil {
+				return err
+			}
+			if userID == "" || wanted[t.Slug][userID] {
+				continue
+			}
+			wanted[t.Slug][userID] = true
+			if _, seen := userTeams[userID]; !seen {
+				order = append(order, userID)
+			}
+			userTeams[userID] = append(userTeams[userID], t.Slug)
+		}
+	}
+
+	updated := make(map[string]bool)
+	for _, userID := range order {
+		changed, err := applyMemberships(tx, userID, userTeams[userID])
+		if err != nil {
+			return err
+		}
+		if changed && !slices.Contains(summary.UsersCreated, userID) {
+			updated[userID] = true
+		}
+	}
+
+	for _, t := range ghTeams {
+		current, err := team.Get(tx, t.Slug)
+		if err != nil {
+			return fmt.Errorf("failed to get team: %w", err)
+		}
+		for _, member := range current.Members {
+			if wanted[t.Slug][member.UserID] {
+				continue
+			}
+			u, err := user.Get(tx, member.UserID)
+			if err != nil {
+				return fmt.Errorf("failed to get user: %w", err)
+			}
+			if len(userTeams[member.UserID]) > 0 || u.TeamName != t.Slug {
+				if err := team.RemoveMember(tx, t.Slug, member.UserID); err != nil {
+					return err
+				}
+				updated[member.UserID] = true
+				continue
+			}
+			if !member.IsActive {
+				continue
+			}
+			if err := s.deactivate(tx, member.UserID); err != nil {
+				return err
+			}
+			summary.UsersDeactivated = append(summary.UsersDeactivated, member.UserID)
+		}
+	}
+
+	for userID := range updated {
+		summary.UsersUpdated = append(summary.UsersUpdated, userID)
+	}
+	return nil
+}
+
+// resolveMember returns the user the login of a member of teamName is mapped to, creating
+// and mapping a user named after the login if there is none.
+// Returns an empty ID for a login that is skipped.
+func (s *GitHubSyncService) resolveMember(tx repository.DBTX, teamName, login string, summary *TeamSyncSummary) (string, error) {
+	userID, err := externallogin.GetUserID(tx, integration.ProviderGitHub, login)
+	if err == nil {
+		return userID, nil
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		return "", err
+	}
+
+	// A failed insert would abort the transaction, so a taken ID is checked up front.
+	_, err = user.Get(tx, login)
+	if err == nil {
+		summary.Skipped = append(summary.Skipped, SkippedLogin{TeamName: teamName, Login: login, Reason: SkipUserIDTaken})
+		return "", nil
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		return "", err
+	}
+	if err := user.Create(tx, &domain.User{UserID: login, Username: login, TeamName: teamName, IsActive: true}); err != nil {
+		return "", err
+	}
+	if err := externallogin.Set(tx, &domain.ExternalLogin{Provider: integration.ProviderGitHub, Login: login, UserID: login}); err != nil {
+		return "", err
+	}
+	summary.UsersCreated = append(summary.UsersCreated, login)
+	return login, nil
+}
+
+// applyMemberships makes the user an active member of every team in teamNames, keeping their
+// primary team if it is one of them and moving it to the first one otherwise.
+// Reports whether anything changed.
+func applyMemberships(tx repository.DBTX, userID string, teamNames []string) (bool, error) {
+	u, err := user.Get(tx, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	changed := false
+	if !slices.Contains(teamNames, u.TeamName) {
+		if err := user.SetPrimaryTeam(tx, userID, teamNames[0]); err != nil {
+			return false, err
+		}
+		changed = true
+	}
+	if !u.IsActive {
+		if _, err := user.SetIsActive(tx, userID, true); err != nil {
+			return false, err
+		}
+		changed = true
+	}
+
+	for _, teamName := range teamNames {
+		current, err := team.Get(tx, teamName)
+		if err != nil {
+			return false, fmt.Errorf("failed to get team: %w", err)
+		}
+		if slices.ContainsFunc(current.Members, func(m domain.TeamMember) bool { return m.UserID == userID }) {
+			continue
+		}
+		if err := team.AddMember(tx, teamName, userID, false); err != nil {
+			return false, err
+		}
+		changed = true
+	}
+	return changed, nil
+}
+
+// deactivate deactivates the user and refills their open reviews from each PR's team.
+func (s *GitHubSyncService) deactivate(tx repository.DBTX, userID string) error {
+	if _, err := user.SetIsActive(tx, userID, false); err != nil {
+		return fmt.Errorf("failed to update user status: %w", err)
+	}
+
+	keys, err := pr.GetOpenIDsByReviewer(tx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get open reviews: %w", err)
+	}
+	for _, key := range keys {
+		if err := pr.DeleteReviewer(tx, key, userID); err != nil {
+			return fmt.Errorf("failed to delete reviewer: %w", err)
+		}
+		if err := s.prService.ReplenishReviewers(tx, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/integration"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/externallogin"
+)
+
+// IntegrationService applies pull request commands of the VCS webhook integrations
+// and manages the mapping of provider logins to users they rely on.
+type IntegrationService struct {
+	db        *sql.DB
+	prService *PRService
+}
+
+// NewIntegrationService creates a new integration service.
+func NewIntegrationService(db *sql.DB, prService *PRService) *IntegrationService {
+	return &IntegrationService{db: db, prService: prService}
+}
+
+// MapLogin maps a provider login to a user, replacing the user it was mapped to before.
+// Returns ErrUserNotFound if the user doesn't exist.
+func (s *IntegrationService) MapLogin(ctx context.Context, login *domain.ExternalLogin) error {
+	ctx, span := startSpan(ctx, "IntegrationService.MapLogin")
+	defer span.End()
+	db := repository.WithContext(ctx, s.db)
+
+	if err := externallogin.Set(db, login); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// Apply executes a command translated from a VCS webhook and returns the resulting pull request.
+// Providers redeliver webhooks, so every action is idempotent: opening an existing pull request
+// returns it unchanged. The merge has already happened in the VCS, so it is recorded even when
+// approvals are missing or the merger's login is not mapped to a user.
+// Returns ErrUnknownExternalLogin if the author of an opened pull request is not mapped to a user.
+func (s *IntegrationService) Apply(ctx context.Context, cmd integration.Command) (*domain.PullRequest, error) {
+	ctx, span := startSpan(ctx, "IntegrationService.Apply")
+	defer span.End()
+
+	switch cmd.Action {
+	case integration.ActionOpen:
+		authorID, err := s.resolveLogin(ctx, cmd.Provider, cmd.ActorLogin)
+		if err != nil {
+			return nil, err
+		}
+		created, err := s.prService.CreatePR(ctx, cmd.Key, cmd.Title, authorID, nil, domain.PRDetails{
+			Description: cmd.Description,
+			ExternalURL: cmd.ExternalURL,
+		})
+		if errors.Is(err, ErrPRExists) {
+			return s.prService.GetPR(ReadPrimary(ctx), cmd.Key)
+		}
+		return created, err
+	case integration.ActionMerge:
+		mergedBy, err := s.resolveLogin(ctx, cmd.Provider, cmd.ActorLogin)
+		if err != nil && !errors.Is(err, ErrUnknownExternalLogin) {
+			return nil, err
+		}
+		// The forced merge is audited as the provider's, not as the anonymous webhook caller's.
+		ctx = withAuditActorName(ctx, "integration:"+cmd.Provider)
+		return s.prService.MergePR(ctx, cmd.Key, MergeOptions{MergedBy: mergedBy, Force: true})
+	case integration.ActionClose:
+		return s.prService.ClosePR(ctx, cmd.Key)
+	default:
+		return nil, fmt.Errorf("unknown integration action %q", cmd.Action)
+	}
+}
+
+// resolveLogin returns the user the provider login is mapped to.
+func (s *IntegrationService) resolveLogin(ctx context.Context, provider, login string) (string, error) {
+	db := repository.WithContext(ctx, s.db)
+
+	userID, err := externallogin.GetUserID(db, provider, login)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return "", fmt.Errorf("%w: %s login %s", ErrUnknownExternalLogin, provider, login)
+		}
+		return "", err
+	}
+	return userID, nil
+}
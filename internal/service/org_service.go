@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/organization"
+)
+
+// OrgService manages organizations.
+// Organizations are never removed, so the ones found once are remembered and not looked up again.
+type OrgService struct {
+	db    *sql.DB
+	known sync.Map
+}
+
+// NewOrgService creates a new organization service.
+func NewOrgService(db *sql.DB) *OrgService {
+	return &OrgService{db: db}
+}
+
+// CreateOrg creates an organization and records the creation in the audit log.
+// Returns ErrOrgExists if an organization with the ID already exists.
+func (s *OrgService) CreateOrg(ctx context.Context, orgID, name string) (*domain.Organization, error) {
+	ctx, span := startSpan(ctx, "OrgService.CreateOrg")
+	defer span.End()
+
+	o := &domain.Organization{OrgID: orgID, Name: name}
+	err := repository.WithTx(ctx, s.db, func(tx repository.DBTX) error {
+		if err := organization.Create(tx, o); err != nil {
+			if errors.Is(err, repository.ErrConflict) {
+				return ErrOrgExists
+			}
+			return err
+		}
+		return recordAudit(ctx, tx, domain.AuditOrgCreate, "org:"+orgID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.known.Store(orgID, true)
+	return o, nil
+}
+
+// ListOrgs returns all organizations.
+func (s *OrgService) ListOrgs(ctx context.Context) ([]domain.Organization, error) {
+	ctx, span := startSpan(ctx, "OrgService.ListOrgs")
+	defer span.End()
+	db := repository.WithContext(ctx, s.db)
+
+	orgs, err := organization.List(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	return orgs, nil
+}
+
+// OrgExists reports whether the organization exists.
+func (s *OrgService) OrgExists(ctx context.Context, orgID string) (bool, error) {
+	if _, ok := s.known.Load(orgID); ok {
+		return true, nil
+	}
+
+	ctx, span := startSpan(ctx, "OrgService.OrgExists")
+	defer span.End()
+	db := repository.WithContext(ctx, s.db)
+
+	exists, err := organization.Exists(db, orgID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check organization: %w", err)
+	}
+	if exists {
+		s.known.Store(orgID, true)
+	}
+	return exists, nil
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/outbox"
+)
+
+// EventPublisher delivers an event outside the service, e.g. to webhooks.
+// A returned error makes the outbox publish the event again later.
+type EventPublisher interface {
+	Publish(ctx context.Context, event domain.Event) error
+}
+
+// OutboxDispatcher periodically hands events written to the outbox to the registered publishers.
+// Delivery is at least once: an event is marked published only after every publisher accepted it,
+// and a failure makes all of them receive it again on a later tick. Events of one pull request are
+// published in the order they were written; a failing event holds back the later ones of its
+// pull request until it is published or given up on after maxAttempts.
+// The outbox is shared by all organizations; publishers get each event with its organization in ctx.
+type OutboxDispatcher struct {
+	db          *sql.DB
+	publishers  []EventPublisher
+	interval    time.Duration
+	batchSize   int
+	maxAttempts int
+}
+
+// NewOutboxDispatcher creates a new outbox dispatcher.
+// batchSize caps how many events are read per tick.
+func NewOutboxDispatcher(db *sql.DB, interval time.Duration, batchSize, maxAttempts int) *OutboxDispatcher {
+	return &OutboxDispatcher{
+		db:          db,
+		interval:    interval,
+		batchSize:   batchSize,
+		maxAttempts: maxAttempts,
+	}
+}
+
+// WithPublisher registers a publisher; every event is handed to each of them.
+func (d *OutboxDispatcher) WithPublisher(publisher EventPublisher) *OutboxDispatcher {
+	d.publishers = append(d.publishers, publisher)
+	return d
+}
+
+// Run ticks every interval until ctx is cancelled.
+func (d *OutboxDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := d.Tick(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("Outbox tick failed: %v", err)
+			}
+		}
+	}
+}
+
+// Tick publishes up to batchSize pending events once.
+// Does nothing while another dispatcher, e.g. of another instance, is processing the outbox.
+// Returns the number of events published.
+func (d *OutboxDispatcher) Tick(ctx context.Context) (int, error) {
+	unlock, ok, err := outbox.TryLock(ctx, d.db)
+	if err != nil || !ok {
+		return 0, err
+	}
+	defer unlock()
+
+	entries, err := outbox.GetPending(d.db, d.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	held := make(map[heldKey]bool)
+	for _, e := range entries {
+		key := heldKey{orgID: e.Event.OrgID, pr: domain.PRKey{RepositoryName: e.Event.RepositoryName, PullRequestID: e.Event.PullRequestID}}
+		if held[key] {
+			continue
+		}
+
+		if err := d.publish(repository.WithOrg(ctx, e.Event.OrgID), e.Event); err != nil {
+			if ctx.Err() != nil {
+				return published, ctx.Err()
+			}
+			giveUp := e.Attempts+1 >= d.maxAttempts
+			if err := outbox.MarkAttemptFailed(d.db, e.ID, err.Error(), giveUp); err != nil {
+				return published, err
+			}
+			if giveUp {
+				log.Printf("Giving up on %s event %d after %d attempts: %v", e.Event.Type, e.ID, e.Attempts+1, err)
+			} else {
+				held[key] = true
+			}
+			continue
+		}
+
+		if err := outbox.MarkPublished(d.db, e.ID); err != nil {
+			return published, err
+		}
+		published++
+	}
+
+	return published, nil
+}
+
+// heldKey identifies a pull request whose later events are held back within a tick.
+type heldKey struct {
+	orgID string
+	pr    domain.PRKey
+}
+
+// publish hands the event to every publisher and joins their errors.
+func (d *OutboxDispatcher) publish(ctx context.Context, event domain.Event) error {
+	var errs []error
+	for _, p := range d.publishers {
+		if err := p.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to publish %s event %s: %w", event.Type, event.ID, errors.Join(errs...))
+	}
+	return nil
+}
+
+// MemoryPublisher is an EventPublisher keeping events in memory, for tests.
+type MemoryPublisher struct {
+	mu     sync.Mutex
+	events []domain.Event
+}
+
+// NewMemoryPublisher creates an empty in-memory publisher.
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+// Publish records the event.
+func (p *MemoryPublisher) Publish(_ context.Context, event domain.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+	return nil
+}
+
+// Events returns the events published so far, oldest first.
+func (p *MemoryPublisher) Events() []domain.Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]domain.Event(nil), p.events...)
+}
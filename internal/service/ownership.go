@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/ownership"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+)
+
+// SetOwnershipRule creates the team's ownership rule for the rule's path prefix or replaces its owner.
+// The prefix is normalized with domain.NormalizePath. Exactly one owner must be set.
+func (s *TeamService) SetOwnershipRule(ctx context.Context, rule domain.OwnershipRule) (*domain.OwnershipRule, error) {
+	ctx, span := startSpan(ctx, "TeamService.SetOwnershipRule")
+	defer span.End()
+
+	if (rule.OwnerUserID == "") == (rule.OwnerTeamName == "") {
+		return nil, ErrInvalidOwner
+	}
+	rule.PathPrefix = domain.NormalizePath(rule.PathPrefix)
+
+	err := repository.WithTx(ctx, s.db, func(tx repository.DBTX) error {
+		exists, err := team.Exists(tx, rule.TeamName)
+		if err != nil {
+			return fmt.Errorf("failed to check team existence: %w", err)
+		}
+		if !exists {
+			return ErrTeamNotFound
+		}
+
+		if rule.OwnerUserID != "" {
+			if _, err := user.Get(tx, rule.OwnerUserID); err != nil {
+				if errors.Is(err, repository.ErrNotFound) {
+					return ErrUserNotFound
+				}
+				return fmt.Errorf("failed to get owner: %w", err)
+			}
+		} else {
+			exists, err := team.Exists(tx, rule.OwnerTeamName)
+			if err != nil {
+				return fmt.Errorf("failed to check owner team existence: %w", err)
+			}
+			if !exists {
+				return ErrOwnerTeamNotFound
+			}
+		}
+
+		return ownership.Set(tx, &rule)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &rule, nil
+}
+
+// ListOwnershipRules returns the team's ownership rules ordered by path prefix.
+func (s *TeamService) ListOwnershipRules(ctx context.Context, teamName string) ([]domain.OwnershipRule, error) {
+	ctx, span := startSpan(ctx, "TeamService.ListOwnershipRules")
+	defer span.End()
+	db := repository.WithContext(ctx, s.prService.reader(ctx))
+
+	exists, err := team.Exists(db, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check team existence: %w", err)
+	}
+	if !exists {
+		return nil, ErrTeamNotFound
+	}
+
+	return ownership.ListByTeam(db, teamName)
+}
+
+// DeleteOwnershipRule removes the team's ownership rule for the path prefix.
+func (s *TeamService) DeleteOwnershipRule(ctx context.Context, teamName, pathPrefix string) error {
+	ctx, span := startSpan(ctx, "TeamService.DeleteOwnershipRule")
+	defer span.End()
+	db := repository.WithContext(ctx, s.db)
+
+	deleted, err := ownership.Delete(db, teamName, domain.NormalizePath(pathPrefix))
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrOwnershipRuleNotFound
+	}
+	return nil
+}
+
+// resolveOwners returns the reviewers the ownership rules of the author's team require for the
+// changed paths. Each path is matched against the rule with the longest covering prefix; rules are
+// resolved in the order of the first path they match, and only while fewer than count reviewers are chosen.
+// Reviewers in chosen count as already picked: an owner among them, or a member of an owning team
+// among them, satisfies the rule. A user owner who is the author or inactive is skipped, as is an
+// owning team without an eligible member.
+func (s *PRService) resolveOwners(exec repository.DBTX, author *domain.User, paths, chosen []string, count int) ([]string, error) {
+	if len(paths) == 0 || len(chosen) >= count {
+		return nil, nil
+	}
+
+	rules, err := ownership.ListByTeam(exec, author.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	chosen = slices.Clone(chosen)
+	var owners []string
+	resolved := make(map[string]bool)
+	for _, path := range paths {
+		if len(chosen) >= count {
+			break
+		}
+		rule := domain.MatchOwnershipRule(rules, path)
+		if rule == nil || resolved[rule.PathPrefix] {
+			continue
+		}
+		resolved[rule.PathPrefix] = true
+
+		ownerID, err := s.pickOwner(exec, author.UserID, *rule, chosen)
+		if err != nil {
+			return nil, err
+		}
+		if ownerID != "" {
+			chosen = append(chosen, ownerID)
+			owners = append(owners, ownerID)
+		}
+	}
+	return owners, nil
+}
+
+// pickOwner returns the reviewer satisfying the rule, or "" if the rule is already satisfied by
+// chosen or nobody eligible owns the path. An owning team is represented by one member picked
+// with the team's assignment strategy.
+func (s *PRService) pickOwner(exec repository.DBTX, authorID string, rule domain.OwnershipRule, chosen []string) (string, error) {
+	if rule.OwnerUserID != "" {
+		if rule.OwnerUserID == authorID || slices.Contains(chosen, rule.OwnerUserID) {
+			return "", nil
+		}
+		u, err := user.Get(exec, rule.OwnerUserID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return "", nil
+			}
+			return "", fmt.Errorf("failed to get owner %s: %w", rule.OwnerUserID, err)
+		}
+		if !u.IsActive {
+			return "", nil
+		}
+		return u.UserID, nil
+	}
+
+	members, err := user.GetReassignCandidates(exec, rule.OwnerTeamName, authorID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get owner team members: %w", err)
+	}
+	candidates := make([]domain.User, 0, len(members))
+	for _, u := range members {
+		if slices.Contains(chosen, u.UserID) {
+			return "", nil
+		}
+		if u.UserID != authorID {
+			candidates = append(candidates, u)
+		}
+	}
+
+	assigner, err := s.assignerFor(exec, rule.OwnerTeamName)
+	if err != nil {
+		return "", err
+	}
+	picked, err := assigner.SelectReviewersN(candidates, 1)
+	if err != nil {
+		return "", fmt.Errorf("failed to select owner: %w", err)
+	}
+	if len(picked) == 0 {
+		return "", nil
+	}
+	return picked[0], nil
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// maxGeneratedIDAttempts bounds how many generated ids CreatePR tries when one is already taken.
+const maxGeneratedIDAttempts = 3
+
+// WithIDGenerator sets the function generating ids of pull requests created without one.
+// By default they are UUIDv7s, so that ids generated later sort after earlier ones.
+func (s *PRService) WithIDGenerator(generate func() string) *PRService {
+	s.generateID = generate
+	return s
+}
+
+// newPRID returns an id for a pull request created without one.
+func (s *PRService) newPRID() (string, error) {
+	if s.generateID != nil {
+		return s.generateID(), nil
+	}
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate pull request id: %w", err)
+	}
+	return id.String(), nil
+}
@@ -1,13 +1,19 @@
 package service
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/mishasvintus/avito_backend_internship/internal/domain"
 	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/history"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/outbox"
 	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
 	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
 )
 
@@ -15,86 +21,238 @@ import (
 type PRService struct {
 	db       *sql.DB
 	assigner *ReviewerAssigner
+	version  *DataVersion
+	retry    RetryPolicy
+	dbRouter *repository.DBRouter
+	// clock stamps when PRs are created, merged and closed, and measures how long reviews
+	// have waited against the team's review SLA.
+	clock Clock
+	// reassignLimit caps manual reassigns per PR; zero disables the cap.
+	reassignLimit int
+	// createLimit caps the PRs an author may create per rolling hour; zero disables the cap.
+	createLimit int
+	// generateID overrides how ids of pull requests created without one are generated.
+	generateID func() string
 }
 
 // NewPRService creates a new pull request service.
 func NewPRService(db *sql.DB, assigner *ReviewerAssigner) *PRService {
 	return &PRService{
-		db:       db,
-		assigner: assigner,
+		db:            db,
+		assigner:      assigner,
+		version:       &DataVersion{},
+		retry:         DefaultRetryPolicy,
+		clock:         NewSystemClock(),
+		reassignLimit: DefaultReassignLimit,
 	}
 }
 
-// CreatePR creates a new pull request and assigns up to 2 reviewers.
-func (s *PRService) CreatePR(prID, prName, authorID string) (*domain.PullRequest, error) {
-	author, err := user.Get(s.db, authorID)
+// WithClock sets the clock that PR timestamps of this service, and review waiting times of
+// this service and the user service built on it, are taken from.
+func (s *PRService) WithClock(clock Clock) *PRService {
+	s.clock = clock
+	return s
+}
+
+// WithRetryPolicy sets how transactions of this service and the team service
+// built on it are retried after transient database errors.
+func (s *PRService) WithRetryPolicy(policy RetryPolicy) *PRService {
+	s.retry = policy
+	return s
+}
+
+// WithReassignLimit sets how many reviewer replacements a PR may go through before
+// ReassignPR refuses further ones without ReassignOptions.Force; zero removes the limit.
+func (s *PRService) WithReassignLimit(limit int) *PRService {
+	s.reassignLimit = limit
+	return s
+}
+
+// WithDBRouter sends the read-only queries of this service and the team and user services
+// built on it through router, so they can be served by a replica. Writes stay on db.
+func (s *PRService) WithDBRouter(router *repository.DBRouter) *PRService {
+	s.dbRouter = router
+	return s
+}
+
+// reader returns the database for the read-only methods of this service and those built on it.
+func (s *PRService) reader(ctx context.Context) *sql.DB {
+	return readDB(ctx, s.db, s.dbRouter)
+}
+
+// DataVersion returns the counter bumped after writes made through this service
+// and the team and user services built on it.
+func (s *PRService) DataVersion() *DataVersion {
+	return s.version
+}
+
+// CreatePR creates a new pull request and assigns up to the reviewer count of the author's team.
+// Required reviewers are assigned first, bypassing capacity and absence checks, followed by the owners
+// of the changed paths under the ownership rules of the author's team (see resolveOwners);
+// the remaining slots are filled by the team's assigner, preferring teammates sharing one of the PR's tags,
+// and then by members of the team's fallback team, if it has one.
+// A pr.created and a reviewer.assigned per reviewer event are written to the outbox in the same transaction.
+// An empty key.PullRequestID is replaced by a generated UUIDv7, retried with a new one if already taken.
+// A conflict with an existing pull request is returned as a PRExistsError carrying it, an author over
+// the creation limit (see WithAuthorCreateLimit) as an AuthorRateLimitError. A reviewer deactivated
+// or deleted after being picked fails the creation with an InactiveReviewerError or a ReviewerNotFoundError.
+func (s *PRService) CreatePR(ctx context.Context, key domain.PRKey, prName, authorID string, requiredReviewers []string, details domain.PRDetails) (*domain.PullRequest, error) {
+	ctx, span := startSpan(ctx, "PRService.CreatePR")
+	defer span.End()
+	db := repository.WithContext(ctx, s.db)
+
+	author, err := user.Get(db, authorID)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, repository.ErrNotFound) {
 			return nil, ErrPRAuthorNotFound
 		}
 		return nil, fmt.Errorf("failed to get author: %w", err)
 	}
+	if err := s.checkCreateLimit(ctx, db, authorID); err != nil {
+		return nil, err
+	}
 
-	teammates, err := user.GetActiveTeammates(s.db, authorID)
+	settings, err := s.teamSettings(db, author.TeamName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get teammates: %w", err)
+		return nil, err
 	}
+	count := settings.ReviewerCount
 
-	reviewers, err := s.assigner.SelectReviewers(teammates)
+	required, err := s.validateRequiredReviewers(db, authorID, requiredReviewers, count)
 	if err != nil {
-		return nil, fmt.Errorf("failed to select reviewers: %w", err)
+		return nil, err
 	}
-
-	tx, err := s.db.Begin()
+	owners, err := s.resolveOwners(db, author, details.ChangedPaths, required, count)
 	if err != nil {
-		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+		return nil, err
 	}
-	defer func() { _ = tx.Rollback() }()
+	required = append(required, owners...)
 
+	reviewers := required
+	var selection, fallbackSelection *domain.AssignmentDecision
+	if len(required) < count {
+		_, selection, err = s.selectReviewers(db, author, count-len(required), required, details.Tags)
+		if err != nil {
+			return nil, err
+		}
+		reviewers = append(reviewers, selection.Selected...)
+	}
+	// The last fallbackCount reviewers come from the fallback team.
+	fallbackCount := 0
+	if len(reviewers) < count && settings.FallbackTeamName != "" {
+		fallbackSelection, err = s.selectFallbackReviewers(db, author, settings.FallbackTeamName, count-len(reviewers), reviewers, details.Tags)
+		if err != nil {
+			return nil, err
+		}
+		reviewers = append(reviewers, fallbackSelection.Selected...)
+		fallbackCount = len(fallbackSelection.Selected)
+	}
+
+	sources := make([]domain.ReviewerSource, len(reviewers))
+	for i := range reviewers {
+		sources[i] = domain.SourceAuto
+		switch {
+		case i < len(required):
+			sources[i] = domain.SourceRequired
+		case i >= len(reviewers)-fallbackCount:
+			sources[i] = domain.SourceFallback
+		}
+	}
+
+	generated := key.PullRequestID == ""
+	if generated {
+		if key.PullRequestID, err = s.newPRID(); err != nil {
+			return nil, err
+		}
+	}
+
+	createdAt := s.clock.Now().UTC()
 	pullRequest := &domain.PullRequest{
-		PullRequestID:        prID,
+		RepositoryName:       key.RepositoryName,
+		PullRequestID:        key.PullRequestID,
 		PullRequestName:      prName,
 		AuthorID:             authorID,
 		TeamName:             author.TeamName,
 		Status:               domain.StatusOpen,
 		AssignedReviewersIDs: reviewers,
+		Description:          details.Description,
+		ExternalURL:          details.ExternalURL,
+		Size:                 details.Size,
+		LinesChanged:         details.LinesChanged,
+		Tags:                 details.Tags,
+		CreatedAt:            &createdAt,
+	}
+	if pullRequest.Size == "" && details.LinesChanged != nil {
+		pullRequest.Size = domain.SizeForLines(*details.LinesChanged)
 	}
 
-	if err := pr.Create(tx, pullRequest); err != nil {
-		if repository.IsUniqueViolation(err) {
-			return nil, ErrPRExists
+	create := func(tx repository.DBTX) error {
+		if err := pr.Create(tx, pullRequest); err != nil {
+			if errors.Is(err, repository.ErrConflict) {
+				return ErrPRExists
+			}
+			if repository.IsForeignKeyViolation(err) {
+				return ErrPRAuthorNotFound
+			}
+			return fmt.Errorf("failed to create pull request: %w", err)
 		}
-		if repository.IsForeignKeyViolation(err) {
-			return nil, ErrPRAuthorNotFound
+		if err := pr.InsertTags(tx, key, details.Tags); err != nil {
+			return err
 		}
-		return nil, fmt.Errorf("failed to create pull request: %w", err)
-	}
 
-	for _, reviewerID := range reviewers {
-		if err := pr.InsertReviewer(tx, prID, reviewerID); err != nil {
+		// Reviewers deactivated or erased since they were picked are not inserted; the missing
+		// count reveals them, and only then are they looked up to name one.
+		inserted, err := pr.InsertActiveReviewers(tx, key, reviewers, sources, createdAt)
+		if err != nil {
 			if repository.IsForeignKeyViolation(err) {
-				return nil, ErrPRAuthorNotFound
+				return ErrReviewerNotFound
 			}
-			return nil, fmt.Errorf("failed to assign reviewer: %w", err)
+			return fmt.Errorf("failed to assign reviewers: %w", err)
+		}
+		if inserted < len(reviewers) {
+			inactive, exists, err := user.FirstInactive(tx, reviewers)
+			if err != nil {
+				return fmt.Errorf("failed to verify reviewers: %w", err)
+			}
+			if !exists {
+				return &ReviewerNotFoundError{UserID: inactive}
+			}
+			return &InactiveReviewerError{UserID: inactive}
 		}
-	}
 
-	// Verify all assigned reviewers are still active
-	for _, reviewerID := range reviewers {
-		u, err := user.Get(tx, reviewerID)
+		created, err := pr.Get(tx, key)
 		if err != nil {
-			return nil, fmt.Errorf("failed to verify reviewer %s: %w", reviewerID, err)
+			return fmt.Errorf("failed to get created pull request: %w", err)
 		}
-		if !u.IsActive {
-			return nil, ErrInactiveReviewer
+		if err := outbox.Insert(tx, prEvent(domain.EventPRCreated, created)); err != nil {
+			return err
 		}
+		return recordAssigned(tx, key, reviewers)
 	}
-
-	if err := tx.Commit(); err != nil {
-		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	err = s.retry.RunTx(ctx, s.db, create)
+	for attempt := 1; generated && errors.Is(err, ErrPRExists) && attempt < maxGeneratedIDAttempts; attempt++ {
+		if key.PullRequestID, err = s.newPRID(); err != nil {
+			return nil, err
+		}
+		pullRequest.PullRequestID = key.PullRequestID
+		err = s.retry.RunTx(ctx, s.db, create)
+	}
+	if errors.Is(err, ErrPRExists) {
+		existing, getErr := pr.Get(db, key)
+		if getErr != nil {
+			// Deleted meanwhile or unreadable: the conflict is still the answer.
+			return nil, err
+		}
+		return nil, &PRExistsError{Existing: existing}
 	}
+	if err != nil {
+		return nil, err
+	}
+	s.version.Bump()
+	s.assigner.LogDecision(repository.Org(db), key, "CREATE", selection)
+	s.assigner.LogDecision(repository.Org(db), key, "FALLBACK", fallbackSelection)
 
-	fullPR, err := pr.Get(s.db, prID)
+	fullPR, err := pr.Get(db, key)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get created pull request: %w", err)
 	}
@@ -102,14 +260,234 @@ func (s *PRService) CreatePR(prID, prName, authorID string) (*domain.PullRequest
 	return fullPR, nil
 }
 
-const maxReviewers = 2
+// WithAuthorCreateLimit sets how many pull requests an author may create within a rolling hour
+// before CreatePR fails with an *AuthorRateLimitError; zero, the default, disables the limit.
+// Calls in a context from SkipCreateLimit are not limited.
+func (s *PRService) WithAuthorCreateLimit(limit int) *PRService {
+	s.createLimit = limit
+	return s
+}
+
+// DefaultReassignLimit is the number of reviewer replacements a PR may go through
+// before manual reassigns are refused.
+const DefaultReassignLimit = 10
+
+// ReviewerSuggestion is the outcome of a dry-run reviewer selection.
+type ReviewerSuggestion struct {
+	AuthorID   string
+	TeamName   string
+	Strategy   Strategy
+	Candidates []domain.User
+	Selected   []string
+}
+
+// SuggestReviewers runs the same selection as CreatePR for up to count reviewers
+// without writing anything.
+func (s *PRService) SuggestReviewers(ctx context.Context, authorID string, count int) (*ReviewerSuggestion, error) {
+	ctx, span := startSpan(ctx, "PRService.SuggestReviewers")
+	defer span.End()
+	db := repository.WithContext(ctx, s.db)
+
+	author, err := user.Get(db, authorID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrPRAuthorNotFound
+		}
+		return nil, fmt.Errorf("failed to get author: %w", err)
+	}
+
+	candidates, decision, err := s.selectReviewers(db, author, count, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReviewerSuggestion{
+		AuthorID:   authorID,
+		TeamName:   author.TeamName,
+		Strategy:   Strategy(decision.Strategy),
+		Candidates: candidates,
+		Selected:   decision.Selected,
+	}, nil
+}
+
+// selectReviewers loads the author's eligible teammates, drops the excluded ones and picks up to count
+// of them with the team's strategy, preferring those sharing one of tags.
+// Returns the candidates and the decision.
+func (s *PRService) selectReviewers(exec repository.DBTX, author *domain.User, count int, exclude, tags []string) ([]domain.User, *domain.AssignmentDecision, error) {
+	all, err := user.GetActiveTeammates(exec, author.UserID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get teammates: %w", err)
+	}
+
+	teammates := make([]domain.User, 0, len(all))
+	var excluded []string
+	for _, u := range all {
+		if slices.Contains(exclude, u.UserID) {
+			excluded = append(excluded, u.UserID)
+		} else {
+			teammates = append(teammates, u)
+		}
+	}
+
+	assigner, err := s.assignerFor(exec, author.TeamName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	decision, err := assigner.ForTags(tags).Decide(teammates, count)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to select reviewers: %w", err)
+	}
+	excludeFirst(decision, excluded, domain.ExcludedAssigned)
 
-// ReplenishReviewers ensures the PR has up to maxReviewers reviewers from its team.
-// Does nothing if PR already has >= maxReviewers or is not OPEN.
-func (s *PRService) ReplenishReviewers(exec repository.DBTX, prID string) error {
-	pullRequest, err := pr.Get(exec, prID)
+	return teammates, decision, nil
+}
+
+// selectFallbackReviewers picks up to count members of the fallback team for the author's PR with the
+// fallback team's strategy, leaving out the author, those in exclude and those excluded from reviewing the author.
+// A fallback team that no longer exists yields no reviewers.
+func (s *PRService) selectFallbackReviewers(exec repository.DBTX, author *domain.User, fallbackTeamName string, count int, exclude, tags []string) (*domain.AssignmentDecision, error) {
+	all, err := user.GetReassignCandidates(exec, fallbackTeamName, author.UserID)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to get fallback team members: %w", err)
+	}
+
+	candidates := make([]domain.User, 0, len(all))
+	var excludedAuthor, excluded []string
+	for _, u := range all {
+		switch {
+		case u.UserID == author.UserID:
+			excludedAuthor = append(excludedAuthor, u.UserID)
+		case slices.Contains(exclude, u.UserID):
+			excluded = append(excluded, u.UserID)
+		default:
+			candidates = append(candidates, u)
+		}
+	}
+
+	assigner, err := s.assignerFor(exec, fallbackTeamName)
+	if err != nil {
+		return nil, err
+	}
+	decision, err := assigner.ForTags(tags).Decide(candidates, count)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select fallback reviewers: %w", err)
+	}
+	excludeFirst(decision, excluded, domain.ExcludedAssigned)
+	excludeFirst(decision, excludedAuthor, domain.ExcludedAuthor)
+	return decision, nil
+}
+
+// teamSettings returns the team's settings, or the defaults if the team doesn't exist.
+func (s *PRService) teamSettings(exec repository.DBTX, teamName string) (*domain.TeamSettings, error) {
+	settings, err := team.GetSettings(exec, teamName)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return &domain.TeamSettings{ReviewerCount: domain.DefaultReviewerCount}, nil
+		}
+		return nil, err
+	}
+	return settings, nil
+}
+
+type skipCreateLimitKey struct{}
+
+// SkipCreateLimit returns a context in which CreatePR does not apply the per-author creation limit,
+// e.g. for requests of admins.
+func SkipCreateLimit(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipCreateLimitKey{}, true)
+}
+
+// checkCreateLimit returns an *AuthorRateLimitError if the author already created createLimit
+// pull requests within the last hour. The count is not locked, so concurrent creates may exceed it slightly.
+func (s *PRService) checkCreateLimit(ctx context.Context, exec repository.DBTX, authorID string) error {
+	if skip, _ := ctx.Value(skipCreateLimitKey{}).(bool); skip || s.createLimit <= 0 {
+		return nil
+	}
+	now := s.clock.Now().UTC()
+	used, oldest, err := pr.CountCreatedSince(exec, authorID, now.Add(-time.Hour))
+	if err != nil {
+		return err
+	}
+	if used < s.createLimit {
+		return nil
+	}
+	return &AuthorRateLimitError{
+		Limit:      s.createLimit,
+		Used:       used,
+		RetryAfter: max(oldest.Add(time.Hour).Sub(now), 0),
+	}
+}
+
+// validateRequiredReviewers checks that there are at most count required reviewers and that every one
+// exists, is active and is not the author.
+// Duplicates are dropped. Returns the reviewers in request order.
+func (s *PRService) validateRequiredReviewers(exec repository.DBTX, authorID string, requiredReviewers []string, count int) ([]string, error) {
+	reviewers := make([]string, 0, len(requiredReviewers))
+	for _, id := range requiredReviewers {
+		if !slices.Contains(reviewers, id) {
+			reviewers = append(reviewers, id)
+		}
+	}
+
+	if len(reviewers) > count {
+		return nil, ErrTooManyRequiredReviewers
+	}
+
+	for _, id := range reviewers {
+		if id == authorID {
+			return nil, ErrRequiredReviewerIsAuthor
+		}
+		u, err := user.Get(exec, id)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil, ErrRequiredReviewerNotFound
+			}
+			return nil, fmt.Errorf("failed to get required reviewer %s: %w", id, err)
+		}
+		// An erased user is gone for good, not merely away.
+		if u.Erased {
+			return nil, ErrRequiredReviewerNotFound
+		}
+		if !u.IsActive {
+			return nil, ErrRequiredReviewerInactive
+		}
+	}
+
+	return reviewers, nil
+}
+
+// assignerFor returns an assigner using the team's configured strategy.
+// Falls back to the default assigner if the team has no valid strategy stored.
+func (s *PRService) assignerFor(exec repository.DBTX, teamName string) (*ReviewerAssigner, error) {
+	name, err := team.GetStrategy(exec, teamName)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return s.assigner, nil
+		}
+		return nil, fmt.Errorf("failed to get team strategy: %w", err)
+	}
+	strategy, err := ParseStrategy(name)
+	if err != nil {
+		return s.assigner, nil
+	}
+	return s.assigner.ForStrategy(strategy), nil
+}
+
+// ReplenishReviewers ensures the PR has as many reviewers from its team as the team's reviewer count.
+// Does nothing if PR already has that many or is not OPEN.
+// Writes a reviewer.assigned event per added reviewer to the outbox through exec.
+func (s *PRService) ReplenishReviewers(exec repository.DBTX, key domain.PRKey) error {
+	return s.replenishReviewersOutside(exec, key, "")
+}
+
+// replenishReviewersOutside is ReplenishReviewers never picking members of excludedTeam (none if empty).
+// Each pick is re-checked to be active and outside excludedTeam when it is inserted, so that a user
+// deactivated or added to excludedTeam by a concurrent transaction is skipped rather than assigned.
+func (s *PRService) replenishReviewersOutside(exec repository.DBTX, key domain.PRKey, excludedTeam string) error {
+	pullRequest, err := pr.Get(exec, key)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
 			return nil
 		}
 		return fmt.Errorf("failed to get PR: %w", err)
@@ -117,128 +495,627 @@ func (s *PRService) ReplenishReviewers(exec repository.DBTX, prID string) error
 	if pullRequest.Status != domain.StatusOpen {
 		return nil
 	}
+	decision, _, err := s.replenishment(exec, pullRequest, excludedTeam)
+	if err != nil || decision == nil {
+		return err
+	}
+
+	added := make([]string, 0, len(decision.Selected))
+	for _, reviewer := range decision.Selected {
+		err := pr.InsertActiveReviewer(exec, key, reviewer, excludedTeam, s.clock.Now().UTC())
+		if errors.Is(err, repository.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		added = append(added, reviewer)
+	}
+	if err := recordAssigned(exec, key, added); err != nil {
+		return err
+	}
+	decision.Selected = added
+	s.assigner.LogDecision(repository.Org(exec), key, "REPLENISH", decision)
+	return nil
+}
+
+// replenishment picks the teammates that would top the open pull request up to its team's reviewer count,
+// leaving out members of excludedTeam (none if empty).
+// Returns the decision selecting them, possibly fewer than missing, nil if none is missing or available,
+// and the reviewer count.
+func (s *PRService) replenishment(exec repository.DBTX, pullRequest *domain.PullRequest, excludedTeam string) (*domain.AssignmentDecision, int, error) {
+	settings, err := s.teamSettings(exec, pullRequest.TeamName)
+	if err != nil {
+		return nil, 0, err
+	}
 	reviewerCount := len(pullRequest.AssignedReviewersIDs)
-	if reviewerCount >= maxReviewers {
-		return nil
+	if reviewerCount >= settings.ReviewerCount {
+		return nil, settings.ReviewerCount, nil
 	}
 
-	candidates, err := user.GetActiveByTeam(exec, pullRequest.TeamName)
+	candidates, err := user.GetReassignCandidatesOutside(exec, pullRequest.TeamName, pullRequest.AuthorID, excludedTeam)
 	if err != nil {
-		return fmt.Errorf("failed to get active users in PR team: %w", err)
+		return nil, 0, fmt.Errorf("failed to get active users in PR team: %w", err)
 	}
-	newReviewers, err := s.assigner.SelectReassignReviewers(candidates, pullRequest.AuthorID, pullRequest.AssignedReviewersIDs)
-	if err != nil || len(newReviewers) == 0 {
-		return nil
+	assigner, err := s.assignerFor(exec, pullRequest.TeamName)
+	if err != nil {
+		return nil, 0, err
+	}
+	decision, err := assigner.ForTags(pullRequest.Tags).DecideReassign(
+		candidates, pullRequest.AuthorID, pullRequest.AssignedReviewersIDs, settings.ReviewerCount-reviewerCount,
+	)
+	if err != nil {
+		return nil, settings.ReviewerCount, nil
 	}
+	return decision, settings.ReviewerCount, nil
+}
 
-	need := min(maxReviewers - reviewerCount, len(newReviewers))
+// addReviewers assigns reviewers to the pull request at assignedAt and writes a reviewer.assigned event per reviewer.
+func addReviewers(exec repository.DBTX, key domain.PRKey, reviewers []string, assignedAt time.Time) error {
+	for _, reviewer := range reviewers {
+		if err := pr.InsertReviewer(exec, key, reviewer, assignedAt); err != nil {
+			return fmt.Errorf("failed to insert reviewer: %w", err)
+		}
+	}
+	return recordAssigned(exec, key, reviewers)
+}
 
-	newReviewers = newReviewers[:need]
+// prEvent returns an event about the pull request as a whole.
+func prEvent(eventType domain.EventType, pullRequest *domain.PullRequest) domain.Event {
+	return domain.Event{
+		Type:           eventType,
+		RepositoryName: pullRequest.RepositoryName,
+		PullRequestID:  pullRequest.PullRequestID,
+		PullRequest:    pullRequest,
+	}
+}
 
-	for _, reviewer := range newReviewers {
-		if err := pr.InsertReviewer(exec, prID, reviewer); err != nil {
-			return fmt.Errorf("failed to insert reviewer: %w", err)
+// recordAssigned writes a reviewer.assigned event per reviewer to the outbox.
+func recordAssigned(exec repository.DBTX, key domain.PRKey, reviewers []string) error {
+	for _, reviewerID := range reviewers {
+		event := domain.Event{
+			Type:           domain.EventReviewerAssigned,
+			RepositoryName: key.RepositoryName,
+			PullRequestID:  key.PullRequestID,
+			ReviewerID:     reviewerID,
+		}
+		if err := outbox.Insert(exec, event); err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
-// MergePR merges a pull request.
-// Idempotent: if already merged, returns current state without error.
-func (s *PRService) MergePR(prID string) (*domain.PullRequest, error) {
-	pullRequest, err := pr.Get(s.db, prID)
+// GetPR retrieves a pull request with its assigned reviewers, whose assignments are measured against the team's review SLA.
+func (s *PRService) GetPR(ctx context.Context, key domain.PRKey) (*domain.PullRequest, error) {
+	ctx, span := startSpan(ctx, "PRService.GetPR")
+	defer span.End()
+	db := repository.WithContext(ctx, s.reader(ctx))
+
+	pullRequest, err := pr.Get(db, key)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, repository.ErrNotFound) {
 			return nil, ErrPRNotFound
 		}
 		return nil, fmt.Errorf("failed to get pull request: %w", err)
 	}
 
-	if pullRequest.Status == domain.StatusMerged {
-		return pullRequest, nil
+	now := s.clock.Now()
+	for i := range pullRequest.Assignments {
+		pullRequest.Assignments[i].Measure(now, pullRequest.Status)
 	}
+	return pullRequest, nil
+}
+
+// MergeOptions controls MergePR.
+// MergedBy names the user performing the merge; empty records no actor.
+// Force skips the check of the team's required approvals.
+type MergeOptions struct {
+	MergedBy string
+	Force    bool
+}
+
+// MergePR merges a pull request.
+// Idempotent: if already merged, returns current state, including the original merged_by, without error.
+// Unless opts.Force is set, approvals are counted in the merge transaction and a *NotApprovedError
+// is returned when the team requires more of them; a forced merge is recorded in the audit log. A pr.merged event is written to the outbox by the merge itself.
+// Returns ErrUserNotFound if opts.MergedBy does not exist and ErrPRClosed if the pull request was closed without merge.
+func (s *PRService) MergePR(ctx context.Context, key domain.PRKey, opts MergeOptions) (*domain.PullRequest, error) {
+	ctx, span := startSpan(ctx, "PRService.MergePR")
+	defer span.End()
+	db := repository.WithContext(ctx, s.db)
+
+	if opts.MergedBy != "" {
+		if _, err := user.Get(db, opts.MergedBy); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil, ErrUserNotFound
+			}
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+	}
+
+	merged := false
+	err := s.retry.RunTx(ctx, s.db, func(tx repository.DBTX) error {
+		merged = false
+		// Reassignments lock the row too, so the reviewers read below are the ones the PR is merged with.
+		if err := pr.Lock(tx, key); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return ErrPRNotFound
+			}
+			return err
+		}
+		pullRequest, err := pr.Get(tx, key)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return ErrPRNotFound
+			}
+			return fmt.Errorf("failed to get pull request: %w", err)
+		}
+
+		switch pullRequest.Status {
+		case domain.StatusMerged:
+			return nil
+		case domain.StatusClosed:
+			return ErrPRClosed
+		}
+
+		if !opts.Force {
+			if err := checkApprovals(tx, pullRequest); err != nil {
+				return err
+			}
+		}
+
+		// ErrNotFound here means a concurrent request merged or closed it first; the re-read below returns that state.
+		if err := pr.UpdateStatusToMerged(tx, key, opts.MergedBy, s.clock.Now()); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil
+			}
+			return fmt.Errorf("failed to merge pull request: %w", err)
+		}
+		merged = true
+
+		if opts.Force {
+			if err := recordAudit(ctx, tx, domain.AuditPRForceMerge, "pr:"+key.String()); err != nil {
+				return err
+			}
+		}
 
-	if err := pr.UpdateStatusToMerged(s.db, prID); err != nil {
-		return nil, fmt.Errorf("failed to merge pull request: %w", err)
+		mergedPR, err := pr.Get(tx, key)
+		if err != nil {
+			return fmt.Errorf("failed to get merged pull request: %w", err)
+		}
+		event := prEvent(domain.EventPRMerged, mergedPR)
+		event.ReviewerIDs = mergedPR.AssignedReviewersIDs
+		return outbox.Insert(tx, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if merged {
+		s.version.Bump()
 	}
 
 	// Get updated PR data
-	mergedPR, err := pr.Get(s.db, prID)
+	mergedPR, err := pr.Get(db, key)
 	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrPRNotFound
+		}
 		return nil, fmt.Errorf("failed to get merged pull request: %w", err)
 	}
+	if mergedPR.Status == domain.StatusClosed {
+		return nil, ErrPRClosed
+	}
 
 	return mergedPR, nil
 }
 
-// ReassignPR replaces one specific reviewer with a new one.
-// New reviewer is chosen from the PR's responsible team (team_name).
-// Returns the updated PR and the new reviewer's ID.
-func (s *PRService) ReassignPR(prID, oldReviewerID string) (*domain.PullRequest, string, error) {
-	pullRequest, err := pr.Get(s.db, prID)
+// checkApprovals returns a *NotApprovedError when the pull request has fewer approvals than its team requires.
+// The requirement is capped at the number of assigned reviewers, so any value not below it means "all of them".
+func checkApprovals(exec repository.DBTX, pullRequest *domain.PullRequest) error {
+	required, err := team.GetRequireApprovals(exec, pullRequest.TeamName)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, "", ErrPRNotFound
+		return fmt.Errorf("failed to get team approval requirement: %w", err)
+	}
+	required = min(required, len(pullRequest.AssignedReviewersIDs))
+
+	approved := len(pullRequest.ApprovedReviewersIDs)
+	if approved >= required {
+		return nil
+	}
+
+	var missing []string
+	for _, reviewerID := range pullRequest.AssignedReviewersIDs {
+		if !slices.Contains(pullRequest.ApprovedReviewersIDs, reviewerID) {
+			missing = append(missing, reviewerID)
 		}
-		return nil, "", fmt.Errorf("failed to get pull request: %w", err)
 	}
+	slices.Sort(missing)
+
+	return &NotApprovedError{Required: required, Approved: approved, Missing: missing}
+}
+
+// ApprovePR records the reviewer's approval of an open pull request.
+// Idempotent: a repeated approval keeps the original one.
+// Returns ErrReviewerNotAssigned if the user is not assigned to the pull request,
+// ErrPRMerged or ErrPRClosed if it is no longer open.
+func (s *PRService) ApprovePR(ctx context.Context, key domain.PRKey, userID string) (*domain.PullRequest, error) {
+	ctx, span := startSpan(ctx, "PRService.ApprovePR")
+	defer span.End()
+	db := repository.WithContext(ctx, s.db)
+
+	err := s.retry.RunTx(ctx, s.db, func(tx repository.DBTX) error {
+		status, err := pr.GetStatus(tx, key)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return ErrPRNotFound
+			}
+			return fmt.Errorf("failed to get pull request status: %w", err)
+		}
+
+		switch status {
+		case domain.StatusMerged:
+			return ErrPRMerged
+		case domain.StatusClosed:
+			return ErrPRClosed
+		}
 
-	candidates, err := user.GetActiveByTeam(s.db, pullRequest.TeamName)
+		if err := pr.Approve(tx, key, userID, s.clock.Now().UTC()); err != nil {
+			if errors.Is(err, pr.ErrReviewerNotAssigned) {
+				return ErrReviewerNotAssigned
+			}
+			return err
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to get active users in PR team: %w", err)
+		return nil, err
 	}
+	s.version.Bump()
 
-	newReviewers, err := s.assigner.SelectReassignReviewers(candidates, pullRequest.AuthorID, pullRequest.AssignedReviewersIDs)
-	if err != nil || len(newReviewers) == 0 {
-		return nil, "", ErrNoCandidate
+	approved, err := pr.Get(db, key)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrPRNotFound
+		}
+		return nil, fmt.Errorf("failed to get approved pull request: %w", err)
 	}
-	newReviewerID := newReviewers[0]
+	return approved, nil
+}
+
+// ClosePR closes a pull request without merging it and records a CLOSE event.
+// Idempotent: if already closed, returns current state without error.
+// Returns ErrPRMerged if the pull request was merged.
+func (s *PRService) ClosePR(ctx context.Context, key domain.PRKey) (*domain.PullRequest, error) {
+	ctx, span := startSpan(ctx, "PRService.ClosePR")
+	defer span.End()
+	db := repository.WithContext(ctx, s.db)
+
+	closed := false
+	err := s.retry.RunTx(ctx, s.db, func(tx repository.DBTX) error {
+		closed = false
+		// Merges and reassignments lock the row too, so the status read below cannot change before the update.
+		if err := pr.Lock(tx, key); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return ErrPRNotFound
+			}
+			return err
+		}
+		pullRequest, err := pr.Get(tx, key)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return ErrPRNotFound
+			}
+			return fmt.Errorf("failed to get pull request: %w", err)
+		}
+
+		switch pullRequest.Status {
+		case domain.StatusClosed:
+			return nil
+		case domain.StatusMerged:
+			return ErrPRMerged
+		}
+
+		if err := pr.UpdateStatusToClosed(tx, key, s.clock.Now()); err != nil {
+			return fmt.Errorf("failed to close pull request: %w", err)
+		}
+		closed = true
 
-	tx, err := s.db.Begin()
+		return history.Record(tx, &domain.AssignmentEvent{
+			RepositoryName: key.RepositoryName,
+			PullRequestID:  key.PullRequestID,
+			Action:         domain.ActionClose,
+		})
+	})
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to begin transaction: %w", err)
+		return nil, err
+	}
+	if closed {
+		s.version.Bump()
 	}
-	defer func() { _ = tx.Rollback() }()
 
-	status, err := pr.GetStatus(tx, prID)
+	closedPR, err := pr.Get(db, key)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, "", ErrPRNotFound
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrPRNotFound
 		}
-		return nil, "", fmt.Errorf("failed to check PR status: %w", err)
+		return nil, fmt.Errorf("failed to get closed pull request: %w", err)
 	}
+	return closedPR, nil
+}
 
-	if status != domain.StatusOpen {
-		return nil, "", ErrPRMerged
-	}
+// ReopenPR returns a MERGED or CLOSED pull request to OPEN and clears merged_at, merged_by and closed_at.
+// Reviewer assignments are kept through merge and close, so the previous reviewers are restored
+// with their review timers restarted; reviewers who have since become inactive are dropped, and
+// free slots are filled by the team's assigner as on creation. A REOPEN event is recorded.
+// Idempotent: an OPEN pull request is returned unchanged.
+func (s *PRService) ReopenPR(ctx context.Context, key domain.PRKey) (*domain.PullRequest, error) {
+	ctx, span := startSpan(ctx, "PRService.ReopenPR")
+	defer span.End()
+	db := repository.WithContext(ctx, s.db)
 
-	if err := pr.ReplaceReviewer(tx, prID, oldReviewerID, newReviewerID); err != nil {
-		if errors.Is(err, pr.ErrReviewerNotAssigned) {
-			return nil, "", ErrReviewerNotAssigned
+	reopened := false
+	err := s.retry.RunTx(ctx, s.db, func(tx repository.DBTX) error {
+		reopened = false
+		// Merges and closes lock the row too, so the status and reviewers read below are current.
+		if err := pr.Lock(tx, key); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return ErrPRNotFound
+			}
+			return err
 		}
-		if repository.IsForeignKeyViolation(err) {
-			return nil, "", ErrPRAuthorNotFound
+		pullRequest, err := pr.Get(tx, key)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return ErrPRNotFound
+			}
+			return fmt.Errorf("failed to get pull request: %w", err)
+		}
+		if pullRequest.Status == domain.StatusOpen {
+			return nil
 		}
-		return nil, "", fmt.Errorf("failed to replace reviewer: %w", err)
-	}
 
-	// Verify new reviewer is active
-	u, err := user.Get(tx, newReviewerID)
+		if err := pr.UpdateStatusToOpen(tx, key); err != nil {
+			return fmt.Errorf("failed to reopen pull request: %w", err)
+		}
+		reopened = true
+
+		for _, reviewerID := range pullRequest.AssignedReviewersIDs {
+			u, err := user.Get(tx, reviewerID)
+			if err != nil && !errors.Is(err, repository.ErrNotFound) {
+				return fmt.Errorf("failed to get reviewer %s: %w", reviewerID, err)
+			}
+			if u != nil && u.IsActive {
+				continue
+			}
+			if err := pr.DeleteReviewer(tx, key, reviewerID); err != nil {
+				return err
+			}
+		}
+		if err := pr.RestartReviews(tx, key, s.clock.Now().UTC()); err != nil {
+			return err
+		}
+		if err := s.ReplenishReviewers(tx, key); err != nil {
+			return err
+		}
+
+		return history.Record(tx, &domain.AssignmentEvent{
+			RepositoryName: key.RepositoryName,
+			PullRequestID:  key.PullRequestID,
+			Action:         domain.ActionReopen,
+		})
+	})
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to verify reviewer %s: %w", newReviewerID, err)
+		return nil, err
+	}
+	if reopened {
+		s.version.Bump()
 	}
-	if !u.IsActive {
-		return nil, "", ErrInactiveReviewer
+
+	reopenedPR, err := pr.Get(db, key)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrPRNotFound
+		}
+		return nil, fmt.Errorf("failed to get reopened pull request: %w", err)
 	}
+	return reopenedPR, nil
+}
+
+// ReassignOptions controls ReassignPR.
+// Force lets the reassign through after the PR reached the reassignment limit.
+type ReassignOptions struct {
+	Force bool
+}
+
+// ReassignPR replaces one specific reviewer with a new one.
+// New reviewer is chosen from the PR's responsible team (team_name).
+// Unless opts.Force is set, ErrReassignLimit is returned once the PR's reviewers have been
+// replaced as many times as the reassignment limit allows.
+// Returns the updated PR and the new reviewer's ID.
+func (s *PRService) ReassignPR(ctx context.Context, key domain.PRKey, oldReviewerID string, opts ReassignOptions) (*domain.PullRequest, string, error) {
+	ctx, span := startSpan(ctx, "PRService.ReassignPR")
+	defer span.End()
+	db := repository.WithContext(ctx, s.db)
 
-	if err := tx.Commit(); err != nil {
-		return nil, "", fmt.Errorf("failed to commit transaction: %w", err)
+	newReviewerID, err := s.reassignReviewer(ctx, key, oldReviewerID, domain.ActionReassign, !opts.Force)
+	if err != nil {
+		return nil, "", err
 	}
 
-	updatedPR, err := pr.Get(s.db, prID)
+	updatedPR, err := pr.Get(db, key)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to get updated pull request: %w", err)
 	}
 
 	return updatedPR, newReviewerID, nil
 }
+
+// ReassignResult is the outcome of moving one review away from a user.
+// ReplacedBy is empty when no replacement candidate was available.
+type ReassignResult struct {
+	PR         domain.PRKey
+	ReplacedBy string
+}
+
+// ReassignAllFrom moves every open review of the user to other teammates, one transaction per PR,
+// so progress survives a failure midway. PRs without a free candidate keep the user assigned.
+func (s *PRService) ReassignAllFrom(ctx context.Context, userID string, action domain.AssignmentAction) ([]ReassignResult, error) {
+	ctx, span := startSpan(ctx, "PRService.ReassignAllFrom")
+	defer span.End()
+	db := repository.WithContext(ctx, s.db)
+
+	keys, err := pr.GetOpenIDsByReviewer(db, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open reviews: %w", err)
+	}
+
+	results := make([]ReassignResult, 0, len(keys))
+	for _, key := range keys {
+		newReviewerID, err := s.reassignReviewer(ctx, key, userID, action, false)
+		if err != nil {
+			if errors.Is(err, ErrNoCandidate) || isStaleCandidate(err) {
+				results = append(results, ReassignResult{PR: key})
+				continue
+			}
+			// Merged, closed or reassigned concurrently: nothing left to move.
+			if errors.Is(err, ErrPRMerged) || errors.Is(err, ErrPRClosed) || errors.Is(err, ErrPRNotFound) || errors.Is(err, ErrReviewerNotAssigned) {
+				continue
+			}
+			return results, fmt.Errorf("failed to reassign %s: %w", key, err)
+		}
+		results = append(results, ReassignResult{PR: key, ReplacedBy: newReviewerID})
+	}
+
+	return results, nil
+}
+
+// isStaleCandidate reports whether reassignReviewer rejected its candidate because the candidate
+// changed between being picked and being assigned.
+func isStaleCandidate(err error) bool {
+	return errors.Is(err, ErrReviewerIsAuthor) ||
+		errors.Is(err, ErrReviewerAssigned) ||
+		errors.Is(err, ErrInactiveReviewer) ||
+		errors.Is(err, ErrReviewerNotFound)
+}
+
+// reassignReviewer swaps oldReviewerID for a fresh candidate from the PR's team in one transaction
+// and records the change, with the decision picking the candidate, in the assignment history under
+// the given action and as a reviewer.reassigned event in the outbox.
+// Every replacement bumps the PR's reassignment count; with enforceLimit set, one taking the count
+// past the reassignment limit is rolled back with ErrReassignLimit.
+// A candidate that became the PR's author, got assigned, was deactivated or was deleted since it was
+// picked fails with ErrReviewerIsAuthor, ErrReviewerAssigned, an InactiveReviewerError or a
+// ReviewerNotFoundError.
+// Returns the new reviewer's ID.
+func (s *PRService) reassignReviewer(ctx context.Context, key domain.PRKey, oldReviewerID string, action domain.AssignmentAction, enforceLimit bool) (string, error) {
+	db := repository.WithContext(ctx, s.db)
+
+	pullRequest, err := pr.Get(db, key)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return "", ErrPRNotFound
+		}
+		return "", fmt.Errorf("failed to get pull request: %w", err)
+	}
+
+	candidates, err := user.GetReassignCandidates(db, pullRequest.TeamName, pullRequest.AuthorID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get active users in PR team: %w", err)
+	}
+
+	assigner, err := s.assignerFor(db, pullRequest.TeamName)
+	if err != nil {
+		return "", err
+	}
+
+	decision, err := assigner.ForTags(pullRequest.Tags).DecideReassign(candidates, pullRequest.AuthorID, pullRequest.AssignedReviewersIDs, 1)
+	if err != nil || len(decision.Selected) == 0 {
+		return "", ErrNoCandidate
+	}
+	newReviewerID := decision.Selected[0]
+
+	err = s.retry.RunTx(ctx, s.db, func(tx repository.DBTX) error {
+		// Bumping the count locks the row first, so a merge committed meanwhile is seen by the status check.
+		count, err := pr.IncrementReassignmentCount(tx, key)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return ErrPRNotFound
+			}
+			return err
+		}
+
+		status, authorID, err := pr.GetStatusAndAuthor(tx, key)
+		if err != nil {
+			return fmt.Errorf("failed to check PR status: %w", err)
+		}
+		switch status {
+		case domain.StatusClosed:
+			return ErrPRClosed
+		case domain.StatusMerged:
+			return ErrPRMerged
+		}
+		if enforceLimit && s.reassignLimit > 0 && count > s.reassignLimit {
+			return ErrReassignLimit
+		}
+
+		// The candidate was picked from reads made before the transaction; check it again against the
+		// locked PR and user rows so that a stale pick is rejected rather than stored.
+		if newReviewerID == authorID {
+			return ErrReviewerIsAuthor
+		}
+		if newReviewerID == oldReviewerID {
+			return ErrReviewerAssigned
+		}
+		active, err := user.LockActive(tx, newReviewerID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return &ReviewerNotFoundError{UserID: newReviewerID}
+			}
+			return fmt.Errorf("failed to verify reviewer %s: %w", newReviewerID, err)
+		}
+		if !active {
+			return &InactiveReviewerError{UserID: newReviewerID}
+		}
+
+		if err := pr.ReplaceReviewer(tx, key, oldReviewerID, newReviewerID, domain.SourceFor(action), s.clock.Now().UTC()); err != nil {
+			if errors.Is(err, pr.ErrReviewerNotAssigned) {
+				return ErrReviewerNotAssigned
+			}
+			if repository.IsUniqueViolation(err) {
+				return ErrReviewerAssigned
+			}
+			if repository.IsForeignKeyViolation(err) {
+				return ErrPRAuthorNotFound
+			}
+			return fmt.Errorf("failed to replace reviewer: %w", err)
+		}
+
+		if err := history.Record(tx, &domain.AssignmentEvent{
+			RepositoryName: key.RepositoryName,
+			PullRequestID:  key.PullRequestID,
+			Action:         action,
+			OldUserID:      oldReviewerID,
+			NewUserID:      newReviewerID,
+			Decision:       decision,
+		}); err != nil {
+			return err
+		}
+
+		return outbox.Insert(tx, domain.Event{
+			Type:               domain.EventReviewerReassigned,
+			RepositoryName:     key.RepositoryName,
+			PullRequestID:      key.PullRequestID,
+			ReviewerID:         newReviewerID,
+			ReplacedReviewerID: oldReviewerID,
+			Reason:             action,
+		})
+	})
+	if err != nil {
+		return "", err
+	}
+	s.version.Bump()
+	s.assigner.LogDecision(repository.Org(db), key, string(action), decision)
+
+	return newReviewerID, nil
+}
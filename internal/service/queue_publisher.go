@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/queue"
+)
+
+// EventSchemaVersion is the schema_version of events sent to the message queue.
+// It changes only when a field is removed or changes meaning; consumers must ignore unknown fields.
+const EventSchemaVersion = 1
+
+// QueueEvent is an event as sent to the message queue.
+type QueueEvent struct {
+	SchemaVersion int `json:"schema_version"`
+	domain.Event
+}
+
+// QueuePublisher is the EventPublisher that sends every event to a message queue as JSON,
+// keyed by the pull request so that consumers see the events of each pull request in order.
+// The key is the pull_request_id, prefixed with "<repository_name>/" when the repository is set.
+type QueuePublisher struct {
+	producer queue.Producer
+}
+
+// NewQueuePublisher creates a publisher sending events through producer.
+func NewQueuePublisher(producer queue.Producer) *QueuePublisher {
+	return &QueuePublisher{producer: producer}
+}
+
+// Publish sends the event. The event ID is the message ID, so a broker deduplicating by it
+// drops an event the outbox publishes again after a failure.
+func (p *QueuePublisher) Publish(ctx context.Context, event domain.Event) error {
+	value, err := json.Marshal(QueueEvent{SchemaVersion: EventSchemaVersion, Event: event})
+	if err != nil {
+		return fmt.Errorf("failed to encode event %s: %w", event.ID, err)
+	}
+
+	key := domain.PRKey{RepositoryName: event.RepositoryName, PullRequestID: event.PullRequestID}
+	if err := p.producer.Send(ctx, queue.Message{Key: key.String(), ID: event.ID, Value: value}); err != nil {
+		return fmt.Errorf("event %s to queue: %w", event.ID, err)
+	}
+	return nil
+}
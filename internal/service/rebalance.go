@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/history"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/outbox"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+)
+
+// RebalanceOptions controls PRService.RebalanceTeam.
+type RebalanceOptions struct {
+	// DryRun computes the moves without applying them.
+	DryRun bool
+	// MaxMoves caps the number of moves; zero means no limit.
+	MaxMoves int
+}
+
+// RebalanceMove is one review moved from an overloaded reviewer to a less loaded teammate.
+type RebalanceMove struct {
+	PR   domain.PRKey
+	From string
+	To   string
+}
+
+// RebalanceTeam evens out review assignments on the team's open PRs. Reviews move from the most
+// loaded reviewer to the least loaded teammate that may review the PR until their loads differ
+// by at most one, no such move is left or opts.MaxMoves is reached. Required reviewers stay in place.
+// All moves are applied in one transaction and recorded in the assignment history and the outbox
+// under ActionRebalance.
+func (s *PRService) RebalanceTeam(ctx context.Context, teamName string, opts RebalanceOptions) ([]RebalanceMove, error) {
+	ctx, span := startSpan(ctx, "PRService.RebalanceTeam")
+	defer span.End()
+	db := repository.WithContext(ctx, s.db)
+
+	exists, err := team.Exists(db, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check team existence: %w", err)
+	}
+	if !exists {
+		return nil, ErrTeamNotFound
+	}
+
+	prs, err := pr.GetOpenByTeam(db, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	eligible := make(map[string]map[string]bool)
+	capacity := make(map[string]int)
+	for _, p := range prs {
+		if _, ok := eligible[p.AuthorID]; ok {
+			continue
+		}
+		candidates, err := user.GetReassignCandidates(db, teamName, p.AuthorID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get reviewer candidates: %w", err)
+		}
+		eligible[p.AuthorID] = make(map[string]bool, len(candidates))
+		for _, c := range candidates {
+			if c.UserID == p.AuthorID {
+				continue
+			}
+			eligible[p.AuthorID][c.UserID] = true
+			if c.MaxOpenReviews != nil {
+				capacity[c.UserID] = *c.MaxOpenReviews - c.OpenReviews
+			}
+		}
+	}
+
+	moves := planRebalance(prs, eligible, capacity, opts.MaxMoves)
+	if opts.DryRun || len(moves) == 0 {
+		return moves, nil
+	}
+
+	movedAt := s.clock.Now().UTC()
+	err = s.retry.RunTx(ctx, s.db, func(tx repository.DBTX) error {
+		for _, m := range moves {
+			if err := applyRebalanceMove(tx, m, movedAt); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.version.Bump()
+
+	return moves, nil
+}
+
+// planRebalance computes at most maxMoves (zero: any number of) rebalance moves over prs,
+// updating their reviewers in place. eligible maps an author to the users that may review their PRs;
+// capacity holds the number of reviews a user with a review limit can still take.
+func planRebalance(prs []domain.PullRequest, eligible map[string]map[string]bool, capacity map[string]int, maxMoves int) []RebalanceMove {
+	load := make(map[string]int)
+	for _, p := range prs {
+		for _, reviewerID := range p.AssignedReviewersIDs {
+			load[reviewerID]++
+		}
+	}
+	targets := make(map[string]bool)
+	for _, users := range eligible {
+		for userID := range users {
+			targets[userID] = true
+			if _, ok := load[userID]; !ok {
+				load[userID] = 0
+			}
+		}
+	}
+
+	var moves []RebalanceMove
+	for maxMoves == 0 || len(moves) < maxMoves {
+		users := make([]string, 0, len(load))
+		for userID := range load {
+			users = append(users, userID)
+		}
+		// Most loaded first; ties are broken by ID to keep the plan deterministic.
+		slices.SortFunc(users, func(a, b string) int {
+			if load[a] != load[b] {
+				return load[b] - load[a]
+			}
+			if a < b {
+				return -1
+			}
+			return 1
+		})
+
+		move, i, ok := nextRebalanceMove(prs, users, load, targets, eligible, capacity)
+		if !ok {
+			return moves
+		}
+
+		reviewers := prs[i].AssignedReviewersIDs
+		reviewers[slices.Index(reviewers, move.From)] = move.To
+		load[move.From]--
+		load[move.To]++
+		if left, limited := capacity[move.To]; limited {
+			capacity[move.To] = left - 1
+		}
+		moves = append(moves, move)
+	}
+	return moves
+}
+
+// nextRebalanceMove finds a review to move between the most loaded reviewer and the least loaded
+// target that differ by at least two. users must be sorted by load, most loaded first.
+// Returns the move and the index of its PR in prs.
+func nextRebalanceMove(
+	prs []domain.PullRequest, users []string, load map[string]int,
+	targets map[string]bool, eligible map[string]map[string]bool, capacity map[string]int,
+) (RebalanceMove, int, bool) {
+	for _, from := range users {
+		for j := len(users) - 1; j >= 0; j-- {
+			to := users[j]
+			if load[from]-load[to] < 2 {
+				break
+			}
+			if !targets[to] {
+				continue
+			}
+			if left, limited := capacity[to]; limited && left <= 0 {
+				continue
+			}
+			for i, p := range prs {
+				if eligible[p.AuthorID][to] &&
+					slices.Contains(p.AssignedReviewersIDs, from) &&
+					!slices.Contains(p.RequiredReviewersIDs, from) &&
+					!slices.Contains(p.AssignedReviewersIDs, to) {
+					return RebalanceMove{PR: p.Key(), From: from, To: to}, i, true
+				}
+			}
+		}
+	}
+	return RebalanceMove{}, 0, false
+}
+
+// applyRebalanceMove swaps the reviewers of one move, assigning the new one at movedAt, and records it.
+func applyRebalanceMove(tx repository.DBTX, m RebalanceMove, movedAt time.Time) error {
+	status, err := pr.GetStatus(tx, m.PR)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrPRNotFound
+		}
+		return fmt.Errorf("failed to check PR status: %w", err)
+	}
+
+	switch status {
+	case domain.StatusClosed:
+		return ErrPRClosed
+	case domain.StatusMerged:
+		return ErrPRMerged
+	}
+
+	if err := pr.ReplaceReviewer(tx, m.PR, m.From, m.To, domain.SourceRebalance, movedAt); err != nil {
+		if errors.Is(err, pr.ErrReviewerNotAssigned) {
+			return ErrReviewerNotAssigned
+		}
+		return fmt.Errorf("failed to replace reviewer: %w", err)
+	}
+
+	if err := history.Record(tx, &domain.AssignmentEvent{
+		RepositoryName: m.PR.RepositoryName,
+		PullRequestID:  m.PR.PullRequestID,
+		Action:         domain.ActionRebalance,
+		OldUserID:      m.From,
+		NewUserID:      m.To,
+	}); err != nil {
+		return err
+	}
+
+	return outbox.Insert(tx, domain.Event{
+		Type:               domain.EventReviewerReassigned,
+		RepositoryName:     m.PR.RepositoryName,
+		PullRequestID:      m.PR.PullRequestID,
+		ReviewerID:         m.To,
+		ReplacedReviewerID: m.From,
+		Reason:             domain.ActionRebalance,
+	})
+}
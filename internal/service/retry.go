@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+)
+
+// RetryPolicy controls how transactions that fail with a transient PostgreSQL error
+// (serialization failure, deadlock) are retried.
+type RetryPolicy struct {
+	// Attempts is the total number of tries; values below 1 mean a single try.
+	Attempts int
+	// BaseDelay is the pause before the first retry; it doubles with every further retry.
+	BaseDelay time.Duration
+}
+
+// DefaultRetryPolicy is used by PRService unless WithRetryPolicy sets another one.
+var DefaultRetryPolicy = RetryPolicy{Attempts: 3, BaseDelay: 20 * time.Millisecond}
+
+// RunTx runs fn in a transaction with repository.WithTx and starts a fresh transaction
+// after every retryable failure. Once attempts are used up, the last error is returned unchanged.
+// The pause between attempts ends early when ctx is done; the last error is then returned
+// joined with ctx.Err(), so both remain visible to errors.Is.
+func (p RetryPolicy) RunTx(ctx context.Context, db *sql.DB, fn func(tx repository.DBTX) error) error {
+	attempts := max(p.Attempts, 1)
+
+	var err error
+	for attempt := range attempts {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return errors.Join(err, ctx.Err())
+			case <-time.After(p.backoff(attempt)):
+			}
+		}
+		err = repository.WithTx(ctx, db, fn)
+		if !repository.IsRetryable(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// backoff returns the pause before the given retry (1-based): BaseDelay doubled per earlier retry,
+// scaled by a random factor in [0.5, 1.5) so that colliding transactions do not retry in lockstep.
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	delay := p.BaseDelay << (retry - 1)
+	jitter, err := secureRandFloat()
+	if err != nil {
+		jitter = 0.5
+	}
+	return time.Duration(float64(delay) * (0.5 + jitter))
+}
@@ -1,74 +1,427 @@
 package service
 
 import (
+	"context"
 	"crypto/rand"
 	"fmt"
+	"log/slog"
 	"math/big"
+	"slices"
+	"sort"
 
 	"github.com/mishasvintus/avito_backend_internship/internal/domain"
 )
 
+// Strategy identifies how reviewers are picked among eligible candidates.
+type Strategy string
+
+// Strategy constants.
+const (
+	// StrategyRandom picks candidates uniformly at random.
+	StrategyRandom Strategy = "random"
+	// StrategyWeighted picks candidates with probability proportional to their assignment weight.
+	StrategyWeighted Strategy = "weighted"
+	// StrategyLeastLoaded picks the candidates with the lowest open review load, where each open
+	// review counts with the weight of its PR's size.
+	StrategyLeastLoaded Strategy = "least_loaded"
+	// StrategyRoundRobin picks the candidates who were assigned a review longest ago.
+	StrategyRoundRobin Strategy = "round_robin"
+)
+
+// ParseStrategy validates a strategy name.
+func ParseStrategy(s string) (Strategy, error) {
+	switch strategy := Strategy(s); strategy {
+	case StrategyRandom, StrategyWeighted, StrategyLeastLoaded, StrategyRoundRobin:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("unknown assignment strategy: %s", s)
+	}
+}
+
 // ReviewerAssigner handles reviewer selection logic.
-type ReviewerAssigner struct{}
+type ReviewerAssigner struct {
+	capacityFallback bool
+	strategy         Strategy
+	tags             []string
+	// decisionLogger receives a record per applied selection; nil disables decision logging.
+	decisionLogger *slog.Logger
+}
 
 // NewReviewerAssigner creates a new reviewer assigner.
+// Uses the random strategy with capacity fallback enabled by default.
 func NewReviewerAssigner() *ReviewerAssigner {
-	return &ReviewerAssigner{}
+	return &ReviewerAssigner{capacityFallback: true, strategy: StrategyRandom}
+}
+
+// WithStrategy configures how reviewers are picked among eligible candidates.
+func (a *ReviewerAssigner) WithStrategy(strategy Strategy) *ReviewerAssigner {
+	a.strategy = strategy
+	return a
+}
+
+// Strategy returns the configured strategy.
+func (a *ReviewerAssigner) Strategy() Strategy {
+	return a.strategy
+}
+
+// ForStrategy returns a copy of the assigner that picks reviewers with the given strategy.
+// The receiver is left unchanged.
+func (a *ReviewerAssigner) ForStrategy(strategy Strategy) *ReviewerAssigner {
+	c := *a
+	c.strategy = strategy
+	return &c
+}
+
+// ForTags returns a copy of the assigner that prefers candidates sharing at least one of the tags
+// and picks among the others only for the slots they cannot fill. No tags means no preference.
+// The receiver is left unchanged.
+func (a *ReviewerAssigner) ForTags(tags []string) *ReviewerAssigner {
+	c := *a
+	c.tags = tags
+	return &c
+}
+
+// WithCapacityFallback configures whether SelectReviewers falls back to the least-loaded
+// teammates when every candidate is at capacity.
+func (a *ReviewerAssigner) WithCapacityFallback(enabled bool) *ReviewerAssigner {
+	a.capacityFallback = enabled
+	return a
+}
+
+// WithDecisionLogger makes every selection applied to a pull request logged to logger with its
+// candidates, exclusions and outcome (see LogDecision). A nil logger, the default, disables it.
+func (a *ReviewerAssigner) WithDecisionLogger(logger *slog.Logger) *ReviewerAssigner {
+	a.decisionLogger = logger
+	return a
 }
 
 // SelectReviewers selects up to 2 reviewers from active teammates.
+// Teammates at their review capacity are skipped; if all of them are at capacity
+// and fallback is enabled, the least-loaded teammates are chosen instead.
 // Uses cryptographically secure random selection.
 func (a *ReviewerAssigner) SelectReviewers(teammates []domain.User) ([]string, error) {
-	if len(teammates) == 0 {
+	return a.SelectReviewersN(teammates, 2)
+}
+
+// SelectReviewersN is SelectReviewers for up to n reviewers.
+func (a *ReviewerAssigner) SelectReviewersN(teammates []domain.User, n int) ([]string, error) {
+	decision, err := a.Decide(teammates, n)
+	if err != nil {
+		return nil, err
+	}
+	return decision.Selected, nil
+}
+
+// Decide is SelectReviewersN returning the whole decision.
+func (a *ReviewerAssigner) Decide(teammates []domain.User, n int) (*domain.AssignmentDecision, error) {
+	decision := a.newDecision()
+	available := make([]domain.User, 0, len(teammates))
+	for _, u := range teammates {
+		if u.AtCapacity() {
+			exclude(decision, u.UserID, domain.ExcludedAtCapacity)
+		} else {
+			available = append(available, u)
+		}
+	}
+
+	if len(available) == 0 && len(teammates) > 0 && a.capacityFallback {
+		decision.Candidates = decisionCandidates(teammates)
+		decision.Excluded = nil
+		decision.CapacityFallback = true
+		decision.Selected = leastLoaded(teammates, n)
+		return decision, nil
+	}
+
+	selected, err := a.pickPreferred(available, n)
+	if err != nil {
+		return nil, err
+	}
+	decision.Candidates = decisionCandidates(available)
+	decision.Selected = selected
+	return decision, nil
+}
+
+// SelectReassignReviewers selects up to 2 new reviewers, excluding author, currently assigned reviewers
+// and users at capacity.
+func (a *ReviewerAssigner) SelectReassignReviewers(teammates []domain.User, authorID string, assignedReviewers []string) ([]string, error) {
+	return a.SelectReassignReviewersN(teammates, authorID, assignedReviewers, 2)
+}
+
+// SelectReassignReviewersN is SelectReassignReviewers for up to n reviewers.
+func (a *ReviewerAssigner) SelectReassignReviewersN(teammates []domain.User, authorID string, assignedReviewers []string, n int) ([]string, error) {
+	decision, err := a.DecideReassign(teammates, authorID, assignedReviewers, n)
+	if err != nil {
+		return nil, err
+	}
+	return decision.Selected, nil
+}
+
+// DecideReassign is SelectReassignReviewersN returning the whole decision.
+func (a *ReviewerAssigner) DecideReassign(teammates []domain.User, authorID string, assignedReviewers []string, n int) (*domain.AssignmentDecision, error) {
+	decision := a.newDecision()
+	candidates := make([]domain.User, 0)
+	for _, user := range teammates {
+		switch {
+		case user.UserID == authorID:
+			exclude(decision, user.UserID, domain.ExcludedAuthor)
+		case slices.Contains(assignedReviewers, user.UserID):
+			exclude(decision, user.UserID, domain.ExcludedAssigned)
+		case user.AtCapacity():
+			exclude(decision, user.UserID, domain.ExcludedAtCapacity)
+		default:
+			candidates = append(candidates, user)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no candidates available for reassignment")
+	}
+
+	selected, err := a.pickPreferred(candidates, n)
+	if err != nil {
+		return nil, err
+	}
+	decision.Candidates = decisionCandidates(candidates)
+	decision.Selected = selected
+	return decision, nil
+}
+
+// newDecision starts the decision of a selection made by the assigner.
+func (a *ReviewerAssigner) newDecision() *domain.AssignmentDecision {
+	return &domain.AssignmentDecision{Strategy: string(a.strategy), Tags: a.tags}
+}
+
+// exclude records in decision that the user was left out of the selection for reason.
+func exclude(decision *domain.AssignmentDecision, userID string, reason domain.ExclusionReason) {
+	decision.Excluded = append(decision.Excluded, domain.DecisionExclusion{UserID: userID, Reason: reason})
+}
+
+// decisionCandidates describes users as candidates of a decision.
+func decisionCandidates(users []domain.User) []domain.DecisionCandidate {
+	candidates := make([]domain.DecisionCandidate, len(users))
+	for i, u := range users {
+		candidates[i] = domain.DecisionCandidate{UserID: u.UserID, Load: u.OpenReviewLoad, Weight: effectiveWeight(u)}
+	}
+	return candidates
+}
+
+// excludeFirst prepends exclusions made by the caller before handing the remaining users
+// to the assigner to the decision.
+func excludeFirst(decision *domain.AssignmentDecision, userIDs []string, reason domain.ExclusionReason) {
+	excluded := make([]domain.DecisionExclusion, 0, len(userIDs)+len(decision.Excluded))
+	for _, id := range userIDs {
+		excluded = append(excluded, domain.DecisionExclusion{UserID: id, Reason: reason})
+	}
+	decision.Excluded = append(excluded, decision.Excluded...)
+}
+
+// LogDecision logs how reviewers of the pull request in the organization were picked for action,
+// if a decision logger is set. Callers log decisions they applied, not those of dry runs.
+func (a *ReviewerAssigner) LogDecision(orgID string, key domain.PRKey, action string, decision *domain.AssignmentDecision) {
+	if a.decisionLogger == nil || decision == nil {
+		return
+	}
+	a.decisionLogger.LogAttrs(context.Background(), slog.LevelInfo, "reviewer assignment decision",
+		slog.String("org_id", orgID),
+		slog.String("repository_name", key.RepositoryName),
+		slog.String("pull_request_id", key.PullRequestID),
+		slog.String("action", action),
+		slog.String("strategy", decision.Strategy),
+		slog.Any("tags", decision.Tags),
+		slog.Any("candidates", decision.Candidates),
+		slog.Any("excluded", decision.Excluded),
+		slog.Bool("capacity_fallback", decision.CapacityFallback),
+		slog.Any("selected", decision.Selected),
+	)
+}
+
+// pickPreferred picks up to n candidates, taking them from those sharing a tag with the assigner's
+// tags first and from the rest only for the remaining slots.
+func (a *ReviewerAssigner) pickPreferred(candidates []domain.User, n int) ([]string, error) {
+	if len(a.tags) == 0 {
+		return a.pick(candidates, n)
+	}
+
+	var matching, others []domain.User
+	for _, u := range candidates {
+		if sharesTag(u.Tags, a.tags) {
+			matching = append(matching, u)
+		} else {
+			others = append(others, u)
+		}
+	}
+
+	reviewers, err := a.pick(matching, n)
+	if err != nil {
+		return nil, err
+	}
+	if len(reviewers) < n {
+		rest, err := a.pick(others, n-len(reviewers))
+		if err != nil {
+			return nil, err
+		}
+		reviewers = append(reviewers, rest...)
+	}
+	return reviewers, nil
+}
+
+// sharesTag reports whether the two tag lists have a tag in common.
+func sharesTag(a, b []string) bool {
+	for _, tag := range a {
+		if slices.Contains(b, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// pick selects up to n distinct candidates according to the configured strategy.
+func (a *ReviewerAssigner) pick(candidates []domain.User, n int) ([]string, error) {
+	switch a.strategy {
+	case StrategyWeighted:
+		return selectWeighted(candidates, n)
+	case StrategyLeastLoaded:
+		return leastLoaded(candidates, n), nil
+	case StrategyRoundRobin:
+		return leastRecentlyAssigned(candidates, n), nil
+	default:
+		return selectRandom(candidates, n)
+	}
+}
+
+// selectRandom picks up to n distinct users at random.
+func selectRandom(candidates []domain.User, n int) ([]string, error) {
+	if len(candidates) == 0 {
 		return []string{}, nil
 	}
 
-	if len(teammates) <= 2 {
-		reviewers := make([]string, len(teammates))
-		for i, user := range teammates {
+	if len(candidates) <= n {
+		reviewers := make([]string, len(candidates))
+		for i, user := range candidates {
 			reviewers[i] = user.UserID
 		}
 		return reviewers, nil
 	}
 
 	selected := make(map[int]bool)
-	reviewers := make([]string, 0, 2)
+	reviewers := make([]string, 0, n)
 
-	for len(reviewers) < 2 {
-		idx, err := secureRandInt(len(teammates))
+	for len(reviewers) < n {
+		idx, err := secureRandInt(len(candidates))
 		if err != nil {
 			return nil, fmt.Errorf("failed to generate random index: %w", err)
 		}
 
 		if !selected[idx] {
 			selected[idx] = true
-			reviewers = append(reviewers, teammates[idx].UserID)
+			reviewers = append(reviewers, candidates[idx].UserID)
 		}
 	}
 
 	return reviewers, nil
 }
 
-// SelectReassignReviewers selects up to 2 new reviewers, excluding author and currently assigned reviewers.
-func (a *ReviewerAssigner) SelectReassignReviewers(teammates []domain.User, authorID string, assignedReviewers []string) ([]string, error) {
-	excludeIDs := make(map[string]struct{})
-	excludeIDs[authorID] = struct{}{}
-	for _, id := range assignedReviewers {
-		excludeIDs[id] = struct{}{}
+// selectWeighted picks up to n distinct users, each draw proportional to the assignment weight
+// of the users not picked yet. Users without a weight count as domain.DefaultAssignmentWeight.
+func selectWeighted(candidates []domain.User, n int) ([]string, error) {
+	remaining := make([]domain.User, len(candidates))
+	copy(remaining, candidates)
+
+	n = min(n, len(remaining))
+	reviewers := make([]string, 0, n)
+	for len(reviewers) < n {
+		total := 0.0
+		for _, u := range remaining {
+			total += effectiveWeight(u)
+		}
+
+		r, err := secureRandFloat()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate random weight: %w", err)
+		}
+		target := r * total
+
+		idx := len(remaining) - 1
+		for i, u := range remaining {
+			target -= effectiveWeight(u)
+			if target < 0 {
+				idx = i
+				break
+			}
+		}
+
+		reviewers = append(reviewers, remaining[idx].UserID)
+		remaining = append(remaining[:idx], remaining[idx+1:]...)
 	}
 
-	candidates := make([]domain.User, 0)
-	for _, user := range teammates {
-		if _, excluded := excludeIDs[user.UserID]; !excluded {
-			candidates = append(candidates, user)
+	return reviewers, nil
+}
+
+// effectiveWeight returns the user's weight, defaulting unset values.
+func effectiveWeight(u domain.User) float64 {
+	if u.AssignmentWeight <= 0 {
+		return domain.DefaultAssignmentWeight
+	}
+	return u.AssignmentWeight
+}
+
+// leastLoaded returns up to n user IDs with the lowest open review load;
+// ties are broken by the number of open reviews, then by user ID.
+func leastLoaded(users []domain.User, n int) []string {
+	sorted := make([]domain.User, len(users))
+	copy(sorted, users)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].OpenReviewLoad != sorted[j].OpenReviewLoad {
+			return sorted[i].OpenReviewLoad < sorted[j].OpenReviewLoad
+		}
+		if sorted[i].OpenReviews != sorted[j].OpenReviews {
+			return sorted[i].OpenReviews < sorted[j].OpenReviews
 		}
+		return sorted[i].UserID < sorted[j].UserID
+	})
+
+	n = min(n, len(sorted))
+	ids := make([]string, n)
+	for i := range n {
+		ids[i] = sorted[i].UserID
 	}
+	return ids
+}
 
-	if len(candidates) == 0 {
-		return nil, fmt.Errorf("no candidates available for reassignment")
+// leastRecentlyAssigned returns up to n user IDs ordered by their last assignment time.
+// Users never assigned come first; ties are broken by user ID.
+func leastRecentlyAssigned(users []domain.User, n int) []string {
+	sorted := make([]domain.User, len(users))
+	copy(sorted, users)
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i].LastAssignedAt, sorted[j].LastAssignedAt
+		switch {
+		case a == nil && b != nil:
+			return true
+		case a != nil && b == nil:
+			return false
+		case a != nil && b != nil && !a.Equal(*b):
+			return a.Before(*b)
+		}
+		return sorted[i].UserID < sorted[j].UserID
+	})
+
+	n = min(n, len(sorted))
+	ids := make([]string, n)
+	for i := range n {
+		ids[i] = sorted[i].UserID
 	}
+	return ids
+}
 
-	return a.SelectReviewers(candidates)
+// secureRandFloat returns a cryptographically secure random float in [0, 1).
+func secureRandFloat() (float64, error) {
+	const precision = 1 << 53
+	nBig, err := rand.Int(rand.Reader, big.NewInt(precision))
+	if err != nil {
+		return 0, err
+	}
+	return float64(nBig.Int64()) / precision, nil
 }
 
 // secureRandInt returns a cryptographically secure random integer in [0, max).
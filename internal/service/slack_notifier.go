@@ -0,0 +1,187 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+)
+
+// SlackTargetResolver returns the pull request of an event and the Slack incoming webhook of its team,
+// which is "" when the team has not enabled Slack notifications.
+type SlackTargetResolver interface {
+	SlackTarget(ctx context.Context, key domain.PRKey) (*domain.PullRequest, string, error)
+	SlackWebhookURL(ctx context.Context, teamName string) (string, error)
+}
+
+// SlackMessage is the body posted to a Slack incoming webhook.
+type SlackMessage struct {
+	Text string `json:"text"`
+}
+
+// SlackNotifier is the EventPublisher that posts reviewer assignments, including reviews
+// escalated past the SLA, to the Slack incoming webhook of the pull request's team, and tells
+// the reviewers of a merged pull request that their reviews are no longer needed. Review digests
+// are posted to the webhook of the team they were compiled for.
+// Other events, merges without reviewers and teams without a Slack webhook are skipped.
+type SlackNotifier struct {
+	targets SlackTargetResolver
+	client  *http.Client
+}
+
+// NewSlackNotifier creates a notifier posting to the webhooks returned by targets.
+func NewSlackNotifier(targets SlackTargetResolver) *SlackNotifier {
+	return &SlackNotifier{
+		targets: targets,
+		client:  &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// WithHTTPClient sets the client used for posting; its Timeout bounds each attempt.
+func (n *SlackNotifier) WithHTTPClient(client *http.Client) *SlackNotifier {
+	n.client = client
+	return n
+}
+
+// Publish posts the notification for the event, if any.
+// A failure is logged and returned, so the outbox posts the event again later.
+func (n *SlackNotifier) Publish(ctx context.Context, event domain.Event) error {
+	switch event.Type {
+	case domain.EventReviewDigest:
+		return n.publishDigest(ctx, event)
+	case domain.EventReviewerAssigned, domain.EventReviewerReassigned:
+	case domain.EventPRMerged:
+		if len(event.ReviewerIDs) == 0 {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	key := domain.PRKey{RepositoryName: event.RepositoryName, PullRequestID: event.PullRequestID}
+	pullRequest, url, err := n.targets.SlackTarget(ctx, key)
+	if err != nil {
+		if errors.Is(err, ErrPRNotFound) {
+			return nil
+		}
+		return fmt.Errorf("event %s: %w", event.ID, err)
+	}
+	if url == "" {
+		return nil
+	}
+
+	if err := n.post(ctx, url, SlackMessage{Text: slackText(event, pullRequest)}); err != nil {
+		log.Printf("Slack notification for event %s to team %s failed: %v", event.ID, pullRequest.TeamName, err)
+		return fmt.Errorf("event %s to slack of team %s: %w", event.ID, pullRequest.TeamName, err)
+	}
+	return nil
+}
+
+// publishDigest posts the digest to its team's webhook.
+func (n *SlackNotifier) publishDigest(ctx context.Context, event domain.Event) error {
+	if event.Digest == nil {
+		return nil
+	}
+	url, err := n.targets.SlackWebhookURL(ctx, event.Digest.TeamName)
+	if err != nil {
+		return fmt.Errorf("event %s: %w", event.ID, err)
+	}
+	if url == "" {
+		return nil
+	}
+
+	if err := n.post(ctx, url, SlackMessage{Text: slackDigestText(event.Digest)}); err != nil {
+		log.Printf("Slack digest %s to team %s failed: %v", event.ID, event.Digest.TeamName, err)
+		return fmt.Errorf("event %s to slack of team %s: %w", event.ID, event.Digest.TeamName, err)
+	}
+	return nil
+}
+
+// post makes a single delivery attempt.
+func (n *SlackNotifier) post(ctx context.Context, url string, message SlackMessage) error {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("slack responded %s", resp.Status)
+	}
+	return nil
+}
+
+// slackText describes the event in Slack mrkdwn.
+func slackText(event domain.Event, pullRequest *domain.PullRequest) string {
+	link := slackLink(pullRequest)
+	author := "*" + slackEscape(pullRequest.AuthorID) + "*"
+	reviewer := "*" + slackEscape(event.ReviewerID) + "*"
+	replaced := "*" + slackEscape(event.ReplacedReviewerID) + "*"
+
+	switch {
+	case event.Type == domain.EventPRMerged:
+		// The reviewers come from the event: by now the pull request may list other ones.
+		reviewers := make([]string, len(event.ReviewerIDs))
+		for i, id := range event.ReviewerIDs {
+			reviewers[i] = "*" + slackEscape(id) + "*"
+		}
+		return fmt.Sprintf("%s by %s is merged; %s, your review is no longer needed", link, author, strings.Join(reviewers, ", "))
+	case event.Type == domain.EventReviewerAssigned:
+		return fmt.Sprintf("%s is asked to review %s by %s", reviewer, link, author)
+	case event.Reason == domain.ActionEscalate:
+		return fmt.Sprintf("%s by %s waited for %s's review past the SLA; %s is asked to review it now", link, author, replaced, reviewer)
+	default:
+		return fmt.Sprintf("%s replaces %s as reviewer of %s by %s", reviewer, replaced, link, author)
+	}
+}
+
+// slackDigestText lists the digest's pending reviews by reviewer, one line per pull request.
+func slackDigestText(d *domain.ReviewDigest) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Pending reviews of team *%s*", slackEscape(d.TeamName))
+	for _, reviewer := range d.Reviewers {
+		fmt.Fprintf(&b, "\n*%s*:", slackEscape(reviewer.Username))
+		for _, r := range reviewer.Reviews {
+			link := slackLink(&domain.PullRequest{PullRequestName: r.PullRequestName, ExternalURL: r.ExternalURL})
+			fmt.Fprintf(&b, "\n• %s by *%s*, waiting %dh", link, slackEscape(r.AuthorID), r.HoursOpen)
+			if r.Overdue {
+				b.WriteString(", past the SLA")
+			}
+		}
+	}
+	return b.String()
+}
+
+// slackLink names the pull request, linking it to its external URL when it has one.
+func slackLink(pullRequest *domain.PullRequest) string {
+	name := slackEscape(pullRequest.PullRequestName)
+	if pullRequest.ExternalURL == nil || *pullRequest.ExternalURL == "" {
+		return "*" + name + "*"
+	}
+	return "<" + slackEscape(*pullRequest.ExternalURL) + "|" + name + ">"
+}
+
+// slackEscaper escapes the characters Slack treats as control sequences in message text.
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func slackEscape(s string) string {
+	return slackEscaper.Replace(s)
+}
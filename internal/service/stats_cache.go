@@ -0,0 +1,124 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/stats"
+)
+
+// maxStatsCacheEntries bounds the number of distinct organization and period pairs kept in the cache.
+const maxStatsCacheEntries = 128
+
+// StatsCache memoizes Statistics per organization and period until the TTL expires or the data version changes.
+// Concurrent misses for the same organization and period share a single computation.
+type StatsCache struct {
+	ttl     time.Duration
+	clock   Clock
+	version *DataVersion
+
+	mu      sync.Mutex
+	entries map[string]*statsCacheEntry
+}
+
+// statsCacheEntry is a cached or in-flight computation.
+// Fields other than ready are written once before ready is closed.
+type statsCacheEntry struct {
+	ready     chan struct{}
+	stats     *Statistics
+	err       error
+	version   uint64
+	expiresAt time.Time
+}
+
+// NewStatsCache creates a cache whose entries live for ttl and are dropped when version changes.
+func NewStatsCache(ttl time.Duration, clock Clock, version *DataVersion) *StatsCache {
+	return &StatsCache{
+		ttl:     ttl,
+		clock:   clock,
+		version: version,
+		entries: make(map[string]*statsCacheEntry),
+	}
+}
+
+// TTL returns how long entries stay fresh.
+func (c *StatsCache) TTL() time.Duration {
+	return c.ttl
+}
+
+// Get returns cached statistics of the organization for the period, calling compute on a miss.
+// Errors are returned to every waiter but never cached.
+func (c *StatsCache) Get(orgID string, period stats.Period, compute func() (*Statistics, error)) (*Statistics, error) {
+	key := orgID + "@" + periodKey(period)
+
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok {
+		select {
+		case <-e.ready:
+			if c.fresh(e) {
+				c.mu.Unlock()
+				return e.stats, nil
+			}
+		default:
+			c.mu.Unlock()
+			<-e.ready
+			return e.stats, e.err
+		}
+	}
+
+	e := &statsCacheEntry{ready: make(chan struct{}), version: c.version.Current()}
+	cached := c.store(key, e)
+	c.mu.Unlock()
+
+	e.stats, e.err = compute()
+	e.expiresAt = c.clock.Now().Add(c.ttl)
+	close(e.ready)
+
+	if e.err != nil && cached {
+		c.mu.Lock()
+		if c.entries[key] == e {
+			delete(c.entries, key)
+		}
+		c.mu.Unlock()
+	}
+
+	return e.stats, e.err
+}
+
+// fresh reports whether a completed entry can be served. Requires c.mu.
+func (c *StatsCache) fresh(e *statsCacheEntry) bool {
+	return e.err == nil && e.version == c.version.Current() && c.clock.Now().Before(e.expiresAt)
+}
+
+// store adds e under key, evicting stale entries when the cache is full.
+// Returns false if there is no room, in which case e is computed without being cached. Requires c.mu.
+func (c *StatsCache) store(key string, e *statsCacheEntry) bool {
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= maxStatsCacheEntries {
+		for k, old := range c.entries {
+			select {
+			case <-old.ready:
+				if !c.fresh(old) {
+					delete(c.entries, k)
+				}
+			default:
+			}
+		}
+		if len(c.entries) >= maxStatsCacheEntries {
+			return false
+		}
+	}
+	c.entries[key] = e
+	return true
+}
+
+// periodKey identifies a period in the cache.
+func periodKey(p stats.Period) string {
+	var from, to string
+	if p.From != nil {
+		from = p.From.UTC().Format(time.RFC3339Nano)
+	}
+	if p.To != nil {
+		to = p.To.UTC().Format(time.RFC3339Nano)
+	}
+	return from + "/" + to
+}
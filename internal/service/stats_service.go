@@ -1,20 +1,98 @@
 package service
 
 import (
+	"cmp"
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"math"
+	"slices"
+	"time"
 
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/absence"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/organization"
 	"github.com/mishasvintus/avito_backend_internship/internal/repository/stats"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
 )
 
+// DefaultStatsQueryTimeout is the GetStatistics query timeout unless configured otherwise.
+const DefaultStatsQueryTimeout = 5 * time.Second
+
+// maxThroughputBuckets caps the number of points in a single time series.
+const maxThroughputBuckets = 366
+
+// defaultThroughputSpan is the window used when from is omitted, per bucket size.
+var defaultThroughputSpan = map[stats.BucketSize]time.Duration{
+	stats.BucketDay:  30 * 24 * time.Hour,
+	stats.BucketWeek: 12 * 7 * 24 * time.Hour,
+}
+
+// bucketLength is the nominal length of one bucket, used to bound the series size.
+var bucketLength = map[stats.BucketSize]time.Duration{
+	stats.BucketDay:  24 * time.Hour,
+	stats.BucketWeek: 7 * 24 * time.Hour,
+}
+
+// LeaderboardPeriod is how far back the leaderboard looks.
+type LeaderboardPeriod string
+
+const (
+	LeaderboardWeek  LeaderboardPeriod = "7d"
+	LeaderboardMonth LeaderboardPeriod = "30d"
+	LeaderboardAll   LeaderboardPeriod = "all"
+)
+
+// leaderboardSpans maps a period to its length; zero means all-time.
+var leaderboardSpans = map[LeaderboardPeriod]time.Duration{
+	LeaderboardWeek:  7 * 24 * time.Hour,
+	LeaderboardMonth: 30 * 24 * time.Hour,
+	LeaderboardAll:   0,
+}
+
+// MaxLeaderboardLimit caps the size of each leaderboard list.
+const MaxLeaderboardLimit = 100
+
 // StatsService handles statistics business logic.
 type StatsService struct {
-	db *sql.DB
+	db           *sql.DB
+	clock        Clock
+	cache        *StatsCache
+	queryTimeout time.Duration
+	dbRouter     *repository.DBRouter
 }
 
 // NewStatsService creates a new stats service.
-func NewStatsService(db *sql.DB) *StatsService {
-	return &StatsService{db: db}
+// clock supplies the default end of time-series windows.
+func NewStatsService(db *sql.DB, clock Clock) *StatsService {
+	return &StatsService{db: db, clock: clock, queryTimeout: DefaultStatsQueryTimeout}
+}
+
+// WithQueryTimeout bounds how long GetStatistics may hold a connection.
+func (s *StatsService) WithQueryTimeout(timeout time.Duration) *StatsService {
+	s.queryTimeout = timeout
+	return s
+}
+
+// WithCache makes GetStatistics serve results from cache. A nil cache disables caching.
+func (s *StatsService) WithCache(cache *StatsCache) *StatsService {
+	s.cache = cache
+	return s
+}
+
+// WithDBRouter sends the queries of this service, all of them read-only, through router,
+// so they can be served by a replica.
+func (s *StatsService) WithDBRouter(router *repository.DBRouter) *StatsService {
+	s.dbRouter = router
+	return s
+}
+
+// reader returns the database the statistics are read from.
+func (s *StatsService) reader(ctx context.Context) *sql.DB {
+	return readDB(ctx, s.db, s.dbRouter)
 }
 
 // Statistics represents all statistics.
@@ -22,28 +100,411 @@ type Statistics struct {
 	Overall       *stats.OverallStats
 	ReviewerStats []stats.ReviewerStat
 	AuthorStats   []stats.AuthorStat
+	MergerStats   []stats.MergerStat
+	Distribution  Distribution
+}
+
+// LoadDistribution summarizes open review assignments per active user.
+// StdDev is the population standard deviation; all values are zero when there are no users.
+type LoadDistribution struct {
+	ActiveUsers int
+	Min         int64
+	Max         int64
+	Mean        float64
+	Median      float64
+	StdDev      float64
+}
+
+// TeamLoadDistribution is the load distribution within one team.
+// OverdueAssignments counts pending reviews of the team's open PRs past the team's review SLA.
+type TeamLoadDistribution struct {
+	TeamName string
+	LoadDistribution
+	OverdueAssignments int64
+}
+
+// Distribution holds load distribution overall and per team.
+// It reflects the current open assignments and ignores the statistics period.
+type Distribution struct {
+	Overall LoadDistribution
+	Teams   []TeamLoadDistribution
+}
+
+// GetStatistics returns all statistics for the period; a zero Period means all-time.
+func (s *StatsService) GetStatistics(ctx context.Context, period stats.Period) (*Statistics, error) {
+	ctx, span := startSpan(ctx, "StatsService.GetStatistics")
+	defer span.End()
+
+	if s.cache == nil {
+		return s.computeStatistics(ctx, period)
+	}
+	return s.cache.Get(repository.OrgFromContext(ctx), period, func() (*Statistics, error) {
+		return s.computeStatistics(ctx, period)
+	})
+}
+
+// computeStatistics queries all statistics for the period in one round trip,
+// giving up after the query timeout. The result may be shared through the cache,
+// so the caller cancelling ctx does not stop the query.
+func (s *StatsService) computeStatistics(ctx context.Context, period stats.Period) (*Statistics, error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.queryTimeout)
+	defer cancel()
+
+	summary, err := stats.GetSummary(ctx, repository.WithContext(ctx, s.reader(ctx)), period, s.clock.Now())
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, ErrStatsTimeout
+		}
+		return nil, fmt.Errorf("failed to get statistics: %w", err)
+	}
+
+	return &Statistics{
+		Overall:       &summary.Overall,
+		ReviewerStats: summary.Reviewers,
+		AuthorStats:   summary.Authors,
+		MergerStats:   summary.Mergers,
+		Distribution:  distributionFrom(summary.MemberLoads, summary.TeamOverdue),
+	}, nil
+}
+
+// distributionFrom computes open assignment distribution overall and per team.
+// A user belonging to several teams is counted in each team but once overall.
+// Overdue reviews are attributed to the team of the PR.
+func distributionFrom(memberLoads []stats.MemberLoad, teamOverdue []stats.TeamOverdue) Distribution {
+	var teamNames []string
+	byTeam := make(map[string][]int64)
+	byUser := make(map[string]int64)
+	for _, l := range memberLoads {
+		if _, ok := byTeam[l.TeamName]; !ok {
+			teamNames = append(teamNames, l.TeamName)
+		}
+		byTeam[l.TeamName] = append(byTeam[l.TeamName], l.OpenAssignments)
+		byUser[l.UserID] = l.OpenAssignments
+	}
+
+	overall := make([]int64, 0, len(byUser))
+	for _, n := range byUser {
+		overall = append(overall, n)
+	}
+
+	distribution := Distribution{
+		Overall: distributionOf(overall),
+		Teams:   make([]TeamLoadDistribution, 0, len(teamNames)),
+	}
+	overdue := make(map[string]int64, len(teamOverdue))
+	for _, o := range teamOverdue {
+		overdue[o.TeamName] = o.OverdueAssignments
+	}
+	for _, name := range teamNames {
+		distribution.Teams = append(distribution.Teams, TeamLoadDistribution{
+			TeamName:           name,
+			LoadDistribution:   distributionOf(byTeam[name]),
+			OverdueAssignments: overdue[name],
+		})
+	}
+
+	return distribution
+}
+
+// distributionOf computes summary statistics of loads.
+func distributionOf(loads []int64) LoadDistribution {
+	if len(loads) == 0 {
+		return LoadDistribution{}
+	}
+
+	sorted := slices.Clone(loads)
+	slices.Sort(sorted)
+
+	var sum int64
+	for _, n := range sorted {
+		sum += n
+	}
+	mean := float64(sum) / float64(len(sorted))
+
+	var squares float64
+	for _, n := range sorted {
+		d := float64(n) - mean
+		squares += d * d
+	}
+
+	mid := len(sorted) / 2
+	median := float64(sorted[mid])
+	if len(sorted)%2 == 0 {
+		median = float64(sorted[mid-1]+sorted[mid]) / 2
+	}
+
+	return LoadDistribution{
+		ActiveUsers: len(sorted),
+		Min:         sorted[0],
+		Max:         sorted[len(sorted)-1],
+		Mean:        mean,
+		Median:      median,
+		StdDev:      math.Sqrt(squares / float64(len(sorted))),
+	}
+}
+
+// GetUserLoad returns per-user review load for export.
+func (s *StatsService) GetUserLoad(ctx context.Context) ([]stats.UserLoad, error) {
+	ctx, span := startSpan(ctx, "StatsService.GetUserLoad")
+	defer span.End()
+	db := repository.WithContext(ctx, s.reader(ctx))
+
+	loads, err := stats.GetUserLoad(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user load: %w", err)
+	}
+	return loads, nil
+}
+
+// Throughput is a PR created/merged time series over a resolved window.
+type Throughput struct {
+	Bucket   stats.BucketSize
+	From     time.Time
+	To       time.Time
+	TeamName string
+	Points   []stats.ThroughputPoint
 }
 
-// GetStatistics returns all statistics.
-func (s *StatsService) GetStatistics() (*Statistics, error) {
-	overall, err := stats.GetOverallStats(s.db)
+// GetThroughput returns PRs created and merged per bucket.
+// A missing to defaults to now and a missing from to a bucket-dependent span before to.
+// An empty teamName covers all teams.
+func (s *StatsService) GetThroughput(ctx context.Context, bucket stats.BucketSize, period stats.Period, teamName string) (*Throughput, error) {
+	ctx, span := startSpan(ctx, "StatsService.GetThroughput")
+	defer span.End()
+	db := repository.WithContext(ctx, s.reader(ctx))
+
+	window, ok := defaultThroughputSpan[bucket]
+	if !ok {
+		return nil, ErrInvalidBucket
+	}
+
+	to := s.clock.Now()
+	if period.To != nil {
+		to = *period.To
+	}
+	from := to.Add(-window)
+	if period.From != nil {
+		from = *period.From
+	}
+	if from.After(to) {
+		return nil, ErrInvalidPeriod
+	}
+	if to.Sub(from)/bucketLength[bucket] >= maxThroughputBuckets {
+		return nil, ErrTooManyBuckets
+	}
+
+	if teamName != "" {
+		exists, err := team.Exists(db, teamName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check team existence: %w", err)
+		}
+		if !exists {
+			return nil, ErrTeamNotFound
+		}
+	}
+
+	points, err := stats.GetThroughput(db, bucket, from, to, teamName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get overall stats: %w", err)
+		return nil, fmt.Errorf("failed to get throughput: %w", err)
+	}
+
+	return &Throughput{
+		Bucket:   bucket,
+		From:     from,
+		To:       to,
+		TeamName: teamName,
+		Points:   points,
+	}, nil
+}
+
+// Leaderboard ranks users by completed reviews and by merged PRs.
+type Leaderboard struct {
+	Period    LeaderboardPeriod
+	Since     *time.Time
+	Reviewers []stats.RankedUser
+	Authors   []stats.RankedUser
+}
+
+// GetLeaderboard returns up to limit top reviewers and authors for the period.
+// A review counts as completed once its PR is merged within the period.
+func (s *StatsService) GetLeaderboard(ctx context.Context, period LeaderboardPeriod, limit int) (*Leaderboard, error) {
+	ctx, span := startSpan(ctx, "StatsService.GetLeaderboard")
+	defer span.End()
+	db := repository.WithContext(ctx, s.reader(ctx))
+
+	window, ok := leaderboardSpans[period]
+	if !ok {
+		return nil, ErrInvalidLeaderboardPeriod
+	}
+	if limit < 1 || limit > MaxLeaderboardLimit {
+		return nil, ErrInvalidLimit
 	}
 
-	reviewerStats, err := stats.GetReviewerStats(s.db)
+	var since *time.Time
+	if window > 0 {
+		t := s.clock.Now().Add(-window)
+		since = &t
+	}
+
+	reviewers, err := stats.GetTopReviewers(db, since, limit)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get reviewer stats: %w", err)
+		return nil, fmt.Errorf("failed to get top reviewers: %w", err)
 	}
 
-	authorStats, err := stats.GetAuthorStats(s.db)
+	authors, err := stats.GetTopAuthors(db, since, limit)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get author stats: %w", err)
+		return nil, fmt.Errorf("failed to get top authors: %w", err)
 	}
 
-	return &Statistics{
-		Overall:       overall,
-		ReviewerStats: reviewerStats,
-		AuthorStats:   authorStats,
+	return &Leaderboard{
+		Period:    period,
+		Since:     since,
+		Reviewers: reviewers,
+		Authors:   authors,
 	}, nil
 }
+
+// UserStatistics is the activity and current availability of a single user.
+// CurrentAbsence is the absence covering today, if any.
+type UserStatistics struct {
+	User           domain.User
+	Activity       stats.UserActivity
+	AtCapacity     bool
+	CurrentAbsence *domain.Absence
+}
+
+// GetUserStatistics returns review and authoring activity of the user
+// together with their capacity and absence state as of now.
+func (s *StatsService) GetUserStatistics(ctx context.Context, userID string) (*UserStatistics, error) {
+	ctx, span := startSpan(ctx, "StatsService.GetUserStatistics")
+	defer span.End()
+	db := repository.WithContext(ctx, s.reader(ctx))
+
+	u, err := user.Get(db, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	activity, err := stats.GetUserActivity(db, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user activity: %w", err)
+	}
+	u.OpenReviews = int(activity.OpenReviews)
+
+	absences, err := absence.GetByUser(db, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absences: %w", err)
+	}
+
+	result := &UserStatistics{
+		User:       *u,
+		Activity:   *activity,
+		AtCapacity: u.AtCapacity(),
+	}
+
+	now := s.clock.Now()
+	for i := range absences {
+		if absences[i].Covers(now) {
+			result.CurrentAbsence = &absences[i]
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// ShortfallCount is the number of open pull requests missing the same number of reviewers.
+type ShortfallCount struct {
+	Missing int
+	PRs     int64
+}
+
+// CoverageCounts counts open pull requests by whether they have as many reviewers as their team's target.
+// Shortfall breaks the under-covered ones down by missing reviewers, ascending.
+type CoverageCounts struct {
+	OpenPRs         int64
+	CoveredPRs      int64
+	UnderCoveredPRs int64
+	Shortfall       []ShortfallCount
+}
+
+// add counts prs pull requests missing shortfall reviewers.
+func (c *CoverageCounts) add(shortfall int, prs int64) {
+	c.OpenPRs += prs
+	if shortfall == 0 {
+		c.CoveredPRs += prs
+		return
+	}
+	c.UnderCoveredPRs += prs
+
+	i, found := slices.BinarySearchFunc(c.Shortfall, shortfall, func(s ShortfallCount, missing int) int {
+		return cmp.Compare(s.Missing, missing)
+	})
+	if found {
+		c.Shortfall[i].PRs += prs
+		return
+	}
+	c.Shortfall = slices.Insert(c.Shortfall, i, ShortfallCount{Missing: shortfall, PRs: prs})
+}
+
+// TeamCoverage is the coverage of one team's open pull requests against its reviewer target.
+type TeamCoverage struct {
+	TeamName       string
+	ReviewerTarget int
+	CoverageCounts
+}
+
+// Coverage is the reviewer coverage of open pull requests overall and per team.
+// Teams without open pull requests are not listed.
+type Coverage struct {
+	CoverageCounts
+	Teams []TeamCoverage
+}
+
+// GetCoverage returns how many open pull requests have fewer reviewers than their team's reviewer_count,
+// overall and per team, ordered by team name.
+func (s *StatsService) GetCoverage(ctx context.Context) (*Coverage, error) {
+	ctx, span := startSpan(ctx, "StatsService.GetCoverage")
+	defer span.End()
+	db := repository.WithContext(ctx, s.reader(ctx))
+
+	rows, err := stats.GetCoverage(db, domain.DefaultReviewerCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get coverage: %w", err)
+	}
+
+	coverage := &Coverage{Teams: make([]TeamCoverage, 0)}
+	for _, r := range rows {
+		coverage.add(r.Shortfall, r.PRs)
+		if n := len(coverage.Teams); n == 0 || coverage.Teams[n-1].TeamName != r.TeamName {
+			coverage.Teams = append(coverage.Teams, TeamCoverage{TeamName: r.TeamName, ReviewerTarget: r.ReviewerTarget})
+		}
+		coverage.Teams[len(coverage.Teams)-1].add(r.Shortfall, r.PRs)
+	}
+	return coverage, nil
+}
+
+// UnderCoveredByOrg returns the number of under-covered open pull requests of every organization,
+// including those with none.
+func (s *StatsService) UnderCoveredByOrg(ctx context.Context) (map[string]int64, error) {
+	ctx, span := startSpan(ctx, "StatsService.UnderCoveredByOrg")
+	defer span.End()
+
+	orgs, err := organization.List(repository.WithContext(ctx, s.reader(ctx)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+
+	underCovered := make(map[string]int64, len(orgs))
+	for _, o := range orgs {
+		coverage, err := s.GetCoverage(repository.WithOrg(ctx, o.OrgID))
+		if err != nil {
+			return nil, fmt.Errorf("organization %s: %w", o.OrgID, err)
+		}
+		underCovered[o.OrgID] = coverage.UnderCoveredPRs
+	}
+	return underCovered, nil
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+)
+
+// importColumns are the required CSV columns, in any order.
+var importColumns = []string{"team_name", "user_id", "username", "is_active"}
+
+// ImportRow is one valid line of a team import file.
+type ImportRow struct {
+	Line     int
+	TeamName string
+	UserID   string
+	Username string
+	IsActive bool
+}
+
+// ImportRowError describes why a line of a team import file was skipped.
+type ImportRowError struct {
+	Line    int
+	Message string
+}
+
+// ImportSummary is the outcome of ImportTeams.
+type ImportSummary struct {
+	TeamsCreated int
+	UsersCreated int
+	UsersUpdated int
+	UsersMoved   int
+	Errors       []ImportRowError
+}
+
+// ParseTeamCSV reads a team import file with a team_name, user_id, username, is_active header.
+// Invalid lines and repeated user IDs are reported as row errors and left out of the result.
+// A missing or malformed header, or unreadable CSV, fails the whole file with ErrInvalidCSV.
+func ParseTeamCSV(r io.Reader) ([]ImportRow, []ImportRowError, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, fmt.Errorf("%w: empty file", ErrInvalidCSV)
+		}
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidCSV, err)
+	}
+
+	index, err := headerIndex(header)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows := make([]ImportRow, 0)
+	rowErrors := make([]ImportRowError, 0)
+	seen := make(map[string]int)
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", ErrInvalidCSV, err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		if len(record) != len(importColumns) {
+			rowErrors = append(rowErrors, ImportRowError{Line: line, Message: fmt.Sprintf("expected %d columns, got %d", len(importColumns), len(record))})
+			continue
+		}
+
+		row := ImportRow{
+			Line:     line,
+			TeamName: strings.TrimSpace(record[index["team_name"]]),
+			UserID:   strings.TrimSpace(record[index["user_id"]]),
+			Username: strings.TrimSpace(record[index["username"]]),
+		}
+		if row.TeamName == "" || row.UserID == "" || row.Username == "" {
+			rowErrors = append(rowErrors, ImportRowError{Line: line, Message: "team_name, user_id and username are required"})
+			continue
+		}
+
+		isActive, err := strconv.ParseBool(strings.TrimSpace(record[index["is_active"]]))
+		if err != nil {
+			rowErrors = append(rowErrors, ImportRowError{Line: line, Message: "is_active must be true or false"})
+			continue
+		}
+		row.IsActive = isActive
+
+		if first, ok := seen[row.UserID]; ok {
+			rowErrors = append(rowErrors, ImportRowError{Line: line, Message: fmt.Sprintf("duplicate user_id %s (first seen on line %d)", row.UserID, first)})
+			continue
+		}
+		seen[row.UserID] = line
+
+		rows = append(rows, row)
+	}
+
+	return rows, rowErrors, nil
+}
+
+// headerIndex maps each required column to its position in the header.
+func headerIndex(header []string) (map[string]int, error) {
+	if len(header) != len(importColumns) {
+		return nil, fmt.Errorf("%w: header must be %s", ErrInvalidCSV, strings.Join(importColumns, ","))
+	}
+
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, dup := index[name]; dup {
+			return nil, fmt.Errorf("%w: duplicate column %s", ErrInvalidCSV, name)
+		}
+		index[name] = i
+	}
+
+	for _, column := range importColumns {
+		if _, ok := index[column]; !ok {
+			return nil, fmt.Errorf("%w: missing column %s", ErrInvalidCSV, column)
+		}
+	}
+
+	return index, nil
+}
+
+// ImportTeams creates or updates teams and users from a CSV file in a single transaction.
+// Missing teams are created with the default strategy. A new user gets the row's team as primary team;
+// an existing user is updated and, if the row names another team, moved there as primary team
+// (the previous team is kept as a secondary membership). Invalid lines and lines of erased users
+// are skipped and reported.
+func (s *TeamService) ImportTeams(ctx context.Context, r io.Reader) (*ImportSummary, error) {
+	ctx, span := startSpan(ctx, "TeamService.ImportTeams")
+	defer span.End()
+
+	rows, rowErrors, err := ParseTeamCSV(r)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &ImportSummary{Errors: rowErrors}
+
+	err = repository.WithTx(ctx, s.db, func(tx repository.DBTX) error {
+		strategy := string(s.prService.assigner.Strategy())
+		knownTeams := make(map[string]struct{})
+		for _, row := range rows {
+			if _, ok := knownTeams[row.TeamName]; ok {
+				continue
+			}
+			exists, err := team.Exists(tx, row.TeamName)
+			if err != nil {
+				return fmt.Errorf("failed to check team existence: %w", err)
+			}
+			if !exists {
+				if err := team.CreateWithStrategy(tx, row.TeamName, strategy); err != nil {
+					return fmt.Errorf("failed to create team: %w", err)
+				}
+				summary.TeamsCreated++
+			}
+			knownTeams[row.TeamName] = struct{}{}
+		}
+
+		for _, row := range rows {
+			existing, err := user.Get(tx, row.UserID)
+			if err != nil && !errors.Is(err, repository.ErrNotFound) {
+				return fmt.Errorf("failed to check user existence: %w", err)
+			}
+
+			if existing == nil {
+				if err := user.Create(tx, &domain.User{
+					UserID:   row.UserID,
+					Username: row.Username,
+					TeamName: row.TeamName,
+					IsActive: row.IsActive,
+				}); err != nil {
+					return fmt.Errorf("failed to create user: %w", err)
+				}
+				summary.UsersCreated++
+				continue
+			}
+
+			if existing.Erased {
+				summary.Errors = append(summary.Errors, ImportRowError{Line: row.Line, Message: fmt.Sprintf("user %s is erased", row.UserID)})
+				continue
+			}
+
+			updated := *existing
+			updated.Username = row.Username
+			updated.IsActive = row.IsActive
+			if err := user.Update(tx, &updated); err != nil {
+				return fmt.Errorf("failed to update user: %w", err)
+			}
+
+			if existing.TeamName != row.TeamName {
+				if err := user.SetPrimaryTeam(tx, row.UserID, row.TeamName); err != nil {
+					return fmt.Errorf("failed to move user: %w", err)
+				}
+				summary.UsersMoved++
+			} else {
+				summary.UsersUpdated++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.prService.version.Bump()
+
+	return summary, nil
+}
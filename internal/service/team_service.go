@@ -1,10 +1,14 @@
 package service
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
 	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
 	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
 	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
@@ -21,66 +25,338 @@ func NewTeamService(db *sql.DB, prService *PRService) *TeamService {
 	return &TeamService{db: db, prService: prService}
 }
 
-// CreateTeam creates a new team with members in a single transaction.
-func (s *TeamService) CreateTeam(teamName string, members []domain.TeamMember) error {
-	tx, err := s.db.Begin()
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+// ConflictPolicy decides what CreateTeam does with members that already belong to another team.
+type ConflictPolicy string
+
+const (
+	// ConflictMove adds such members to the new team, keeping their primary team.
+	ConflictMove ConflictPolicy = "move"
+	// ConflictReject fails team creation with a UserInOtherTeamError.
+	ConflictReject ConflictPolicy = "reject"
+)
+
+// ParseConflictPolicy validates a conflict policy name; an empty name means ConflictMove.
+func ParseConflictPolicy(s string) (ConflictPolicy, error) {
+	switch policy := ConflictPolicy(s); policy {
+	case "":
+		return ConflictMove, nil
+	case ConflictMove, ConflictReject:
+		return policy, nil
+	default:
+		return "", ErrUnknownConflictPolicy
 	}
-	defer func() { _ = tx.Rollback() }()
+}
 
-	// Check if team already exists
-	exists, err := team.Exists(tx, teamName)
-	if err != nil {
-		return fmt.Errorf("failed to check team existence: %w", err)
+// IfExists decides what CreateTeam does when the team already exists.
+type IfExists string
+
+const (
+	// IfExistsFail fails with ErrTeamExists.
+	IfExistsFail IfExists = "fail"
+	// IfExistsIgnore leaves the existing team untouched.
+	IfExistsIgnore IfExists = "ignore"
+	// IfExistsUpdate adds missing members, updates changed ones and applies an explicit strategy.
+	// Members absent from the request stay in the team.
+	IfExistsUpdate IfExists = "update"
+)
+
+// ParseIfExists validates an if_exists mode name; an empty name means IfExistsFail.
+func ParseIfExists(s string) (IfExists, error) {
+	switch mode := IfExists(s); mode {
+	case "":
+		return IfExistsFail, nil
+	case IfExistsFail, IfExistsIgnore, IfExistsUpdate:
+		return mode, nil
+	default:
+		return "", ErrUnknownIfExists
 	}
-	if exists {
-		return ErrTeamExists
+}
+
+// TeamOutcome tells what CreateTeam did.
+type TeamOutcome string
+
+const (
+	// TeamCreated means a new team was created.
+	TeamCreated TeamOutcome = "created"
+	// TeamUpdated means an existing team was changed by IfExistsUpdate.
+	TeamUpdated TeamOutcome = "updated"
+	// TeamUnchanged means the existing team already matched or IfExistsIgnore was used.
+	TeamUnchanged TeamOutcome = "unchanged"
+)
+
+// CreateTeamOptions tunes CreateTeam. The zero value uses ConflictMove and IfExistsFail.
+type CreateTeamOptions struct {
+	ConflictPolicy ConflictPolicy
+	IfExists       IfExists
+}
+
+// CreateTeam creates a new team with members in a single transaction and returns the team as written
+// by that transaction, so callers need not read it back.
+// An empty assignment strategy defaults to the one configured for the PR service.
+// Listing the same user twice fails with a DuplicateMemberError.
+// What happens to an existing team is decided by opts.IfExists.
+func (s *TeamService) CreateTeam(ctx context.Context, t *domain.Team, opts CreateTeamOptions) (*domain.Team, TeamOutcome, error) {
+	ctx, span := startSpan(ctx, "TeamService.CreateTeam")
+	defer span.End()
+
+	teamName := t.TeamName
+
+	seen := make(map[string]struct{}, len(t.Members))
+	for _, member := range t.Members {
+		if _, ok := seen[member.UserID]; ok {
+			return nil, "", &DuplicateMemberError{UserID: member.UserID}
+		}
+		seen[member.UserID] = struct{}{}
 	}
 
-	// Create team
-	if err := team.Create(tx, teamName); err != nil {
-		return fmt.Errorf("failed to create team: %w", err)
+	var explicitStrategy Strategy
+	if t.AssignmentStrategy != "" {
+		parsed, err := ParseStrategy(t.AssignmentStrategy)
+		if err != nil {
+			return nil, "", ErrUnknownStrategy
+		}
+		explicitStrategy = parsed
 	}
 
-	// Process each user: create if not exists, update if exists
-	for _, member := range members {
-		u := domain.User{
-			UserID:   member.UserID,
-			Username: member.Username,
-			TeamName: teamName,
-			IsActive: member.IsActive,
+	var result *domain.Team
+	outcome := TeamCreated
+	err := repository.WithTx(ctx, s.db, func(tx repository.DBTX) error {
+		// Check if team already exists
+		exists, err := team.Exists(tx, teamName)
+		if err != nil {
+			return fmt.Errorf("failed to check team existence: %w", err)
+		}
+		if exists {
+			switch opts.IfExists {
+			case IfExistsIgnore:
+				outcome = TeamUnchanged
+				result, err = team.Get(tx, teamName)
+				if err != nil {
+					return fmt.Errorf("failed to get team: %w", err)
+				}
+				return nil
+			case IfExistsUpdate:
+				result, outcome, err = s.reconcileTeam(tx, t, explicitStrategy, opts.ConflictPolicy)
+				return err
+			default:
+				return ErrTeamExists
+			}
+		}
+
+		// Create team
+		strategy := explicitStrategy
+		if strategy == "" {
+			strategy = s.prService.assigner.Strategy()
+		}
+		if err := team.CreateWithStrategy(tx, teamName, string(strategy)); err != nil {
+			if errors.Is(err, repository.ErrConflict) {
+				return ErrTeamExists
+			}
+			return fmt.Errorf("failed to create team: %w", err)
 		}
 
-		// Check if user exists
-		existingUser, err := user.Get(tx, member.UserID)
-		if err != nil && err != sql.ErrNoRows {
-			return fmt.Errorf("failed to check user existence: %w", err)
+		// A new team has the default settings.
+		result = &domain.Team{
+			TeamName:           teamName,
+			AssignmentStrategy: string(strategy),
+			ReviewerCount:      domain.DefaultReviewerCount,
+			Members:            make([]domain.TeamMember, 0, len(t.Members)),
+		}
+		for _, member := range t.Members {
+			written, err := addMember(tx, teamName, member, opts.ConflictPolicy)
+			if err != nil {
+				return err
+			}
+			result.Members = append(result.Members, written)
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	if outcome != TeamUnchanged {
+		s.prService.version.Bump()
+	}
+
+	return result, outcome, nil
+}
 
-		if existingUser == nil {
-			if err := user.Create(tx, &u); err != nil {
-				return fmt.Errorf("failed to create user: %w", err)
+// reconcileTeam brings an existing team in line with t: missing members are added,
+// listed members whose attributes differ are updated and an explicit strategy is applied.
+// Returns the team with the changes applied.
+func (s *TeamService) reconcileTeam(tx repository.DBTX, t *domain.Team, strategy Strategy, policy ConflictPolicy) (*domain.Team, TeamOutcome, error) {
+	current, err := team.Get(tx, t.TeamName)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get team: %w", err)
+	}
+
+	outcome := TeamUnchanged
+	if strategy != "" && string(strategy) != current.AssignmentStrategy {
+		if err := team.SetStrategy(tx, t.TeamName, string(strategy)); err != nil {
+			return nil, "", fmt.Errorf("failed to update team: %w", err)
+		}
+		current.AssignmentStrategy = string(strategy)
+		outcome = TeamUpdated
+	}
+
+	members := make(map[string]int, len(current.Members))
+	for i, member := range current.Members {
+		members[member.UserID] = i
+	}
+
+	for _, member := range t.Members {
+		i, ok := members[member.UserID]
+		if !ok {
+			written, err := addMember(tx, t.TeamName, member, policy)
+			if err != nil {
+				return nil, "", err
 			}
-		} else {
-			if err := user.Update(tx, &u); err != nil {
-				return fmt.Errorf("failed to update user: %w", err)
+			current.Members = append(current.Members, written)
+			outcome = TeamUpdated
+			continue
+		}
+		existing := current.Members[i]
+		member = keepStoredSettings(member, existing.MaxOpenReviews, existing.AssignmentWeight)
+		if sameMember(existing, member) {
+			continue
+		}
+		if err := user.Update(tx, memberUser(t.TeamName, member)); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil, "", &UserErasedError{UserID: member.UserID}
 			}
+			return nil, "", fmt.Errorf("failed to update user: %w", err)
+		}
+		if err := setMemberTags(tx, member); err != nil {
+			return nil, "", err
+		}
+		current.Members[i] = writtenMember(member, existing.Tags)
+		outcome = TeamUpdated
+	}
+	return current, outcome, nil
+}
+
+// addMember creates the user with teamName as their primary team, or, if the user exists,
+// updates them and adds teamName as an extra membership. Returns the member as written.
+func addMember(tx repository.DBTX, teamName string, member domain.TeamMember, policy ConflictPolicy) (domain.TeamMember, error) {
+	existingUser, err := user.Get(tx, member.UserID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return domain.TeamMember{}, fmt.Errorf("failed to check user existence: %w", err)
+	}
+
+	if existingUser == nil {
+		if err := user.Create(tx, memberUser(teamName, member)); err != nil {
+			return domain.TeamMember{}, fmt.Errorf("failed to create user: %w", err)
+		}
+		if err := setMemberTags(tx, member); err != nil {
+			return domain.TeamMember{}, err
 		}
+		return writtenMember(member, []string{}), nil
+	}
+
+	if existingUser.Erased {
+		return domain.TeamMember{}, &UserErasedError{UserID: member.UserID}
+	}
+	if existingUser.TeamName != teamName && policy == ConflictReject {
+		return domain.TeamMember{}, &UserInOtherTeamError{UserID: member.UserID, TeamName: existingUser.TeamName}
+	}
+	member = keepStoredSettings(member, existingUser.MaxOpenReviews, existingUser.AssignmentWeight)
+	if err := user.Update(tx, memberUser(teamName, member)); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return domain.TeamMember{}, &UserErasedError{UserID: member.UserID}
+		}
+		return domain.TeamMember{}, fmt.Errorf("failed to update user: %w", err)
+	}
+	if err := team.AddMember(tx, teamName, member.UserID, false); err != nil {
+		return domain.TeamMember{}, fmt.Errorf("failed to add team member: %w", err)
+	}
+
+	// Tags omitted from the request are kept.
+	storedTags := member.Tags
+	if member.Tags == nil {
+		storedTags, err = user.GetTags(tx, member.UserID)
+		if err != nil {
+			return domain.TeamMember{}, err
+		}
+	}
+	if err := setMemberTags(tx, member); err != nil {
+		return domain.TeamMember{}, err
+	}
+	return writtenMember(member, storedTags), nil
+}
+
+// keepStoredSettings fills the capacity and weight the member omits with the stored ones,
+// so re-adding an existing user doesn't reset them.
+func keepStoredSettings(member domain.TeamMember, maxOpenReviews *int, weight float64) domain.TeamMember {
+	if member.MaxOpenReviews == nil {
+		member.MaxOpenReviews = maxOpenReviews
 	}
+	if member.AssignmentWeight == 0 {
+		member.AssignmentWeight = weight
+	}
+	return member
+}
 
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+// writtenMember returns member as stored: with the default weight if it has none,
+// and with storedTags, the user's current tags, if it lists no tags.
+func writtenMember(member domain.TeamMember, storedTags []string) domain.TeamMember {
+	if member.AssignmentWeight == 0 {
+		member.AssignmentWeight = domain.DefaultAssignmentWeight
+	}
+	if member.Tags == nil {
+		member.Tags = storedTags
+	} else {
+		member.Tags = slices.Sorted(slices.Values(member.Tags))
 	}
+	return member
+}
 
+// setMemberTags replaces the member's tags unless the member lists none.
+func setMemberTags(tx repository.DBTX, member domain.TeamMember) error {
+	if member.Tags == nil {
+		return nil
+	}
+	if err := user.SetTags(tx, member.UserID, member.Tags); err != nil {
+		return fmt.Errorf("failed to set user tags: %w", err)
+	}
 	return nil
 }
 
+func memberUser(teamName string, member domain.TeamMember) *domain.User {
+	return &domain.User{
+		UserID:           member.UserID,
+		Username:         member.Username,
+		TeamName:         teamName,
+		IsActive:         member.IsActive,
+		MaxOpenReviews:   member.MaxOpenReviews,
+		AssignmentWeight: member.AssignmentWeight,
+	}
+}
+
+// sameMember reports whether applying want to the stored member would change nothing.
+func sameMember(stored, want domain.TeamMember) bool {
+	weight := want.AssignmentWeight
+	if weight == 0 {
+		weight = domain.DefaultAssignmentWeight
+	}
+	sameCapacity := (stored.MaxOpenReviews == nil) == (want.MaxOpenReviews == nil) &&
+		(stored.MaxOpenReviews == nil || *stored.MaxOpenReviews == *want.MaxOpenReviews)
+	sameTags := want.Tags == nil || slices.Equal(stored.Tags, slices.Sorted(slices.Values(want.Tags)))
+	return stored.Username == want.Username &&
+		stored.IsActive == want.IsActive &&
+		stored.AssignmentWeight == weight &&
+		sameCapacity &&
+		sameTags
+}
+
 // GetTeam retrieves a team with all its members.
-func (s *TeamService) GetTeam(teamName string) (*domain.Team, error) {
-	t, err := team.Get(s.db, teamName)
+func (s *TeamService) GetTeam(ctx context.Context, teamName string) (*domain.Team, error) {
+	ctx, span := startSpan(ctx, "TeamService.GetTeam")
+	defer span.End()
+	db := repository.WithContext(ctx, s.prService.reader(ctx))
+
+	t, err := team.Get(db, teamName)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, repository.ErrNotFound) {
 			return nil, ErrTeamNotFound
 		}
 		return nil, fmt.Errorf("failed to get team: %w", err)
@@ -88,57 +364,307 @@ func (s *TeamService) GetTeam(teamName string) (*domain.Team, error) {
 	return t, nil
 }
 
-// DeactivateTeam deactivates all users in a team and reassigns open PRs.
-func (s *TeamService) DeactivateTeam(teamName string) error {
-	// Check if team exists
-	_, err := team.Get(s.db, teamName)
+// TeamUpdate lists the team settings to change; an empty AssignmentStrategy and
+// nil pointers leave the current values.
+// ReviewerCount pointing to 0 restores domain.DefaultReviewerCount, ReviewSLAHours pointing to 0
+// removes the review SLA, a SlackWebhookURL pointing to "" disables the team's Slack notifications
+// and a FallbackTeamName pointing to "" removes the fallback team.
+type TeamUpdate struct {
+	AssignmentStrategy string
+	ReviewerCount      *int
+	RequireApprovals   *int
+	ReviewSLAHours     *int
+	SlackWebhookURL    *string
+	FallbackTeamName   *string
+}
+
+// UpdateTeam changes the team's settings: assignment strategy, reviewer count, approval requirement,
+// review SLA, Slack webhook and fallback team.
+// Only PRs created, reassigned or merged afterwards are affected.
+func (s *TeamService) UpdateTeam(ctx context.Context, teamName string, update TeamUpdate) (*domain.Team, error) {
+	ctx, span := startSpan(ctx, "TeamService.UpdateTeam")
+	defer span.End()
+
+	var strategy Strategy
+	if update.AssignmentStrategy != "" {
+		parsed, err := ParseStrategy(update.AssignmentStrategy)
+		if err != nil {
+			return nil, ErrUnknownStrategy
+		}
+		strategy = parsed
+	}
+	if update.RequireApprovals != nil && *update.RequireApprovals < 0 {
+		return nil, ErrInvalidRequireApprovals
+	}
+	if update.ReviewSLAHours != nil && *update.ReviewSLAHours < 0 {
+		return nil, ErrInvalidReviewSLA
+	}
+	if update.ReviewerCount != nil && (*update.ReviewerCount < 0 || *update.ReviewerCount > domain.MaxReviewerCount) {
+		return nil, ErrInvalidReviewerCount
+	}
+	if update.FallbackTeamName != nil && *update.FallbackTeamName == teamName {
+		return nil, ErrInvalidFallbackTeam
+	}
+
+	err := s.prService.retry.RunTx(ctx, s.db, func(tx repository.DBTX) error {
+		exists, err := team.Exists(tx, teamName)
+		if err != nil {
+			return fmt.Errorf("failed to check team existence: %w", err)
+		}
+		if !exists {
+			return ErrTeamNotFound
+		}
+
+		if strategy != "" {
+			if err := team.SetStrategy(tx, teamName, string(strategy)); err != nil {
+				return fmt.Errorf("failed to update team strategy: %w", err)
+			}
+		}
+		if update.RequireApprovals != nil {
+			if err := team.SetRequireApprovals(tx, teamName, *update.RequireApprovals); err != nil {
+				return fmt.Errorf("failed to update team approval requirement: %w", err)
+			}
+		}
+		if update.ReviewSLAHours != nil {
+			if err := team.SetReviewSLAHours(tx, teamName, *update.ReviewSLAHours); err != nil {
+				return fmt.Errorf("failed to update team review SLA: %w", err)
+			}
+		}
+		if update.SlackWebhookURL != nil {
+			if err := team.SetSlackWebhookURL(tx, teamName, *update.SlackWebhookURL); err != nil {
+				return fmt.Errorf("failed to update team slack webhook: %w", err)
+			}
+		}
+		if update.ReviewerCount != nil {
+			if err := team.SetReviewerCount(tx, teamName, *update.ReviewerCount); err != nil {
+				return fmt.Errorf("failed to update team reviewer count: %w", err)
+			}
+		}
+		if update.FallbackTeamName != nil {
+			if *update.FallbackTeamName != "" {
+				exists, err := team.Exists(tx, *update.FallbackTeamName)
+				if err != nil {
+					return fmt.Errorf("failed to check fallback team existence: %w", err)
+				}
+				if !exists {
+					return ErrFallbackTeamNotFound
+				}
+			}
+			if err := team.SetFallbackTeamName(tx, teamName, *update.FallbackTeamName); err != nil {
+				return fmt.Errorf("failed to update team fallback team: %w", err)
+			}
+		}
+		return nil
+	})
 	if err != nil {
-		if err == sql.ErrNoRows {
+		return nil, err
+	}
+
+	return s.GetTeam(ReadPrimary(ctx), teamName)
+}
+
+// RenameTeam renames the team together with everything that refers to it by name: its members,
+// pull requests, ownership rules, digest schedule and the teams using it as a fallback.
+// Reviewer assignments are not touched. The rename is recorded in the audit log as team:<old>-><new>.
+func (s *TeamService) RenameTeam(ctx context.Context, oldName, newName string) (*domain.Team, error) {
+	ctx, span := startSpan(ctx, "TeamService.RenameTeam")
+	defer span.End()
+
+	var renamed *domain.Team
+	err := s.prService.retry.RunTx(ctx, s.db, func(tx repository.DBTX) error {
+		exists, err := team.Exists(tx, oldName)
+		if err != nil {
+			return fmt.Errorf("failed to check team existence: %w", err)
+		}
+		if !exists {
 			return ErrTeamNotFound
 		}
-		return fmt.Errorf("failed to check team: %w", err)
+
+		if err := team.Rename(tx, oldName, newName); err != nil {
+			if errors.Is(err, repository.ErrConflict) {
+				return ErrTeamExists
+			}
+			return fmt.Errorf("failed to rename team: %w", err)
+		}
+		if err := recordAudit(ctx, tx, domain.AuditTeamRename, "team:"+oldName+"->"+newName); err != nil {
+			return err
+		}
+
+		renamed, err = team.Get(tx, newName)
+		if err != nil {
+			return fmt.Errorf("failed to get team: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	s.prService.version.Bump()
+	return renamed, nil
+}
+
+// GetTeamSettings returns the team's effective settings.
+func (s *TeamService) GetTeamSettings(ctx context.Context, teamName string) (*domain.TeamSettings, error) {
+	ctx, span := startSpan(ctx, "TeamService.GetTeamSettings")
+	defer span.End()
+	db := repository.WithContext(ctx, s.prService.reader(ctx))
 
-	tx, err := s.db.Begin()
+	settings, err := team.GetSettings(db, teamName)
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrTeamNotFound
+		}
+		return nil, fmt.Errorf("failed to get team settings: %w", err)
 	}
-	defer func() { _ = tx.Rollback() }()
+	return settings, nil
+}
+
+// GetTeamWorkload returns the open reviews of the team's active members and the staffing of the team's
+// open pull requests, measured against the team's reviewer count. Assignments are measured against the review SLA
+// of their pull request's team.
+func (s *TeamService) GetTeamWorkload(ctx context.Context, teamName string) (*domain.TeamWorkload, error) {
+	ctx, span := startSpan(ctx, "TeamService.GetTeamWorkload")
+	defer span.End()
+	db := repository.WithContext(ctx, s.prService.reader(ctx))
 
-	// 1. Deactivate all team users
-	if err := team.DeactivateAll(tx, teamName); err != nil {
-		return fmt.Errorf("failed to deactivate team: %w", err)
+	settings, err := team.GetSettings(db, teamName)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrTeamNotFound
+		}
+		return nil, fmt.Errorf("failed to get team settings: %w", err)
 	}
 
-	// 2. Find open PRs that have reviewers from this team 
-	prReviewers, err := pr.GetOpenPRsWithReviewersFromTeam(tx, teamName)
+	members, err := team.GetMemberWorkloads(db, teamName)
+	if err != nil {
+		return nil, err
+	}
+	staffing, err := team.GetPRStaffing(db, teamName, settings.ReviewerCount)
 	if err != nil {
-		return fmt.Errorf("failed to get open PRs: %w", err)
+		return nil, err
 	}
 
-	// 3. For each PR: remove reviewers from the team, then replenish from PR's team if needed
-	for prID, reviewerIDs := range prReviewers {
-		for _, reviewerID := range reviewerIDs {
-			if err := pr.DeleteReviewer(tx, prID, reviewerID); err != nil {
-				return fmt.Errorf("failed to delete reviewer: %w", err)
-			}
+	now := s.prService.clock.Now()
+	for _, m := range members {
+		for _, p := range m.PullRequests {
+			p.Assignment.Measure(now, p.Status)
 		}
+	}
+	return &domain.TeamWorkload{
+		TeamName:         teamName,
+		Members:          members,
+		OpenPRs:          staffing.OpenPRs,
+		UnassignedPRs:    staffing.UnassignedPRs,
+		UnderAssignedPRs: staffing.UnderAssignedPRs,
+		ReviewerCount:    settings.ReviewerCount,
+	}, nil
+}
 
-		pullRequest, err := pr.Get(tx, prID)
-		if err != nil {
-			return fmt.Errorf("failed to get PR: %w", err)
+// SlackTarget returns the pull request and the Slack incoming webhook of its team,
+// which is "" when the team has not enabled Slack notifications.
+func (s *TeamService) SlackTarget(ctx context.Context, key domain.PRKey) (*domain.PullRequest, string, error) {
+	ctx, span := startSpan(ctx, "TeamService.SlackTarget")
+	defer span.End()
+	db := repository.WithContext(ctx, s.db)
+
+	pullRequest, err := pr.Get(db, key)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, "", ErrPRNotFound
 		}
-		if pullRequest.TeamName == teamName {
-			continue
+		return nil, "", fmt.Errorf("failed to get pull request: %w", err)
+	}
+
+	url, err := team.GetSlackWebhookURL(db, pullRequest.TeamName)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return pullRequest, "", nil
 		}
-		if err := s.prService.ReplenishReviewers(tx, prID); err != nil {
-			return err
+		return nil, "", fmt.Errorf("failed to get team slack webhook: %w", err)
+	}
+	return pullRequest, url, nil
+}
+
+// SlackWebhookURL returns the team's Slack incoming webhook, which is "" when the team
+// has not enabled Slack notifications or no longer exists.
+func (s *TeamService) SlackWebhookURL(ctx context.Context, teamName string) (string, error) {
+	ctx, span := startSpan(ctx, "TeamService.SlackWebhookURL")
+	defer span.End()
+	db := repository.WithContext(ctx, s.db)
+
+	url, err := team.GetSlackWebhookURL(db, teamName)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return "", nil
 		}
+		return "", fmt.Errorf("failed to get team slack webhook: %w", err)
 	}
+	return url, nil
+}
 
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+// DeactivateTeam deactivates all users in a team and reassigns open PRs.
+// The deactivation is recorded in the audit log.
+func (s *TeamService) DeactivateTeam(ctx context.Context, teamName string) error {
+	ctx, span := startSpan(ctx, "TeamService.DeactivateTeam")
+	defer span.End()
+	db := repository.WithContext(ctx, s.db)
+
+	// Check if team exists
+	_, err := team.Get(db, teamName)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrTeamNotFound
+		}
+		return fmt.Errorf("failed to check team: %w", err)
+	}
+
+	err = s.prService.retry.RunTx(ctx, s.db, func(tx repository.DBTX) error {
+		// 1. Deactivate all team users
+		if err := team.DeactivateAll(tx, teamName); err != nil {
+			return fmt.Errorf("failed to deactivate team: %w", err)
+		}
+		if err := recordAudit(ctx, tx, domain.AuditTeamDeactivate, "team:"+teamName); err != nil {
+			return err
+		}
+
+		// 2. Find open PRs that have reviewers from this team
+		prReviewers, err := pr.GetOpenPRsWithReviewersFromTeam(tx, teamName)
+		if err != nil {
+			return fmt.Errorf("failed to get open PRs: %w", err)
+		}
+
+		// 3. For each PR: remove reviewers from the team, then replenish from PR's team if needed.
+		// Members of the deactivated team may also belong to the PR's team; they are never picked.
+		for key, reviewerIDs := range prReviewers {
+			for _, reviewerID := range reviewerIDs {
+				if err := pr.DeleteReviewer(tx, key, reviewerID); err != nil {
+					return fmt.Errorf("failed to delete reviewer: %w", err)
+				}
+			}
+
+			pullRequest, err := pr.Get(tx, key)
+			if err != nil {
+				return fmt.Errorf("failed to get PR: %w", err)
+			}
+			if pullRequest.TeamName == teamName {
+				continue
+			}
+			if err := s.prService.replenishReviewersOutside(tx, key, teamName); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
+	s.prService.version.Bump()
 
 	return nil
 }
+
+// RebalanceTeam evens out open review assignments among the team's members.
+// See PRService.RebalanceTeam.
+func (s *TeamService) RebalanceTeam(ctx context.Context, teamName string, opts RebalanceOptions) ([]RebalanceMove, error) {
+	return s.prService.RebalanceTeam(ctx, teamName, opts)
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/mishasvintus/avito_backend_internship/internal/service"
+
+// startSpan starts a span of a service method. The tracer is taken from the global provider
+// on every call, so spans go to whichever provider is installed at the time.
+func startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name)
+}
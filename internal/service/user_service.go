@@ -1,43 +1,432 @@
 package service
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/pagination"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/absence"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/exclusion"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/externallogin"
 	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
 	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
 )
 
 // UserService handles user business logic.
 type UserService struct {
-	db *sql.DB
+	db        *sql.DB
+	prService *PRService
 }
 
 // NewUserService creates a new user service.
-func NewUserService(db *sql.DB) *UserService {
-	return &UserService{db: db}
+func NewUserService(db *sql.DB, prService *PRService) *UserService {
+	return &UserService{db: db, prService: prService}
 }
 
 // SetIsActive updates the is_active status of a user.
-func (s *UserService) SetIsActive(userID string, isActive bool) (*domain.User, error) {
-	u, err := user.SetIsActive(s.db, userID, isActive)
+// An erased user cannot be activated again and is reported as ErrUserNotFound.
+func (s *UserService) SetIsActive(ctx context.Context, userID string, isActive bool) (*domain.User, error) {
+	ctx, span := startSpan(ctx, "UserService.SetIsActive")
+	defer span.End()
+	db := repository.WithContext(ctx, s.db)
+
+	u, err := user.SetIsActive(db, userID, isActive)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, repository.ErrNotFound) {
 			return nil, ErrUserNotFound
 		}
 		return nil, fmt.Errorf("failed to update user status: %w", err)
 	}
+	s.prService.version.Bump()
+
+	return u, nil
+}
+
+// ActivityChange is one item of a batch is_active update.
+type ActivityChange struct {
+	UserID   string
+	IsActive bool
+}
+
+// ActivityChangeError reports a batch item that was skipped.
+type ActivityChangeError struct {
+	UserID string
+	Err    error
+}
+
+// ActivityBatchResult is the outcome of SetIsActiveBatch.
+type ActivityBatchResult struct {
+	Updated []domain.User
+	Errors  []ActivityChangeError
+}
+
+// SetIsActiveBatch applies is_active changes in a single transaction.
+// Unknown users are skipped and reported per item; the rest is applied.
+// Any other failure rolls back the whole batch.
+// Open reviews of deactivated users are released and refilled from each PR's team,
+// as DeactivateTeam does, so no open PR keeps an inactive reviewer.
+func (s *UserService) SetIsActiveBatch(ctx context.Context, changes []ActivityChange) (*ActivityBatchResult, error) {
+	ctx, span := startSpan(ctx, "UserService.SetIsActiveBatch")
+	defer span.End()
+
+	result := &ActivityBatchResult{
+		Updated: make([]domain.User, 0, len(changes)),
+		Errors:  make([]ActivityChangeError, 0),
+	}
+
+	err := repository.WithTx(ctx, s.db, func(tx repository.DBTX) error {
+		deactivated := make([]string, 0)
+		for _, change := range changes {
+			u, err := user.SetIsActive(tx, change.UserID, change.IsActive)
+			if err != nil {
+				if errors.Is(err, repository.ErrNotFound) {
+					result.Errors = append(result.Errors, ActivityChangeError{UserID: change.UserID, Err: ErrUserNotFound})
+					continue
+				}
+				return fmt.Errorf("failed to update user status: %w", err)
+			}
+			result.Updated = append(result.Updated, *u)
+			if !change.IsActive {
+				deactivated = append(deactivated, change.UserID)
+			}
+		}
+
+		for _, userID := range deactivated {
+			// A later item in the batch may have re-activated the user.
+			u, err := user.Get(tx, userID)
+			if err != nil {
+				return fmt.Errorf("failed to get user: %w", err)
+			}
+			if u.IsActive {
+				continue
+			}
+
+			keys, err := pr.GetOpenIDsByReviewer(tx, userID)
+			if err != nil {
+				return fmt.Errorf("failed to get open reviews: %w", err)
+			}
+			for _, key := range keys {
+				if err := pr.DeleteReviewer(tx, key, userID); err != nil {
+					return fmt.Errorf("failed to delete reviewer: %w", err)
+				}
+				if err := s.prService.ReplenishReviewers(tx, key); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.prService.version.Bump()
+
+	return result, nil
+}
+
+// EraseUser removes the user's personal data in one transaction: the username is replaced with
+// domain.ErasedUsername, absences, reviewer exclusions and provider logins are deleted, and the user
+// is deactivated and marked erased, so they are never picked as a reviewer again and no longer
+// listed in teams. Their open reviews are released and refilled from each PR's team.
+// The user ID stays, so PRs they authored, merged or reviewed remain intact and resolve to the
+// tombstone name. Every call is recorded in the audit log; erasing an erased user changes nothing else.
+func (s *UserService) EraseUser(ctx context.Context, userID string) (*domain.User, error) {
+	ctx, span := startSpan(ctx, "UserService.EraseUser")
+	defer span.End()
+
+	var erased *domain.User
+	err := s.prService.retry.RunTx(ctx, s.db, func(tx repository.DBTX) error {
+		u, err := user.Erase(tx, userID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return ErrUserNotFound
+			}
+			return err
+		}
+
+		if _, err := absence.Delete(tx, userID, nil); err != nil {
+			return err
+		}
+		if err := exclusion.DeleteByUser(tx, userID); err != nil {
+			return err
+		}
+		if err := externallogin.DeleteByUser(tx, userID); err != nil {
+			return err
+		}
+
+		keys, err := pr.GetOpenIDsByReviewer(tx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to get open reviews: %w", err)
+		}
+		for _, key := range keys {
+			if err := pr.DeleteReviewer(tx, key, userID); err != nil {
+				return fmt.Errorf("failed to delete reviewer: %w", err)
+			}
+			if err := s.prService.ReplenishReviewers(tx, key); err != nil {
+				return err
+			}
+		}
+
+		erased = u
+		return recordAudit(ctx, tx, domain.AuditUserErase, "user:"+userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.prService.version.Bump()
+
+	return erased, nil
+}
+
+// SetCapacity updates the maximum number of open reviews a user may hold.
+// A nil limit removes the restriction.
+func (s *UserService) SetCapacity(ctx context.Context, userID string, maxOpenReviews *int) (*domain.User, error) {
+	ctx, span := startSpan(ctx, "UserService.SetCapacity")
+	defer span.End()
+	db := repository.WithContext(ctx, s.db)
+
+	u, err := user.SetMaxOpenReviews(db, userID, maxOpenReviews)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to update user capacity: %w", err)
+	}
+
+	return u, nil
+}
+
+// SetTags replaces the user's expertise tags; an empty list removes them all.
+func (s *UserService) SetTags(ctx context.Context, userID string, tags []string) (*domain.User, error) {
+	ctx, span := startSpan(ctx, "UserService.SetTags")
+	defer span.End()
+
+	var u *domain.User
+	err := repository.WithTx(ctx, s.db, func(tx repository.DBTX) error {
+		var err error
+		u, err = user.Get(tx, userID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return ErrUserNotFound
+			}
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		if err := user.SetTags(tx, userID, tags); err != nil {
+			return fmt.Errorf("failed to set user tags: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
+	u.Tags = slices.Sorted(slices.Values(tags))
 	return u, nil
 }
 
-// GetUserReviews returns all pull requests where the user is assigned as a reviewer.
-func (s *UserService) GetUserReviews(userID string) ([]domain.PullRequestShort, error) {
-	prs, err := pr.GetByUser(s.db, userID)
+// GetUserReviews returns all pull requests where the user is assigned as a reviewer,
+// with the time the user's review has waited measured against the team's review SLA.
+// A non-nil repositoryName limits the result to that repository.
+func (s *UserService) GetUserReviews(ctx context.Context, userID string, repositoryName *string) ([]domain.PullRequestShort, error) {
+	ctx, span := startSpan(ctx, "UserService.GetUserReviews")
+	defer span.End()
+	db := repository.WithContext(ctx, s.prService.reader(ctx))
+
+	prs, err := pr.GetByUser(db, userID, repositoryName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user reviews: %w", err)
 	}
 
+	now := s.prService.clock.Now()
+	for _, p := range prs {
+		p.Assignment.Measure(now, p.Status)
+	}
 	return prs, nil
 }
+
+// GetUserReviewCount returns how many open pull requests the user is assigned to review and how
+// many of those reviews are overdue, counted in the database rather than listed as GetUserReviews does.
+// A non-nil repositoryName limits the count to that repository. An unknown user yields zero counts.
+func (s *UserService) GetUserReviewCount(ctx context.Context, userID string, repositoryName *string) (pr.ReviewCount, error) {
+	ctx, span := startSpan(ctx, "UserService.GetUserReviewCount")
+	defer span.End()
+	db := repository.WithContext(ctx, s.prService.reader(ctx))
+
+	count, err := pr.CountByUser(db, userID, repositoryName, s.prService.clock.Now())
+	if err != nil {
+		return pr.ReviewCount{}, fmt.Errorf("failed to count user reviews: %w", err)
+	}
+	return count, nil
+}
+
+// MaxAuthoredPageSize caps the number of authored pull requests returned at once.
+const MaxAuthoredPageSize = 100
+
+// GetAuthoredPRs returns a page of pull requests authored by the user matching filter, newest first,
+// each with its reviewers' assignments measured against the team's review SLA.
+// Returns ErrInvalidLimit unless filter.Limit is between 1 and MaxAuthoredPageSize.
+func (s *UserService) GetAuthoredPRs(ctx context.Context, userID string, filter pr.AuthoredFilter) (pagination.Page[domain.PullRequest], error) {
+	ctx, span := startSpan(ctx, "UserService.GetAuthoredPRs")
+	defer span.End()
+	db := repository.WithContext(ctx, s.prService.reader(ctx))
+
+	if filter.Limit < 1 || filter.Limit > MaxAuthoredPageSize || filter.Offset < 0 {
+		return pagination.Page[domain.PullRequest]{}, ErrInvalidLimit
+	}
+
+	limit := filter.Limit
+	filter.Limit++
+	prs, err := pr.GetByAuthor(db, userID, filter)
+	if err != nil {
+		return pagination.Page[domain.PullRequest]{}, fmt.Errorf("failed to get user authored PRs: %w", err)
+	}
+
+	now := s.prService.clock.Now()
+	for _, p := range prs {
+		for i := range p.Assignments {
+			p.Assignments[i].Measure(now, p.Status)
+		}
+	}
+	return pagination.NewPage(prs, limit, pr.AuthoredCursor), nil
+}
+
+// SetAbsence records an absence window for the user.
+// With reassignOpen and an absence covering today, every open review the user holds is moved to a
+// teammate right away; a future absence only keeps the user from new assignments once it starts.
+// Reviews without an available replacement stay with the user and are reported with an empty ReplacedBy.
+// Each review moves in its own transaction after the absence is saved, so a failure midway returns a
+// ReassignIncompleteError with the reviews handled so far.
+func (s *UserService) SetAbsence(ctx context.Context, a domain.Absence, reassignOpen bool) ([]ReassignResult, error) {
+	ctx, span := startSpan(ctx, "UserService.SetAbsence")
+	defer span.End()
+	db := repository.WithContext(ctx, s.db)
+
+	if a.ToDate.Before(a.FromDate) {
+		return nil, ErrInvalidAbsence
+	}
+
+	if _, err := user.Get(db, a.UserID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := absence.Create(db, &a); err != nil {
+		return nil, fmt.Errorf("failed to create absence: %w", err)
+	}
+
+	if !reassignOpen || !a.Covers(s.prService.clock.Now()) {
+		return []ReassignResult{}, nil
+	}
+
+	results, err := s.prService.ReassignAllFrom(ctx, a.UserID, domain.ActionAbsence)
+	if err != nil {
+		return nil, &ReassignIncompleteError{Reassigned: results, Err: err}
+	}
+	return results, nil
+}
+
+// ReassignAll moves every open review of the user to a teammate from each PR's team, like a manual
+// reassign, in one transaction per PR (see PRService.ReassignAllFrom), so progress survives a failure midway.
+// Reviews without an available replacement stay with the user and are reported with an empty ReplacedBy.
+// With deactivate, the user is deactivated afterwards. The call is recorded in the audit log.
+func (s *UserService) ReassignAll(ctx context.Context, userID string, deactivate bool) ([]ReassignResult, error) {
+	ctx, span := startSpan(ctx, "UserService.ReassignAll")
+	defer span.End()
+	db := repository.WithContext(ctx, s.db)
+
+	if _, err := user.Get(db, userID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	results, err := s.prService.ReassignAllFrom(ctx, userID, domain.ActionReassign)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reassign open reviews: %w", err)
+	}
+
+	err = s.prService.retry.RunTx(ctx, s.db, func(tx repository.DBTX) error {
+		if deactivate {
+			if _, err := user.SetIsActive(tx, userID, false); err != nil {
+				if errors.Is(err, repository.ErrNotFound) {
+					return ErrUserNotFound
+				}
+				return fmt.Errorf("failed to deactivate user: %w", err)
+			}
+		}
+		return recordAudit(ctx, tx, domain.AuditUserReassignAll, "user:"+userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.prService.version.Bump()
+
+	return results, nil
+}
+
+// RemoveAbsence deletes the user's absence starting at fromDate, or all of them when fromDate is nil.
+func (s *UserService) RemoveAbsence(ctx context.Context, userID string, fromDate *time.Time) error {
+	ctx, span := startSpan(ctx, "UserService.RemoveAbsence")
+	defer span.End()
+	db := repository.WithContext(ctx, s.db)
+
+	removed, err := absence.Delete(db, userID, fromDate)
+	if err != nil {
+		return fmt.Errorf("failed to remove absence: %w", err)
+	}
+	if removed == 0 {
+		return ErrAbsenceNotFound
+	}
+	return nil
+}
+
+// AddExclusion forbids the reviewer from being assigned to the author's pull requests.
+// Existing assignments are kept. Adding an existing exclusion is a no-op.
+func (s *UserService) AddExclusion(ctx context.Context, e domain.Exclusion) error {
+	ctx, span := startSpan(ctx, "UserService.AddExclusion")
+	defer span.End()
+	db := repository.WithContext(ctx, s.db)
+
+	if e.ReviewerID == e.AuthorID {
+		return ErrSelfExclusion
+	}
+
+	for _, id := range []string{e.ReviewerID, e.AuthorID} {
+		if _, err := user.Get(db, id); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return ErrUserNotFound
+			}
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+	}
+
+	if err := exclusion.Create(db, &e); err != nil {
+		return fmt.Errorf("failed to add exclusion: %w", err)
+	}
+	return nil
+}
+
+// RemoveExclusion allows the reviewer to be assigned to the author's pull requests again.
+func (s *UserService) RemoveExclusion(ctx context.Context, e domain.Exclusion) error {
+	ctx, span := startSpan(ctx, "UserService.RemoveExclusion")
+	defer span.End()
+	db := repository.WithContext(ctx, s.db)
+
+	removed, err := exclusion.Delete(db, &e)
+	if err != nil {
+		return fmt.Errorf("failed to remove exclusion: %w", err)
+	}
+	if removed == 0 {
+		return ErrExclusionNotFound
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+)
+
+// Headers sent with every webhook delivery.
+const (
+	// WebhookSignatureHeader carries "sha256=" followed by the hex HMAC-SHA256 of the body keyed by the webhook secret.
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	// WebhookEventHeader carries the event type.
+	WebhookEventHeader = "X-Webhook-Event"
+	// WebhookDeliveryHeader carries the event ID, which stays the same across retries.
+	WebhookDeliveryHeader = "X-Webhook-Delivery"
+)
+
+// WebhookLister returns the current webhook subscriptions.
+type WebhookLister interface {
+	ListWebhooks(ctx context.Context) ([]domain.Webhook, error)
+}
+
+// DefaultWebhookRetryPolicy is used by WebhookSender unless WithRetryPolicy sets another one.
+var DefaultWebhookRetryPolicy = RetryPolicy{Attempts: 5, BaseDelay: time.Second}
+
+// WebhookSender is the EventPublisher that POSTs events to every webhook.
+type WebhookSender struct {
+	webhooks WebhookLister
+	client   *http.Client
+	retry    RetryPolicy
+}
+
+// NewWebhookSender creates a sender delivering to the webhooks returned by webhooks.
+func NewWebhookSender(webhooks WebhookLister) *WebhookSender {
+	return &WebhookSender{
+		webhooks: webhooks,
+		client:   &http.Client{Timeout: 5 * time.Second},
+		retry:    DefaultWebhookRetryPolicy,
+	}
+}
+
+// WithHTTPClient sets the client used for deliveries; its Timeout bounds each attempt.
+func (s *WebhookSender) WithHTTPClient(client *http.Client) *WebhookSender {
+	s.client = client
+	return s
+}
+
+// WithRetryPolicy sets how often and how long apart a failed delivery is retried.
+// A delivery fails when the request errors or the receiver answers with a non-2xx status.
+func (s *WebhookSender) WithRetryPolicy(policy RetryPolicy) *WebhookSender {
+	s.retry = policy
+	return s
+}
+
+// Publish sends the event to every webhook, retrying each failed delivery with backoff.
+// Returns the errors of the webhooks that never accepted it; the outbox then publishes the event
+// again later, so webhooks that did accept it may receive it twice and should deduplicate
+// by the WebhookDeliveryHeader value.
+func (s *WebhookSender) Publish(ctx context.Context, event domain.Event) error {
+	webhooks, err := s.webhooks.ListWebhooks(ctx)
+	if err != nil {
+		return fmt.Errorf("event %s: %w", event.ID, err)
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event %s: %w", event.ID, err)
+	}
+
+	var errs []error
+	for _, w := range webhooks {
+		if err := s.deliverWithRetry(ctx, w, event, body); err != nil {
+			errs = append(errs, fmt.Errorf("event %s to webhook %d: %w", event.ID, w.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// deliverWithRetry POSTs body to the webhook until it answers 2xx, attempts run out or ctx is cancelled.
+func (s *WebhookSender) deliverWithRetry(ctx context.Context, w domain.Webhook, event domain.Event, body []byte) error {
+	attempts := max(s.retry.Attempts, 1)
+
+	var err error
+	for attempt := range attempts {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(s.retry.backoff(attempt)):
+			}
+		}
+		err = s.deliver(ctx, w, event, body)
+		if err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// deliver makes a single signed delivery attempt.
+func (s *WebhookSender) deliver(ctx context.Context, w domain.Webhook, event domain.Event, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(w.Secret, body))
+	req.Header.Set(WebhookEventHeader, string(event.Type))
+	req.Header.Set(WebhookDeliveryHeader, event.ID)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("receiver responded %s", resp.Status)
+	}
+	return nil
+}
+
+// SignWebhookPayload returns the WebhookSignatureHeader value for body signed with secret.
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
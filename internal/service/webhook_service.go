@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/webhook"
+)
+
+// WebhookService manages webhook subscriptions.
+type WebhookService struct {
+	db *sql.DB
+}
+
+// NewWebhookService creates a new webhook service.
+func NewWebhookService(db *sql.DB) *WebhookService {
+	return &WebhookService{db: db}
+}
+
+// CreateWebhook subscribes url to assignment events signed with secret.
+// The subscription is recorded in the audit log.
+func (s *WebhookService) CreateWebhook(ctx context.Context, url, secret string) (*domain.Webhook, error) {
+	ctx, span := startSpan(ctx, "WebhookService.CreateWebhook")
+	defer span.End()
+
+	w := &domain.Webhook{URL: url, Secret: secret}
+	err := repository.WithTx(ctx, s.db, func(tx repository.DBTX) error {
+		if err := webhook.Create(tx, w); err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx, domain.AuditWebhookCreate, "webhook:"+strconv.FormatInt(w.ID, 10))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// DeleteWebhook removes a subscription and records the removal in the audit log.
+// Returns ErrWebhookNotFound if it doesn't exist.
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id int64) error {
+	ctx, span := startSpan(ctx, "WebhookService.DeleteWebhook")
+	defer span.End()
+
+	return repository.WithTx(ctx, s.db, func(tx repository.DBTX) error {
+		if err := webhook.Delete(tx, id); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return ErrWebhookNotFound
+			}
+			return err
+		}
+		return recordAudit(ctx, tx, domain.AuditWebhookDelete, "webhook:"+strconv.FormatInt(id, 10))
+	})
+}
+
+// ListWebhooks returns all subscriptions with their secrets.
+func (s *WebhookService) ListWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	ctx, span := startSpan(ctx, "WebhookService.ListWebhooks")
+	defer span.End()
+	db := repository.WithContext(ctx, s.db)
+
+	webhooks, err := webhook.List(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	return webhooks, nil
+}
@@ -0,0 +1,52 @@
+// Package tracing sets up OpenTelemetry trace export.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+// ServiceName names the service in exported spans unless OTEL_SERVICE_NAME overrides it.
+const ServiceName = "pr-reviewer-assignment-service"
+
+// Setup creates a tracer provider batching spans to an OTLP/HTTP collector and installs it,
+// together with the W3C trace context and baggage propagators, as the OpenTelemetry globals.
+// The exporter reads the standard OTEL_EXPORTER_OTLP_* variables, the resource
+// OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES, and the sampler OTEL_TRACES_SAMPLER.
+// The caller must shut the provider down to flush buffered spans.
+func Setup(ctx context.Context) (*sdktrace.TracerProvider, error) {
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(ServiceName)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(Propagator())
+
+	return provider, nil
+}
+
+// Propagator reads and writes the W3C traceparent, tracestate and baggage headers.
+func Propagator() propagation.TextMapPropagator {
+	return propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+}
@@ -0,0 +1,6 @@
+-- Drop assignment history and assignment timestamps
+
+DROP INDEX IF EXISTS idx_pr_reviewers_assigned_at;
+DROP INDEX IF EXISTS idx_assignment_history_pull_request_id;
+DROP TABLE IF EXISTS assignment_history CASCADE;
+ALTER TABLE pr_reviewers DROP COLUMN IF EXISTS assigned_at;
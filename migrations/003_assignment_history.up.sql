@@ -0,0 +1,19 @@
+-- Track when each reviewer was assigned (used to detect overdue reviews)
+ALTER TABLE pr_reviewers ADD COLUMN IF NOT EXISTS assigned_at TIMESTAMP NOT NULL DEFAULT NOW();
+
+-- Create assignment_history table (audit trail of reviewer changes)
+CREATE TABLE IF NOT EXISTS assignment_history (
+    assignment_history_id SERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL,
+    action VARCHAR(32) NOT NULL,
+    old_user_id VARCHAR(255) NULL,
+    new_user_id VARCHAR(255) NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE
+);
+
+-- history.GetByPR() - WHERE pull_request_id = $1
+CREATE INDEX IF NOT EXISTS idx_assignment_history_pull_request_id ON assignment_history(pull_request_id);
+
+-- pr.GetOverdueAssignments() - WHERE assigned_at < $1 ORDER BY assigned_at
+CREATE INDEX IF NOT EXISTS idx_pr_reviewers_assigned_at ON pr_reviewers(assigned_at);
@@ -0,0 +1,3 @@
+-- Drop per-user review capacity
+
+ALTER TABLE users DROP COLUMN IF EXISTS max_open_reviews;
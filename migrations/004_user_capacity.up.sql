@@ -0,0 +1,2 @@
+-- Optional per-user limit of simultaneously open reviews (NULL = unlimited)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS max_open_reviews INTEGER NULL CHECK (max_open_reviews >= 0);
@@ -0,0 +1,4 @@
+-- Drop user absences
+
+DROP INDEX IF EXISTS idx_user_absences_user_id_dates;
+DROP TABLE IF EXISTS user_absences CASCADE;
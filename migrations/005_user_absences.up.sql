@@ -0,0 +1,12 @@
+-- Create user_absences table (vacations / out-of-office windows, inclusive)
+CREATE TABLE IF NOT EXISTS user_absences (
+    user_absence_id SERIAL PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    from_date DATE NOT NULL,
+    to_date DATE NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+    CHECK (from_date <= to_date)
+);
+
+-- Candidate queries - NOT EXISTS (... WHERE user_id = u.user_id AND CURRENT_DATE BETWEEN ...)
+CREATE INDEX IF NOT EXISTS idx_user_absences_user_id_dates ON user_absences(user_id, from_date, to_date);
@@ -0,0 +1,3 @@
+-- Drop assignment weight
+
+ALTER TABLE users DROP COLUMN IF EXISTS assignment_weight;
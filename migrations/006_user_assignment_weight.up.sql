@@ -0,0 +1,2 @@
+-- Relative chance of being picked by the weighted strategy
+ALTER TABLE users ADD COLUMN IF NOT EXISTS assignment_weight DOUBLE PRECISION NOT NULL DEFAULT 1.0 CHECK (assignment_weight > 0);
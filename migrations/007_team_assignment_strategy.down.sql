@@ -0,0 +1,3 @@
+-- Drop per-team assignment strategy
+
+ALTER TABLE teams DROP COLUMN IF EXISTS assignment_strategy;
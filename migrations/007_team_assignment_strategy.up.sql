@@ -0,0 +1,2 @@
+-- Reviewer selection strategy per team (random | weighted | least_loaded | round_robin)
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS assignment_strategy VARCHAR(32) NOT NULL DEFAULT 'random';
@@ -0,0 +1,4 @@
+-- Drop reviewer exclusions
+
+DROP INDEX IF EXISTS idx_reviewer_exclusions_author_id;
+DROP TABLE IF EXISTS reviewer_exclusions CASCADE;
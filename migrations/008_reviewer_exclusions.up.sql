@@ -0,0 +1,13 @@
+-- Create reviewer_exclusions table (reviewer never reviews PRs of author)
+CREATE TABLE IF NOT EXISTS reviewer_exclusions (
+    reviewer_id VARCHAR(255) NOT NULL,
+    author_id VARCHAR(255) NOT NULL,
+    PRIMARY KEY (reviewer_id, author_id),
+    FOREIGN KEY (reviewer_id) REFERENCES users(user_id) ON DELETE CASCADE,
+    FOREIGN KEY (author_id) REFERENCES users(user_id) ON DELETE CASCADE,
+    CHECK (reviewer_id <> author_id)
+);
+
+-- Candidate queries - NOT EXISTS (... WHERE reviewer_id = u.user_id AND author_id = $1)
+-- are served by the primary key; this one serves lookups by author alone.
+CREATE INDEX IF NOT EXISTS idx_reviewer_exclusions_author_id ON reviewer_exclusions(author_id);
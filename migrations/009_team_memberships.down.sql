@@ -0,0 +1,5 @@
+-- Drop team memberships (users.team_name still holds the primary team)
+
+DROP INDEX IF EXISTS idx_team_memberships_team_name;
+DROP INDEX IF EXISTS idx_team_memberships_primary;
+DROP TABLE IF EXISTS team_memberships CASCADE;
@@ -0,0 +1,20 @@
+-- Create team_memberships table (a user may belong to several teams; users.team_name is the primary one)
+CREATE TABLE IF NOT EXISTS team_memberships (
+    user_id VARCHAR(255) NOT NULL,
+    team_name VARCHAR(255) NOT NULL,
+    is_primary BOOLEAN NOT NULL DEFAULT false,
+    PRIMARY KEY (user_id, team_name),
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+    FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE
+);
+
+-- At most one primary team per user
+CREATE UNIQUE INDEX IF NOT EXISTS idx_team_memberships_primary ON team_memberships(user_id) WHERE is_primary;
+
+-- Candidate and team queries - WHERE m.team_name = $1
+CREATE INDEX IF NOT EXISTS idx_team_memberships_team_name ON team_memberships(team_name);
+
+-- Backfill from the single-team schema
+INSERT INTO team_memberships (user_id, team_name, is_primary)
+SELECT user_id, team_name, true FROM users
+ON CONFLICT (user_id, team_name) DO NOTHING;
@@ -0,0 +1,4 @@
+-- Drop pull request description and external URL
+
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS external_url;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS description;
@@ -0,0 +1,3 @@
+-- Optional pull request description and link to the PR in the code host (NULL = not provided)
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS description TEXT NULL;
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS external_url TEXT NULL;
@@ -0,0 +1,22 @@
+-- Return to a single pull request id namespace.
+-- Fails if two repositories hold pull requests with the same id.
+
+ALTER TABLE assignment_history DROP CONSTRAINT IF EXISTS assignment_history_pr_fkey;
+ALTER TABLE pr_reviewers DROP CONSTRAINT IF EXISTS pr_reviewers_pr_fkey;
+ALTER TABLE pr_reviewers DROP CONSTRAINT IF EXISTS pr_reviewers_pr_user_key;
+ALTER TABLE pr_reviewers DROP CONSTRAINT IF EXISTS pr_reviewers_pull_request_id_user_id_key;
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_pkey CASCADE;
+DROP INDEX IF EXISTS idx_assignment_history_pr;
+
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_pkey PRIMARY KEY (pull_request_id);
+ALTER TABLE pr_reviewers ADD CONSTRAINT pr_reviewers_pull_request_id_user_id_key UNIQUE (pull_request_id, user_id);
+ALTER TABLE pr_reviewers ADD CONSTRAINT pr_reviewers_pull_request_id_fkey
+    FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE;
+ALTER TABLE assignment_history ADD CONSTRAINT assignment_history_pull_request_id_fkey
+    FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE;
+CREATE INDEX IF NOT EXISTS idx_pr_reviewers_pull_request_id ON pr_reviewers(pull_request_id);
+CREATE INDEX IF NOT EXISTS idx_assignment_history_pull_request_id ON assignment_history(pull_request_id);
+
+ALTER TABLE assignment_history DROP COLUMN IF EXISTS repository_name;
+ALTER TABLE pr_reviewers DROP COLUMN IF EXISTS repository_name;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS repository_name;
@@ -0,0 +1,23 @@
+-- Pull request ids are unique per repository: key pull requests by (repository_name, pull_request_id).
+-- Existing rows are backfilled with the default (empty) repository by the column default.
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS repository_name VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE pr_reviewers ADD COLUMN IF NOT EXISTS repository_name VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE assignment_history ADD COLUMN IF NOT EXISTS repository_name VARCHAR(255) NOT NULL DEFAULT '';
+
+-- Drop everything that references the single-column key
+ALTER TABLE pr_reviewers DROP CONSTRAINT IF EXISTS pr_reviewers_pull_request_id_fkey;
+ALTER TABLE pr_reviewers DROP CONSTRAINT IF EXISTS pr_reviewers_pull_request_id_user_id_key;
+ALTER TABLE assignment_history DROP CONSTRAINT IF EXISTS assignment_history_pull_request_id_fkey;
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_pkey;
+
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_pkey PRIMARY KEY (repository_name, pull_request_id);
+ALTER TABLE pr_reviewers ADD CONSTRAINT pr_reviewers_pr_user_key UNIQUE (repository_name, pull_request_id, user_id);
+ALTER TABLE pr_reviewers ADD CONSTRAINT pr_reviewers_pr_fkey
+    FOREIGN KEY (repository_name, pull_request_id) REFERENCES pull_requests(repository_name, pull_request_id) ON DELETE CASCADE;
+ALTER TABLE assignment_history ADD CONSTRAINT assignment_history_pr_fkey
+    FOREIGN KEY (repository_name, pull_request_id) REFERENCES pull_requests(repository_name, pull_request_id) ON DELETE CASCADE;
+
+-- pr.Get() and history.GetByPR() - WHERE repository_name = $1 AND pull_request_id = $2
+DROP INDEX IF EXISTS idx_pr_reviewers_pull_request_id;
+DROP INDEX IF EXISTS idx_assignment_history_pull_request_id;
+CREATE INDEX IF NOT EXISTS idx_assignment_history_pr ON assignment_history(repository_name, pull_request_id);
@@ -0,0 +1,4 @@
+-- Drop closed_at; closed pull requests fall back to OPEN
+
+UPDATE pull_requests SET status = 'OPEN' WHERE status = 'CLOSED';
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS closed_at;
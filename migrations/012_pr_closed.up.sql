@@ -0,0 +1,2 @@
+-- Pull requests closed without merge: status CLOSED and the time it was closed (NULL while not closed)
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS closed_at TIMESTAMP NULL;
@@ -0,0 +1,4 @@
+-- Drop merged_by
+
+DROP INDEX IF EXISTS idx_pull_requests_merged_by;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS merged_by;
@@ -0,0 +1,5 @@
+-- Who merged the pull request (NULL when not merged or merged without an actor)
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS merged_by VARCHAR(255) NULL REFERENCES users(user_id) ON DELETE SET NULL;
+
+-- stats.GetSummary() - merger_stats JOIN ON p.merged_by = u.user_id
+CREATE INDEX IF NOT EXISTS idx_pull_requests_merged_by ON pull_requests(merged_by);
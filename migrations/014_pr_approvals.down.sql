@@ -0,0 +1,4 @@
+-- Drop reviewer approvals and the per-team approval requirement
+
+ALTER TABLE teams DROP COLUMN IF EXISTS require_approvals;
+ALTER TABLE pr_reviewers DROP COLUMN IF EXISTS approved_at;
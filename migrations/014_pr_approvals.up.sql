@@ -0,0 +1,5 @@
+-- Reviewer approvals: when the reviewer approved the pull request (NULL while not approved)
+ALTER TABLE pr_reviewers ADD COLUMN IF NOT EXISTS approved_at TIMESTAMP NULL;
+
+-- Approvals a team's pull request needs before it can be merged (0 = no requirement)
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS require_approvals INTEGER NOT NULL DEFAULT 0 CHECK (require_approvals >= 0);
@@ -0,0 +1,3 @@
+-- Drop webhook subscriptions
+
+DROP TABLE IF EXISTS webhooks CASCADE;
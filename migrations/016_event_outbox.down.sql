@@ -0,0 +1,4 @@
+-- Drop the event outbox
+
+DROP INDEX IF EXISTS idx_event_outbox_pending;
+DROP TABLE IF EXISTS event_outbox CASCADE;
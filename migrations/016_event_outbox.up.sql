@@ -0,0 +1,18 @@
+-- Transactional outbox: events are written in the transaction of the change they describe
+-- and handed to publishers by a background dispatcher
+CREATE TABLE IF NOT EXISTS event_outbox (
+    event_id BIGSERIAL PRIMARY KEY,
+    event_type VARCHAR(64) NOT NULL,
+    repository_name VARCHAR(255) NOT NULL,
+    pull_request_id VARCHAR(255) NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NULL,
+    published_at TIMESTAMP NULL,
+    failed_at TIMESTAMP NULL
+);
+
+-- Dispatcher poll - WHERE published_at IS NULL AND failed_at IS NULL ORDER BY event_id
+CREATE INDEX IF NOT EXISTS idx_event_outbox_pending ON event_outbox(event_id)
+    WHERE published_at IS NULL AND failed_at IS NULL;
@@ -0,0 +1,3 @@
+-- Drop the per-team Slack incoming webhook
+
+ALTER TABLE teams DROP COLUMN IF EXISTS slack_webhook_url;
@@ -0,0 +1,2 @@
+-- Slack incoming webhook the team's notifications are posted to (NULL = notifications disabled)
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS slack_webhook_url TEXT NULL;
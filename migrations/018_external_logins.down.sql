@@ -0,0 +1,3 @@
+-- Drop the VCS login mapping
+
+DROP TABLE IF EXISTS external_logins;
@@ -0,0 +1,8 @@
+-- Maps logins of VCS providers (e.g. gitlab) to users for the VCS webhook integrations
+CREATE TABLE IF NOT EXISTS external_logins (
+    provider VARCHAR(32) NOT NULL,
+    external_login VARCHAR(255) NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    PRIMARY KEY (provider, external_login),
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+-- Drop the required reviewer flag
+
+ALTER TABLE pr_reviewers DROP COLUMN IF EXISTS required;
@@ -0,0 +1,2 @@
+-- Reviewers named as required when the PR was created; rebalancing never moves them
+ALTER TABLE pr_reviewers ADD COLUMN IF NOT EXISTS required BOOLEAN NOT NULL DEFAULT false;
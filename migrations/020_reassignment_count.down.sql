@@ -0,0 +1,3 @@
+-- Drop the reassignment counter
+
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS reassignment_count;
@@ -0,0 +1,2 @@
+-- Number of reviewer replacements made on the PR; manual reassigns are capped on it
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS reassignment_count INT NOT NULL DEFAULT 0;
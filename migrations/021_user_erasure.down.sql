@@ -0,0 +1,3 @@
+-- Drop the erasure mark
+
+ALTER TABLE users DROP COLUMN IF EXISTS erased_at;
@@ -0,0 +1,2 @@
+-- When the user's personal data was erased; erased users never review and are hidden from team listings
+ALTER TABLE users ADD COLUMN IF NOT EXISTS erased_at TIMESTAMP NULL;
@@ -0,0 +1,3 @@
+-- Drop the audit log
+
+DROP TABLE IF EXISTS audit_log;
@@ -0,0 +1,15 @@
+-- Audit log of administrative actions: who did what to which object, and in which request
+CREATE TABLE IF NOT EXISTS audit_log (
+    audit_id BIGSERIAL PRIMARY KEY,
+    actor VARCHAR(255) NOT NULL,
+    action VARCHAR(64) NOT NULL,
+    target VARCHAR(512) NOT NULL,
+    request_id VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- audit.List() - WHERE created_at >= $1 AND created_at <= $2
+CREATE INDEX IF NOT EXISTS idx_audit_log_created_at ON audit_log(created_at);
+
+-- audit.List() - WHERE actor = $3
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor ON audit_log(actor);
@@ -0,0 +1,76 @@
+-- Return to a single organization.
+-- Fails if two organizations hold teams, users or pull requests with the same id.
+
+ALTER TABLE webhooks DROP CONSTRAINT IF EXISTS webhooks_org_id_fkey;
+ALTER TABLE teams DROP CONSTRAINT IF EXISTS teams_org_id_fkey;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_team_name_fkey;
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_author_id_fkey;
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_team_name_fkey;
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_merged_by_fkey;
+ALTER TABLE pr_reviewers DROP CONSTRAINT IF EXISTS pr_reviewers_pr_fkey;
+ALTER TABLE pr_reviewers DROP CONSTRAINT IF EXISTS pr_reviewers_user_id_fkey;
+ALTER TABLE pr_reviewers DROP CONSTRAINT IF EXISTS pr_reviewers_pr_user_key;
+ALTER TABLE assignment_history DROP CONSTRAINT IF EXISTS assignment_history_pr_fkey;
+ALTER TABLE user_absences DROP CONSTRAINT IF EXISTS user_absences_user_id_fkey;
+ALTER TABLE reviewer_exclusions DROP CONSTRAINT IF EXISTS reviewer_exclusions_reviewer_id_fkey;
+ALTER TABLE reviewer_exclusions DROP CONSTRAINT IF EXISTS reviewer_exclusions_author_id_fkey;
+ALTER TABLE reviewer_exclusions DROP CONSTRAINT IF EXISTS reviewer_exclusions_pkey;
+ALTER TABLE team_memberships DROP CONSTRAINT IF EXISTS team_memberships_user_id_fkey;
+ALTER TABLE team_memberships DROP CONSTRAINT IF EXISTS team_memberships_team_name_fkey;
+ALTER TABLE team_memberships DROP CONSTRAINT IF EXISTS team_memberships_pkey;
+ALTER TABLE external_logins DROP CONSTRAINT IF EXISTS external_logins_user_id_fkey;
+ALTER TABLE external_logins DROP CONSTRAINT IF EXISTS external_logins_pkey;
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_pkey;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_pkey;
+ALTER TABLE teams DROP CONSTRAINT IF EXISTS teams_pkey;
+DROP INDEX IF EXISTS idx_team_memberships_primary;
+DROP INDEX IF EXISTS idx_webhooks_org_id;
+
+ALTER TABLE teams ADD CONSTRAINT teams_pkey PRIMARY KEY (team_name);
+ALTER TABLE users ADD CONSTRAINT users_pkey PRIMARY KEY (user_id);
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_pkey PRIMARY KEY (repository_name, pull_request_id);
+ALTER TABLE pr_reviewers ADD CONSTRAINT pr_reviewers_pr_user_key UNIQUE (repository_name, pull_request_id, user_id);
+ALTER TABLE reviewer_exclusions ADD CONSTRAINT reviewer_exclusions_pkey PRIMARY KEY (reviewer_id, author_id);
+ALTER TABLE team_memberships ADD CONSTRAINT team_memberships_pkey PRIMARY KEY (user_id, team_name);
+ALTER TABLE external_logins ADD CONSTRAINT external_logins_pkey PRIMARY KEY (provider, external_login);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_team_memberships_primary ON team_memberships(user_id) WHERE is_primary;
+
+ALTER TABLE users ADD CONSTRAINT users_team_name_fkey
+    FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE;
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_author_id_fkey
+    FOREIGN KEY (author_id) REFERENCES users(user_id) ON DELETE CASCADE;
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_team_name_fkey
+    FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE;
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_merged_by_fkey
+    FOREIGN KEY (merged_by) REFERENCES users(user_id) ON DELETE SET NULL;
+ALTER TABLE pr_reviewers ADD CONSTRAINT pr_reviewers_pr_fkey
+    FOREIGN KEY (repository_name, pull_request_id) REFERENCES pull_requests(repository_name, pull_request_id) ON DELETE CASCADE;
+ALTER TABLE pr_reviewers ADD CONSTRAINT pr_reviewers_user_id_fkey
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE;
+ALTER TABLE assignment_history ADD CONSTRAINT assignment_history_pr_fkey
+    FOREIGN KEY (repository_name, pull_request_id) REFERENCES pull_requests(repository_name, pull_request_id) ON DELETE CASCADE;
+ALTER TABLE user_absences ADD CONSTRAINT user_absences_user_id_fkey
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE;
+ALTER TABLE reviewer_exclusions ADD CONSTRAINT reviewer_exclusions_reviewer_id_fkey
+    FOREIGN KEY (reviewer_id) REFERENCES users(user_id) ON DELETE CASCADE;
+ALTER TABLE reviewer_exclusions ADD CONSTRAINT reviewer_exclusions_author_id_fkey
+    FOREIGN KEY (author_id) REFERENCES users(user_id) ON DELETE CASCADE;
+ALTER TABLE team_memberships ADD CONSTRAINT team_memberships_user_id_fkey
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE;
+ALTER TABLE team_memberships ADD CONSTRAINT team_memberships_team_name_fkey
+    FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE;
+ALTER TABLE external_logins ADD CONSTRAINT external_logins_user_id_fkey
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE;
+
+ALTER TABLE audit_log DROP COLUMN IF EXISTS org_id;
+ALTER TABLE webhooks DROP COLUMN IF EXISTS org_id;
+ALTER TABLE external_logins DROP COLUMN IF EXISTS org_id;
+ALTER TABLE team_memberships DROP COLUMN IF EXISTS org_id;
+ALTER TABLE reviewer_exclusions DROP COLUMN IF EXISTS org_id;
+ALTER TABLE user_absences DROP COLUMN IF EXISTS org_id;
+ALTER TABLE assignment_history DROP COLUMN IF EXISTS org_id;
+ALTER TABLE pr_reviewers DROP COLUMN IF EXISTS org_id;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS org_id;
+ALTER TABLE users DROP COLUMN IF EXISTS org_id;
+ALTER TABLE teams DROP COLUMN IF EXISTS org_id;
+DROP TABLE IF EXISTS organizations;
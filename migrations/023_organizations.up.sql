@@ -0,0 +1,88 @@
+-- Organizations: teams, users and pull requests belong to an organization, and their ids are unique
+-- within it. Existing rows are moved to the 'default' organization by the column defaults.
+CREATE TABLE IF NOT EXISTS organizations (
+    org_id VARCHAR(64) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO organizations (org_id, name) VALUES ('default', 'Default organization')
+ON CONFLICT (org_id) DO NOTHING;
+
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS org_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS org_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS org_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE pr_reviewers ADD COLUMN IF NOT EXISTS org_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE assignment_history ADD COLUMN IF NOT EXISTS org_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE user_absences ADD COLUMN IF NOT EXISTS org_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE reviewer_exclusions ADD COLUMN IF NOT EXISTS org_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE team_memberships ADD COLUMN IF NOT EXISTS org_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE external_logins ADD COLUMN IF NOT EXISTS org_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE webhooks ADD COLUMN IF NOT EXISTS org_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE audit_log ADD COLUMN IF NOT EXISTS org_id VARCHAR(64) NOT NULL DEFAULT 'default';
+
+-- Drop everything that references the organization-less keys
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_team_name_fkey;
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_author_id_fkey;
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_team_name_fkey;
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_merged_by_fkey;
+ALTER TABLE pr_reviewers DROP CONSTRAINT IF EXISTS pr_reviewers_pr_fkey;
+ALTER TABLE pr_reviewers DROP CONSTRAINT IF EXISTS pr_reviewers_user_id_fkey;
+ALTER TABLE pr_reviewers DROP CONSTRAINT IF EXISTS pr_reviewers_pr_user_key;
+ALTER TABLE assignment_history DROP CONSTRAINT IF EXISTS assignment_history_pr_fkey;
+ALTER TABLE user_absences DROP CONSTRAINT IF EXISTS user_absences_user_id_fkey;
+ALTER TABLE reviewer_exclusions DROP CONSTRAINT IF EXISTS reviewer_exclusions_reviewer_id_fkey;
+ALTER TABLE reviewer_exclusions DROP CONSTRAINT IF EXISTS reviewer_exclusions_author_id_fkey;
+ALTER TABLE reviewer_exclusions DROP CONSTRAINT IF EXISTS reviewer_exclusions_pkey;
+ALTER TABLE team_memberships DROP CONSTRAINT IF EXISTS team_memberships_user_id_fkey;
+ALTER TABLE team_memberships DROP CONSTRAINT IF EXISTS team_memberships_team_name_fkey;
+ALTER TABLE team_memberships DROP CONSTRAINT IF EXISTS team_memberships_pkey;
+ALTER TABLE external_logins DROP CONSTRAINT IF EXISTS external_logins_user_id_fkey;
+ALTER TABLE external_logins DROP CONSTRAINT IF EXISTS external_logins_pkey;
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_pkey;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_pkey;
+ALTER TABLE teams DROP CONSTRAINT IF EXISTS teams_pkey;
+DROP INDEX IF EXISTS idx_team_memberships_primary;
+
+ALTER TABLE teams ADD CONSTRAINT teams_pkey PRIMARY KEY (org_id, team_name);
+ALTER TABLE users ADD CONSTRAINT users_pkey PRIMARY KEY (org_id, user_id);
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_pkey PRIMARY KEY (org_id, repository_name, pull_request_id);
+ALTER TABLE pr_reviewers ADD CONSTRAINT pr_reviewers_pr_user_key UNIQUE (org_id, repository_name, pull_request_id, user_id);
+ALTER TABLE reviewer_exclusions ADD CONSTRAINT reviewer_exclusions_pkey PRIMARY KEY (org_id, reviewer_id, author_id);
+ALTER TABLE team_memberships ADD CONSTRAINT team_memberships_pkey PRIMARY KEY (org_id, user_id, team_name);
+ALTER TABLE external_logins ADD CONSTRAINT external_logins_pkey PRIMARY KEY (org_id, provider, external_login);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_team_memberships_primary ON team_memberships(org_id, user_id) WHERE is_primary;
+
+ALTER TABLE teams ADD CONSTRAINT teams_org_id_fkey
+    FOREIGN KEY (org_id) REFERENCES organizations(org_id);
+ALTER TABLE users ADD CONSTRAINT users_team_name_fkey
+    FOREIGN KEY (org_id, team_name) REFERENCES teams(org_id, team_name) ON DELETE CASCADE;
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_author_id_fkey
+    FOREIGN KEY (org_id, author_id) REFERENCES users(org_id, user_id) ON DELETE CASCADE;
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_team_name_fkey
+    FOREIGN KEY (org_id, team_name) REFERENCES teams(org_id, team_name) ON DELETE CASCADE;
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_merged_by_fkey
+    FOREIGN KEY (org_id, merged_by) REFERENCES users(org_id, user_id) ON DELETE SET NULL (merged_by);
+ALTER TABLE pr_reviewers ADD CONSTRAINT pr_reviewers_pr_fkey
+    FOREIGN KEY (org_id, repository_name, pull_request_id) REFERENCES pull_requests(org_id, repository_name, pull_request_id) ON DELETE CASCADE;
+ALTER TABLE pr_reviewers ADD CONSTRAINT pr_reviewers_user_id_fkey
+    FOREIGN KEY (org_id, user_id) REFERENCES users(org_id, user_id) ON DELETE CASCADE;
+ALTER TABLE assignment_history ADD CONSTRAINT assignment_history_pr_fkey
+    FOREIGN KEY (org_id, repository_name, pull_request_id) REFERENCES pull_requests(org_id, repository_name, pull_request_id) ON DELETE CASCADE;
+ALTER TABLE user_absences ADD CONSTRAINT user_absences_user_id_fkey
+    FOREIGN KEY (org_id, user_id) REFERENCES users(org_id, user_id) ON DELETE CASCADE;
+ALTER TABLE reviewer_exclusions ADD CONSTRAINT reviewer_exclusions_reviewer_id_fkey
+    FOREIGN KEY (org_id, reviewer_id) REFERENCES users(org_id, user_id) ON DELETE CASCADE;
+ALTER TABLE reviewer_exclusions ADD CONSTRAINT reviewer_exclusions_author_id_fkey
+    FOREIGN KEY (org_id, author_id) REFERENCES users(org_id, user_id) ON DELETE CASCADE;
+ALTER TABLE team_memberships ADD CONSTRAINT team_memberships_user_id_fkey
+    FOREIGN KEY (org_id, user_id) REFERENCES users(org_id, user_id) ON DELETE CASCADE;
+ALTER TABLE team_memberships ADD CONSTRAINT team_memberships_team_name_fkey
+    FOREIGN KEY (org_id, team_name) REFERENCES teams(org_id, team_name) ON DELETE CASCADE;
+ALTER TABLE external_logins ADD CONSTRAINT external_logins_user_id_fkey
+    FOREIGN KEY (org_id, user_id) REFERENCES users(org_id, user_id) ON DELETE CASCADE;
+ALTER TABLE webhooks ADD CONSTRAINT webhooks_org_id_fkey
+    FOREIGN KEY (org_id) REFERENCES organizations(org_id) ON DELETE CASCADE;
+
+-- webhook.List() - WHERE org_id = $1
+CREATE INDEX IF NOT EXISTS idx_webhooks_org_id ON webhooks(org_id);
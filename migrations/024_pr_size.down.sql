@@ -0,0 +1,4 @@
+-- Drop pull request size hint
+
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS lines_changed;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS size;
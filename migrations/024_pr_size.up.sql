@@ -0,0 +1,3 @@
+-- Optional size hint of the pull request (NULL = not provided); weights the reviewers' load
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS size VARCHAR(2) NULL CHECK (size IN ('XS', 'S', 'M', 'L', 'XL'));
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS lines_changed INT NULL CHECK (lines_changed >= 0);
@@ -0,0 +1,4 @@
+-- Drop expertise tags
+
+DROP TABLE IF EXISTS pr_tags;
+DROP TABLE IF EXISTS user_tags;
@@ -0,0 +1,19 @@
+-- Expertise tags of users; reviewers sharing a tag with a PR are preferred for it
+CREATE TABLE IF NOT EXISTS user_tags (
+    org_id VARCHAR(64) NOT NULL DEFAULT 'default',
+    user_id VARCHAR(255) NOT NULL,
+    tag VARCHAR(64) NOT NULL,
+    PRIMARY KEY (org_id, user_id, tag),
+    FOREIGN KEY (org_id, user_id) REFERENCES users(org_id, user_id) ON DELETE CASCADE
+);
+
+-- Expertise tags of pull requests, given on creation
+CREATE TABLE IF NOT EXISTS pr_tags (
+    org_id VARCHAR(64) NOT NULL DEFAULT 'default',
+    repository_name VARCHAR(255) NOT NULL,
+    pull_request_id VARCHAR(255) NOT NULL,
+    tag VARCHAR(64) NOT NULL,
+    PRIMARY KEY (org_id, repository_name, pull_request_id, tag),
+    FOREIGN KEY (org_id, repository_name, pull_request_id)
+        REFERENCES pull_requests(org_id, repository_name, pull_request_id) ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+-- Drop code ownership rules
+
+DROP TABLE IF EXISTS ownership_rules;
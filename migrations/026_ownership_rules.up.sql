@@ -0,0 +1,15 @@
+-- Code ownership rules of teams: a PR of the team changing a path under path_prefix requires a review
+-- by the owner, either a user or one member of a team. The empty path_prefix covers the whole repository.
+CREATE TABLE IF NOT EXISTS ownership_rules (
+    org_id VARCHAR(64) NOT NULL DEFAULT 'default',
+    team_name VARCHAR(255) NOT NULL,
+    path_prefix VARCHAR(1024) NOT NULL,
+    owner_user_id VARCHAR(255) NULL,
+    owner_team_name VARCHAR(255) NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (org_id, team_name, path_prefix),
+    CHECK ((owner_user_id IS NULL) <> (owner_team_name IS NULL)),
+    FOREIGN KEY (org_id, team_name) REFERENCES teams(org_id, team_name) ON DELETE CASCADE,
+    FOREIGN KEY (org_id, owner_user_id) REFERENCES users(org_id, user_id) ON DELETE CASCADE,
+    FOREIGN KEY (org_id, owner_team_name) REFERENCES teams(org_id, team_name) ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+-- Drop the per-team review SLA
+
+ALTER TABLE teams DROP COLUMN IF EXISTS review_sla_hours;
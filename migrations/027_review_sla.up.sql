@@ -0,0 +1,2 @@
+-- Hours within which a review of the team's pull requests should be done (0 = no SLA)
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS review_sla_hours INTEGER NOT NULL DEFAULT 0 CHECK (review_sla_hours >= 0);
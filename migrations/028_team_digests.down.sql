@@ -0,0 +1,3 @@
+-- Drop team digest schedules
+
+DROP TABLE IF EXISTS team_digests;
@@ -0,0 +1,11 @@
+-- Daily digests of pending reviews, sent to the team's notification channels at hour:00 in timezone.
+-- last_sent_at is when the digest was last sent, or configured if it has not been sent since.
+CREATE TABLE IF NOT EXISTS team_digests (
+    org_id VARCHAR(64) NOT NULL DEFAULT 'default',
+    team_name VARCHAR(255) NOT NULL,
+    hour INTEGER NOT NULL CHECK (hour BETWEEN 0 AND 23),
+    timezone VARCHAR(64) NOT NULL,
+    last_sent_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (org_id, team_name),
+    FOREIGN KEY (org_id, team_name) REFERENCES teams(org_id, team_name) ON DELETE CASCADE
+);
@@ -0,0 +1,4 @@
+-- Drop the per-team reviewer count and fallback team
+
+ALTER TABLE teams DROP COLUMN IF EXISTS fallback_team_name;
+ALTER TABLE teams DROP COLUMN IF EXISTS reviewer_count;
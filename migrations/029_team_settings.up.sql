@@ -0,0 +1,4 @@
+-- Reviewers assigned to a new pull request of the team (NULL = service default)
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS reviewer_count INTEGER NULL CHECK (reviewer_count BETWEEN 1 AND 5);
+-- Team whose members fill the reviewer slots the team's own members cannot (NULL = none)
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS fallback_team_name VARCHAR(300) NULL;
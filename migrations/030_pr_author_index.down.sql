@@ -0,0 +1,3 @@
+-- Drop the authored pull requests index
+
+DROP INDEX IF EXISTS idx_pull_requests_author_created_at;
@@ -0,0 +1,2 @@
+-- pr.GetByAuthor() - WHERE author_id = $1 ORDER BY created_at DESC
+CREATE INDEX IF NOT EXISTS idx_pull_requests_author_created_at ON pull_requests(org_id, author_id, created_at DESC);
@@ -0,0 +1,20 @@
+-- Store timestamps as local wall-clock TIMESTAMP values again (in the session TimeZone)
+
+ALTER TABLE team_digests ALTER COLUMN last_sent_at TYPE TIMESTAMP;
+ALTER TABLE ownership_rules ALTER COLUMN created_at TYPE TIMESTAMP;
+ALTER TABLE organizations ALTER COLUMN created_at TYPE TIMESTAMP;
+ALTER TABLE audit_log ALTER COLUMN created_at TYPE TIMESTAMP;
+ALTER TABLE users ALTER COLUMN erased_at TYPE TIMESTAMP;
+ALTER TABLE event_outbox
+    ALTER COLUMN failed_at TYPE TIMESTAMP,
+    ALTER COLUMN published_at TYPE TIMESTAMP,
+    ALTER COLUMN created_at TYPE TIMESTAMP;
+ALTER TABLE webhooks ALTER COLUMN created_at TYPE TIMESTAMP;
+ALTER TABLE assignment_history ALTER COLUMN created_at TYPE TIMESTAMP;
+ALTER TABLE pr_reviewers
+    ALTER COLUMN approved_at TYPE TIMESTAMP,
+    ALTER COLUMN assigned_at TYPE TIMESTAMP;
+ALTER TABLE pull_requests
+    ALTER COLUMN closed_at TYPE TIMESTAMP,
+    ALTER COLUMN merged_at TYPE TIMESTAMP,
+    ALTER COLUMN created_at TYPE TIMESTAMP;
@@ -0,0 +1,21 @@
+-- Store timestamps as TIMESTAMPTZ. The service used to write local wall-clock values into TIMESTAMP
+-- columns; they are converted using the session TimeZone, so run this migration in the time zone
+-- the service ran in (e.g. PGTZ=Europe/Moscow).
+ALTER TABLE pull_requests
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN merged_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN closed_at TYPE TIMESTAMPTZ;
+ALTER TABLE pr_reviewers
+    ALTER COLUMN assigned_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN approved_at TYPE TIMESTAMPTZ;
+ALTER TABLE assignment_history ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+ALTER TABLE webhooks ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+ALTER TABLE event_outbox
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN published_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN failed_at TYPE TIMESTAMPTZ;
+ALTER TABLE users ALTER COLUMN erased_at TYPE TIMESTAMPTZ;
+ALTER TABLE audit_log ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+ALTER TABLE organizations ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+ALTER TABLE ownership_rules ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+ALTER TABLE team_digests ALTER COLUMN last_sent_at TYPE TIMESTAMPTZ;
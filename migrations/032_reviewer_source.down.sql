@@ -0,0 +1,12 @@
+-- Restore the required reviewer flag from the reviewer source.
+-- The update is guarded so the file also runs on a database source was never added to.
+
+ALTER TABLE pr_reviewers ADD COLUMN IF NOT EXISTS required BOOLEAN NOT NULL DEFAULT false;
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.columns
+               WHERE table_schema = current_schema() AND table_name = 'pr_reviewers' AND column_name = 'source') THEN
+        UPDATE pr_reviewers SET required = true WHERE source = 'required';
+    END IF;
+END $$;
+ALTER TABLE pr_reviewers DROP COLUMN IF EXISTS source;
@@ -0,0 +1,6 @@
+-- Why each reviewer was assigned: auto, required, fallback, rebalance or escalation.
+-- Replaces the required flag; rows written before are either required or auto.
+ALTER TABLE pr_reviewers ADD COLUMN IF NOT EXISTS source VARCHAR(16) NOT NULL DEFAULT 'auto'
+    CHECK (source IN ('auto', 'required', 'fallback', 'rebalance', 'escalation'));
+UPDATE pr_reviewers SET source = 'required' WHERE required;
+ALTER TABLE pr_reviewers DROP COLUMN IF EXISTS required;
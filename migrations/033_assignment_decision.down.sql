@@ -0,0 +1,3 @@
+-- Drop the assignment decision snapshots
+
+ALTER TABLE assignment_history DROP COLUMN IF EXISTS decision;
@@ -0,0 +1,2 @@
+-- Snapshot of how the reviewer of an assignment event was picked: strategy, candidates, exclusions and choice.
+ALTER TABLE assignment_history ADD COLUMN IF NOT EXISTS decision JSONB;
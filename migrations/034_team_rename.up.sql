@@ -0,0 +1,24 @@
+-- Foreign keys to teams follow a renamed team to its new name (POST /team/rename).
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_team_name_fkey;
+ALTER TABLE users ADD CONSTRAINT users_team_name_fkey
+    FOREIGN KEY (org_id, team_name) REFERENCES teams(org_id, team_name) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_team_name_fkey;
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_team_name_fkey
+    FOREIGN KEY (org_id, team_name) REFERENCES teams(org_id, team_name) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE team_memberships DROP CONSTRAINT IF EXISTS team_memberships_team_name_fkey;
+ALTER TABLE team_memberships ADD CONSTRAINT team_memberships_team_name_fkey
+    FOREIGN KEY (org_id, team_name) REFERENCES teams(org_id, team_name) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE ownership_rules DROP CONSTRAINT IF EXISTS ownership_rules_org_id_team_name_fkey;
+ALTER TABLE ownership_rules ADD CONSTRAINT ownership_rules_org_id_team_name_fkey
+    FOREIGN KEY (org_id, team_name) REFERENCES teams(org_id, team_name) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE ownership_rules DROP CONSTRAINT IF EXISTS ownership_rules_org_id_owner_team_name_fkey;
+ALTER TABLE ownership_rules ADD CONSTRAINT ownership_rules_org_id_owner_team_name_fkey
+    FOREIGN KEY (org_id, owner_team_name) REFERENCES teams(org_id, team_name) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE team_digests DROP CONSTRAINT IF EXISTS team_digests_org_id_team_name_fkey;
+ALTER TABLE team_digests ADD CONSTRAINT team_digests_org_id_team_name_fkey
+    FOREIGN KEY (org_id, team_name) REFERENCES teams(org_id, team_name) ON UPDATE CASCADE ON DELETE CASCADE;
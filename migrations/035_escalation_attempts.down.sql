@@ -0,0 +1,3 @@
+-- Drop the escalation attempt times
+
+ALTER TABLE pr_reviewers DROP COLUMN IF EXISTS escalation_attempted_at;
@@ -0,0 +1,3 @@
+-- When the escalation worker last failed to find a replacement for an overdue assignment.
+-- Attempted assignments are escalated after untried ones so that they cannot fill every batch.
+ALTER TABLE pr_reviewers ADD COLUMN IF NOT EXISTS escalation_attempted_at TIMESTAMPTZ;
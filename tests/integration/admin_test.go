@@ -0,0 +1,197 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/admin"
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/history"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/stats"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestAdmin_Seed(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	svc := admin.Services{
+		Teams: service.NewTeamService(db, prService),
+		PRs:   prService,
+		Stats: service.NewStatsService(db, service.NewSystemClock()),
+	}
+	opts := admin.SeedOptions{Prefix: "seed", Teams: 2, Users: 3, PRs: 4}
+
+	t.Run("dry run changes nothing", func(t *testing.T) {
+		dryRun := opts
+		dryRun.DryRun = true
+		var out bytes.Buffer
+		result, err := admin.Seed(t.Context(), svc, dryRun, &out)
+		require.NoError(t, err)
+		assert.Equal(t, admin.SeedResult{Teams: 2, Users: 6, PRs: 8}, result)
+		assert.Contains(t, out.String(), "would create team seed-team-1")
+
+		exists, err := team.Exists(db, "seed-team-1")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("creates teams, users and pull requests", func(t *testing.T) {
+		result, err := admin.Seed(t.Context(), svc, opts, &bytes.Buffer{})
+		require.NoError(t, err)
+		assert.Equal(t, admin.SeedResult{Teams: 2, Users: 6, PRs: 8}, result)
+
+		statistics, err := svc.Stats.GetStatistics(t.Context(), stats.Period{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), statistics.Overall.TotalTeams)
+		assert.Equal(t, int64(6), statistics.Overall.TotalUsers)
+		assert.Equal(t, int64(8), statistics.Overall.TotalPRs)
+
+		seeded, err := pr.Get(db, domain.PRKey{RepositoryName: "seed-repo", PullRequestID: "seed-t2-pr4"})
+		require.NoError(t, err)
+		assert.Equal(t, "seed-t2-u1", seeded.AuthorID)
+		assert.Len(t, seeded.AssignedReviewersIDs, 2)
+	})
+
+	t.Run("seeding again creates nothing", func(t *testing.T) {
+		result, err := admin.Seed(t.Context(), svc, opts, &bytes.Buffer{})
+		require.NoError(t, err)
+		assert.Equal(t, admin.SeedResult{}, result)
+	})
+}
+
+func TestAdmin_Rebalance(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	require.NoError(t, team.Create(db, "team_rb"))
+	for _, id := range []string{"author_rb", "r1_rb", "r2_rb", "r3_rb", "r4_rb"} {
+		require.NoError(t, user.Create(db, &domain.User{UserID: id, Username: id, TeamName: "team_rb", IsActive: true}))
+	}
+	// r1_rb reviews all seven pull requests, r2_rb three of them, the others none.
+	for i := 1; i <= 7; i++ {
+		key := domain.PRKey{RepositoryName: "repo_rb", PullRequestID: fmt.Sprintf("pr%d", i)}
+		require.NoError(t, pr.Create(db, &domain.PullRequest{
+			RepositoryName:  key.RepositoryName,
+			PullRequestID:   key.PullRequestID,
+			PullRequestName: key.PullRequestID,
+			AuthorID:        "author_rb",
+			TeamName:        "team_rb",
+			Status:          domain.StatusOpen,
+		}))
+		require.NoError(t, pr.InsertReviewer(db, key, "r1_rb", time.Now().UTC()))
+		if i <= 3 {
+			require.NoError(t, pr.InsertReviewer(db, key, "r2_rb", time.Now().UTC()))
+		}
+	}
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	svc := admin.Services{PRs: prService}
+
+	loads := func() map[string]int {
+		t.Helper()
+		prs, err := pr.GetOpenByTeam(db, "team_rb")
+		require.NoError(t, err)
+		counts := map[string]int{"r1_rb": 0, "r2_rb": 0, "r3_rb": 0, "r4_rb": 0}
+		for _, p := range prs {
+			assert.NotContains(t, p.AssignedReviewersIDs, p.AuthorID)
+			for _, r := range p.AssignedReviewersIDs {
+				counts[r]++
+			}
+		}
+		return counts
+	}
+
+	t.Run("dry run reports moves without applying them", func(t *testing.T) {
+		var out bytes.Buffer
+		moves, err := admin.Rebalance(t.Context(), svc, "team_rb", service.RebalanceOptions{DryRun: true}, &out)
+		require.NoError(t, err)
+		assert.NotEmpty(t, moves)
+		assert.Contains(t, out.String(), "dry run: would move")
+		assert.Equal(t, map[string]int{"r1_rb": 7, "r2_rb": 3, "r3_rb": 0, "r4_rb": 0}, loads())
+	})
+
+	t.Run("evens out open assignments", func(t *testing.T) {
+		var out bytes.Buffer
+		moves, err := admin.Rebalance(t.Context(), svc, "team_rb", service.RebalanceOptions{}, &out)
+		require.NoError(t, err)
+		assert.Len(t, moves, 4)
+
+		after := loads()
+		minLoad, maxLoad := 10, 0
+		for _, l := range after {
+			minLoad, maxLoad = min(minLoad, l), max(maxLoad, l)
+		}
+		assert.LessOrEqual(t, maxLoad-minLoad, 1, "loads after rebalance: %v", after)
+
+		events, err := history.GetByPR(db, moves[0].PR)
+		require.NoError(t, err)
+		require.NotEmpty(t, events)
+		assert.Equal(t, domain.ActionRebalance, events[len(events)-1].Action)
+		assert.Equal(t, moves[0].From, events[len(events)-1].OldUserID)
+	})
+
+	t.Run("balanced team is left alone", func(t *testing.T) {
+		var out bytes.Buffer
+		moves, err := admin.Rebalance(t.Context(), svc, "team_rb", service.RebalanceOptions{}, &out)
+		require.NoError(t, err)
+		assert.Empty(t, moves)
+		assert.Contains(t, out.String(), "already balanced")
+	})
+
+	t.Run("unknown team", func(t *testing.T) {
+		_, err := admin.Rebalance(t.Context(), svc, "ghost_team", service.RebalanceOptions{}, &bytes.Buffer{})
+		assert.ErrorIs(t, err, service.ErrTeamNotFound)
+	})
+}
+
+func TestAdmin_Stats(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	svc := admin.Services{
+		Teams: service.NewTeamService(db, prService),
+		PRs:   prService,
+		Stats: service.NewStatsService(db, service.NewSystemClock()),
+	}
+	_, err = admin.Seed(t.Context(), svc, admin.SeedOptions{Prefix: "st", Teams: 1, Users: 3, PRs: 2}, &bytes.Buffer{})
+	require.NoError(t, err)
+
+	t.Run("json matches the api response", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, admin.Stats(t.Context(), svc, admin.FormatJSON, &out))
+
+		var response handler.StatisticsResponse
+		require.NoError(t, json.Unmarshal(out.Bytes(), &response))
+		assert.Equal(t, int64(2), response.Overall.TotalPRs)
+		assert.Equal(t, int64(4), response.Overall.TotalAssignments)
+		require.Len(t, response.Distribution.Teams, 1)
+		assert.Equal(t, "st-team-1", response.Distribution.Teams[0].TeamName)
+	})
+
+	t.Run("table", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, admin.Stats(t.Context(), svc, admin.FormatTable, &out))
+		assert.Contains(t, out.String(), "REVIEWER")
+		assert.Contains(t, out.String(), "st-team-1")
+	})
+}
@@ -0,0 +1,82 @@
+package integration
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/history"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestPRService_AssignmentDecisions(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	require.NoError(t, team.Create(db, "team_dec"))
+	for _, id := range []string{"author_dec", "required_dec", "r1_dec", "r2_dec"} {
+		require.NoError(t, user.Create(db, &domain.User{UserID: id, Username: id, TeamName: "team_dec", IsActive: true}))
+	}
+
+	recorder := &tests.LogRecorder{}
+	prService := service.NewPRService(db, service.NewReviewerAssigner().WithDecisionLogger(slog.New(recorder)))
+	key := domain.PRKey{RepositoryName: "backend", PullRequestID: "pr_dec"}
+
+	created, err := prService.CreatePR(t.Context(), key, "Decide", "author_dec", []string{"required_dec"}, domain.PRDetails{})
+	require.NoError(t, err)
+	require.Len(t, created.AssignedReviewersIDs, 2)
+	picked := created.AssignedReviewersIDs[1]
+
+	records := recorder.Attrs("reviewer assignment decision")
+	require.Len(t, records, 1)
+	assert.Equal(t, "CREATE", records[0]["action"])
+	assert.Equal(t, domain.DefaultOrgID, records[0]["org_id"])
+	assert.Equal(t, "backend", records[0]["repository_name"])
+	assert.Equal(t, "pr_dec", records[0]["pull_request_id"])
+	assert.Equal(t, "random", records[0]["strategy"])
+	assert.ElementsMatch(t, []string{"r1_dec", "r2_dec"}, candidateIDs(records[0]["candidates"]))
+	assert.Equal(t, []domain.DecisionExclusion{{UserID: "required_dec", Reason: domain.ExcludedAssigned}}, records[0]["excluded"])
+	assert.Equal(t, []string{picked}, records[0]["selected"])
+
+	_, replacedBy, err := prService.ReassignPR(t.Context(), key, picked, service.ReassignOptions{})
+	require.NoError(t, err)
+
+	records = recorder.Attrs("reviewer assignment decision")
+	require.Len(t, records, 2)
+	assert.Equal(t, "REASSIGN", records[1]["action"])
+	assert.Equal(t, []string{replacedBy}, records[1]["selected"])
+
+	t.Run("the decision is stored with the history event", func(t *testing.T) {
+		events, err := history.GetByPR(db, key)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		decision := events[0].Decision
+		require.NotNil(t, decision)
+		assert.Equal(t, "random", decision.Strategy)
+		assert.Equal(t, []domain.DecisionCandidate{{UserID: replacedBy, Weight: 1}}, decision.Candidates)
+		assert.ElementsMatch(t, []domain.DecisionExclusion{
+			{UserID: "author_dec", Reason: domain.ExcludedAuthor},
+			{UserID: "required_dec", Reason: domain.ExcludedAssigned},
+			{UserID: picked, Reason: domain.ExcludedAssigned},
+		}, decision.Excluded)
+		assert.Equal(t, []string{replacedBy}, decision.Selected)
+	})
+}
+
+// candidateIDs returns the user IDs of the logged decision candidates.
+func candidateIDs(value any) []string {
+	candidates, _ := value.([]domain.DecisionCandidate)
+	ids := make([]string, len(candidates))
+	for i, c := range candidates {
+		ids[i] = c.UserID
+	}
+	return ids
+}
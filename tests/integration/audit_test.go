@@ -0,0 +1,115 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/middleware"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/router"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestAudit_DeactivateTeamRecordsOneEntry(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	require.NoError(t, team.Create(db, "team_audit"))
+	require.NoError(t, user.Create(db, &domain.User{UserID: "member_audit", Username: "Member", TeamName: "team_audit", IsActive: true}))
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	r, err := router.SetupRoutes(router.Options{Mode: gin.TestMode, AdminAPIKeys: []string{"audit-secret"}},
+		handler.NewTeamHandler(service.NewTeamService(db, prService)), nil, nil, nil, nil, nil,
+		handler.NewAuditHandler(service.NewAuditService(db)), nil)
+	require.NoError(t, err)
+
+	serve := func(method, target, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, router.APIPrefix+target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(middleware.APIKeyHeader, "audit-secret")
+		req.Header.Set(middleware.RequestIDHeader, "req-audit-1")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	before := time.Now().Add(-time.Minute)
+	w := serve(http.MethodPost, "/team/deactivate", `{"team_name":"team_audit"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// A failed deactivation is rolled back together with its audit entry.
+	w = serve(http.MethodPost, "/team/deactivate", `{"team_name":"ghost_audit"}`)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	w = serve(http.MethodGet, "/admin/audit", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var response handler.AuditLogResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+	require.Len(t, response.Entries, 1)
+	entry := response.Entries[0]
+	assert.Positive(t, entry.ID)
+	assert.Equal(t, middleware.APIKeyActor("audit-secret"), entry.Actor)
+	assert.NotContains(t, entry.Actor, "audit-secret")
+	assert.Equal(t, string(domain.AuditTeamDeactivate), entry.Action)
+	assert.Equal(t, "team:team_audit", entry.Target)
+	assert.Equal(t, "req-audit-1", entry.RequestID)
+	createdAt, err := time.Parse(time.RFC3339, entry.CreatedAt)
+	require.NoError(t, err)
+	assert.True(t, createdAt.After(before), createdAt)
+	assert.Zero(t, response.NextBeforeID)
+
+	t.Run("filters", func(t *testing.T) {
+		w := serve(http.MethodGet, "/admin/audit?actor=anonymous", "")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"items":[],"has_more":false,"entries":[]}`, w.Body.String())
+
+		w = serve(http.MethodGet, "/admin/audit?to="+before.UTC().Format(time.RFC3339), "")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"items":[],"has_more":false,"entries":[]}`, w.Body.String())
+	})
+
+	t.Run("pages", func(t *testing.T) {
+		w := serve(http.MethodPost, "/team/deactivate", `{"team_name":"team_audit"}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = serve(http.MethodGet, "/admin/audit?limit=1", "")
+		require.Equal(t, http.StatusOK, w.Code)
+		var page handler.AuditLogResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		require.Len(t, page.Items, 1)
+		assert.Greater(t, page.Items[0].ID, entry.ID)
+		assert.True(t, page.HasMore)
+		assert.Equal(t, page.Items[0].ID, page.NextBeforeID)
+
+		var next handler.AuditLogResponse
+		w = serve(http.MethodGet, "/admin/audit?limit=1&cursor="+page.NextCursor, "")
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &next))
+		require.Len(t, next.Items, 1)
+		assert.Equal(t, entry.ID, next.Items[0].ID)
+		assert.False(t, next.HasMore)
+
+		w = serve(http.MethodGet, "/admin/audit?before_id="+strconv.FormatInt(page.NextBeforeID, 10), "")
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &next))
+		require.Len(t, next.Entries, 1)
+		assert.Equal(t, entry.ID, next.Entries[0].ID)
+	})
+}
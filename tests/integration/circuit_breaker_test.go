@@ -0,0 +1,145 @@
+package integration
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/middleware"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+	"github.com/mishasvintus/avito_backend_internship/internal/router"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+// dbProxy forwards TCP connections to the test database and can cut them all, simulating an outage.
+type dbProxy struct {
+	t        *testing.T
+	addr     string
+	upstream string
+
+	mu       sync.Mutex
+	listener net.Listener
+	conns    []net.Conn
+}
+
+func startDBProxy(t *testing.T) *dbProxy {
+	host, port := tests.TestDBAddr()
+	p := &dbProxy{t: t, addr: "127.0.0.1:0", upstream: net.JoinHostPort(host, port)}
+	p.start()
+	p.addr = p.listener.Addr().String()
+	t.Cleanup(p.stop)
+	return p
+}
+
+// start listens on the proxy address, which stays the same across restarts.
+func (p *dbProxy) start() {
+	listener, err := net.Listen("tcp", p.addr)
+	require.NoError(p.t, err)
+	p.mu.Lock()
+	p.listener = listener
+	p.mu.Unlock()
+
+	go func() {
+		for {
+			client, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			server, err := net.Dial("tcp", p.upstream)
+			if err != nil {
+				_ = client.Close()
+				continue
+			}
+			p.mu.Lock()
+			p.conns = append(p.conns, client, server)
+			p.mu.Unlock()
+			go func() { _, _ = io.Copy(server, client); _ = server.Close() }()
+			go func() { _, _ = io.Copy(client, server); _ = client.Close() }()
+		}
+	}()
+}
+
+// stop refuses new connections and drops the open ones.
+func (p *dbProxy) stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.listener != nil {
+		_ = p.listener.Close()
+		p.listener = nil
+	}
+	for _, c := range p.conns {
+		_ = c.Close()
+	}
+	p.conns = nil
+}
+
+func TestCircuitBreaker_DatabaseOutage(t *testing.T) {
+	proxy := startDBProxy(t)
+	host, port, err := net.SplitHostPort(proxy.addr)
+	require.NoError(t, err)
+
+	db, err := repository.NewPostgresDB(tests.TestDSNAt(host, port), repository.PoolOptions{
+		MaxOpenConns:    4,
+		MaxIdleConns:    4,
+		ConnMaxLifetime: time.Minute,
+	})
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	const openTimeout = 500 * time.Millisecond
+	breaker := middleware.NewCircuitBreaker(2, openTimeout, service.NewSystemClock())
+	prHandler := handler.NewPRHandler(service.NewPRService(db, service.NewReviewerAssigner()))
+	r, err := router.SetupRoutes(router.Options{Mode: gin.TestMode, CircuitBreaker: breaker}, nil, nil, prHandler, nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+
+	getPR := func() (*httptest.ResponseRecorder, time.Duration) {
+		start := time.Now()
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/pullRequest/get?pull_request_id=pr_breaker_ghost", nil))
+		return w, time.Since(start)
+	}
+	health := func() int {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+		return w.Code
+	}
+
+	w, _ := getPR()
+	require.Equal(t, http.StatusNotFound, w.Code, "the database answers through the proxy")
+	assert.Equal(t, http.StatusOK, health())
+
+	proxy.stop()
+	for range 2 {
+		w, _ := getPR()
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	}
+	require.Equal(t, middleware.CircuitOpen, breaker.State())
+	assert.Equal(t, http.StatusServiceUnavailable, health())
+
+	for range 5 {
+		w, elapsed := getPR()
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, "1", w.Header().Get("Retry-After"))
+		assert.Less(t, elapsed, 50*time.Millisecond, "open breaker fails fast")
+	}
+
+	// Once the database is back, the first request after the open timeout probes it and closes the breaker.
+	proxy.start()
+	time.Sleep(openTimeout)
+	require.Eventually(t, func() bool {
+		w, _ := getPR()
+		return w.Code == http.StatusNotFound
+	}, 5*time.Second, 50*time.Millisecond)
+	assert.Equal(t, middleware.CircuitClosed, breaker.State())
+	assert.Equal(t, http.StatusOK, health())
+}
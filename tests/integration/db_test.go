@@ -0,0 +1,43 @@
+package integration
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestNewPostgresDB_StatementTimeout(t *testing.T) {
+	db, err := repository.NewPostgresDB(tests.TestDSN(), repository.PoolOptions{
+		MaxOpenConns:     2,
+		MaxIdleConns:     2,
+		ConnMaxLifetime:  time.Minute,
+		StatementTimeout: 100 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	var timeout string
+	require.NoError(t, db.QueryRow(`SHOW statement_timeout`).Scan(&timeout))
+	assert.Equal(t, "100ms", timeout)
+
+	start := time.Now()
+	_, err = db.Exec(`SELECT pg_sleep(5)`)
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), 2*time.Second)
+
+	// 57014 = query_canceled
+	var pqErr *pq.Error
+	require.True(t, errors.As(err, &pqErr))
+	assert.Equal(t, pq.ErrorCode("57014"), pqErr.Code)
+
+	// The connection stays usable for fast statements.
+	var one int
+	require.NoError(t, db.QueryRow(`SELECT 1`).Scan(&one))
+}
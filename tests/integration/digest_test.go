@@ -0,0 +1,171 @@
+package integration
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestDigestScheduler_Tick(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	// 09:00 in Moscow is 06:00 UTC.
+	setAt := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	clock := &tests.FakeClock{Current: setAt}
+	prService := service.NewPRService(db, service.NewReviewerAssigner()).WithClock(clock)
+	teamService := service.NewTeamService(db, prService)
+
+	_, _, err = teamService.CreateTeam(t.Context(), &domain.Team{
+		TeamName: "team_dg",
+		Members: []domain.TeamMember{
+			{UserID: "author_dg", Username: "author", IsActive: true},
+			{UserID: "rev1_dg", Username: "rev1", IsActive: true},
+			{UserID: "rev2_dg", Username: "rev2", IsActive: true},
+			{UserID: "rev3_dg", Username: "rev3", IsActive: true},
+		},
+	}, service.CreateTeamOptions{})
+	require.NoError(t, err)
+	sla := 24
+	_, err = teamService.UpdateTeam(t.Context(), "team_dg", service.TeamUpdate{ReviewSLAHours: &sla})
+	require.NoError(t, err)
+
+	createPR := func(t *testing.T, id string, reviewers []string, assignedAt time.Time) {
+		t.Helper()
+		_, err := prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: id}, "Digest "+id, "author_dg", reviewers, domain.PRDetails{})
+		require.NoError(t, err)
+		_, err = db.Exec(`UPDATE pr_reviewers SET assigned_at = $2 WHERE pull_request_id = $1`, id, assignedAt)
+		require.NoError(t, err)
+	}
+	createPR(t, "pr_old_dg", []string{"rev1_dg", "rev2_dg"}, setAt.Add(-40*time.Hour))
+	createPR(t, "pr_new_dg", []string{"rev1_dg", "rev3_dg"}, setAt.Add(-2*time.Hour))
+	_, err = prService.ApprovePR(t.Context(), domain.PRKey{PullRequestID: "pr_old_dg"}, "rev2_dg")
+	require.NoError(t, err)
+	// An inactive member keeps the assignment but gets no digest entry.
+	_, err = user.SetIsActive(db, "rev3_dg", false)
+	require.NoError(t, err)
+
+	schedule, err := teamService.SetDigestSchedule(t.Context(), "team_dg", 9, "Europe/Moscow")
+	require.NoError(t, err)
+	assert.True(t, schedule.LastSentAt.Equal(setAt))
+
+	publisher := service.NewMemoryPublisher()
+	dispatcher := service.NewOutboxDispatcher(db, time.Second, 100, 3).WithPublisher(publisher)
+	tick := func(t *testing.T) int {
+		t.Helper()
+		sent, err := service.NewDigestScheduler(db, prService, clock, time.Minute).Tick(t.Context())
+		require.NoError(t, err)
+		_, err = dispatcher.Tick(t.Context())
+		require.NoError(t, err)
+		return sent
+	}
+
+	t.Run("not sent for the hour that passed before the schedule was set", func(t *testing.T) {
+		assert.Zero(t, tick(t))
+		clock.Current = time.Date(2026, 3, 3, 5, 59, 0, 0, time.UTC)
+		assert.Zero(t, tick(t))
+		assert.Empty(t, publisher.Events())
+	})
+
+	t.Run("sent at the hour", func(t *testing.T) {
+		clock.Current = time.Date(2026, 3, 3, 6, 0, 30, 0, time.UTC)
+		assert.Equal(t, 1, tick(t))
+
+		events := publisher.Events()
+		require.Len(t, events, 1)
+		assert.Equal(t, domain.EventReviewDigest, events[0].Type)
+		require.NotNil(t, events[0].Digest)
+		digest := events[0].Digest
+		assert.Equal(t, "team_dg", digest.TeamName)
+		require.Len(t, digest.Reviewers, 1)
+		assert.Equal(t, "rev1_dg", digest.Reviewers[0].UserID)
+		assert.Equal(t, "rev1", digest.Reviewers[0].Username)
+
+		reviews := digest.Reviewers[0].Reviews
+		require.Len(t, reviews, 2)
+		assert.Equal(t, "pr_old_dg", reviews[0].PullRequestID)
+		assert.Equal(t, 58, reviews[0].HoursOpen)
+		assert.True(t, reviews[0].Overdue)
+		assert.Equal(t, "pr_new_dg", reviews[1].PullRequestID)
+		assert.Equal(t, 20, reviews[1].HoursOpen)
+		assert.False(t, reviews[1].Overdue)
+
+		stored, err := teamService.GetDigestSchedule(t.Context(), "team_dg")
+		require.NoError(t, err)
+		assert.True(t, stored.LastSentAt.Equal(clock.Now()))
+	})
+
+	t.Run("not sent again the same day, also after a restart", func(t *testing.T) {
+		clock.Advance(10 * time.Hour)
+		assert.Zero(t, tick(t))
+		assert.Len(t, publisher.Events(), 1)
+	})
+
+	t.Run("missed days are sent once", func(t *testing.T) {
+		clock.Current = time.Date(2026, 3, 6, 3, 0, 0, 0, time.UTC)
+		assert.Equal(t, 1, tick(t))
+		assert.Zero(t, tick(t))
+		assert.Len(t, publisher.Events(), 2)
+	})
+
+	t.Run("concurrent ticks send once", func(t *testing.T) {
+		clock.Current = time.Date(2026, 3, 7, 6, 0, 0, 0, time.UTC)
+
+		var wg sync.WaitGroup
+		sent := make([]int, 4)
+		for i := range sent {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				n, err := service.NewDigestScheduler(db, prService, clock, time.Minute).Tick(t.Context())
+				assert.NoError(t, err)
+				sent[i] = n
+			}()
+		}
+		wg.Wait()
+
+		total := 0
+		for _, n := range sent {
+			total += n
+		}
+		assert.Equal(t, 1, total)
+	})
+
+	t.Run("nothing pending sends nothing but counts as sent", func(t *testing.T) {
+		_, err := prService.MergePR(t.Context(), domain.PRKey{PullRequestID: "pr_old_dg"}, service.MergeOptions{})
+		require.NoError(t, err)
+		_, err = prService.MergePR(t.Context(), domain.PRKey{PullRequestID: "pr_new_dg"}, service.MergeOptions{})
+		require.NoError(t, err)
+
+		clock.Current = time.Date(2026, 3, 8, 6, 0, 0, 0, time.UTC)
+		assert.Zero(t, tick(t))
+
+		stored, err := teamService.GetDigestSchedule(t.Context(), "team_dg")
+		require.NoError(t, err)
+		assert.True(t, stored.LastSentAt.Equal(clock.Now()))
+	})
+
+	t.Run("schedule validation and removal", func(t *testing.T) {
+		_, err := teamService.SetDigestSchedule(t.Context(), "team_dg", 9, "Mars/Olympus")
+		assert.ErrorIs(t, err, service.ErrInvalidTimezone)
+		_, err = teamService.SetDigestSchedule(t.Context(), "team_dg", 24, "UTC")
+		assert.ErrorIs(t, err, service.ErrInvalidDigestHour)
+		_, err = teamService.SetDigestSchedule(t.Context(), "ghost_dg", 9, "UTC")
+		assert.ErrorIs(t, err, service.ErrTeamNotFound)
+
+		require.NoError(t, teamService.DeleteDigestSchedule(t.Context(), "team_dg"))
+		assert.ErrorIs(t, teamService.DeleteDigestSchedule(t.Context(), "team_dg"), service.ErrDigestNotFound)
+		_, err = teamService.GetDigestSchedule(t.Context(), "team_dg")
+		assert.ErrorIs(t, err, service.ErrDigestNotFound)
+	})
+}
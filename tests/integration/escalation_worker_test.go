@@ -0,0 +1,149 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/history"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestEscalationWorker_Tick(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	teamName := "team_esc"
+	require.NoError(t, team.Create(db, teamName))
+	for _, id := range []string{"author_esc", "r1_esc", "r2_esc", "r3_esc"} {
+		require.NoError(t, user.Create(db, &domain.User{UserID: id, Username: id, TeamName: teamName, IsActive: true}))
+	}
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	created, err := prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: "pr_esc"}, "Overdue PR", "author_esc", nil, domain.PRDetails{})
+	require.NoError(t, err)
+	require.Len(t, created.AssignedReviewersIDs, 2)
+
+	sla := time.Hour
+	clock := &tests.FakeClock{Current: time.Now()}
+
+	t.Run("nothing escalated within SLA", func(t *testing.T) {
+		worker := service.NewEscalationWorker(db, prService, clock, time.Minute, sla, 10)
+		escalated, err := worker.Tick(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, 0, escalated)
+	})
+
+	t.Run("overdue assignment reassigned once per free candidate", func(t *testing.T) {
+		clock.Advance(2 * sla)
+		worker := service.NewEscalationWorker(db, prService, clock, time.Minute, sla, 10)
+
+		// Only one teammate is free, so only one of the two overdue assignments can move.
+		escalated, err := worker.Tick(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, 1, escalated)
+
+		updated, err := pr.Get(db, domain.PRKey{PullRequestID: "pr_esc"})
+		require.NoError(t, err)
+		assert.Len(t, updated.AssignedReviewersIDs, 2)
+		assert.Contains(t, updated.AssignedReviewersIDs, "r3_esc")
+		assert.Equal(t, domain.SourceEscalation, sourcesOf(updated)["r3_esc"])
+		assert.NotContains(t, updated.AssignedReviewersIDs, "author_esc")
+
+		events, err := history.GetByPR(db, domain.PRKey{PullRequestID: "pr_esc"})
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, domain.ActionEscalate, events[0].Action)
+		assert.Equal(t, "r3_esc", events[0].NewUserID)
+		assert.Contains(t, created.AssignedReviewersIDs, events[0].OldUserID)
+	})
+
+	t.Run("batch size limits escalations per tick", func(t *testing.T) {
+		require.NoError(t, user.Create(db, &domain.User{UserID: "r4_esc", Username: "r4_esc", TeamName: teamName, IsActive: true}))
+		require.NoError(t, user.Create(db, &domain.User{UserID: "r5_esc", Username: "r5_esc", TeamName: teamName, IsActive: true}))
+
+		clock.Advance(2 * sla)
+		worker := service.NewEscalationWorker(db, prService, clock, time.Minute, sla, 1)
+		escalated, err := worker.Tick(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, 1, escalated)
+	})
+
+	t.Run("merged PRs are not escalated", func(t *testing.T) {
+		_, err := prService.MergePR(t.Context(), domain.PRKey{PullRequestID: "pr_esc"}, service.MergeOptions{})
+		require.NoError(t, err)
+
+		clock.Advance(2 * sla)
+		worker := service.NewEscalationWorker(db, prService, clock, time.Minute, sla, 10)
+		escalated, err := worker.Tick(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, 0, escalated)
+	})
+}
+
+func TestEscalationWorker_TickDoesNotStallOnUnescalatableAssignments(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	// team_stuck has no free member, so its PR's two assignments can never be escalated.
+	// team_free has one, so its PR's assignment can.
+	require.NoError(t, team.Create(db, "team_stuck"))
+	for _, id := range []string{"author_stuck", "r1_stuck", "r2_stuck"} {
+		require.NoError(t, user.Create(db, &domain.User{UserID: id, Username: id, TeamName: "team_stuck", IsActive: true}))
+	}
+	require.NoError(t, team.Create(db, "team_free"))
+	for _, id := range []string{"author_free", "r1_free", "r2_free"} {
+		require.NoError(t, user.Create(db, &domain.User{UserID: id, Username: id, TeamName: "team_free", IsActive: true}))
+	}
+
+	stuck := domain.PRKey{PullRequestID: "pr_stuck"}
+	require.NoError(t, pr.Create(db, &domain.PullRequest{
+		PullRequestID: stuck.PullRequestID, PullRequestName: "Stuck", AuthorID: "author_stuck", TeamName: "team_stuck", Status: domain.StatusOpen,
+	}))
+	require.NoError(t, pr.InsertReviewer(db, stuck, "r1_stuck", time.Now().UTC()))
+	require.NoError(t, pr.InsertReviewer(db, stuck, "r2_stuck", time.Now().UTC()))
+	free := domain.PRKey{PullRequestID: "pr_free"}
+	require.NoError(t, pr.Create(db, &domain.PullRequest{
+		PullRequestID: free.PullRequestID, PullRequestName: "Free", AuthorID: "author_free", TeamName: "team_free", Status: domain.StatusOpen,
+	}))
+	require.NoError(t, pr.InsertReviewer(db, free, "r1_free", time.Now().UTC()))
+
+	// The stuck assignments are the oldest, so they fill a batch of two.
+	now := time.Now()
+	_, err = db.Exec(`UPDATE pr_reviewers SET assigned_at = $2 WHERE pull_request_id = $1`, stuck.PullRequestID, now.Add(-3*time.Hour))
+	require.NoError(t, err)
+	_, err = db.Exec(`UPDATE pr_reviewers SET assigned_at = $2 WHERE pull_request_id = $1`, free.PullRequestID, now.Add(-2*time.Hour))
+	require.NoError(t, err)
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	clock := &tests.FakeClock{Current: now}
+	worker := service.NewEscalationWorker(db, prService, clock, time.Minute, time.Hour, 2)
+
+	escalated, err := worker.Tick(t.Context())
+	require.NoError(t, err)
+	assert.Zero(t, escalated, "the first batch holds only the stuck assignments")
+
+	clock.Advance(time.Minute)
+	escalated, err = worker.Tick(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 1, escalated)
+
+	updated, err := pr.Get(db, free)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"r2_free"}, updated.AssignedReviewersIDs)
+
+	stillStuck, err := pr.Get(db, stuck)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"r1_stuck", "r2_stuck"}, stillStuck.AssignedReviewersIDs)
+}
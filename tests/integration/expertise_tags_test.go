@@ -0,0 +1,56 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestExpertiseTags_PreferMatchingReviewers(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	teamService := service.NewTeamService(db, prService)
+	userService := service.NewUserService(db, prService)
+	_, _, err = teamService.CreateTeam(t.Context(), &domain.Team{
+		TeamName: "team_tags",
+		Members: []domain.TeamMember{
+			{UserID: "author_tags", Username: "author", IsActive: true},
+			{UserID: "go_tags", Username: "gopher", IsActive: true, Tags: []string{"go", "payments"}},
+			{UserID: "front_tags", Username: "front", IsActive: true, Tags: []string{"frontend"}},
+			{UserID: "plain_tags", Username: "plain", IsActive: true},
+		},
+	}, service.CreateTeamOptions{})
+	require.NoError(t, err)
+
+	team, err := teamService.GetTeam(t.Context(), "team_tags")
+	require.NoError(t, err)
+	tags := make(map[string][]string)
+	for _, m := range team.Members {
+		tags[m.UserID] = m.Tags
+	}
+	assert.Equal(t, []string{"go", "payments"}, tags["go_tags"])
+	assert.Empty(t, tags["plain_tags"])
+
+	u, err := userService.SetTags(t.Context(), "plain_tags", []string{"payments"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"payments"}, u.Tags)
+	_, err = userService.SetTags(t.Context(), "nonexistent", []string{"go"})
+	assert.ErrorIs(t, err, service.ErrUserNotFound)
+
+	for _, id := range []string{"pr_tags_1", "pr_tags_2", "pr_tags_3"} {
+		created, err := prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: id}, "Payments", "author_tags", nil,
+			domain.PRDetails{Tags: []string{"payments"}})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"go_tags", "plain_tags"}, created.AssignedReviewersIDs)
+		assert.Equal(t, []string{"payments"}, created.Tags)
+	}
+}
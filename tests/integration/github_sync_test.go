@@ -0,0 +1,149 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/integration"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/audit"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+// fakeGitHubClient returns fixed teams, or err if set.
+type fakeGitHubClient struct {
+	teams []integration.GitHubTeam
+	err   error
+}
+
+func (c *fakeGitHubClient) ListTeams(context.Context) ([]integration.GitHubTeam, error) {
+	return c.teams, c.err
+}
+
+func TestGitHubSyncService_SyncTeams(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	integrationService := service.NewIntegrationService(db, prService)
+
+	// backend_gh exists with a mapped member, a member who left GitHub and a reviewer to inherit
+	// the leaver's review; taken_gh occupies the ID an unmapped login would get.
+	require.NoError(t, team.Create(db, "backend_gh"))
+	require.NoError(t, team.Create(db, "manual_gh"))
+	for _, u := range []domain.User{
+		{UserID: "alice_gh", Username: "Alice", TeamName: "backend_gh", IsActive: true},
+		{UserID: "leaver_gh", Username: "Leaver", TeamName: "backend_gh", IsActive: true},
+		{UserID: "rev_gh", Username: "Reviewer", TeamName: "backend_gh", IsActive: true},
+		{UserID: "taken_gh", Username: "Taken", TeamName: "manual_gh", IsActive: true},
+	} {
+		require.NoError(t, user.Create(db, &u))
+	}
+	for login, userID := range map[string]string{"alice-gh": "alice_gh", "rev-gh": "rev_gh"} {
+		require.NoError(t, integrationService.MapLogin(t.Context(),
+			&domain.ExternalLogin{Provider: integration.ProviderGitHub, Login: login, UserID: userID}))
+	}
+
+	key := domain.PRKey{PullRequestID: "pr_gh"}
+	require.NoError(t, pr.Create(db, &domain.PullRequest{
+		PullRequestID: "pr_gh", PullRequestName: "Feature", AuthorID: "alice_gh", TeamName: "backend_gh", Status: domain.StatusOpen,
+	}))
+	require.NoError(t, pr.InsertReviewer(db, key, "leaver_gh", time.Now().UTC()))
+
+	client := &fakeGitHubClient{teams: []integration.GitHubTeam{
+		{Slug: "ops_gh", Name: "Ops", Members: []string{"alice-gh", "new_gh"}},
+		{Slug: "backend_gh", Name: "Backend", Members: []string{"alice-gh", "rev-gh", "new_gh", "taken_gh"}},
+	}}
+	syncService := service.NewGitHubSyncService(db, prService, client, "acme")
+
+	summary, err := syncService.SyncTeams(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ops_gh"}, summary.TeamsCreated)
+	assert.Equal(t, []string{"new_gh"}, summary.UsersCreated)
+	assert.Equal(t, []string{"alice_gh"}, summary.UsersUpdated)
+	assert.Equal(t, []string{"leaver_gh"}, summary.UsersDeactivated)
+	assert.Equal(t, []service.SkippedLogin{{TeamName: "backend_gh", Login: "taken_gh", Reason: service.SkipUserIDTaken}}, summary.Skipped)
+
+	// The first team by name becomes the primary team of a new user.
+	created, err := user.Get(db, "new_gh")
+	require.NoError(t, err)
+	assert.Equal(t, "backend_gh", created.TeamName)
+	ops, err := team.Get(db, "ops_gh")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"alice_gh", "new_gh"}, memberIDs(ops))
+
+	// The leaver is deactivated and their review refilled.
+	leaver, err := user.Get(db, "leaver_gh")
+	require.NoError(t, err)
+	assert.False(t, leaver.IsActive)
+	reviewed, err := prService.GetPR(t.Context(), key)
+	require.NoError(t, err)
+	assert.NotContains(t, reviewed.AssignedReviewersIDs, "leaver_gh")
+	assert.Contains(t, reviewed.AssignedReviewersIDs, "rev_gh")
+
+	entries, err := audit.List(db, audit.Filter{Limit: 10})
+	require.NoError(t, err)
+	require.NotEmpty(t, entries)
+	assert.Equal(t, domain.AuditTeamsSync, entries[0].Action)
+	assert.Equal(t, "github:acme", entries[0].Target)
+
+	t.Run("repeated sync changes nothing", func(t *testing.T) {
+		again, err := syncService.SyncTeams(t.Context())
+		require.NoError(t, err)
+		assert.False(t, again.Changed())
+		assert.Len(t, again.Skipped, 1)
+	})
+
+	t.Run("member leaving one of two teams stays active", func(t *testing.T) {
+		client.teams[0].Members = []string{"new_gh"}
+		summary, err := syncService.SyncTeams(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"alice_gh"}, summary.UsersUpdated)
+		assert.Empty(t, summary.UsersDeactivated)
+
+		ops, err := team.Get(db, "ops_gh")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"new_gh"}, memberIDs(ops))
+		alice, err := user.Get(db, "alice_gh")
+		require.NoError(t, err)
+		assert.True(t, alice.IsActive)
+		assert.Equal(t, "backend_gh", alice.TeamName)
+	})
+
+	t.Run("teams missing on GitHub are left untouched", func(t *testing.T) {
+		manual, err := team.Get(db, "manual_gh")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"taken_gh"}, memberIDs(manual))
+	})
+
+	t.Run("GitHub failure", func(t *testing.T) {
+		failing := service.NewGitHubSyncService(db, prService, &fakeGitHubClient{err: errors.New("responded 502")}, "acme")
+		_, err := failing.SyncTeams(t.Context())
+		assert.ErrorIs(t, err, service.ErrGitHubUnavailable)
+	})
+
+	t.Run("without a client", func(t *testing.T) {
+		_, err := service.NewGitHubSyncService(db, prService, nil, "").SyncTeams(t.Context())
+		assert.ErrorIs(t, err, service.ErrGitHubSyncDisabled)
+	})
+}
+
+// memberIDs returns the IDs of the team's members.
+func memberIDs(t *domain.Team) []string {
+	ids := make([]string, 0, len(t.Members))
+	for _, m := range t.Members {
+		ids = append(ids, m.UserID)
+	}
+	return ids
+}
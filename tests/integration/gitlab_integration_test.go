@@ -0,0 +1,102 @@
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/integration"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+// replayGitLab applies a captured GitLab webhook payload from tests/testdata/gitlab.
+func replayGitLab(t *testing.T, s *service.IntegrationService, fixture string) (*domain.PullRequest, error) {
+	t.Helper()
+	body, err := os.ReadFile(filepath.Join("..", "testdata", "gitlab", fixture))
+	require.NoError(t, err)
+	cmd, err := integration.ParseGitLabMergeRequest(body)
+	require.NoError(t, err)
+	return s.Apply(t.Context(), cmd)
+}
+
+func TestIntegrationService_GitLabMergeRequest(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	require.NoError(t, team.Create(db, "team_gl"))
+	for _, id := range []string{"alice_gl", "bob_gl", "rev_gl"} {
+		require.NoError(t, user.Create(db, &domain.User{UserID: id, Username: id, TeamName: "team_gl", IsActive: true}))
+	}
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	integrationService := service.NewIntegrationService(db, prService)
+	key := domain.PRKey{RepositoryName: "platform/backend", PullRequestID: "42"}
+
+	t.Run("unmapped author is rejected", func(t *testing.T) {
+		_, err := replayGitLab(t, integrationService, "merge_request_open.json")
+		require.ErrorIs(t, err, service.ErrUnknownExternalLogin)
+
+		_, err = prService.GetPR(t.Context(), key)
+		assert.ErrorIs(t, err, service.ErrPRNotFound)
+	})
+
+	t.Run("login of a missing user cannot be mapped", func(t *testing.T) {
+		err := integrationService.MapLogin(t.Context(), &domain.ExternalLogin{Provider: integration.ProviderGitLab, Login: "ghost", UserID: "ghost"})
+		assert.ErrorIs(t, err, service.ErrUserNotFound)
+	})
+
+	require.NoError(t, integrationService.MapLogin(t.Context(), &domain.ExternalLogin{Provider: integration.ProviderGitLab, Login: "alice.smith", UserID: "bob_gl"}))
+	// Mapping a login again replaces the user.
+	require.NoError(t, integrationService.MapLogin(t.Context(), &domain.ExternalLogin{Provider: integration.ProviderGitLab, Login: "alice.smith", UserID: "alice_gl"}))
+	require.NoError(t, integrationService.MapLogin(t.Context(), &domain.ExternalLogin{Provider: integration.ProviderGitLab, Login: "bob.jones", UserID: "bob_gl"}))
+
+	t.Run("open creates the pull request", func(t *testing.T) {
+		created, err := replayGitLab(t, integrationService, "merge_request_open.json")
+		require.NoError(t, err)
+		assert.Equal(t, key, created.Key())
+		assert.Equal(t, "Add login via SSO", created.PullRequestName)
+		assert.Equal(t, "alice_gl", created.AuthorID)
+		assert.Equal(t, domain.StatusOpen, created.Status)
+		assert.ElementsMatch(t, []string{"bob_gl", "rev_gl"}, created.AssignedReviewersIDs)
+		require.NotNil(t, created.ExternalURL)
+		assert.Equal(t, "https://gitlab.example.com/platform/backend/-/merge_requests/42", *created.ExternalURL)
+		require.NotNil(t, created.Description)
+
+		redelivered, err := replayGitLab(t, integrationService, "merge_request_open.json")
+		require.NoError(t, err)
+		assert.Equal(t, created.AssignedReviewersIDs, redelivered.AssignedReviewersIDs)
+	})
+
+	t.Run("merge records the merger", func(t *testing.T) {
+		merged, err := replayGitLab(t, integrationService, "merge_request_merge.json")
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusMerged, merged.Status)
+		assert.Equal(t, "bob_gl", merged.MergedBy)
+		assert.NotNil(t, merged.MergedAt)
+
+		_, err = replayGitLab(t, integrationService, "merge_request_close.json")
+		assert.ErrorIs(t, err, service.ErrPRMerged)
+	})
+
+	t.Run("close after reopening", func(t *testing.T) {
+		_, err := prService.ReopenPR(t.Context(), key)
+		require.NoError(t, err)
+
+		closed, err := replayGitLab(t, integrationService, "merge_request_close.json")
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusClosed, closed.Status)
+		assert.NotNil(t, closed.ClosedAt)
+
+		_, err = replayGitLab(t, integrationService, "merge_request_merge.json")
+		assert.ErrorIs(t, err, service.ErrPRClosed)
+	})
+}
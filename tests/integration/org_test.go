@@ -0,0 +1,113 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/middleware"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/stats"
+	"github.com/mishasvintus/avito_backend_internship/internal/router"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestOrganizations_IsolateData(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	ctx := context.Background()
+	orgService := service.NewOrgService(db)
+	_, err = orgService.CreateOrg(ctx, "acme_org_test", "Acme")
+	require.NoError(t, err)
+	_, err = orgService.CreateOrg(ctx, "acme_org_test", "Acme again")
+	require.ErrorIs(t, err, service.ErrOrgExists)
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	teamService := service.NewTeamService(db, prService)
+	statsService := service.NewStatsService(db, service.NewSystemClock())
+	r, err := router.SetupRoutes(router.Options{Mode: gin.TestMode, Orgs: orgService},
+		handler.NewTeamHandler(teamService), nil, handler.NewPRHandler(prService), nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+
+	serve := func(orgID, target, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, router.APIPrefix+target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if orgID != "" {
+			req.Header.Set(middleware.OrgHeader, orgID)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// The same team, user and PR IDs are created in both organizations.
+	w := serve("", "/team/add", `{"team_name":"team_org","members":[
+		{"user_id":"author_org","username":"Default Author","is_active":true},
+		{"user_id":"reviewer_org_1","username":"Default Reviewer","is_active":true}]}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	w = serve("acme_org_test", "/team/add", `{"team_name":"team_org","members":[
+		{"user_id":"author_org","username":"Acme Author","is_active":true},
+		{"user_id":"reviewer_org_1","username":"Acme Reviewer","is_active":true},
+		{"user_id":"reviewer_org_2","username":"Acme Reviewer 2","is_active":true}]}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	for _, orgID := range []string{"", "acme_org_test"} {
+		w = serve(orgID, "/pullRequest/create", `{"pull_request_id":"pr_org","pull_request_name":"Feature","author_id":"author_org"}`)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	}
+	w = serve("acme_org_test", "/pullRequest/create", `{"pull_request_id":"pr_org_2","pull_request_name":"Fix","author_id":"author_org"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	w = serve("globex_org_test", "/team/add", `{"team_name":"team_org","members":[]}`)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	defaultCtx := repository.WithOrg(ctx, domain.DefaultOrgID)
+	acmeCtx := repository.WithOrg(ctx, "acme_org_test")
+
+	defaultTeam, err := teamService.GetTeam(defaultCtx, "team_org")
+	require.NoError(t, err)
+	assert.Len(t, defaultTeam.Members, 2)
+	acmeTeam, err := teamService.GetTeam(acmeCtx, "team_org")
+	require.NoError(t, err)
+	assert.Len(t, acmeTeam.Members, 3)
+
+	defaultPR, err := prService.GetPR(defaultCtx, domain.PRKey{PullRequestID: "pr_org"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"reviewer_org_1"}, defaultPR.AssignedReviewersIDs)
+	acmePR, err := prService.GetPR(acmeCtx, domain.PRKey{PullRequestID: "pr_org"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"reviewer_org_1", "reviewer_org_2"}, acmePR.AssignedReviewersIDs)
+
+	_, err = prService.GetPR(defaultCtx, domain.PRKey{PullRequestID: "pr_org_2"})
+	assert.ErrorIs(t, err, service.ErrPRNotFound)
+
+	defaultStats, err := statsService.GetStatistics(defaultCtx, stats.Period{})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, defaultStats.Overall.TotalPRs)
+	assert.EqualValues(t, 2, defaultStats.Overall.TotalUsers)
+	acmeStats, err := statsService.GetStatistics(acmeCtx, stats.Period{})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, acmeStats.Overall.TotalPRs)
+	assert.EqualValues(t, 3, acmeStats.Overall.TotalUsers)
+
+	orgs, err := orgService.ListOrgs(ctx)
+	require.NoError(t, err)
+	ids := make([]string, 0, len(orgs))
+	for _, o := range orgs {
+		ids = append(ids, o.OrgID)
+	}
+	assert.Equal(t, []string{"acme_org_test", domain.DefaultOrgID}, ids)
+}
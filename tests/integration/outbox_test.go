@@ -0,0 +1,337 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/queue"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/outbox"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+// failingPublisher rejects events of the pull requests listed in failFor.
+type failingPublisher struct {
+	mu      sync.Mutex
+	failFor map[string]bool
+}
+
+func (p *failingPublisher) Publish(_ context.Context, event domain.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.failFor[event.PullRequestID] {
+		return errors.New("receiver unavailable")
+	}
+	return nil
+}
+
+func (p *failingPublisher) heal(pullRequestID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.failFor, pullRequestID)
+}
+
+// eventSummary returns "type pull_request_id" per event.
+func eventSummary(events []domain.Event) []string {
+	summary := make([]string, len(events))
+	for i, e := range events {
+		summary[i] = string(e.Type) + " " + e.PullRequestID
+	}
+	return summary
+}
+
+func TestPRService_WritesOutboxEvents(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	require.NoError(t, team.Create(db, "team_ev"))
+	for _, id := range []string{"author_ev", "rev1_ev", "rev2_ev", "rev3_ev"} {
+		require.NoError(t, user.Create(db, &domain.User{UserID: id, Username: id, TeamName: "team_ev", IsActive: true}))
+	}
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	publisher := service.NewMemoryPublisher()
+	dispatcher := service.NewOutboxDispatcher(db, time.Second, 100, 3).WithPublisher(publisher)
+	key := domain.PRKey{RepositoryName: "repo_ev", PullRequestID: "pr_ev"}
+
+	created, err := prService.CreatePR(t.Context(), key, "Events", "author_ev", []string{"rev1_ev", "rev2_ev"}, domain.PRDetails{})
+	require.NoError(t, err)
+	_, newReviewerID, err := prService.ReassignPR(t.Context(), key, "rev1_ev", service.ReassignOptions{})
+	require.NoError(t, err)
+
+	// Rolled back operations leave nothing in the outbox.
+	_, err = prService.CreatePR(t.Context(), key, "Events", "author_ev", nil, domain.PRDetails{})
+	require.ErrorIs(t, err, service.ErrPRExists)
+	_, _, err = prService.ReassignPR(t.Context(), key, "author_ev", service.ReassignOptions{})
+	require.Error(t, err)
+
+	merged, err := prService.MergePR(t.Context(), key, service.MergeOptions{})
+	require.NoError(t, err)
+	_, err = prService.MergePR(t.Context(), key, service.MergeOptions{})
+	require.NoError(t, err)
+
+	published, err := dispatcher.Tick(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 5, published)
+
+	events := publisher.Events()
+	require.Len(t, events, 5)
+	assert.Equal(t, []string{
+		"pr.created pr_ev",
+		"reviewer.assigned pr_ev",
+		"reviewer.assigned pr_ev",
+		"reviewer.reassigned pr_ev",
+		"pr.merged pr_ev",
+	}, eventSummary(events))
+
+	var lastID int64
+	for _, e := range events {
+		id, err := strconv.ParseInt(e.ID, 10, 64)
+		require.NoError(t, err)
+		assert.Greater(t, id, lastID)
+		lastID = id
+		assert.Equal(t, "repo_ev", e.RepositoryName)
+		assert.False(t, e.OccurredAt.IsZero())
+	}
+
+	require.NotNil(t, events[0].PullRequest)
+	assert.Equal(t, created.AssignedReviewersIDs, events[0].PullRequest.AssignedReviewersIDs)
+	assert.ElementsMatch(t, []string{"rev1_ev", "rev2_ev"}, []string{events[1].ReviewerID, events[2].ReviewerID})
+	assert.Equal(t, newReviewerID, events[3].ReviewerID)
+	assert.Equal(t, "rev1_ev", events[3].ReplacedReviewerID)
+	assert.Equal(t, domain.ActionReassign, events[3].Reason)
+	require.NotNil(t, events[4].PullRequest)
+	assert.Equal(t, domain.StatusMerged, events[4].PullRequest.Status)
+	assert.Equal(t, merged.MergedAt.Unix(), events[4].PullRequest.MergedAt.Unix())
+	// The merge names the reviewers assigned at that moment, after the reassignment.
+	assert.ElementsMatch(t, []string{"rev2_ev", newReviewerID}, events[4].ReviewerIDs)
+
+	// Published events are not handed out again.
+	published, err = dispatcher.Tick(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, published)
+	assert.Len(t, publisher.Events(), 5)
+}
+
+func TestOutboxDispatcher_OrderAndRetries(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	for _, e := range []domain.Event{
+		{Type: domain.EventPRCreated, PullRequestID: "pr_a"},
+		{Type: domain.EventPRCreated, PullRequestID: "pr_b"},
+		{Type: domain.EventReviewerAssigned, PullRequestID: "pr_a", ReviewerID: "u1"},
+		{Type: domain.EventPRMerged, PullRequestID: "pr_b"},
+	} {
+		require.NoError(t, outbox.Insert(db, e))
+	}
+
+	flaky := &failingPublisher{failFor: map[string]bool{"pr_a": true}}
+	publisher := service.NewMemoryPublisher()
+	dispatcher := service.NewOutboxDispatcher(db, time.Second, 100, 3).
+		WithPublisher(flaky).
+		WithPublisher(publisher)
+
+	t.Run("failing pull request holds back only its own events", func(t *testing.T) {
+		published, err := dispatcher.Tick(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 2, published)
+
+		// At least once: the healthy publisher got pr_a's first event, which is published again later.
+		assert.Equal(t, []string{"pr.created pr_a", "pr.created pr_b", "pr.merged pr_b"}, eventSummary(publisher.Events()))
+
+		pending, err := outbox.GetPending(db, 100)
+		require.NoError(t, err)
+		require.Len(t, pending, 2)
+		assert.Equal(t, 1, pending[0].Attempts)
+		assert.Zero(t, pending[1].Attempts, "the held back event was not attempted")
+	})
+
+	t.Run("recovered pull request is published in order", func(t *testing.T) {
+		flaky.heal("pr_a")
+
+		published, err := dispatcher.Tick(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 2, published)
+		assert.Equal(t, []string{"pr.created pr_a", "reviewer.assigned pr_a"}, eventSummary(publisher.Events()[3:]))
+
+		pending, err := outbox.GetPending(db, 100)
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+	})
+}
+
+func TestOutboxDispatcher_GivesUp(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	require.NoError(t, outbox.Insert(db, domain.Event{Type: domain.EventPRCreated, PullRequestID: "pr_dead"}))
+
+	dispatcher := service.NewOutboxDispatcher(db, time.Second, 100, 2).
+		WithPublisher(&failingPublisher{failFor: map[string]bool{"pr_dead": true}})
+
+	for range 2 {
+		published, err := dispatcher.Tick(context.Background())
+		require.NoError(t, err)
+		assert.Zero(t, published)
+	}
+
+	pending, err := outbox.GetPending(db, 100)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	var attempts int
+	var lastError string
+	require.NoError(t, db.QueryRow(`SELECT attempts, last_error FROM event_outbox WHERE failed_at IS NOT NULL`).Scan(&attempts, &lastError))
+	assert.Equal(t, 2, attempts)
+	assert.Contains(t, lastError, "receiver unavailable")
+}
+
+func TestOutboxDispatcher_SingleActiveDispatcher(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	require.NoError(t, outbox.Insert(db, domain.Event{Type: domain.EventPRCreated, PullRequestID: "pr_lock"}))
+
+	publisher := service.NewMemoryPublisher()
+	dispatcher := service.NewOutboxDispatcher(db, time.Second, 100, 3).WithPublisher(publisher)
+
+	unlock, ok, err := outbox.TryLock(context.Background(), db)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	published, err := dispatcher.Tick(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, published)
+	assert.Empty(t, publisher.Events())
+
+	unlock()
+
+	published, err = dispatcher.Tick(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, published)
+}
+
+func TestOutboxDispatcher_DeliversWebhooks(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	var mu sync.Mutex
+	var bodies [][]byte
+	var signatures []string
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		bodies = append(bodies, body)
+		signatures = append(signatures, r.Header.Get(service.WebhookSignatureHeader))
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	webhookService := service.NewWebhookService(db)
+	_, err = webhookService.CreateWebhook(t.Context(), receiver.URL, "outbox-secret")
+	require.NoError(t, err)
+
+	require.NoError(t, outbox.Insert(db, domain.Event{Type: domain.EventReviewerAssigned, RepositoryName: "repo_wh", PullRequestID: "pr_wh", ReviewerID: "u1"}))
+
+	dispatcher := service.NewOutboxDispatcher(db, time.Second, 100, 3).
+		WithPublisher(service.NewWebhookSender(webhookService))
+	published, err := dispatcher.Tick(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, published)
+
+	require.Len(t, bodies, 1)
+	assert.Equal(t, service.SignWebhookPayload("outbox-secret", bodies[0]), signatures[0])
+
+	var payload domain.Event
+	require.NoError(t, json.Unmarshal(bodies[0], &payload))
+	assert.Equal(t, domain.EventReviewerAssigned, payload.Type)
+	assert.Equal(t, "pr_wh", payload.PullRequestID)
+	assert.Equal(t, "u1", payload.ReviewerID)
+	assert.NotEmpty(t, payload.ID)
+}
+
+// recordingProducer records the messages it accepts and rejects all while down is set.
+type recordingProducer struct {
+	mu       sync.Mutex
+	down     bool
+	messages []queue.Message
+}
+
+func (p *recordingProducer) Send(_ context.Context, msg queue.Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.down {
+		return errors.New("broker unavailable")
+	}
+	p.messages = append(p.messages, msg)
+	return nil
+}
+
+func (p *recordingProducer) Close() error { return nil }
+
+func TestOutboxDispatcher_PublishesToQueue(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	for _, e := range []domain.Event{
+		{Type: domain.EventPRCreated, RepositoryName: "platform/api", PullRequestID: "pr_q"},
+		{Type: domain.EventReviewerAssigned, RepositoryName: "platform/api", PullRequestID: "pr_q", ReviewerID: "u1"},
+	} {
+		require.NoError(t, outbox.Insert(db, e))
+	}
+
+	producer := &recordingProducer{down: true}
+	dispatcher := service.NewOutboxDispatcher(db, time.Second, 100, 5).
+		WithPublisher(service.NewQueuePublisher(producer))
+
+	published, err := dispatcher.Tick(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, published)
+	pending, err := outbox.GetPending(db, 100)
+	require.NoError(t, err)
+	assert.Len(t, pending, 2, "events stay pending while the broker is down")
+
+	producer.down = false
+	published, err = dispatcher.Tick(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, published)
+
+	require.Len(t, producer.messages, 2)
+	for i, msg := range producer.messages {
+		assert.Equal(t, "platform/api/pr_q", msg.Key)
+		assert.Equal(t, pending[i].Event.ID, msg.ID)
+
+		var event service.QueueEvent
+		require.NoError(t, json.Unmarshal(msg.Value, &event))
+		assert.Equal(t, service.EventSchemaVersion, event.SchemaVersion)
+		assert.Equal(t, pending[i].Event.Type, event.Type)
+	}
+}
@@ -0,0 +1,83 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestOwnershipRules_RequireOwnersOfChangedPaths(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	teamService := service.NewTeamService(db, prService)
+	for _, tm := range []*domain.Team{
+		{TeamName: "team_own", Members: []domain.TeamMember{
+			{UserID: "author_own", Username: "author", IsActive: true},
+			{UserID: "lead_own", Username: "lead", IsActive: true},
+			{UserID: "svc_own", Username: "svc", IsActive: true},
+			{UserID: "dev_own", Username: "dev", IsActive: true},
+		}},
+		{TeamName: "team_own_payments", Members: []domain.TeamMember{
+			{UserID: "pay_own", Username: "pay", IsActive: true},
+			{UserID: "pay_off_own", Username: "pay off", IsActive: false},
+		}},
+	} {
+		_, _, err = teamService.CreateTeam(t.Context(), tm, service.CreateTeamOptions{})
+		require.NoError(t, err)
+	}
+
+	// Overlapping rules: the longest covering prefix wins.
+	for _, rule := range []domain.OwnershipRule{
+		{TeamName: "team_own", PathPrefix: "/internal/", OwnerUserID: "lead_own"},
+		{TeamName: "team_own", PathPrefix: "internal/service", OwnerUserID: "svc_own"},
+		{TeamName: "team_own", PathPrefix: "internal/service/billing", OwnerTeamName: "team_own_payments"},
+		{TeamName: "team_own", PathPrefix: "docs", OwnerUserID: "author_own"},
+	} {
+		_, err = teamService.SetOwnershipRule(t.Context(), rule)
+		require.NoError(t, err)
+	}
+	_, err = teamService.SetOwnershipRule(t.Context(), domain.OwnershipRule{TeamName: "team_own", PathPrefix: "api", OwnerTeamName: "missing"})
+	assert.ErrorIs(t, err, service.ErrOwnerTeamNotFound)
+
+	rules, err := teamService.ListOwnershipRules(t.Context(), "team_own")
+	require.NoError(t, err)
+	require.Len(t, rules, 4)
+	assert.Equal(t, "docs", rules[0].PathPrefix)
+	assert.Equal(t, "internal", rules[1].PathPrefix)
+
+	create := func(id string, required []string, paths ...string) *domain.PullRequest {
+		t.Helper()
+		created, err := prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: id}, id, "author_own", required,
+			domain.PRDetails{ChangedPaths: paths})
+		require.NoError(t, err)
+		return created
+	}
+
+	created := create("pr_own_1", nil, "internal/service/billing/invoice.go", "internal/service/pr.go", "internal/handler/h.go")
+	assert.Equal(t, []string{"pay_own", "svc_own"}, created.AssignedReviewersIDs)
+
+	created = create("pr_own_2", nil, "internal/services/legacy.go")
+	require.Len(t, created.AssignedReviewersIDs, 2)
+	assert.Contains(t, created.AssignedReviewersIDs, "lead_own")
+
+	// The author's own rule is skipped and an explicit required reviewer who owns the path counts once.
+	created = create("pr_own_3", []string{"svc_own"}, "docs/readme.md", "internal/service/x.go")
+	require.Len(t, created.AssignedReviewersIDs, 2)
+	assert.Contains(t, created.AssignedReviewersIDs, "svc_own")
+	assert.NotContains(t, created.AssignedReviewersIDs, "author_own")
+
+	require.NoError(t, teamService.DeleteOwnershipRule(t.Context(), "team_own", "/internal/service/billing/"))
+	assert.ErrorIs(t, teamService.DeleteOwnershipRule(t.Context(), "team_own", "internal/service/billing"), service.ErrOwnershipRuleNotFound)
+	created = create("pr_own_4", nil, "internal/service/billing/invoice.go")
+	assert.Contains(t, created.AssignedReviewersIDs, "svc_own")
+	assert.NotContains(t, created.AssignedReviewersIDs, "pay_own")
+}
@@ -0,0 +1,109 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestPRService_RequiredApprovals(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	require.NoError(t, team.Create(db, "team_appr"))
+	for _, id := range []string{"author_appr", "reviewer_appr_1", "reviewer_appr_2"} {
+		require.NoError(t, user.Create(db, &domain.User{UserID: id, Username: id, TeamName: "team_appr", IsActive: true}))
+	}
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	teamService := service.NewTeamService(db, prService)
+
+	createPR := func(t *testing.T, id string) domain.PRKey {
+		t.Helper()
+		key := domain.PRKey{PullRequestID: id}
+		created, err := prService.CreatePR(t.Context(), key, id, "author_appr", nil, domain.PRDetails{})
+		require.NoError(t, err)
+		require.Len(t, created.AssignedReviewersIDs, 2)
+		return key
+	}
+
+	t.Run("no requirement by default", func(t *testing.T) {
+		merged, err := prService.MergePR(t.Context(), createPR(t, "pr_appr_default"), service.MergeOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusMerged, merged.Status)
+	})
+
+	two := 2
+	updated, err := teamService.UpdateTeam(t.Context(), "team_appr", service.TeamUpdate{RequireApprovals: &two})
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated.RequireApprovals)
+	assert.Equal(t, string(service.StrategyRandom), updated.AssignmentStrategy)
+
+	t.Run("threshold not met", func(t *testing.T) {
+		key := createPR(t, "pr_appr_missing")
+		approved, err := prService.ApprovePR(t.Context(), key, "reviewer_appr_1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"reviewer_appr_1"}, approved.ApprovedReviewersIDs)
+
+		_, err = prService.MergePR(t.Context(), key, service.MergeOptions{})
+		var notApproved *service.NotApprovedError
+		require.ErrorAs(t, err, &notApproved)
+		assert.ErrorIs(t, err, service.ErrNotApproved)
+		assert.Equal(t, 2, notApproved.Required)
+		assert.Equal(t, 1, notApproved.Approved)
+		assert.Equal(t, []string{"reviewer_appr_2"}, notApproved.Missing)
+
+		stored, err := prService.GetPR(t.Context(), key)
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusOpen, stored.Status)
+	})
+
+	t.Run("threshold met", func(t *testing.T) {
+		key := createPR(t, "pr_appr_met")
+		for _, reviewerID := range []string{"reviewer_appr_1", "reviewer_appr_2", "reviewer_appr_2"} {
+			_, err := prService.ApprovePR(t.Context(), key, reviewerID)
+			require.NoError(t, err)
+		}
+
+		merged, err := prService.MergePR(t.Context(), key, service.MergeOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusMerged, merged.Status)
+		assert.ElementsMatch(t, []string{"reviewer_appr_1", "reviewer_appr_2"}, merged.ApprovedReviewersIDs)
+
+		_, err = prService.ApprovePR(t.Context(), key, "reviewer_appr_1")
+		assert.ErrorIs(t, err, service.ErrPRMerged)
+	})
+
+	t.Run("force override", func(t *testing.T) {
+		key := createPR(t, "pr_appr_force")
+
+		merged, err := prService.MergePR(t.Context(), key, service.MergeOptions{Force: true})
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusMerged, merged.Status)
+		assert.Empty(t, merged.ApprovedReviewersIDs)
+	})
+
+	t.Run("only assigned reviewers approve", func(t *testing.T) {
+		key := createPR(t, "pr_appr_author")
+		_, err := prService.ApprovePR(t.Context(), key, "author_appr")
+		assert.ErrorIs(t, err, service.ErrReviewerNotAssigned)
+
+		_, err = prService.ApprovePR(t.Context(), domain.PRKey{PullRequestID: "pr_appr_ghost"}, "reviewer_appr_1")
+		assert.ErrorIs(t, err, service.ErrPRNotFound)
+	})
+
+	t.Run("reopen drops approvals", func(t *testing.T) {
+		reopened, err := prService.ReopenPR(t.Context(), domain.PRKey{PullRequestID: "pr_appr_met"})
+		require.NoError(t, err)
+		assert.Empty(t, reopened.ApprovedReviewersIDs)
+	})
+}
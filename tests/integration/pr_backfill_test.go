@@ -0,0 +1,138 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/audit"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestPRService_BackfillReviewers(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+
+	// team_bf has enough reviewers for the default target of 2, team_bf_small only one.
+	require.NoError(t, team.Create(db, "team_bf"))
+	require.NoError(t, team.Create(db, "team_bf_small"))
+	for _, u := range []domain.User{
+		{UserID: "a_bf", Username: "a", TeamName: "team_bf", IsActive: true},
+		{UserID: "r1_bf", Username: "r1", TeamName: "team_bf", IsActive: true},
+		{UserID: "r2_bf", Username: "r2", TeamName: "team_bf", IsActive: true},
+		{UserID: "r3_bf", Username: "r3", TeamName: "team_bf", IsActive: true},
+		{UserID: "a_bfs", Username: "a", TeamName: "team_bf_small", IsActive: true},
+		{UserID: "r1_bfs", Username: "r1", TeamName: "team_bf_small", IsActive: true},
+	} {
+		require.NoError(t, user.Create(db, &u))
+	}
+
+	// Seeded directly so that each PR gets exactly the reviewers listed.
+	for _, p := range []struct {
+		id        string
+		author    string
+		teamName  string
+		status    domain.PRStatus
+		reviewers []string
+	}{
+		{"bf_full", "a_bf", "team_bf", domain.StatusOpen, []string{"r1_bf", "r2_bf"}},
+		{"bf_one", "a_bf", "team_bf", domain.StatusOpen, []string{"r1_bf"}},
+		{"bf_none", "a_bf", "team_bf", domain.StatusOpen, nil},
+		{"bf_merged", "a_bf", "team_bf", domain.StatusMerged, nil},
+		{"bfs_none", "a_bfs", "team_bf_small", domain.StatusOpen, nil},
+	} {
+		key := domain.PRKey{PullRequestID: p.id}
+		require.NoError(t, pr.Create(db, &domain.PullRequest{
+			PullRequestID: p.id, PullRequestName: p.id, AuthorID: p.author, TeamName: p.teamName, Status: p.status,
+		}))
+		for _, r := range p.reviewers {
+			require.NoError(t, pr.InsertReviewer(db, key, r, time.Now().UTC()))
+		}
+	}
+
+	reviewersOf := func(id string) []string {
+		t.Helper()
+		p, err := pr.Get(db, domain.PRKey{PullRequestID: id})
+		require.NoError(t, err)
+		return p.AssignedReviewersIDs
+	}
+	full := reviewersOf("bf_full")
+
+	t.Run("dry run changes nothing", func(t *testing.T) {
+		result, err := prService.BackfillReviewers(t.Context(), "team_bf", service.BackfillOptions{DryRun: true})
+		require.NoError(t, err)
+		require.Len(t, result.PRs, 2)
+		assert.Equal(t, 2, result.Touched())
+		assert.Zero(t, result.Uncoverable())
+		assert.Len(t, reviewersOf("bf_one"), 1)
+		assert.Empty(t, reviewersOf("bf_none"))
+	})
+
+	t.Run("max_prs leaves the rest for a later run", func(t *testing.T) {
+		result, err := prService.BackfillReviewers(t.Context(), "team_bf", service.BackfillOptions{MaxPRs: 1})
+		require.NoError(t, err)
+		require.Len(t, result.PRs, 1)
+		assert.Equal(t, 1, result.Remaining)
+		assert.Len(t, result.PRs[0].Added, 1)
+		assert.Zero(t, result.PRs[0].Missing)
+	})
+
+	t.Run("all teams", func(t *testing.T) {
+		result, err := prService.BackfillReviewers(t.Context(), "", service.BackfillOptions{})
+		require.NoError(t, err)
+		require.Len(t, result.PRs, 2)
+		assert.Zero(t, result.Remaining)
+		assert.Equal(t, 2, result.Touched())
+		assert.Equal(t, 1, result.Uncoverable())
+
+		for _, p := range result.PRs {
+			if p.PR.PullRequestID == "bfs_none" {
+				assert.Equal(t, []string{"r1_bfs"}, p.Added)
+				assert.Equal(t, 1, p.Missing)
+			}
+		}
+		assert.Len(t, reviewersOf("bf_one"), 2)
+		assert.Len(t, reviewersOf("bf_none"), 2)
+		assert.NotContains(t, reviewersOf("bf_none"), "a_bf")
+		assert.Equal(t, []string{"r1_bfs"}, reviewersOf("bfs_none"))
+	})
+
+	t.Run("covered and merged PRs are untouched", func(t *testing.T) {
+		assert.ElementsMatch(t, full, reviewersOf("bf_full"))
+		assert.Empty(t, reviewersOf("bf_merged"))
+	})
+
+	t.Run("nothing left to backfill", func(t *testing.T) {
+		result, err := prService.BackfillReviewers(t.Context(), "team_bf", service.BackfillOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, result.PRs)
+	})
+
+	t.Run("runs that added reviewers are audited", func(t *testing.T) {
+		entries, err := audit.List(db, audit.Filter{Limit: 10})
+		require.NoError(t, err)
+		targets := make([]string, 0)
+		for _, e := range entries {
+			if e.Action == domain.AuditReviewersBackfill {
+				targets = append(targets, e.Target)
+			}
+		}
+		assert.Equal(t, []string{"org:" + domain.DefaultOrgID, "team:team_bf"}, targets)
+	})
+
+	t.Run("unknown team", func(t *testing.T) {
+		_, err := prService.BackfillReviewers(t.Context(), "ghost_bf", service.BackfillOptions{})
+		assert.ErrorIs(t, err, service.ErrTeamNotFound)
+	})
+}
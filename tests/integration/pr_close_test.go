@@ -0,0 +1,105 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestPRService_ClosePR(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	require.NoError(t, team.Create(db, "team_close"))
+	for _, id := range []string{"author_close", "reviewer_close_1", "reviewer_close_2", "reviewer_close_3"} {
+		require.NoError(t, user.Create(db, &domain.User{UserID: id, Username: id, TeamName: "team_close", IsActive: true}))
+	}
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	userService := service.NewUserService(db, prService)
+
+	created, err := prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: "pr_close"}, "Abandoned", "author_close", nil, domain.PRDetails{})
+	require.NoError(t, err)
+	require.Len(t, created.AssignedReviewersIDs, 2)
+	reviewerID := created.AssignedReviewersIDs[0]
+
+	closeEvents := func(t *testing.T) int {
+		t.Helper()
+		var n int
+		require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM assignment_history WHERE pull_request_id = $1 AND action = $2`,
+			"pr_close", domain.ActionClose).Scan(&n))
+		return n
+	}
+
+	t.Run("closes an open PR", func(t *testing.T) {
+		version := prService.DataVersion().Current()
+		closed, err := prService.ClosePR(t.Context(), domain.PRKey{PullRequestID: "pr_close"})
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusClosed, closed.Status)
+		assert.NotNil(t, closed.ClosedAt)
+		assert.Nil(t, closed.MergedAt)
+		assert.Equal(t, 1, closeEvents(t))
+		assert.Greater(t, prService.DataVersion().Current(), version)
+	})
+
+	t.Run("close is idempotent", func(t *testing.T) {
+		first, err := prService.GetPR(t.Context(), domain.PRKey{PullRequestID: "pr_close"})
+		require.NoError(t, err)
+
+		version := prService.DataVersion().Current()
+		again, err := prService.ClosePR(t.Context(), domain.PRKey{PullRequestID: "pr_close"})
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusClosed, again.Status)
+		assert.Equal(t, first.ClosedAt, again.ClosedAt)
+		assert.Equal(t, 1, closeEvents(t), "closing again records nothing")
+		assert.Equal(t, version, prService.DataVersion().Current())
+	})
+
+	t.Run("closed PR cannot be merged or reassigned", func(t *testing.T) {
+		_, err := prService.MergePR(t.Context(), domain.PRKey{PullRequestID: "pr_close"}, service.MergeOptions{})
+		assert.ErrorIs(t, err, service.ErrPRClosed)
+
+		_, _, err = prService.ReassignPR(t.Context(), domain.PRKey{PullRequestID: "pr_close"}, reviewerID, service.ReassignOptions{})
+		assert.ErrorIs(t, err, service.ErrPRClosed)
+	})
+
+	t.Run("merged PR cannot be closed", func(t *testing.T) {
+		_, err := prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: "pr_close_merged"}, "Done", "author_close", nil, domain.PRDetails{})
+		require.NoError(t, err)
+		_, err = prService.MergePR(t.Context(), domain.PRKey{PullRequestID: "pr_close_merged"}, service.MergeOptions{})
+		require.NoError(t, err)
+
+		_, err = prService.ClosePR(t.Context(), domain.PRKey{PullRequestID: "pr_close_merged"})
+		assert.ErrorIs(t, err, service.ErrPRMerged)
+	})
+
+	t.Run("closed PR does not count as open review", func(t *testing.T) {
+		candidates, err := user.GetReassignCandidates(db, "team_close", "author_close")
+		require.NoError(t, err)
+		for _, c := range candidates {
+			assert.Zero(t, c.OpenReviews, c.UserID)
+		}
+
+		reviews, err := userService.GetUserReviews(t.Context(), reviewerID, nil)
+		require.NoError(t, err)
+		statuses := make(map[string]domain.PRStatus, len(reviews))
+		for _, r := range reviews {
+			statuses[r.PullRequestID] = r.Status
+		}
+		assert.Equal(t, domain.StatusClosed, statuses["pr_close"])
+	})
+
+	t.Run("error - PR not found", func(t *testing.T) {
+		_, err := prService.ClosePR(t.Context(), domain.PRKey{PullRequestID: "ghost_pr"})
+		assert.ErrorIs(t, err, service.ErrPRNotFound)
+	})
+}
@@ -0,0 +1,87 @@
+package integration
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestPRService_AuthorCreateLimit(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	require.NoError(t, team.Create(db, "team_cl"))
+	for _, id := range []string{"bot_cl", "human_cl", "reviewer_cl_1", "reviewer_cl_2"} {
+		require.NoError(t, user.Create(db, &domain.User{UserID: id, Username: id, TeamName: "team_cl", IsActive: true}))
+	}
+
+	const limit = 3
+	start := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	clock := &tests.FakeClock{Current: start}
+	prService := service.NewPRService(db, service.NewReviewerAssigner()).WithClock(clock).WithAuthorCreateLimit(limit)
+
+	n := 0
+	create := func(t *testing.T, authorID string) error {
+		t.Helper()
+		n++
+		_, err := prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: fmt.Sprintf("pr_cl_%d", n)}, "Bump", authorID, nil, domain.PRDetails{})
+		return err
+	}
+
+	// Exactly at the limit: one creation every ten minutes.
+	for i := 0; i < limit; i++ {
+		require.NoError(t, create(t, "bot_cl"), "create %d", i+1)
+		clock.Advance(10 * time.Minute)
+	}
+
+	t.Run("one over the limit", func(t *testing.T) {
+		err := create(t, "bot_cl")
+		require.ErrorIs(t, err, service.ErrAuthorRateLimited)
+		var limited *service.AuthorRateLimitError
+		require.ErrorAs(t, err, &limited)
+		assert.Equal(t, limit, limited.Limit)
+		assert.Equal(t, limit, limited.Used)
+		assert.Equal(t, 30*time.Minute, limited.RetryAfter, "the first creation leaves the window an hour after it")
+	})
+
+	t.Run("other authors are not limited", func(t *testing.T) {
+		require.NoError(t, create(t, "human_cl"))
+	})
+
+	t.Run("bypass", func(t *testing.T) {
+		n++
+		_, err := prService.CreatePR(service.SkipCreateLimit(t.Context()), domain.PRKey{PullRequestID: fmt.Sprintf("pr_cl_%d", n)}, "Admin", "bot_cl", nil, domain.PRDetails{})
+		require.NoError(t, err)
+	})
+
+	t.Run("rolling window", func(t *testing.T) {
+		// The first creation is now exactly one hour old and still counted.
+		clock.Current = start.Add(time.Hour)
+		require.ErrorIs(t, create(t, "bot_cl"), service.ErrAuthorRateLimited)
+
+		clock.Current = start.Add(time.Hour + time.Second)
+		err := create(t, "bot_cl")
+		require.ErrorIs(t, err, service.ErrAuthorRateLimited, "the bypassed creation still counts")
+
+		clock.Current = start.Add(time.Hour + 10*time.Minute + time.Second)
+		require.NoError(t, create(t, "bot_cl"))
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		unlimited := service.NewPRService(db, service.NewReviewerAssigner()).WithClock(clock)
+		n++
+		_, err := unlimited.CreatePR(t.Context(), domain.PRKey{PullRequestID: fmt.Sprintf("pr_cl_%d", n)}, "Bump", "bot_cl", nil, domain.PRDetails{})
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,71 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestPRRepository_Details(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	require.NoError(t, team.Create(db, "team_det"))
+	require.NoError(t, user.Create(db, &domain.User{UserID: "author_det", Username: "author", TeamName: "team_det", IsActive: true}))
+
+	t.Run("details are stored and read back", func(t *testing.T) {
+		description := "Adds full-text search"
+		url := "https://github.com/example/repo/pull/1"
+		require.NoError(t, pr.Create(db, &domain.PullRequest{
+			PullRequestID: "pr_det_1", PullRequestName: "Search", AuthorID: "author_det", TeamName: "team_det",
+			Status: domain.StatusOpen, Description: &description, ExternalURL: &url,
+		}))
+
+		got, err := pr.Get(db, domain.PRKey{PullRequestID: "pr_det_1"})
+		require.NoError(t, err)
+		require.NotNil(t, got.Description)
+		require.NotNil(t, got.ExternalURL)
+		assert.Equal(t, description, *got.Description)
+		assert.Equal(t, url, *got.ExternalURL)
+	})
+
+	t.Run("missing details stay NULL", func(t *testing.T) {
+		require.NoError(t, pr.Create(db, &domain.PullRequest{
+			PullRequestID: "pr_det_2", PullRequestName: "Plain", AuthorID: "author_det", TeamName: "team_det",
+			Status: domain.StatusOpen,
+		}))
+
+		got, err := pr.Get(db, domain.PRKey{PullRequestID: "pr_det_2"})
+		require.NoError(t, err)
+		assert.Nil(t, got.Description)
+		assert.Nil(t, got.ExternalURL)
+	})
+
+	t.Run("service passes details through", func(t *testing.T) {
+		prService := service.NewPRService(db, service.NewReviewerAssigner())
+		url := "http://git.example.com/pr/3"
+
+		created, err := prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: "pr_det_3"}, "Via service", "author_det", nil, domain.PRDetails{ExternalURL: &url})
+		require.NoError(t, err)
+		assert.Nil(t, created.Description)
+		require.NotNil(t, created.ExternalURL)
+		assert.Equal(t, url, *created.ExternalURL)
+
+		got, err := prService.GetPR(t.Context(), domain.PRKey{PullRequestID: "pr_det_3"})
+		require.NoError(t, err)
+		assert.Equal(t, created, got)
+
+		_, err = prService.GetPR(t.Context(), domain.PRKey{PullRequestID: "ghost_pr"})
+		assert.ErrorIs(t, err, service.ErrPRNotFound)
+	})
+}
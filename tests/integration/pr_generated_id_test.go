@@ -0,0 +1,82 @@
+package integration
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+var uuidV7Pattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestPRService_CreatePR_GeneratedID(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	teamService := service.NewTeamService(db, prService)
+	_, _, err = teamService.CreateTeam(t.Context(), &domain.Team{
+		TeamName: "team_gen",
+		Members: []domain.TeamMember{
+			{UserID: "author_gen", Username: "author", IsActive: true},
+			{UserID: "rev_gen", Username: "rev", IsActive: true},
+		},
+	}, service.CreateTeamOptions{})
+	require.NoError(t, err)
+
+	t.Run("missing id is generated", func(t *testing.T) {
+		first, err := prService.CreatePR(t.Context(), domain.PRKey{}, "Generated", "author_gen", nil, domain.PRDetails{})
+		require.NoError(t, err)
+		second, err := prService.CreatePR(t.Context(), domain.PRKey{}, "Generated", "author_gen", nil, domain.PRDetails{})
+		require.NoError(t, err)
+
+		assert.Regexp(t, uuidV7Pattern, first.PullRequestID)
+		assert.Regexp(t, uuidV7Pattern, second.PullRequestID)
+		assert.NotEqual(t, first.PullRequestID, second.PullRequestID)
+		assert.Equal(t, []string{"rev_gen"}, first.AssignedReviewersIDs)
+	})
+
+	t.Run("given id is kept", func(t *testing.T) {
+		created, err := prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: "pr_gen_given"}, "Given", "author_gen", nil, domain.PRDetails{})
+		require.NoError(t, err)
+		assert.Equal(t, "pr_gen_given", created.PullRequestID)
+
+		_, err = prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: "pr_gen_given"}, "Again", "author_gen", nil, domain.PRDetails{})
+		assert.ErrorIs(t, err, service.ErrPRExists)
+		var exists *service.PRExistsError
+		require.ErrorAs(t, err, &exists)
+		assert.Equal(t, "Given", exists.Existing.PullRequestName)
+		assert.Equal(t, "author_gen", exists.Existing.AuthorID)
+		assert.Equal(t, []string{"rev_gen"}, exists.Existing.AssignedReviewersIDs)
+	})
+
+	t.Run("taken generated id is retried with a new one", func(t *testing.T) {
+		ids := []string{"pr_gen_given", "pr_gen_retry"}
+		generating := service.NewPRService(db, service.NewReviewerAssigner()).WithIDGenerator(func() string {
+			id := ids[0]
+			ids = ids[1:]
+			return id
+		})
+
+		created, err := generating.CreatePR(t.Context(), domain.PRKey{}, "Retried", "author_gen", nil, domain.PRDetails{})
+		require.NoError(t, err)
+		assert.Equal(t, "pr_gen_retry", created.PullRequestID)
+		assert.Empty(t, ids)
+	})
+
+	t.Run("gives up after repeated collisions", func(t *testing.T) {
+		generating := service.NewPRService(db, service.NewReviewerAssigner()).WithIDGenerator(func() string {
+			return "pr_gen_given"
+		})
+
+		_, err := generating.CreatePR(t.Context(), domain.PRKey{}, "Colliding", "author_gen", nil, domain.PRDetails{})
+		assert.ErrorIs(t, err, service.ErrPRExists)
+	})
+}
@@ -0,0 +1,84 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/stats"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestPRService_MergedBy(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	require.NoError(t, team.Create(db, "team_mb"))
+	for _, id := range []string{"author_mb", "lead_mb", "other_mb"} {
+		require.NoError(t, user.Create(db, &domain.User{UserID: id, Username: id, TeamName: "team_mb", IsActive: true}))
+	}
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	statsService := service.NewStatsService(db, service.NewSystemClock())
+
+	for _, id := range []string{"pr_mb_1", "pr_mb_2", "pr_mb_3"} {
+		_, err := prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: id}, id, "author_mb", nil, domain.PRDetails{})
+		require.NoError(t, err)
+	}
+
+	t.Run("records who merged", func(t *testing.T) {
+		merged, err := prService.MergePR(t.Context(), domain.PRKey{PullRequestID: "pr_mb_1"}, service.MergeOptions{MergedBy: "lead_mb"})
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusMerged, merged.Status)
+		assert.Equal(t, "lead_mb", merged.MergedBy)
+	})
+
+	t.Run("repeat merge keeps the original actor", func(t *testing.T) {
+		again, err := prService.MergePR(t.Context(), domain.PRKey{PullRequestID: "pr_mb_1"}, service.MergeOptions{MergedBy: "other_mb"})
+		require.NoError(t, err)
+		assert.Equal(t, "lead_mb", again.MergedBy)
+	})
+
+	t.Run("merge without actor", func(t *testing.T) {
+		merged, err := prService.MergePR(t.Context(), domain.PRKey{PullRequestID: "pr_mb_2"}, service.MergeOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusMerged, merged.Status)
+		assert.Empty(t, merged.MergedBy)
+	})
+
+	t.Run("unknown actor", func(t *testing.T) {
+		_, err := prService.MergePR(t.Context(), domain.PRKey{PullRequestID: "pr_mb_3"}, service.MergeOptions{MergedBy: "ghost_mb"})
+		assert.ErrorIs(t, err, service.ErrUserNotFound)
+
+		pr, err := prService.GetPR(t.Context(), domain.PRKey{PullRequestID: "pr_mb_3"})
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusOpen, pr.Status)
+	})
+
+	t.Run("merger statistics", func(t *testing.T) {
+		st, err := statsService.GetStatistics(t.Context(), stats.Period{})
+		require.NoError(t, err)
+
+		counts := make(map[string]int64)
+		for _, m := range st.MergerStats {
+			counts[m.UserID] = m.Count
+		}
+		assert.Equal(t, int64(1), counts["lead_mb"])
+		assert.Equal(t, int64(0), counts["other_mb"])
+		require.NotEmpty(t, st.MergerStats)
+		assert.Equal(t, "lead_mb", st.MergerStats[0].UserID)
+	})
+
+	t.Run("reopen clears the actor", func(t *testing.T) {
+		reopened, err := prService.ReopenPR(t.Context(), domain.PRKey{PullRequestID: "pr_mb_1"})
+		require.NoError(t, err)
+		assert.Empty(t, reopened.MergedBy)
+	})
+}
@@ -0,0 +1,77 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestPRRepository_GetOpenByTeam(t *testing.T) {
+	t.Parallel()
+	db := tests.WithTestTx(t)
+
+	require.NoError(t, team.Create(db, "team_obt"))
+	for _, id := range []string{"author_obt", "r1_obt", "r2_obt"} {
+		require.NoError(t, user.Create(db, &domain.User{UserID: id, Username: id, TeamName: "team_obt", IsActive: true}))
+	}
+
+	create := func(id string, reviewers ...string) domain.PRKey {
+		key := domain.PRKey{RepositoryName: "repo_obt", PullRequestID: id}
+		require.NoError(t, pr.Create(db, &domain.PullRequest{
+			RepositoryName:  key.RepositoryName,
+			PullRequestID:   key.PullRequestID,
+			PullRequestName: id,
+			AuthorID:        "author_obt",
+			TeamName:        "team_obt",
+			Status:          domain.StatusOpen,
+		}))
+		for _, r := range reviewers {
+			require.NoError(t, pr.InsertReviewer(db, key, r, time.Now().UTC()))
+		}
+		return key
+	}
+
+	withRequired := create("b", "r2_obt")
+	require.NoError(t, pr.InsertRequiredReviewer(db, withRequired, "r1_obt", time.Now().UTC()))
+	create("a", "r1_obt")
+	create("c")
+	merged := create("d", "r1_obt")
+	require.NoError(t, pr.UpdateStatusToMerged(db, merged, "", time.Now()))
+
+	prs, err := pr.GetOpenByTeam(db, "team_obt")
+	require.NoError(t, err)
+	require.Len(t, prs, 3, "merged pull requests are left out")
+
+	assert.Equal(t, "a", prs[0].PullRequestID)
+	assert.Equal(t, []string{"r1_obt"}, prs[0].AssignedReviewersIDs)
+	assert.Empty(t, prs[0].RequiredReviewersIDs)
+
+	assert.Equal(t, withRequired, prs[1].Key())
+	assert.Equal(t, "author_obt", prs[1].AuthorID)
+	assert.Equal(t, []string{"r1_obt", "r2_obt"}, prs[1].AssignedReviewersIDs)
+	assert.Equal(t, []string{"r1_obt"}, prs[1].RequiredReviewersIDs)
+
+	assert.Equal(t, "c", prs[2].PullRequestID)
+	assert.Empty(t, prs[2].AssignedReviewersIDs)
+
+	t.Run("replacing a required reviewer drops the flag", func(t *testing.T) {
+		require.NoError(t, pr.ReplaceReviewer(db, withRequired, "r1_obt", "author_obt", domain.SourceAuto, time.Now().UTC()))
+
+		prs, err := pr.GetOpenByTeam(db, "team_obt")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"author_obt", "r2_obt"}, prs[1].AssignedReviewersIDs)
+		assert.Empty(t, prs[1].RequiredReviewersIDs)
+
+		replaced, err := pr.Get(db, withRequired)
+		require.NoError(t, err)
+		assert.Equal(t, domain.SourceAuto, sourcesOf(replaced)["author_obt"])
+	})
+}
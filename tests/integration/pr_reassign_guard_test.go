@@ -0,0 +1,231 @@
+package integration
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+// reassignBlockedBy starts a reassign of oldReviewerID while tx holds a lock it needs, waits until
+// the reassign has picked its candidate and is waiting for the lock, commits tx and returns the outcome.
+func reassignBlockedBy(t *testing.T, db *sql.DB, tx *sql.Tx, prService *service.PRService, key domain.PRKey, oldReviewerID string) (string, error) {
+	t.Helper()
+
+	var waiting int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM pg_stat_activity WHERE datname = current_database() AND wait_event_type = 'Lock'`).Scan(&waiting))
+	require.Zero(t, waiting)
+
+	type outcome struct {
+		replacedBy string
+		err        error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		_, replacedBy, err := prService.ReassignPR(t.Context(), key, oldReviewerID, service.ReassignOptions{Force: true})
+		done <- outcome{replacedBy, err}
+	}()
+
+	require.Eventually(t, func() bool {
+		err := db.QueryRow(`SELECT COUNT(*) FROM pg_stat_activity WHERE datname = current_database() AND wait_event_type = 'Lock'`).Scan(&waiting)
+		return err == nil && waiting == 1
+	}, 5*time.Second, 10*time.Millisecond, "the reassign never waited for the lock")
+	require.NoError(t, tx.Commit())
+
+	select {
+	case o := <-done:
+		return o.replacedBy, o.err
+	case <-time.After(5 * time.Second):
+		t.Fatal("the reassign did not finish after the lock was released")
+		return "", nil
+	}
+}
+
+func TestPRService_ReassignPR_RechecksCandidate(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	// The PR's reviewers are r1 and r2, so the only candidate for replacing r1 is cand.
+	require.NoError(t, team.Create(db, "team_guard"))
+	for _, id := range []string{"author_guard", "r1_guard", "r2_guard", "cand_guard"} {
+		require.NoError(t, user.Create(db, &domain.User{UserID: id, Username: id, TeamName: "team_guard", IsActive: true}))
+	}
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+
+	createPR := func(t *testing.T, id string) domain.PRKey {
+		t.Helper()
+		key := domain.PRKey{PullRequestID: id}
+		require.NoError(t, pr.Create(db, &domain.PullRequest{
+			PullRequestID:   id,
+			PullRequestName: "Guarded",
+			AuthorID:        "author_guard",
+			TeamName:        "team_guard",
+			Status:          domain.StatusOpen,
+		}))
+		require.NoError(t, pr.InsertReviewer(db, key, "r1_guard", time.Now().UTC()))
+		require.NoError(t, pr.InsertReviewer(db, key, "r2_guard", time.Now().UTC()))
+		return key
+	}
+	reviewersOf := func(t *testing.T, key domain.PRKey) []string {
+		t.Helper()
+		p, err := pr.Get(db, key)
+		require.NoError(t, err)
+		return p.AssignedReviewersIDs
+	}
+
+	t.Run("candidate deactivated after being picked", func(t *testing.T) {
+		key := createPR(t, "pr_guard_inactive")
+		tx, err := db.Begin()
+		require.NoError(t, err)
+		_, err = tx.Exec(`UPDATE users SET is_active = false WHERE user_id = $1`, "cand_guard")
+		require.NoError(t, err)
+
+		_, err = reassignBlockedBy(t, db, tx, prService, key, "r1_guard")
+		var inactive *service.InactiveReviewerError
+		require.ErrorAs(t, err, &inactive)
+		assert.Equal(t, "cand_guard", inactive.UserID)
+		assert.ElementsMatch(t, []string{"r1_guard", "r2_guard"}, reviewersOf(t, key))
+
+		_, err = db.Exec(`UPDATE users SET is_active = true WHERE user_id = $1`, "cand_guard")
+		require.NoError(t, err)
+	})
+
+	t.Run("candidate assigned after being picked", func(t *testing.T) {
+		key := createPR(t, "pr_guard_assigned")
+		tx, err := db.Begin()
+		require.NoError(t, err)
+		require.NoError(t, pr.InsertReviewer(tx, key, "cand_guard", time.Now().UTC()))
+
+		_, err = reassignBlockedBy(t, db, tx, prService, key, "r1_guard")
+		require.ErrorIs(t, err, service.ErrReviewerAssigned)
+		assert.ElementsMatch(t, []string{"r1_guard", "r2_guard", "cand_guard"}, reviewersOf(t, key))
+	})
+
+	t.Run("candidate became the author after being picked", func(t *testing.T) {
+		key := createPR(t, "pr_guard_author")
+		tx, err := db.Begin()
+		require.NoError(t, err)
+		_, err = tx.Exec(`UPDATE pull_requests SET author_id = $2 WHERE pull_request_id = $1`, key.PullRequestID, "cand_guard")
+		require.NoError(t, err)
+
+		_, err = reassignBlockedBy(t, db, tx, prService, key, "r1_guard")
+		require.ErrorIs(t, err, service.ErrReviewerIsAuthor)
+		assert.ElementsMatch(t, []string{"r1_guard", "r2_guard"}, reviewersOf(t, key))
+	})
+
+	t.Run("candidate unchanged", func(t *testing.T) {
+		key := createPR(t, "pr_guard_ok")
+		_, replacedBy, err := prService.ReassignPR(t.Context(), key, "r1_guard", service.ReassignOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "cand_guard", replacedBy)
+		assert.ElementsMatch(t, []string{"cand_guard", "r2_guard"}, reviewersOf(t, key))
+	})
+}
+
+func TestPRService_ReassignPR_ConcurrentWithMembershipChanges(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	const members = 6
+	require.NoError(t, team.Create(db, "team_hammer"))
+	require.NoError(t, user.Create(db, &domain.User{UserID: "author_hammer", Username: "author", TeamName: "team_hammer", IsActive: true}))
+	for i := range members {
+		id := fmt.Sprintf("m%d_hammer", i)
+		require.NoError(t, user.Create(db, &domain.User{UserID: id, Username: id, TeamName: "team_hammer", IsActive: true}))
+	}
+	key := domain.PRKey{PullRequestID: "pr_hammer"}
+	require.NoError(t, pr.Create(db, &domain.PullRequest{
+		PullRequestID:   key.PullRequestID,
+		PullRequestName: "Hammered",
+		AuthorID:        "author_hammer",
+		TeamName:        "team_hammer",
+		Status:          domain.StatusOpen,
+	}))
+	require.NoError(t, pr.InsertReviewer(db, key, "m0_hammer", time.Now().UTC()))
+	require.NoError(t, pr.InsertReviewer(db, key, "m1_hammer", time.Now().UTC()))
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	userService := service.NewUserService(db, prService)
+
+	// Reassigners replace whichever reviewers they last saw while a toggler keeps deactivating and
+	// reactivating members, so candidates are routinely stale by the time they are assigned.
+	stop := make(chan struct{})
+	var toggler sync.WaitGroup
+	toggler.Add(1)
+	go func() {
+		defer toggler.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			_, err := userService.SetIsActive(t.Context(), fmt.Sprintf("m%d_hammer", i%members), i/members%2 == 1)
+			assert.NoError(t, err)
+		}
+	}()
+
+	var reassigners sync.WaitGroup
+	var mu sync.Mutex
+	succeeded := 0
+	for range 4 {
+		reassigners.Add(1)
+		go func() {
+			defer reassigners.Done()
+			for i := range 25 {
+				p, err := pr.Get(db, key)
+				if !assert.NoError(t, err) {
+					return
+				}
+				_, _, err = prService.ReassignPR(t.Context(), key, p.AssignedReviewersIDs[i%len(p.AssignedReviewersIDs)], service.ReassignOptions{Force: true})
+				switch {
+				case err == nil:
+					mu.Lock()
+					succeeded++
+					mu.Unlock()
+				case errors.Is(err, service.ErrNoCandidate),
+					errors.Is(err, service.ErrReviewerNotAssigned),
+					errors.Is(err, service.ErrReviewerAssigned),
+					errors.Is(err, service.ErrInactiveReviewer),
+					errors.Is(err, service.ErrReviewerIsAuthor):
+				default:
+					assert.NoError(t, err)
+				}
+			}
+		}()
+	}
+	reassigners.Wait()
+	close(stop)
+	toggler.Wait()
+
+	assert.Positive(t, succeeded)
+	final, err := pr.Get(db, key)
+	require.NoError(t, err)
+	assert.Len(t, final.AssignedReviewersIDs, 2)
+	assert.NotContains(t, final.AssignedReviewersIDs, "author_hammer")
+
+	// Each successful reassign recorded one replacement and none of them was the author.
+	var replacements, byAuthor int
+	require.NoError(t, db.QueryRow(`
+		SELECT COUNT(*), COUNT(*) FILTER (WHERE new_user_id = $3)
+		FROM assignment_history WHERE pull_request_id = $1 AND action = $2
+	`, key.PullRequestID, domain.ActionReassign, "author_hammer").Scan(&replacements, &byAuthor))
+	assert.Equal(t, succeeded, replacements)
+	assert.Zero(t, byAuthor)
+}
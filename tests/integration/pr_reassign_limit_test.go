@@ -0,0 +1,58 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestPRService_ReassignLimit(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	require.NoError(t, team.Create(db, "team_churn"))
+	for _, id := range []string{"author_churn", "reviewer_churn_1", "reviewer_churn_2", "reviewer_churn_3"} {
+		require.NoError(t, user.Create(db, &domain.User{UserID: id, Username: id, TeamName: "team_churn", IsActive: true}))
+	}
+
+	const limit = 3
+	prService := service.NewPRService(db, service.NewReviewerAssigner()).WithReassignLimit(limit)
+	key := domain.PRKey{PullRequestID: "pr_churn"}
+
+	created, err := prService.CreatePR(t.Context(), key, "Flaky", "author_churn", nil, domain.PRDetails{})
+	require.NoError(t, err)
+	require.Len(t, created.AssignedReviewersIDs, 2)
+	assert.Zero(t, created.ReassignmentCount)
+
+	// The one teammate left out of the review keeps swapping places with the same slot.
+	reviewerID := created.AssignedReviewersIDs[0]
+	for i := 1; i <= limit; i++ {
+		updated, replacedBy, err := prService.ReassignPR(t.Context(), key, reviewerID, service.ReassignOptions{})
+		require.NoError(t, err, "reassign %d", i)
+		assert.Equal(t, i, updated.ReassignmentCount)
+		reviewerID = replacedBy
+	}
+
+	_, _, err = prService.ReassignPR(t.Context(), key, reviewerID, service.ReassignOptions{})
+	require.ErrorIs(t, err, service.ErrReassignLimit)
+
+	blocked, err := prService.GetPR(t.Context(), key)
+	require.NoError(t, err)
+	assert.Equal(t, limit, blocked.ReassignmentCount, "the refused reassign is rolled back")
+	assert.Contains(t, blocked.AssignedReviewersIDs, reviewerID)
+
+	forced, replacedBy, err := prService.ReassignPR(t.Context(), key, reviewerID, service.ReassignOptions{Force: true})
+	require.NoError(t, err)
+	assert.Equal(t, limit+1, forced.ReassignmentCount)
+	assert.NotContains(t, forced.AssignedReviewersIDs, reviewerID)
+	assert.Contains(t, forced.AssignedReviewersIDs, replacedBy)
+}
@@ -0,0 +1,116 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/history"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestPRService_ReopenPR(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	require.NoError(t, team.Create(db, "team_reopen"))
+	for _, id := range []string{"author_reopen", "reviewer_reopen_1", "reviewer_reopen_2", "reviewer_reopen_3"} {
+		require.NoError(t, user.Create(db, &domain.User{UserID: id, Username: id, TeamName: "team_reopen", IsActive: true}))
+	}
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	userService := service.NewUserService(db, prService)
+
+	t.Run("reopen after merge, then merge again", func(t *testing.T) {
+		key := domain.PRKey{PullRequestID: "pr_reopen_merged"}
+		created, err := prService.CreatePR(t.Context(), key, "Fat-fingered", "author_reopen", nil, domain.PRDetails{})
+		require.NoError(t, err)
+		_, err = prService.MergePR(t.Context(), key, service.MergeOptions{})
+		require.NoError(t, err)
+
+		version := prService.DataVersion().Current()
+		reopened, err := prService.ReopenPR(t.Context(), key)
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusOpen, reopened.Status)
+		assert.Greater(t, prService.DataVersion().Current(), version)
+		assert.Nil(t, reopened.MergedAt)
+		assert.Nil(t, reopened.ClosedAt)
+		assert.ElementsMatch(t, created.AssignedReviewersIDs, reopened.AssignedReviewersIDs)
+
+		events, err := history.GetByPR(db, key)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, domain.ActionReopen, events[0].Action)
+
+		merged, err := prService.MergePR(t.Context(), key, service.MergeOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusMerged, merged.Status)
+		assert.NotNil(t, merged.MergedAt)
+	})
+
+	t.Run("reopen after close", func(t *testing.T) {
+		key := domain.PRKey{PullRequestID: "pr_reopen_closed"}
+		_, err := prService.CreatePR(t.Context(), key, "Abandoned", "author_reopen", nil, domain.PRDetails{})
+		require.NoError(t, err)
+		_, err = prService.ClosePR(t.Context(), key)
+		require.NoError(t, err)
+
+		reopened, err := prService.ReopenPR(t.Context(), key)
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusOpen, reopened.Status)
+		assert.Nil(t, reopened.ClosedAt)
+
+		_, _, err = prService.ReassignPR(t.Context(), key, reopened.AssignedReviewersIDs[0], service.ReassignOptions{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("inactive reviewers are replaced", func(t *testing.T) {
+		key := domain.PRKey{PullRequestID: "pr_reopen_inactive"}
+		created, err := prService.CreatePR(t.Context(), key, "Stale", "author_reopen", nil, domain.PRDetails{})
+		require.NoError(t, err)
+		require.Len(t, created.AssignedReviewersIDs, 2)
+		_, err = prService.MergePR(t.Context(), key, service.MergeOptions{})
+		require.NoError(t, err)
+
+		gone := created.AssignedReviewersIDs[0]
+		_, err = userService.SetIsActive(t.Context(), gone, false)
+		require.NoError(t, err)
+
+		reopened, err := prService.ReopenPR(t.Context(), key)
+		require.NoError(t, err)
+		assert.Len(t, reopened.AssignedReviewersIDs, 2)
+		assert.NotContains(t, reopened.AssignedReviewersIDs, gone)
+		assert.Contains(t, reopened.AssignedReviewersIDs, created.AssignedReviewersIDs[1])
+
+		_, err = userService.SetIsActive(t.Context(), gone, true)
+		require.NoError(t, err)
+	})
+
+	t.Run("open PR is returned unchanged", func(t *testing.T) {
+		key := domain.PRKey{PullRequestID: "pr_reopen_open"}
+		created, err := prService.CreatePR(t.Context(), key, "Open", "author_reopen", nil, domain.PRDetails{})
+		require.NoError(t, err)
+
+		version := prService.DataVersion().Current()
+		reopened, err := prService.ReopenPR(t.Context(), key)
+		require.NoError(t, err)
+		assert.Equal(t, created.AssignedReviewersIDs, reopened.AssignedReviewersIDs)
+		assert.Equal(t, version, prService.DataVersion().Current())
+
+		events, err := history.GetByPR(db, key)
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
+
+	t.Run("error - PR not found", func(t *testing.T) {
+		_, err := prService.ReopenPR(t.Context(), domain.PRKey{PullRequestID: "ghost_pr"})
+		assert.ErrorIs(t, err, service.ErrPRNotFound)
+	})
+}
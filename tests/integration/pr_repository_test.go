@@ -0,0 +1,78 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestPRService_Repositories(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	require.NoError(t, team.Create(db, "team_repo"))
+	require.NoError(t, user.Create(db, &domain.User{UserID: "author_repo", Username: "author", TeamName: "team_repo", IsActive: true}))
+	require.NoError(t, user.Create(db, &domain.User{UserID: "reviewer_repo", Username: "reviewer", TeamName: "team_repo", IsActive: true}))
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	userService := service.NewUserService(db, prService)
+
+	backend := domain.PRKey{RepositoryName: "backend", PullRequestID: "PR-1"}
+	frontend := domain.PRKey{RepositoryName: "frontend", PullRequestID: "PR-1"}
+	legacy := domain.PRKey{PullRequestID: "PR-1"}
+
+	t.Run("same id in different repositories", func(t *testing.T) {
+		for _, key := range []domain.PRKey{backend, frontend, legacy} {
+			created, err := prService.CreatePR(t.Context(), key, "Change in "+key.String(), "author_repo", nil, domain.PRDetails{})
+			require.NoError(t, err)
+			assert.Equal(t, key, created.Key())
+			assert.Equal(t, []string{"reviewer_repo"}, created.AssignedReviewersIDs)
+		}
+	})
+
+	t.Run("PR_EXISTS is scoped per repository", func(t *testing.T) {
+		_, err := prService.CreatePR(t.Context(), backend, "Again", "author_repo", nil, domain.PRDetails{})
+		assert.ErrorIs(t, err, service.ErrPRExists)
+
+		_, err = prService.CreatePR(t.Context(), domain.PRKey{RepositoryName: "mobile", PullRequestID: "PR-1"}, "New", "author_repo", nil, domain.PRDetails{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("merge affects only its repository", func(t *testing.T) {
+		merged, err := prService.MergePR(t.Context(), backend, service.MergeOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusMerged, merged.Status)
+
+		other, err := prService.GetPR(t.Context(), frontend)
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusOpen, other.Status)
+	})
+
+	t.Run("reviews are filtered by repository", func(t *testing.T) {
+		all, err := userService.GetUserReviews(t.Context(), "reviewer_repo", nil)
+		require.NoError(t, err)
+		assert.Len(t, all, 4)
+
+		name := "frontend"
+		filtered, err := userService.GetUserReviews(t.Context(), "reviewer_repo", &name)
+		require.NoError(t, err)
+		require.Len(t, filtered, 1)
+		assert.Equal(t, "frontend", filtered[0].RepositoryName)
+		assert.Equal(t, "PR-1", filtered[0].PullRequestID)
+
+		defaultRepo := ""
+		filtered, err = userService.GetUserReviews(t.Context(), "reviewer_repo", &defaultRepo)
+		require.NoError(t, err)
+		require.Len(t, filtered, 1)
+		assert.Empty(t, filtered[0].RepositoryName)
+	})
+}
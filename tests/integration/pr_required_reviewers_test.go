@@ -0,0 +1,93 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestPRService_CreatePR_RequiredReviewers(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	require.NoError(t, team.Create(db, "team_req"))
+	require.NoError(t, team.Create(db, "team_owners"))
+	for _, u := range []domain.User{
+		{UserID: "author_req", Username: "author", TeamName: "team_req", IsActive: true},
+		{UserID: "mate1_req", Username: "mate1", TeamName: "team_req", IsActive: true},
+		{UserID: "mate2_req", Username: "mate2", TeamName: "team_req", IsActive: true},
+		{UserID: "owner_req", Username: "owner", TeamName: "team_owners", IsActive: true},
+		{UserID: "gone_req", Username: "gone", TeamName: "team_req", IsActive: false},
+	} {
+		require.NoError(t, user.Create(db, &u))
+	}
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+
+	t.Run("required reviewer assigned first and rest filled from team", func(t *testing.T) {
+		created, err := prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: "pr_req_1"}, "Owned", "author_req", []string{"owner_req"}, domain.PRDetails{})
+		require.NoError(t, err)
+		require.Len(t, created.AssignedReviewersIDs, 2)
+		assert.Contains(t, created.AssignedReviewersIDs, "owner_req")
+
+		sources := sourcesOf(created)
+		assert.Equal(t, domain.SourceRequired, sources["owner_req"])
+		for _, id := range created.AssignedReviewersIDs {
+			if id != "owner_req" {
+				assert.Equal(t, domain.SourceAuto, sources[id])
+			}
+		}
+	})
+
+	t.Run("required teammate is not picked twice", func(t *testing.T) {
+		created, err := prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: "pr_req_2"}, "Pinned", "author_req", []string{"mate1_req", "mate1_req"}, domain.PRDetails{})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"mate1_req", "mate2_req"}, created.AssignedReviewersIDs)
+	})
+
+	t.Run("required reviewers fill every slot", func(t *testing.T) {
+		created, err := prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: "pr_req_3"}, "Both", "author_req", []string{"owner_req", "mate2_req"}, domain.PRDetails{})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"owner_req", "mate2_req"}, created.AssignedReviewersIDs)
+	})
+
+	t.Run("invalid required reviewers", func(t *testing.T) {
+		cases := []struct {
+			name     string
+			required []string
+			err      error
+		}{
+			{"unknown", []string{"ghost"}, service.ErrRequiredReviewerNotFound},
+			{"inactive", []string{"gone_req"}, service.ErrRequiredReviewerInactive},
+			{"author", []string{"author_req"}, service.ErrRequiredReviewerIsAuthor},
+			{"exceeds target count", []string{"owner_req", "mate1_req", "mate2_req"}, service.ErrTooManyRequiredReviewers},
+		}
+		for _, c := range cases {
+			_, err := prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: "pr_req_bad"}, "Bad", "author_req", c.required, domain.PRDetails{})
+			assert.ErrorIs(t, err, c.err, c.name)
+		}
+
+		_, err := pr.Get(db, domain.PRKey{PullRequestID: "pr_req_bad"})
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+}
+
+// sourcesOf maps each reviewer of a pull request read by pr.Get to why it was assigned.
+func sourcesOf(p *domain.PullRequest) map[string]domain.ReviewerSource {
+	sources := make(map[string]domain.ReviewerSource, len(p.Assignments))
+	for _, a := range p.Assignments {
+		sources[a.UserID] = a.Source
+	}
+	return sources
+}
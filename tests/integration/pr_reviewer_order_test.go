@@ -0,0 +1,65 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/router"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestPRService_ReviewerOrderIsStable(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	require.NoError(t, team.Create(db, "team_order"))
+	for _, id := range []string{"author_order", "zed_order", "amy_order", "kim_order"} {
+		require.NoError(t, user.Create(db, &domain.User{UserID: id, Username: id, TeamName: "team_order", IsActive: true}))
+	}
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	r, err := router.SetupRoutes(router.Options{Mode: gin.TestMode}, nil, nil, handler.NewPRHandler(prService), nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+
+	getReviewers := func() []string {
+		t.Helper()
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/pullRequest/get?pull_request_id=pr_order", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		var response handler.GetPRResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.NotNil(t, response.PR)
+		return response.PR.AssignedReviewers
+	}
+
+	key := domain.PRKey{PullRequestID: "pr_order"}
+	created, err := prService.CreatePR(t.Context(), key, "Ordered", "author_order", []string{"zed_order", "amy_order"}, domain.PRDetails{})
+	require.NoError(t, err)
+
+	// Both reviewers are assigned in the same transaction, so user_id breaks the tie.
+	assert.Equal(t, []string{"amy_order", "zed_order"}, created.AssignedReviewersIDs)
+	for range 10 {
+		assert.Equal(t, []string{"amy_order", "zed_order"}, getReviewers())
+	}
+
+	// A replacement is assigned later than the remaining reviewer and goes last.
+	_, replacedBy, err := prService.ReassignPR(t.Context(), key, "amy_order", service.ReassignOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "kim_order", replacedBy)
+	for range 10 {
+		assert.Equal(t, []string{"zed_order", "kim_order"}, getReviewers())
+	}
+}
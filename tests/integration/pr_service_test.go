@@ -2,6 +2,7 @@ package integration
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -54,7 +55,7 @@ func TestPRService_CreatePR(t *testing.T) {
 		prID := "pr1"
 		prName := "Test PR"
 
-		createdPR, err := prService.CreatePR(prID, prName, authorID)
+		createdPR, err := prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: prID}, prName, authorID, nil, domain.PRDetails{})
 		require.NoError(t, err)
 		assert.Equal(t, prID, createdPR.PullRequestID)
 		assert.Equal(t, prName, createdPR.PullRequestName)
@@ -65,7 +66,7 @@ func TestPRService_CreatePR(t *testing.T) {
 	})
 
 	t.Run("error - author not found", func(t *testing.T) {
-		_, err := prService.CreatePR("pr2", "Test PR", "nonexistent")
+		_, err := prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: "pr2"}, "Test PR", "nonexistent", nil, domain.PRDetails{})
 		assert.Error(t, err)
 		assert.True(t, assert.ErrorIs(t, err, service.ErrPRAuthorNotFound))
 	})
@@ -84,16 +85,68 @@ func TestPRService_CreatePR(t *testing.T) {
 		}))
 
 		// Create PR first time
-		_, err := prService.CreatePR(prID, prName, authorID)
+		_, err := prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: prID}, prName, authorID, nil, domain.PRDetails{})
 		require.NoError(t, err)
 
 		// Try to create again
-		_, err = prService.CreatePR(prID, prName, authorID)
+		_, err = prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: prID}, prName, authorID, nil, domain.PRDetails{})
 		assert.Error(t, err)
 		assert.True(t, assert.ErrorIs(t, err, service.ErrPRExists))
 	})
 }
 
+func TestPR_InsertActiveReviewers(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	require.NoError(t, team.Create(db, "team_batch"))
+	for _, u := range []*domain.User{
+		{UserID: "batch_author", Username: "author", TeamName: "team_batch", IsActive: true},
+		{UserID: "batch_active", Username: "active", TeamName: "team_batch", IsActive: true},
+		{UserID: "batch_inactive", Username: "inactive", TeamName: "team_batch", IsActive: false},
+		{UserID: "batch_required", Username: "required", TeamName: "team_batch", IsActive: true},
+		{UserID: "batch_erased", Username: "erased", TeamName: "team_batch", IsActive: true},
+	} {
+		require.NoError(t, user.Create(db, u))
+	}
+	// Erased but still flagged active: the erasure alone must keep the user out.
+	_, err = db.Exec(`UPDATE users SET erased_at = NOW() WHERE user_id = $1`, "batch_erased")
+	require.NoError(t, err)
+	key := domain.PRKey{PullRequestID: "pr-batch"}
+	require.NoError(t, pr.Create(db, &domain.PullRequest{
+		PullRequestID: key.PullRequestID, PullRequestName: "Batch", AuthorID: "batch_author", TeamName: "team_batch", Status: domain.StatusOpen,
+	}))
+
+	reviewers := []string{"batch_required", "batch_inactive", "batch_active", "batch_missing", "batch_erased"}
+	inserted, err := pr.InsertActiveReviewers(db, key, reviewers,
+		[]domain.ReviewerSource{domain.SourceRequired, domain.SourceAuto, domain.SourceAuto, domain.SourceAuto, domain.SourceAuto}, time.Now().UTC())
+	require.NoError(t, err)
+	assert.Equal(t, 2, inserted)
+
+	pullRequest, err := pr.Get(db, key)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"batch_required", "batch_active"}, pullRequest.AssignedReviewersIDs)
+	assert.ElementsMatch(t, []string{"batch_required"}, pullRequest.RequiredReviewersIDs)
+
+	inactive, exists, err := user.FirstInactive(db, reviewers)
+	require.NoError(t, err)
+	assert.Equal(t, "batch_inactive", inactive)
+	assert.True(t, exists)
+	inactive, exists, err = user.FirstInactive(db, []string{"batch_active", "batch_missing"})
+	require.NoError(t, err)
+	assert.Equal(t, "batch_missing", inactive)
+	assert.False(t, exists)
+	inactive, exists, err = user.FirstInactive(db, []string{"batch_active", "batch_erased"})
+	require.NoError(t, err)
+	assert.Equal(t, "batch_erased", inactive)
+	assert.False(t, exists, "erased users are reported as unknown")
+	inactive, _, err = user.FirstInactive(db, []string{"batch_active", "batch_required"})
+	require.NoError(t, err)
+	assert.Empty(t, inactive)
+}
+
 func TestPRService_MergePR(t *testing.T) {
 	db, err := tests.SetupTestDB()
 	require.NoError(t, err)
@@ -126,7 +179,7 @@ func TestPRService_MergePR(t *testing.T) {
 			Status:          domain.StatusOpen,
 		}))
 
-		mergedPR, err := prService.MergePR(prID)
+		mergedPR, err := prService.MergePR(t.Context(), domain.PRKey{PullRequestID: prID}, service.MergeOptions{})
 		require.NoError(t, err)
 		assert.Equal(t, prID, mergedPR.PullRequestID)
 		assert.Equal(t, domain.StatusMerged, mergedPR.Status)
@@ -135,13 +188,13 @@ func TestPRService_MergePR(t *testing.T) {
 
 	t.Run("success - idempotent merge", func(t *testing.T) {
 		// PR already merged, should return without error
-		mergedPR, err := prService.MergePR(prID)
+		mergedPR, err := prService.MergePR(t.Context(), domain.PRKey{PullRequestID: prID}, service.MergeOptions{})
 		require.NoError(t, err)
 		assert.Equal(t, domain.StatusMerged, mergedPR.Status)
 	})
 
 	t.Run("error - PR not found", func(t *testing.T) {
-		_, err := prService.MergePR("nonexistent")
+		_, err := prService.MergePR(t.Context(), domain.PRKey{PullRequestID: "nonexistent"}, service.MergeOptions{})
 		assert.Error(t, err)
 		assert.True(t, assert.ErrorIs(t, err, service.ErrPRNotFound))
 	})
@@ -159,7 +212,7 @@ func TestPRService_ReplenishReviewers(t *testing.T) {
 		tx, err := db.Begin()
 		require.NoError(t, err)
 		defer func() { _ = tx.Rollback() }()
-		err = prService.ReplenishReviewers(tx, "nonexistent_pr")
+		err = prService.ReplenishReviewers(tx, domain.PRKey{PullRequestID: "nonexistent_pr"})
 		require.NoError(t, err)
 	})
 
@@ -175,7 +228,7 @@ func TestPRService_ReplenishReviewers(t *testing.T) {
 		tx, err := db.Begin()
 		require.NoError(t, err)
 		defer func() { _ = tx.Rollback() }()
-		err = prService.ReplenishReviewers(tx, prID)
+		err = prService.ReplenishReviewers(tx, domain.PRKey{PullRequestID: prID})
 		require.NoError(t, err)
 	})
 
@@ -191,12 +244,12 @@ func TestPRService_ReplenishReviewers(t *testing.T) {
 		require.NoError(t, pr.Create(db, &domain.PullRequest{
 			PullRequestID: prID, PullRequestName: "Full", AuthorID: authorID, TeamName: teamName, Status: domain.StatusOpen,
 		}))
-		require.NoError(t, pr.InsertReviewer(db, prID, r1))
-		require.NoError(t, pr.InsertReviewer(db, prID, r2))
+		require.NoError(t, pr.InsertReviewer(db, domain.PRKey{PullRequestID: prID}, r1, time.Now().UTC()))
+		require.NoError(t, pr.InsertReviewer(db, domain.PRKey{PullRequestID: prID}, r2, time.Now().UTC()))
 		tx, err := db.Begin()
 		require.NoError(t, err)
 		defer func() { _ = tx.Rollback() }()
-		err = prService.ReplenishReviewers(tx, prID)
+		err = prService.ReplenishReviewers(tx, domain.PRKey{PullRequestID: prID})
 		require.NoError(t, err)
 	})
 
@@ -212,11 +265,11 @@ func TestPRService_ReplenishReviewers(t *testing.T) {
 		require.NoError(t, pr.Create(db, &domain.PullRequest{
 			PullRequestID: prID, PullRequestName: "NoCand", AuthorID: authorID, TeamName: teamName, Status: domain.StatusOpen,
 		}))
-		require.NoError(t, pr.InsertReviewer(db, prID, r1))
+		require.NoError(t, pr.InsertReviewer(db, domain.PRKey{PullRequestID: prID}, r1, time.Now().UTC()))
 		tx, err := db.Begin()
 		require.NoError(t, err)
 		defer func() { _ = tx.Rollback() }()
-		err = prService.ReplenishReviewers(tx, prID)
+		err = prService.ReplenishReviewers(tx, domain.PRKey{PullRequestID: prID})
 		require.NoError(t, err)
 	})
 
@@ -232,14 +285,14 @@ func TestPRService_ReplenishReviewers(t *testing.T) {
 		require.NoError(t, pr.Create(db, &domain.PullRequest{
 			PullRequestID: prID, PullRequestName: "Repl", AuthorID: authorID, TeamName: teamName, Status: domain.StatusOpen,
 		}))
-		require.NoError(t, pr.InsertReviewer(db, prID, r1))
+		require.NoError(t, pr.InsertReviewer(db, domain.PRKey{PullRequestID: prID}, r1, time.Now().UTC()))
 		tx, err := db.Begin()
 		require.NoError(t, err)
 		defer func() { _ = tx.Rollback() }()
-		err = prService.ReplenishReviewers(tx, prID)
+		err = prService.ReplenishReviewers(tx, domain.PRKey{PullRequestID: prID})
 		require.NoError(t, err)
 		require.NoError(t, tx.Commit())
-		updated, err := pr.Get(db, prID)
+		updated, err := pr.Get(db, domain.PRKey{PullRequestID: prID})
 		require.NoError(t, err)
 		assert.Len(t, updated.AssignedReviewersIDs, 2)
 		assert.Contains(t, updated.AssignedReviewersIDs, r1)
@@ -291,9 +344,9 @@ func TestPRService_ReassignPR(t *testing.T) {
 			TeamName:        teamName,
 			Status:          domain.StatusOpen,
 		}))
-		require.NoError(t, pr.InsertReviewer(db, prID, oldReviewerID))
+		require.NoError(t, pr.InsertReviewer(db, domain.PRKey{PullRequestID: prID}, oldReviewerID, time.Now().UTC()))
 
-		updatedPR, replacedBy, err := prService.ReassignPR(prID, oldReviewerID)
+		updatedPR, replacedBy, err := prService.ReassignPR(t.Context(), domain.PRKey{PullRequestID: prID}, oldReviewerID, service.ReassignOptions{})
 		require.NoError(t, err)
 		assert.Equal(t, prID, updatedPR.PullRequestID)
 		assert.Equal(t, newReviewerID, replacedBy)
@@ -302,7 +355,7 @@ func TestPRService_ReassignPR(t *testing.T) {
 	})
 
 	t.Run("error - PR not found", func(t *testing.T) {
-		_, _, err := prService.ReassignPR("nonexistent", oldReviewerID)
+		_, _, err := prService.ReassignPR(t.Context(), domain.PRKey{PullRequestID: "nonexistent"}, oldReviewerID, service.ReassignOptions{})
 		assert.Error(t, err)
 		assert.True(t, assert.ErrorIs(t, err, service.ErrPRNotFound))
 	})
@@ -318,7 +371,7 @@ func TestPRService_ReassignPR(t *testing.T) {
 			Status:          domain.StatusMerged,
 		}))
 
-		_, _, err := prService.ReassignPR(prID, oldReviewerID)
+		_, _, err := prService.ReassignPR(t.Context(), domain.PRKey{PullRequestID: prID}, oldReviewerID, service.ReassignOptions{})
 		assert.Error(t, err)
 		assert.True(t, assert.ErrorIs(t, err, service.ErrPRMerged))
 	})
@@ -351,10 +404,10 @@ func TestPRService_ReassignPR(t *testing.T) {
 			TeamName:        teamName,
 			Status:          domain.StatusOpen,
 		}))
-		require.NoError(t, pr.InsertReviewer(db, prID, assignedReviewerID))
+		require.NoError(t, pr.InsertReviewer(db, domain.PRKey{PullRequestID: prID}, assignedReviewerID, time.Now().UTC()))
 
 		// Try to reassign reviewer that is not assigned (but exists in team)
-		_, _, err := prService.ReassignPR(prID, unassignedReviewerID)
+		_, _, err := prService.ReassignPR(t.Context(), domain.PRKey{PullRequestID: prID}, unassignedReviewerID, service.ReassignOptions{})
 		assert.Error(t, err)
 		assert.True(t, assert.ErrorIs(t, err, service.ErrReviewerNotAssigned))
 	})
@@ -379,10 +432,10 @@ func TestPRService_ReassignPR(t *testing.T) {
 			TeamName:        teamNameNC,
 			Status:          domain.StatusOpen,
 		}))
-		require.NoError(t, pr.InsertReviewer(db, prID, r1ID))
-		require.NoError(t, pr.InsertReviewer(db, prID, r2ID))
+		require.NoError(t, pr.InsertReviewer(db, domain.PRKey{PullRequestID: prID}, r1ID, time.Now().UTC()))
+		require.NoError(t, pr.InsertReviewer(db, domain.PRKey{PullRequestID: prID}, r2ID, time.Now().UTC()))
 
-		_, _, err := prService.ReassignPR(prID, r1ID)
+		_, _, err := prService.ReassignPR(t.Context(), domain.PRKey{PullRequestID: prID}, r1ID, service.ReassignOptions{})
 		assert.Error(t, err)
 		assert.True(t, assert.ErrorIs(t, err, service.ErrNoCandidate))
 	})
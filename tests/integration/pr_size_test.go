@@ -0,0 +1,70 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/stats"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestPRSize_WeightsLeastLoadedAssignment(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	teamService := service.NewTeamService(db, prService)
+	_, _, err = teamService.CreateTeam(t.Context(), &domain.Team{
+		TeamName:           "team_sz",
+		AssignmentStrategy: string(service.StrategyLeastLoaded),
+		Members: []domain.TeamMember{
+			{UserID: "author_sz", Username: "author", IsActive: true},
+			{UserID: "xl_holder_sz", Username: "xl", IsActive: true},
+			{UserID: "xs_holder_sz", Username: "xs", IsActive: true},
+			{UserID: "idle_sz", Username: "idle", IsActive: true},
+		},
+	}, service.CreateTeamOptions{})
+	require.NoError(t, err)
+
+	// One XL review outweighs three XS ones.
+	seed := func(id string, size domain.PRSize, reviewerID string) {
+		t.Helper()
+		key := domain.PRKey{PullRequestID: id}
+		require.NoError(t, pr.Create(db, &domain.PullRequest{
+			PullRequestID: id, PullRequestName: id, AuthorID: "author_sz", TeamName: "team_sz",
+			Status: domain.StatusOpen, Size: size,
+		}))
+		require.NoError(t, pr.InsertReviewer(db, key, reviewerID, time.Now().UTC()))
+	}
+	seed("pr_sz_xl", domain.SizeXL, "xl_holder_sz")
+	for _, id := range []string{"pr_sz_xs_1", "pr_sz_xs_2", "pr_sz_xs_3"} {
+		seed(id, domain.SizeXS, "xs_holder_sz")
+	}
+
+	lines := 1500
+	created, err := prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: "pr_sz_new"}, "New", "author_sz", nil,
+		domain.PRDetails{LinesChanged: &lines})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"idle_sz", "xs_holder_sz"}, created.AssignedReviewersIDs)
+	assert.Equal(t, domain.SizeXL, created.Size)
+	require.NotNil(t, created.LinesChanged)
+	assert.Equal(t, 1500, *created.LinesChanged)
+
+	statistics, err := service.NewStatsService(db, service.NewSystemClock()).GetStatistics(t.Context(), stats.Period{})
+	require.NoError(t, err)
+	load := make(map[string]int64)
+	for _, r := range statistics.ReviewerStats {
+		load[r.UserID] = r.OpenLoad
+	}
+	assert.Equal(t, int64(8), load["xl_holder_sz"])
+	assert.Equal(t, int64(3+8), load["xs_holder_sz"])
+	assert.Equal(t, int64(8), load["idle_sz"])
+}
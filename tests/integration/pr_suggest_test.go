@@ -0,0 +1,80 @@
+package integration
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/absence"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func countRows(t *testing.T, db *sql.DB, table string) int {
+	t.Helper()
+	var n int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM `+table).Scan(&n))
+	return n
+}
+
+func TestPRService_SuggestReviewers(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	zero := 0
+	teamName := "team_sg"
+	require.NoError(t, team.Create(db, teamName))
+	require.NoError(t, user.Create(db, &domain.User{UserID: "author_sg", Username: "author", TeamName: teamName, IsActive: true}))
+	require.NoError(t, user.Create(db, &domain.User{UserID: "free_sg", Username: "free", TeamName: teamName, IsActive: true}))
+	require.NoError(t, user.Create(db, &domain.User{UserID: "busy_sg", Username: "busy", TeamName: teamName, IsActive: true, MaxOpenReviews: &zero}))
+	require.NoError(t, user.Create(db, &domain.User{UserID: "away_sg", Username: "away", TeamName: teamName, IsActive: true}))
+	require.NoError(t, user.Create(db, &domain.User{UserID: "off_sg", Username: "off", TeamName: teamName, IsActive: false}))
+
+	today := time.Now().Truncate(24 * time.Hour)
+	require.NoError(t, absence.Create(db, &domain.Absence{UserID: "away_sg", FromDate: today.AddDate(0, 0, -1), ToDate: today.AddDate(0, 0, 1)}))
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+
+	t.Run("honors capacity and absences without writing", func(t *testing.T) {
+		prsBefore := countRows(t, db, "pull_requests")
+		reviewersBefore := countRows(t, db, "pr_reviewers")
+		historyBefore := countRows(t, db, "assignment_history")
+
+		suggestion, err := prService.SuggestReviewers(t.Context(), "author_sg", 2)
+		require.NoError(t, err)
+		assert.Equal(t, teamName, suggestion.TeamName)
+		assert.Equal(t, service.StrategyRandom, suggestion.Strategy)
+
+		ids := make([]string, len(suggestion.Candidates))
+		for i, c := range suggestion.Candidates {
+			ids[i] = c.UserID
+		}
+		assert.ElementsMatch(t, []string{"free_sg", "busy_sg"}, ids)
+		assert.Equal(t, []string{"free_sg"}, suggestion.Selected)
+
+		assert.Equal(t, prsBefore, countRows(t, db, "pull_requests"))
+		assert.Equal(t, reviewersBefore, countRows(t, db, "pr_reviewers"))
+		assert.Equal(t, historyBefore, countRows(t, db, "assignment_history"))
+	})
+
+	t.Run("honors team strategy", func(t *testing.T) {
+		require.NoError(t, team.SetStrategy(db, teamName, string(service.StrategyRoundRobin)))
+		suggestion, err := prService.SuggestReviewers(t.Context(), "author_sg", 1)
+		require.NoError(t, err)
+		assert.Equal(t, service.StrategyRoundRobin, suggestion.Strategy)
+		assert.Equal(t, []string{"free_sg"}, suggestion.Selected)
+	})
+
+	t.Run("unknown author", func(t *testing.T) {
+		_, err := prService.SuggestReviewers(t.Context(), "ghost", 2)
+		assert.ErrorIs(t, err, service.ErrPRAuthorNotFound)
+	})
+}
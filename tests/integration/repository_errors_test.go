@@ -0,0 +1,75 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestRepository_SentinelErrors(t *testing.T) {
+	t.Parallel()
+	db := tests.WithTestTx(t)
+
+	require.NoError(t, team.Create(db, "team_re"))
+	require.NoError(t, user.Create(db, &domain.User{UserID: "u_re", Username: "u", TeamName: "team_re", IsActive: true}))
+	require.NoError(t, pr.Create(db, &domain.PullRequest{
+		PullRequestID: "pr_re", PullRequestName: "PR", AuthorID: "u_re", TeamName: "team_re", Status: domain.StatusOpen,
+	}))
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := user.Get(db, "ghost")
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+		assert.ErrorContains(t, err, "ghost")
+
+		_, err = user.SetIsActive(db, "ghost", false)
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+
+		_, err = user.SetMaxOpenReviews(db, "ghost", nil)
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+
+		assert.ErrorIs(t, user.Update(db, &domain.User{UserID: "ghost"}), repository.ErrNotFound)
+		assert.ErrorIs(t, user.SetPrimaryTeam(db, "ghost", "team_re"), repository.ErrNotFound)
+
+		_, err = team.Get(db, "ghost_team")
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+		assert.ErrorIs(t, team.SetStrategy(db, "ghost_team", "random"), repository.ErrNotFound)
+
+		_, err = pr.Get(db, domain.PRKey{PullRequestID: "ghost_pr"})
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+
+		_, err = pr.GetStatus(db, domain.PRKey{PullRequestID: "ghost_pr"})
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+
+	t.Run("merge of merged PR is not found", func(t *testing.T) {
+		require.NoError(t, pr.UpdateStatusToMerged(db, domain.PRKey{PullRequestID: "pr_re"}, "", time.Now()))
+		assert.ErrorIs(t, pr.UpdateStatusToMerged(db, domain.PRKey{PullRequestID: "pr_re"}, "", time.Now()), repository.ErrNotFound)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		// Unique violations abort the transaction, so each one runs in its own savepoint.
+		assert.ErrorIs(t, db.Savepoint(func() error {
+			return team.Create(db, "team_re")
+		}), repository.ErrConflict)
+		assert.ErrorIs(t, db.Savepoint(func() error {
+			return team.CreateWithStrategy(db, "team_re", "random")
+		}), repository.ErrConflict)
+		assert.ErrorIs(t, db.Savepoint(func() error {
+			return user.Create(db, &domain.User{UserID: "u_re", Username: "u", TeamName: "team_re"})
+		}), repository.ErrConflict)
+		assert.ErrorIs(t, db.Savepoint(func() error {
+			return pr.Create(db, &domain.PullRequest{
+				PullRequestID: "pr_re", PullRequestName: "PR", AuthorID: "u_re", TeamName: "team_re", Status: domain.StatusOpen,
+			})
+		}), repository.ErrConflict)
+	})
+}
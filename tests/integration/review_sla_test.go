@@ -0,0 +1,124 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/stats"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestReviewSLA_OverdueAssignments(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	assignedAt := time.Date(2026, 3, 2, 9, 30, 0, 0, time.Local)
+	clock := &tests.FakeClock{Current: assignedAt}
+	prService := service.NewPRService(db, service.NewReviewerAssigner()).WithClock(clock)
+	teamService := service.NewTeamService(db, prService)
+	userService := service.NewUserService(db, prService)
+	statsService := service.NewStatsService(db, clock)
+
+	_, _, err = teamService.CreateTeam(t.Context(), &domain.Team{
+		TeamName: "team_sla",
+		Members: []domain.TeamMember{
+			{UserID: "author_sla", Username: "author", IsActive: true},
+			{UserID: "rev1_sla", Username: "rev1", IsActive: true},
+			{UserID: "rev2_sla", Username: "rev2", IsActive: true},
+		},
+	}, service.CreateTeamOptions{})
+	require.NoError(t, err)
+	sla := 24
+	updated, err := teamService.UpdateTeam(t.Context(), "team_sla", service.TeamUpdate{ReviewSLAHours: &sla})
+	require.NoError(t, err)
+	assert.Equal(t, 24, updated.ReviewSLAHours)
+
+	key := domain.PRKey{PullRequestID: "pr_sla"}
+	_, err = prService.CreatePR(t.Context(), key, "Slow review", "author_sla", []string{"rev1_sla", "rev2_sla"}, domain.PRDetails{})
+	require.NoError(t, err)
+	_, err = db.Exec(`UPDATE pr_reviewers SET assigned_at = $2 WHERE pull_request_id = $1`, key.PullRequestID, assignedAt)
+	require.NoError(t, err)
+	_, err = prService.ApprovePR(t.Context(), key, "rev2_sla")
+	require.NoError(t, err)
+
+	overdueOf := func(t *testing.T) map[string]bool {
+		t.Helper()
+		pullRequest, err := prService.GetPR(t.Context(), key)
+		require.NoError(t, err)
+		require.Len(t, pullRequest.Assignments, 2)
+		overdue := make(map[string]bool)
+		for _, a := range pullRequest.Assignments {
+			assert.True(t, a.AssignedAt.Equal(assignedAt))
+			require.NotNil(t, a.HoursOpen)
+			assert.Equal(t, int(clock.Now().Sub(assignedAt)/time.Hour), *a.HoursOpen)
+			overdue[a.UserID] = a.Overdue
+		}
+		return overdue
+	}
+	teamOverdue := func(t *testing.T) int64 {
+		t.Helper()
+		st, err := statsService.GetStatistics(t.Context(), stats.Period{})
+		require.NoError(t, err)
+		for _, td := range st.Distribution.Teams {
+			if td.TeamName == "team_sla" {
+				return td.OverdueAssignments
+			}
+		}
+		t.Fatal("team_sla is missing from the distribution")
+		return 0
+	}
+
+	t.Run("not overdue at the SLA hour", func(t *testing.T) {
+		clock.Current = assignedAt.Add(24 * time.Hour)
+		assert.Equal(t, map[string]bool{"rev1_sla": false, "rev2_sla": false}, overdueOf(t))
+		assert.Zero(t, teamOverdue(t))
+	})
+
+	t.Run("pending review overdue right after the SLA hour", func(t *testing.T) {
+		clock.Current = assignedAt.Add(24*time.Hour + time.Second)
+		assert.Equal(t, map[string]bool{"rev1_sla": true, "rev2_sla": false}, overdueOf(t))
+		assert.Equal(t, int64(1), teamOverdue(t))
+
+		reviews, err := userService.GetUserReviews(t.Context(), "rev1_sla", nil)
+		require.NoError(t, err)
+		require.Len(t, reviews, 1)
+		require.NotNil(t, reviews[0].Assignment)
+		assert.Equal(t, 24, *reviews[0].Assignment.HoursOpen)
+		assert.True(t, reviews[0].Assignment.Overdue)
+	})
+
+	t.Run("removing the SLA clears the flag", func(t *testing.T) {
+		noSLA := 0
+		_, err := teamService.UpdateTeam(t.Context(), "team_sla", service.TeamUpdate{ReviewSLAHours: &noSLA})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]bool{"rev1_sla": false, "rev2_sla": false}, overdueOf(t))
+		assert.Zero(t, teamOverdue(t))
+	})
+
+	t.Run("merged pull request is never overdue", func(t *testing.T) {
+		_, err := teamService.UpdateTeam(t.Context(), "team_sla", service.TeamUpdate{ReviewSLAHours: &sla})
+		require.NoError(t, err)
+		_, err = prService.MergePR(t.Context(), key, service.MergeOptions{})
+		require.NoError(t, err)
+
+		reviews, err := userService.GetUserReviews(t.Context(), "rev1_sla", nil)
+		require.NoError(t, err)
+		require.Len(t, reviews, 1)
+		assert.Nil(t, reviews[0].Assignment.HoursOpen)
+		assert.False(t, reviews[0].Assignment.Overdue)
+		assert.Zero(t, teamOverdue(t))
+	})
+
+	t.Run("negative SLA", func(t *testing.T) {
+		negative := -1
+		_, err := teamService.UpdateTeam(t.Context(), "team_sla", service.TeamUpdate{ReviewSLAHours: &negative})
+		assert.ErrorIs(t, err, service.ErrInvalidReviewSLA)
+	})
+}
@@ -0,0 +1,58 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestVerifySchema(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	t.Run("migrated database", func(t *testing.T) {
+		missing, err := repository.VerifySchema(db)
+		require.NoError(t, err)
+		assert.Empty(t, missing)
+	})
+
+	t.Run("missing items are listed", func(t *testing.T) {
+		// DDL is transactional: the schema is damaged only inside tx and restored by the rollback.
+		tx, err := db.Begin()
+		require.NoError(t, err)
+		defer func() { _ = tx.Rollback() }()
+
+		for _, stmt := range []string{
+			"DROP TABLE team_digests",
+			"ALTER TABLE pr_reviewers DROP COLUMN source",
+			"ALTER TABLE assignment_history DROP COLUMN decision",
+			"ALTER TABLE pr_reviewers DROP CONSTRAINT pr_reviewers_pr_user_key",
+			"DROP INDEX idx_pull_requests_author_created_at",
+		} {
+			_, err := tx.Exec(stmt)
+			require.NoError(t, err, stmt)
+		}
+
+		missing, err := repository.VerifySchema(tx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"column pr_reviewers.source",
+			"column assignment_history.decision",
+			"table team_digests",
+			"constraint pr_reviewers_pr_user_key",
+			"constraint team_digests_pkey",
+			"index idx_pull_requests_author_created_at",
+		}, missing)
+	})
+
+	t.Run("rollback restores the schema", func(t *testing.T) {
+		missing, err := repository.VerifySchema(db)
+		require.NoError(t, err)
+		assert.Empty(t, missing)
+	})
+}
@@ -0,0 +1,84 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestSlackNotifier_PostsTeamAssignments(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	var mu sync.Mutex
+	var messages []service.SlackMessage
+	slack := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var message service.SlackMessage
+		_ = json.Unmarshal(body, &message)
+		mu.Lock()
+		messages = append(messages, message)
+		mu.Unlock()
+	}))
+	defer slack.Close()
+
+	for _, teamName := range []string{"team_slack", "team_quiet"} {
+		require.NoError(t, team.Create(db, teamName))
+		for _, id := range []string{"author", "rev"} {
+			userID := id + "_" + teamName
+			require.NoError(t, user.Create(db, &domain.User{UserID: userID, Username: userID, TeamName: teamName, IsActive: true}))
+		}
+	}
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	teamService := service.NewTeamService(db, prService)
+
+	url := slack.URL
+	updated, err := teamService.UpdateTeam(t.Context(), "team_slack", service.TeamUpdate{SlackWebhookURL: &url})
+	require.NoError(t, err)
+	assert.Equal(t, slack.URL, updated.SlackWebhookURL)
+
+	externalURL := "https://git.example.com/repo/pull/1"
+	_, err = prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: "pr_slack"}, "Add login", "author_team_slack", nil,
+		domain.PRDetails{ExternalURL: &externalURL})
+	require.NoError(t, err)
+	_, err = prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: "pr_quiet"}, "Add logout", "author_team_quiet", nil, domain.PRDetails{})
+	require.NoError(t, err)
+
+	dispatcher := service.NewOutboxDispatcher(db, time.Second, 100, 3).
+		WithPublisher(service.NewSlackNotifier(teamService))
+	published, err := dispatcher.Tick(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 4, published)
+
+	require.Len(t, messages, 1, "only the team with a slack webhook is notified")
+	assert.Equal(t, "*rev_team_slack* is asked to review <"+externalURL+"|Add login> by *author_team_slack*", messages[0].Text)
+
+	t.Run("empty url disables notifications", func(t *testing.T) {
+		empty := ""
+		updated, err := teamService.UpdateTeam(t.Context(), "team_slack", service.TeamUpdate{SlackWebhookURL: &empty})
+		require.NoError(t, err)
+		assert.Empty(t, updated.SlackWebhookURL)
+
+		pullRequest, url, err := teamService.SlackTarget(t.Context(), domain.PRKey{PullRequestID: "pr_slack"})
+		require.NoError(t, err)
+		assert.Equal(t, "pr_slack", pullRequest.PullRequestID)
+		assert.Empty(t, url)
+	})
+}
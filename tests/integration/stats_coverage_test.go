@@ -0,0 +1,95 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestStatsService_GetCoverage(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	ctx := context.Background()
+	statsService := service.NewStatsService(db, service.NewSystemClock())
+	_, err = service.NewOrgService(db).CreateOrg(ctx, "empty_cov_org", "Empty")
+	require.NoError(t, err)
+
+	// cov_a keeps the default target of 2 reviewers, cov_b asks for 3.
+	require.NoError(t, team.Create(db, "cov_a"))
+	require.NoError(t, team.Create(db, "cov_b"))
+	require.NoError(t, team.SetReviewerCount(db, "cov_b", 3))
+	for _, u := range []domain.User{
+		{UserID: "author_cov", Username: "author", TeamName: "cov_a", IsActive: true},
+		{UserID: "r1_cov", Username: "r1", TeamName: "cov_a", IsActive: true},
+		{UserID: "r2_cov", Username: "r2", TeamName: "cov_a", IsActive: true},
+		{UserID: "r3_cov", Username: "r3", TeamName: "cov_b", IsActive: true},
+	} {
+		require.NoError(t, user.Create(db, &u))
+	}
+
+	// Seeded directly so that each PR gets exactly the reviewers listed.
+	for _, p := range []struct {
+		id        string
+		teamName  string
+		status    domain.PRStatus
+		reviewers []string
+	}{
+		{"cov_a_full", "cov_a", domain.StatusOpen, []string{"r1_cov", "r2_cov"}},
+		{"cov_a_one", "cov_a", domain.StatusOpen, []string{"r1_cov"}},
+		{"cov_a_none", "cov_a", domain.StatusOpen, nil},
+		{"cov_a_merged", "cov_a", domain.StatusMerged, nil},
+		{"cov_b_one", "cov_b", domain.StatusOpen, []string{"r3_cov"}},
+		{"cov_b_two", "cov_b", domain.StatusOpen, []string{"r1_cov", "r3_cov"}},
+	} {
+		key := domain.PRKey{PullRequestID: p.id}
+		require.NoError(t, pr.Create(db, &domain.PullRequest{
+			PullRequestID: p.id, PullRequestName: p.id, AuthorID: "author_cov", TeamName: p.teamName, Status: p.status,
+		}))
+		for _, r := range p.reviewers {
+			require.NoError(t, pr.InsertReviewer(db, key, r, time.Now().UTC()))
+		}
+	}
+
+	t.Run("overall and per team", func(t *testing.T) {
+		coverage, err := statsService.GetCoverage(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, service.CoverageCounts{
+			OpenPRs:         5,
+			CoveredPRs:      1,
+			UnderCoveredPRs: 4,
+			Shortfall:       []service.ShortfallCount{{Missing: 1, PRs: 2}, {Missing: 2, PRs: 2}},
+		}, coverage.CoverageCounts)
+		assert.Equal(t, []service.TeamCoverage{
+			{TeamName: "cov_a", ReviewerTarget: 2, CoverageCounts: service.CoverageCounts{
+				OpenPRs: 3, CoveredPRs: 1, UnderCoveredPRs: 2,
+				Shortfall: []service.ShortfallCount{{Missing: 1, PRs: 1}, {Missing: 2, PRs: 1}},
+			}},
+			{TeamName: "cov_b", ReviewerTarget: 3, CoverageCounts: service.CoverageCounts{
+				OpenPRs: 2, UnderCoveredPRs: 2,
+				Shortfall: []service.ShortfallCount{{Missing: 1, PRs: 1}, {Missing: 2, PRs: 1}},
+			}},
+		}, coverage.Teams)
+	})
+
+	t.Run("under-covered count per organization", func(t *testing.T) {
+		counts, err := statsService.UnderCoveredByOrg(ctx)
+		require.NoError(t, err)
+		assert.EqualValues(t, 4, counts[domain.DefaultOrgID])
+		assert.Contains(t, counts, "empty_cov_org")
+		assert.Zero(t, counts["empty_cov_org"])
+	})
+}
@@ -0,0 +1,127 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/stats"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestStatsService_GetLeaderboard(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	clock := &tests.FakeClock{Current: time.Date(2024, 3, 20, 12, 0, 0, 0, time.Local)}
+	statsService := service.NewStatsService(db, clock)
+
+	require.NoError(t, team.Create(db, "lb_team"))
+	for _, id := range []string{"lb_a", "lb_b", "lb_c", "lb_d"} {
+		require.NoError(t, user.Create(db, &domain.User{UserID: id, Username: id, TeamName: "lb_team", IsActive: true}))
+	}
+
+	daysAgo := func(days int) *time.Time {
+		t := clock.Now().AddDate(0, 0, -days)
+		return &t
+	}
+	seed := []struct {
+		id        string
+		authorID  string
+		mergedAt  *time.Time
+		reviewers []string
+	}{
+		// Within 7 days: lb_b and lb_c tie on two reviews, lb_a has one.
+		{"lb_pr1", "lb_a", daysAgo(1), []string{"lb_b", "lb_c"}},
+		{"lb_pr2", "lb_d", daysAgo(6), []string{"lb_b", "lb_c"}},
+		{"lb_pr3", "lb_d", daysAgo(3), []string{"lb_a"}},
+		// Within 30 days only: lb_a catches up with three reviews.
+		{"lb_pr4", "lb_b", daysAgo(10), []string{"lb_a"}},
+		{"lb_pr5", "lb_b", daysAgo(20), []string{"lb_a", "lb_d"}},
+		// Older than 30 days.
+		{"lb_pr6", "lb_c", daysAgo(60), []string{"lb_d"}},
+		{"lb_pr7", "lb_c", daysAgo(90), []string{"lb_d"}},
+		// Open PRs never count.
+		{"lb_open", "lb_a", nil, []string{"lb_d"}},
+	}
+	for _, s := range seed {
+		status := domain.StatusOpen
+		if s.mergedAt != nil {
+			status = domain.StatusMerged
+		}
+		require.NoError(t, pr.Create(db, &domain.PullRequest{
+			PullRequestID:   s.id,
+			PullRequestName: s.id,
+			AuthorID:        s.authorID,
+			TeamName:        "lb_team",
+			Status:          status,
+		}))
+		for _, r := range s.reviewers {
+			require.NoError(t, pr.InsertReviewer(db, domain.PRKey{PullRequestID: s.id}, r, time.Now().UTC()))
+		}
+		_, err := db.Exec(`UPDATE pull_requests SET merged_at = $2 WHERE pull_request_id = $1`, s.id, s.mergedAt)
+		require.NoError(t, err)
+	}
+
+	// Usernames equal user IDs in this dataset.
+	entry := func(id string, count int64) stats.RankedUser {
+		return stats.RankedUser{UserID: id, Username: id, Count: count}
+	}
+
+	t.Run("last 7 days with ties broken by user_id", func(t *testing.T) {
+		lb, err := statsService.GetLeaderboard(t.Context(), service.LeaderboardWeek, 10)
+		require.NoError(t, err)
+
+		require.NotNil(t, lb.Since)
+		assert.True(t, lb.Since.Equal(*daysAgo(7)))
+		assert.Equal(t, []stats.RankedUser{entry("lb_b", 2), entry("lb_c", 2), entry("lb_a", 1)}, lb.Reviewers)
+		assert.Equal(t, []stats.RankedUser{entry("lb_d", 2), entry("lb_a", 1)}, lb.Authors)
+	})
+
+	t.Run("last 30 days", func(t *testing.T) {
+		lb, err := statsService.GetLeaderboard(t.Context(), service.LeaderboardMonth, 10)
+		require.NoError(t, err)
+
+		assert.Equal(t, []stats.RankedUser{entry("lb_a", 3), entry("lb_b", 2), entry("lb_c", 2), entry("lb_d", 1)}, lb.Reviewers)
+		assert.Equal(t, []stats.RankedUser{entry("lb_b", 2), entry("lb_d", 2), entry("lb_a", 1)}, lb.Authors)
+	})
+
+	t.Run("all time", func(t *testing.T) {
+		lb, err := statsService.GetLeaderboard(t.Context(), service.LeaderboardAll, 10)
+		require.NoError(t, err)
+
+		assert.Nil(t, lb.Since)
+		assert.Equal(t, []stats.RankedUser{entry("lb_a", 3), entry("lb_d", 3), entry("lb_b", 2), entry("lb_c", 2)}, lb.Reviewers)
+		assert.Equal(t, []stats.RankedUser{entry("lb_b", 2), entry("lb_c", 2), entry("lb_d", 2), entry("lb_a", 1)}, lb.Authors)
+	})
+
+	t.Run("limit truncates after ordering", func(t *testing.T) {
+		lb, err := statsService.GetLeaderboard(t.Context(), service.LeaderboardAll, 2)
+		require.NoError(t, err)
+
+		assert.Equal(t, []stats.RankedUser{entry("lb_a", 3), entry("lb_d", 3)}, lb.Reviewers)
+		assert.Equal(t, []stats.RankedUser{entry("lb_b", 2), entry("lb_c", 2)}, lb.Authors)
+	})
+
+	t.Run("error - invalid period", func(t *testing.T) {
+		_, err := statsService.GetLeaderboard(t.Context(), service.LeaderboardPeriod("1y"), 10)
+		assert.ErrorIs(t, err, service.ErrInvalidLeaderboardPeriod)
+	})
+
+	t.Run("error - invalid limit", func(t *testing.T) {
+		_, err := statsService.GetLeaderboard(t.Context(), service.LeaderboardAll, 0)
+		assert.ErrorIs(t, err, service.ErrInvalidLimit)
+
+		_, err = statsService.GetLeaderboard(t.Context(), service.LeaderboardAll, service.MaxLeaderboardLimit+1)
+		assert.ErrorIs(t, err, service.ErrInvalidLimit)
+	})
+}
@@ -0,0 +1,73 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/stats"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+// statsLatencyBudget is the upper bound for computing GET /stats on the seeded dataset.
+const statsLatencyBudget = 2 * time.Second
+
+func TestStatsService_GetStatistics_Latency(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	// 20 teams, 2000 users, 5000 PRs (every third merged) with two reviewers each.
+	seed := []string{
+		`INSERT INTO teams (team_name) SELECT 'perf_team_' || t FROM generate_series(1, 20) t`,
+		`INSERT INTO users (user_id, username, team_name, is_active)
+		 SELECT 'perf_u' || u, 'user ' || u, 'perf_team_' || (u % 20 + 1), u % 10 <> 0
+		 FROM generate_series(1, 2000) u`,
+		`INSERT INTO team_memberships (user_id, team_name, is_primary)
+		 SELECT user_id, team_name, true FROM users WHERE user_id LIKE 'perf_u%'`,
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, team_name, status, created_at, merged_at)
+		 SELECT 'perf_pr' || p, 'PR ' || p, 'perf_u' || (p % 2000 + 1), 'perf_team_' || ((p % 2000 + 1) % 20 + 1),
+		        CASE WHEN p % 3 = 0 THEN 'MERGED' ELSE 'OPEN' END,
+		        NOW() - (p || ' minutes')::interval,
+		        CASE WHEN p % 3 = 0 THEN NOW() - (p || ' seconds')::interval END
+		 FROM generate_series(1, 5000) p`,
+		`INSERT INTO pr_reviewers (pull_request_id, user_id)
+		 SELECT 'perf_pr' || p, 'perf_u' || ((p + r * 20) % 2000 + 1)
+		 FROM generate_series(1, 5000) p, generate_series(1, 2) r`,
+	}
+	for _, q := range seed {
+		_, err := db.Exec(q)
+		require.NoError(t, err)
+	}
+
+	statsService := service.NewStatsService(db, service.NewSystemClock())
+
+	// Warm up the connection and plan cache so the measurement reflects steady state.
+	_, err = statsService.GetStatistics(t.Context(), stats.Period{})
+	require.NoError(t, err)
+
+	start := time.Now()
+	st, err := statsService.GetStatistics(t.Context(), stats.Period{})
+	elapsed := time.Since(start)
+	require.NoError(t, err)
+	t.Logf("GetStatistics on %d PRs took %s", st.Overall.TotalPRs, elapsed)
+
+	assert.Equal(t, int64(5000), st.Overall.TotalPRs)
+	assert.Equal(t, int64(1666), st.Overall.MergedPRs)
+	assert.Equal(t, int64(10000), st.Overall.TotalAssignments)
+	assert.Len(t, st.ReviewerStats, 2000)
+	assert.Len(t, st.AuthorStats, 2000)
+	assert.Equal(t, 1800, st.Distribution.Overall.ActiveUsers)
+	assert.Len(t, st.Distribution.Teams, 20)
+	assert.Less(t, elapsed, statsLatencyBudget)
+
+	t.Run("timeout", func(t *testing.T) {
+		slow := service.NewStatsService(db, service.NewSystemClock()).WithQueryTimeout(time.Nanosecond)
+		_, err := slow.GetStatistics(t.Context(), stats.Period{})
+		assert.ErrorIs(t, err, service.ErrStatsTimeout)
+	})
+}
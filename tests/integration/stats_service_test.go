@@ -1,7 +1,10 @@
 package integration
 
 import (
+	"fmt"
+	"math"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -21,10 +24,10 @@ func TestStatsService_GetStatistics(t *testing.T) {
 	defer func() { _ = db.Close() }()
 	defer func() { _ = tests.CleanupTestDB(db) }()
 
-	statsService := service.NewStatsService(db)
+	statsService := service.NewStatsService(db, service.NewSystemClock())
 
 	t.Run("success - empty statistics", func(t *testing.T) {
-		stats, err := statsService.GetStatistics()
+		stats, err := statsService.GetStatistics(t.Context(), stats.Period{})
 		require.NoError(t, err)
 		require.NotNil(t, stats)
 		require.NotNil(t, stats.Overall)
@@ -95,13 +98,13 @@ func TestStatsService_GetStatistics(t *testing.T) {
 		}))
 
 		// Assign reviewers
-		require.NoError(t, pr.InsertReviewer(db, prID1, reviewerID1))
-		require.NoError(t, pr.InsertReviewer(db, prID1, reviewerID2))
-		require.NoError(t, pr.InsertReviewer(db, prID2, reviewerID1))
-		require.NoError(t, pr.InsertReviewer(db, prID3, reviewerID2))
+		require.NoError(t, pr.InsertReviewer(db, domain.PRKey{PullRequestID: prID1}, reviewerID1, time.Now().UTC()))
+		require.NoError(t, pr.InsertReviewer(db, domain.PRKey{PullRequestID: prID1}, reviewerID2, time.Now().UTC()))
+		require.NoError(t, pr.InsertReviewer(db, domain.PRKey{PullRequestID: prID2}, reviewerID1, time.Now().UTC()))
+		require.NoError(t, pr.InsertReviewer(db, domain.PRKey{PullRequestID: prID3}, reviewerID2, time.Now().UTC()))
 
 		// Get statistics
-		st, err := statsService.GetStatistics()
+		st, err := statsService.GetStatistics(t.Context(), stats.Period{})
 		require.NoError(t, err)
 		require.NotNil(t, st)
 		require.NotNil(t, st.Overall)
@@ -131,17 +134,25 @@ func TestStatsService_GetStatistics(t *testing.T) {
 			}
 		}
 
+		// reviewer1 reviews pr1 and pr2 (both open);
+		// reviewer2 reviews pr1 (open) and pr3 (merged)
 		require.NotNil(t, reviewer1Stat, "reviewer1 should be in stats")
 		assert.Equal(t, "reviewer1", reviewer1Stat.Username)
 		assert.Equal(t, int64(2), reviewer1Stat.Count)
+		assert.Equal(t, int64(2), reviewer1Stat.OpenCount)
+		assert.Equal(t, int64(0), reviewer1Stat.MergedCount)
 
 		require.NotNil(t, reviewer2Stat, "reviewer2 should be in stats")
 		assert.Equal(t, "reviewer2", reviewer2Stat.Username)
 		assert.Equal(t, int64(2), reviewer2Stat.Count)
+		assert.Equal(t, int64(1), reviewer2Stat.OpenCount)
+		assert.Equal(t, int64(1), reviewer2Stat.MergedCount)
 
 		require.NotNil(t, authorStat, "author should be in stats")
 		assert.Equal(t, "author", authorStat.Username)
 		assert.Equal(t, int64(0), authorStat.Count)
+		assert.Equal(t, int64(0), authorStat.OpenCount)
+		assert.Equal(t, int64(0), authorStat.MergedCount)
 
 		// Check author stats
 		// author1 should have 2 PRs, reviewer1 should have 1 PR
@@ -180,7 +191,7 @@ func TestStatsService_GetStatistics(t *testing.T) {
 			IsActive: true,
 		}))
 
-		st, err := statsService.GetStatistics()
+		st, err := statsService.GetStatistics(t.Context(), stats.Period{})
 		require.NoError(t, err)
 
 		// Find user in reviewer stats
@@ -194,6 +205,8 @@ func TestStatsService_GetStatistics(t *testing.T) {
 
 		require.NotNil(t, userStat)
 		assert.Equal(t, int64(0), userStat.Count)
+		assert.Equal(t, int64(0), userStat.OpenCount)
+		assert.Equal(t, int64(0), userStat.MergedCount)
 
 		// Find user in author stats
 		var userAuthorStat *stats.AuthorStat
@@ -208,3 +221,309 @@ func TestStatsService_GetStatistics(t *testing.T) {
 		assert.Equal(t, int64(0), userAuthorStat.Count)
 	})
 }
+
+func TestStatsService_GetUserLoad(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	statsService := service.NewStatsService(db, service.NewSystemClock())
+
+	require.NoError(t, team.Create(db, "load_team"))
+	require.NoError(t, user.Create(db, &domain.User{UserID: "load_author", Username: "Doe, John", TeamName: "load_team", IsActive: true}))
+	require.NoError(t, user.Create(db, &domain.User{UserID: "load_rev", Username: "rev", TeamName: "load_team", IsActive: false}))
+
+	require.NoError(t, pr.Create(db, &domain.PullRequest{PullRequestID: "load_pr1", PullRequestName: "PR 1", AuthorID: "load_author", TeamName: "load_team", Status: domain.StatusOpen}))
+	require.NoError(t, pr.Create(db, &domain.PullRequest{PullRequestID: "load_pr2", PullRequestName: "PR 2", AuthorID: "load_author", TeamName: "load_team", Status: domain.StatusMerged}))
+	require.NoError(t, pr.InsertReviewer(db, domain.PRKey{PullRequestID: "load_pr1"}, "load_rev", time.Now().UTC()))
+	require.NoError(t, pr.InsertReviewer(db, domain.PRKey{PullRequestID: "load_pr2"}, "load_rev", time.Now().UTC()))
+
+	loads, err := statsService.GetUserLoad(t.Context())
+	require.NoError(t, err)
+	require.Len(t, loads, 2)
+
+	assert.Equal(t, stats.UserLoad{
+		UserID:           "load_author",
+		Username:         "Doe, John",
+		TeamName:         "load_team",
+		IsActive:         true,
+		OpenAssignments:  0,
+		TotalAssignments: 0,
+		AuthoredPRs:      2,
+	}, loads[0])
+	assert.Equal(t, stats.UserLoad{
+		UserID:           "load_rev",
+		Username:         "rev",
+		TeamName:         "load_team",
+		IsActive:         false,
+		OpenAssignments:  1,
+		TotalAssignments: 2,
+		AuthoredPRs:      0,
+	}, loads[1])
+}
+
+func TestStatsService_GetStatistics_Period(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	statsService := service.NewStatsService(db, service.NewSystemClock())
+
+	require.NoError(t, team.Create(db, "period_team"))
+	require.NoError(t, user.Create(db, &domain.User{UserID: "period_author", Username: "author", TeamName: "period_team", IsActive: true}))
+	require.NoError(t, user.Create(db, &domain.User{UserID: "period_rev", Username: "rev", TeamName: "period_team", IsActive: true}))
+
+	jan1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+	jan31 := time.Date(2024, 1, 31, 23, 59, 59, 0, time.Local)
+
+	// pr_before is created a second before the window, pr_start exactly on from, pr_end exactly on to,
+	// pr_after a second after the window.
+	seed := []struct {
+		id         string
+		createdAt  time.Time
+		mergedAt   *time.Time
+		assignedAt time.Time
+	}{
+		{"pr_before", jan1.Add(-time.Second), &jan1, jan1.Add(-time.Second)},
+		{"pr_start", jan1, &jan31, jan1},
+		{"pr_end", jan31, nil, jan31},
+		{"pr_after", jan31.Add(time.Second), nil, jan31.Add(time.Second)},
+	}
+	for _, s := range seed {
+		require.NoError(t, pr.Create(db, &domain.PullRequest{
+			PullRequestID:   s.id,
+			PullRequestName: s.id,
+			AuthorID:        "period_author",
+			TeamName:        "period_team",
+			Status:          domain.StatusOpen,
+		}))
+		require.NoError(t, pr.InsertReviewer(db, domain.PRKey{PullRequestID: s.id}, "period_rev", time.Now().UTC()))
+		_, err := db.Exec(`UPDATE pull_requests SET created_at = $2, merged_at = $3 WHERE pull_request_id = $1`, s.id, s.createdAt, s.mergedAt)
+		require.NoError(t, err)
+		_, err = db.Exec(`UPDATE pr_reviewers SET assigned_at = $2 WHERE pull_request_id = $1`, s.id, s.assignedAt)
+		require.NoError(t, err)
+	}
+
+	countFor := func(st *service.Statistics, userID string) (int64, int64) {
+		var reviews, authored int64
+		for _, rs := range st.ReviewerStats {
+			if rs.UserID == userID {
+				reviews = rs.Count
+			}
+		}
+		for _, as := range st.AuthorStats {
+			if as.UserID == userID {
+				authored = as.Count
+			}
+		}
+		return reviews, authored
+	}
+
+	t.Run("all-time when period is empty", func(t *testing.T) {
+		st, err := statsService.GetStatistics(t.Context(), stats.Period{})
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(4), st.Overall.TotalPRs)
+		assert.Equal(t, int64(2), st.Overall.MergedPRs)
+		assert.Equal(t, int64(4), st.Overall.TotalAssignments)
+		assert.Equal(t, int64(2), st.Overall.TotalUsers)
+		assert.Equal(t, int64(1), st.Overall.TotalTeams)
+	})
+
+	t.Run("window includes both boundaries", func(t *testing.T) {
+		st, err := statsService.GetStatistics(t.Context(), stats.Period{From: &jan1, To: &jan31})
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(2), st.Overall.TotalPRs)
+		assert.Equal(t, int64(2), st.Overall.MergedPRs)
+		assert.Equal(t, int64(2), st.Overall.TotalAssignments)
+		assert.Equal(t, int64(2), st.Overall.TotalUsers)
+
+		reviews, _ := countFor(st, "period_rev")
+		assert.Equal(t, int64(2), reviews)
+		_, authored := countFor(st, "period_author")
+		assert.Equal(t, int64(2), authored)
+	})
+
+	t.Run("open-ended from", func(t *testing.T) {
+		st, err := statsService.GetStatistics(t.Context(), stats.Period{From: &jan31})
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(2), st.Overall.TotalPRs)
+		assert.Equal(t, int64(1), st.Overall.MergedPRs)
+		assert.Equal(t, int64(2), st.Overall.TotalAssignments)
+	})
+
+	t.Run("open-ended to", func(t *testing.T) {
+		beforeJan1 := jan1.Add(-time.Second)
+		st, err := statsService.GetStatistics(t.Context(), stats.Period{To: &beforeJan1})
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(1), st.Overall.TotalPRs)
+		assert.Equal(t, int64(0), st.Overall.MergedPRs)
+		assert.Equal(t, int64(1), st.Overall.TotalAssignments)
+		assert.Len(t, st.ReviewerStats, 2)
+	})
+}
+
+func TestStatsService_GetStatistics_Distribution(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	statsService := service.NewStatsService(db, service.NewSystemClock())
+
+	t.Run("empty when there are no active users", func(t *testing.T) {
+		st, err := statsService.GetStatistics(t.Context(), stats.Period{})
+		require.NoError(t, err)
+
+		assert.Equal(t, service.LoadDistribution{}, st.Distribution.Overall)
+		assert.Empty(t, st.Distribution.Teams)
+	})
+
+	t.Run("skewed load", func(t *testing.T) {
+		require.NoError(t, team.Create(db, "dist_a"))
+		require.NoError(t, team.Create(db, "dist_b"))
+
+		// dist_a: a1..a4 idle, a5 holds five open reviews.
+		// dist_b: b1..b5 hold one open review each.
+		// The author and an inactive reviewer are not counted.
+		require.NoError(t, user.Create(db, &domain.User{UserID: "dist_author", Username: "author", TeamName: "dist_a", IsActive: false}))
+		require.NoError(t, user.Create(db, &domain.User{UserID: "dist_inactive", Username: "inactive", TeamName: "dist_a", IsActive: false}))
+		for _, id := range []string{"a1", "a2", "a3", "a4", "a5"} {
+			require.NoError(t, user.Create(db, &domain.User{UserID: "dist_" + id, Username: id, TeamName: "dist_a", IsActive: true}))
+		}
+		for _, id := range []string{"b1", "b2", "b3", "b4", "b5"} {
+			require.NoError(t, user.Create(db, &domain.User{UserID: "dist_" + id, Username: id, TeamName: "dist_b", IsActive: true}))
+		}
+
+		createPR := func(id string, status domain.PRStatus, reviewers ...string) {
+			require.NoError(t, pr.Create(db, &domain.PullRequest{
+				PullRequestID:   id,
+				PullRequestName: id,
+				AuthorID:        "dist_author",
+				TeamName:        "dist_a",
+				Status:          status,
+			}))
+			for _, r := range reviewers {
+				require.NoError(t, pr.InsertReviewer(db, domain.PRKey{PullRequestID: id}, r, time.Now().UTC()))
+			}
+		}
+		for i := 1; i <= 5; i++ {
+			createPR(fmt.Sprintf("dist_pr%d", i), domain.StatusOpen, "dist_a5")
+		}
+		createPR("dist_pr_b", domain.StatusOpen, "dist_b1", "dist_b2", "dist_b3", "dist_b4", "dist_b5", "dist_inactive")
+		createPR("dist_pr_merged", domain.StatusMerged, "dist_a1", "dist_b1")
+
+		st, err := statsService.GetStatistics(t.Context(), stats.Period{})
+		require.NoError(t, err)
+
+		// Overall loads: 0,0,0,0,5,1,1,1,1,1 -> mean 1, variance 2.
+		overall := st.Distribution.Overall
+		assert.Equal(t, 10, overall.ActiveUsers)
+		assert.Equal(t, int64(0), overall.Min)
+		assert.Equal(t, int64(5), overall.Max)
+		assert.Equal(t, 1.0, overall.Mean)
+		assert.Equal(t, 1.0, overall.Median)
+		assert.InDelta(t, math.Sqrt2, overall.StdDev, 1e-9)
+
+		require.Len(t, st.Distribution.Teams, 2)
+
+		// dist_a loads: 0,0,0,0,5 -> mean 1, variance 4.
+		assert.Equal(t, service.TeamLoadDistribution{
+			TeamName: "dist_a",
+			LoadDistribution: service.LoadDistribution{
+				ActiveUsers: 5, Min: 0, Max: 5, Mean: 1, Median: 0, StdDev: 2,
+			},
+		}, st.Distribution.Teams[0])
+
+		assert.Equal(t, service.TeamLoadDistribution{
+			TeamName: "dist_b",
+			LoadDistribution: service.LoadDistribution{
+				ActiveUsers: 5, Min: 1, Max: 1, Mean: 1, Median: 1, StdDev: 0,
+			},
+		}, st.Distribution.Teams[1])
+	})
+
+	t.Run("secondary membership counts in both teams but once overall", func(t *testing.T) {
+		require.NoError(t, team.AddMember(db, "dist_b", "dist_a5", false))
+
+		st, err := statsService.GetStatistics(t.Context(), stats.Period{})
+		require.NoError(t, err)
+
+		assert.Equal(t, 10, st.Distribution.Overall.ActiveUsers)
+		require.Len(t, st.Distribution.Teams, 2)
+
+		// dist_b loads: 1,1,1,1,1,5 -> mean 5/3, median 1.
+		teamB := st.Distribution.Teams[1]
+		assert.Equal(t, "dist_b", teamB.TeamName)
+		assert.Equal(t, 6, teamB.ActiveUsers)
+		assert.Equal(t, int64(5), teamB.Max)
+		assert.InDelta(t, 5.0/3.0, teamB.Mean, 1e-9)
+		assert.Equal(t, 1.0, teamB.Median)
+	})
+}
+
+func TestStatsService_GetStatistics_Cache(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	teamService := service.NewTeamService(db, prService)
+	clock := &tests.FakeClock{Current: time.Now()}
+	statsService := service.NewStatsService(db, clock).
+		WithCache(service.NewStatsCache(time.Minute, clock, prService.DataVersion()))
+
+	_, _, err = teamService.CreateTeam(t.Context(), &domain.Team{
+		TeamName: "cache_team",
+		Members: []domain.TeamMember{
+			{UserID: "cache_author", Username: "author", IsActive: true},
+			{UserID: "cache_rev", Username: "rev", IsActive: true},
+		},
+	}, service.CreateTeamOptions{})
+	require.NoError(t, err)
+
+	first, err := statsService.GetStatistics(t.Context(), stats.Period{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), first.Overall.TotalPRs)
+
+	t.Run("writes bypassing the services are not seen until ttl expires", func(t *testing.T) {
+		require.NoError(t, pr.Create(db, &domain.PullRequest{
+			PullRequestID:   "cache_direct",
+			PullRequestName: "direct",
+			AuthorID:        "cache_author",
+			TeamName:        "cache_team",
+			Status:          domain.StatusOpen,
+		}))
+
+		cached, err := statsService.GetStatistics(t.Context(), stats.Period{})
+		require.NoError(t, err)
+		assert.Same(t, first, cached)
+
+		clock.Advance(time.Minute)
+		fresh, err := statsService.GetStatistics(t.Context(), stats.Period{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), fresh.Overall.TotalPRs)
+	})
+
+	t.Run("writes through PRService invalidate immediately", func(t *testing.T) {
+		_, err := prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: "cache_pr"}, "via service", "cache_author", nil, domain.PRDetails{})
+		require.NoError(t, err)
+
+		st, err := statsService.GetStatistics(t.Context(), stats.Period{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), st.Overall.TotalPRs)
+
+		_, err = prService.MergePR(t.Context(), domain.PRKey{PullRequestID: "cache_pr"}, service.MergeOptions{})
+		require.NoError(t, err)
+
+		st, err = statsService.GetStatistics(t.Context(), stats.Period{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), st.Overall.MergedPRs)
+	})
+}
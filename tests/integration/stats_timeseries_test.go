@@ -0,0 +1,147 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/stats"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestStatsService_GetThroughput(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	// Wednesday; the current week starts on Monday 2024-03-18.
+	clock := &tests.FakeClock{Current: time.Date(2024, 3, 20, 12, 0, 0, 0, time.UTC)}
+	statsService := service.NewStatsService(db, clock)
+
+	require.NoError(t, team.Create(db, "ts_backend"))
+	require.NoError(t, team.Create(db, "ts_frontend"))
+	require.NoError(t, user.Create(db, &domain.User{UserID: "ts_back", Username: "back", TeamName: "ts_backend", IsActive: true}))
+	require.NoError(t, user.Create(db, &domain.User{UserID: "ts_front", Username: "front", TeamName: "ts_frontend", IsActive: true}))
+
+	weeksAgo := func(weeks int, d time.Duration) time.Time {
+		return clock.Now().AddDate(0, 0, -7*weeks).Add(d)
+	}
+	seed := []struct {
+		id        string
+		authorID  string
+		teamName  string
+		createdAt time.Time
+		mergedAt  *time.Time
+	}{
+		{"ts_pr1", "ts_back", "ts_backend", weeksAgo(2, 0), ptrTime(weeksAgo(1, 0))},
+		{"ts_pr2", "ts_back", "ts_backend", weeksAgo(2, time.Hour), ptrTime(weeksAgo(0, -time.Hour))},
+		{"ts_pr3", "ts_back", "ts_backend", weeksAgo(0, -2*time.Hour), nil},
+		{"ts_pr4", "ts_front", "ts_frontend", weeksAgo(1, 0), ptrTime(weeksAgo(1, time.Hour))},
+		{"ts_old", "ts_back", "ts_backend", weeksAgo(20, 0), ptrTime(weeksAgo(20, time.Hour))},
+	}
+	for _, s := range seed {
+		require.NoError(t, pr.Create(db, &domain.PullRequest{
+			PullRequestID:   s.id,
+			PullRequestName: s.id,
+			AuthorID:        s.authorID,
+			TeamName:        s.teamName,
+			Status:          domain.StatusOpen,
+		}))
+		_, err := db.Exec(`UPDATE pull_requests SET created_at = $2, merged_at = $3 WHERE pull_request_id = $1`, s.id, s.createdAt, s.mergedAt)
+		require.NoError(t, err)
+	}
+
+	weekStart := func(weeks int) string {
+		return time.Date(2024, 3, 18, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -7*weeks).Format(time.DateOnly)
+	}
+	type point struct {
+		start           string
+		created, merged int64
+	}
+	toPoints := func(ps []stats.ThroughputPoint) []point {
+		out := make([]point, len(ps))
+		for i, p := range ps {
+			out[i] = point{p.BucketStart.Format(time.DateOnly), p.Created, p.Merged}
+		}
+		return out
+	}
+
+	t.Run("weekly buckets over a bounded window", func(t *testing.T) {
+		from := weeksAgo(3, 0)
+		result, err := statsService.GetThroughput(t.Context(), stats.BucketWeek, stats.Period{From: &from}, "")
+		require.NoError(t, err)
+
+		assert.True(t, result.To.Equal(clock.Now()))
+		assert.Equal(t, []point{
+			{weekStart(3), 0, 0},
+			{weekStart(2), 2, 0},
+			{weekStart(1), 1, 2},
+			{weekStart(0), 1, 1},
+		}, toPoints(result.Points))
+	})
+
+	t.Run("default window is twelve weeks before now", func(t *testing.T) {
+		result, err := statsService.GetThroughput(t.Context(), stats.BucketWeek, stats.Period{}, "")
+		require.NoError(t, err)
+
+		assert.True(t, result.From.Equal(weeksAgo(12, 0)))
+		assert.Len(t, result.Points, 13)
+
+		var created, merged int64
+		for _, p := range result.Points {
+			created += p.Created
+			merged += p.Merged
+		}
+		assert.Equal(t, int64(4), created, "ts_old is outside the default window")
+		assert.Equal(t, int64(3), merged)
+	})
+
+	t.Run("filtered by team", func(t *testing.T) {
+		from := weeksAgo(2, 0)
+		result, err := statsService.GetThroughput(t.Context(), stats.BucketWeek, stats.Period{From: &from}, "ts_frontend")
+		require.NoError(t, err)
+
+		assert.Equal(t, []point{
+			{weekStart(2), 0, 0},
+			{weekStart(1), 1, 1},
+			{weekStart(0), 0, 0},
+		}, toPoints(result.Points))
+	})
+
+	t.Run("daily buckets", func(t *testing.T) {
+		from := weeksAgo(0, -3*time.Hour)
+		to := clock.Now()
+		result, err := statsService.GetThroughput(t.Context(), stats.BucketDay, stats.Period{From: &from, To: &to}, "ts_backend")
+		require.NoError(t, err)
+
+		assert.Equal(t, []point{{"2024-03-20", 1, 1}}, toPoints(result.Points))
+	})
+
+	t.Run("error - unknown team", func(t *testing.T) {
+		_, err := statsService.GetThroughput(t.Context(), stats.BucketWeek, stats.Period{}, "ts_missing")
+		assert.ErrorIs(t, err, service.ErrTeamNotFound)
+	})
+
+	t.Run("error - too many buckets", func(t *testing.T) {
+		from := clock.Now().AddDate(-2, 0, 0)
+		_, err := statsService.GetThroughput(t.Context(), stats.BucketDay, stats.Period{From: &from}, "")
+		assert.ErrorIs(t, err, service.ErrTooManyBuckets)
+	})
+
+	t.Run("error - unknown bucket", func(t *testing.T) {
+		_, err := statsService.GetThroughput(t.Context(), stats.BucketSize("month"), stats.Period{}, "")
+		assert.ErrorIs(t, err, service.ErrInvalidBucket)
+	})
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,133 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/absence"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestStatsService_GetUserStatistics(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	clock := &tests.FakeClock{Current: time.Date(2024, 3, 20, 12, 0, 0, 0, time.Local)}
+	statsService := service.NewStatsService(db, clock)
+
+	maxOpen := 1
+	require.NoError(t, team.Create(db, "us_team"))
+	require.NoError(t, user.Create(db, &domain.User{UserID: "us_author", Username: "author", TeamName: "us_team", IsActive: true}))
+	require.NoError(t, user.Create(db, &domain.User{UserID: "us_rev", Username: "rev", TeamName: "us_team", IsActive: true, MaxOpenReviews: &maxOpen}))
+	require.NoError(t, user.Create(db, &domain.User{UserID: "us_idle", Username: "idle", TeamName: "us_team", IsActive: false}))
+
+	created := clock.Now().Add(-48 * time.Hour)
+	seed := []struct {
+		id       string
+		author   string
+		mergedIn *time.Duration
+	}{
+		{"us_pr1", "us_author", ptrDuration(time.Hour)},
+		{"us_pr2", "us_author", ptrDuration(3 * time.Hour)},
+		{"us_pr3", "us_author", nil},
+		{"us_pr4", "us_rev", nil},
+		{"us_pr5", "us_rev", ptrDuration(time.Hour)},
+	}
+	for _, s := range seed {
+		status := domain.StatusOpen
+		var mergedAt *time.Time
+		if s.mergedIn != nil {
+			status = domain.StatusMerged
+			m := created.Add(*s.mergedIn)
+			mergedAt = &m
+		}
+		require.NoError(t, pr.Create(db, &domain.PullRequest{
+			PullRequestID:   s.id,
+			PullRequestName: s.id,
+			AuthorID:        s.author,
+			TeamName:        "us_team",
+			Status:          status,
+		}))
+		_, err := db.Exec(`UPDATE pull_requests SET created_at = $2, merged_at = $3 WHERE pull_request_id = $1`, s.id, created, mergedAt)
+		require.NoError(t, err)
+	}
+	for _, id := range []string{"us_pr1", "us_pr2", "us_pr3"} {
+		require.NoError(t, pr.InsertReviewer(db, domain.PRKey{PullRequestID: id}, "us_rev", time.Now().UTC()))
+	}
+	require.NoError(t, pr.InsertReviewer(db, domain.PRKey{PullRequestID: "us_pr5"}, "us_author", time.Now().UTC()))
+
+	require.NoError(t, absence.Create(db, &domain.Absence{
+		UserID:   "us_rev",
+		FromDate: time.Date(2024, 3, 18, 0, 0, 0, 0, time.UTC),
+		ToDate:   time.Date(2024, 3, 22, 0, 0, 0, 0, time.UTC),
+	}))
+	require.NoError(t, absence.Create(db, &domain.Absence{
+		UserID:   "us_author",
+		FromDate: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		ToDate:   time.Date(2024, 4, 5, 0, 0, 0, 0, time.UTC),
+	}))
+
+	t.Run("reviewer with activity, at capacity and absent", func(t *testing.T) {
+		st, err := statsService.GetUserStatistics(t.Context(), "us_rev")
+		require.NoError(t, err)
+
+		assert.Equal(t, "us_rev", st.User.UserID)
+		assert.Equal(t, int64(1), st.Activity.OpenReviews)
+		assert.Equal(t, int64(2), st.Activity.CompletedReviews)
+		assert.Equal(t, int64(1), st.Activity.AuthoredOpen)
+		assert.Equal(t, int64(1), st.Activity.AuthoredMerged)
+		require.NotNil(t, st.Activity.AvgTimeToMerge)
+		assert.Equal(t, 2*time.Hour, *st.Activity.AvgTimeToMerge)
+		assert.True(t, st.AtCapacity)
+		require.NotNil(t, st.CurrentAbsence)
+		assert.Equal(t, "2024-03-18", st.CurrentAbsence.FromDate.Format(domain.DateLayout))
+	})
+
+	t.Run("future absence is not current", func(t *testing.T) {
+		st, err := statsService.GetUserStatistics(t.Context(), "us_author")
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(0), st.Activity.OpenReviews)
+		assert.Equal(t, int64(1), st.Activity.CompletedReviews)
+		assert.Equal(t, int64(1), st.Activity.AuthoredOpen)
+		assert.Equal(t, int64(2), st.Activity.AuthoredMerged)
+		require.NotNil(t, st.Activity.AvgTimeToMerge)
+		assert.Equal(t, time.Hour, *st.Activity.AvgTimeToMerge)
+		assert.False(t, st.AtCapacity)
+		assert.Nil(t, st.CurrentAbsence)
+	})
+
+	t.Run("user with zero activity", func(t *testing.T) {
+		st, err := statsService.GetUserStatistics(t.Context(), "us_idle")
+		require.NoError(t, err)
+
+		assert.Equal(t, "us_idle", st.User.UserID)
+		assert.False(t, st.User.IsActive)
+		assert.Zero(t, st.Activity.OpenReviews)
+		assert.Zero(t, st.Activity.CompletedReviews)
+		assert.Zero(t, st.Activity.AuthoredOpen)
+		assert.Zero(t, st.Activity.AuthoredMerged)
+		assert.Nil(t, st.Activity.AvgTimeToMerge)
+		assert.False(t, st.AtCapacity)
+		assert.Nil(t, st.CurrentAbsence)
+	})
+
+	t.Run("error - unknown user", func(t *testing.T) {
+		_, err := statsService.GetUserStatistics(t.Context(), "us_ghost")
+		assert.ErrorIs(t, err, service.ErrUserNotFound)
+	})
+}
+
+func ptrDuration(d time.Duration) *time.Duration {
+	return &d
+}
@@ -2,11 +2,13 @@ package integration
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
 	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
 	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
 	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
@@ -32,7 +34,7 @@ func TestTeamService_DeactivateTeam(t *testing.T) {
 		require.NoError(t, user.Create(db, &domain.User{UserID: userID1, Username: "User1", TeamName: teamName, IsActive: true}))
 		require.NoError(t, user.Create(db, &domain.User{UserID: userID2, Username: "User2", TeamName: teamName, IsActive: true}))
 
-		err := teamService.DeactivateTeam(teamName)
+		err := teamService.DeactivateTeam(t.Context(), teamName)
 		require.NoError(t, err)
 
 		// Verify users are inactive
@@ -46,7 +48,7 @@ func TestTeamService_DeactivateTeam(t *testing.T) {
 	})
 
 	t.Run("error - team not found", func(t *testing.T) {
-		err := teamService.DeactivateTeam("nonexistent_team")
+		err := teamService.DeactivateTeam(t.Context(), "nonexistent_team")
 		assert.ErrorIs(t, err, service.ErrTeamNotFound)
 	})
 
@@ -66,9 +68,9 @@ func TestTeamService_DeactivateTeam(t *testing.T) {
 
 		prID := "pr-deact-1"
 		require.NoError(t, pr.Create(db, &domain.PullRequest{PullRequestID: prID, PullRequestName: "PR 1", AuthorID: authorID, TeamName: authorTeam, Status: domain.StatusOpen}))
-		require.NoError(t, pr.InsertReviewer(db, prID, reviewerID))
+		require.NoError(t, pr.InsertReviewer(db, domain.PRKey{PullRequestID: prID}, reviewerID, time.Now().UTC()))
 
-		err := teamService.DeactivateTeam(teamToDeactivate)
+		err := teamService.DeactivateTeam(t.Context(), teamToDeactivate)
 		require.NoError(t, err)
 
 		// Verify reviewer is inactive
@@ -77,7 +79,7 @@ func TestTeamService_DeactivateTeam(t *testing.T) {
 		assert.False(t, uRev.IsActive)
 
 		// Verify PR is updated
-		pullRequest, err := pr.Get(db, prID)
+		pullRequest, err := pr.Get(db, domain.PRKey{PullRequestID: prID})
 		require.NoError(t, err)
 		assert.Len(t, pullRequest.AssignedReviewersIDs, 1)
 		assert.NotEqual(t, reviewerID, pullRequest.AssignedReviewersIDs[0])
@@ -97,14 +99,74 @@ func TestTeamService_DeactivateTeam(t *testing.T) {
 		require.NoError(t, pr.Create(db, &domain.PullRequest{
 			PullRequestID: prIDSame, PullRequestName: "PR Same", AuthorID: authorIDSame, TeamName: teamNameSame, Status: domain.StatusOpen,
 		}))
-		require.NoError(t, pr.InsertReviewer(db, prIDSame, reviewerIDSame))
+		require.NoError(t, pr.InsertReviewer(db, domain.PRKey{PullRequestID: prIDSame}, reviewerIDSame, time.Now().UTC()))
 
-		err := teamService.DeactivateTeam(teamNameSame)
+		err := teamService.DeactivateTeam(t.Context(), teamNameSame)
 		require.NoError(t, err)
 
 		// PR should have no reviewers (replenish skipped because PR team == deactivated team)
-		pullRequest, err := pr.Get(db, prIDSame)
+		pullRequest, err := pr.Get(db, domain.PRKey{PullRequestID: prIDSame})
 		require.NoError(t, err)
 		assert.Empty(t, pullRequest.AssignedReviewersIDs)
 	})
+
+	t.Run("success - members of the deactivated team who also belong to the PR team are never picked", func(t *testing.T) {
+		// crossID and spareID belong to the deactivated team and, as secondary members, to the PR's team.
+		goneTeam := "team_cross_gone"
+		prTeam := "team_cross_pr"
+		reviewerID := "cross_reviewer"
+		crossID := "cross_member"
+		spareID := "cross_spare"
+		authorID := "cross_author"
+		mateID := "cross_mate"
+		require.NoError(t, team.Create(db, goneTeam))
+		require.NoError(t, team.Create(db, prTeam))
+		for _, id := range []string{reviewerID, crossID, spareID} {
+			require.NoError(t, user.Create(db, &domain.User{UserID: id, Username: id, TeamName: goneTeam, IsActive: true}))
+		}
+		require.NoError(t, team.AddMember(db, prTeam, crossID, false))
+		require.NoError(t, team.AddMember(db, prTeam, spareID, false))
+		require.NoError(t, user.Create(db, &domain.User{UserID: authorID, Username: "CrossAuthor", TeamName: prTeam, IsActive: true}))
+		require.NoError(t, user.Create(db, &domain.User{UserID: mateID, Username: "CrossMate", TeamName: prTeam, IsActive: true}))
+
+		// While still active, the cross members are candidates unless their team is excluded.
+		candidates, err := user.GetReassignCandidatesOutside(db, prTeam, authorID, goneTeam)
+		require.NoError(t, err)
+		candidateIDs := make([]string, 0, len(candidates))
+		for _, c := range candidates {
+			candidateIDs = append(candidateIDs, c.UserID)
+		}
+		assert.ElementsMatch(t, []string{authorID, mateID}, candidateIDs)
+		all, err := user.GetReassignCandidates(db, prTeam, authorID)
+		require.NoError(t, err)
+		assert.Len(t, all, 4)
+
+		prIDs := []string{"pr-cross-1", "pr-cross-2", "pr-cross-3"}
+		for _, prID := range prIDs {
+			require.NoError(t, pr.Create(db, &domain.PullRequest{
+				PullRequestID: prID, PullRequestName: prID, AuthorID: authorID, TeamName: prTeam, Status: domain.StatusOpen,
+			}))
+			require.NoError(t, pr.InsertReviewer(db, domain.PRKey{PullRequestID: prID}, reviewerID, time.Now().UTC()))
+		}
+		// The insert itself refuses members of the excluded team.
+		err = pr.InsertActiveReviewer(db, domain.PRKey{PullRequestID: prIDs[0]}, crossID, goneTeam, time.Now().UTC())
+		require.ErrorIs(t, err, repository.ErrNotFound)
+
+		require.NoError(t, teamService.DeactivateTeam(t.Context(), goneTeam))
+
+		for _, prID := range prIDs {
+			pullRequest, err := pr.Get(db, domain.PRKey{PullRequestID: prID})
+			require.NoError(t, err)
+			assert.Equal(t, []string{mateID}, pullRequest.AssignedReviewersIDs, prID)
+			for _, id := range pullRequest.AssignedReviewersIDs {
+				u, err := user.Get(db, id)
+				require.NoError(t, err)
+				assert.True(t, u.IsActive, "inactive user %s assigned to %s", id, prID)
+			}
+		}
+
+		// A deactivated user is refused at insert time even without an excluded team.
+		err = pr.InsertActiveReviewer(db, domain.PRKey{PullRequestID: prIDs[0]}, crossID, "", time.Now().UTC())
+		require.ErrorIs(t, err, repository.ErrNotFound)
+	})
 }
@@ -0,0 +1,69 @@
+package integration
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestTeamService_ImportTeams(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	require.NoError(t, team.Create(db, "old_team"))
+	require.NoError(t, user.Create(db, &domain.User{UserID: "stay", Username: "stay", TeamName: "old_team", IsActive: true}))
+	require.NoError(t, user.Create(db, &domain.User{UserID: "mover", Username: "mover", TeamName: "old_team", IsActive: true}))
+
+	teamService := service.NewTeamService(db, service.NewPRService(db, service.NewReviewerAssigner()))
+
+	input := "team_name,user_id,username,is_active\n" +
+		"old_team,stay,Stay Renamed,false\n" +
+		"new_team,mover,mover,true\n" +
+		"new_team,fresh,\"Fresh, Jr\",true\n" +
+		"new_team,fresh,Duplicate,true\n"
+
+	summary, err := teamService.ImportTeams(t.Context(), strings.NewReader(input))
+	require.NoError(t, err)
+	assert.Equal(t, 1, summary.TeamsCreated)
+	assert.Equal(t, 1, summary.UsersCreated)
+	assert.Equal(t, 1, summary.UsersUpdated)
+	assert.Equal(t, 1, summary.UsersMoved)
+	require.Len(t, summary.Errors, 1)
+	assert.Equal(t, 5, summary.Errors[0].Line)
+
+	stay, err := user.Get(db, "stay")
+	require.NoError(t, err)
+	assert.Equal(t, "Stay Renamed", stay.Username)
+	assert.False(t, stay.IsActive)
+
+	mover, err := user.Get(db, "mover")
+	require.NoError(t, err)
+	assert.Equal(t, "new_team", mover.TeamName)
+
+	fresh, err := user.Get(db, "fresh")
+	require.NoError(t, err)
+	assert.Equal(t, "Fresh, Jr", fresh.Username)
+
+	newTeam, err := team.Get(db, "new_team")
+	require.NoError(t, err)
+	assert.Len(t, newTeam.Members, 2)
+
+	t.Run("malformed header writes nothing", func(t *testing.T) {
+		_, err := teamService.ImportTeams(t.Context(), strings.NewReader("team,user\nx,y\n"))
+		assert.ErrorIs(t, err, service.ErrInvalidCSV)
+
+		exists, err := team.Exists(db, "x")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
@@ -0,0 +1,69 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestTeamMemberships(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	teamService := service.NewTeamService(db, prService)
+
+	_, _, err = teamService.CreateTeam(t.Context(), &domain.Team{TeamName: "squad_a", Members: []domain.TeamMember{
+		{UserID: "author_a", Username: "author_a", IsActive: true},
+		{UserID: "platform", Username: "platform", IsActive: true},
+	}}, service.CreateTeamOptions{})
+	require.NoError(t, err)
+	_, _, err = teamService.CreateTeam(t.Context(), &domain.Team{TeamName: "squad_b", Members: []domain.TeamMember{
+		{UserID: "author_b", Username: "author_b", IsActive: true},
+		{UserID: "platform", Username: "platform", IsActive: true},
+	}}, service.CreateTeamOptions{})
+	require.NoError(t, err)
+
+	t.Run("user listed in both teams with first one as primary", func(t *testing.T) {
+		for _, name := range []string{"squad_a", "squad_b"} {
+			tm, err := teamService.GetTeam(t.Context(), name)
+			require.NoError(t, err)
+			ids := make([]string, len(tm.Members))
+			for i, m := range tm.Members {
+				ids[i] = m.UserID
+			}
+			assert.Contains(t, ids, "platform", name)
+		}
+
+		u, err := user.Get(db, "platform")
+		require.NoError(t, err)
+		assert.Equal(t, "squad_a", u.TeamName)
+	})
+
+	t.Run("assignable from either pool", func(t *testing.T) {
+		prA, err := prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: "pr_a"}, "A", "author_a", nil, domain.PRDetails{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"platform"}, prA.AssignedReviewersIDs)
+
+		prB, err := prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: "pr_b"}, "B", "author_b", nil, domain.PRDetails{})
+		require.NoError(t, err)
+		assert.Equal(t, "squad_b", prB.TeamName)
+		assert.Equal(t, []string{"platform"}, prB.AssignedReviewersIDs)
+	})
+
+	t.Run("secondary team reviewers are found by team lookup", func(t *testing.T) {
+		reviewers, err := pr.GetOpenPRsWithReviewersFromTeam(db, "squad_b")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"platform"}, reviewers[domain.PRKey{PullRequestID: "pr_a"}])
+		assert.ElementsMatch(t, []string{"platform"}, reviewers[domain.PRKey{PullRequestID: "pr_b"}])
+	})
+}
@@ -0,0 +1,121 @@
+package integration
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/history"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestTeamService_RebalanceTeam(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	teamService := service.NewTeamService(db, prService)
+
+	reviewers := []string{"b_tr", "c_tr", "d_tr", "e_tr"}
+	require.NoError(t, team.Create(db, "team_tr"))
+	for _, id := range append([]string{"a_tr"}, reviewers...) {
+		require.NoError(t, user.Create(db, &domain.User{UserID: id, Username: id, TeamName: "team_tr", IsActive: true}))
+	}
+
+	// b_tr reviews all eight pull requests of a_tr, c_tr two of them.
+	for i := 1; i <= 8; i++ {
+		key := domain.PRKey{PullRequestID: fmt.Sprintf("tr-%d", i)}
+		require.NoError(t, pr.Create(db, &domain.PullRequest{
+			PullRequestID:   key.PullRequestID,
+			PullRequestName: key.PullRequestID,
+			AuthorID:        "a_tr",
+			TeamName:        "team_tr",
+			Status:          domain.StatusOpen,
+		}))
+		require.NoError(t, pr.InsertReviewer(db, key, "b_tr", time.Now().UTC()))
+		if i <= 2 {
+			require.NoError(t, pr.InsertReviewer(db, key, "c_tr", time.Now().UTC()))
+		}
+	}
+	// b_tr is a required reviewer of one more pull request.
+	requiredKey := domain.PRKey{PullRequestID: "tr-required"}
+	_, err = prService.CreatePR(t.Context(), requiredKey, "Required review", "a_tr", []string{"b_tr"}, domain.PRDetails{})
+	require.NoError(t, err)
+
+	loads := func() map[string]int {
+		t.Helper()
+		prs, err := pr.GetOpenByTeam(db, "team_tr")
+		require.NoError(t, err)
+		counts := make(map[string]int)
+		for _, p := range prs {
+			for _, r := range p.AssignedReviewersIDs {
+				counts[r]++
+			}
+		}
+		return counts
+	}
+	before := loads()
+
+	t.Run("dry run respects max_moves and changes nothing", func(t *testing.T) {
+		moves, err := teamService.RebalanceTeam(t.Context(), "team_tr", service.RebalanceOptions{DryRun: true, MaxMoves: 1})
+		require.NoError(t, err)
+		require.Len(t, moves, 1)
+		assert.Equal(t, "b_tr", moves[0].From)
+		assert.Equal(t, before, loads())
+	})
+
+	t.Run("max_moves limits applied moves", func(t *testing.T) {
+		moves, err := teamService.RebalanceTeam(t.Context(), "team_tr", service.RebalanceOptions{MaxMoves: 2})
+		require.NoError(t, err)
+		require.Len(t, moves, 2)
+		assert.Equal(t, before["b_tr"]-2, loads()["b_tr"])
+
+		events, err := history.GetByPR(db, moves[0].PR)
+		require.NoError(t, err)
+		require.NotEmpty(t, events)
+		last := events[len(events)-1]
+		assert.Equal(t, domain.ActionRebalance, last.Action)
+		assert.Equal(t, moves[0].From, last.OldUserID)
+		assert.Equal(t, moves[0].To, last.NewUserID)
+
+		moved, err := pr.Get(db, moves[0].PR)
+		require.NoError(t, err)
+		assert.Equal(t, domain.SourceRebalance, sourcesOf(moved)[moves[0].To])
+	})
+
+	t.Run("spread differs by at most one", func(t *testing.T) {
+		_, err := teamService.RebalanceTeam(t.Context(), "team_tr", service.RebalanceOptions{})
+		require.NoError(t, err)
+
+		after := loads()
+		minLoad, maxLoad := after[reviewers[0]], after[reviewers[0]]
+		for _, id := range reviewers {
+			minLoad, maxLoad = min(minLoad, after[id]), max(maxLoad, after[id])
+		}
+		assert.LessOrEqual(t, maxLoad-minLoad, 1, "loads after rebalance: %v", after)
+		assert.Zero(t, after["a_tr"], "the author never reviews own pull requests")
+
+		required, err := pr.Get(db, requiredKey)
+		require.NoError(t, err)
+		assert.Contains(t, required.AssignedReviewersIDs, "b_tr", "required reviewers are not moved")
+
+		moves, err := teamService.RebalanceTeam(t.Context(), "team_tr", service.RebalanceOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, moves)
+	})
+
+	t.Run("unknown team", func(t *testing.T) {
+		_, err := teamService.RebalanceTeam(t.Context(), "ghost_tr", service.RebalanceOptions{})
+		assert.ErrorIs(t, err, service.ErrTeamNotFound)
+	})
+}
@@ -0,0 +1,132 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/audit"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/digest"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/ownership"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestTeamService_RenameTeam(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	teamService := service.NewTeamService(db, prService)
+
+	for _, tm := range []*domain.Team{
+		{TeamName: "backend_rn", Members: []domain.TeamMember{
+			{UserID: "author_rn", Username: "Author", IsActive: true},
+			{UserID: "dev1_rn", Username: "Dev 1", IsActive: true},
+			{UserID: "dev2_rn", Username: "Dev 2", IsActive: true},
+		}},
+		{TeamName: "frontend_rn", Members: []domain.TeamMember{
+			{UserID: "web_rn", Username: "Web", IsActive: true},
+		}},
+	} {
+		_, _, err = teamService.CreateTeam(t.Context(), tm, service.CreateTeamOptions{})
+		require.NoError(t, err)
+	}
+	require.NoError(t, team.AddMember(db, "backend_rn", "web_rn", false))
+	_, err = teamService.SetOwnershipRule(t.Context(), domain.OwnershipRule{TeamName: "backend_rn", PathPrefix: "api", OwnerUserID: "dev1_rn"})
+	require.NoError(t, err)
+	_, err = teamService.SetOwnershipRule(t.Context(), domain.OwnershipRule{TeamName: "frontend_rn", PathPrefix: "api", OwnerTeamName: "backend_rn"})
+	require.NoError(t, err)
+	require.NoError(t, digest.Set(db, &domain.DigestSchedule{TeamName: "backend_rn", Hour: 9, Timezone: "UTC"}))
+	fallback := "backend_rn"
+	_, err = teamService.UpdateTeam(t.Context(), "frontend_rn", service.TeamUpdate{FallbackTeamName: &fallback})
+	require.NoError(t, err)
+
+	key := domain.PRKey{PullRequestID: "pr_rn"}
+	created, err := prService.CreatePR(t.Context(), key, "Add search", "author_rn", nil, domain.PRDetails{})
+	require.NoError(t, err)
+	require.NotEmpty(t, created.AssignedReviewersIDs)
+
+	t.Run("new name taken", func(t *testing.T) {
+		_, err := teamService.RenameTeam(t.Context(), "backend_rn", "frontend_rn")
+		require.ErrorIs(t, err, service.ErrTeamExists)
+
+		// Nothing moved.
+		exists, err := team.Exists(db, "backend_rn")
+		require.NoError(t, err)
+		assert.True(t, exists)
+		u, err := user.Get(db, "dev1_rn")
+		require.NoError(t, err)
+		assert.Equal(t, "backend_rn", u.TeamName)
+	})
+
+	t.Run("nonexistent team", func(t *testing.T) {
+		_, err := teamService.RenameTeam(t.Context(), "ghost_rn", "platform_rn")
+		require.ErrorIs(t, err, service.ErrTeamNotFound)
+
+		exists, err := team.Exists(db, "platform_rn")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("references follow and open assignments are unaffected", func(t *testing.T) {
+		renamed, err := teamService.RenameTeam(t.Context(), "backend_rn", "platform_rn")
+		require.NoError(t, err)
+		assert.Equal(t, "platform_rn", renamed.TeamName)
+		memberIDs := make([]string, 0, len(renamed.Members))
+		for _, m := range renamed.Members {
+			memberIDs = append(memberIDs, m.UserID)
+		}
+		assert.ElementsMatch(t, []string{"author_rn", "dev1_rn", "dev2_rn", "web_rn"}, memberIDs)
+
+		exists, err := team.Exists(db, "backend_rn")
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		for _, id := range []string{"author_rn", "dev1_rn", "dev2_rn"} {
+			u, err := user.Get(db, id)
+			require.NoError(t, err)
+			assert.Equal(t, "platform_rn", u.TeamName)
+		}
+		// A secondary membership does not change the user's primary team.
+		web, err := user.Get(db, "web_rn")
+		require.NoError(t, err)
+		assert.Equal(t, "frontend_rn", web.TeamName)
+
+		rules, err := ownership.ListByTeam(db, "platform_rn")
+		require.NoError(t, err)
+		require.Len(t, rules, 1)
+		assert.Equal(t, "dev1_rn", rules[0].OwnerUserID)
+		rules, err = ownership.ListByTeam(db, "frontend_rn")
+		require.NoError(t, err)
+		require.Len(t, rules, 1)
+		assert.Equal(t, "platform_rn", rules[0].OwnerTeamName)
+
+		schedule, err := digest.Get(db, "platform_rn")
+		require.NoError(t, err)
+		assert.Equal(t, 9, schedule.Hour)
+
+		settings, err := team.GetSettings(db, "frontend_rn")
+		require.NoError(t, err)
+		assert.Equal(t, "platform_rn", settings.FallbackTeamName)
+
+		got, err := pr.Get(db, key)
+		require.NoError(t, err)
+		assert.Equal(t, "platform_rn", got.TeamName)
+		assert.Equal(t, domain.StatusOpen, got.Status)
+		assert.ElementsMatch(t, created.AssignedReviewersIDs, got.AssignedReviewersIDs)
+
+		entries, err := audit.List(db, audit.Filter{Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, domain.AuditTeamRename, entries[0].Action)
+		assert.Equal(t, "team:backend_rn->platform_rn", entries[0].Target)
+	})
+}
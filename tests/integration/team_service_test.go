@@ -63,7 +63,7 @@ func TestTeamService_CreateTeam(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := teamService.CreateTeam(tt.teamName, tt.members)
+			_, _, err := teamService.CreateTeam(t.Context(), &domain.Team{TeamName: tt.teamName, Members: tt.members}, service.CreateTeamOptions{})
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -91,6 +91,225 @@ func TestTeamService_CreateTeam(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("existing user keeps earlier team membership", func(t *testing.T) {
+		first, err := team.Get(db, "team1")
+		require.NoError(t, err)
+		ids := make([]string, len(first.Members))
+		for i, m := range first.Members {
+			ids[i] = m.UserID
+		}
+		assert.Contains(t, ids, "user1")
+
+		u, err := user.Get(db, "user1")
+		require.NoError(t, err)
+		assert.Equal(t, "team1", u.TeamName)
+	})
+}
+
+func TestTeamService_CreateTeamConflicts(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	teamService := service.NewTeamService(db, prService)
+
+	_, _, err = teamService.CreateTeam(t.Context(), &domain.Team{TeamName: "home", Members: []domain.TeamMember{
+		{UserID: "shared", Username: "shared", IsActive: true},
+	}}, service.CreateTeamOptions{})
+	require.NoError(t, err)
+
+	t.Run("duplicate user ids are rejected", func(t *testing.T) {
+		_, _, err := teamService.CreateTeam(t.Context(), &domain.Team{TeamName: "dup", Members: []domain.TeamMember{
+			{UserID: "dup1", Username: "first", IsActive: true},
+			{UserID: "dup1", Username: "second", IsActive: false},
+		}}, service.CreateTeamOptions{})
+
+		var duplicate *service.DuplicateMemberError
+		require.ErrorAs(t, err, &duplicate)
+		assert.Equal(t, "dup1", duplicate.UserID)
+		assert.ErrorIs(t, err, service.ErrDuplicateMember)
+
+		exists, err := team.Exists(db, "dup")
+		require.NoError(t, err)
+		assert.False(t, exists)
+		_, err = user.Get(db, "dup1")
+		assert.Error(t, err)
+	})
+
+	t.Run("reject policy fails and rolls back", func(t *testing.T) {
+		_, _, err := teamService.CreateTeam(t.Context(), &domain.Team{TeamName: "strict", Members: []domain.TeamMember{
+			{UserID: "newcomer", Username: "newcomer", IsActive: true},
+			{UserID: "shared", Username: "renamed", IsActive: true},
+		}}, service.CreateTeamOptions{ConflictPolicy: service.ConflictReject})
+
+		var inOtherTeam *service.UserInOtherTeamError
+		require.ErrorAs(t, err, &inOtherTeam)
+		assert.Equal(t, service.UserInOtherTeamError{UserID: "shared", TeamName: "home"}, *inOtherTeam)
+
+		exists, err := team.Exists(db, "strict")
+		require.NoError(t, err)
+		assert.False(t, exists)
+		_, err = user.Get(db, "newcomer")
+		assert.Error(t, err)
+		u, err := user.Get(db, "shared")
+		require.NoError(t, err)
+		assert.Equal(t, "shared", u.Username)
+	})
+
+	t.Run("reject policy accepts new users", func(t *testing.T) {
+		_, _, err := teamService.CreateTeam(t.Context(), &domain.Team{TeamName: "fresh", Members: []domain.TeamMember{
+			{UserID: "fresh1", Username: "fresh1", IsActive: true},
+		}}, service.CreateTeamOptions{ConflictPolicy: service.ConflictReject})
+		require.NoError(t, err)
+	})
+
+	t.Run("move policy adds the member to the new team", func(t *testing.T) {
+		_, _, err := teamService.CreateTeam(t.Context(), &domain.Team{TeamName: "lenient", Members: []domain.TeamMember{
+			{UserID: "shared", Username: "renamed", IsActive: true},
+		}}, service.CreateTeamOptions{ConflictPolicy: service.ConflictMove})
+		require.NoError(t, err)
+
+		tm, err := team.Get(db, "lenient")
+		require.NoError(t, err)
+		require.Len(t, tm.Members, 1)
+		assert.Equal(t, "renamed", tm.Members[0].Username)
+	})
+}
+
+func TestTeamService_CreateTeamIfExists(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	teamService := service.NewTeamService(db, prService)
+
+	capacity := 2
+	roster := []domain.TeamMember{
+		{UserID: "ie1", Username: "first", IsActive: true},
+		{UserID: "ie2", Username: "second", IsActive: true, MaxOpenReviews: &capacity},
+	}
+	_, outcome, err := teamService.CreateTeam(t.Context(), &domain.Team{TeamName: "infra", Members: roster}, service.CreateTeamOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, service.TeamCreated, outcome)
+
+	t.Run("fail is the default", func(t *testing.T) {
+		_, _, err := teamService.CreateTeam(t.Context(), &domain.Team{TeamName: "infra", Members: roster}, service.CreateTeamOptions{})
+		assert.ErrorIs(t, err, service.ErrTeamExists)
+	})
+
+	t.Run("ignore leaves a different roster untouched", func(t *testing.T) {
+		_, outcome, err := teamService.CreateTeam(t.Context(), &domain.Team{TeamName: "infra", Members: []domain.TeamMember{
+			{UserID: "ie1", Username: "renamed", IsActive: false},
+			{UserID: "ie3", Username: "third", IsActive: true},
+		}}, service.CreateTeamOptions{IfExists: service.IfExistsIgnore})
+		require.NoError(t, err)
+		assert.Equal(t, service.TeamUnchanged, outcome)
+
+		tm, err := team.Get(db, "infra")
+		require.NoError(t, err)
+		assert.Len(t, tm.Members, 2)
+		u, err := user.Get(db, "ie1")
+		require.NoError(t, err)
+		assert.Equal(t, "first", u.Username)
+	})
+
+	t.Run("update with an identical roster changes nothing", func(t *testing.T) {
+		_, outcome, err := teamService.CreateTeam(t.Context(), &domain.Team{TeamName: "infra", Members: roster},
+			service.CreateTeamOptions{IfExists: service.IfExistsUpdate})
+		require.NoError(t, err)
+		assert.Equal(t, service.TeamUnchanged, outcome)
+	})
+
+	t.Run("update reconciles the member diff", func(t *testing.T) {
+		_, outcome, err := teamService.CreateTeam(t.Context(), &domain.Team{TeamName: "infra", AssignmentStrategy: "least_loaded", Members: []domain.TeamMember{
+			{UserID: "ie2", Username: "second", IsActive: false, MaxOpenReviews: &capacity},
+			{UserID: "ie3", Username: "third", IsActive: true},
+		}}, service.CreateTeamOptions{IfExists: service.IfExistsUpdate})
+		require.NoError(t, err)
+		assert.Equal(t, service.TeamUpdated, outcome)
+
+		tm, err := team.Get(db, "infra")
+		require.NoError(t, err)
+		assert.Equal(t, "least_loaded", tm.AssignmentStrategy)
+		members := make(map[string]domain.TeamMember)
+		for _, m := range tm.Members {
+			members[m.UserID] = m
+		}
+		require.Len(t, members, 3)
+		assert.True(t, members["ie1"].IsActive, "unlisted members stay")
+		assert.False(t, members["ie2"].IsActive)
+		assert.True(t, members["ie3"].IsActive)
+	})
+
+	t.Run("update honours the conflict policy for new members", func(t *testing.T) {
+		_, _, err := teamService.CreateTeam(t.Context(), &domain.Team{TeamName: "other", Members: []domain.TeamMember{
+			{UserID: "outsider", Username: "outsider", IsActive: true},
+		}}, service.CreateTeamOptions{})
+		require.NoError(t, err)
+
+		_, _, err = teamService.CreateTeam(t.Context(), &domain.Team{TeamName: "infra", Members: []domain.TeamMember{
+			{UserID: "outsider", Username: "outsider", IsActive: true},
+		}}, service.CreateTeamOptions{IfExists: service.IfExistsUpdate, ConflictPolicy: service.ConflictReject})
+		assert.ErrorIs(t, err, service.ErrUserInOtherTeam)
+	})
+}
+
+func TestTeamService_CreateTeamReturnsWrittenTeam(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	teamService := service.NewTeamService(db, prService)
+
+	// assertStored checks the returned team against what a fresh read finds.
+	assertStored := func(t *testing.T, returned *domain.Team) {
+		t.Helper()
+		stored, err := team.Get(db, returned.TeamName)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, stored.Members, returned.Members)
+		stored.Members, returned.Members = nil, nil
+		assert.Equal(t, stored, returned)
+	}
+
+	_, _, err = teamService.CreateTeam(t.Context(), &domain.Team{TeamName: "rw_home", Members: []domain.TeamMember{
+		{UserID: "rw_tagged", Username: "tagged", IsActive: true, Tags: []string{"go", "db"}},
+	}}, service.CreateTeamOptions{})
+	require.NoError(t, err)
+
+	capacity := 3
+	created, outcome, err := teamService.CreateTeam(t.Context(), &domain.Team{TeamName: "rw_team", Members: []domain.TeamMember{
+		{UserID: "rw_new", Username: "new", IsActive: true, MaxOpenReviews: &capacity, AssignmentWeight: 2},
+		{UserID: "rw_plain", Username: "plain", IsActive: false},
+		// An existing user keeps their tags when the request lists none.
+		{UserID: "rw_tagged", Username: "tagged", IsActive: true},
+	}}, service.CreateTeamOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, service.TeamCreated, outcome)
+	require.Len(t, created.Members, 3)
+	assert.Equal(t, []string{"db", "go"}, created.Members[2].Tags)
+	assertStored(t, created)
+
+	updated, outcome, err := teamService.CreateTeam(t.Context(), &domain.Team{TeamName: "rw_team", AssignmentStrategy: "least_loaded", Members: []domain.TeamMember{
+		{UserID: "rw_plain", Username: "plain", IsActive: true, Tags: []string{"ui"}},
+		{UserID: "rw_late", Username: "late", IsActive: true},
+	}}, service.CreateTeamOptions{IfExists: service.IfExistsUpdate})
+	require.NoError(t, err)
+	assert.Equal(t, service.TeamUpdated, outcome)
+	assert.Len(t, updated.Members, 4)
+	assertStored(t, updated)
+
+	ignored, outcome, err := teamService.CreateTeam(t.Context(), &domain.Team{TeamName: "rw_team"},
+		service.CreateTeamOptions{IfExists: service.IfExistsIgnore})
+	require.NoError(t, err)
+	assert.Equal(t, service.TeamUnchanged, outcome)
+	assertStored(t, ignored)
 }
 
 func TestTeamService_GetTeam(t *testing.T) {
@@ -157,7 +376,7 @@ func TestTeamService_GetTeam(t *testing.T) {
 				require.NoError(t, team.Create(db, "empty_team"))
 			}
 
-			team, err := teamService.GetTeam(tt.teamName)
+			team, err := teamService.GetTeam(t.Context(), tt.teamName)
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -0,0 +1,113 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestTeamSettings_ReviewerCountAndFallback(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	teamService := service.NewTeamService(db, prService)
+
+	_, _, err = teamService.CreateTeam(t.Context(), &domain.Team{
+		TeamName: "team_ts",
+		Members: []domain.TeamMember{
+			{UserID: "author_ts", Username: "author", IsActive: true},
+			{UserID: "rev1_ts", Username: "rev1", IsActive: true},
+			{UserID: "rev2_ts", Username: "rev2", IsActive: true},
+			{UserID: "rev3_ts", Username: "rev3", IsActive: true},
+			{UserID: "rev4_ts", Username: "rev4", IsActive: true},
+		},
+	}, service.CreateTeamOptions{})
+	require.NoError(t, err)
+	_, _, err = teamService.CreateTeam(t.Context(), &domain.Team{
+		TeamName: "small_ts",
+		Members: []domain.TeamMember{
+			{UserID: "small_author_ts", Username: "small_author", IsActive: true},
+			{UserID: "small_rev_ts", Username: "small_rev", IsActive: true},
+		},
+	}, service.CreateTeamOptions{})
+	require.NoError(t, err)
+
+	reviewersOf := func(t *testing.T, id, authorID string) []string {
+		t.Helper()
+		pullRequest, err := prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: id}, "Settings "+id, authorID, nil, domain.PRDetails{})
+		require.NoError(t, err)
+		return pullRequest.AssignedReviewersIDs
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		settings, err := teamService.GetTeamSettings(t.Context(), "team_ts")
+		require.NoError(t, err)
+		assert.Equal(t, domain.DefaultReviewerCount, settings.ReviewerCount)
+		assert.Empty(t, settings.FallbackTeamName)
+		assert.Len(t, reviewersOf(t, "pr_default_ts", "author_ts"), 2)
+	})
+
+	t.Run("next pull request honors a changed reviewer count", func(t *testing.T) {
+		three := 3
+		updated, err := teamService.UpdateTeam(t.Context(), "team_ts", service.TeamUpdate{ReviewerCount: &three})
+		require.NoError(t, err)
+		assert.Equal(t, 3, updated.Settings().ReviewerCount)
+		assert.Len(t, reviewersOf(t, "pr_three_ts", "author_ts"), 3)
+
+		reset := 0
+		updated, err = teamService.UpdateTeam(t.Context(), "team_ts", service.TeamUpdate{ReviewerCount: &reset})
+		require.NoError(t, err)
+		assert.Equal(t, domain.DefaultReviewerCount, updated.ReviewerCount)
+		assert.Len(t, reviewersOf(t, "pr_reset_ts", "author_ts"), 2)
+	})
+
+	t.Run("fallback team fills the remaining slots", func(t *testing.T) {
+		assert.Equal(t, []string{"small_rev_ts"}, reviewersOf(t, "pr_small_ts", "small_author_ts"))
+
+		fallback := "team_ts"
+		updated, err := teamService.UpdateTeam(t.Context(), "small_ts", service.TeamUpdate{FallbackTeamName: &fallback})
+		require.NoError(t, err)
+		assert.Equal(t, "team_ts", updated.FallbackTeamName)
+
+		reviewers := reviewersOf(t, "pr_fallback_ts", "small_author_ts")
+		require.Len(t, reviewers, 2)
+		assert.Contains(t, reviewers, "small_rev_ts")
+		assert.NotContains(t, reviewers, "small_author_ts")
+
+		created, err := pr.Get(db, domain.PRKey{PullRequestID: "pr_fallback_ts"})
+		require.NoError(t, err)
+		for id, source := range sourcesOf(created) {
+			if id == "small_rev_ts" {
+				assert.Equal(t, domain.SourceAuto, source)
+			} else {
+				assert.Equal(t, domain.SourceFallback, source, id)
+			}
+		}
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		six := 6
+		_, err := teamService.UpdateTeam(t.Context(), "team_ts", service.TeamUpdate{ReviewerCount: &six})
+		assert.ErrorIs(t, err, service.ErrInvalidReviewerCount)
+
+		self := "team_ts"
+		_, err = teamService.UpdateTeam(t.Context(), "team_ts", service.TeamUpdate{FallbackTeamName: &self})
+		assert.ErrorIs(t, err, service.ErrInvalidFallbackTeam)
+
+		ghost := "ghost_ts"
+		_, err = teamService.UpdateTeam(t.Context(), "team_ts", service.TeamUpdate{FallbackTeamName: &ghost})
+		assert.ErrorIs(t, err, service.ErrFallbackTeamNotFound)
+
+		_, err = teamService.GetTeamSettings(t.Context(), "ghost_ts")
+		assert.ErrorIs(t, err, service.ErrTeamNotFound)
+	})
+}
@@ -0,0 +1,77 @@
+package integration
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestTeamService_AssignmentStrategy(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	teamService := service.NewTeamService(db, prService)
+
+	members := []domain.TeamMember{
+		{UserID: "author_st", Username: "author", IsActive: true},
+		{UserID: "a_st", Username: "a", IsActive: true},
+		{UserID: "b_st", Username: "b", IsActive: true},
+		{UserID: "c_st", Username: "c", IsActive: true},
+	}
+
+	t.Run("defaults to service strategy", func(t *testing.T) {
+		_, _, err := teamService.CreateTeam(t.Context(), &domain.Team{TeamName: "team_st", Members: members}, service.CreateTeamOptions{})
+		require.NoError(t, err)
+		got, err := teamService.GetTeam(t.Context(), "team_st")
+		require.NoError(t, err)
+		assert.Equal(t, string(service.StrategyRandom), got.AssignmentStrategy)
+	})
+
+	t.Run("unknown strategy rejected on create", func(t *testing.T) {
+		_, _, err := teamService.CreateTeam(t.Context(), &domain.Team{TeamName: "team_bad", AssignmentStrategy: "alphabetical"}, service.CreateTeamOptions{})
+		assert.ErrorIs(t, err, service.ErrUnknownStrategy)
+	})
+
+	t.Run("update rejects unknown strategy and team", func(t *testing.T) {
+		_, err := teamService.UpdateTeam(t.Context(), "team_st", service.TeamUpdate{AssignmentStrategy: "alphabetical"})
+		assert.ErrorIs(t, err, service.ErrUnknownStrategy)
+
+		_, err = teamService.UpdateTeam(t.Context(), "nonexistent", service.TeamUpdate{AssignmentStrategy: "random"})
+		assert.ErrorIs(t, err, service.ErrTeamNotFound)
+	})
+
+	t.Run("least_loaded strategy used for new PRs only", func(t *testing.T) {
+		first, err := prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: "pr_st_1"}, "First", "author_st", nil, domain.PRDetails{})
+		require.NoError(t, err)
+		require.Len(t, first.AssignedReviewersIDs, 2)
+
+		updated, err := teamService.UpdateTeam(t.Context(), "team_st", service.TeamUpdate{AssignmentStrategy: "least_loaded"})
+		require.NoError(t, err)
+		assert.Equal(t, "least_loaded", updated.AssignmentStrategy)
+
+		// Existing assignments are untouched by the strategy change.
+		stored, err := pr.Get(db, domain.PRKey{PullRequestID: "pr_st_1"})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, first.AssignedReviewersIDs, stored.AssignedReviewersIDs)
+
+		// The only teammate without open reviews must be picked first.
+		second, err := prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: "pr_st_2"}, "Second", "author_st", nil, domain.PRDetails{})
+		require.NoError(t, err)
+		require.Len(t, second.AssignedReviewersIDs, 2)
+		for _, id := range []string{"a_st", "b_st", "c_st"} {
+			if !slices.Contains(first.AssignedReviewersIDs, id) {
+				assert.Contains(t, second.AssignedReviewersIDs, id)
+			}
+		}
+	})
+}
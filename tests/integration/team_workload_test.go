@@ -0,0 +1,139 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestTeamService_GetTeamWorkload(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	assignedAt := time.Date(2026, 3, 2, 9, 30, 0, 0, time.Local)
+	clock := &tests.FakeClock{Current: assignedAt.Add(30 * time.Hour)}
+	prService := service.NewPRService(db, service.NewReviewerAssigner()).WithClock(clock)
+	teamService := service.NewTeamService(db, prService)
+
+	_, _, err = teamService.CreateTeam(t.Context(), &domain.Team{
+		TeamName: "team_wl",
+		Members: []domain.TeamMember{
+			{UserID: "author_wl", Username: "author", IsActive: true},
+			{UserID: "rev1_wl", Username: "rev1", IsActive: true},
+			{UserID: "rev2_wl", Username: "rev2", IsActive: true},
+			{UserID: "idle_wl", Username: "idle", IsActive: true},
+			{UserID: "gone_wl", Username: "gone", IsActive: true},
+		},
+	}, service.CreateTeamOptions{})
+	require.NoError(t, err)
+	_, _, err = teamService.CreateTeam(t.Context(), &domain.Team{
+		TeamName: "other_wl",
+		Members: []domain.TeamMember{
+			{UserID: "other_author_wl", Username: "other_author", IsActive: true},
+		},
+	}, service.CreateTeamOptions{})
+	require.NoError(t, err)
+	// rev1 is also a member of other_wl and reviews its PR.
+	require.NoError(t, team.AddMember(db, "other_wl", "rev1_wl", false))
+	sla := 24
+	_, err = teamService.UpdateTeam(t.Context(), "team_wl", service.TeamUpdate{ReviewSLAHours: &sla})
+	require.NoError(t, err)
+
+	// Seeded directly so that each PR gets exactly the reviewers listed.
+	seed := func(t *testing.T, teamName, id, authorID string, status domain.PRStatus, reviewers ...string) {
+		t.Helper()
+		require.NoError(t, pr.Create(db, &domain.PullRequest{
+			PullRequestID: id, PullRequestName: "Workload " + id, AuthorID: authorID, TeamName: teamName, Status: status,
+		}))
+		for _, r := range reviewers {
+			require.NoError(t, pr.InsertReviewer(db, domain.PRKey{PullRequestID: id}, r, time.Now().UTC()))
+		}
+		_, err := db.Exec(`UPDATE pr_reviewers SET assigned_at = $2 WHERE pull_request_id = $1`, id, assignedAt)
+		require.NoError(t, err)
+	}
+	seed(t, "team_wl", "pr_full_wl", "author_wl", domain.StatusOpen, "rev1_wl", "rev2_wl")
+	seed(t, "team_wl", "pr_half_wl", "author_wl", domain.StatusOpen, "rev1_wl")
+	seed(t, "team_wl", "pr_none_wl", "author_wl", domain.StatusOpen)
+	seed(t, "team_wl", "pr_merged_wl", "author_wl", domain.StatusMerged, "rev2_wl")
+	seed(t, "other_wl", "pr_other_wl", "other_author_wl", domain.StatusOpen, "rev1_wl")
+	seed(t, "team_wl", "pr_gone_wl", "author_wl", domain.StatusOpen, "gone_wl", "rev2_wl")
+	_, err = user.SetIsActive(db, "gone_wl", false)
+	require.NoError(t, err)
+
+	workload, err := teamService.GetTeamWorkload(t.Context(), "team_wl")
+	require.NoError(t, err)
+
+	t.Run("active members with their open reviews", func(t *testing.T) {
+		byUser := make(map[string][]string)
+		var order []string
+		for _, m := range workload.Members {
+			order = append(order, m.UserID)
+			ids := make([]string, len(m.PullRequests))
+			for i, p := range m.PullRequests {
+				ids[i] = p.PullRequestID
+			}
+			byUser[m.UserID] = ids
+		}
+		assert.Equal(t, []string{"author_wl", "idle_wl", "rev1_wl", "rev2_wl"}, order)
+		assert.Empty(t, byUser["author_wl"])
+		assert.Empty(t, byUser["idle_wl"])
+		assert.ElementsMatch(t, []string{"pr_full_wl", "pr_half_wl", "pr_other_wl"}, byUser["rev1_wl"])
+		assert.ElementsMatch(t, []string{"pr_full_wl", "pr_gone_wl"}, byUser["rev2_wl"])
+		assert.Equal(t, 5, workload.OpenAssignments())
+	})
+
+	t.Run("assignments are measured against the SLA of the PR's team", func(t *testing.T) {
+		for _, m := range workload.Members {
+			if m.UserID != "rev1_wl" {
+				continue
+			}
+			for _, p := range m.PullRequests {
+				require.NotNil(t, p.Assignment.HoursOpen)
+				assert.Equal(t, 30, *p.Assignment.HoursOpen)
+				assert.Equal(t, p.TeamName == "team_wl", p.Assignment.Overdue, p.PullRequestID)
+			}
+		}
+	})
+
+	t.Run("staffing of the team's open pull requests", func(t *testing.T) {
+		assert.Equal(t, 4, workload.OpenPRs)
+		assert.Equal(t, 1, workload.UnassignedPRs)
+		assert.Equal(t, 1, workload.UnderAssignedPRs)
+		assert.Equal(t, domain.DefaultReviewerCount, workload.ReviewerCount)
+	})
+
+	t.Run("under-assigned follows the team's reviewer count", func(t *testing.T) {
+		three := 3
+		_, err := teamService.UpdateTeam(t.Context(), "team_wl", service.TeamUpdate{ReviewerCount: &three})
+		require.NoError(t, err)
+
+		workload, err := teamService.GetTeamWorkload(t.Context(), "team_wl")
+		require.NoError(t, err)
+		assert.Equal(t, 1, workload.UnassignedPRs)
+		assert.Equal(t, 3, workload.UnderAssignedPRs)
+	})
+
+	t.Run("team without members or pull requests", func(t *testing.T) {
+		require.NoError(t, team.Create(db, "empty_wl"))
+		workload, err := teamService.GetTeamWorkload(t.Context(), "empty_wl")
+		require.NoError(t, err)
+		assert.Empty(t, workload.Members)
+		assert.Zero(t, workload.OpenPRs)
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		_, err := teamService.GetTeamWorkload(t.Context(), "ghost_wl")
+		assert.ErrorIs(t, err, service.ErrTeamNotFound)
+	})
+}
@@ -0,0 +1,88 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestPRTimestamps_UTC(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	moscow := time.FixedZone("MSK", 3*60*60)
+	clock := &tests.FakeClock{Current: time.Date(2026, 3, 2, 12, 30, 0, 0, moscow)}
+	prService := service.NewPRService(db, service.NewReviewerAssigner()).WithClock(clock)
+	teamService := service.NewTeamService(db, prService)
+
+	_, _, err = teamService.CreateTeam(t.Context(), &domain.Team{
+		TeamName: "team_utc",
+		Members: []domain.TeamMember{
+			{UserID: "author_utc", Username: "author", IsActive: true},
+			{UserID: "rev_utc", Username: "rev", IsActive: true},
+		},
+	}, service.CreateTeamOptions{})
+	require.NoError(t, err)
+
+	t.Run("created and merged at the clock's time, read back in UTC", func(t *testing.T) {
+		_, err := prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: "pr_utc_1"}, "UTC", "author_utc", nil, domain.PRDetails{})
+		require.NoError(t, err)
+		clock.Current = clock.Current.Add(2 * time.Hour)
+		_, err = prService.MergePR(t.Context(), domain.PRKey{PullRequestID: "pr_utc_1"}, service.MergeOptions{Force: true})
+		require.NoError(t, err)
+
+		got, err := pr.Get(db, domain.PRKey{PullRequestID: "pr_utc_1"})
+		require.NoError(t, err)
+		require.NotNil(t, got.CreatedAt)
+		require.NotNil(t, got.MergedAt)
+		assert.Equal(t, "2026-03-02T09:30:00Z", got.CreatedAt.Format(time.RFC3339))
+		assert.Equal(t, "2026-03-02T11:30:00Z", got.MergedAt.Format(time.RFC3339))
+		require.NotEmpty(t, got.Assignments)
+		for _, a := range got.Assignments {
+			assert.Equal(t, time.UTC, a.AssignedAt.Location())
+			assert.Equal(t, "2026-03-02T09:30:00Z", a.AssignedAt.Format(time.RFC3339))
+		}
+	})
+
+	t.Run("reviews restarted at the clock's time on reopen", func(t *testing.T) {
+		key := domain.PRKey{PullRequestID: "pr_utc_2"}
+		_, err := prService.CreatePR(t.Context(), key, "Reopened", "author_utc", nil, domain.PRDetails{})
+		require.NoError(t, err)
+		_, err = prService.ClosePR(t.Context(), key)
+		require.NoError(t, err)
+		clock.Current = clock.Current.Add(24 * time.Hour)
+		_, err = prService.ReopenPR(t.Context(), key)
+		require.NoError(t, err)
+
+		got, err := pr.Get(db, key)
+		require.NoError(t, err)
+		require.NotEmpty(t, got.Assignments)
+		want := clock.Current.UTC().Format(time.RFC3339)
+		for _, a := range got.Assignments {
+			assert.Equal(t, want, a.AssignedAt.Format(time.RFC3339))
+		}
+	})
+
+	t.Run("PR stored as local wall clock before the migration", func(t *testing.T) {
+		require.NoError(t, pr.Create(db, &domain.PullRequest{
+			PullRequestID: "pr_utc_old", PullRequestName: "Old", AuthorID: "author_utc", TeamName: "team_utc", Status: domain.StatusOpen,
+		}))
+		// What the migration makes of 12:30 written by a service running in Moscow.
+		_, err := db.Exec(`UPDATE pull_requests SET created_at = TIMESTAMP '2026-03-02 12:30:00' AT TIME ZONE 'Europe/Moscow' WHERE pull_request_id = $1`, "pr_utc_old")
+		require.NoError(t, err)
+
+		got, err := pr.Get(db, domain.PRKey{PullRequestID: "pr_utc_old"})
+		require.NoError(t, err)
+		require.NotNil(t, got.CreatedAt)
+		assert.Equal(t, "2026-03-02T09:30:00Z", got.CreatedAt.Format(time.RFC3339))
+	})
+}
@@ -0,0 +1,106 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/absence"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestUserAbsence_CandidateBoundaries(t *testing.T) {
+	t.Parallel()
+	db := tests.WithTestTx(t)
+
+	teamName := "team_abs_bounds"
+	require.NoError(t, team.Create(db, teamName))
+	for _, id := range []string{"author_abs_bounds", "starts_today", "ends_today", "ended_yesterday", "starts_tomorrow"} {
+		require.NoError(t, user.Create(db, &domain.User{UserID: id, Username: id, TeamName: teamName, IsActive: true}))
+	}
+
+	today := time.Now()
+	day := 24 * time.Hour
+	require.NoError(t, absence.Create(db, &domain.Absence{UserID: "starts_today", FromDate: today, ToDate: today.Add(7 * day)}))
+	require.NoError(t, absence.Create(db, &domain.Absence{UserID: "ends_today", FromDate: today.Add(-7 * day), ToDate: today}))
+	require.NoError(t, absence.Create(db, &domain.Absence{UserID: "ended_yesterday", FromDate: today.Add(-7 * day), ToDate: today.Add(-day)}))
+	require.NoError(t, absence.Create(db, &domain.Absence{UserID: "starts_tomorrow", FromDate: today.Add(day), ToDate: today.Add(7 * day)}))
+
+	ids := func(users []domain.User) []string {
+		out := make([]string, len(users))
+		for i, u := range users {
+			out[i] = u.UserID
+		}
+		return out
+	}
+
+	teammates, err := user.GetActiveTeammates(db, "author_abs_bounds")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"ended_yesterday", "starts_tomorrow"}, ids(teammates))
+
+	byTeam, err := user.GetActiveByTeam(db, teamName)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"author_abs_bounds", "ended_yesterday", "starts_tomorrow"}, ids(byTeam))
+}
+
+func TestUserService_SetAbsence(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	teamName := "team_abs"
+	require.NoError(t, team.Create(db, teamName))
+	for _, id := range []string{"author_abs", "r1_abs", "r2_abs", "r3_abs"} {
+		require.NoError(t, user.Create(db, &domain.User{UserID: id, Username: id, TeamName: teamName, IsActive: true}))
+	}
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	userService := service.NewUserService(db, prService)
+
+	created, err := prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: "pr_abs"}, "Vacation PR", "author_abs", nil, domain.PRDetails{})
+	require.NoError(t, err)
+	require.Len(t, created.AssignedReviewersIDs, 2)
+	leaving := created.AssignedReviewersIDs[0]
+
+	t.Run("error - user not found", func(t *testing.T) {
+		_, err := userService.SetAbsence(t.Context(), domain.Absence{UserID: "ghost", FromDate: time.Now(), ToDate: time.Now()}, false)
+		assert.ErrorIs(t, err, service.ErrUserNotFound)
+	})
+
+	t.Run("error - ends before start", func(t *testing.T) {
+		_, err := userService.SetAbsence(t.Context(), domain.Absence{UserID: leaving, FromDate: time.Now(), ToDate: time.Now().Add(-48 * time.Hour)}, false)
+		assert.ErrorIs(t, err, service.ErrInvalidAbsence)
+	})
+
+	t.Run("reassign_open keeps reviews for a future absence", func(t *testing.T) {
+		nextWeek := time.Now().Add(7 * 24 * time.Hour)
+		results, err := userService.SetAbsence(t.Context(), domain.Absence{UserID: leaving, FromDate: nextWeek, ToDate: nextWeek}, true)
+		require.NoError(t, err)
+		assert.Empty(t, results)
+
+		reviews, err := userService.GetUserReviews(t.Context(), leaving, nil)
+		require.NoError(t, err)
+		assert.Len(t, reviews, 1)
+	})
+
+	t.Run("reassign_open moves held reviews", func(t *testing.T) {
+		results, err := userService.SetAbsence(t.Context(), domain.Absence{UserID: leaving, FromDate: time.Now(), ToDate: time.Now()}, true)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "pr_abs", results[0].PR.PullRequestID)
+		assert.NotEmpty(t, results[0].ReplacedBy)
+		assert.NotEqual(t, leaving, results[0].ReplacedBy)
+	})
+
+	t.Run("remove absence", func(t *testing.T) {
+		require.NoError(t, userService.RemoveAbsence(t.Context(), leaving, nil))
+		assert.ErrorIs(t, userService.RemoveAbsence(t.Context(), leaving, nil), service.ErrAbsenceNotFound)
+	})
+}
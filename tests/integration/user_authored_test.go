@@ -0,0 +1,139 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/pagination"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestUserService_GetAuthoredPRs(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	createdAt := time.Date(2026, 3, 2, 9, 30, 0, 0, time.Local)
+	clock := &tests.FakeClock{Current: createdAt.Add(26 * time.Hour)}
+	prService := service.NewPRService(db, service.NewReviewerAssigner()).WithClock(clock)
+	teamService := service.NewTeamService(db, prService)
+	userService := service.NewUserService(db, prService)
+
+	_, _, err = teamService.CreateTeam(t.Context(), &domain.Team{
+		TeamName: "team_au",
+		Members: []domain.TeamMember{
+			{UserID: "author_au", Username: "author", IsActive: true},
+			{UserID: "quiet_au", Username: "quiet", IsActive: true},
+			{UserID: "rev1_au", Username: "rev1", IsActive: true},
+			{UserID: "rev2_au", Username: "rev2", IsActive: true},
+		},
+	}, service.CreateTeamOptions{})
+	require.NoError(t, err)
+	sla := 24
+	_, err = teamService.UpdateTeam(t.Context(), "team_au", service.TeamUpdate{ReviewSLAHours: &sla})
+	require.NoError(t, err)
+
+	for i, id := range []string{"pr_first_au", "pr_second_au", "pr_third_au"} {
+		_, err := prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: id}, "Authored "+id, "author_au",
+			[]string{"rev1_au", "rev2_au"}, domain.PRDetails{})
+		require.NoError(t, err)
+		at := createdAt.Add(time.Duration(i) * time.Minute)
+		_, err = db.Exec(`UPDATE pull_requests SET created_at = $2 WHERE pull_request_id = $1`, id, at)
+		require.NoError(t, err)
+		_, err = db.Exec(`UPDATE pr_reviewers SET assigned_at = $2 WHERE pull_request_id = $1`, id, at)
+		require.NoError(t, err)
+	}
+	_, err = prService.ApprovePR(t.Context(), domain.PRKey{PullRequestID: "pr_third_au"}, "rev2_au")
+	require.NoError(t, err)
+	_, err = prService.MergePR(t.Context(), domain.PRKey{PullRequestID: "pr_first_au"}, service.MergeOptions{})
+	require.NoError(t, err)
+
+	idsOf := func(prs []domain.PullRequest) []string {
+		ids := make([]string, len(prs))
+		for i, p := range prs {
+			ids[i] = p.PullRequestID
+		}
+		return ids
+	}
+
+	t.Run("newest first with reviewers", func(t *testing.T) {
+		page, err := userService.GetAuthoredPRs(t.Context(), "author_au", pr.AuthoredFilter{Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr_third_au", "pr_second_au", "pr_first_au"}, idsOf(page.Items))
+		assert.False(t, page.HasMore)
+
+		third := page.Items[0]
+		assert.Equal(t, []string{"rev1_au", "rev2_au"}, third.AssignedReviewersIDs)
+		assert.Equal(t, []string{"rev2_au"}, third.ApprovedReviewersIDs)
+		require.Len(t, third.Assignments, 2)
+		for _, a := range third.Assignments {
+			assert.True(t, a.AssignedAt.Equal(createdAt.Add(2*time.Minute)), "assigned at %s", a.AssignedAt)
+			require.NotNil(t, a.HoursOpen)
+			assert.Equal(t, 25, *a.HoursOpen)
+		}
+		assert.True(t, third.Assignments[0].Overdue)
+		assert.False(t, third.Assignments[1].Overdue)
+
+		first := page.Items[2]
+		assert.Equal(t, domain.StatusMerged, first.Status)
+		require.Len(t, first.Assignments, 2)
+		assert.Nil(t, first.Assignments[0].HoursOpen)
+	})
+
+	t.Run("status filter", func(t *testing.T) {
+		open := domain.StatusOpen
+		page, err := userService.GetAuthoredPRs(t.Context(), "author_au", pr.AuthoredFilter{Status: &open, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr_third_au", "pr_second_au"}, idsOf(page.Items))
+	})
+
+	t.Run("pages", func(t *testing.T) {
+		page, err := userService.GetAuthoredPRs(t.Context(), "author_au", pr.AuthoredFilter{Limit: 2})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr_third_au", "pr_second_au"}, idsOf(page.Items))
+		assert.True(t, page.HasMore)
+
+		page, err = userService.GetAuthoredPRs(t.Context(), "author_au", pr.AuthoredFilter{Limit: 2, Offset: 2})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr_first_au"}, idsOf(page.Items))
+		assert.False(t, page.HasMore)
+	})
+
+	t.Run("author with no pull requests", func(t *testing.T) {
+		page, err := userService.GetAuthoredPRs(t.Context(), "quiet_au", pr.AuthoredFilter{Limit: 10})
+		require.NoError(t, err)
+		assert.Empty(t, page.Items)
+		assert.NotNil(t, page.Items)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		_, err := userService.GetAuthoredPRs(t.Context(), "author_au", pr.AuthoredFilter{Limit: service.MaxAuthoredPageSize + 1})
+		assert.ErrorIs(t, err, service.ErrInvalidLimit)
+	})
+
+	t.Run("cursor pages are stable when pull requests are created between them", func(t *testing.T) {
+		page, err := userService.GetAuthoredPRs(t.Context(), "author_au", pr.AuthoredFilter{Limit: 2})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr_third_au", "pr_second_au"}, idsOf(page.Items))
+		require.True(t, page.HasMore)
+
+		_, err = prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: "pr_fourth_au"}, "Authored pr_fourth_au", "author_au",
+			[]string{"rev1_au"}, domain.PRDetails{})
+		require.NoError(t, err)
+
+		after, err := pagination.Decode(page.NextCursor)
+		require.NoError(t, err)
+		page, err = userService.GetAuthoredPRs(t.Context(), "author_au", pr.AuthoredFilter{Limit: 2, After: after})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr_first_au"}, idsOf(page.Items))
+		assert.False(t, page.HasMore)
+		assert.Empty(t, page.NextCursor)
+	})
+}
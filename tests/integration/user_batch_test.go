@@ -0,0 +1,70 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestUserService_SetIsActiveBatch(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	teamName := "team_batch"
+	require.NoError(t, team.Create(db, teamName))
+	for _, id := range []string{"author_bt", "r1_bt", "r2_bt", "spare_bt"} {
+		require.NoError(t, user.Create(db, &domain.User{UserID: id, Username: id, TeamName: teamName, IsActive: true}))
+	}
+	// Keep the spare out of the initial selection.
+	_, err = user.SetIsActive(db, "spare_bt", false)
+	require.NoError(t, err)
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	userService := service.NewUserService(db, prService)
+
+	created, err := prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: "pr_bt"}, "Batch", "author_bt", nil, domain.PRDetails{})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"r1_bt", "r2_bt"}, created.AssignedReviewersIDs)
+
+	t.Run("unknown ids are skipped and the rest is applied", func(t *testing.T) {
+		result, err := userService.SetIsActiveBatch(t.Context(), []service.ActivityChange{
+			{UserID: "spare_bt", IsActive: true},
+			{UserID: "ghost", IsActive: false},
+			{UserID: "r1_bt", IsActive: false},
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Updated, 2)
+		require.Len(t, result.Errors, 1)
+		assert.Equal(t, "ghost", result.Errors[0].UserID)
+		assert.ErrorIs(t, result.Errors[0].Err, service.ErrUserNotFound)
+
+		r1, err := user.Get(db, "r1_bt")
+		require.NoError(t, err)
+		assert.False(t, r1.IsActive)
+	})
+
+	t.Run("deactivated reviewer is replaced on open PRs", func(t *testing.T) {
+		updated, err := pr.Get(db, domain.PRKey{PullRequestID: "pr_bt"})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"r2_bt", "spare_bt"}, updated.AssignedReviewersIDs)
+	})
+
+	t.Run("reviewer removed without replacement when nobody is left", func(t *testing.T) {
+		_, err := userService.SetIsActiveBatch(t.Context(), []service.ActivityChange{{UserID: "r2_bt", IsActive: false}})
+		require.NoError(t, err)
+
+		updated, err := pr.Get(db, domain.PRKey{PullRequestID: "pr_bt"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"spare_bt"}, updated.AssignedReviewersIDs)
+	})
+}
@@ -0,0 +1,148 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestUserService_SetCapacity(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	require.NoError(t, team.Create(db, "team_cap"))
+	require.NoError(t, user.Create(db, &domain.User{UserID: "u_cap", Username: "u_cap", TeamName: "team_cap", IsActive: true}))
+
+	userService := service.NewUserService(db, service.NewPRService(db, service.NewReviewerAssigner()))
+
+	limit := 3
+	u, err := userService.SetCapacity(t.Context(), "u_cap", &limit)
+	require.NoError(t, err)
+	require.NotNil(t, u.MaxOpenReviews)
+	assert.Equal(t, 3, *u.MaxOpenReviews)
+
+	u, err = userService.SetCapacity(t.Context(), "u_cap", nil)
+	require.NoError(t, err)
+	assert.Nil(t, u.MaxOpenReviews)
+
+	_, err = userService.SetCapacity(t.Context(), "nonexistent", &limit)
+	assert.ErrorIs(t, err, service.ErrUserNotFound)
+}
+
+func TestTeamService_CreateTeam_ReAddKeepsCapacity(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	teamService := service.NewTeamService(db, prService)
+	userService := service.NewUserService(db, prService)
+
+	member := domain.TeamMember{UserID: "u_readd", Username: "u_readd", IsActive: true, AssignmentWeight: 2.5}
+	_, _, err = teamService.CreateTeam(t.Context(), &domain.Team{TeamName: "team_readd", Members: []domain.TeamMember{member}}, service.CreateTeamOptions{})
+	require.NoError(t, err)
+	limit := 2
+	_, err = userService.SetCapacity(t.Context(), "u_readd", &limit)
+	require.NoError(t, err)
+
+	assertKept := func(t *testing.T) {
+		t.Helper()
+		u, err := user.Get(db, "u_readd")
+		require.NoError(t, err)
+		require.NotNil(t, u.MaxOpenReviews)
+		assert.Equal(t, 2, *u.MaxOpenReviews)
+		assert.Equal(t, 2.5, u.AssignmentWeight)
+	}
+
+	// The payload omits both settings and renames the user, so the member is rewritten.
+	renamed := domain.TeamMember{UserID: "u_readd", Username: "renamed", IsActive: true}
+
+	t.Run("re-add to the same team", func(t *testing.T) {
+		written, _, err := teamService.CreateTeam(t.Context(), &domain.Team{TeamName: "team_readd", Members: []domain.TeamMember{renamed}},
+			service.CreateTeamOptions{IfExists: service.IfExistsUpdate})
+		require.NoError(t, err)
+		assertKept(t)
+		require.Len(t, written.Members, 1)
+		require.NotNil(t, written.Members[0].MaxOpenReviews)
+		assert.Equal(t, 2, *written.Members[0].MaxOpenReviews)
+		assert.Equal(t, 2.5, written.Members[0].AssignmentWeight)
+	})
+
+	t.Run("add to another team", func(t *testing.T) {
+		_, _, err := teamService.CreateTeam(t.Context(), &domain.Team{TeamName: "team_readd_other", Members: []domain.TeamMember{renamed}}, service.CreateTeamOptions{})
+		require.NoError(t, err)
+		assertKept(t)
+	})
+
+	t.Run("explicit values still apply", func(t *testing.T) {
+		five := 5
+		explicit := domain.TeamMember{UserID: "u_readd", Username: "renamed", IsActive: true, MaxOpenReviews: &five, AssignmentWeight: 1}
+		_, _, err := teamService.CreateTeam(t.Context(), &domain.Team{TeamName: "team_readd", Members: []domain.TeamMember{explicit}},
+			service.CreateTeamOptions{IfExists: service.IfExistsUpdate})
+		require.NoError(t, err)
+		u, err := user.Get(db, "u_readd")
+		require.NoError(t, err)
+		require.NotNil(t, u.MaxOpenReviews)
+		assert.Equal(t, 5, *u.MaxOpenReviews)
+		assert.Equal(t, 1.0, u.AssignmentWeight)
+	})
+}
+
+func TestPRService_CreatePR_Capacity(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	one := 1
+	teamName := "team_cap"
+	require.NoError(t, team.Create(db, teamName))
+	require.NoError(t, user.Create(db, &domain.User{UserID: "author_cap", Username: "author", TeamName: teamName, IsActive: true}))
+	require.NoError(t, user.Create(db, &domain.User{UserID: "part_time", Username: "part_time", TeamName: teamName, IsActive: true, MaxOpenReviews: &one}))
+	require.NoError(t, user.Create(db, &domain.User{UserID: "full_time", Username: "full_time", TeamName: teamName, IsActive: true}))
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+
+	t.Run("candidate query counts open reviews", func(t *testing.T) {
+		created, err := prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: "pr_cap_1"}, "First", "author_cap", nil, domain.PRDetails{})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"part_time", "full_time"}, created.AssignedReviewersIDs)
+
+		teammates, err := user.GetActiveTeammates(db, "author_cap")
+		require.NoError(t, err)
+		for _, tm := range teammates {
+			assert.Equal(t, 1, tm.OpenReviews, tm.UserID)
+		}
+	})
+
+	t.Run("candidate exactly at capacity is skipped", func(t *testing.T) {
+		created, err := prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: "pr_cap_2"}, "Second", "author_cap", nil, domain.PRDetails{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"full_time"}, created.AssignedReviewersIDs)
+	})
+
+	t.Run("merged PRs free capacity", func(t *testing.T) {
+		_, err := prService.MergePR(t.Context(), domain.PRKey{PullRequestID: "pr_cap_1"}, service.MergeOptions{})
+		require.NoError(t, err)
+
+		created, err := prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: "pr_cap_3"}, "Third", "author_cap", nil, domain.PRDetails{})
+		require.NoError(t, err)
+		assert.Contains(t, created.AssignedReviewersIDs, "part_time")
+	})
+
+	t.Run("reassign returns no candidate when replacement is at capacity", func(t *testing.T) {
+		// part_time now holds pr_cap_3 and is at capacity; full_time is on pr_cap_2 already.
+		_, _, err := prService.ReassignPR(t.Context(), domain.PRKey{PullRequestID: "pr_cap_2"}, "full_time", service.ReassignOptions{})
+		assert.ErrorIs(t, err, service.ErrNoCandidate)
+	})
+}
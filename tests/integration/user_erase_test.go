@@ -0,0 +1,135 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/stats"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestUserService_EraseUser(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	require.NoError(t, team.Create(db, "team_erase"))
+	for _, id := range []string{"leaver_erase", "mate1_erase", "mate2_erase", "mate3_erase"} {
+		require.NoError(t, user.Create(db, &domain.User{UserID: id, Username: "Name of " + id, TeamName: "team_erase", IsActive: true}))
+	}
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	userService := service.NewUserService(db, prService)
+	teamService := service.NewTeamService(db, prService)
+	statsService := service.NewStatsService(db, service.NewSystemClock())
+
+	authored := domain.PRKey{PullRequestID: "pr_erase_authored"}
+	_, err = prService.CreatePR(t.Context(), authored, "Written by the leaver", "leaver_erase", nil, domain.PRDetails{})
+	require.NoError(t, err)
+	_, err = prService.MergePR(t.Context(), authored, service.MergeOptions{MergedBy: "leaver_erase"})
+	require.NoError(t, err)
+
+	reviewed := domain.PRKey{PullRequestID: "pr_erase_reviewed"}
+	_, err = prService.CreatePR(t.Context(), reviewed, "Reviewed by the leaver", "mate1_erase", []string{"leaver_erase"}, domain.PRDetails{})
+	require.NoError(t, err)
+
+	erased, err := userService.EraseUser(t.Context(), "leaver_erase")
+	require.NoError(t, err)
+	assert.Equal(t, "leaver_erase", erased.UserID)
+	assert.Equal(t, domain.ErasedUsername, erased.Username)
+	assert.False(t, erased.IsActive)
+
+	t.Run("authored PR keeps its author", func(t *testing.T) {
+		got, err := prService.GetPR(t.Context(), authored)
+		require.NoError(t, err)
+		assert.Equal(t, "leaver_erase", got.AuthorID)
+		assert.Equal(t, "leaver_erase", got.MergedBy)
+	})
+
+	t.Run("open review handed over", func(t *testing.T) {
+		got, err := prService.GetPR(t.Context(), reviewed)
+		require.NoError(t, err)
+		assert.NotContains(t, got.AssignedReviewersIDs, "leaver_erase")
+		assert.Len(t, got.AssignedReviewersIDs, 2)
+	})
+
+	t.Run("erased author appears in stats under the tombstone", func(t *testing.T) {
+		lb, err := statsService.GetLeaderboard(t.Context(), service.LeaderboardAll, 10)
+		require.NoError(t, err)
+		assert.Contains(t, lb.Authors, stats.RankedUser{UserID: "leaver_erase", Username: domain.ErasedUsername, Count: 1})
+
+		loads, err := statsService.GetUserLoad(t.Context())
+		require.NoError(t, err)
+		for _, load := range loads {
+			if load.UserID == "leaver_erase" {
+				assert.Equal(t, domain.ErasedUsername, load.Username)
+				assert.EqualValues(t, 1, load.AuthoredPRs)
+				assert.Zero(t, load.OpenAssignments)
+			}
+			assert.NotContains(t, load.Username, "leaver")
+		}
+	})
+
+	t.Run("hidden from team and never picked again", func(t *testing.T) {
+		got, err := teamService.GetTeam(t.Context(), "team_erase")
+		require.NoError(t, err)
+		for _, member := range got.Members {
+			assert.NotEqual(t, "leaver_erase", member.UserID)
+		}
+
+		created, err := prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: "pr_erase_after"}, "After", "mate2_erase", nil, domain.PRDetails{})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"mate1_erase", "mate3_erase"}, created.AssignedReviewersIDs)
+	})
+
+	t.Run("cannot be brought back", func(t *testing.T) {
+		_, err := userService.SetIsActive(t.Context(), "leaver_erase", true)
+		assert.ErrorIs(t, err, service.ErrUserNotFound)
+
+		_, err = userService.SetIsActive(t.Context(), "leaver_erase", false)
+		assert.NoError(t, err, "deactivating stays harmless")
+
+		batch, err := userService.SetIsActiveBatch(t.Context(), []service.ActivityChange{{UserID: "leaver_erase", IsActive: true}})
+		require.NoError(t, err)
+		assert.Empty(t, batch.Updated)
+		require.Len(t, batch.Errors, 1)
+		assert.ErrorIs(t, batch.Errors[0].Err, service.ErrUserNotFound)
+
+		for _, teamName := range []string{"team_erase", "team_erase_new"} {
+			_, _, err = teamService.CreateTeam(t.Context(), &domain.Team{
+				TeamName: teamName,
+				Members:  []domain.TeamMember{{UserID: "leaver_erase", Username: "Back again", IsActive: true}},
+			}, service.CreateTeamOptions{IfExists: service.IfExistsUpdate})
+			var erasedErr *service.UserErasedError
+			require.ErrorAs(t, err, &erasedErr, teamName)
+			assert.Equal(t, "leaver_erase", erasedErr.UserID)
+		}
+
+		_, err = prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: "pr_erase_required"}, "Required", "mate2_erase", []string{"leaver_erase"}, domain.PRDetails{})
+		assert.ErrorIs(t, err, service.ErrRequiredReviewerNotFound)
+
+		got, err := user.Get(db, "leaver_erase")
+		require.NoError(t, err)
+		assert.Equal(t, domain.ErasedUsername, got.Username)
+		assert.False(t, got.IsActive)
+		assert.True(t, got.Erased)
+	})
+
+	t.Run("erasing again is a no-op", func(t *testing.T) {
+		again, err := userService.EraseUser(t.Context(), "leaver_erase")
+		require.NoError(t, err)
+		assert.Equal(t, domain.ErasedUsername, again.Username)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		_, err := userService.EraseUser(t.Context(), "ghost_erase")
+		assert.ErrorIs(t, err, service.ErrUserNotFound)
+	})
+}
@@ -0,0 +1,79 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/team"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestUserService_Exclusions(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	teamName := "team_ex"
+	require.NoError(t, team.Create(db, teamName))
+	for _, id := range []string{"author_ex", "r1_ex", "r2_ex", "pair_ex"} {
+		require.NoError(t, user.Create(db, &domain.User{UserID: id, Username: id, TeamName: teamName, IsActive: true}))
+	}
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	userService := service.NewUserService(db, prService)
+
+	t.Run("validation", func(t *testing.T) {
+		err := userService.AddExclusion(t.Context(), domain.Exclusion{ReviewerID: "author_ex", AuthorID: "author_ex"})
+		assert.ErrorIs(t, err, service.ErrSelfExclusion)
+
+		err = userService.AddExclusion(t.Context(), domain.Exclusion{ReviewerID: "ghost", AuthorID: "author_ex"})
+		assert.ErrorIs(t, err, service.ErrUserNotFound)
+
+		err = userService.RemoveExclusion(t.Context(), domain.Exclusion{ReviewerID: "r1_ex", AuthorID: "author_ex"})
+		assert.ErrorIs(t, err, service.ErrExclusionNotFound)
+	})
+
+	t.Run("excluded reviewer never picked on create", func(t *testing.T) {
+		require.NoError(t, userService.AddExclusion(t.Context(), domain.Exclusion{ReviewerID: "pair_ex", AuthorID: "author_ex"}))
+		// Idempotent.
+		require.NoError(t, userService.AddExclusion(t.Context(), domain.Exclusion{ReviewerID: "pair_ex", AuthorID: "author_ex"}))
+
+		created, err := prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: "pr_ex_1"}, "First", "author_ex", nil, domain.PRDetails{})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"r1_ex", "r2_ex"}, created.AssignedReviewersIDs)
+
+		suggestion, err := prService.SuggestReviewers(t.Context(), "author_ex", 3)
+		require.NoError(t, err)
+		for _, c := range suggestion.Candidates {
+			assert.NotEqual(t, "pair_ex", c.UserID)
+		}
+	})
+
+	t.Run("reassign returns no candidate when only remaining teammate is excluded", func(t *testing.T) {
+		_, _, err := prService.ReassignPR(t.Context(), domain.PRKey{PullRequestID: "pr_ex_1"}, "r1_ex", service.ReassignOptions{})
+		assert.ErrorIs(t, err, service.ErrNoCandidate)
+	})
+
+	t.Run("exclusion does not touch existing assignments", func(t *testing.T) {
+		require.NoError(t, userService.AddExclusion(t.Context(), domain.Exclusion{ReviewerID: "r1_ex", AuthorID: "author_ex"}))
+
+		existing, err := pr.Get(db, domain.PRKey{PullRequestID: "pr_ex_1"})
+		require.NoError(t, err)
+		assert.Contains(t, existing.AssignedReviewersIDs, "r1_ex")
+	})
+
+	t.Run("removed exclusion makes reviewer eligible again", func(t *testing.T) {
+		require.NoError(t, userService.RemoveExclusion(t.Context(), domain.Exclusion{ReviewerID: "pair_ex", AuthorID: "author_ex"}))
+
+		_, replacedBy, err := prService.ReassignPR(t.Context(), domain.PRKey{PullRequestID: "pr_ex_1"}, "r1_ex", service.ReassignOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "pair_ex", replacedBy)
+	})
+}
@@ -0,0 +1,108 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/audit"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/history"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/user"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestUserService_ReassignAll(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	teamService := service.NewTeamService(db, prService)
+	userService := service.NewUserService(db, prService)
+
+	_, _, err = teamService.CreateTeam(t.Context(), &domain.Team{
+		TeamName: "team_ra",
+		Members: []domain.TeamMember{
+			{UserID: "author_ra", Username: "author", IsActive: true},
+			{UserID: "leaving_ra", Username: "leaving", IsActive: true},
+			{UserID: "helper_ra", Username: "helper", IsActive: true},
+		},
+	}, service.CreateTeamOptions{})
+	require.NoError(t, err)
+	one := 1
+	_, err = userService.SetCapacity(t.Context(), "helper_ra", &one)
+	require.NoError(t, err)
+
+	// Seeded directly so that leaving_ra is the only reviewer of each PR, one second apart.
+	for i, p := range []struct {
+		id     string
+		status domain.PRStatus
+	}{
+		{"pr_first_ra", domain.StatusOpen},
+		{"pr_second_ra", domain.StatusOpen},
+		{"pr_merged_ra", domain.StatusMerged},
+	} {
+		require.NoError(t, pr.Create(db, &domain.PullRequest{
+			PullRequestID: p.id, PullRequestName: "Reassign " + p.id, AuthorID: "author_ra", TeamName: "team_ra", Status: p.status,
+		}))
+		require.NoError(t, pr.InsertReviewer(db, domain.PRKey{PullRequestID: p.id}, "leaving_ra", time.Now().UTC()))
+		_, err := db.Exec(`UPDATE pull_requests SET created_at = created_at + make_interval(secs => $2) WHERE pull_request_id = $1`, p.id, i)
+		require.NoError(t, err)
+	}
+
+	t.Run("user not found", func(t *testing.T) {
+		_, err := userService.ReassignAll(t.Context(), "ghost_ra", true)
+		assert.ErrorIs(t, err, service.ErrUserNotFound)
+	})
+
+	// helper_ra takes the first review and is then at capacity, so the second one has no candidate.
+	results, err := userService.ReassignAll(t.Context(), "leaving_ra", true)
+	require.NoError(t, err)
+
+	t.Run("one result per open review", func(t *testing.T) {
+		assert.Equal(t, []service.ReassignResult{
+			{PR: domain.PRKey{PullRequestID: "pr_first_ra"}, ReplacedBy: "helper_ra"},
+			{PR: domain.PRKey{PullRequestID: "pr_second_ra"}},
+		}, results)
+	})
+
+	t.Run("reassignments are kept and recorded", func(t *testing.T) {
+		first, err := pr.Get(db, domain.PRKey{PullRequestID: "pr_first_ra"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"helper_ra"}, first.AssignedReviewersIDs)
+
+		events, err := history.GetByPR(db, domain.PRKey{PullRequestID: "pr_first_ra"})
+		require.NoError(t, err)
+		require.NotEmpty(t, events)
+		last := events[len(events)-1]
+		assert.Equal(t, domain.ActionReassign, last.Action)
+		assert.Equal(t, "leaving_ra", last.OldUserID)
+		assert.Equal(t, "helper_ra", last.NewUserID)
+
+		second, err := pr.Get(db, domain.PRKey{PullRequestID: "pr_second_ra"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"leaving_ra"}, second.AssignedReviewersIDs)
+
+		merged, err := pr.Get(db, domain.PRKey{PullRequestID: "pr_merged_ra"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"leaving_ra"}, merged.AssignedReviewersIDs)
+	})
+
+	t.Run("user is deactivated and the call audited", func(t *testing.T) {
+		leaving, err := user.Get(db, "leaving_ra")
+		require.NoError(t, err)
+		assert.False(t, leaving.IsActive)
+
+		entries, err := audit.List(db, audit.Filter{Limit: 10})
+		require.NoError(t, err)
+		require.NotEmpty(t, entries)
+		assert.Equal(t, domain.AuditUserReassignAll, entries[0].Action)
+		assert.Equal(t, "user:leaving_ra", entries[0].Target)
+	})
+}
@@ -0,0 +1,90 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestUserService_GetUserReviewCount(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	assignedAt := time.Date(2026, 3, 2, 9, 30, 0, 0, time.Local)
+	clock := &tests.FakeClock{Current: assignedAt}
+	prService := service.NewPRService(db, service.NewReviewerAssigner()).WithClock(clock)
+	teamService := service.NewTeamService(db, prService)
+	userService := service.NewUserService(db, prService)
+
+	_, _, err = teamService.CreateTeam(t.Context(), &domain.Team{
+		TeamName: "team_count",
+		Members: []domain.TeamMember{
+			{UserID: "author_count", Username: "author", IsActive: true},
+			{UserID: "rev_count", Username: "rev", IsActive: true},
+		},
+	}, service.CreateTeamOptions{})
+	require.NoError(t, err)
+	sla := 24
+	_, err = teamService.UpdateTeam(t.Context(), "team_count", service.TeamUpdate{ReviewSLAHours: &sla})
+	require.NoError(t, err)
+
+	keys := []domain.PRKey{
+		{RepositoryName: "repo_count", PullRequestID: "pr_pending"},
+		{RepositoryName: "repo_count", PullRequestID: "pr_approved"},
+		{RepositoryName: "other_count", PullRequestID: "pr_pending"},
+		{RepositoryName: "repo_count", PullRequestID: "pr_merged"},
+	}
+	for _, key := range keys {
+		_, err := prService.CreatePR(t.Context(), key, "Review me", "author_count", []string{"rev_count"}, domain.PRDetails{})
+		require.NoError(t, err)
+	}
+	_, err = db.Exec(`UPDATE pr_reviewers SET assigned_at = $2 WHERE user_id = $1`, "rev_count", assignedAt)
+	require.NoError(t, err)
+	_, err = prService.ApprovePR(t.Context(), keys[1], "rev_count")
+	require.NoError(t, err)
+	_, err = prService.MergePR(t.Context(), keys[3], service.MergeOptions{})
+	require.NoError(t, err)
+
+	count := func(t *testing.T, userID string, repositoryName *string) pr.ReviewCount {
+		t.Helper()
+		c, err := userService.GetUserReviewCount(t.Context(), userID, repositoryName)
+		require.NoError(t, err)
+		return c
+	}
+
+	t.Run("open reviews are counted, merged ones are not", func(t *testing.T) {
+		assert.Equal(t, pr.ReviewCount{Open: 3}, count(t, "rev_count", nil))
+
+		reviews, err := userService.GetUserReviews(t.Context(), "rev_count", nil)
+		require.NoError(t, err)
+		assert.Len(t, reviews, 4, "getReview also lists the merged pull request")
+	})
+
+	t.Run("not overdue at the SLA hour", func(t *testing.T) {
+		clock.Current = assignedAt.Add(24 * time.Hour)
+		assert.Equal(t, pr.ReviewCount{Open: 3}, count(t, "rev_count", nil))
+	})
+
+	t.Run("pending reviews overdue right after the SLA hour", func(t *testing.T) {
+		clock.Current = assignedAt.Add(24*time.Hour + time.Second)
+		assert.Equal(t, pr.ReviewCount{Open: 3, Overdue: 2}, count(t, "rev_count", nil), "the approved review is not overdue")
+	})
+
+	t.Run("repository_name limits the count", func(t *testing.T) {
+		repositoryName := "repo_count"
+		assert.Equal(t, pr.ReviewCount{Open: 2, Overdue: 1}, count(t, "rev_count", &repositoryName))
+	})
+
+	t.Run("unknown user has zero counts", func(t *testing.T) {
+		assert.Equal(t, pr.ReviewCount{}, count(t, "nobody_count", nil))
+	})
+}
@@ -3,6 +3,7 @@ package integration
 import (
 	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -34,7 +35,7 @@ func TestUserService_SetIsActive(t *testing.T) {
 		IsActive: true,
 	}))
 
-	userService := service.NewUserService(db)
+	userService := service.NewUserService(db, service.NewPRService(db, service.NewReviewerAssigner()))
 
 	tests := []struct {
 		name           string
@@ -67,7 +68,7 @@ func TestUserService_SetIsActive(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			u, err := userService.SetIsActive(tt.userID, tt.isActive)
+			u, err := userService.SetIsActive(t.Context(), tt.userID, tt.isActive)
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -108,7 +109,7 @@ func TestUserService_GetUserReviews(t *testing.T) {
 		IsActive: true,
 	}))
 
-	userService := service.NewUserService(db)
+	userService := service.NewUserService(db, service.NewPRService(db, service.NewReviewerAssigner()))
 
 	t.Run("success - returns user reviews", func(t *testing.T) {
 		// Create PR with reviewer
@@ -117,7 +118,7 @@ func TestUserService_GetUserReviews(t *testing.T) {
 
 		require.NoError(t, createPRWithReviewer(db, prID, prName, authorID, reviewerID, teamName))
 
-		reviews, err := userService.GetUserReviews(reviewerID)
+		reviews, err := userService.GetUserReviews(t.Context(), reviewerID, nil)
 		require.NoError(t, err)
 		assert.Len(t, reviews, 1)
 		assert.Equal(t, prID, reviews[0].PullRequestID)
@@ -126,7 +127,7 @@ func TestUserService_GetUserReviews(t *testing.T) {
 	})
 
 	t.Run("success - empty reviews list", func(t *testing.T) {
-		reviews, err := userService.GetUserReviews("user_with_no_reviews")
+		reviews, err := userService.GetUserReviews(t.Context(), "user_with_no_reviews", nil)
 		require.NoError(t, err)
 		assert.Empty(t, reviews)
 	})
@@ -141,7 +142,7 @@ func TestUserService_GetUserReviews(t *testing.T) {
 		require.NoError(t, createPRWithReviewer(db, prID1, prName1, authorID, reviewerID, teamName))
 		require.NoError(t, createPRWithReviewer(db, prID2, prName2, authorID, reviewerID, teamName))
 
-		reviews, err := userService.GetUserReviews(reviewerID)
+		reviews, err := userService.GetUserReviews(t.Context(), reviewerID, nil)
 		require.NoError(t, err)
 		assert.GreaterOrEqual(t, len(reviews), 2)
 	})
@@ -159,5 +160,5 @@ func createPRWithReviewer(db *sql.DB, prID, prName, authorID, reviewerID, teamNa
 	if err := pr.Create(db, pullRequest); err != nil {
 		return err
 	}
-	return pr.InsertReviewer(db, prID, reviewerID)
+	return pr.InsertReviewer(db, domain.PRKey{PullRequestID: prID}, reviewerID, time.Now().UTC())
 }
@@ -0,0 +1,42 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestWebhookService(t *testing.T) {
+	db, err := tests.SetupTestDB()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	defer func() { _ = tests.CleanupTestDB(db) }()
+
+	webhookService := service.NewWebhookService(db)
+
+	first, err := webhookService.CreateWebhook(t.Context(), "https://a.example.com/hook", "secret-a")
+	require.NoError(t, err)
+	assert.Positive(t, first.ID)
+	assert.False(t, first.CreatedAt.IsZero())
+
+	second, err := webhookService.CreateWebhook(t.Context(), "https://b.example.com/hook", "secret-b")
+	require.NoError(t, err)
+
+	webhooks, err := webhookService.ListWebhooks(t.Context())
+	require.NoError(t, err)
+	require.Len(t, webhooks, 2)
+	assert.Equal(t, "https://a.example.com/hook", webhooks[0].URL)
+	assert.Equal(t, "secret-a", webhooks[0].Secret)
+
+	require.NoError(t, webhookService.DeleteWebhook(t.Context(), first.ID))
+	assert.ErrorIs(t, webhookService.DeleteWebhook(t.Context(), first.ID), service.ErrWebhookNotFound)
+
+	webhooks, err = webhookService.ListWebhooks(t.Context())
+	require.NoError(t, err)
+	require.Len(t, webhooks, 1)
+	assert.Equal(t, second.ID, webhooks[0].ID)
+}
@@ -0,0 +1,99 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	audit "github.com/mishasvintus/avito_backend_internship/internal/repository/audit"
+
+	domain "github.com/mishasvintus/avito_backend_internship/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	pagination "github.com/mishasvintus/avito_backend_internship/internal/pagination"
+)
+
+// MockAuditServiceInterface is an autogenerated mock type for the AuditServiceInterface type
+type MockAuditServiceInterface struct {
+	mock.Mock
+}
+
+type MockAuditServiceInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAuditServiceInterface) EXPECT() *MockAuditServiceInterface_Expecter {
+	return &MockAuditServiceInterface_Expecter{mock: &_m.Mock}
+}
+
+// ListAudit provides a mock function with given fields: ctx, filter
+func (_m *MockAuditServiceInterface) ListAudit(ctx context.Context, filter audit.Filter) (pagination.Page[domain.AuditEntry], error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAudit")
+	}
+
+	var r0 pagination.Page[domain.AuditEntry]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Filter) (pagination.Page[domain.AuditEntry], error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Filter) pagination.Page[domain.AuditEntry]); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(pagination.Page[domain.AuditEntry])
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, audit.Filter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuditServiceInterface_ListAudit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAudit'
+type MockAuditServiceInterface_ListAudit_Call struct {
+	*mock.Call
+}
+
+// ListAudit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter audit.Filter
+func (_e *MockAuditServiceInterface_Expecter) ListAudit(ctx interface{}, filter interface{}) *MockAuditServiceInterface_ListAudit_Call {
+	return &MockAuditServiceInterface_ListAudit_Call{Call: _e.mock.On("ListAudit", ctx, filter)}
+}
+
+func (_c *MockAuditServiceInterface_ListAudit_Call) Run(run func(ctx context.Context, filter audit.Filter)) *MockAuditServiceInterface_ListAudit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(audit.Filter))
+	})
+	return _c
+}
+
+func (_c *MockAuditServiceInterface_ListAudit_Call) Return(_a0 pagination.Page[domain.AuditEntry], _a1 error) *MockAuditServiceInterface_ListAudit_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuditServiceInterface_ListAudit_Call) RunAndReturn(run func(context.Context, audit.Filter) (pagination.Page[domain.AuditEntry], error)) *MockAuditServiceInterface_ListAudit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAuditServiceInterface creates a new instance of MockAuditServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAuditServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAuditServiceInterface {
+	mock := &MockAuditServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
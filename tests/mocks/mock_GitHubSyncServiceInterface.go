@@ -0,0 +1,96 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	service "github.com/mishasvintus/avito_backend_internship/internal/service"
+)
+
+// MockGitHubSyncServiceInterface is an autogenerated mock type for the GitHubSyncServiceInterface type
+type MockGitHubSyncServiceInterface struct {
+	mock.Mock
+}
+
+type MockGitHubSyncServiceInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockGitHubSyncServiceInterface) EXPECT() *MockGitHubSyncServiceInterface_Expecter {
+	return &MockGitHubSyncServiceInterface_Expecter{mock: &_m.Mock}
+}
+
+// SyncTeams provides a mock function with given fields: ctx
+func (_m *MockGitHubSyncServiceInterface) SyncTeams(ctx context.Context) (*service.TeamSyncSummary, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SyncTeams")
+	}
+
+	var r0 *service.TeamSyncSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*service.TeamSyncSummary, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *service.TeamSyncSummary); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.TeamSyncSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGitHubSyncServiceInterface_SyncTeams_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SyncTeams'
+type MockGitHubSyncServiceInterface_SyncTeams_Call struct {
+	*mock.Call
+}
+
+// SyncTeams is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockGitHubSyncServiceInterface_Expecter) SyncTeams(ctx interface{}) *MockGitHubSyncServiceInterface_SyncTeams_Call {
+	return &MockGitHubSyncServiceInterface_SyncTeams_Call{Call: _e.mock.On("SyncTeams", ctx)}
+}
+
+func (_c *MockGitHubSyncServiceInterface_SyncTeams_Call) Run(run func(ctx context.Context)) *MockGitHubSyncServiceInterface_SyncTeams_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockGitHubSyncServiceInterface_SyncTeams_Call) Return(_a0 *service.TeamSyncSummary, _a1 error) *MockGitHubSyncServiceInterface_SyncTeams_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGitHubSyncServiceInterface_SyncTeams_Call) RunAndReturn(run func(context.Context) (*service.TeamSyncSummary, error)) *MockGitHubSyncServiceInterface_SyncTeams_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockGitHubSyncServiceInterface creates a new instance of MockGitHubSyncServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockGitHubSyncServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockGitHubSyncServiceInterface {
+	mock := &MockGitHubSyncServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
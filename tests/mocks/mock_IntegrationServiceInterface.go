@@ -0,0 +1,146 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/mishasvintus/avito_backend_internship/internal/domain"
+
+	integration "github.com/mishasvintus/avito_backend_internship/internal/integration"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockIntegrationServiceInterface is an autogenerated mock type for the IntegrationServiceInterface type
+type MockIntegrationServiceInterface struct {
+	mock.Mock
+}
+
+type MockIntegrationServiceInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIntegrationServiceInterface) EXPECT() *MockIntegrationServiceInterface_Expecter {
+	return &MockIntegrationServiceInterface_Expecter{mock: &_m.Mock}
+}
+
+// Apply provides a mock function with given fields: ctx, cmd
+func (_m *MockIntegrationServiceInterface) Apply(ctx context.Context, cmd integration.Command) (*domain.PullRequest, error) {
+	ret := _m.Called(ctx, cmd)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Apply")
+	}
+
+	var r0 *domain.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, integration.Command) (*domain.PullRequest, error)); ok {
+		return rf(ctx, cmd)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, integration.Command) *domain.PullRequest); ok {
+		r0 = rf(ctx, cmd)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, integration.Command) error); ok {
+		r1 = rf(ctx, cmd)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIntegrationServiceInterface_Apply_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Apply'
+type MockIntegrationServiceInterface_Apply_Call struct {
+	*mock.Call
+}
+
+// Apply is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cmd integration.Command
+func (_e *MockIntegrationServiceInterface_Expecter) Apply(ctx interface{}, cmd interface{}) *MockIntegrationServiceInterface_Apply_Call {
+	return &MockIntegrationServiceInterface_Apply_Call{Call: _e.mock.On("Apply", ctx, cmd)}
+}
+
+func (_c *MockIntegrationServiceInterface_Apply_Call) Run(run func(ctx context.Context, cmd integration.Command)) *MockIntegrationServiceInterface_Apply_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(integration.Command))
+	})
+	return _c
+}
+
+func (_c *MockIntegrationServiceInterface_Apply_Call) Return(_a0 *domain.PullRequest, _a1 error) *MockIntegrationServiceInterface_Apply_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIntegrationServiceInterface_Apply_Call) RunAndReturn(run func(context.Context, integration.Command) (*domain.PullRequest, error)) *MockIntegrationServiceInterface_Apply_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MapLogin provides a mock function with given fields: ctx, login
+func (_m *MockIntegrationServiceInterface) MapLogin(ctx context.Context, login *domain.ExternalLogin) error {
+	ret := _m.Called(ctx, login)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MapLogin")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ExternalLogin) error); ok {
+		r0 = rf(ctx, login)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIntegrationServiceInterface_MapLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MapLogin'
+type MockIntegrationServiceInterface_MapLogin_Call struct {
+	*mock.Call
+}
+
+// MapLogin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - login *domain.ExternalLogin
+func (_e *MockIntegrationServiceInterface_Expecter) MapLogin(ctx interface{}, login interface{}) *MockIntegrationServiceInterface_MapLogin_Call {
+	return &MockIntegrationServiceInterface_MapLogin_Call{Call: _e.mock.On("MapLogin", ctx, login)}
+}
+
+func (_c *MockIntegrationServiceInterface_MapLogin_Call) Run(run func(ctx context.Context, login *domain.ExternalLogin)) *MockIntegrationServiceInterface_MapLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.ExternalLogin))
+	})
+	return _c
+}
+
+func (_c *MockIntegrationServiceInterface_MapLogin_Call) Return(_a0 error) *MockIntegrationServiceInterface_MapLogin_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIntegrationServiceInterface_MapLogin_Call) RunAndReturn(run func(context.Context, *domain.ExternalLogin) error) *MockIntegrationServiceInterface_MapLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockIntegrationServiceInterface creates a new instance of MockIntegrationServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIntegrationServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIntegrationServiceInterface {
+	mock := &MockIntegrationServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
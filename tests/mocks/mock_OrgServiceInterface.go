@@ -0,0 +1,156 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/mishasvintus/avito_backend_internship/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockOrgServiceInterface is an autogenerated mock type for the OrgServiceInterface type
+type MockOrgServiceInterface struct {
+	mock.Mock
+}
+
+type MockOrgServiceInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOrgServiceInterface) EXPECT() *MockOrgServiceInterface_Expecter {
+	return &MockOrgServiceInterface_Expecter{mock: &_m.Mock}
+}
+
+// CreateOrg provides a mock function with given fields: ctx, orgID, name
+func (_m *MockOrgServiceInterface) CreateOrg(ctx context.Context, orgID string, name string) (*domain.Organization, error) {
+	ret := _m.Called(ctx, orgID, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrg")
+	}
+
+	var r0 *domain.Organization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.Organization, error)); ok {
+		return rf(ctx, orgID, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.Organization); ok {
+		r0 = rf(ctx, orgID, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Organization)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, orgID, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOrgServiceInterface_CreateOrg_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrg'
+type MockOrgServiceInterface_CreateOrg_Call struct {
+	*mock.Call
+}
+
+// CreateOrg is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID string
+//   - name string
+func (_e *MockOrgServiceInterface_Expecter) CreateOrg(ctx interface{}, orgID interface{}, name interface{}) *MockOrgServiceInterface_CreateOrg_Call {
+	return &MockOrgServiceInterface_CreateOrg_Call{Call: _e.mock.On("CreateOrg", ctx, orgID, name)}
+}
+
+func (_c *MockOrgServiceInterface_CreateOrg_Call) Run(run func(ctx context.Context, orgID string, name string)) *MockOrgServiceInterface_CreateOrg_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockOrgServiceInterface_CreateOrg_Call) Return(_a0 *domain.Organization, _a1 error) *MockOrgServiceInterface_CreateOrg_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOrgServiceInterface_CreateOrg_Call) RunAndReturn(run func(context.Context, string, string) (*domain.Organization, error)) *MockOrgServiceInterface_CreateOrg_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOrgs provides a mock function with given fields: ctx
+func (_m *MockOrgServiceInterface) ListOrgs(ctx context.Context) ([]domain.Organization, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOrgs")
+	}
+
+	var r0 []domain.Organization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.Organization, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.Organization); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Organization)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOrgServiceInterface_ListOrgs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOrgs'
+type MockOrgServiceInterface_ListOrgs_Call struct {
+	*mock.Call
+}
+
+// ListOrgs is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockOrgServiceInterface_Expecter) ListOrgs(ctx interface{}) *MockOrgServiceInterface_ListOrgs_Call {
+	return &MockOrgServiceInterface_ListOrgs_Call{Call: _e.mock.On("ListOrgs", ctx)}
+}
+
+func (_c *MockOrgServiceInterface_ListOrgs_Call) Run(run func(ctx context.Context)) *MockOrgServiceInterface_ListOrgs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockOrgServiceInterface_ListOrgs_Call) Return(_a0 []domain.Organization, _a1 error) *MockOrgServiceInterface_ListOrgs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOrgServiceInterface_ListOrgs_Call) RunAndReturn(run func(context.Context) ([]domain.Organization, error)) *MockOrgServiceInterface_ListOrgs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockOrgServiceInterface creates a new instance of MockOrgServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOrgServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOrgServiceInterface {
+	mock := &MockOrgServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -3,9 +3,13 @@
 package mocks
 
 import (
+	context "context"
+
 	domain "github.com/mishasvintus/avito_backend_internship/internal/domain"
 
 	mock "github.com/stretchr/testify/mock"
+
+	service "github.com/mishasvintus/avito_backend_internship/internal/service"
 )
 
 // MockPRServiceInterface is an autogenerated mock type for the PRServiceInterface type
@@ -21,9 +25,188 @@ func (_m *MockPRServiceInterface) EXPECT() *MockPRServiceInterface_Expecter {
 	return &MockPRServiceInterface_Expecter{mock: &_m.Mock}
 }
 
-// CreatePR provides a mock function with given fields: prID, prName, authorID
-func (_m *MockPRServiceInterface) CreatePR(prID string, prName string, authorID string) (*domain.PullRequest, error) {
-	ret := _m.Called(prID, prName, authorID)
+// ApprovePR provides a mock function with given fields: ctx, key, userID
+func (_m *MockPRServiceInterface) ApprovePR(ctx context.Context, key domain.PRKey, userID string) (*domain.PullRequest, error) {
+	ret := _m.Called(ctx, key, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApprovePR")
+	}
+
+	var r0 *domain.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PRKey, string) (*domain.PullRequest, error)); ok {
+		return rf(ctx, key, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PRKey, string) *domain.PullRequest); ok {
+		r0 = rf(ctx, key, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.PRKey, string) error); ok {
+		r1 = rf(ctx, key, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPRServiceInterface_ApprovePR_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApprovePR'
+type MockPRServiceInterface_ApprovePR_Call struct {
+	*mock.Call
+}
+
+// ApprovePR is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key domain.PRKey
+//   - userID string
+func (_e *MockPRServiceInterface_Expecter) ApprovePR(ctx interface{}, key interface{}, userID interface{}) *MockPRServiceInterface_ApprovePR_Call {
+	return &MockPRServiceInterface_ApprovePR_Call{Call: _e.mock.On("ApprovePR", ctx, key, userID)}
+}
+
+func (_c *MockPRServiceInterface_ApprovePR_Call) Run(run func(ctx context.Context, key domain.PRKey, userID string)) *MockPRServiceInterface_ApprovePR_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.PRKey), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockPRServiceInterface_ApprovePR_Call) Return(_a0 *domain.PullRequest, _a1 error) *MockPRServiceInterface_ApprovePR_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPRServiceInterface_ApprovePR_Call) RunAndReturn(run func(context.Context, domain.PRKey, string) (*domain.PullRequest, error)) *MockPRServiceInterface_ApprovePR_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BackfillReviewers provides a mock function with given fields: ctx, teamName, opts
+func (_m *MockPRServiceInterface) BackfillReviewers(ctx context.Context, teamName string, opts service.BackfillOptions) (*service.BackfillResult, error) {
+	ret := _m.Called(ctx, teamName, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BackfillReviewers")
+	}
+
+	var r0 *service.BackfillResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, service.BackfillOptions) (*service.BackfillResult, error)); ok {
+		return rf(ctx, teamName, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, service.BackfillOptions) *service.BackfillResult); ok {
+		r0 = rf(ctx, teamName, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.BackfillResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, service.BackfillOptions) error); ok {
+		r1 = rf(ctx, teamName, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPRServiceInterface_BackfillReviewers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BackfillReviewers'
+type MockPRServiceInterface_BackfillReviewers_Call struct {
+	*mock.Call
+}
+
+// BackfillReviewers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - opts service.BackfillOptions
+func (_e *MockPRServiceInterface_Expecter) BackfillReviewers(ctx interface{}, teamName interface{}, opts interface{}) *MockPRServiceInterface_BackfillReviewers_Call {
+	return &MockPRServiceInterface_BackfillReviewers_Call{Call: _e.mock.On("BackfillReviewers", ctx, teamName, opts)}
+}
+
+func (_c *MockPRServiceInterface_BackfillReviewers_Call) Run(run func(ctx context.Context, teamName string, opts service.BackfillOptions)) *MockPRServiceInterface_BackfillReviewers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(service.BackfillOptions))
+	})
+	return _c
+}
+
+func (_c *MockPRServiceInterface_BackfillReviewers_Call) Return(_a0 *service.BackfillResult, _a1 error) *MockPRServiceInterface_BackfillReviewers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPRServiceInterface_BackfillReviewers_Call) RunAndReturn(run func(context.Context, string, service.BackfillOptions) (*service.BackfillResult, error)) *MockPRServiceInterface_BackfillReviewers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClosePR provides a mock function with given fields: ctx, key
+func (_m *MockPRServiceInterface) ClosePR(ctx context.Context, key domain.PRKey) (*domain.PullRequest, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClosePR")
+	}
+
+	var r0 *domain.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PRKey) (*domain.PullRequest, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PRKey) *domain.PullRequest); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.PRKey) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPRServiceInterface_ClosePR_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClosePR'
+type MockPRServiceInterface_ClosePR_Call struct {
+	*mock.Call
+}
+
+// ClosePR is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key domain.PRKey
+func (_e *MockPRServiceInterface_Expecter) ClosePR(ctx interface{}, key interface{}) *MockPRServiceInterface_ClosePR_Call {
+	return &MockPRServiceInterface_ClosePR_Call{Call: _e.mock.On("ClosePR", ctx, key)}
+}
+
+func (_c *MockPRServiceInterface_ClosePR_Call) Run(run func(ctx context.Context, key domain.PRKey)) *MockPRServiceInterface_ClosePR_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.PRKey))
+	})
+	return _c
+}
+
+func (_c *MockPRServiceInterface_ClosePR_Call) Return(_a0 *domain.PullRequest, _a1 error) *MockPRServiceInterface_ClosePR_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPRServiceInterface_ClosePR_Call) RunAndReturn(run func(context.Context, domain.PRKey) (*domain.PullRequest, error)) *MockPRServiceInterface_ClosePR_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreatePR provides a mock function with given fields: ctx, key, prName, authorID, requiredReviewers, details
+func (_m *MockPRServiceInterface) CreatePR(ctx context.Context, key domain.PRKey, prName string, authorID string, requiredReviewers []string, details domain.PRDetails) (*domain.PullRequest, error) {
+	ret := _m.Called(ctx, key, prName, authorID, requiredReviewers, details)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreatePR")
@@ -31,19 +214,19 @@ func (_m *MockPRServiceInterface) CreatePR(prID string, prName string, authorID
 
 	var r0 *domain.PullRequest
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, string) (*domain.PullRequest, error)); ok {
-		return rf(prID, prName, authorID)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PRKey, string, string, []string, domain.PRDetails) (*domain.PullRequest, error)); ok {
+		return rf(ctx, key, prName, authorID, requiredReviewers, details)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, string) *domain.PullRequest); ok {
-		r0 = rf(prID, prName, authorID)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PRKey, string, string, []string, domain.PRDetails) *domain.PullRequest); ok {
+		r0 = rf(ctx, key, prName, authorID, requiredReviewers, details)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.PullRequest)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
-		r1 = rf(prID, prName, authorID)
+	if rf, ok := ret.Get(1).(func(context.Context, domain.PRKey, string, string, []string, domain.PRDetails) error); ok {
+		r1 = rf(ctx, key, prName, authorID, requiredReviewers, details)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -57,16 +240,19 @@ type MockPRServiceInterface_CreatePR_Call struct {
 }
 
 // CreatePR is a helper method to define mock.On call
-//   - prID string
+//   - ctx context.Context
+//   - key domain.PRKey
 //   - prName string
 //   - authorID string
-func (_e *MockPRServiceInterface_Expecter) CreatePR(prID interface{}, prName interface{}, authorID interface{}) *MockPRServiceInterface_CreatePR_Call {
-	return &MockPRServiceInterface_CreatePR_Call{Call: _e.mock.On("CreatePR", prID, prName, authorID)}
+//   - requiredReviewers []string
+//   - details domain.PRDetails
+func (_e *MockPRServiceInterface_Expecter) CreatePR(ctx interface{}, key interface{}, prName interface{}, authorID interface{}, requiredReviewers interface{}, details interface{}) *MockPRServiceInterface_CreatePR_Call {
+	return &MockPRServiceInterface_CreatePR_Call{Call: _e.mock.On("CreatePR", ctx, key, prName, authorID, requiredReviewers, details)}
 }
 
-func (_c *MockPRServiceInterface_CreatePR_Call) Run(run func(prID string, prName string, authorID string)) *MockPRServiceInterface_CreatePR_Call {
+func (_c *MockPRServiceInterface_CreatePR_Call) Run(run func(ctx context.Context, key domain.PRKey, prName string, authorID string, requiredReviewers []string, details domain.PRDetails)) *MockPRServiceInterface_CreatePR_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(domain.PRKey), args[2].(string), args[3].(string), args[4].([]string), args[5].(domain.PRDetails))
 	})
 	return _c
 }
@@ -76,14 +262,73 @@ func (_c *MockPRServiceInterface_CreatePR_Call) Return(_a0 *domain.PullRequest,
 	return _c
 }
 
-func (_c *MockPRServiceInterface_CreatePR_Call) RunAndReturn(run func(string, string, string) (*domain.PullRequest, error)) *MockPRServiceInterface_CreatePR_Call {
+func (_c *MockPRServiceInterface_CreatePR_Call) RunAndReturn(run func(context.Context, domain.PRKey, string, string, []string, domain.PRDetails) (*domain.PullRequest, error)) *MockPRServiceInterface_CreatePR_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// MergePR provides a mock function with given fields: prID
-func (_m *MockPRServiceInterface) MergePR(prID string) (*domain.PullRequest, error) {
-	ret := _m.Called(prID)
+// GetPR provides a mock function with given fields: ctx, key
+func (_m *MockPRServiceInterface) GetPR(ctx context.Context, key domain.PRKey) (*domain.PullRequest, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPR")
+	}
+
+	var r0 *domain.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PRKey) (*domain.PullRequest, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PRKey) *domain.PullRequest); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.PRKey) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPRServiceInterface_GetPR_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPR'
+type MockPRServiceInterface_GetPR_Call struct {
+	*mock.Call
+}
+
+// GetPR is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key domain.PRKey
+func (_e *MockPRServiceInterface_Expecter) GetPR(ctx interface{}, key interface{}) *MockPRServiceInterface_GetPR_Call {
+	return &MockPRServiceInterface_GetPR_Call{Call: _e.mock.On("GetPR", ctx, key)}
+}
+
+func (_c *MockPRServiceInterface_GetPR_Call) Run(run func(ctx context.Context, key domain.PRKey)) *MockPRServiceInterface_GetPR_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.PRKey))
+	})
+	return _c
+}
+
+func (_c *MockPRServiceInterface_GetPR_Call) Return(_a0 *domain.PullRequest, _a1 error) *MockPRServiceInterface_GetPR_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPRServiceInterface_GetPR_Call) RunAndReturn(run func(context.Context, domain.PRKey) (*domain.PullRequest, error)) *MockPRServiceInterface_GetPR_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MergePR provides a mock function with given fields: ctx, key, opts
+func (_m *MockPRServiceInterface) MergePR(ctx context.Context, key domain.PRKey, opts service.MergeOptions) (*domain.PullRequest, error) {
+	ret := _m.Called(ctx, key, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for MergePR")
@@ -91,19 +336,19 @@ func (_m *MockPRServiceInterface) MergePR(prID string) (*domain.PullRequest, err
 
 	var r0 *domain.PullRequest
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*domain.PullRequest, error)); ok {
-		return rf(prID)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PRKey, service.MergeOptions) (*domain.PullRequest, error)); ok {
+		return rf(ctx, key, opts)
 	}
-	if rf, ok := ret.Get(0).(func(string) *domain.PullRequest); ok {
-		r0 = rf(prID)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PRKey, service.MergeOptions) *domain.PullRequest); ok {
+		r0 = rf(ctx, key, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.PullRequest)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(prID)
+	if rf, ok := ret.Get(1).(func(context.Context, domain.PRKey, service.MergeOptions) error); ok {
+		r1 = rf(ctx, key, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -117,14 +362,16 @@ type MockPRServiceInterface_MergePR_Call struct {
 }
 
 // MergePR is a helper method to define mock.On call
-//   - prID string
-func (_e *MockPRServiceInterface_Expecter) MergePR(prID interface{}) *MockPRServiceInterface_MergePR_Call {
-	return &MockPRServiceInterface_MergePR_Call{Call: _e.mock.On("MergePR", prID)}
+//   - ctx context.Context
+//   - key domain.PRKey
+//   - opts service.MergeOptions
+func (_e *MockPRServiceInterface_Expecter) MergePR(ctx interface{}, key interface{}, opts interface{}) *MockPRServiceInterface_MergePR_Call {
+	return &MockPRServiceInterface_MergePR_Call{Call: _e.mock.On("MergePR", ctx, key, opts)}
 }
 
-func (_c *MockPRServiceInterface_MergePR_Call) Run(run func(prID string)) *MockPRServiceInterface_MergePR_Call {
+func (_c *MockPRServiceInterface_MergePR_Call) Run(run func(ctx context.Context, key domain.PRKey, opts service.MergeOptions)) *MockPRServiceInterface_MergePR_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(domain.PRKey), args[2].(service.MergeOptions))
 	})
 	return _c
 }
@@ -134,14 +381,14 @@ func (_c *MockPRServiceInterface_MergePR_Call) Return(_a0 *domain.PullRequest, _
 	return _c
 }
 
-func (_c *MockPRServiceInterface_MergePR_Call) RunAndReturn(run func(string) (*domain.PullRequest, error)) *MockPRServiceInterface_MergePR_Call {
+func (_c *MockPRServiceInterface_MergePR_Call) RunAndReturn(run func(context.Context, domain.PRKey, service.MergeOptions) (*domain.PullRequest, error)) *MockPRServiceInterface_MergePR_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ReassignPR provides a mock function with given fields: prID, oldReviewerID
-func (_m *MockPRServiceInterface) ReassignPR(prID string, oldReviewerID string) (*domain.PullRequest, string, error) {
-	ret := _m.Called(prID, oldReviewerID)
+// ReassignPR provides a mock function with given fields: ctx, key, oldReviewerID, opts
+func (_m *MockPRServiceInterface) ReassignPR(ctx context.Context, key domain.PRKey, oldReviewerID string, opts service.ReassignOptions) (*domain.PullRequest, string, error) {
+	ret := _m.Called(ctx, key, oldReviewerID, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ReassignPR")
@@ -150,25 +397,25 @@ func (_m *MockPRServiceInterface) ReassignPR(prID string, oldReviewerID string)
 	var r0 *domain.PullRequest
 	var r1 string
 	var r2 error
-	if rf, ok := ret.Get(0).(func(string, string) (*domain.PullRequest, string, error)); ok {
-		return rf(prID, oldReviewerID)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PRKey, string, service.ReassignOptions) (*domain.PullRequest, string, error)); ok {
+		return rf(ctx, key, oldReviewerID, opts)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) *domain.PullRequest); ok {
-		r0 = rf(prID, oldReviewerID)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PRKey, string, service.ReassignOptions) *domain.PullRequest); ok {
+		r0 = rf(ctx, key, oldReviewerID, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.PullRequest)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) string); ok {
-		r1 = rf(prID, oldReviewerID)
+	if rf, ok := ret.Get(1).(func(context.Context, domain.PRKey, string, service.ReassignOptions) string); ok {
+		r1 = rf(ctx, key, oldReviewerID, opts)
 	} else {
 		r1 = ret.Get(1).(string)
 	}
 
-	if rf, ok := ret.Get(2).(func(string, string) error); ok {
-		r2 = rf(prID, oldReviewerID)
+	if rf, ok := ret.Get(2).(func(context.Context, domain.PRKey, string, service.ReassignOptions) error); ok {
+		r2 = rf(ctx, key, oldReviewerID, opts)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -182,15 +429,17 @@ type MockPRServiceInterface_ReassignPR_Call struct {
 }
 
 // ReassignPR is a helper method to define mock.On call
-//   - prID string
+//   - ctx context.Context
+//   - key domain.PRKey
 //   - oldReviewerID string
-func (_e *MockPRServiceInterface_Expecter) ReassignPR(prID interface{}, oldReviewerID interface{}) *MockPRServiceInterface_ReassignPR_Call {
-	return &MockPRServiceInterface_ReassignPR_Call{Call: _e.mock.On("ReassignPR", prID, oldReviewerID)}
+//   - opts service.ReassignOptions
+func (_e *MockPRServiceInterface_Expecter) ReassignPR(ctx interface{}, key interface{}, oldReviewerID interface{}, opts interface{}) *MockPRServiceInterface_ReassignPR_Call {
+	return &MockPRServiceInterface_ReassignPR_Call{Call: _e.mock.On("ReassignPR", ctx, key, oldReviewerID, opts)}
 }
 
-func (_c *MockPRServiceInterface_ReassignPR_Call) Run(run func(prID string, oldReviewerID string)) *MockPRServiceInterface_ReassignPR_Call {
+func (_c *MockPRServiceInterface_ReassignPR_Call) Run(run func(ctx context.Context, key domain.PRKey, oldReviewerID string, opts service.ReassignOptions)) *MockPRServiceInterface_ReassignPR_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(context.Context), args[1].(domain.PRKey), args[2].(string), args[3].(service.ReassignOptions))
 	})
 	return _c
 }
@@ -200,7 +449,126 @@ func (_c *MockPRServiceInterface_ReassignPR_Call) Return(_a0 *domain.PullRequest
 	return _c
 }
 
-func (_c *MockPRServiceInterface_ReassignPR_Call) RunAndReturn(run func(string, string) (*domain.PullRequest, string, error)) *MockPRServiceInterface_ReassignPR_Call {
+func (_c *MockPRServiceInterface_ReassignPR_Call) RunAndReturn(run func(context.Context, domain.PRKey, string, service.ReassignOptions) (*domain.PullRequest, string, error)) *MockPRServiceInterface_ReassignPR_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReopenPR provides a mock function with given fields: ctx, key
+func (_m *MockPRServiceInterface) ReopenPR(ctx context.Context, key domain.PRKey) (*domain.PullRequest, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReopenPR")
+	}
+
+	var r0 *domain.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PRKey) (*domain.PullRequest, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PRKey) *domain.PullRequest); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.PRKey) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPRServiceInterface_ReopenPR_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReopenPR'
+type MockPRServiceInterface_ReopenPR_Call struct {
+	*mock.Call
+}
+
+// ReopenPR is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key domain.PRKey
+func (_e *MockPRServiceInterface_Expecter) ReopenPR(ctx interface{}, key interface{}) *MockPRServiceInterface_ReopenPR_Call {
+	return &MockPRServiceInterface_ReopenPR_Call{Call: _e.mock.On("ReopenPR", ctx, key)}
+}
+
+func (_c *MockPRServiceInterface_ReopenPR_Call) Run(run func(ctx context.Context, key domain.PRKey)) *MockPRServiceInterface_ReopenPR_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.PRKey))
+	})
+	return _c
+}
+
+func (_c *MockPRServiceInterface_ReopenPR_Call) Return(_a0 *domain.PullRequest, _a1 error) *MockPRServiceInterface_ReopenPR_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPRServiceInterface_ReopenPR_Call) RunAndReturn(run func(context.Context, domain.PRKey) (*domain.PullRequest, error)) *MockPRServiceInterface_ReopenPR_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SuggestReviewers provides a mock function with given fields: ctx, authorID, count
+func (_m *MockPRServiceInterface) SuggestReviewers(ctx context.Context, authorID string, count int) (*service.ReviewerSuggestion, error) {
+	ret := _m.Called(ctx, authorID, count)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SuggestReviewers")
+	}
+
+	var r0 *service.ReviewerSuggestion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) (*service.ReviewerSuggestion, error)); ok {
+		return rf(ctx, authorID, count)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) *service.ReviewerSuggestion); ok {
+		r0 = rf(ctx, authorID, count)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.ReviewerSuggestion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, authorID, count)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPRServiceInterface_SuggestReviewers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SuggestReviewers'
+type MockPRServiceInterface_SuggestReviewers_Call struct {
+	*mock.Call
+}
+
+// SuggestReviewers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - authorID string
+//   - count int
+func (_e *MockPRServiceInterface_Expecter) SuggestReviewers(ctx interface{}, authorID interface{}, count interface{}) *MockPRServiceInterface_SuggestReviewers_Call {
+	return &MockPRServiceInterface_SuggestReviewers_Call{Call: _e.mock.On("SuggestReviewers", ctx, authorID, count)}
+}
+
+func (_c *MockPRServiceInterface_SuggestReviewers_Call) Run(run func(ctx context.Context, authorID string, count int)) *MockPRServiceInterface_SuggestReviewers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockPRServiceInterface_SuggestReviewers_Call) Return(_a0 *service.ReviewerSuggestion, _a1 error) *MockPRServiceInterface_SuggestReviewers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPRServiceInterface_SuggestReviewers_Call) RunAndReturn(run func(context.Context, string, int) (*service.ReviewerSuggestion, error)) *MockPRServiceInterface_SuggestReviewers_Call {
 	_c.Call.Return(run)
 	return _c
 }
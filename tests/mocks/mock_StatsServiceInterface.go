@@ -3,8 +3,13 @@
 package mocks
 
 import (
-	service "github.com/mishasvintus/avito_backend_internship/internal/service"
+	context "context"
+
 	mock "github.com/stretchr/testify/mock"
+
+	service "github.com/mishasvintus/avito_backend_internship/internal/service"
+
+	stats "github.com/mishasvintus/avito_backend_internship/internal/repository/stats"
 )
 
 // MockStatsServiceInterface is an autogenerated mock type for the StatsServiceInterface type
@@ -20,9 +25,127 @@ func (_m *MockStatsServiceInterface) EXPECT() *MockStatsServiceInterface_Expecte
 	return &MockStatsServiceInterface_Expecter{mock: &_m.Mock}
 }
 
-// GetStatistics provides a mock function with no fields
-func (_m *MockStatsServiceInterface) GetStatistics() (*service.Statistics, error) {
-	ret := _m.Called()
+// GetCoverage provides a mock function with given fields: ctx
+func (_m *MockStatsServiceInterface) GetCoverage(ctx context.Context) (*service.Coverage, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCoverage")
+	}
+
+	var r0 *service.Coverage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*service.Coverage, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *service.Coverage); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.Coverage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStatsServiceInterface_GetCoverage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCoverage'
+type MockStatsServiceInterface_GetCoverage_Call struct {
+	*mock.Call
+}
+
+// GetCoverage is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStatsServiceInterface_Expecter) GetCoverage(ctx interface{}) *MockStatsServiceInterface_GetCoverage_Call {
+	return &MockStatsServiceInterface_GetCoverage_Call{Call: _e.mock.On("GetCoverage", ctx)}
+}
+
+func (_c *MockStatsServiceInterface_GetCoverage_Call) Run(run func(ctx context.Context)) *MockStatsServiceInterface_GetCoverage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockStatsServiceInterface_GetCoverage_Call) Return(_a0 *service.Coverage, _a1 error) *MockStatsServiceInterface_GetCoverage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStatsServiceInterface_GetCoverage_Call) RunAndReturn(run func(context.Context) (*service.Coverage, error)) *MockStatsServiceInterface_GetCoverage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLeaderboard provides a mock function with given fields: ctx, period, limit
+func (_m *MockStatsServiceInterface) GetLeaderboard(ctx context.Context, period service.LeaderboardPeriod, limit int) (*service.Leaderboard, error) {
+	ret := _m.Called(ctx, period, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLeaderboard")
+	}
+
+	var r0 *service.Leaderboard
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, service.LeaderboardPeriod, int) (*service.Leaderboard, error)); ok {
+		return rf(ctx, period, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, service.LeaderboardPeriod, int) *service.Leaderboard); ok {
+		r0 = rf(ctx, period, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.Leaderboard)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, service.LeaderboardPeriod, int) error); ok {
+		r1 = rf(ctx, period, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStatsServiceInterface_GetLeaderboard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLeaderboard'
+type MockStatsServiceInterface_GetLeaderboard_Call struct {
+	*mock.Call
+}
+
+// GetLeaderboard is a helper method to define mock.On call
+//   - ctx context.Context
+//   - period service.LeaderboardPeriod
+//   - limit int
+func (_e *MockStatsServiceInterface_Expecter) GetLeaderboard(ctx interface{}, period interface{}, limit interface{}) *MockStatsServiceInterface_GetLeaderboard_Call {
+	return &MockStatsServiceInterface_GetLeaderboard_Call{Call: _e.mock.On("GetLeaderboard", ctx, period, limit)}
+}
+
+func (_c *MockStatsServiceInterface_GetLeaderboard_Call) Run(run func(ctx context.Context, period service.LeaderboardPeriod, limit int)) *MockStatsServiceInterface_GetLeaderboard_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(service.LeaderboardPeriod), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockStatsServiceInterface_GetLeaderboard_Call) Return(_a0 *service.Leaderboard, _a1 error) *MockStatsServiceInterface_GetLeaderboard_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStatsServiceInterface_GetLeaderboard_Call) RunAndReturn(run func(context.Context, service.LeaderboardPeriod, int) (*service.Leaderboard, error)) *MockStatsServiceInterface_GetLeaderboard_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStatistics provides a mock function with given fields: ctx, period
+func (_m *MockStatsServiceInterface) GetStatistics(ctx context.Context, period stats.Period) (*service.Statistics, error) {
+	ret := _m.Called(ctx, period)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetStatistics")
@@ -30,19 +153,19 @@ func (_m *MockStatsServiceInterface) GetStatistics() (*service.Statistics, error
 
 	var r0 *service.Statistics
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (*service.Statistics, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context, stats.Period) (*service.Statistics, error)); ok {
+		return rf(ctx, period)
 	}
-	if rf, ok := ret.Get(0).(func() *service.Statistics); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context, stats.Period) *service.Statistics); ok {
+		r0 = rf(ctx, period)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*service.Statistics)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context, stats.Period) error); ok {
+		r1 = rf(ctx, period)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -56,13 +179,15 @@ type MockStatsServiceInterface_GetStatistics_Call struct {
 }
 
 // GetStatistics is a helper method to define mock.On call
-func (_e *MockStatsServiceInterface_Expecter) GetStatistics() *MockStatsServiceInterface_GetStatistics_Call {
-	return &MockStatsServiceInterface_GetStatistics_Call{Call: _e.mock.On("GetStatistics")}
+//   - ctx context.Context
+//   - period stats.Period
+func (_e *MockStatsServiceInterface_Expecter) GetStatistics(ctx interface{}, period interface{}) *MockStatsServiceInterface_GetStatistics_Call {
+	return &MockStatsServiceInterface_GetStatistics_Call{Call: _e.mock.On("GetStatistics", ctx, period)}
 }
 
-func (_c *MockStatsServiceInterface_GetStatistics_Call) Run(run func()) *MockStatsServiceInterface_GetStatistics_Call {
+func (_c *MockStatsServiceInterface_GetStatistics_Call) Run(run func(ctx context.Context, period stats.Period)) *MockStatsServiceInterface_GetStatistics_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context), args[1].(stats.Period))
 	})
 	return _c
 }
@@ -72,7 +197,185 @@ func (_c *MockStatsServiceInterface_GetStatistics_Call) Return(_a0 *service.Stat
 	return _c
 }
 
-func (_c *MockStatsServiceInterface_GetStatistics_Call) RunAndReturn(run func() (*service.Statistics, error)) *MockStatsServiceInterface_GetStatistics_Call {
+func (_c *MockStatsServiceInterface_GetStatistics_Call) RunAndReturn(run func(context.Context, stats.Period) (*service.Statistics, error)) *MockStatsServiceInterface_GetStatistics_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetThroughput provides a mock function with given fields: ctx, bucket, period, teamName
+func (_m *MockStatsServiceInterface) GetThroughput(ctx context.Context, bucket stats.BucketSize, period stats.Period, teamName string) (*service.Throughput, error) {
+	ret := _m.Called(ctx, bucket, period, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetThroughput")
+	}
+
+	var r0 *service.Throughput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, stats.BucketSize, stats.Period, string) (*service.Throughput, error)); ok {
+		return rf(ctx, bucket, period, teamName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, stats.BucketSize, stats.Period, string) *service.Throughput); ok {
+		r0 = rf(ctx, bucket, period, teamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.Throughput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, stats.BucketSize, stats.Period, string) error); ok {
+		r1 = rf(ctx, bucket, period, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStatsServiceInterface_GetThroughput_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetThroughput'
+type MockStatsServiceInterface_GetThroughput_Call struct {
+	*mock.Call
+}
+
+// GetThroughput is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bucket stats.BucketSize
+//   - period stats.Period
+//   - teamName string
+func (_e *MockStatsServiceInterface_Expecter) GetThroughput(ctx interface{}, bucket interface{}, period interface{}, teamName interface{}) *MockStatsServiceInterface_GetThroughput_Call {
+	return &MockStatsServiceInterface_GetThroughput_Call{Call: _e.mock.On("GetThroughput", ctx, bucket, period, teamName)}
+}
+
+func (_c *MockStatsServiceInterface_GetThroughput_Call) Run(run func(ctx context.Context, bucket stats.BucketSize, period stats.Period, teamName string)) *MockStatsServiceInterface_GetThroughput_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(stats.BucketSize), args[2].(stats.Period), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockStatsServiceInterface_GetThroughput_Call) Return(_a0 *service.Throughput, _a1 error) *MockStatsServiceInterface_GetThroughput_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStatsServiceInterface_GetThroughput_Call) RunAndReturn(run func(context.Context, stats.BucketSize, stats.Period, string) (*service.Throughput, error)) *MockStatsServiceInterface_GetThroughput_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserLoad provides a mock function with given fields: ctx
+func (_m *MockStatsServiceInterface) GetUserLoad(ctx context.Context) ([]stats.UserLoad, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserLoad")
+	}
+
+	var r0 []stats.UserLoad
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]stats.UserLoad, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []stats.UserLoad); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]stats.UserLoad)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStatsServiceInterface_GetUserLoad_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserLoad'
+type MockStatsServiceInterface_GetUserLoad_Call struct {
+	*mock.Call
+}
+
+// GetUserLoad is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStatsServiceInterface_Expecter) GetUserLoad(ctx interface{}) *MockStatsServiceInterface_GetUserLoad_Call {
+	return &MockStatsServiceInterface_GetUserLoad_Call{Call: _e.mock.On("GetUserLoad", ctx)}
+}
+
+func (_c *MockStatsServiceInterface_GetUserLoad_Call) Run(run func(ctx context.Context)) *MockStatsServiceInterface_GetUserLoad_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockStatsServiceInterface_GetUserLoad_Call) Return(_a0 []stats.UserLoad, _a1 error) *MockStatsServiceInterface_GetUserLoad_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStatsServiceInterface_GetUserLoad_Call) RunAndReturn(run func(context.Context) ([]stats.UserLoad, error)) *MockStatsServiceInterface_GetUserLoad_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserStatistics provides a mock function with given fields: ctx, userID
+func (_m *MockStatsServiceInterface) GetUserStatistics(ctx context.Context, userID string) (*service.UserStatistics, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserStatistics")
+	}
+
+	var r0 *service.UserStatistics
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*service.UserStatistics, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *service.UserStatistics); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.UserStatistics)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStatsServiceInterface_GetUserStatistics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserStatistics'
+type MockStatsServiceInterface_GetUserStatistics_Call struct {
+	*mock.Call
+}
+
+// GetUserStatistics is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockStatsServiceInterface_Expecter) GetUserStatistics(ctx interface{}, userID interface{}) *MockStatsServiceInterface_GetUserStatistics_Call {
+	return &MockStatsServiceInterface_GetUserStatistics_Call{Call: _e.mock.On("GetUserStatistics", ctx, userID)}
+}
+
+func (_c *MockStatsServiceInterface_GetUserStatistics_Call) Run(run func(ctx context.Context, userID string)) *MockStatsServiceInterface_GetUserStatistics_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStatsServiceInterface_GetUserStatistics_Call) Return(_a0 *service.UserStatistics, _a1 error) *MockStatsServiceInterface_GetUserStatistics_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStatsServiceInterface_GetUserStatistics_Call) RunAndReturn(run func(context.Context, string) (*service.UserStatistics, error)) *MockStatsServiceInterface_GetUserStatistics_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -3,9 +3,15 @@
 package mocks
 
 import (
+	context "context"
+
 	domain "github.com/mishasvintus/avito_backend_internship/internal/domain"
 
+	io "io"
+
 	mock "github.com/stretchr/testify/mock"
+
+	service "github.com/mishasvintus/avito_backend_internship/internal/service"
 )
 
 // MockTeamServiceInterface is an autogenerated mock type for the TeamServiceInterface type
@@ -21,22 +27,41 @@ func (_m *MockTeamServiceInterface) EXPECT() *MockTeamServiceInterface_Expecter
 	return &MockTeamServiceInterface_Expecter{mock: &_m.Mock}
 }
 
-// CreateTeam provides a mock function with given fields: teamName, members
-func (_m *MockTeamServiceInterface) CreateTeam(teamName string, members []domain.TeamMember) error {
-	ret := _m.Called(teamName, members)
+// CreateTeam provides a mock function with given fields: ctx, team, opts
+func (_m *MockTeamServiceInterface) CreateTeam(ctx context.Context, team *domain.Team, opts service.CreateTeamOptions) (*domain.Team, service.TeamOutcome, error) {
+	ret := _m.Called(ctx, team, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateTeam")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string, []domain.TeamMember) error); ok {
-		r0 = rf(teamName, members)
+	var r0 *domain.Team
+	var r1 service.TeamOutcome
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Team, service.CreateTeamOptions) (*domain.Team, service.TeamOutcome, error)); ok {
+		return rf(ctx, team, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Team, service.CreateTeamOptions) *domain.Team); ok {
+		r0 = rf(ctx, team, opts)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Team)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Team, service.CreateTeamOptions) service.TeamOutcome); ok {
+		r1 = rf(ctx, team, opts)
+	} else {
+		r1 = ret.Get(1).(service.TeamOutcome)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *domain.Team, service.CreateTeamOptions) error); ok {
+		r2 = rf(ctx, team, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
 // MockTeamServiceInterface_CreateTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTeam'
@@ -45,40 +70,41 @@ type MockTeamServiceInterface_CreateTeam_Call struct {
 }
 
 // CreateTeam is a helper method to define mock.On call
-//   - teamName string
-//   - members []domain.TeamMember
-func (_e *MockTeamServiceInterface_Expecter) CreateTeam(teamName interface{}, members interface{}) *MockTeamServiceInterface_CreateTeam_Call {
-	return &MockTeamServiceInterface_CreateTeam_Call{Call: _e.mock.On("CreateTeam", teamName, members)}
+//   - ctx context.Context
+//   - team *domain.Team
+//   - opts service.CreateTeamOptions
+func (_e *MockTeamServiceInterface_Expecter) CreateTeam(ctx interface{}, team interface{}, opts interface{}) *MockTeamServiceInterface_CreateTeam_Call {
+	return &MockTeamServiceInterface_CreateTeam_Call{Call: _e.mock.On("CreateTeam", ctx, team, opts)}
 }
 
-func (_c *MockTeamServiceInterface_CreateTeam_Call) Run(run func(teamName string, members []domain.TeamMember)) *MockTeamServiceInterface_CreateTeam_Call {
+func (_c *MockTeamServiceInterface_CreateTeam_Call) Run(run func(ctx context.Context, team *domain.Team, opts service.CreateTeamOptions)) *MockTeamServiceInterface_CreateTeam_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].([]domain.TeamMember))
+		run(args[0].(context.Context), args[1].(*domain.Team), args[2].(service.CreateTeamOptions))
 	})
 	return _c
 }
 
-func (_c *MockTeamServiceInterface_CreateTeam_Call) Return(_a0 error) *MockTeamServiceInterface_CreateTeam_Call {
-	_c.Call.Return(_a0)
+func (_c *MockTeamServiceInterface_CreateTeam_Call) Return(_a0 *domain.Team, _a1 service.TeamOutcome, _a2 error) *MockTeamServiceInterface_CreateTeam_Call {
+	_c.Call.Return(_a0, _a1, _a2)
 	return _c
 }
 
-func (_c *MockTeamServiceInterface_CreateTeam_Call) RunAndReturn(run func(string, []domain.TeamMember) error) *MockTeamServiceInterface_CreateTeam_Call {
+func (_c *MockTeamServiceInterface_CreateTeam_Call) RunAndReturn(run func(context.Context, *domain.Team, service.CreateTeamOptions) (*domain.Team, service.TeamOutcome, error)) *MockTeamServiceInterface_CreateTeam_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeactivateTeam provides a mock function with given fields: teamName
-func (_m *MockTeamServiceInterface) DeactivateTeam(teamName string) error {
-	ret := _m.Called(teamName)
+// DeactivateTeam provides a mock function with given fields: ctx, teamName
+func (_m *MockTeamServiceInterface) DeactivateTeam(ctx context.Context, teamName string) error {
+	ret := _m.Called(ctx, teamName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeactivateTeam")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(teamName)
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, teamName)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -92,14 +118,15 @@ type MockTeamServiceInterface_DeactivateTeam_Call struct {
 }
 
 // DeactivateTeam is a helper method to define mock.On call
+//   - ctx context.Context
 //   - teamName string
-func (_e *MockTeamServiceInterface_Expecter) DeactivateTeam(teamName interface{}) *MockTeamServiceInterface_DeactivateTeam_Call {
-	return &MockTeamServiceInterface_DeactivateTeam_Call{Call: _e.mock.On("DeactivateTeam", teamName)}
+func (_e *MockTeamServiceInterface_Expecter) DeactivateTeam(ctx interface{}, teamName interface{}) *MockTeamServiceInterface_DeactivateTeam_Call {
+	return &MockTeamServiceInterface_DeactivateTeam_Call{Call: _e.mock.On("DeactivateTeam", ctx, teamName)}
 }
 
-func (_c *MockTeamServiceInterface_DeactivateTeam_Call) Run(run func(teamName string)) *MockTeamServiceInterface_DeactivateTeam_Call {
+func (_c *MockTeamServiceInterface_DeactivateTeam_Call) Run(run func(ctx context.Context, teamName string)) *MockTeamServiceInterface_DeactivateTeam_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -109,14 +136,168 @@ func (_c *MockTeamServiceInterface_DeactivateTeam_Call) Return(_a0 error) *MockT
 	return _c
 }
 
-func (_c *MockTeamServiceInterface_DeactivateTeam_Call) RunAndReturn(run func(string) error) *MockTeamServiceInterface_DeactivateTeam_Call {
+func (_c *MockTeamServiceInterface_DeactivateTeam_Call) RunAndReturn(run func(context.Context, string) error) *MockTeamServiceInterface_DeactivateTeam_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteDigestSchedule provides a mock function with given fields: ctx, teamName
+func (_m *MockTeamServiceInterface) DeleteDigestSchedule(ctx context.Context, teamName string) error {
+	ret := _m.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDigestSchedule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, teamName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTeamServiceInterface_DeleteDigestSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteDigestSchedule'
+type MockTeamServiceInterface_DeleteDigestSchedule_Call struct {
+	*mock.Call
+}
+
+// DeleteDigestSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+func (_e *MockTeamServiceInterface_Expecter) DeleteDigestSchedule(ctx interface{}, teamName interface{}) *MockTeamServiceInterface_DeleteDigestSchedule_Call {
+	return &MockTeamServiceInterface_DeleteDigestSchedule_Call{Call: _e.mock.On("DeleteDigestSchedule", ctx, teamName)}
+}
+
+func (_c *MockTeamServiceInterface_DeleteDigestSchedule_Call) Run(run func(ctx context.Context, teamName string)) *MockTeamServiceInterface_DeleteDigestSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockTeamServiceInterface_DeleteDigestSchedule_Call) Return(_a0 error) *MockTeamServiceInterface_DeleteDigestSchedule_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTeamServiceInterface_DeleteDigestSchedule_Call) RunAndReturn(run func(context.Context, string) error) *MockTeamServiceInterface_DeleteDigestSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteOwnershipRule provides a mock function with given fields: ctx, teamName, pathPrefix
+func (_m *MockTeamServiceInterface) DeleteOwnershipRule(ctx context.Context, teamName string, pathPrefix string) error {
+	ret := _m.Called(ctx, teamName, pathPrefix)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteOwnershipRule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, teamName, pathPrefix)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTeamServiceInterface_DeleteOwnershipRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteOwnershipRule'
+type MockTeamServiceInterface_DeleteOwnershipRule_Call struct {
+	*mock.Call
+}
+
+// DeleteOwnershipRule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - pathPrefix string
+func (_e *MockTeamServiceInterface_Expecter) DeleteOwnershipRule(ctx interface{}, teamName interface{}, pathPrefix interface{}) *MockTeamServiceInterface_DeleteOwnershipRule_Call {
+	return &MockTeamServiceInterface_DeleteOwnershipRule_Call{Call: _e.mock.On("DeleteOwnershipRule", ctx, teamName, pathPrefix)}
+}
+
+func (_c *MockTeamServiceInterface_DeleteOwnershipRule_Call) Run(run func(ctx context.Context, teamName string, pathPrefix string)) *MockTeamServiceInterface_DeleteOwnershipRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockTeamServiceInterface_DeleteOwnershipRule_Call) Return(_a0 error) *MockTeamServiceInterface_DeleteOwnershipRule_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTeamServiceInterface_DeleteOwnershipRule_Call) RunAndReturn(run func(context.Context, string, string) error) *MockTeamServiceInterface_DeleteOwnershipRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDigestSchedule provides a mock function with given fields: ctx, teamName
+func (_m *MockTeamServiceInterface) GetDigestSchedule(ctx context.Context, teamName string) (*domain.DigestSchedule, error) {
+	ret := _m.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDigestSchedule")
+	}
+
+	var r0 *domain.DigestSchedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.DigestSchedule, error)); ok {
+		return rf(ctx, teamName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.DigestSchedule); ok {
+		r0 = rf(ctx, teamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.DigestSchedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTeamServiceInterface_GetDigestSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDigestSchedule'
+type MockTeamServiceInterface_GetDigestSchedule_Call struct {
+	*mock.Call
+}
+
+// GetDigestSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+func (_e *MockTeamServiceInterface_Expecter) GetDigestSchedule(ctx interface{}, teamName interface{}) *MockTeamServiceInterface_GetDigestSchedule_Call {
+	return &MockTeamServiceInterface_GetDigestSchedule_Call{Call: _e.mock.On("GetDigestSchedule", ctx, teamName)}
+}
+
+func (_c *MockTeamServiceInterface_GetDigestSchedule_Call) Run(run func(ctx context.Context, teamName string)) *MockTeamServiceInterface_GetDigestSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockTeamServiceInterface_GetDigestSchedule_Call) Return(_a0 *domain.DigestSchedule, _a1 error) *MockTeamServiceInterface_GetDigestSchedule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTeamServiceInterface_GetDigestSchedule_Call) RunAndReturn(run func(context.Context, string) (*domain.DigestSchedule, error)) *MockTeamServiceInterface_GetDigestSchedule_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTeam provides a mock function with given fields: teamName
-func (_m *MockTeamServiceInterface) GetTeam(teamName string) (*domain.Team, error) {
-	ret := _m.Called(teamName)
+// GetTeam provides a mock function with given fields: ctx, teamName
+func (_m *MockTeamServiceInterface) GetTeam(ctx context.Context, teamName string) (*domain.Team, error) {
+	ret := _m.Called(ctx, teamName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTeam")
@@ -124,19 +305,19 @@ func (_m *MockTeamServiceInterface) GetTeam(teamName string) (*domain.Team, erro
 
 	var r0 *domain.Team
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*domain.Team, error)); ok {
-		return rf(teamName)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Team, error)); ok {
+		return rf(ctx, teamName)
 	}
-	if rf, ok := ret.Get(0).(func(string) *domain.Team); ok {
-		r0 = rf(teamName)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Team); ok {
+		r0 = rf(ctx, teamName)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Team)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(teamName)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, teamName)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -150,14 +331,15 @@ type MockTeamServiceInterface_GetTeam_Call struct {
 }
 
 // GetTeam is a helper method to define mock.On call
+//   - ctx context.Context
 //   - teamName string
-func (_e *MockTeamServiceInterface_Expecter) GetTeam(teamName interface{}) *MockTeamServiceInterface_GetTeam_Call {
-	return &MockTeamServiceInterface_GetTeam_Call{Call: _e.mock.On("GetTeam", teamName)}
+func (_e *MockTeamServiceInterface_Expecter) GetTeam(ctx interface{}, teamName interface{}) *MockTeamServiceInterface_GetTeam_Call {
+	return &MockTeamServiceInterface_GetTeam_Call{Call: _e.mock.On("GetTeam", ctx, teamName)}
 }
 
-func (_c *MockTeamServiceInterface_GetTeam_Call) Run(run func(teamName string)) *MockTeamServiceInterface_GetTeam_Call {
+func (_c *MockTeamServiceInterface_GetTeam_Call) Run(run func(ctx context.Context, teamName string)) *MockTeamServiceInterface_GetTeam_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -167,7 +349,543 @@ func (_c *MockTeamServiceInterface_GetTeam_Call) Return(_a0 *domain.Team, _a1 er
 	return _c
 }
 
-func (_c *MockTeamServiceInterface_GetTeam_Call) RunAndReturn(run func(string) (*domain.Team, error)) *MockTeamServiceInterface_GetTeam_Call {
+func (_c *MockTeamServiceInterface_GetTeam_Call) RunAndReturn(run func(context.Context, string) (*domain.Team, error)) *MockTeamServiceInterface_GetTeam_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTeamSettings provides a mock function with given fields: ctx, teamName
+func (_m *MockTeamServiceInterface) GetTeamSettings(ctx context.Context, teamName string) (*domain.TeamSettings, error) {
+	ret := _m.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTeamSettings")
+	}
+
+	var r0 *domain.TeamSettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.TeamSettings, error)); ok {
+		return rf(ctx, teamName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.TeamSettings); ok {
+		r0 = rf(ctx, teamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TeamSettings)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTeamServiceInterface_GetTeamSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamSettings'
+type MockTeamServiceInterface_GetTeamSettings_Call struct {
+	*mock.Call
+}
+
+// GetTeamSettings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+func (_e *MockTeamServiceInterface_Expecter) GetTeamSettings(ctx interface{}, teamName interface{}) *MockTeamServiceInterface_GetTeamSettings_Call {
+	return &MockTeamServiceInterface_GetTeamSettings_Call{Call: _e.mock.On("GetTeamSettings", ctx, teamName)}
+}
+
+func (_c *MockTeamServiceInterface_GetTeamSettings_Call) Run(run func(ctx context.Context, teamName string)) *MockTeamServiceInterface_GetTeamSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockTeamServiceInterface_GetTeamSettings_Call) Return(_a0 *domain.TeamSettings, _a1 error) *MockTeamServiceInterface_GetTeamSettings_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTeamServiceInterface_GetTeamSettings_Call) RunAndReturn(run func(context.Context, string) (*domain.TeamSettings, error)) *MockTeamServiceInterface_GetTeamSettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTeamWorkload provides a mock function with given fields: ctx, teamName
+func (_m *MockTeamServiceInterface) GetTeamWorkload(ctx context.Context, teamName string) (*domain.TeamWorkload, error) {
+	ret := _m.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTeamWorkload")
+	}
+
+	var r0 *domain.TeamWorkload
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.TeamWorkload, error)); ok {
+		return rf(ctx, teamName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.TeamWorkload); ok {
+		r0 = rf(ctx, teamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TeamWorkload)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTeamServiceInterface_GetTeamWorkload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamWorkload'
+type MockTeamServiceInterface_GetTeamWorkload_Call struct {
+	*mock.Call
+}
+
+// GetTeamWorkload is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+func (_e *MockTeamServiceInterface_Expecter) GetTeamWorkload(ctx interface{}, teamName interface{}) *MockTeamServiceInterface_GetTeamWorkload_Call {
+	return &MockTeamServiceInterface_GetTeamWorkload_Call{Call: _e.mock.On("GetTeamWorkload", ctx, teamName)}
+}
+
+func (_c *MockTeamServiceInterface_GetTeamWorkload_Call) Run(run func(ctx context.Context, teamName string)) *MockTeamServiceInterface_GetTeamWorkload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockTeamServiceInterface_GetTeamWorkload_Call) Return(_a0 *domain.TeamWorkload, _a1 error) *MockTeamServiceInterface_GetTeamWorkload_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTeamServiceInterface_GetTeamWorkload_Call) RunAndReturn(run func(context.Context, string) (*domain.TeamWorkload, error)) *MockTeamServiceInterface_GetTeamWorkload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ImportTeams provides a mock function with given fields: ctx, r
+func (_m *MockTeamServiceInterface) ImportTeams(ctx context.Context, r io.Reader) (*service.ImportSummary, error) {
+	ret := _m.Called(ctx, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportTeams")
+	}
+
+	var r0 *service.ImportSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, io.Reader) (*service.ImportSummary, error)); ok {
+		return rf(ctx, r)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, io.Reader) *service.ImportSummary); ok {
+		r0 = rf(ctx, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.ImportSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, io.Reader) error); ok {
+		r1 = rf(ctx, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTeamServiceInterface_ImportTeams_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportTeams'
+type MockTeamServiceInterface_ImportTeams_Call struct {
+	*mock.Call
+}
+
+// ImportTeams is a helper method to define mock.On call
+//   - ctx context.Context
+//   - r io.Reader
+func (_e *MockTeamServiceInterface_Expecter) ImportTeams(ctx interface{}, r interface{}) *MockTeamServiceInterface_ImportTeams_Call {
+	return &MockTeamServiceInterface_ImportTeams_Call{Call: _e.mock.On("ImportTeams", ctx, r)}
+}
+
+func (_c *MockTeamServiceInterface_ImportTeams_Call) Run(run func(ctx context.Context, r io.Reader)) *MockTeamServiceInterface_ImportTeams_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(io.Reader))
+	})
+	return _c
+}
+
+func (_c *MockTeamServiceInterface_ImportTeams_Call) Return(_a0 *service.ImportSummary, _a1 error) *MockTeamServiceInterface_ImportTeams_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTeamServiceInterface_ImportTeams_Call) RunAndReturn(run func(context.Context, io.Reader) (*service.ImportSummary, error)) *MockTeamServiceInterface_ImportTeams_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOwnershipRules provides a mock function with given fields: ctx, teamName
+func (_m *MockTeamServiceInterface) ListOwnershipRules(ctx context.Context, teamName string) ([]domain.OwnershipRule, error) {
+	ret := _m.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOwnershipRules")
+	}
+
+	var r0 []domain.OwnershipRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.OwnershipRule, error)); ok {
+		return rf(ctx, teamName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.OwnershipRule); ok {
+		r0 = rf(ctx, teamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.OwnershipRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTeamServiceInterface_ListOwnershipRules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOwnershipRules'
+type MockTeamServiceInterface_ListOwnershipRules_Call struct {
+	*mock.Call
+}
+
+// ListOwnershipRules is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+func (_e *MockTeamServiceInterface_Expecter) ListOwnershipRules(ctx interface{}, teamName interface{}) *MockTeamServiceInterface_ListOwnershipRules_Call {
+	return &MockTeamServiceInterface_ListOwnershipRules_Call{Call: _e.mock.On("ListOwnershipRules", ctx, teamName)}
+}
+
+func (_c *MockTeamServiceInterface_ListOwnershipRules_Call) Run(run func(ctx context.Context, teamName string)) *MockTeamServiceInterface_ListOwnershipRules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockTeamServiceInterface_ListOwnershipRules_Call) Return(_a0 []domain.OwnershipRule, _a1 error) *MockTeamServiceInterface_ListOwnershipRules_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTeamServiceInterface_ListOwnershipRules_Call) RunAndReturn(run func(context.Context, string) ([]domain.OwnershipRule, error)) *MockTeamServiceInterface_ListOwnershipRules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RebalanceTeam provides a mock function with given fields: ctx, teamName, opts
+func (_m *MockTeamServiceInterface) RebalanceTeam(ctx context.Context, teamName string, opts service.RebalanceOptions) ([]service.RebalanceMove, error) {
+	ret := _m.Called(ctx, teamName, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RebalanceTeam")
+	}
+
+	var r0 []service.RebalanceMove
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, service.RebalanceOptions) ([]service.RebalanceMove, error)); ok {
+		return rf(ctx, teamName, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, service.RebalanceOptions) []service.RebalanceMove); ok {
+		r0 = rf(ctx, teamName, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]service.RebalanceMove)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, service.RebalanceOptions) error); ok {
+		r1 = rf(ctx, teamName, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTeamServiceInterface_RebalanceTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RebalanceTeam'
+type MockTeamServiceInterface_RebalanceTeam_Call struct {
+	*mock.Call
+}
+
+// RebalanceTeam is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - opts service.RebalanceOptions
+func (_e *MockTeamServiceInterface_Expecter) RebalanceTeam(ctx interface{}, teamName interface{}, opts interface{}) *MockTeamServiceInterface_RebalanceTeam_Call {
+	return &MockTeamServiceInterface_RebalanceTeam_Call{Call: _e.mock.On("RebalanceTeam", ctx, teamName, opts)}
+}
+
+func (_c *MockTeamServiceInterface_RebalanceTeam_Call) Run(run func(ctx context.Context, teamName string, opts service.RebalanceOptions)) *MockTeamServiceInterface_RebalanceTeam_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(service.RebalanceOptions))
+	})
+	return _c
+}
+
+func (_c *MockTeamServiceInterface_RebalanceTeam_Call) Return(_a0 []service.RebalanceMove, _a1 error) *MockTeamServiceInterface_RebalanceTeam_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTeamServiceInterface_RebalanceTeam_Call) RunAndReturn(run func(context.Context, string, service.RebalanceOptions) ([]service.RebalanceMove, error)) *MockTeamServiceInterface_RebalanceTeam_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RenameTeam provides a mock function with given fields: ctx, oldName, newName
+func (_m *MockTeamServiceInterface) RenameTeam(ctx context.Context, oldName string, newName string) (*domain.Team, error) {
+	ret := _m.Called(ctx, oldName, newName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenameTeam")
+	}
+
+	var r0 *domain.Team
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.Team, error)); ok {
+		return rf(ctx, oldName, newName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.Team); ok {
+		r0 = rf(ctx, oldName, newName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Team)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, oldName, newName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTeamServiceInterface_RenameTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenameTeam'
+type MockTeamServiceInterface_RenameTeam_Call struct {
+	*mock.Call
+}
+
+// RenameTeam is a helper method to define mock.On call
+//   - ctx context.Context
+//   - oldName string
+//   - newName string
+func (_e *MockTeamServiceInterface_Expecter) RenameTeam(ctx interface{}, oldName interface{}, newName interface{}) *MockTeamServiceInterface_RenameTeam_Call {
+	return &MockTeamServiceInterface_RenameTeam_Call{Call: _e.mock.On("RenameTeam", ctx, oldName, newName)}
+}
+
+func (_c *MockTeamServiceInterface_RenameTeam_Call) Run(run func(ctx context.Context, oldName string, newName string)) *MockTeamServiceInterface_RenameTeam_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockTeamServiceInterface_RenameTeam_Call) Return(_a0 *domain.Team, _a1 error) *MockTeamServiceInterface_RenameTeam_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTeamServiceInterface_RenameTeam_Call) RunAndReturn(run func(context.Context, string, string) (*domain.Team, error)) *MockTeamServiceInterface_RenameTeam_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetDigestSchedule provides a mock function with given fields: ctx, teamName, hour, timezone
+func (_m *MockTeamServiceInterface) SetDigestSchedule(ctx context.Context, teamName string, hour int, timezone string) (*domain.DigestSchedule, error) {
+	ret := _m.Called(ctx, teamName, hour, timezone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDigestSchedule")
+	}
+
+	var r0 *domain.DigestSchedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, string) (*domain.DigestSchedule, error)); ok {
+		return rf(ctx, teamName, hour, timezone)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, string) *domain.DigestSchedule); ok {
+		r0 = rf(ctx, teamName, hour, timezone)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.DigestSchedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, string) error); ok {
+		r1 = rf(ctx, teamName, hour, timezone)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTeamServiceInterface_SetDigestSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetDigestSchedule'
+type MockTeamServiceInterface_SetDigestSchedule_Call struct {
+	*mock.Call
+}
+
+// SetDigestSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - hour int
+//   - timezone string
+func (_e *MockTeamServiceInterface_Expecter) SetDigestSchedule(ctx interface{}, teamName interface{}, hour interface{}, timezone interface{}) *MockTeamServiceInterface_SetDigestSchedule_Call {
+	return &MockTeamServiceInterface_SetDigestSchedule_Call{Call: _e.mock.On("SetDigestSchedule", ctx, teamName, hour, timezone)}
+}
+
+func (_c *MockTeamServiceInterface_SetDigestSchedule_Call) Run(run func(ctx context.Context, teamName string, hour int, timezone string)) *MockTeamServiceInterface_SetDigestSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockTeamServiceInterface_SetDigestSchedule_Call) Return(_a0 *domain.DigestSchedule, _a1 error) *MockTeamServiceInterface_SetDigestSchedule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTeamServiceInterface_SetDigestSchedule_Call) RunAndReturn(run func(context.Context, string, int, string) (*domain.DigestSchedule, error)) *MockTeamServiceInterface_SetDigestSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetOwnershipRule provides a mock function with given fields: ctx, rule
+func (_m *MockTeamServiceInterface) SetOwnershipRule(ctx context.Context, rule domain.OwnershipRule) (*domain.OwnershipRule, error) {
+	ret := _m.Called(ctx, rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetOwnershipRule")
+	}
+
+	var r0 *domain.OwnershipRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.OwnershipRule) (*domain.OwnershipRule, error)); ok {
+		return rf(ctx, rule)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.OwnershipRule) *domain.OwnershipRule); ok {
+		r0 = rf(ctx, rule)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.OwnershipRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.OwnershipRule) error); ok {
+		r1 = rf(ctx, rule)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTeamServiceInterface_SetOwnershipRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetOwnershipRule'
+type MockTeamServiceInterface_SetOwnershipRule_Call struct {
+	*mock.Call
+}
+
+// SetOwnershipRule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - rule domain.OwnershipRule
+func (_e *MockTeamServiceInterface_Expecter) SetOwnershipRule(ctx interface{}, rule interface{}) *MockTeamServiceInterface_SetOwnershipRule_Call {
+	return &MockTeamServiceInterface_SetOwnershipRule_Call{Call: _e.mock.On("SetOwnershipRule", ctx, rule)}
+}
+
+func (_c *MockTeamServiceInterface_SetOwnershipRule_Call) Run(run func(ctx context.Context, rule domain.OwnershipRule)) *MockTeamServiceInterface_SetOwnershipRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.OwnershipRule))
+	})
+	return _c
+}
+
+func (_c *MockTeamServiceInterface_SetOwnershipRule_Call) Return(_a0 *domain.OwnershipRule, _a1 error) *MockTeamServiceInterface_SetOwnershipRule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTeamServiceInterface_SetOwnershipRule_Call) RunAndReturn(run func(context.Context, domain.OwnershipRule) (*domain.OwnershipRule, error)) *MockTeamServiceInterface_SetOwnershipRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTeam provides a mock function with given fields: ctx, teamName, update
+func (_m *MockTeamServiceInterface) UpdateTeam(ctx context.Context, teamName string, update service.TeamUpdate) (*domain.Team, error) {
+	ret := _m.Called(ctx, teamName, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTeam")
+	}
+
+	var r0 *domain.Team
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, service.TeamUpdate) (*domain.Team, error)); ok {
+		return rf(ctx, teamName, update)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, service.TeamUpdate) *domain.Team); ok {
+		r0 = rf(ctx, teamName, update)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Team)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, service.TeamUpdate) error); ok {
+		r1 = rf(ctx, teamName, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTeamServiceInterface_UpdateTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTeam'
+type MockTeamServiceInterface_UpdateTeam_Call struct {
+	*mock.Call
+}
+
+// UpdateTeam is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - update service.TeamUpdate
+func (_e *MockTeamServiceInterface_Expecter) UpdateTeam(ctx interface{}, teamName interface{}, update interface{}) *MockTeamServiceInterface_UpdateTeam_Call {
+	return &MockTeamServiceInterface_UpdateTeam_Call{Call: _e.mock.On("UpdateTeam", ctx, teamName, update)}
+}
+
+func (_c *MockTeamServiceInterface_UpdateTeam_Call) Run(run func(ctx context.Context, teamName string, update service.TeamUpdate)) *MockTeamServiceInterface_UpdateTeam_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(service.TeamUpdate))
+	})
+	return _c
+}
+
+func (_c *MockTeamServiceInterface_UpdateTeam_Call) Return(_a0 *domain.Team, _a1 error) *MockTeamServiceInterface_UpdateTeam_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTeamServiceInterface_UpdateTeam_Call) RunAndReturn(run func(context.Context, string, service.TeamUpdate) (*domain.Team, error)) *MockTeamServiceInterface_UpdateTeam_Call {
 	_c.Call.Return(run)
 	return _c
 }
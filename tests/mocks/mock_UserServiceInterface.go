@@ -3,9 +3,19 @@
 package mocks
 
 import (
+	context "context"
+
 	domain "github.com/mishasvintus/avito_backend_internship/internal/domain"
 
 	mock "github.com/stretchr/testify/mock"
+
+	pagination "github.com/mishasvintus/avito_backend_internship/internal/pagination"
+
+	pr "github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+
+	service "github.com/mishasvintus/avito_backend_internship/internal/service"
+
+	time "time"
 )
 
 // MockUserServiceInterface is an autogenerated mock type for the UserServiceInterface type
@@ -21,9 +31,231 @@ func (_m *MockUserServiceInterface) EXPECT() *MockUserServiceInterface_Expecter
 	return &MockUserServiceInterface_Expecter{mock: &_m.Mock}
 }
 
-// GetUserReviews provides a mock function with given fields: userID
-func (_m *MockUserServiceInterface) GetUserReviews(userID string) ([]domain.PullRequestShort, error) {
-	ret := _m.Called(userID)
+// AddExclusion provides a mock function with given fields: ctx, exclusion
+func (_m *MockUserServiceInterface) AddExclusion(ctx context.Context, exclusion domain.Exclusion) error {
+	ret := _m.Called(ctx, exclusion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddExclusion")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Exclusion) error); ok {
+		r0 = rf(ctx, exclusion)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUserServiceInterface_AddExclusion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddExclusion'
+type MockUserServiceInterface_AddExclusion_Call struct {
+	*mock.Call
+}
+
+// AddExclusion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - exclusion domain.Exclusion
+func (_e *MockUserServiceInterface_Expecter) AddExclusion(ctx interface{}, exclusion interface{}) *MockUserServiceInterface_AddExclusion_Call {
+	return &MockUserServiceInterface_AddExclusion_Call{Call: _e.mock.On("AddExclusion", ctx, exclusion)}
+}
+
+func (_c *MockUserServiceInterface_AddExclusion_Call) Run(run func(ctx context.Context, exclusion domain.Exclusion)) *MockUserServiceInterface_AddExclusion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Exclusion))
+	})
+	return _c
+}
+
+func (_c *MockUserServiceInterface_AddExclusion_Call) Return(_a0 error) *MockUserServiceInterface_AddExclusion_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUserServiceInterface_AddExclusion_Call) RunAndReturn(run func(context.Context, domain.Exclusion) error) *MockUserServiceInterface_AddExclusion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EraseUser provides a mock function with given fields: ctx, userID
+func (_m *MockUserServiceInterface) EraseUser(ctx context.Context, userID string) (*domain.User, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EraseUser")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.User, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserServiceInterface_EraseUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EraseUser'
+type MockUserServiceInterface_EraseUser_Call struct {
+	*mock.Call
+}
+
+// EraseUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockUserServiceInterface_Expecter) EraseUser(ctx interface{}, userID interface{}) *MockUserServiceInterface_EraseUser_Call {
+	return &MockUserServiceInterface_EraseUser_Call{Call: _e.mock.On("EraseUser", ctx, userID)}
+}
+
+func (_c *MockUserServiceInterface_EraseUser_Call) Run(run func(ctx context.Context, userID string)) *MockUserServiceInterface_EraseUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockUserServiceInterface_EraseUser_Call) Return(_a0 *domain.User, _a1 error) *MockUserServiceInterface_EraseUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserServiceInterface_EraseUser_Call) RunAndReturn(run func(context.Context, string) (*domain.User, error)) *MockUserServiceInterface_EraseUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAuthoredPRs provides a mock function with given fields: ctx, userID, filter
+func (_m *MockUserServiceInterface) GetAuthoredPRs(ctx context.Context, userID string, filter pr.AuthoredFilter) (pagination.Page[domain.PullRequest], error) {
+	ret := _m.Called(ctx, userID, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAuthoredPRs")
+	}
+
+	var r0 pagination.Page[domain.PullRequest]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, pr.AuthoredFilter) (pagination.Page[domain.PullRequest], error)); ok {
+		return rf(ctx, userID, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, pr.AuthoredFilter) pagination.Page[domain.PullRequest]); ok {
+		r0 = rf(ctx, userID, filter)
+	} else {
+		r0 = ret.Get(0).(pagination.Page[domain.PullRequest])
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, pr.AuthoredFilter) error); ok {
+		r1 = rf(ctx, userID, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserServiceInterface_GetAuthoredPRs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuthoredPRs'
+type MockUserServiceInterface_GetAuthoredPRs_Call struct {
+	*mock.Call
+}
+
+// GetAuthoredPRs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - filter pr.AuthoredFilter
+func (_e *MockUserServiceInterface_Expecter) GetAuthoredPRs(ctx interface{}, userID interface{}, filter interface{}) *MockUserServiceInterface_GetAuthoredPRs_Call {
+	return &MockUserServiceInterface_GetAuthoredPRs_Call{Call: _e.mock.On("GetAuthoredPRs", ctx, userID, filter)}
+}
+
+func (_c *MockUserServiceInterface_GetAuthoredPRs_Call) Run(run func(ctx context.Context, userID string, filter pr.AuthoredFilter)) *MockUserServiceInterface_GetAuthoredPRs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(pr.AuthoredFilter))
+	})
+	return _c
+}
+
+func (_c *MockUserServiceInterface_GetAuthoredPRs_Call) Return(_a0 pagination.Page[domain.PullRequest], _a1 error) *MockUserServiceInterface_GetAuthoredPRs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserServiceInterface_GetAuthoredPRs_Call) RunAndReturn(run func(context.Context, string, pr.AuthoredFilter) (pagination.Page[domain.PullRequest], error)) *MockUserServiceInterface_GetAuthoredPRs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserReviewCount provides a mock function with given fields: ctx, userID, repositoryName
+func (_m *MockUserServiceInterface) GetUserReviewCount(ctx context.Context, userID string, repositoryName *string) (pr.ReviewCount, error) {
+	ret := _m.Called(ctx, userID, repositoryName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserReviewCount")
+	}
+
+	var r0 pr.ReviewCount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *string) (pr.ReviewCount, error)); ok {
+		return rf(ctx, userID, repositoryName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *string) pr.ReviewCount); ok {
+		r0 = rf(ctx, userID, repositoryName)
+	} else {
+		r0 = ret.Get(0).(pr.ReviewCount)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *string) error); ok {
+		r1 = rf(ctx, userID, repositoryName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserServiceInterface_GetUserReviewCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserReviewCount'
+type MockUserServiceInterface_GetUserReviewCount_Call struct {
+	*mock.Call
+}
+
+// GetUserReviewCount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - repositoryName *string
+func (_e *MockUserServiceInterface_Expecter) GetUserReviewCount(ctx interface{}, userID interface{}, repositoryName interface{}) *MockUserServiceInterface_GetUserReviewCount_Call {
+	return &MockUserServiceInterface_GetUserReviewCount_Call{Call: _e.mock.On("GetUserReviewCount", ctx, userID, repositoryName)}
+}
+
+func (_c *MockUserServiceInterface_GetUserReviewCount_Call) Run(run func(ctx context.Context, userID string, repositoryName *string)) *MockUserServiceInterface_GetUserReviewCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*string))
+	})
+	return _c
+}
+
+func (_c *MockUserServiceInterface_GetUserReviewCount_Call) Return(_a0 pr.ReviewCount, _a1 error) *MockUserServiceInterface_GetUserReviewCount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserServiceInterface_GetUserReviewCount_Call) RunAndReturn(run func(context.Context, string, *string) (pr.ReviewCount, error)) *MockUserServiceInterface_GetUserReviewCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserReviews provides a mock function with given fields: ctx, userID, repositoryName
+func (_m *MockUserServiceInterface) GetUserReviews(ctx context.Context, userID string, repositoryName *string) ([]domain.PullRequestShort, error) {
+	ret := _m.Called(ctx, userID, repositoryName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetUserReviews")
@@ -31,19 +263,19 @@ func (_m *MockUserServiceInterface) GetUserReviews(userID string) ([]domain.Pull
 
 	var r0 []domain.PullRequestShort
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]domain.PullRequestShort, error)); ok {
-		return rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *string) ([]domain.PullRequestShort, error)); ok {
+		return rf(ctx, userID, repositoryName)
 	}
-	if rf, ok := ret.Get(0).(func(string) []domain.PullRequestShort); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *string) []domain.PullRequestShort); ok {
+		r0 = rf(ctx, userID, repositoryName)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]domain.PullRequestShort)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, *string) error); ok {
+		r1 = rf(ctx, userID, repositoryName)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -57,14 +289,16 @@ type MockUserServiceInterface_GetUserReviews_Call struct {
 }
 
 // GetUserReviews is a helper method to define mock.On call
+//   - ctx context.Context
 //   - userID string
-func (_e *MockUserServiceInterface_Expecter) GetUserReviews(userID interface{}) *MockUserServiceInterface_GetUserReviews_Call {
-	return &MockUserServiceInterface_GetUserReviews_Call{Call: _e.mock.On("GetUserReviews", userID)}
+//   - repositoryName *string
+func (_e *MockUserServiceInterface_Expecter) GetUserReviews(ctx interface{}, userID interface{}, repositoryName interface{}) *MockUserServiceInterface_GetUserReviews_Call {
+	return &MockUserServiceInterface_GetUserReviews_Call{Call: _e.mock.On("GetUserReviews", ctx, userID, repositoryName)}
 }
 
-func (_c *MockUserServiceInterface_GetUserReviews_Call) Run(run func(userID string)) *MockUserServiceInterface_GetUserReviews_Call {
+func (_c *MockUserServiceInterface_GetUserReviews_Call) Run(run func(ctx context.Context, userID string, repositoryName *string)) *MockUserServiceInterface_GetUserReviews_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(*string))
 	})
 	return _c
 }
@@ -74,14 +308,289 @@ func (_c *MockUserServiceInterface_GetUserReviews_Call) Return(_a0 []domain.Pull
 	return _c
 }
 
-func (_c *MockUserServiceInterface_GetUserReviews_Call) RunAndReturn(run func(string) ([]domain.PullRequestShort, error)) *MockUserServiceInterface_GetUserReviews_Call {
+func (_c *MockUserServiceInterface_GetUserReviews_Call) RunAndReturn(run func(context.Context, string, *string) ([]domain.PullRequestShort, error)) *MockUserServiceInterface_GetUserReviews_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReassignAll provides a mock function with given fields: ctx, userID, deactivate
+func (_m *MockUserServiceInterface) ReassignAll(ctx context.Context, userID string, deactivate bool) ([]service.ReassignResult, error) {
+	ret := _m.Called(ctx, userID, deactivate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReassignAll")
+	}
+
+	var r0 []service.ReassignResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) ([]service.ReassignResult, error)); ok {
+		return rf(ctx, userID, deactivate)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) []service.ReassignResult); ok {
+		r0 = rf(ctx, userID, deactivate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]service.ReassignResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = rf(ctx, userID, deactivate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserServiceInterface_ReassignAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReassignAll'
+type MockUserServiceInterface_ReassignAll_Call struct {
+	*mock.Call
+}
+
+// ReassignAll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - deactivate bool
+func (_e *MockUserServiceInterface_Expecter) ReassignAll(ctx interface{}, userID interface{}, deactivate interface{}) *MockUserServiceInterface_ReassignAll_Call {
+	return &MockUserServiceInterface_ReassignAll_Call{Call: _e.mock.On("ReassignAll", ctx, userID, deactivate)}
+}
+
+func (_c *MockUserServiceInterface_ReassignAll_Call) Run(run func(ctx context.Context, userID string, deactivate bool)) *MockUserServiceInterface_ReassignAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockUserServiceInterface_ReassignAll_Call) Return(_a0 []service.ReassignResult, _a1 error) *MockUserServiceInterface_ReassignAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserServiceInterface_ReassignAll_Call) RunAndReturn(run func(context.Context, string, bool) ([]service.ReassignResult, error)) *MockUserServiceInterface_ReassignAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveAbsence provides a mock function with given fields: ctx, userID, fromDate
+func (_m *MockUserServiceInterface) RemoveAbsence(ctx context.Context, userID string, fromDate *time.Time) error {
+	ret := _m.Called(ctx, userID, fromDate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveAbsence")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *time.Time) error); ok {
+		r0 = rf(ctx, userID, fromDate)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUserServiceInterface_RemoveAbsence_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveAbsence'
+type MockUserServiceInterface_RemoveAbsence_Call struct {
+	*mock.Call
+}
+
+// RemoveAbsence is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - fromDate *time.Time
+func (_e *MockUserServiceInterface_Expecter) RemoveAbsence(ctx interface{}, userID interface{}, fromDate interface{}) *MockUserServiceInterface_RemoveAbsence_Call {
+	return &MockUserServiceInterface_RemoveAbsence_Call{Call: _e.mock.On("RemoveAbsence", ctx, userID, fromDate)}
+}
+
+func (_c *MockUserServiceInterface_RemoveAbsence_Call) Run(run func(ctx context.Context, userID string, fromDate *time.Time)) *MockUserServiceInterface_RemoveAbsence_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*time.Time))
+	})
+	return _c
+}
+
+func (_c *MockUserServiceInterface_RemoveAbsence_Call) Return(_a0 error) *MockUserServiceInterface_RemoveAbsence_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUserServiceInterface_RemoveAbsence_Call) RunAndReturn(run func(context.Context, string, *time.Time) error) *MockUserServiceInterface_RemoveAbsence_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveExclusion provides a mock function with given fields: ctx, exclusion
+func (_m *MockUserServiceInterface) RemoveExclusion(ctx context.Context, exclusion domain.Exclusion) error {
+	ret := _m.Called(ctx, exclusion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveExclusion")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Exclusion) error); ok {
+		r0 = rf(ctx, exclusion)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUserServiceInterface_RemoveExclusion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveExclusion'
+type MockUserServiceInterface_RemoveExclusion_Call struct {
+	*mock.Call
+}
+
+// RemoveExclusion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - exclusion domain.Exclusion
+func (_e *MockUserServiceInterface_Expecter) RemoveExclusion(ctx interface{}, exclusion interface{}) *MockUserServiceInterface_RemoveExclusion_Call {
+	return &MockUserServiceInterface_RemoveExclusion_Call{Call: _e.mock.On("RemoveExclusion", ctx, exclusion)}
+}
+
+func (_c *MockUserServiceInterface_RemoveExclusion_Call) Run(run func(ctx context.Context, exclusion domain.Exclusion)) *MockUserServiceInterface_RemoveExclusion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Exclusion))
+	})
+	return _c
+}
+
+func (_c *MockUserServiceInterface_RemoveExclusion_Call) Return(_a0 error) *MockUserServiceInterface_RemoveExclusion_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUserServiceInterface_RemoveExclusion_Call) RunAndReturn(run func(context.Context, domain.Exclusion) error) *MockUserServiceInterface_RemoveExclusion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetAbsence provides a mock function with given fields: ctx, absence, reassignOpen
+func (_m *MockUserServiceInterface) SetAbsence(ctx context.Context, absence domain.Absence, reassignOpen bool) ([]service.ReassignResult, error) {
+	ret := _m.Called(ctx, absence, reassignOpen)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetAbsence")
+	}
+
+	var r0 []service.ReassignResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Absence, bool) ([]service.ReassignResult, error)); ok {
+		return rf(ctx, absence, reassignOpen)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Absence, bool) []service.ReassignResult); ok {
+		r0 = rf(ctx, absence, reassignOpen)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]service.ReassignResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Absence, bool) error); ok {
+		r1 = rf(ctx, absence, reassignOpen)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserServiceInterface_SetAbsence_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetAbsence'
+type MockUserServiceInterface_SetAbsence_Call struct {
+	*mock.Call
+}
+
+// SetAbsence is a helper method to define mock.On call
+//   - ctx context.Context
+//   - absence domain.Absence
+//   - reassignOpen bool
+func (_e *MockUserServiceInterface_Expecter) SetAbsence(ctx interface{}, absence interface{}, reassignOpen interface{}) *MockUserServiceInterface_SetAbsence_Call {
+	return &MockUserServiceInterface_SetAbsence_Call{Call: _e.mock.On("SetAbsence", ctx, absence, reassignOpen)}
+}
+
+func (_c *MockUserServiceInterface_SetAbsence_Call) Run(run func(ctx context.Context, absence domain.Absence, reassignOpen bool)) *MockUserServiceInterface_SetAbsence_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Absence), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockUserServiceInterface_SetAbsence_Call) Return(_a0 []service.ReassignResult, _a1 error) *MockUserServiceInterface_SetAbsence_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserServiceInterface_SetAbsence_Call) RunAndReturn(run func(context.Context, domain.Absence, bool) ([]service.ReassignResult, error)) *MockUserServiceInterface_SetAbsence_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetCapacity provides a mock function with given fields: ctx, userID, maxOpenReviews
+func (_m *MockUserServiceInterface) SetCapacity(ctx context.Context, userID string, maxOpenReviews *int) (*domain.User, error) {
+	ret := _m.Called(ctx, userID, maxOpenReviews)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCapacity")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *int) (*domain.User, error)); ok {
+		return rf(ctx, userID, maxOpenReviews)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *int) *domain.User); ok {
+		r0 = rf(ctx, userID, maxOpenReviews)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *int) error); ok {
+		r1 = rf(ctx, userID, maxOpenReviews)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserServiceInterface_SetCapacity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetCapacity'
+type MockUserServiceInterface_SetCapacity_Call struct {
+	*mock.Call
+}
+
+// SetCapacity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - maxOpenReviews *int
+func (_e *MockUserServiceInterface_Expecter) SetCapacity(ctx interface{}, userID interface{}, maxOpenReviews interface{}) *MockUserServiceInterface_SetCapacity_Call {
+	return &MockUserServiceInterface_SetCapacity_Call{Call: _e.mock.On("SetCapacity", ctx, userID, maxOpenReviews)}
+}
+
+func (_c *MockUserServiceInterface_SetCapacity_Call) Run(run func(ctx context.Context, userID string, maxOpenReviews *int)) *MockUserServiceInterface_SetCapacity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*int))
+	})
+	return _c
+}
+
+func (_c *MockUserServiceInterface_SetCapacity_Call) Return(_a0 *domain.User, _a1 error) *MockUserServiceInterface_SetCapacity_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserServiceInterface_SetCapacity_Call) RunAndReturn(run func(context.Context, string, *int) (*domain.User, error)) *MockUserServiceInterface_SetCapacity_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetIsActive provides a mock function with given fields: userID, isActive
-func (_m *MockUserServiceInterface) SetIsActive(userID string, isActive bool) (*domain.User, error) {
-	ret := _m.Called(userID, isActive)
+// SetIsActive provides a mock function with given fields: ctx, userID, isActive
+func (_m *MockUserServiceInterface) SetIsActive(ctx context.Context, userID string, isActive bool) (*domain.User, error) {
+	ret := _m.Called(ctx, userID, isActive)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SetIsActive")
@@ -89,19 +598,19 @@ func (_m *MockUserServiceInterface) SetIsActive(userID string, isActive bool) (*
 
 	var r0 *domain.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, bool) (*domain.User, error)); ok {
-		return rf(userID, isActive)
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) (*domain.User, error)); ok {
+		return rf(ctx, userID, isActive)
 	}
-	if rf, ok := ret.Get(0).(func(string, bool) *domain.User); ok {
-		r0 = rf(userID, isActive)
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) *domain.User); ok {
+		r0 = rf(ctx, userID, isActive)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, bool) error); ok {
-		r1 = rf(userID, isActive)
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = rf(ctx, userID, isActive)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -115,15 +624,16 @@ type MockUserServiceInterface_SetIsActive_Call struct {
 }
 
 // SetIsActive is a helper method to define mock.On call
+//   - ctx context.Context
 //   - userID string
 //   - isActive bool
-func (_e *MockUserServiceInterface_Expecter) SetIsActive(userID interface{}, isActive interface{}) *MockUserServiceInterface_SetIsActive_Call {
-	return &MockUserServiceInterface_SetIsActive_Call{Call: _e.mock.On("SetIsActive", userID, isActive)}
+func (_e *MockUserServiceInterface_Expecter) SetIsActive(ctx interface{}, userID interface{}, isActive interface{}) *MockUserServiceInterface_SetIsActive_Call {
+	return &MockUserServiceInterface_SetIsActive_Call{Call: _e.mock.On("SetIsActive", ctx, userID, isActive)}
 }
 
-func (_c *MockUserServiceInterface_SetIsActive_Call) Run(run func(userID string, isActive bool)) *MockUserServiceInterface_SetIsActive_Call {
+func (_c *MockUserServiceInterface_SetIsActive_Call) Run(run func(ctx context.Context, userID string, isActive bool)) *MockUserServiceInterface_SetIsActive_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(bool))
+		run(args[0].(context.Context), args[1].(string), args[2].(bool))
 	})
 	return _c
 }
@@ -133,7 +643,126 @@ func (_c *MockUserServiceInterface_SetIsActive_Call) Return(_a0 *domain.User, _a
 	return _c
 }
 
-func (_c *MockUserServiceInterface_SetIsActive_Call) RunAndReturn(run func(string, bool) (*domain.User, error)) *MockUserServiceInterface_SetIsActive_Call {
+func (_c *MockUserServiceInterface_SetIsActive_Call) RunAndReturn(run func(context.Context, string, bool) (*domain.User, error)) *MockUserServiceInterface_SetIsActive_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetIsActiveBatch provides a mock function with given fields: ctx, changes
+func (_m *MockUserServiceInterface) SetIsActiveBatch(ctx context.Context, changes []service.ActivityChange) (*service.ActivityBatchResult, error) {
+	ret := _m.Called(ctx, changes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetIsActiveBatch")
+	}
+
+	var r0 *service.ActivityBatchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []service.ActivityChange) (*service.ActivityBatchResult, error)); ok {
+		return rf(ctx, changes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []service.ActivityChange) *service.ActivityBatchResult); ok {
+		r0 = rf(ctx, changes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.ActivityBatchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []service.ActivityChange) error); ok {
+		r1 = rf(ctx, changes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserServiceInterface_SetIsActiveBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetIsActiveBatch'
+type MockUserServiceInterface_SetIsActiveBatch_Call struct {
+	*mock.Call
+}
+
+// SetIsActiveBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - changes []service.ActivityChange
+func (_e *MockUserServiceInterface_Expecter) SetIsActiveBatch(ctx interface{}, changes interface{}) *MockUserServiceInterface_SetIsActiveBatch_Call {
+	return &MockUserServiceInterface_SetIsActiveBatch_Call{Call: _e.mock.On("SetIsActiveBatch", ctx, changes)}
+}
+
+func (_c *MockUserServiceInterface_SetIsActiveBatch_Call) Run(run func(ctx context.Context, changes []service.ActivityChange)) *MockUserServiceInterface_SetIsActiveBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]service.ActivityChange))
+	})
+	return _c
+}
+
+func (_c *MockUserServiceInterface_SetIsActiveBatch_Call) Return(_a0 *service.ActivityBatchResult, _a1 error) *MockUserServiceInterface_SetIsActiveBatch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserServiceInterface_SetIsActiveBatch_Call) RunAndReturn(run func(context.Context, []service.ActivityChange) (*service.ActivityBatchResult, error)) *MockUserServiceInterface_SetIsActiveBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetTags provides a mock function with given fields: ctx, userID, tags
+func (_m *MockUserServiceInterface) SetTags(ctx context.Context, userID string, tags []string) (*domain.User, error) {
+	ret := _m.Called(ctx, userID, tags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTags")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) (*domain.User, error)); ok {
+		return rf(ctx, userID, tags)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) *domain.User); ok {
+		r0 = rf(ctx, userID, tags)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, userID, tags)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserServiceInterface_SetTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTags'
+type MockUserServiceInterface_SetTags_Call struct {
+	*mock.Call
+}
+
+// SetTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - tags []string
+func (_e *MockUserServiceInterface_Expecter) SetTags(ctx interface{}, userID interface{}, tags interface{}) *MockUserServiceInterface_SetTags_Call {
+	return &MockUserServiceInterface_SetTags_Call{Call: _e.mock.On("SetTags", ctx, userID, tags)}
+}
+
+func (_c *MockUserServiceInterface_SetTags_Call) Run(run func(ctx context.Context, userID string, tags []string)) *MockUserServiceInterface_SetTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *MockUserServiceInterface_SetTags_Call) Return(_a0 *domain.User, _a1 error) *MockUserServiceInterface_SetTags_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserServiceInterface_SetTags_Call) RunAndReturn(run func(context.Context, string, []string) (*domain.User, error)) *MockUserServiceInterface_SetTags_Call {
 	_c.Call.Return(run)
 	return _c
 }
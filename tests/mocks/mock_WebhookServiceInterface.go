@@ -0,0 +1,145 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/mishasvintus/avito_backend_internship/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockWebhookServiceInterface is an autogenerated mock type for the WebhookServiceInterface type
+type MockWebhookServiceInterface struct {
+	mock.Mock
+}
+
+type MockWebhookServiceInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWebhookServiceInterface) EXPECT() *MockWebhookServiceInterface_Expecter {
+	return &MockWebhookServiceInterface_Expecter{mock: &_m.Mock}
+}
+
+// CreateWebhook provides a mock function with given fields: ctx, url, secret
+func (_m *MockWebhookServiceInterface) CreateWebhook(ctx context.Context, url string, secret string) (*domain.Webhook, error) {
+	ret := _m.Called(ctx, url, secret)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWebhook")
+	}
+
+	var r0 *domain.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.Webhook, error)); ok {
+		return rf(ctx, url, secret)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.Webhook); ok {
+		r0 = rf(ctx, url, secret)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, url, secret)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookServiceInterface_CreateWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWebhook'
+type MockWebhookServiceInterface_CreateWebhook_Call struct {
+	*mock.Call
+}
+
+// CreateWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - url string
+//   - secret string
+func (_e *MockWebhookServiceInterface_Expecter) CreateWebhook(ctx interface{}, url interface{}, secret interface{}) *MockWebhookServiceInterface_CreateWebhook_Call {
+	return &MockWebhookServiceInterface_CreateWebhook_Call{Call: _e.mock.On("CreateWebhook", ctx, url, secret)}
+}
+
+func (_c *MockWebhookServiceInterface_CreateWebhook_Call) Run(run func(ctx context.Context, url string, secret string)) *MockWebhookServiceInterface_CreateWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockWebhookServiceInterface_CreateWebhook_Call) Return(_a0 *domain.Webhook, _a1 error) *MockWebhookServiceInterface_CreateWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookServiceInterface_CreateWebhook_Call) RunAndReturn(run func(context.Context, string, string) (*domain.Webhook, error)) *MockWebhookServiceInterface_CreateWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWebhook provides a mock function with given fields: ctx, id
+func (_m *MockWebhookServiceInterface) DeleteWebhook(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookServiceInterface_DeleteWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhook'
+type MockWebhookServiceInterface_DeleteWebhook_Call struct {
+	*mock.Call
+}
+
+// DeleteWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *MockWebhookServiceInterface_Expecter) DeleteWebhook(ctx interface{}, id interface{}) *MockWebhookServiceInterface_DeleteWebhook_Call {
+	return &MockWebhookServiceInterface_DeleteWebhook_Call{Call: _e.mock.On("DeleteWebhook", ctx, id)}
+}
+
+func (_c *MockWebhookServiceInterface_DeleteWebhook_Call) Run(run func(ctx context.Context, id int64)) *MockWebhookServiceInterface_DeleteWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockWebhookServiceInterface_DeleteWebhook_Call) Return(_a0 error) *MockWebhookServiceInterface_DeleteWebhook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookServiceInterface_DeleteWebhook_Call) RunAndReturn(run func(context.Context, int64) error) *MockWebhookServiceInterface_DeleteWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockWebhookServiceInterface creates a new instance of MockWebhookServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWebhookServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWebhookServiceInterface {
+	mock := &MockWebhookServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
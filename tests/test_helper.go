@@ -1,25 +1,61 @@
 package tests
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"log/slog"
 	"os"
+	"sync"
+	"time"
 
 	_ "github.com/lib/pq"
 )
 
 // SetupTestDB creates a test database connection.
 func SetupTestDB() (*sql.DB, error) {
-	host := os.Getenv("TEST_DB_HOST")
+	// Connect
+	db, err := sql.Open("postgres", TestDSN())
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	// Clean up
+	if err := CleanupTestDB(db); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to cleanup database: %w", err)
+	}
+
+	return db, nil
+}
+
+// TestDSN returns the connection string of the test database, configured by TEST_DB_* variables.
+func TestDSN() string {
+	host, port := TestDBAddr()
+	return TestDSNAt(host, port)
+}
+
+// TestDBAddr returns the host and port of the test database, configured by TEST_DB_HOST and TEST_DB_PORT.
+func TestDBAddr() (host, port string) {
+	host = os.Getenv("TEST_DB_HOST")
 	if host == "" {
 		host = "localhost"
 	}
 
-	port := os.Getenv("TEST_DB_PORT")
+	port = os.Getenv("TEST_DB_PORT")
 	if port == "" {
 		port = "5432"
 	}
+	return host, port
+}
 
+// TestDSNAt returns the connection string of the test database reached at host and port,
+// e.g. through a proxy.
+func TestDSNAt(host, port string) string {
 	user := os.Getenv("TEST_DB_USER")
 	if user == "" {
 		user = "avito_user"
@@ -35,31 +71,26 @@ func SetupTestDB() (*sql.DB, error) {
 		dbName = "avito_db"
 	}
 
-	// Connect
-	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
 		host, port, user, password, dbName)
-	db, err := sql.Open("postgres", dsn)
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to database: %w", err)
-	}
-
-	if err := db.Ping(); err != nil {
-		return nil, fmt.Errorf("failed to ping database: %w", err)
-	}
-
-	// Clean up
-	if err := CleanupTestDB(db); err != nil {
-		_ = db.Close()
-		return nil, fmt.Errorf("failed to cleanup database: %w", err)
-	}
-
-	return db, nil
 }
 
 // CleanupTestDB truncates all tables to clean up test data.
 func CleanupTestDB(db *sql.DB) error {
 	// Truncate tables in reverse order of dependencies
 	tables := []string{
+		"audit_log",
+		"external_logins",
+		"event_outbox",
+		"webhooks",
+		"assignment_history",
+		"reviewer_exclusions",
+		"team_memberships",
+		"user_absences",
+		"team_digests",
+		"ownership_rules",
+		"pr_tags",
+		"user_tags",
 		"pr_reviewers",
 		"pull_requests",
 		"users",
@@ -73,5 +104,73 @@ func CleanupTestDB(db *sql.DB) error {
 		}
 	}
 
+	// The default organization is created by the migration and must survive cleanup.
+	if _, err := db.Exec("DELETE FROM organizations WHERE org_id <> 'default'"); err != nil {
+		return fmt.Errorf("failed to delete organizations: %w", err)
+	}
+
+	return nil
+}
+
+// FakeClock is a manually controlled clock for time-dependent tests.
+type FakeClock struct {
+	Current time.Time
+}
+
+// Now returns the clock's current time.
+func (c *FakeClock) Now() time.Time {
+	return c.Current
+}
+
+// Advance moves the clock forward by d.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.Current = c.Current.Add(d)
+}
+
+// LogRecorder is a slog.Handler keeping every record it handles, for asserting on logs.
+type LogRecorder struct {
+	mu      sync.Mutex
+	records []slog.Record
+}
+
+// Enabled reports that records of every level are kept.
+func (r *LogRecorder) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+// Handle keeps the record.
+func (r *LogRecorder) Handle(_ context.Context, record slog.Record) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = append(r.records, record.Clone())
 	return nil
 }
+
+// WithAttrs returns r; attributes added to the logger are not recorded.
+func (r *LogRecorder) WithAttrs([]slog.Attr) slog.Handler {
+	return r
+}
+
+// WithGroup returns r; groups are not recorded.
+func (r *LogRecorder) WithGroup(string) slog.Handler {
+	return r
+}
+
+// Attrs returns the attributes of the records with the given message, oldest first, keyed by name.
+func (r *LogRecorder) Attrs(message string) []map[string]any {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var found []map[string]any
+	for _, record := range r.records {
+		if record.Message != message {
+			continue
+		}
+		attrs := make(map[string]any)
+		record.Attrs(func(a slog.Attr) bool {
+			attrs[a.Key] = a.Value.Any()
+			return true
+		})
+		found = append(found, attrs)
+	}
+	return found
+}
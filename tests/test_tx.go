@@ -0,0 +1,79 @@
+package tests
+
+import (
+	"database/sql"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+)
+
+var (
+	sharedDBOnce sync.Once
+	sharedDB     *sql.DB
+	sharedDBErr  error
+)
+
+// TestTx is a transaction on the test database that is rolled back when the test ends.
+// It implements repository.DBTX, so repository functions take it in place of *sql.DB.
+type TestTx struct {
+	*sql.Tx
+	savepoints int
+}
+
+var _ repository.DBTX = (*TestTx)(nil)
+
+// WithTestTx opens a transaction on a connection pool shared by the test binary and rolls it back
+// in t.Cleanup. Nothing the test writes is ever committed, so tests using it need no TRUNCATE
+// and may call t.Parallel.
+//
+// Limitations:
+//   - Only repository functions can run inside it: services take *sql.DB and open their own
+//     transactions, so service tests keep SetupTestDB and CleanupTestDB.
+//   - The test sees only its own writes and committed data. Anything that needs another connection
+//     to see the data (workers, advisory locks, concurrent transactions) cannot use it.
+//   - Committed data of other tests stays visible, so IDs must be unique to the test and
+//     assertions must not assume empty tables.
+//   - A failed statement aborts a Postgres transaction; run statements expected to fail
+//     in TestTx.Savepoint.
+func WithTestTx(t testing.TB) *TestTx {
+	t.Helper()
+
+	sharedDBOnce.Do(func() {
+		sharedDB, sharedDBErr = sql.Open("postgres", TestDSN())
+		if sharedDBErr == nil {
+			sharedDBErr = sharedDB.Ping()
+		}
+	})
+	if sharedDBErr != nil {
+		t.Fatalf("failed to connect to test database: %v", sharedDBErr)
+	}
+
+	tx, err := sharedDB.Begin()
+	if err != nil {
+		t.Fatalf("failed to begin test transaction: %v", err)
+	}
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	return &TestTx{Tx: tx}
+}
+
+// Savepoint runs fn inside a savepoint and rolls back to it if fn fails, keeping the
+// transaction usable after an expected error such as a unique violation. Returns fn's error.
+func (tx *TestTx) Savepoint(fn func() error) error {
+	tx.savepoints++
+	name := fmt.Sprintf("test_sp_%d", tx.savepoints)
+
+	if _, err := tx.Exec("SAVEPOINT " + name); err != nil {
+		return fmt.Errorf("failed to create savepoint: %w", err)
+	}
+	if err := fn(); err != nil {
+		if _, rbErr := tx.Exec("ROLLBACK TO SAVEPOINT " + name); rbErr != nil {
+			return fmt.Errorf("%w (rollback to savepoint failed: %v)", err, rbErr)
+		}
+		return err
+	}
+	_, err := tx.Exec("RELEASE SAVEPOINT " + name)
+	return err
+}
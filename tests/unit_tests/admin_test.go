@@ -0,0 +1,61 @@
+package unit_tests
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/admin"
+)
+
+func TestAdminRun_Usage(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		expectedStderr string
+	}{
+		{name: "no command", args: nil, expectedStderr: "Usage: admin <command>"},
+		{name: "unknown command", args: []string{"migrate"}, expectedStderr: "rebalance"},
+		{name: "unknown flag", args: []string{"stats", "--verbose"}, expectedStderr: "flag provided but not defined"},
+		{name: "positional arguments", args: []string{"seed", "extra"}},
+		{name: "rebalance without team", args: []string{"rebalance", "--dry-run"}},
+		{name: "negative max moves", args: []string{"rebalance", "--team", "backend", "--max-moves", "-1"}},
+		{name: "unknown stats format", args: []string{"stats", "--format", "yaml"}},
+		{name: "seed without teams", args: []string{"seed", "--teams", "0"}},
+		{name: "seed with invalid prefix", args: []string{"seed", "--prefix", "demo data"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			err := admin.Run(t.Context(), tt.args, admin.Services{}, &stdout, &stderr)
+			require.ErrorIs(t, err, admin.ErrUsage)
+			assert.Empty(t, stdout.String())
+			assert.Contains(t, stderr.String(), tt.expectedStderr)
+		})
+	}
+}
+
+func TestAdminRun_Help(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	require.NoError(t, admin.Run(t.Context(), []string{"help"}, admin.Services{}, &stdout, &stderr))
+	for _, command := range []string{"seed", "rebalance", "stats"} {
+		assert.Contains(t, stdout.String(), command)
+	}
+
+	stdout.Reset()
+	err := admin.Run(t.Context(), []string{"seed", "-h"}, admin.Services{}, &stdout, &stderr)
+	assert.ErrorIs(t, err, flag.ErrHelp)
+	assert.Contains(t, stderr.String(), "-dry-run")
+}
+
+func TestAdminRun_SeedDryRun(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	err := admin.Run(t.Context(), []string{"seed", "--dry-run", "--teams", "2", "--users", "4", "--prs", "3"}, admin.Services{}, &stdout, &stderr)
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "would create team demo-team-2 with 4 users and 3 pull requests")
+	assert.Contains(t, stdout.String(), "dry run: would seed 2 teams, 8 users, 6 pull requests")
+}
@@ -0,0 +1,91 @@
+package unit_tests
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestReviewerAssigner_Decide(t *testing.T) {
+	limit := 1
+	teammates := users("author", "assigned", "busy", "light", "heavy")
+	teammates[2].MaxOpenReviews, teammates[2].OpenReviews = &limit, 1
+	teammates[3].OpenReviewLoad = 1
+	teammates[4].OpenReviewLoad, teammates[4].AssignmentWeight = 5, 2.5
+
+	assigner := service.NewReviewerAssigner().WithStrategy(service.StrategyLeastLoaded)
+
+	t.Run("reassign lists candidates, exclusions and the choice", func(t *testing.T) {
+		decision, err := assigner.ForTags([]string{"go"}).DecideReassign(teammates, "author", []string{"assigned"}, 1)
+		require.NoError(t, err)
+		assert.Equal(t, &domain.AssignmentDecision{
+			Strategy: "least_loaded",
+			Tags:     []string{"go"},
+			Candidates: []domain.DecisionCandidate{
+				{UserID: "light", Load: 1, Weight: 1},
+				{UserID: "heavy", Load: 5, Weight: 2.5},
+			},
+			Excluded: []domain.DecisionExclusion{
+				{UserID: "author", Reason: domain.ExcludedAuthor},
+				{UserID: "assigned", Reason: domain.ExcludedAssigned},
+				{UserID: "busy", Reason: domain.ExcludedAtCapacity},
+			},
+			Selected: []string{"light"},
+		}, decision)
+	})
+
+	t.Run("capacity fallback", func(t *testing.T) {
+		decision, err := assigner.Decide(teammates[2:3], 1)
+		require.NoError(t, err)
+		assert.True(t, decision.CapacityFallback)
+		assert.Empty(t, decision.Excluded)
+		assert.Equal(t, []domain.DecisionCandidate{{UserID: "busy", Weight: 1}}, decision.Candidates)
+		assert.Equal(t, []string{"busy"}, decision.Selected)
+	})
+
+	t.Run("selection matches SelectReviewersN", func(t *testing.T) {
+		decision, err := assigner.Decide(teammates, 2)
+		require.NoError(t, err)
+		selected, err := assigner.SelectReviewersN(teammates, 2)
+		require.NoError(t, err)
+		assert.Equal(t, selected, decision.Selected)
+		assert.Equal(t, []domain.DecisionExclusion{{UserID: "busy", Reason: domain.ExcludedAtCapacity}}, decision.Excluded)
+	})
+}
+
+func TestReviewerAssigner_LogDecision(t *testing.T) {
+	decision := &domain.AssignmentDecision{
+		Strategy:   "random",
+		Candidates: []domain.DecisionCandidate{{UserID: "u2", Load: 3, Weight: 1}},
+		Excluded:   []domain.DecisionExclusion{{UserID: "u1", Reason: domain.ExcludedAuthor}},
+		Selected:   []string{"u2"},
+	}
+	key := domain.PRKey{RepositoryName: "backend", PullRequestID: "pr-1"}
+
+	recorder := &tests.LogRecorder{}
+	assigner := service.NewReviewerAssigner().WithDecisionLogger(slog.New(recorder))
+	// Copies made per team and per PR keep the logger.
+	assigner.ForStrategy(service.StrategyRandom).LogDecision("acme", key, "REASSIGN", decision)
+	assigner.LogDecision("acme", key, "REPLENISH", nil)
+
+	records := recorder.Attrs("reviewer assignment decision")
+	require.Len(t, records, 1, "nil decisions are not logged")
+	assert.Equal(t, map[string]any{
+		"org_id":            "acme",
+		"repository_name":   "backend",
+		"pull_request_id":   "pr-1",
+		"action":            "REASSIGN",
+		"strategy":          "random",
+		"tags":              []string(nil),
+		"candidates":        decision.Candidates,
+		"excluded":          decision.Excluded,
+		"capacity_fallback": false,
+		"selected":          []string{"u2"},
+	}, records[0])
+}
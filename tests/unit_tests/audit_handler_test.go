@@ -0,0 +1,209 @@
+package unit_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/middleware"
+	"github.com/mishasvintus/avito_backend_internship/internal/pagination"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/audit"
+	"github.com/mishasvintus/avito_backend_internship/internal/router"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func TestAuditHandler_ListAudit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	at := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	entries := []domain.AuditEntry{
+		{ID: 7, Actor: "api_key:0123456789ab", Action: domain.AuditTeamDeactivate, Target: "team:backend", RequestID: "req-2", CreatedAt: at},
+		{ID: 5, Actor: "api_key:0123456789ab", Action: domain.AuditUserErase, Target: "user:u1", RequestID: "req-1", CreatedAt: at},
+	}
+	next := audit.Cursor(entries[1])
+
+	tests := []struct {
+		name             string
+		query            string
+		mockSetup        func(*handlermocks.MockAuditServiceInterface)
+		expectedStatus   int
+		validateResponse func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "success - default page",
+			query: "",
+			mockSetup: func(m *handlermocks.MockAuditServiceInterface) {
+				m.EXPECT().ListAudit(mock.Anything, audit.Filter{Limit: 50}).Return(pagination.Page[domain.AuditEntry]{Items: entries}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.AuditLogResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Len(t, response.Items, 2)
+				assert.Equal(t, response.Items, response.Entries)
+				assert.False(t, response.HasMore)
+				assert.Equal(t, handler.AuditEntryResponse{
+					ID:        7,
+					Actor:     "api_key:0123456789ab",
+					Action:    "team.deactivate",
+					Target:    "team:backend",
+					RequestID: "req-2",
+					CreatedAt: "2026-03-02T10:00:00Z",
+				}, response.Entries[0])
+				assert.Zero(t, response.NextBeforeID)
+				assert.NotContains(t, w.Body.String(), "next_before_id")
+				assert.NotContains(t, w.Body.String(), "next_cursor")
+			},
+		},
+		{
+			name:  "success - full page with filters links the next one",
+			query: "?from=2026-03-01T00:00:00Z&actor=api_key:0123456789ab&limit=2&before_id=9",
+			mockSetup: func(m *handlermocks.MockAuditServiceInterface) {
+				m.EXPECT().ListAudit(mock.Anything, audit.Filter{
+					From:     &from,
+					Actor:    "api_key:0123456789ab",
+					BeforeID: 9,
+					Limit:    2,
+				}).Return(pagination.Page[domain.AuditEntry]{Items: entries, NextCursor: next.Encode(), HasMore: true}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.AuditLogResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Len(t, response.Entries, 2)
+				assert.True(t, response.HasMore)
+				assert.Equal(t, next.Encode(), response.NextCursor)
+				assert.EqualValues(t, 5, response.NextBeforeID)
+			},
+		},
+		{
+			name:  "success - cursor continues the listing",
+			query: "?limit=2&cursor=" + next.Encode(),
+			mockSetup: func(m *handlermocks.MockAuditServiceInterface) {
+				m.EXPECT().ListAudit(mock.Anything, audit.Filter{After: &next, Limit: 2}).
+					Return(pagination.Page[domain.AuditEntry]{Items: entries, NextCursor: next.Encode(), HasMore: true}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.AuditLogResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.True(t, response.HasMore)
+				// Clients paging by cursor are not sent the legacy link.
+				assert.Zero(t, response.NextBeforeID)
+			},
+		},
+		{
+			name:  "success - empty log",
+			query: "",
+			mockSetup: func(m *handlermocks.MockAuditServiceInterface) {
+				m.EXPECT().ListAudit(mock.Anything, audit.Filter{Limit: 50}).Return(pagination.Page[domain.AuditEntry]{Items: []domain.AuditEntry{}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.JSONEq(t, `{"items":[],"has_more":false,"entries":[]}`, w.Body.String())
+			},
+		},
+		{
+			name:           "error - limit out of range",
+			query:          "?limit=101",
+			mockSetup:      func(m *handlermocks.MockAuditServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "limit must be an integer between 1 and 100", response.Error.Message)
+			},
+		},
+		{
+			name:           "error - invalid before_id",
+			query:          "?before_id=0",
+			mockSetup:      func(m *handlermocks.MockAuditServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "before_id must be a positive integer", response.Error.Message)
+			},
+		},
+		{
+			name:           "error - invalid cursor",
+			query:          "?cursor=" + pagination.Cursor{CreatedAt: at, ID: "backend/pr-1"}.Encode(),
+			mockSetup:      func(m *handlermocks.MockAuditServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "cursor must be a next_cursor of a previous page", response.Error.Message)
+			},
+		},
+		{
+			name:           "error - cursor with before_id",
+			query:          "?before_id=9&cursor=" + next.Encode(),
+			mockSetup:      func(m *handlermocks.MockAuditServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "cursor and before_id cannot be combined", response.Error.Message)
+			},
+		},
+		{
+			name:           "error - from after to",
+			query:          "?from=2026-03-02T00:00:00Z&to=2026-03-01T00:00:00Z",
+			mockSetup:      func(m *handlermocks.MockAuditServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "from must not be after to", response.Error.Message)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockAuditServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/admin/audit"+tt.query, nil)
+
+			handler.NewAuditHandler(mockService).ListAudit(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			tt.validateResponse(t, w)
+		})
+	}
+}
+
+func TestAuditHandler_ListAudit_RequiresAdmin(t *testing.T) {
+	r, err := router.SetupRoutes(router.Options{Mode: gin.TestMode, AdminAPIKeys: []string{"secret"}},
+		nil, nil, nil, nil, nil, nil, handler.NewAuditHandler(handlermocks.NewMockAuditServiceInterface(t)), nil)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, router.APIPrefix+"/admin/audit", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAuditActor_DoesNotRevealAPIKey(t *testing.T) {
+	actor := middleware.APIKeyActor("super-secret-key")
+
+	assert.True(t, strings.HasPrefix(actor, "api_key:"), actor)
+	assert.Len(t, actor, len("api_key:")+12)
+	assert.NotContains(t, actor, "super-secret-key")
+	assert.Equal(t, actor, middleware.APIKeyActor("super-secret-key"))
+	assert.NotEqual(t, actor, middleware.APIKeyActor("other-key"))
+}
@@ -0,0 +1,124 @@
+package unit_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/middleware"
+	"github.com/mishasvintus/avito_backend_internship/internal/router"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func TestAuth_AdminRoutes(t *testing.T) {
+	tests := []struct {
+		name            string
+		adminKeys       []string
+		apiKey          string
+		reachesService  bool
+		expectedStatus  int
+		expectedMessage string
+	}{
+		{
+			name:            "no API key",
+			adminKeys:       []string{"secret"},
+			expectedStatus:  http.StatusUnauthorized,
+			expectedMessage: "admin API key required",
+		},
+		{
+			name:            "unknown API key",
+			adminKeys:       []string{"secret"},
+			apiKey:          "guess",
+			expectedStatus:  http.StatusUnauthorized,
+			expectedMessage: "invalid API key",
+		},
+		{
+			name:            "admin API disabled",
+			apiKey:          "secret",
+			expectedStatus:  http.StatusUnauthorized,
+			expectedMessage: "invalid API key",
+		},
+		{
+			name:           "admin API key",
+			adminKeys:      []string{"other", "secret"},
+			apiKey:         "secret",
+			reachesService: true,
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prService := handlermocks.NewMockPRServiceInterface(t)
+			if tt.reachesService {
+				prService.EXPECT().ReopenPR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}).
+					Return(&domain.PullRequest{PullRequestID: "pr1", Status: domain.StatusOpen}, nil)
+			}
+			r, err := router.SetupRoutes(router.Options{Mode: gin.TestMode, AdminAPIKeys: tt.adminKeys},
+				handler.NewTeamHandler(handlermocks.NewMockTeamServiceInterface(t)),
+				handler.NewUserHandler(handlermocks.NewMockUserServiceInterface(t)),
+				handler.NewPRHandler(prService),
+				handler.NewStatsHandler(handlermocks.NewMockStatsServiceInterface(t)),
+				handler.NewWebhookHandler(handlermocks.NewMockWebhookServiceInterface(t)),
+				handler.NewIntegrationHandler(handlermocks.NewMockIntegrationServiceInterface(t)),
+				handler.NewAuditHandler(handlermocks.NewMockAuditServiceInterface(t)),
+				nil,
+			)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, router.APIPrefix+"/pullRequest/reopen", strings.NewReader(`{"pull_request_id":"pr1"}`))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.apiKey != "" {
+				req.Header.Set(middleware.APIKeyHeader, tt.apiKey)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			if tt.expectedMessage == "" {
+				return
+			}
+			var response handler.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, handler.ErrorUnauthorized, response.Error.Code)
+			assert.Equal(t, tt.expectedMessage, response.Error.Message)
+		})
+	}
+}
+
+func TestAuth_AnonymousRequestsPassThrough(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(middleware.Authenticate([]string{"secret"}))
+	r.GET("/team/get", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"admin": c.GetBool(middleware.AdminContextKey), "key": c.GetString(middleware.APIKeyContextKey)})
+	})
+
+	for _, tt := range []struct {
+		apiKey   string
+		expected string
+	}{
+		{apiKey: "", expected: `{"admin":false,"key":""}`},
+		{apiKey: "secret", expected: `{"admin":true,"key":"secret"}`},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/team/get", nil)
+		if tt.apiKey != "" {
+			req.Header.Set(middleware.APIKeyHeader, tt.apiKey)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, tt.expected, w.Body.String())
+	}
+}
@@ -0,0 +1,135 @@
+package unit_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/router"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+// newStrictBodyRouter builds the full router with mocks that expect no calls:
+// every request in these tests must be rejected before reaching a service.
+func newStrictBodyRouter(t *testing.T, maxBodyBytes int64) *gin.Engine {
+	t.Helper()
+
+	r, err := router.SetupRoutes(router.Options{Mode: gin.TestMode, MaxBodyBytes: maxBodyBytes},
+		handler.NewTeamHandler(handlermocks.NewMockTeamServiceInterface(t)),
+		handler.NewUserHandler(handlermocks.NewMockUserServiceInterface(t)),
+		handler.NewPRHandler(handlermocks.NewMockPRServiceInterface(t)),
+		handler.NewStatsHandler(handlermocks.NewMockStatsServiceInterface(t)),
+		handler.NewWebhookHandler(handlermocks.NewMockWebhookServiceInterface(t)),
+		handler.NewIntegrationHandler(handlermocks.NewMockIntegrationServiceInterface(t)),
+		nil,
+		nil,
+	)
+	require.NoError(t, err)
+	return r
+}
+
+func TestRequestBody_Strictness(t *testing.T) {
+	oversizedTeam := `{"team_name":"backend","members":[{"user_id":"u1","username":"` +
+		strings.Repeat("a", 2048) + `","is_active":true}]}`
+	oversizedPR := `{"pull_request_id":"pr-1","pull_request_name":"` +
+		strings.Repeat("a", 2048) + `","author_id":"u1"}`
+
+	tests := []struct {
+		name            string
+		path            string
+		body            string
+		chunked         bool
+		expectedStatus  int
+		expectedCode    handler.ErrorCode
+		expectedMessage string
+	}{
+		{
+			name:            "oversized team",
+			path:            "/team/add",
+			body:            oversizedTeam,
+			expectedStatus:  http.StatusRequestEntityTooLarge,
+			expectedCode:    handler.ErrorPayloadTooLarge,
+			expectedMessage: "request body exceeds 1024 bytes",
+		},
+		{
+			name:            "oversized pull request",
+			path:            "/pullRequest/create",
+			body:            oversizedPR,
+			expectedStatus:  http.StatusRequestEntityTooLarge,
+			expectedCode:    handler.ErrorPayloadTooLarge,
+			expectedMessage: "request body exceeds 1024 bytes",
+		},
+		{
+			name:            "oversized pull request without content length",
+			path:            "/pullRequest/create",
+			body:            oversizedPR,
+			chunked:         true,
+			expectedStatus:  http.StatusRequestEntityTooLarge,
+			expectedCode:    handler.ErrorPayloadTooLarge,
+			expectedMessage: "request body exceeds 1024 bytes",
+		},
+		{
+			name:            "unknown field in pull request",
+			path:            "/pullRequest/create",
+			body:            `{"pull_request_id":"pr-1","pull_request_name":"Fix","author_id":"u1","autor_id":"u2"}`,
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: `unknown field "autor_id"`,
+		},
+		{
+			name:            "unknown field in team",
+			path:            "/team/add",
+			body:            `{"team_name":"backend","members":[],"strategy":"random"}`,
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: `unknown field "strategy"`,
+		},
+		{
+			name:            "unknown field in team member",
+			path:            "/team/add",
+			body:            `{"team_name":"backend","members":[{"user_id":"u1","username":"Alice","is_active":true,"active":true}]}`,
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: `unknown field "active"`,
+		},
+		{
+			name:            "unknown field in user request",
+			path:            "/users/setIsActive",
+			body:            `{"user_id":"u1","is_active":true,"isActive":false}`,
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: `unknown field "isActive"`,
+		},
+		{
+			name:            "missing required field still fails validation",
+			path:            "/pullRequest/create",
+			body:            `{"pull_request_id":"pr-1","pull_request_name":"Fix"}`,
+			expectedStatus:  http.StatusBadRequest,
+			expectedCode:    handler.ErrorValidation,
+			expectedMessage: "invalid request body",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newStrictBodyRouter(t, 1024)
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var response handler.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedCode, response.Error.Code)
+			assert.Equal(t, tt.expectedMessage, response.Error.Message)
+		})
+	}
+}
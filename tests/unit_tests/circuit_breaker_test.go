@@ -0,0 +1,204 @@
+package unit_tests
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/middleware"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+	"github.com/mishasvintus/avito_backend_internship/internal/router"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestCircuitBreaker_StateMachine(t *testing.T) {
+	clock := &tests.FakeClock{Current: time.Date(2025, 11, 1, 12, 0, 0, 0, time.UTC)}
+	b := middleware.NewCircuitBreaker(3, 10*time.Second, clock)
+
+	admit := func() {
+		t.Helper()
+		ok, _ := b.Allow()
+		require.True(t, ok)
+	}
+
+	// Failures must be consecutive: a success resets the count, a request without database access does not.
+	for _, outcome := range []middleware.Outcome{
+		middleware.OutcomeFailure, middleware.OutcomeFailure, middleware.OutcomeSuccess,
+		middleware.OutcomeFailure, middleware.OutcomeSkipped, middleware.OutcomeFailure,
+	} {
+		admit()
+		b.Record(outcome)
+	}
+	assert.Equal(t, middleware.CircuitClosed, b.State())
+
+	admit()
+	b.Record(middleware.OutcomeFailure)
+	assert.Equal(t, middleware.CircuitOpen, b.State())
+
+	clock.Advance(4 * time.Second)
+	ok, wait := b.Allow()
+	assert.False(t, ok)
+	assert.Equal(t, 6*time.Second, wait)
+
+	// After the timeout a single probe is admitted; its failure opens the breaker again.
+	clock.Advance(6 * time.Second)
+	assert.Equal(t, middleware.CircuitHalfOpen, b.State())
+	admit()
+	ok, _ = b.Allow()
+	assert.False(t, ok, "only one probe at a time")
+	b.Record(middleware.OutcomeFailure)
+	assert.Equal(t, middleware.CircuitOpen, b.State())
+
+	// A probe that did not reach the database leaves the breaker half-open for the next one.
+	clock.Advance(10 * time.Second)
+	admit()
+	b.Record(middleware.OutcomeSkipped)
+	assert.Equal(t, middleware.CircuitHalfOpen, b.State())
+	admit()
+	b.Record(middleware.OutcomeSuccess)
+	assert.Equal(t, middleware.CircuitClosed, b.State())
+}
+
+func TestBreakerMiddleware_FailsFastWhileOpen(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	clock := &tests.FakeClock{Current: time.Date(2025, 11, 1, 12, 0, 0, 0, time.UTC)}
+	breaker := middleware.NewCircuitBreaker(2, 30*time.Second, clock)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.Breaker(breaker))
+	r.GET("/touch", func(c *gin.Context) {
+		if _, err := repository.WithContext(c.Request.Context(), db).Exec("UPDATE users SET is_active = true"); err != nil {
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+	r.GET("/static", func(c *gin.Context) { c.Status(http.StatusOK) })
+	serve := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+	mock.ExpectExec("UPDATE users").WillReturnError(refused)
+	mock.ExpectExec("UPDATE users").WillReturnError(refused)
+
+	assert.Equal(t, http.StatusInternalServerError, serve("/touch").Code)
+	assert.Equal(t, http.StatusOK, serve("/static").Code, "requests without database access do not count")
+	assert.Equal(t, http.StatusInternalServerError, serve("/touch").Code)
+	require.Equal(t, middleware.CircuitOpen, breaker.State())
+
+	clock.Advance(5 * time.Second)
+	w := serve("/touch")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "25", w.Header().Get("Retry-After"))
+	var body handler.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, handler.ErrorServiceUnavailable, body.Error.Code)
+	require.NoError(t, mock.ExpectationsWereMet(), "the rejected request did not reach the database")
+
+	clock.Advance(25 * time.Second)
+	mock.ExpectExec("UPDATE users").WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.Equal(t, http.StatusOK, serve("/touch").Code)
+	assert.Equal(t, middleware.CircuitClosed, breaker.State())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBreakerMiddleware_TimeoutsDoNotCount(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	clock := &tests.FakeClock{Current: time.Date(2025, 11, 1, 12, 0, 0, 0, time.UTC)}
+	breaker := middleware.NewCircuitBreaker(1, 30*time.Second, clock)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.Breaker(breaker))
+	r.GET("/touch", func(c *gin.Context) {
+		if _, err := repository.WithContext(c.Request.Context(), db).Exec("UPDATE users SET is_active = true"); err != nil {
+			c.Status(http.StatusGatewayTimeout)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	mock.ExpectExec("UPDATE users").WillReturnError(fmt.Errorf("failed to update user: %w", context.DeadlineExceeded))
+	mock.ExpectExec("UPDATE users").WillReturnError(&pq.Error{Code: "57014", Message: "canceling statement due to statement timeout"})
+	for range 2 {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/touch", nil))
+		assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+		assert.Equal(t, middleware.CircuitClosed, breaker.State(), "a timed-out statement is not an outage")
+	}
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIsUnavailable(t *testing.T) {
+	assert.True(t, repository.IsUnavailable(&net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}))
+	assert.True(t, repository.IsUnavailable(fmt.Errorf("wrapped: %w", driver.ErrBadConn)))
+	assert.True(t, repository.IsUnavailable(io.EOF))
+	assert.True(t, repository.IsUnavailable(&pq.Error{Code: "08006"}))
+	assert.True(t, repository.IsUnavailable(&pq.Error{Code: "57P01"}))
+	assert.False(t, repository.IsUnavailable(context.DeadlineExceeded))
+	assert.False(t, repository.IsUnavailable(fmt.Errorf("wrapped: %w", context.DeadlineExceeded)))
+	assert.False(t, repository.IsUnavailable(&pq.Error{Code: "57014"}))
+	assert.False(t, repository.IsUnavailable(&pq.Error{Code: "23505"}))
+	assert.False(t, repository.IsUnavailable(nil))
+}
+
+func TestHealth_ReflectsCircuitBreaker(t *testing.T) {
+	clock := &tests.FakeClock{Current: time.Date(2025, 11, 1, 12, 0, 0, 0, time.UTC)}
+	breaker := middleware.NewCircuitBreaker(1, time.Minute, clock)
+
+	health := func(opts router.Options) (int, handler.HealthResponse) {
+		t.Helper()
+		opts.Mode = gin.TestMode
+		r, err := router.SetupRoutes(opts, nil, nil, nil, nil, nil, nil, nil, nil)
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+		var body handler.HealthResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		return w.Code, body
+	}
+
+	code, body := health(router.Options{})
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, handler.HealthResponse{Status: "ok"}, body)
+
+	code, body = health(router.Options{CircuitBreaker: breaker})
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, handler.HealthResponse{Status: "ok", CircuitBreaker: "closed"}, body)
+
+	breaker.Allow()
+	breaker.Record(middleware.OutcomeFailure)
+	code, body = health(router.Options{CircuitBreaker: breaker})
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, handler.HealthResponse{Status: "degraded", CircuitBreaker: "open"}, body)
+
+	clock.Advance(time.Minute)
+	code, body = health(router.Options{CircuitBreaker: breaker})
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "half_open", body.CircuitBreaker)
+}
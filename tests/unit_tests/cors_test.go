@@ -0,0 +1,137 @@
+package unit_tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/middleware"
+	"github.com/mishasvintus/avito_backend_internship/internal/router"
+)
+
+func newCORSRouter(t *testing.T, origins []string) *gin.Engine {
+	t.Helper()
+
+	r, err := router.SetupRoutes(router.Options{
+		Mode: gin.TestMode,
+		CORS: middleware.CORSOptions{
+			AllowedOrigins: origins,
+			AllowedMethods: []string{"GET", "POST"},
+			AllowedHeaders: []string{"Content-Type"},
+			MaxAge:         10 * time.Minute,
+		},
+	}, nil, nil, nil, nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+	r.GET("/test/cors", func(c *gin.Context) {
+		c.String(http.StatusOK, "ok")
+	})
+	return r
+}
+
+func TestCORS(t *testing.T) {
+	const dashboard = "https://dashboard.example.com"
+
+	tests := []struct {
+		name            string
+		origins         []string
+		method          string
+		path            string
+		origin          string
+		preflight       bool
+		expectedStatus  int
+		expectedOrigin  string
+		expectedMethods string
+		expectedMaxAge  string
+	}{
+		{
+			name:           "allowed origin",
+			origins:        []string{dashboard},
+			method:         http.MethodGet,
+			path:           "/test/cors",
+			origin:         dashboard,
+			expectedStatus: http.StatusOK,
+			expectedOrigin: dashboard,
+		},
+		{
+			name:           "disallowed origin",
+			origins:        []string{dashboard},
+			method:         http.MethodGet,
+			path:           "/test/cors",
+			origin:         "https://evil.example.com",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:            "preflight from allowed origin",
+			origins:         []string{dashboard},
+			method:          http.MethodOptions,
+			path:            "/pullRequest/create",
+			origin:          dashboard,
+			preflight:       true,
+			expectedStatus:  http.StatusNoContent,
+			expectedOrigin:  dashboard,
+			expectedMethods: "GET, POST",
+			expectedMaxAge:  "600",
+		},
+		{
+			name:           "preflight from disallowed origin",
+			origins:        []string{dashboard},
+			method:         http.MethodOptions,
+			path:           "/pullRequest/create",
+			origin:         "https://evil.example.com",
+			preflight:      true,
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:            "wildcard",
+			origins:         []string{"*"},
+			method:          http.MethodOptions,
+			path:            "/stats",
+			origin:          "https://anything.example.com",
+			preflight:       true,
+			expectedStatus:  http.StatusNoContent,
+			expectedOrigin:  "*",
+			expectedMethods: "GET, POST",
+			expectedMaxAge:  "600",
+		},
+		{
+			name:           "disabled by default",
+			origins:        nil,
+			method:         http.MethodOptions,
+			path:           "/pullRequest/create",
+			origin:         dashboard,
+			preflight:      true,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newCORSRouter(t, tt.origins)
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("Origin", tt.origin)
+			if tt.preflight {
+				req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+				req.Header.Set("Access-Control-Request-Headers", "Content-Type")
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedOrigin, w.Header().Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, tt.expectedMethods, w.Header().Get("Access-Control-Allow-Methods"))
+			assert.Equal(t, tt.expectedMaxAge, w.Header().Get("Access-Control-Max-Age"))
+			if tt.expectedMethods != "" {
+				assert.Equal(t, "Content-Type", w.Header().Get("Access-Control-Allow-Headers"))
+			}
+			if tt.origins == nil {
+				assert.Empty(t, w.Header().Get("Vary"))
+			}
+		})
+	}
+}
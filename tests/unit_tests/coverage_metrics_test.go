@@ -0,0 +1,65 @@
+package unit_tests
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/metrics"
+)
+
+// fakeCoverageSource returns counts, or err if set.
+type fakeCoverageSource struct {
+	counts map[string]int64
+	err    error
+}
+
+func (f *fakeCoverageSource) UnderCoveredByOrg(context.Context) (map[string]int64, error) {
+	return f.counts, f.err
+}
+
+func TestCoverageCollector_Sample(t *testing.T) {
+	var logs bytes.Buffer
+	registry := prometheus.NewRegistry()
+	source := &fakeCoverageSource{counts: map[string]int64{"default": 3, "acme": 0}}
+	collector := metrics.NewCoverageCollector(source, registry, 0, slog.New(slog.NewJSONHandler(&logs, nil)))
+
+	underCovered := func() map[string]float64 {
+		t.Helper()
+		families, err := registry.Gather()
+		require.NoError(t, err)
+		values := make(map[string]float64)
+		for _, f := range families {
+			if f.GetName() != "review_coverage_under_covered_pull_requests" {
+				continue
+			}
+			for _, m := range f.GetMetric() {
+				values[m.GetLabel()[0].GetValue()] = m.GetGauge().GetValue()
+			}
+		}
+		return values
+	}
+
+	collector.Sample(t.Context())
+	assert.Equal(t, map[string]float64{"default": 3, "acme": 0}, underCovered())
+
+	t.Run("failed sample keeps the previous values", func(t *testing.T) {
+		source.err = errors.New("connection refused")
+		collector.Sample(t.Context())
+		assert.Equal(t, map[string]float64{"default": 3, "acme": 0}, underCovered())
+		assert.Contains(t, logs.String(), `"level":"WARN"`)
+		source.err = nil
+	})
+
+	t.Run("organizations no longer reported are dropped", func(t *testing.T) {
+		source.counts = map[string]int64{"default": 1}
+		collector.Sample(t.Context())
+		assert.Equal(t, map[string]float64{"default": 1}, underCovered())
+	})
+}
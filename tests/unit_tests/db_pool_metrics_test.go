@@ -0,0 +1,119 @@
+package unit_tests
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/metrics"
+	"github.com/mishasvintus/avito_backend_internship/internal/router"
+)
+
+func TestDBPoolCollector_StarvedPool(t *testing.T) {
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	db.SetMaxOpenConns(1)
+
+	var logs bytes.Buffer
+	registry := prometheus.NewRegistry()
+	collector := metrics.NewDBPoolCollector(db, registry, time.Hour, slog.New(slog.NewJSONHandler(&logs, nil)))
+
+	held, err := db.Conn(t.Context())
+	require.NoError(t, err)
+
+	collector.Sample()
+	assert.Equal(t, 1.0, gaugeValue(t, registry, "db_pool_in_use_connections"))
+	assert.Equal(t, 1.0, gaugeValue(t, registry, "db_pool_max_open_connections"))
+	assert.Equal(t, 0.0, gaugeValue(t, registry, "db_pool_wait_count"))
+	assert.Empty(t, logs.String(), "no warning while nobody waits")
+
+	// A second caller blocks until the held connection is released.
+	acquired := make(chan error, 1)
+	go func() {
+		conn, err := db.Conn(context.Background())
+		if err == nil {
+			err = conn.Close()
+		}
+		acquired <- err
+	}()
+	require.Eventually(t, func() bool { return db.Stats().WaitCount == 1 }, time.Second, time.Millisecond)
+
+	collector.Sample()
+	assert.Equal(t, 1.0, gaugeValue(t, registry, "db_pool_wait_count"))
+	assert.Contains(t, logs.String(), `"level":"WARN"`)
+	assert.Contains(t, logs.String(), `"waits":1`)
+
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, held.Close())
+	require.NoError(t, <-acquired)
+
+	logs.Reset()
+	collector.Sample()
+	assert.Equal(t, 0.0, gaugeValue(t, registry, "db_pool_in_use_connections"))
+	assert.Equal(t, 1.0, gaugeValue(t, registry, "db_pool_idle_connections"))
+	assert.GreaterOrEqual(t, gaugeValue(t, registry, "db_pool_wait_duration_seconds"), 0.01)
+	assert.Empty(t, logs.String(), "the wait was already reported")
+
+	r, err := router.SetupRoutes(router.Options{
+		Mode:    gin.TestMode,
+		Metrics: promhttp.HandlerFor(registry, promhttp.HandlerOpts{}),
+	}, nil, nil, nil, nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "db_pool_wait_count 1")
+}
+
+func TestDBPoolCollector_RunStopsWithContext(t *testing.T) {
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	db.SetMaxOpenConns(3)
+
+	registry := prometheus.NewRegistry()
+	collector := metrics.NewDBPoolCollector(db, registry, time.Millisecond, slog.New(slog.DiscardHandler))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		collector.Run(ctx)
+		close(done)
+	}()
+	require.Eventually(t, func() bool {
+		return gaugeValue(t, registry, "db_pool_max_open_connections") == 3
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after cancellation")
+	}
+}
+
+// gaugeValue returns the value of the named gauge in registry.
+func gaugeValue(t *testing.T, registry *prometheus.Registry, name string) float64 {
+	t.Helper()
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	for _, f := range families {
+		if f.GetName() == name {
+			return f.GetMetric()[0].GetGauge().GetValue()
+		}
+	}
+	t.Fatalf("gauge %s is not registered", name)
+	return 0
+}
@@ -0,0 +1,133 @@
+package unit_tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+)
+
+type routedServices struct {
+	primary sqlmock.Sqlmock
+	replica sqlmock.Sqlmock
+	prs     *service.PRService
+	teams   *service.TeamService
+	users   *service.UserService
+	stats   *service.StatsService
+}
+
+// newRoutedServices builds the services over two mock pools. Every replica ping has to be expected,
+// and a query reaching the pool that does not expect it fails the test.
+func newRoutedServices(t *testing.T, checkInterval time.Duration) routedServices {
+	primaryDB, primary, err := sqlmock.New()
+	require.NoError(t, err)
+	replicaDB, replica, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = primaryDB.Close()
+		_ = replicaDB.Close()
+	})
+
+	router := repository.NewDBRouter(primaryDB, replicaDB).WithCheckInterval(checkInterval)
+	prs := service.NewPRService(primaryDB, service.NewReviewerAssigner()).WithDBRouter(router)
+	return routedServices{
+		primary: primary,
+		replica: replica,
+		prs:     prs,
+		teams:   service.NewTeamService(primaryDB, prs),
+		users:   service.NewUserService(primaryDB, prs),
+		stats:   service.NewStatsService(primaryDB, service.NewSystemClock()).WithDBRouter(router),
+	}
+}
+
+func (s routedServices) assertExpectations(t *testing.T) {
+	t.Helper()
+	assert.NoError(t, s.primary.ExpectationsWereMet(), "primary")
+	assert.NoError(t, s.replica.ExpectationsWereMet(), "replica")
+}
+
+var routedPRKey = domain.PRKey{PullRequestID: "pr-1"}
+
+func TestDBRouter_ReadsGoToReplica(t *testing.T) {
+	s := newRoutedServices(t, time.Hour)
+
+	s.replica.ExpectPing()
+	s.replica.ExpectQuery("FROM teams").WillReturnError(errors.New("no rows"))
+	s.replica.ExpectQuery("FROM pull_requests pr\\s+JOIN pr_reviewers").
+		WillReturnRows(sqlmock.NewRows([]string{"repository_name", "pull_request_id", "pull_request_name", "author_id", "team_name", "status",
+			"assigned_at", "approved", "review_sla_hours"}).
+			AddRow("", "pr-1", "Add search", "u1", "backend", "OPEN", time.Now(), false, 24))
+	s.replica.ExpectQuery("FROM pull_requests").WillReturnRows(sqlmock.NewRows([]string{"pull_request_id"}))
+	s.replica.ExpectQuery("FROM users u").WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+
+	_, err := s.teams.GetTeam(t.Context(), "backend")
+	require.Error(t, err)
+
+	reviews, err := s.users.GetUserReviews(t.Context(), "u2", nil)
+	require.NoError(t, err)
+	require.Len(t, reviews, 1)
+	assert.Equal(t, "pr-1", reviews[0].PullRequestID)
+
+	_, err = s.prs.GetPR(t.Context(), routedPRKey)
+	assert.ErrorIs(t, err, service.ErrPRNotFound)
+
+	loads, err := s.stats.GetUserLoad(t.Context())
+	require.NoError(t, err)
+	assert.Empty(t, loads)
+
+	s.assertExpectations(t)
+}
+
+func TestDBRouter_WritesAndReadPrimaryStayOnPrimary(t *testing.T) {
+	s := newRoutedServices(t, time.Hour)
+
+	s.primary.ExpectBegin()
+	s.primary.ExpectQuery("FROM teams").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	s.primary.ExpectExec("UPDATE teams").WillReturnResult(sqlmock.NewResult(0, 1))
+	s.primary.ExpectCommit()
+	// UpdateTeam reads the team back from the primary, which already has the change.
+	s.primary.ExpectQuery("FROM teams").WillReturnError(errors.New("no rows"))
+	s.primary.ExpectQuery("FROM pull_requests").WillReturnRows(sqlmock.NewRows([]string{"pull_request_id"}))
+
+	approvals := 1
+	_, err := s.teams.UpdateTeam(t.Context(), "backend", service.TeamUpdate{RequireApprovals: &approvals})
+	require.Error(t, err)
+
+	_, err = s.prs.GetPR(service.ReadPrimary(t.Context()), routedPRKey)
+	assert.ErrorIs(t, err, service.ErrPRNotFound)
+
+	s.assertExpectations(t)
+}
+
+func TestDBRouter_FallsBackWhileReplicaIsDown(t *testing.T) {
+	s := newRoutedServices(t, 0)
+
+	s.replica.ExpectPing().WillReturnError(errors.New("connection refused"))
+	s.primary.ExpectQuery("FROM pull_requests").WillReturnRows(sqlmock.NewRows([]string{"pull_request_id"}))
+	s.replica.ExpectPing()
+	s.replica.ExpectQuery("FROM pull_requests").WillReturnRows(sqlmock.NewRows([]string{"pull_request_id"}))
+
+	_, err := s.prs.GetPR(t.Context(), routedPRKey)
+	assert.ErrorIs(t, err, service.ErrPRNotFound)
+	_, err = s.prs.GetPR(t.Context(), routedPRKey)
+	assert.ErrorIs(t, err, service.ErrPRNotFound)
+
+	s.assertExpectations(t)
+}
+
+func TestDBRouter_WithoutReplica(t *testing.T) {
+	primaryDB, primary, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = primaryDB.Close() }()
+
+	router := repository.NewDBRouter(primaryDB, nil)
+	assert.Same(t, primaryDB, router.Reader(t.Context()))
+	assert.NoError(t, primary.ExpectationsWereMet())
+}
@@ -0,0 +1,81 @@
+package unit_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/router"
+)
+
+// TestOpenAPI_CoversRoutes keeps the served specification in sync with the router.
+func TestOpenAPI_CoversRoutes(t *testing.T) {
+	r, err := router.SetupRoutes(router.Options{Mode: gin.TestMode}, nil, nil, nil, nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+
+	var spec struct {
+		OpenAPI string `json:"openapi"`
+		Servers []struct {
+			URL string `json:"url"`
+		} `json:"servers"`
+		Paths      map[string]map[string]any `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]struct {
+					Properties map[string]struct {
+						Enum []string `json:"enum"`
+					} `json:"properties"`
+				} `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &spec))
+	assert.True(t, strings.HasPrefix(spec.OpenAPI, "3."))
+	require.Len(t, spec.Servers, 1)
+	assert.Equal(t, router.APIPrefix, spec.Servers[0].URL)
+
+	for _, route := range r.Routes() {
+		path, versioned := strings.CutPrefix(route.Path, router.APIPrefix)
+		if !versioned {
+			continue
+		}
+		operations, ok := spec.Paths[path]
+		if assert.True(t, ok, "route %s %s is missing from the spec", route.Method, route.Path) {
+			assert.Contains(t, operations, strings.ToLower(route.Method), "route %s %s is missing from the spec", route.Method, route.Path)
+		}
+	}
+
+	codes := spec.Components.Schemas["ErrorResponse"].Properties["error"].Properties["code"].Enum
+	for _, code := range []string{"NOT_FOUND", "PAYLOAD_TOO_LARGE", "RATE_LIMITED", "VALIDATION_ERROR", "UNAUTHORIZED", "NOT_APPROVED", "TIMEOUT", "INTERNAL", "SERVICE_UNAVAILABLE"} {
+		assert.Contains(t, codes, code)
+	}
+}
+
+func TestSwaggerUI(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		r, err := router.SetupRoutes(router.Options{Mode: gin.TestMode, DocsUI: enabled}, nil, nil, nil, nil, nil, nil, nil, nil)
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+
+		if !enabled {
+			assert.Equal(t, http.StatusNotFound, w.Code)
+			continue
+		}
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "text/html")
+		assert.Contains(t, w.Body.String(), `url: "/openapi.json"`)
+	}
+}
@@ -0,0 +1,178 @@
+package unit_tests
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/integration"
+	"github.com/mishasvintus/avito_backend_internship/internal/router"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+// gitHubFixture reads a captured GitHub API response from tests/testdata/github.
+func gitHubFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	body, err := os.ReadFile(filepath.Join("..", "testdata", "github", name))
+	require.NoError(t, err)
+	return body
+}
+
+// fakeGitHub serves the fixtures of the acme organization, splitting the team list into two pages.
+func fakeGitHub(t *testing.T) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer gh-token", r.Header.Get("Authorization"))
+		assert.Equal(t, "application/vnd.github+json", r.Header.Get("Accept"))
+		assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+
+		switch {
+		case r.URL.Path == "/orgs/acme/teams" && r.URL.Query().Get("page") == "":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/orgs/acme/teams?per_page=100&page=2>; rel="next", <%s/orgs/acme/teams?per_page=100&page=2>; rel="last"`,
+				server.URL, server.URL))
+			_, _ = w.Write(gitHubFixture(t, "teams_page_1.json"))
+		case r.URL.Path == "/orgs/acme/teams":
+			_, _ = w.Write(gitHubFixture(t, "teams_page_2.json"))
+		case strings.HasPrefix(r.URL.Path, "/orgs/acme/teams/") && strings.HasSuffix(r.URL.Path, "/members"):
+			slug := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/orgs/acme/teams/"), "/members")
+			_, _ = w.Write(gitHubFixture(t, "members_"+slug+".json"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGitHubRESTClient_ListTeams(t *testing.T) {
+	server := fakeGitHub(t)
+
+	teams, err := integration.NewGitHubRESTClient("gh-token", "acme").
+		WithBaseURL(server.URL).
+		WithHTTPClient(server.Client()).
+		ListTeams(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, []integration.GitHubTeam{
+		{Slug: "backend", Name: "Backend", Members: []string{"alice-dev", "bob"}},
+		{Slug: "platform-ops", Name: "Platform Ops", Members: []string{"bob"}},
+	}, teams)
+}
+
+func TestGitHubRESTClient_ListTeamsFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message":"Bad credentials"}`))
+	}))
+	defer server.Close()
+
+	_, err := integration.NewGitHubRESTClient("wrong", "acme").WithBaseURL(server.URL).ListTeams(t.Context())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "401")
+}
+
+func TestIntegrationHandler_SyncGitHubTeams(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		mockSetup      func(*handlermocks.MockGitHubSyncServiceInterface)
+		expectedStatus int
+		expectedCode   handler.ErrorCode
+		expectedBody   string
+	}{
+		{
+			name: "success",
+			mockSetup: func(m *handlermocks.MockGitHubSyncServiceInterface) {
+				m.EXPECT().SyncTeams(mock.Anything).Return(&service.TeamSyncSummary{
+					TeamsCreated:     []string{"platform-ops"},
+					UsersCreated:     []string{"alice-dev"},
+					UsersUpdated:     []string{"bob"},
+					UsersDeactivated: []string{},
+					Skipped:          []service.SkippedLogin{{TeamName: "backend", Login: "carol", Reason: service.SkipUserIDTaken}},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"teams_created":["platform-ops"],"users_created":["alice-dev"],"users_updated":["bob"],
+				"users_deactivated":[],"skipped":[{"team_name":"backend","login":"carol","reason":"user_id_taken"}]}`,
+		},
+		{
+			name: "error - github unavailable",
+			mockSetup: func(m *handlermocks.MockGitHubSyncServiceInterface) {
+				m.EXPECT().SyncTeams(mock.Anything).
+					Return(nil, fmt.Errorf("%w: %v", service.ErrGitHubUnavailable, errors.New("responded 401")))
+			},
+			expectedStatus: http.StatusBadGateway,
+			expectedCode:   handler.ErrorUpstream,
+		},
+		{
+			name: "error - sync disabled",
+			mockSetup: func(m *handlermocks.MockGitHubSyncServiceInterface) {
+				m.EXPECT().SyncTeams(mock.Anything).Return(nil, service.ErrGitHubSyncDisabled)
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedCode:   handler.ErrorServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockGitHubSyncServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/integrations/github/syncTeams", nil)
+
+			handler.NewIntegrationHandler(handlermocks.NewMockIntegrationServiceInterface(t)).
+				WithGitHubSync(mockService).
+				SyncGitHubTeams(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, w.Body.String())
+				return
+			}
+			var response handler.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedCode, response.Error.Code)
+		})
+	}
+}
+
+func TestIntegrationHandler_SyncGitHubTeamsNotConfigured(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/integrations/github/syncTeams", nil)
+
+	handler.NewIntegrationHandler(handlermocks.NewMockIntegrationServiceInterface(t)).SyncGitHubTeams(c)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestIntegrationHandler_SyncGitHubTeamsRequiresAdmin(t *testing.T) {
+	integrationHandler := handler.NewIntegrationHandler(handlermocks.NewMockIntegrationServiceInterface(t)).
+		WithGitHubSync(handlermocks.NewMockGitHubSyncServiceInterface(t))
+	r, err := router.SetupRoutes(router.Options{Mode: gin.TestMode, AdminAPIKeys: []string{"secret"}},
+		nil, nil, nil, nil, nil, integrationHandler, nil, nil)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, router.APIPrefix+"/integrations/github/syncTeams", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,317 @@
+package unit_tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/integration"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+// gitLabFixture reads a captured GitLab webhook payload from tests/testdata/gitlab.
+func gitLabFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	body, err := os.ReadFile(filepath.Join("..", "testdata", "gitlab", name))
+	require.NoError(t, err)
+	return body
+}
+
+func TestParseGitLabMergeRequest(t *testing.T) {
+	key := domain.PRKey{RepositoryName: "platform/backend", PullRequestID: "42"}
+	description := "Adds SSO login.\n\nCloses #7"
+	url := "https://gitlab.example.com/platform/backend/-/merge_requests/42"
+
+	tests := []struct {
+		fixture  string
+		expected integration.Command
+	}{
+		{
+			fixture: "merge_request_open.json",
+			expected: integration.Command{
+				Provider:    integration.ProviderGitLab,
+				Action:      integration.ActionOpen,
+				Key:         key,
+				Title:       "Add login via SSO",
+				Description: &description,
+				ExternalURL: &url,
+				ActorLogin:  "alice.smith",
+			},
+		},
+		{
+			fixture: "merge_request_merge.json",
+			expected: integration.Command{
+				Provider:    integration.ProviderGitLab,
+				Action:      integration.ActionMerge,
+				Key:         key,
+				Title:       "Add login via SSO",
+				Description: &description,
+				ExternalURL: &url,
+				ActorLogin:  "bob.jones",
+			},
+		},
+		{
+			fixture: "merge_request_close.json",
+			expected: integration.Command{
+				Provider:    integration.ProviderGitLab,
+				Action:      integration.ActionClose,
+				Key:         key,
+				Title:       "Add login via SSO",
+				Description: &description,
+				ExternalURL: &url,
+				ActorLogin:  "alice.smith",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			cmd, err := integration.ParseGitLabMergeRequest(gitLabFixture(t, tt.fixture))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, cmd)
+		})
+	}
+
+	t.Run("other actions are ignored", func(t *testing.T) {
+		_, err := integration.ParseGitLabMergeRequest(gitLabFixture(t, "merge_request_update.json"))
+		assert.ErrorIs(t, err, integration.ErrIgnoredEvent)
+	})
+
+	t.Run("long titles are truncated", func(t *testing.T) {
+		var hook map[string]any
+		require.NoError(t, json.Unmarshal(gitLabFixture(t, "merge_request_open.json"), &hook))
+		hook["object_attributes"].(map[string]any)["title"] = strings.Repeat("я", 300)
+		body, err := json.Marshal(hook)
+		require.NoError(t, err)
+
+		cmd, err := integration.ParseGitLabMergeRequest(body)
+		require.NoError(t, err)
+		assert.Equal(t, strings.Repeat("я", 255), cmd.Title)
+	})
+
+	for name, body := range map[string]string{
+		"malformed json":        `{"object_kind":`,
+		"not a merge request":   `{"object_kind":"push"}`,
+		"missing merge request": `{"object_kind":"merge_request","user":{"username":"a"},"project":{"path_with_namespace":"p"},"object_attributes":{"action":"open"}}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := integration.ParseGitLabMergeRequest([]byte(body))
+			assert.ErrorIs(t, err, integration.ErrInvalidPayload)
+		})
+	}
+}
+
+func TestIntegrationHandler_GitLabWebhook(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	openFixture := gitLabFixture(t, "merge_request_open.json")
+	opened := &domain.PullRequest{
+		RepositoryName:  "platform/backend",
+		PullRequestID:   "42",
+		PullRequestName: "Add login via SSO",
+		AuthorID:        "u1",
+		Status:          domain.StatusOpen,
+	}
+
+	tests := []struct {
+		name            string
+		token           string
+		event           string
+		body            []byte
+		mockSetup       func(*handlermocks.MockIntegrationServiceInterface)
+		expectedStatus  int
+		expectedMessage string
+	}{
+		{
+			name:  "opened merge request creates the pull request",
+			token: "gl-secret",
+			event: integration.GitLabMergeRequestEvent,
+			body:  openFixture,
+			mockSetup: func(m *handlermocks.MockIntegrationServiceInterface) {
+				m.EXPECT().Apply(mock.Anything, mockCommand(integration.ActionOpen, "alice.smith")).Return(opened, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:            "wrong token",
+			token:           "guess",
+			event:           integration.GitLabMergeRequestEvent,
+			body:            openFixture,
+			mockSetup:       func(m *handlermocks.MockIntegrationServiceInterface) {},
+			expectedStatus:  http.StatusUnauthorized,
+			expectedMessage: "invalid X-Gitlab-Token",
+		},
+		{
+			name:            "missing token",
+			event:           integration.GitLabMergeRequestEvent,
+			body:            openFixture,
+			mockSetup:       func(m *handlermocks.MockIntegrationServiceInterface) {},
+			expectedStatus:  http.StatusUnauthorized,
+			expectedMessage: "invalid X-Gitlab-Token",
+		},
+		{
+			name:            "other event types are ignored",
+			token:           "gl-secret",
+			event:           "Push Hook",
+			body:            []byte(`{"object_kind":"push"}`),
+			mockSetup:       func(m *handlermocks.MockIntegrationServiceInterface) {},
+			expectedStatus:  http.StatusOK,
+			expectedMessage: "event ignored",
+		},
+		{
+			name:            "other merge request actions are ignored",
+			token:           "gl-secret",
+			event:           integration.GitLabMergeRequestEvent,
+			body:            gitLabFixture(t, "merge_request_update.json"),
+			mockSetup:       func(m *handlermocks.MockIntegrationServiceInterface) {},
+			expectedStatus:  http.StatusOK,
+			expectedMessage: "event ignored",
+		},
+		{
+			name:            "invalid payload",
+			token:           "gl-secret",
+			event:           integration.GitLabMergeRequestEvent,
+			body:            []byte(`{"object_kind":`),
+			mockSetup:       func(m *handlermocks.MockIntegrationServiceInterface) {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: "invalid webhook payload",
+		},
+		{
+			name:  "author login not mapped",
+			token: "gl-secret",
+			event: integration.GitLabMergeRequestEvent,
+			body:  openFixture,
+			mockSetup: func(m *handlermocks.MockIntegrationServiceInterface) {
+				m.EXPECT().Apply(mock.Anything, mockCommand(integration.ActionOpen, "alice.smith")).Return(nil, service.ErrUnknownExternalLogin)
+			},
+			expectedStatus:  http.StatusNotFound,
+			expectedMessage: "no user is mapped to the external login",
+		},
+		{
+			name:  "closing a merged pull request",
+			token: "gl-secret",
+			event: integration.GitLabMergeRequestEvent,
+			body:  gitLabFixture(t, "merge_request_close.json"),
+			mockSetup: func(m *handlermocks.MockIntegrationServiceInterface) {
+				m.EXPECT().Apply(mock.Anything, mockCommand(integration.ActionClose, "alice.smith")).Return(nil, service.ErrPRMerged)
+			},
+			expectedStatus:  http.StatusConflict,
+			expectedMessage: "cannot close merged PR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockIntegrationServiceInterface(t)
+			tt.mockSetup(mockService)
+			h := handler.NewIntegrationHandler(mockService).WithGitLabToken("gl-secret")
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/integrations/gitlab/webhook", bytes.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Request.Header.Set(integration.GitLabEventHeader, tt.event)
+			if tt.token != "" {
+				c.Request.Header.Set(integration.GitLabTokenHeader, tt.token)
+			}
+
+			h.GitLabWebhook(c)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			if tt.expectedMessage != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedMessage)
+				return
+			}
+			var response handler.WebhookEventResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			require.NotNil(t, response.PR)
+			assert.Equal(t, "42", response.PR.PullRequestID)
+			assert.Equal(t, "platform/backend", response.PR.RepositoryName)
+		})
+	}
+
+	t.Run("integration without a configured token rejects everything", func(t *testing.T) {
+		h := handler.NewIntegrationHandler(handlermocks.NewMockIntegrationServiceInterface(t))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/integrations/gitlab/webhook", bytes.NewReader(openFixture))
+		c.Request.Header.Set(integration.GitLabEventHeader, integration.GitLabMergeRequestEvent)
+		c.Request.Header.Set(integration.GitLabTokenHeader, "")
+
+		h.GitLabWebhook(c)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+// mockCommand matches a command of the fixture merge request with the given action and actor.
+func mockCommand(action integration.Action, actorLogin string) any {
+	return mock.MatchedBy(func(cmd integration.Command) bool {
+		return cmd.Provider == integration.ProviderGitLab && cmd.Action == action && cmd.ActorLogin == actorLogin &&
+			cmd.Key == domain.PRKey{RepositoryName: "platform/backend", PullRequestID: "42"}
+	})
+}
+
+func TestIntegrationHandler_MapLogin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		body           string
+		mockSetup      func(*handlermocks.MockIntegrationServiceInterface)
+		expectedStatus int
+	}{
+		{
+			name: "success",
+			body: `{"provider":"gitlab","external_login":"alice.smith","user_id":"u1"}`,
+			mockSetup: func(m *handlermocks.MockIntegrationServiceInterface) {
+				m.EXPECT().MapLogin(mock.Anything, &domain.ExternalLogin{Provider: "gitlab", Login: "alice.smith", UserID: "u1"}).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "unknown provider",
+			body:           `{"provider":"bitbucket","external_login":"alice.smith","user_id":"u1"}`,
+			mockSetup:      func(m *handlermocks.MockIntegrationServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "user not found",
+			body: `{"provider":"gitlab","external_login":"alice.smith","user_id":"ghost"}`,
+			mockSetup: func(m *handlermocks.MockIntegrationServiceInterface) {
+				m.EXPECT().MapLogin(mock.Anything, &domain.ExternalLogin{Provider: "gitlab", Login: "alice.smith", UserID: "ghost"}).Return(service.ErrUserNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockIntegrationServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/integrations/logins", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler.NewIntegrationHandler(mockService).MapLogin(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+		})
+	}
+}
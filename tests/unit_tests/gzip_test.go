@@ -0,0 +1,108 @@
+package unit_tests
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/stats"
+	"github.com/mishasvintus/avito_backend_internship/internal/router"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func largeStatistics() *service.Statistics {
+	statistics := &service.Statistics{Overall: &stats.OverallStats{TotalUsers: 500}}
+	for i := range 500 {
+		statistics.ReviewerStats = append(statistics.ReviewerStats, stats.ReviewerStat{
+			UserID: fmt.Sprintf("user-%d", i), Username: fmt.Sprintf("User %d", i), Count: int64(i),
+		})
+	}
+	return statistics
+}
+
+func newGzipRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+
+	mockService := handlermocks.NewMockStatsServiceInterface(t)
+	mockService.EXPECT().GetStatistics(mock.Anything, stats.Period{}).Return(largeStatistics(), nil).Maybe()
+
+	r, err := router.SetupRoutes(router.Options{Mode: gin.TestMode, GzipMinSize: 1024},
+		nil, nil, nil, handler.NewStatsHandler(mockService), nil, nil, nil, nil)
+	require.NoError(t, err)
+	r.GET("/test/small", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+	r.GET("/metrics", func(c *gin.Context) {
+		c.String(http.StatusOK, strings.Repeat("http_requests_total 1\n", 200))
+	})
+	return r
+}
+
+func TestGzip_LargeStatistics(t *testing.T) {
+	r := newGzipRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/stats", nil)
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	assert.Contains(t, w.Header().Values("Vary"), "Accept-Encoding")
+
+	reader, err := gzip.NewReader(w.Body)
+	require.NoError(t, err)
+	body, err := io.ReadAll(reader)
+	require.NoError(t, err)
+
+	var response handler.StatisticsResponse
+	require.NoError(t, json.Unmarshal(body, &response))
+	assert.Len(t, response.ReviewerStats, 500)
+	assert.Less(t, w.Body.Len(), len(body))
+}
+
+func TestGzip_Plain(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		acceptEncoding string
+	}{
+		{name: "client without Accept-Encoding", path: "/stats", acceptEncoding: ""},
+		{name: "gzip refused", path: "/stats", acceptEncoding: "gzip;q=0, identity"},
+		{name: "small body", path: "/test/small", acceptEncoding: "gzip"},
+		{name: "metrics endpoint", path: "/metrics", acceptEncoding: "gzip"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newGzipRouter(t)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			assert.Empty(t, w.Header().Get("Content-Encoding"))
+			if tt.path == "/stats" {
+				var response handler.StatisticsResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Len(t, response.ReviewerStats, 500)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package unit_tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/middleware"
+	"github.com/mishasvintus/avito_backend_internship/internal/router"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+// serverTiming returns the total duration in milliseconds from a Server-Timing header.
+func serverTiming(t *testing.T, header string) float64 {
+	t.Helper()
+	value, ok := strings.CutPrefix(header, "total;dur=")
+	require.True(t, ok, "unexpected Server-Timing %q", header)
+	dur, err := strconv.ParseFloat(value, 64)
+	require.NoError(t, err)
+	return dur
+}
+
+func TestLatency(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var logs bytes.Buffer
+	registry := prometheus.NewRegistry()
+	budget := middleware.NewLatencyBudget(time.Second, map[string]time.Duration{"/stats/coverage": 20 * time.Millisecond},
+		registry, slog.New(slog.NewJSONHandler(&logs, nil)))
+
+	// The fake service answers slower than the coverage budget but well within the default one.
+	statsService := handlermocks.NewMockStatsServiceInterface(t)
+	statsService.EXPECT().GetCoverage(mock.Anything).RunAndReturn(func(context.Context) (*service.Coverage, error) {
+		time.Sleep(50 * time.Millisecond)
+		return &service.Coverage{Teams: []service.TeamCoverage{}}, nil
+	}).Maybe()
+	statsHandler := handler.NewStatsHandler(statsService)
+
+	r := gin.New()
+	r.Use(middleware.RequestID(), middleware.Latency(budget, router.APIPrefix))
+	r.GET(router.APIPrefix+"/stats/coverage", statsHandler.GetCoverage)
+	r.GET("/stats/coverage", statsHandler.GetCoverage)
+	// /team/workload keeps the default budget.
+	r.GET(router.APIPrefix+"/team/workload", statsHandler.GetCoverage)
+	r.POST(router.APIPrefix+"/ping", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	slowCount := func() map[string]float64 {
+		t.Helper()
+		families, err := registry.Gather()
+		require.NoError(t, err)
+		counts := make(map[string]float64)
+		for _, f := range families {
+			if f.GetName() != "http_slow_requests_total" {
+				continue
+			}
+			for _, m := range f.GetMetric() {
+				counts[m.GetLabel()[0].GetValue()] = m.GetCounter().GetValue()
+			}
+		}
+		return counts
+	}
+	serve := func(method, target string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, nil)
+		req.Header.Set(middleware.RequestIDHeader, "req-slow")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("request over the route budget is logged and counted", func(t *testing.T) {
+		logs.Reset()
+		w := serve(http.MethodGet, router.APIPrefix+"/stats/coverage?team_name=backend")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.GreaterOrEqual(t, serverTiming(t, w.Header().Get(middleware.ServerTimingHeader)), 50.0)
+		assert.Equal(t, map[string]float64{"/stats/coverage": 1}, slowCount())
+
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal(logs.Bytes(), &entry))
+		assert.Equal(t, "WARN", entry["level"])
+		assert.Equal(t, "slow request", entry["msg"])
+		assert.Equal(t, "req-slow", entry["request_id"])
+		assert.Equal(t, "/stats/coverage", entry["route"])
+		assert.Equal(t, map[string]any{"team_name": "backend"}, entry["params"])
+		assert.EqualValues(t, http.StatusOK, entry["status"])
+		assert.EqualValues(t, 20, entry["budget_ms"])
+		assert.GreaterOrEqual(t, entry["duration_ms"], 50.0)
+	})
+
+	t.Run("legacy alias shares the route", func(t *testing.T) {
+		serve(http.MethodGet, "/stats/coverage")
+		assert.Equal(t, map[string]float64{"/stats/coverage": 2}, slowCount())
+	})
+
+	t.Run("request within the default budget", func(t *testing.T) {
+		logs.Reset()
+		w := serve(http.MethodGet, router.APIPrefix+"/team/workload")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.GreaterOrEqual(t, serverTiming(t, w.Header().Get(middleware.ServerTimingHeader)), 50.0)
+		assert.Empty(t, logs.String())
+		assert.Equal(t, map[string]float64{"/stats/coverage": 2}, slowCount())
+	})
+
+	t.Run("response without a body", func(t *testing.T) {
+		w := serve(http.MethodPost, router.APIPrefix+"/ping")
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.NotEmpty(t, w.Header().Get(middleware.ServerTimingHeader))
+	})
+
+	t.Run("unmatched route", func(t *testing.T) {
+		w := serve(http.MethodGet, "/missing")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.NotEmpty(t, w.Header().Get(middleware.ServerTimingHeader))
+	})
+}
+
+func TestLatency_WithoutBudget(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(middleware.Latency(nil, router.APIPrefix))
+	r.GET("/test", func(c *gin.Context) { c.String(http.StatusOK, "ok") })
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test", nil))
+	assert.Equal(t, "ok", w.Body.String())
+	assert.GreaterOrEqual(t, serverTiming(t, w.Header().Get(middleware.ServerTimingHeader)), 0.0)
+}
@@ -0,0 +1,178 @@
+package unit_tests
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/lifecycle"
+)
+
+// fakeComponent records its start and stop in a shared journal.
+type fakeComponent struct {
+	name     string
+	journal  *journal
+	startErr error
+	stopErr  error
+	// stopDelay keeps Stop busy, ignoring its context.
+	stopDelay time.Duration
+	errs      chan error
+}
+
+func (c *fakeComponent) Start(context.Context) error {
+	c.journal.add("start " + c.name)
+	return c.startErr
+}
+
+func (c *fakeComponent) Stop(context.Context) error {
+	time.Sleep(c.stopDelay)
+	c.journal.add("stop " + c.name)
+	return c.stopErr
+}
+
+// failingComponent is a fakeComponent that can fail after starting.
+type failingComponent struct {
+	*fakeComponent
+}
+
+func (c failingComponent) Errors() <-chan error {
+	return c.errs
+}
+
+type journal struct {
+	mu      sync.Mutex
+	entries []string
+}
+
+func (j *journal) add(entry string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.entries = append(j.entries, entry)
+}
+
+func (j *journal) get() []string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return append([]string(nil), j.entries...)
+}
+
+func TestManager_StartsInOrderAndStopsInReverse(t *testing.T) {
+	j := &journal{}
+	m := lifecycle.New().
+		Add("database", &fakeComponent{name: "database", journal: j}, time.Second).
+		Add("worker", &fakeComponent{name: "worker", journal: j}, time.Second).
+		Add("http", &fakeComponent{name: "http", journal: j}, time.Second)
+
+	require.NoError(t, m.Start(t.Context()))
+	assert.Equal(t, []string{"start database", "start worker", "start http"}, j.get())
+
+	require.NoError(t, m.Stop())
+	assert.Equal(t, []string{
+		"start database", "start worker", "start http",
+		"stop http", "stop worker", "stop database",
+	}, j.get())
+
+	// Stopping again does not stop the components twice.
+	require.NoError(t, m.Stop())
+	assert.Len(t, j.get(), 6)
+}
+
+func TestManager_StartFailureStopsStartedComponents(t *testing.T) {
+	j := &journal{}
+	m := lifecycle.New().
+		Add("database", &fakeComponent{name: "database", journal: j}, time.Second).
+		Add("worker", &fakeComponent{name: "worker", journal: j}, time.Second).
+		Add("http", &fakeComponent{name: "http", journal: j, startErr: errors.New("address already in use")}, time.Second).
+		Add("late", &fakeComponent{name: "late", journal: j}, time.Second)
+
+	err := m.Start(t.Context())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to start http: address already in use")
+	assert.Equal(t, []string{
+		"start database", "start worker", "start http",
+		"stop worker", "stop database",
+	}, j.get())
+}
+
+func TestManager_Wait(t *testing.T) {
+	t.Run("first fatal error is returned", func(t *testing.T) {
+		j := &journal{}
+		first := failingComponent{&fakeComponent{name: "http", journal: j, errs: make(chan error, 1)}}
+		second := failingComponent{&fakeComponent{name: "grpc", journal: j, errs: make(chan error, 1)}}
+		m := lifecycle.New().
+			Add("http", first, time.Second).
+			Add("grpc", second, time.Second)
+		require.NoError(t, m.Start(t.Context()))
+
+		listenerErr := errors.New("listener closed")
+		first.errs <- listenerErr
+		err := m.Wait(t.Context())
+		require.ErrorIs(t, err, listenerErr)
+		assert.Contains(t, err.Error(), "http failed")
+
+		// Components keep running until Stop.
+		assert.Equal(t, []string{"start http", "start grpc"}, j.get())
+		second.errs <- errors.New("late failure")
+		require.NoError(t, m.Stop())
+		assert.Equal(t, []string{"start http", "start grpc", "stop grpc", "stop http"}, j.get())
+	})
+
+	t.Run("returns nil when the context is done", func(t *testing.T) {
+		m := lifecycle.New().Add("worker", &fakeComponent{name: "worker", journal: &journal{}}, time.Second)
+		require.NoError(t, m.Start(t.Context()))
+
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		assert.NoError(t, m.Wait(ctx))
+		require.NoError(t, m.Stop())
+	})
+}
+
+func TestManager_StopTimeout(t *testing.T) {
+	j := &journal{}
+	m := lifecycle.New().
+		Add("database", &fakeComponent{name: "database", journal: j}, time.Second).
+		Add("stuck", &fakeComponent{name: "stuck", journal: j, stopDelay: time.Second}, 50*time.Millisecond).
+		Add("http", &fakeComponent{name: "http", journal: j, stopErr: errors.New("drain failed")}, time.Second)
+	require.NoError(t, m.Start(t.Context()))
+
+	began := time.Now()
+	err := m.Stop()
+	assert.Less(t, time.Since(began), 500*time.Millisecond, "a stuck component must not hold up the others")
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "failed to stop stuck")
+	assert.Contains(t, err.Error(), "failed to stop http: drain failed")
+	// The component after the stuck one is still stopped, before it finishes.
+	assert.Equal(t, []string{"start database", "start stuck", "start http", "stop http", "stop database"}, j.get())
+}
+
+func TestWorker(t *testing.T) {
+	t.Run("stop cancels the loop and waits for it", func(t *testing.T) {
+		var stopped bool
+		w := lifecycle.Worker(func(ctx context.Context) {
+			<-ctx.Done()
+			stopped = true
+		})
+		require.NoError(t, w.Start(t.Context()))
+		require.NoError(t, w.Stop(t.Context()))
+		assert.True(t, stopped)
+	})
+
+	t.Run("loop ignoring cancellation", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+		w := lifecycle.Worker(func(context.Context) { <-release })
+		require.NoError(t, w.Start(t.Context()))
+
+		ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, w.Stop(ctx), context.DeadlineExceeded)
+	})
+}
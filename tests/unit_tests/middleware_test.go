@@ -0,0 +1,72 @@
+package unit_tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/middleware"
+	"github.com/mishasvintus/avito_backend_internship/internal/router"
+)
+
+func TestRecovery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var logs bytes.Buffer
+	r := gin.New()
+	r.Use(middleware.RequestID(), middleware.Recovery(slog.New(slog.NewJSONHandler(&logs, nil))))
+	r.GET("/test/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+
+	before := middleware.PanicsTotal.Value()
+
+	req := httptest.NewRequest(http.MethodGet, "/test/panic", nil)
+	req.Header.Set(middleware.RequestIDHeader, "req-42")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, "req-42", w.Header().Get(middleware.RequestIDHeader))
+
+	var response handler.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, handler.ErrorInternal, response.Error.Code)
+	assert.Equal(t, "internal server error", response.Error.Message)
+
+	assert.Equal(t, before+1, middleware.PanicsTotal.Value())
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(logs.Bytes(), &entry))
+	assert.Equal(t, "panic recovered", entry["msg"])
+	assert.Equal(t, "boom", entry["panic"])
+	assert.Equal(t, "req-42", entry["request_id"])
+	assert.Equal(t, "/test/panic", entry["path"])
+	assert.Contains(t, entry["stack"], "runtime/debug.Stack")
+}
+
+func TestSetupRoutes_RecoversPanics(t *testing.T) {
+	r, err := router.SetupRoutes(router.Options{Mode: gin.TestMode}, nil, nil, nil, nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+	r.GET("/test/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test/panic", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.NotEmpty(t, w.Header().Get(middleware.RequestIDHeader))
+
+	var response handler.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, handler.ErrorInternal, response.Error.Code)
+}
@@ -0,0 +1,206 @@
+package unit_tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/middleware"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+	"github.com/mishasvintus/avito_backend_internship/internal/router"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func TestOrgHandler_CreateOrg(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	createdAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		body             string
+		mockSetup        func(*handlermocks.MockOrgServiceInterface)
+		expectedStatus   int
+		validateResponse func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "success",
+			body: `{"org_id":"acme","name":"Acme Corp"}`,
+			mockSetup: func(m *handlermocks.MockOrgServiceInterface) {
+				m.EXPECT().CreateOrg(mock.Anything, "acme", "Acme Corp").
+					Return(&domain.Organization{OrgID: "acme", Name: "Acme Corp", CreatedAt: createdAt}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.JSONEq(t,
+					`{"organization":{"org_id":"acme","name":"Acme Corp","created_at":"2026-03-01T12:00:00Z"}}`,
+					w.Body.String())
+			},
+		},
+		{
+			name: "error - organization exists",
+			body: `{"org_id":"acme","name":"Acme Corp"}`,
+			mockSetup: func(m *handlermocks.MockOrgServiceInterface) {
+				m.EXPECT().CreateOrg(mock.Anything, "acme", "Acme Corp").Return(nil, service.ErrOrgExists)
+			},
+			expectedStatus: http.StatusConflict,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, handler.ErrorOrgExists, response.Error.Code)
+			},
+		},
+		{
+			name:           "error - invalid org_id",
+			body:           `{"org_id":"Acme Corp","name":"Acme Corp"}`,
+			mockSetup:      func(m *handlermocks.MockOrgServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Len(t, response.Error.Details, 1)
+				assert.Equal(t, "org_id", response.Error.Details[0].Field)
+				assert.Equal(t, "org_id", response.Error.Details[0].Rule)
+			},
+		},
+		{
+			name:           "error - missing name",
+			body:           `{"org_id":"acme"}`,
+			mockSetup:      func(m *handlermocks.MockOrgServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Len(t, response.Error.Details, 1)
+				assert.Equal(t, "name", response.Error.Details[0].Field)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockOrgServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/admin/orgs", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler.NewOrgHandler(mockService).CreateOrg(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			tt.validateResponse(t, w)
+		})
+	}
+}
+
+func TestOrgHandler_ListOrgs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	createdAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	mockService := handlermocks.NewMockOrgServiceInterface(t)
+	mockService.EXPECT().ListOrgs(mock.Anything).Return([]domain.Organization{
+		{OrgID: "acme", Name: "Acme Corp", CreatedAt: createdAt},
+		{OrgID: domain.DefaultOrgID, Name: "Default", CreatedAt: createdAt},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/admin/orgs", nil)
+
+	handler.NewOrgHandler(mockService).ListOrgs(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Organizations []handler.OrgResponse `json:"organizations"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Organizations, 2)
+	assert.Equal(t, "acme", response.Organizations[0].OrgID)
+	assert.Equal(t, domain.DefaultOrgID, response.Organizations[1].OrgID)
+}
+
+func TestOrgHandler_RequiresAdmin(t *testing.T) {
+	r, err := router.SetupRoutes(router.Options{Mode: gin.TestMode, AdminAPIKeys: []string{"secret"}},
+		nil, nil, nil, nil, nil, nil, nil, handler.NewOrgHandler(handlermocks.NewMockOrgServiceInterface(t)))
+	require.NoError(t, err)
+
+	for _, method := range []string{http.MethodGet, http.MethodPost} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(method, router.APIPrefix+"/admin/orgs", strings.NewReader(`{}`)))
+		assert.Equal(t, http.StatusUnauthorized, w.Code, method)
+	}
+}
+
+// stubOrgResolver knows a fixed set of organizations.
+type stubOrgResolver map[string]bool
+
+func (s stubOrgResolver) OrgExists(_ context.Context, orgID string) (bool, error) {
+	return s[orgID], nil
+}
+
+func TestOrgMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	serve := func(resolver middleware.OrgResolver, orgID string) (*httptest.ResponseRecorder, string) {
+		var seen string
+		r := gin.New()
+		r.Use(middleware.Org(resolver))
+		r.GET("/test", func(c *gin.Context) {
+			seen = repository.OrgFromContext(c.Request.Context())
+			c.Status(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		if orgID != "" {
+			req.Header.Set(middleware.OrgHeader, orgID)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w, seen
+	}
+
+	t.Run("no header uses the default organization", func(t *testing.T) {
+		w, seen := serve(nil, "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, domain.DefaultOrgID, seen)
+	})
+
+	t.Run("known organization scopes the request", func(t *testing.T) {
+		w, seen := serve(stubOrgResolver{"acme": true}, "acme")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "acme", seen)
+	})
+
+	t.Run("unknown organization is rejected", func(t *testing.T) {
+		w, seen := serve(stubOrgResolver{"acme": true}, "globex")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Empty(t, seen)
+
+		var response handler.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "organization not found", response.Error.Message)
+	})
+
+	t.Run("nil resolver serves only the default organization", func(t *testing.T) {
+		w, _ := serve(nil, "acme")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w, seen := serve(nil, domain.DefaultOrgID)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, domain.DefaultOrgID, seen)
+	})
+}
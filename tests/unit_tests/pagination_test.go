@@ -0,0 +1,111 @@
+package unit_tests
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/pagination"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/audit"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+)
+
+func TestCursor_EncodeDecode(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	cursor := pagination.Cursor{CreatedAt: time.Date(2026, 3, 2, 12, 30, 0, 123456000, moscow), ID: "backend-api/pr-1001"}
+
+	encoded := cursor.Encode()
+	assert.NotContains(t, encoded, "pr-1001", "cursors are opaque")
+	assert.NotContains(t, encoded, "=")
+
+	decoded, err := pagination.Decode(encoded)
+	require.NoError(t, err)
+	assert.True(t, decoded.CreatedAt.Equal(cursor.CreatedAt), "microseconds are kept: %s", decoded.CreatedAt)
+	assert.Equal(t, time.UTC, decoded.CreatedAt.Location())
+	assert.Equal(t, cursor.ID, decoded.ID)
+	assert.Equal(t, encoded, decoded.Encode())
+
+	for name, raw := range map[string]string{
+		"not base64":   "not a cursor!",
+		"not JSON":     base64.RawURLEncoding.EncodeToString([]byte("backend/pr-1")),
+		"missing id":   base64.RawURLEncoding.EncodeToString([]byte(`{"t":"2026-03-02T09:30:00Z"}`)),
+		"missing time": base64.RawURLEncoding.EncodeToString([]byte(`{"id":"7"}`)),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := pagination.Decode(raw)
+			assert.ErrorIs(t, err, pagination.ErrInvalidCursor)
+		})
+	}
+}
+
+func TestNewPage(t *testing.T) {
+	at := time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC)
+	key := func(id string) pagination.Cursor { return pagination.Cursor{CreatedAt: at, ID: id} }
+
+	t.Run("fewer items than the limit", func(t *testing.T) {
+		page := pagination.NewPage([]string{"c", "b"}, 3, key)
+		assert.Equal(t, pagination.Page[string]{Items: []string{"c", "b"}}, page)
+	})
+
+	t.Run("exactly the limit", func(t *testing.T) {
+		page := pagination.NewPage([]string{"c", "b", "a"}, 3, key)
+		assert.Equal(t, []string{"c", "b", "a"}, page.Items)
+		assert.False(t, page.HasMore)
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("an extra item links the next page", func(t *testing.T) {
+		page := pagination.NewPage([]string{"d", "c", "b", "a"}, 3, key)
+		assert.Equal(t, []string{"d", "c", "b"}, page.Items)
+		assert.True(t, page.HasMore)
+		assert.Equal(t, key("b").Encode(), page.NextCursor)
+	})
+
+	t.Run("no items", func(t *testing.T) {
+		page := pagination.NewPage[string](nil, 3, key)
+		assert.Equal(t, []string{}, page.Items)
+	})
+}
+
+func TestCursor_PagesAreStableWhenRowsAreInserted(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	at := time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC)
+	columns := []string{"audit_id", "org_id", "actor", "action", "target", "request_id", "created_at"}
+	row := func(rows *sqlmock.Rows, id int64, createdAt time.Time) *sqlmock.Rows {
+		return rows.AddRow(id, "default", "system", domain.AuditTeamDeactivate, "team:backend", "", createdAt)
+	}
+	auditService := service.NewAuditService(db)
+
+	// Entries 4 and 3 share their creation time; the id orders them.
+	mock.ExpectQuery("FROM audit_log").
+		WithArgs(nil, nil, "", 0, 3, nil, nil).
+		WillReturnRows(row(row(row(sqlmock.NewRows(columns), 5, at.Add(time.Minute)), 4, at), 3, at))
+	first, err := auditService.ListAudit(t.Context(), audit.Filter{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, first.Items, 2)
+	assert.True(t, first.HasMore)
+
+	// Entry 6 is recorded before the next page is requested. The next page still starts right
+	// after entry 4: it is found by its sort key, not by how many entries precede it.
+	after, err := pagination.Decode(first.NextCursor)
+	require.NoError(t, err)
+	mock.ExpectQuery(`created_at < \$6 OR \(created_at = \$6 AND audit_id < \$7\)`).
+		WithArgs(nil, nil, "", 0, 3, at, "4").
+		WillReturnRows(row(row(sqlmock.NewRows(columns), 3, at), 2, at.Add(-time.Minute)))
+	second, err := auditService.ListAudit(t.Context(), audit.Filter{Limit: 2, After: after})
+	require.NoError(t, err)
+	require.Len(t, second.Items, 2)
+	assert.EqualValues(t, 3, second.Items[0].ID)
+	assert.EqualValues(t, 2, second.Items[1].ID)
+	assert.False(t, second.HasMore)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
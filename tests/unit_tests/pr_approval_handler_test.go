@@ -0,0 +1,209 @@
+package unit_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func TestPRHandler_ApprovePR(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name             string
+		body             string
+		mockSetup        func(*handlermocks.MockPRServiceInterface)
+		expectedStatus   int
+		validateResponse func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "success - records approval",
+			body: `{"repository_name":"backend","pull_request_id":"pr1","user_id":"reviewer1"}`,
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().ApprovePR(mock.Anything, domain.PRKey{RepositoryName: "backend", PullRequestID: "pr1"}, "reviewer1").Return(&domain.PullRequest{
+					RepositoryName: "backend", PullRequestID: "pr1", AuthorID: "author1", Status: domain.StatusOpen,
+					AssignedReviewersIDs: []string{"reviewer1", "reviewer2"},
+					ApprovedReviewersIDs: []string{"reviewer1"},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ApprovePRResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.NotNil(t, response.PR)
+				assert.Equal(t, []string{"reviewer1"}, response.PR.ApprovedReviewers)
+			},
+		},
+		{
+			name: "error - PR not found",
+			body: `{"pull_request_id":"ghost","user_id":"reviewer1"}`,
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().ApprovePR(mock.Anything, domain.PRKey{PullRequestID: "ghost"}, "reviewer1").Return(nil, service.ErrPRNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "pull request not found", response.Error.Message)
+			},
+		},
+		{
+			name: "error - user is not a reviewer",
+			body: `{"pull_request_id":"pr1","user_id":"author1"}`,
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().ApprovePR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, "author1").Return(nil, service.ErrReviewerNotAssigned)
+			},
+			expectedStatus: http.StatusConflict,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, handler.ErrorNotAssigned, response.Error.Code)
+			},
+		},
+		{
+			name: "error - PR is merged",
+			body: `{"pull_request_id":"pr1","user_id":"reviewer1"}`,
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().ApprovePR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, "reviewer1").Return(nil, service.ErrPRMerged)
+			},
+			expectedStatus: http.StatusConflict,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, handler.ErrorPRMerged, response.Error.Code)
+				assert.Equal(t, "cannot approve merged PR", response.Error.Message)
+			},
+		},
+		{
+			name: "error - PR is closed",
+			body: `{"pull_request_id":"pr1","user_id":"reviewer1"}`,
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().ApprovePR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, "reviewer1").Return(nil, service.ErrPRClosed)
+			},
+			expectedStatus: http.StatusConflict,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, handler.ErrorPRClosed, response.Error.Code)
+			},
+		},
+		{
+			name:           "error - missing user_id",
+			body:           `{"pull_request_id":"pr1"}`,
+			mockSetup:      func(m *handlermocks.MockPRServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, handler.ErrorValidation, response.Error.Code)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockPRServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/pullRequest/approve", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler.NewPRHandler(mockService).ApprovePR(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			tt.validateResponse(t, w)
+		})
+	}
+}
+
+func TestPRHandler_MergePR_RequiredApprovals(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name             string
+		body             string
+		admin            bool
+		mockSetup        func(*handlermocks.MockPRServiceInterface)
+		expectedStatus   int
+		validateResponse func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "error - missing approvals",
+			body: `{"pull_request_id":"pr1"}`,
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().MergePR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, service.MergeOptions{}).
+					Return(nil, &service.NotApprovedError{Required: 2, Approved: 1, Missing: []string{"reviewer2"}})
+			},
+			expectedStatus: http.StatusConflict,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, handler.ErrorNotApproved, response.Error.Code)
+				assert.Equal(t, "PR has 1 of 2 required approvals", response.Error.Message)
+				assert.Equal(t, []string{"reviewer2"}, response.Error.MissingReviewers)
+			},
+		},
+		{
+			name:  "success - admin forces merge",
+			body:  `{"pull_request_id":"pr1","force":true}`,
+			admin: true,
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().MergePR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, service.MergeOptions{Force: true}).
+					Return(&domain.PullRequest{PullRequestID: "pr1", Status: domain.StatusMerged}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.MergePRResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.NotNil(t, response.PR)
+				assert.Equal(t, "MERGED", response.PR.Status)
+			},
+		},
+		{
+			name:           "error - force without admin key",
+			body:           `{"pull_request_id":"pr1","force":true}`,
+			mockSetup:      func(m *handlermocks.MockPRServiceInterface) {},
+			expectedStatus: http.StatusUnauthorized,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, handler.ErrorUnauthorized, response.Error.Code)
+				assert.Equal(t, "force merge requires an admin API key", response.Error.Message)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockPRServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/pullRequest/merge", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			if tt.admin {
+				c.Set(handler.AdminContextKey, true)
+			}
+
+			handler.NewPRHandler(mockService).MergePR(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			tt.validateResponse(t, w)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package unit_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func TestPRHandler_ClosePR(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	closedAt := time.Date(2025, 10, 24, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		body             string
+		mockSetup        func(*handlermocks.MockPRServiceInterface)
+		expectedStatus   int
+		validateResponse func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "success - closes PR",
+			body: `{"repository_name":"backend","pull_request_id":"pr1"}`,
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().ClosePR(mock.Anything, domain.PRKey{RepositoryName: "backend", PullRequestID: "pr1"}).Return(&domain.PullRequest{
+					RepositoryName: "backend", PullRequestID: "pr1", PullRequestName: "Abandoned", AuthorID: "author1",
+					Status: domain.StatusClosed, AssignedReviewersIDs: []string{"reviewer1"}, ClosedAt: &closedAt,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ClosePRResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.NotNil(t, response.PR)
+				assert.Equal(t, "CLOSED", response.PR.Status)
+				assert.Equal(t, "2025-10-24T12:00:00Z", response.PR.ClosedAt)
+				assert.Empty(t, response.PR.MergedAt)
+			},
+		},
+		{
+			name: "error - PR is merged",
+			body: `{"pull_request_id":"pr1"}`,
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().ClosePR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}).Return(nil, service.ErrPRMerged)
+			},
+			expectedStatus: http.StatusConflict,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, handler.ErrorPRMerged, response.Error.Code)
+				assert.Equal(t, "cannot close merged PR", response.Error.Message)
+			},
+		},
+		{
+			name: "error - PR not found",
+			body: `{"pull_request_id":"ghost"}`,
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().ClosePR(mock.Anything, domain.PRKey{PullRequestID: "ghost"}).Return(nil, service.ErrPRNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, handler.ErrorNotFound, response.Error.Code)
+			},
+		},
+		{
+			name:           "error - missing pull_request_id",
+			body:           `{}`,
+			mockSetup:      func(m *handlermocks.MockPRServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, handler.ErrorValidation, response.Error.Code)
+			},
+		},
+		{
+			name: "error - internal",
+			body: `{"pull_request_id":"pr1"}`,
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().ClosePR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}).Return(nil, assert.AnError)
+			},
+			expectedStatus:   http.StatusInternalServerError,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockPRServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/pullRequest/close", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler.NewPRHandler(mockService).ClosePR(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			tt.validateResponse(t, w)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package unit_tests
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+)
+
+// expectCreatePRUntilInsert expects the statements CreatePR runs, up to the pull request insert,
+// for an author whose team gets as many reviewers as are required.
+func expectCreatePRUntilInsert(mock sqlmock.Sqlmock, required []string) {
+	userColumns := []string{"user_id", "username", "team_name", "is_active", "max_open_reviews", "assignment_weight", "erased"}
+	mock.ExpectQuery("FROM users").WillReturnRows(sqlmock.NewRows(userColumns).AddRow("author", "Author", "backend", true, nil, 1, false))
+	mock.ExpectQuery("FROM teams").WillReturnRows(sqlmock.NewRows([]string{"assignment_strategy", "reviewer_count", "require_approvals",
+		"review_sla_hours", "slack_webhook_url", "fallback_team_name"}).AddRow("random", len(required), 0, 0, nil, nil))
+	for _, id := range required {
+		mock.ExpectQuery("FROM users").WillReturnRows(sqlmock.NewRows(userColumns).AddRow(id, id, "backend", true, nil, 1, false))
+	}
+	mock.ExpectBegin()
+}
+
+// expectCreatePRUntilReviewers is expectCreatePRUntilInsert followed by a successful pull request insert.
+func expectCreatePRUntilReviewers(mock sqlmock.Sqlmock, required []string) {
+	expectCreatePRUntilInsert(mock, required)
+	mock.ExpectExec("INSERT INTO pull_requests").WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func TestCreatePR_InsertsReviewersInOneStatement(t *testing.T) {
+	prColumns := []string{"repository_name", "pull_request_id", "pull_request_name", "author_id", "team_name", "status",
+		"created_at", "merged_at", "merged_by", "closed_at", "description", "external_url", "size", "lines_changed", "reassignment_count", "tags", "review_sla_hours"}
+
+	// Checking reviewers one by one took two statements per reviewer: an insert and a lookup.
+	for n := 1; n <= 5; n++ {
+		t.Run(fmt.Sprintf("%d reviewers", n), func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer func() { _ = db.Close() }()
+
+			required := make([]string, n)
+			for i := range required {
+				required[i] = fmt.Sprintf("u%d", i+1)
+			}
+			expectGet := func() {
+				mock.ExpectQuery("FROM pull_requests").WillReturnRows(sqlmock.NewRows(prColumns).
+					AddRow("", "pr-1", "Add search", "author", "backend", "OPEN", time.Now(), nil, nil, nil, "", "", nil, nil, 0, "{}", 0))
+				rows := sqlmock.NewRows([]string{"user_id", "source", "assigned_at", "approved"})
+				for _, id := range required {
+					rows.AddRow(id, "required", time.Now(), false)
+				}
+				mock.ExpectQuery("FROM pr_reviewers").WillReturnRows(rows)
+			}
+
+			expectCreatePRUntilReviewers(mock, required)
+			mock.ExpectExec("INSERT INTO pr_reviewers").WillReturnResult(sqlmock.NewResult(0, int64(n)))
+			expectGet()
+			for range n + 1 {
+				mock.ExpectExec("INSERT INTO event_outbox").WillReturnResult(sqlmock.NewResult(1, 1))
+			}
+			mock.ExpectCommit()
+			expectGet()
+
+			prService := service.NewPRService(db, service.NewReviewerAssigner())
+			created, err := prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: "pr-1"}, "Add search", "author", required, domain.PRDetails{})
+			require.NoError(t, err)
+			assert.Equal(t, required, created.AssignedReviewersIDs)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCreatePR_InactiveReviewerIsNamed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	required := []string{"u1", "u2", "u3"}
+	expectCreatePRUntilReviewers(mock, required)
+	// u2 was deactivated after it was checked, so only two reviewers are inserted.
+	mock.ExpectExec("INSERT INTO pr_reviewers").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectQuery("FROM unnest").WillReturnRows(sqlmock.NewRows([]string{"user_id", "exists"}).AddRow("u2", true))
+	mock.ExpectRollback()
+
+	prService := service.NewPRService(db, service.NewReviewerAssigner())
+	_, err = prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: "pr-1"}, "Add search", "author", required, domain.PRDetails{})
+	require.ErrorIs(t, err, service.ErrInactiveReviewer)
+	var inactive *service.InactiveReviewerError
+	require.ErrorAs(t, err, &inactive)
+	assert.Equal(t, "u2", inactive.UserID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreatePR_InsertFailures(t *testing.T) {
+	foreignKeyViolation := &pq.Error{Code: "23503"}
+
+	tests := []struct {
+		name        string
+		expect      func(mock sqlmock.Sqlmock)
+		expectedErr error
+		reviewerID  string
+	}{
+		{
+			name: "pull request row references a deleted author",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO pull_requests").WillReturnError(foreignKeyViolation)
+			},
+			expectedErr: service.ErrPRAuthorNotFound,
+		},
+		{
+			name: "reviewer row references a deleted user",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO pull_requests").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO pr_reviewers").WillReturnError(foreignKeyViolation)
+			},
+			expectedErr: service.ErrReviewerNotFound,
+		},
+		{
+			name: "reviewer deleted before the insert",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO pull_requests").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO pr_reviewers").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("FROM unnest").WillReturnRows(sqlmock.NewRows([]string{"user_id", "exists"}).AddRow("u2", false))
+			},
+			expectedErr: service.ErrReviewerNotFound,
+			reviewerID:  "u2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer func() { _ = db.Close() }()
+
+			required := []string{"u1", "u2"}
+			expectCreatePRUntilInsert(mock, required)
+			tt.expect(mock)
+			mock.ExpectRollback()
+
+			prService := service.NewPRService(db, service.NewReviewerAssigner())
+			_, err = prService.CreatePR(t.Context(), domain.PRKey{PullRequestID: "pr-1"}, "Add search", "author", required, domain.PRDetails{})
+			require.ErrorIs(t, err, tt.expectedErr)
+			if tt.reviewerID != "" {
+				var notFound *service.ReviewerNotFoundError
+				require.ErrorAs(t, err, &notFound)
+				assert.Equal(t, tt.reviewerID, notFound.UserID)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package unit_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestPRHandler_CreatePR_Details(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name             string
+		body             string
+		mockSetup        func(*handlermocks.MockPRServiceInterface)
+		expectedStatus   int
+		validateResponse func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "success - details are passed through and returned",
+			body: `{"pull_request_id":"pr1","pull_request_name":"Fix","author_id":"author1",` +
+				`"description":"Fixes the crash","external_url":"https://github.com/example/repo/pull/1"}`,
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				details := domain.PRDetails{
+					Description: stringPtr("Fixes the crash"),
+					ExternalURL: stringPtr("https://github.com/example/repo/pull/1"),
+				}
+				m.EXPECT().CreatePR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, "Fix", "author1", []string(nil), details).Return(&domain.PullRequest{
+					PullRequestID: "pr1", PullRequestName: "Fix", AuthorID: "author1", Status: domain.StatusOpen,
+					Description: details.Description, ExternalURL: details.ExternalURL,
+				}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.CreatePRResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.NotNil(t, response.PR)
+				require.NotNil(t, response.PR.Description)
+				require.NotNil(t, response.PR.ExternalURL)
+				assert.Equal(t, "Fixes the crash", *response.PR.Description)
+				assert.Equal(t, "https://github.com/example/repo/pull/1", *response.PR.ExternalURL)
+			},
+		},
+		{
+			name:           "error - relative external url",
+			body:           `{"pull_request_id":"pr1","pull_request_name":"Fix","author_id":"author1","external_url":"/pull/1"}`,
+			mockSetup:      func(m *handlermocks.MockPRServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, []handler.FieldError{
+					{Field: "external_url", Rule: "http_url", Message: "must be an absolute http or https URL"},
+				}, response.Error.Details)
+			},
+		},
+		{
+			name:           "error - non-http external url",
+			body:           `{"pull_request_id":"pr1","pull_request_name":"Fix","author_id":"author1","external_url":"ftp://example.com/pr/1"}`,
+			mockSetup:      func(m *handlermocks.MockPRServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, handler.ErrorValidation, response.Error.Code)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockPRServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/pullRequest/create", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler.NewPRHandler(mockService).CreatePR(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			tt.validateResponse(t, w)
+		})
+	}
+}
+
+func TestPRHandler_GetPR(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name             string
+		query            string
+		mockSetup        func(*handlermocks.MockPRServiceInterface)
+		expectedStatus   int
+		validateResponse func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "success - with details",
+			query: "?pull_request_id=pr1",
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().GetPR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}).Return(&domain.PullRequest{
+					PullRequestID: "pr1", PullRequestName: "Fix", AuthorID: "author1", TeamName: "team1",
+					Status: domain.StatusOpen, AssignedReviewersIDs: []string{"u2"},
+					Description: stringPtr(""), ExternalURL: stringPtr("https://github.com/example/repo/pull/1"),
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response struct {
+					PR map[string]any `json:"pr"`
+				}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "pr1", response.PR["pull_request_id"])
+				assert.Equal(t, "", response.PR["description"])
+				assert.Equal(t, "https://github.com/example/repo/pull/1", response.PR["external_url"])
+			},
+		},
+		{
+			name:  "success - NULL details are omitted",
+			query: "?pull_request_id=pr_old",
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().GetPR(mock.Anything, domain.PRKey{PullRequestID: "pr_old"}).Return(&domain.PullRequest{
+					PullRequestID: "pr_old", PullRequestName: "Old", AuthorID: "author1", TeamName: "team1",
+					Status: domain.StatusMerged,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response struct {
+					PR map[string]any `json:"pr"`
+				}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "MERGED", response.PR["status"])
+				assert.NotContains(t, response.PR, "description")
+				assert.NotContains(t, response.PR, "external_url")
+			},
+		},
+		{
+			name:  "success - assignments measured against the review SLA",
+			query: "?pull_request_id=pr_sla",
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().GetPR(mock.Anything, domain.PRKey{PullRequestID: "pr_sla"}).Return(&domain.PullRequest{
+					PullRequestID: "pr_sla", PullRequestName: "Slow", AuthorID: "author1", TeamName: "team1",
+					Status: domain.StatusOpen, AssignedReviewersIDs: []string{"u2", "u3"},
+					Assignments: []domain.ReviewerAssignment{
+						{UserID: "u2", AssignedAt: time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC), HoursOpen: intPtr(30), Overdue: true},
+						{UserID: "u3", AssignedAt: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC), HoursOpen: intPtr(6)},
+					},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.GetPRResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.NotNil(t, response.PR)
+				assert.Equal(t, []handler.AssignmentResponse{
+					{ReviewerID: "u2", AssignedAt: "2026-03-01T09:00:00Z", HoursOpen: intPtr(30), Overdue: true},
+					{ReviewerID: "u3", AssignedAt: "2026-03-02T09:00:00Z", HoursOpen: intPtr(6)},
+				}, response.PR.Assignments)
+			},
+		},
+		{
+			name:  "success - pull request in a named repository",
+			query: "?repository_name=backend&pull_request_id=pr1",
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().GetPR(mock.Anything, domain.PRKey{RepositoryName: "backend", PullRequestID: "pr1"}).Return(&domain.PullRequest{
+					RepositoryName: "backend", PullRequestID: "pr1", PullRequestName: "Fix", AuthorID: "author1",
+					TeamName: "team1", Status: domain.StatusOpen,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response struct {
+					PR map[string]any `json:"pr"`
+				}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "backend", response.PR["repository_name"])
+				assert.Equal(t, "pr1", response.PR["pull_request_id"])
+			},
+		},
+		{
+			name:  "error - not found",
+			query: "?pull_request_id=ghost",
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().GetPR(mock.Anything, domain.PRKey{PullRequestID: "ghost"}).Return(nil, service.ErrPRNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, handler.ErrorNotFound, response.Error.Code)
+			},
+		},
+		{
+			name:           "error - missing pull_request_id",
+			query:          "",
+			mockSetup:      func(m *handlermocks.MockPRServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "pull_request_id parameter is required", response.Error.Message)
+			},
+		},
+		{
+			name:  "error - internal",
+			query: "?pull_request_id=pr1",
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().GetPR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}).Return(nil, assert.AnError)
+			},
+			expectedStatus:   http.StatusInternalServerError,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockPRServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/pullRequest/get"+tt.query, nil)
+
+			handler.NewPRHandler(mockService).GetPR(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			tt.validateResponse(t, w)
+		})
+	}
+}
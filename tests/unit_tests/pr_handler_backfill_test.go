@@ -0,0 +1,140 @@
+package unit_tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func TestPRHandler_BackfillReviewers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	result := &service.BackfillResult{
+		PRs: []service.BackfilledPR{
+			{PR: domain.PRKey{RepositoryName: "backend", PullRequestID: "pr-1"}, Added: []string{"u3"}},
+			{PR: domain.PRKey{PullRequestID: "pr-2"}, Missing: 2},
+		},
+		Remaining: 4,
+	}
+
+	tests := []struct {
+		name             string
+		query            string
+		requestBody      interface{}
+		mockSetup        func(*handlermocks.MockPRServiceInterface)
+		expectedStatus   int
+		validateResponse func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "success - one team",
+			requestBody: map[string]interface{}{"team_name": "backend", "max_prs": 2},
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().BackfillReviewers(mock.Anything, "backend", service.BackfillOptions{MaxPRs: 2}).Return(result, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.JSONEq(t, `{"team_name":"backend","dry_run":false,"touched":1,"uncoverable":1,"remaining":4,
+					"pull_requests":[
+						{"repository_name":"backend","pull_request_id":"pr-1","added_reviewers":["u3"],"missing_reviewers":0},
+						{"repository_name":"","pull_request_id":"pr-2","added_reviewers":[],"missing_reviewers":2}]}`,
+					w.Body.String())
+			},
+		},
+		{
+			name:        "success - all teams, dry run in query",
+			query:       "?dry_run=true",
+			requestBody: map[string]interface{}{},
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().BackfillReviewers(mock.Anything, "", service.BackfillOptions{DryRun: true}).
+					Return(&service.BackfillResult{PRs: []service.BackfilledPR{}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.JSONEq(t, `{"dry_run":true,"touched":0,"uncoverable":0,"remaining":0,"pull_requests":[]}`, w.Body.String())
+			},
+		},
+		{
+			name:           "error - invalid dry_run",
+			query:          "?dry_run=maybe",
+			requestBody:    map[string]interface{}{},
+			mockSetup:      func(m *handlermocks.MockPRServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "dry_run must be true or false", response.Error.Message)
+			},
+		},
+		{
+			name:           "error - max_prs not positive",
+			requestBody:    map[string]interface{}{"max_prs": -1},
+			mockSetup:      func(m *handlermocks.MockPRServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, handler.ErrorValidation, response.Error.Code)
+			},
+		},
+		{
+			name:        "error - team not found",
+			requestBody: map[string]interface{}{"team_name": "ghost"},
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().BackfillReviewers(mock.Anything, "ghost", service.BackfillOptions{}).Return(nil, service.ErrTeamNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "team not found", response.Error.Message)
+			},
+		},
+		{
+			name:        "error - service failure",
+			requestBody: map[string]interface{}{},
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().BackfillReviewers(mock.Anything, "", service.BackfillOptions{}).Return(nil, errors.New("database is down"))
+			},
+			expectedStatus:   http.StatusInternalServerError,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockPRServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			prHandler := handler.NewPRHandler(mockService)
+
+			body, err := json.Marshal(tt.requestBody)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/admin/backfillReviewers"+tt.query, bytes.NewBuffer(body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			prHandler.BackfillReviewers(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			tt.validateResponse(t, w)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package unit_tests
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/mishasvintus/avito_backend_internship/internal/domain"
@@ -37,28 +39,60 @@ func TestPRHandler_MergePR(t *testing.T) {
 				"pull_request_id": "pr1",
 			},
 			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
-				m.EXPECT().MergePR("pr1").Return(&domain.PullRequest{
-					PullRequestID:     "pr1",
-					PullRequestName:   "Fix bug",
-					AuthorID:          "author1",
-					Status:            domain.StatusMerged,
+				m.EXPECT().MergePR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, service.MergeOptions{}).Return(&domain.PullRequest{
+					PullRequestID:        "pr1",
+					PullRequestName:      "Fix bug",
+					AuthorID:             "author1",
+					Status:               domain.StatusMerged,
 					AssignedReviewersIDs: []string{"reviewer1"},
-					CreatedAt:         &now,
-					MergedAt:          &mergedAt,
+					CreatedAt:            &now,
+					MergedAt:             &mergedAt,
 				}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
-				var response handler.SuccessResponse
+				var response handler.MergePRResponse
 				err := json.Unmarshal(w.Body.Bytes(), &response)
 				require.NoError(t, err)
 				assert.NotNil(t, response.PR)
 				assert.Equal(t, "pr1", response.PR.PullRequestID)
 				assert.Equal(t, "Fix bug", response.PR.PullRequestName)
 				assert.Equal(t, "MERGED", response.PR.Status)
+
+				var envelope map[string]json.RawMessage
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &envelope))
+				assert.Len(t, envelope, 1, "the pr is the only field of the envelope")
 				assert.NotEmpty(t, response.PR.MergedAt)
 			},
 		},
+		{
+			name: "success - timestamps are serialized in UTC",
+			requestBody: map[string]interface{}{
+				"pull_request_id": "pr1",
+			},
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				moscow := time.FixedZone("MSK", 3*60*60)
+				createdAt := time.Date(2026, 3, 2, 12, 30, 0, 0, moscow)
+				mergedAt := time.Date(2026, 3, 3, 1, 15, 0, 0, moscow)
+				m.EXPECT().MergePR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, service.MergeOptions{}).Return(&domain.PullRequest{
+					PullRequestID:        "pr1",
+					PullRequestName:      "Fix bug",
+					AuthorID:             "author1",
+					Status:               domain.StatusMerged,
+					AssignedReviewersIDs: []string{"reviewer1"},
+					CreatedAt:            &createdAt,
+					MergedAt:             &mergedAt,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.MergePRResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, "2026-03-02T09:30:00Z", response.PR.CreatedAt)
+				assert.Equal(t, "2026-03-02T22:15:00Z", response.PR.MergedAt)
+			},
+		},
 		{
 			name:        "error - invalid request body",
 			requestBody: map[string]interface{}{
@@ -79,7 +113,7 @@ func TestPRHandler_MergePR(t *testing.T) {
 				"pull_request_id": "nonexistent",
 			},
 			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
-				m.EXPECT().MergePR("nonexistent").Return(nil, service.ErrPRNotFound)
+				m.EXPECT().MergePR(mock.Anything, domain.PRKey{PullRequestID: "nonexistent"}, service.MergeOptions{}).Return(nil, service.ErrPRNotFound)
 			},
 			expectedStatus: http.StatusNotFound,
 			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -89,13 +123,72 @@ func TestPRHandler_MergePR(t *testing.T) {
 				assert.Equal(t, "pull request not found", response.Error.Message)
 			},
 		},
+		{
+			name: "success - records who merged",
+			requestBody: map[string]interface{}{
+				"pull_request_id": "pr1",
+				"merged_by":       "lead1",
+			},
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().MergePR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, service.MergeOptions{MergedBy: "lead1"}).Return(&domain.PullRequest{
+					PullRequestID: "pr1",
+					AuthorID:      "author1",
+					Status:        domain.StatusMerged,
+					MergedAt:      &mergedAt,
+					MergedBy:      "lead1",
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.MergePRResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.NotNil(t, response.PR)
+				assert.Equal(t, "lead1", response.PR.MergedBy)
+			},
+		},
+		{
+			name: "error - merging user not found",
+			requestBody: map[string]interface{}{
+				"pull_request_id": "pr1",
+				"merged_by":       "ghost",
+			},
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().MergePR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, service.MergeOptions{MergedBy: "ghost"}).Return(nil, service.ErrUserNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, handler.ErrorNotFound, response.Error.Code)
+				assert.Equal(t, "user not found", response.Error.Message)
+			},
+		},
+		{
+			name: "error - PR is closed",
+			requestBody: map[string]interface{}{
+				"pull_request_id": "closed_pr",
+			},
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().MergePR(mock.Anything, domain.PRKey{PullRequestID: "closed_pr"}, service.MergeOptions{}).Return(nil, service.ErrPRClosed)
+			},
+			expectedStatus: http.StatusConflict,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, handler.ErrorPRClosed, response.Error.Code)
+				assert.Equal(t, "cannot merge closed PR", response.Error.Message)
+			},
+		},
 		{
 			name: "error - internal error from service",
 			requestBody: map[string]interface{}{
 				"pull_request_id": "pr1",
 			},
 			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
-				m.EXPECT().MergePR("pr1").Return(nil, assert.AnError)
+				m.EXPECT().MergePR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, service.MergeOptions{}).Return(nil, assert.AnError)
 			},
 			expectedStatus: http.StatusInternalServerError,
 			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -153,18 +246,22 @@ func TestPRHandler_CreatePR(t *testing.T) {
 				"author_id":         "author1",
 			},
 			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
-				m.EXPECT().CreatePR("pr1", "Fix bug", "author1").Return(&domain.PullRequest{
-					PullRequestID:     "pr1",
-					PullRequestName:   "Fix bug",
-					AuthorID:          "author1",
-					Status:            domain.StatusOpen,
+				m.EXPECT().CreatePR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, "Fix bug", "author1", []string(nil), domain.PRDetails{}).Return(&domain.PullRequest{
+					PullRequestID:        "pr1",
+					PullRequestName:      "Fix bug",
+					AuthorID:             "author1",
+					Status:               domain.StatusOpen,
 					AssignedReviewersIDs: []string{"reviewer1", "reviewer2"},
-					CreatedAt:         &now,
+					CreatedAt:            &now,
+					Assignments: []domain.ReviewerAssignment{
+						{UserID: "reviewer1", Source: domain.SourceRequired, AssignedAt: now},
+						{UserID: "reviewer2", Source: domain.SourceFallback, AssignedAt: now},
+					},
 				}, nil)
 			},
 			expectedStatus: http.StatusCreated,
 			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
-				var response handler.SuccessResponse
+				var response handler.CreatePRResponse
 				err := json.Unmarshal(w.Body.Bytes(), &response)
 				require.NoError(t, err)
 				assert.NotNil(t, response.PR)
@@ -173,6 +270,52 @@ func TestPRHandler_CreatePR(t *testing.T) {
 				assert.Equal(t, "author1", response.PR.AuthorID)
 				assert.Equal(t, "OPEN", response.PR.Status)
 				assert.Len(t, response.PR.AssignedReviewers, 2)
+				assert.Equal(t, []handler.ReviewerResponse{
+					{UserID: "reviewer1", Source: "required", AssignedAt: now.UTC().Format(time.RFC3339)},
+					{UserID: "reviewer2", Source: "fallback", AssignedAt: now.UTC().Format(time.RFC3339)},
+				}, response.PR.Reviewers)
+			},
+		},
+		{
+			name: "success - missing id is left to the service",
+			requestBody: map[string]interface{}{
+				"pull_request_name": "Fix bug",
+				"author_id":         "author1",
+			},
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().CreatePR(mock.Anything, domain.PRKey{}, "Fix bug", "author1", []string(nil), domain.PRDetails{}).Return(&domain.PullRequest{
+					PullRequestID:   "0195a1b2-c3d4-7e5f-8a6b-7c8d9e0f1a2b",
+					PullRequestName: "Fix bug",
+					AuthorID:        "author1",
+					Status:          domain.StatusOpen,
+					CreatedAt:       &now,
+				}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.CreatePRResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.NotNil(t, response.PR)
+				assert.Equal(t, "0195a1b2-c3d4-7e5f-8a6b-7c8d9e0f1a2b", response.PR.PullRequestID)
+			},
+		},
+		{
+			name: "error - empty id",
+			requestBody: map[string]interface{}{
+				"pull_request_id":   "",
+				"pull_request_name": "Fix bug",
+				"author_id":         "author1",
+			},
+			mockSetup:      func(m *handlermocks.MockPRServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Len(t, response.Error.Details, 1)
+				assert.Equal(t, "pull_request_id", response.Error.Details[0].Field)
+				assert.Equal(t, "entity_id", response.Error.Details[0].Rule)
 			},
 		},
 		{
@@ -198,7 +341,16 @@ func TestPRHandler_CreatePR(t *testing.T) {
 				"author_id":         "author1",
 			},
 			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
-				m.EXPECT().CreatePR("existing_pr", "Fix bug", "author1").Return(nil, service.ErrPRExists)
+				m.EXPECT().CreatePR(mock.Anything, domain.PRKey{PullRequestID: "existing_pr"}, "Fix bug", "author1", []string(nil), domain.PRDetails{}).Return(nil, &service.PRExistsError{
+					Existing: &domain.PullRequest{
+						PullRequestID:        "existing_pr",
+						PullRequestName:      "Earlier fix",
+						AuthorID:             "author2",
+						TeamName:             "backend",
+						Status:               domain.StatusMerged,
+						AssignedReviewersIDs: []string{"reviewer1", "reviewer2"},
+					},
+				})
 			},
 			expectedStatus: http.StatusConflict,
 			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -207,6 +359,59 @@ func TestPRHandler_CreatePR(t *testing.T) {
 				require.NoError(t, err)
 				assert.Equal(t, handler.ErrorPRExists, response.Error.Code)
 				assert.Equal(t, "PR id already exists", response.Error.Message)
+				require.NotNil(t, response.Error.ExistingPR)
+				assert.Equal(t, "existing_pr", response.Error.ExistingPR.PullRequestID)
+				assert.Equal(t, "author2", response.Error.ExistingPR.AuthorID)
+				assert.Equal(t, "MERGED", response.Error.ExistingPR.Status)
+				assert.Equal(t, []string{"reviewer1", "reviewer2"}, response.Error.ExistingPR.AssignedReviewers)
+			},
+		},
+		{
+			name: "success - PR in a named repository",
+			requestBody: map[string]interface{}{
+				"repository_name":   "backend",
+				"pull_request_id":   "PR-1",
+				"pull_request_name": "Fix bug",
+				"author_id":         "author1",
+			},
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().CreatePR(mock.Anything, domain.PRKey{RepositoryName: "backend", PullRequestID: "PR-1"}, "Fix bug", "author1", []string(nil), domain.PRDetails{}).Return(&domain.PullRequest{
+					RepositoryName:  "backend",
+					PullRequestID:   "PR-1",
+					PullRequestName: "Fix bug",
+					AuthorID:        "author1",
+					Status:          domain.StatusOpen,
+				}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.CreatePRResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.NotNil(t, response.PR)
+				assert.Equal(t, "backend", response.PR.RepositoryName)
+				assert.Equal(t, "PR-1", response.PR.PullRequestID)
+			},
+		},
+		{
+			name: "error - PR already exists in repository",
+			requestBody: map[string]interface{}{
+				"repository_name":   "backend",
+				"pull_request_id":   "PR-1",
+				"pull_request_name": "Fix bug",
+				"author_id":         "author1",
+			},
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().CreatePR(mock.Anything, domain.PRKey{RepositoryName: "backend", PullRequestID: "PR-1"}, "Fix bug", "author1", []string(nil), domain.PRDetails{}).Return(nil, service.ErrPRExists)
+			},
+			expectedStatus: http.StatusConflict,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, handler.ErrorPRExists, response.Error.Code)
+				assert.Equal(t, "PR id already exists in repository backend", response.Error.Message)
+				assert.Nil(t, response.Error.ExistingPR)
 			},
 		},
 		{
@@ -217,16 +422,58 @@ func TestPRHandler_CreatePR(t *testing.T) {
 				"author_id":         "nonexistent",
 			},
 			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
-				m.EXPECT().CreatePR("pr1", "Fix bug", "nonexistent").Return(nil, service.ErrPRAuthorNotFound)
+				m.EXPECT().CreatePR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, "Fix bug", "nonexistent", []string(nil), domain.PRDetails{}).Return(nil, service.ErrPRAuthorNotFound)
 			},
 			expectedStatus: http.StatusNotFound,
 			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
 				var response handler.ErrorResponse
 				err := json.Unmarshal(w.Body.Bytes(), &response)
 				require.NoError(t, err)
+				assert.Equal(t, handler.ErrorNotFound, response.Error.Code)
 				assert.Equal(t, "author or team not found", response.Error.Message)
 			},
 		},
+		{
+			name: "error - reviewer deleted during creation",
+			requestBody: map[string]interface{}{
+				"pull_request_id":   "pr1",
+				"pull_request_name": "Fix bug",
+				"author_id":         "author1",
+			},
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().CreatePR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, "Fix bug", "author1", []string(nil), domain.PRDetails{}).
+					Return(nil, &service.ReviewerNotFoundError{UserID: "u7"})
+			},
+			expectedStatus: http.StatusNotFound,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, handler.ErrorReviewerNotFound, response.Error.Code)
+				assert.Equal(t, "reviewer not found: u7", response.Error.Message)
+			},
+		},
+		{
+			name: "error - author over the creation limit",
+			requestBody: map[string]interface{}{
+				"pull_request_id":   "pr1",
+				"pull_request_name": "Fix bug",
+				"author_id":         "bot",
+			},
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().CreatePR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, "Fix bug", "bot", []string(nil), domain.PRDetails{}).
+					Return(nil, fmt.Errorf("wrapped: %w", &service.AuthorRateLimitError{Limit: 5, Used: 5, RetryAfter: 90500 * time.Millisecond}))
+			},
+			expectedStatus: http.StatusTooManyRequests,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, handler.ErrorAuthorRateLimited, response.Error.Code)
+				assert.Equal(t, &handler.CreateLimitUsage{Limit: 5, Used: 5, RetryAfterSeconds: 91}, response.Error.Usage)
+				assert.Equal(t, "91", w.Header().Get("Retry-After"))
+			},
+		},
 		{
 			name: "error - inactive reviewer",
 			requestBody: map[string]interface{}{
@@ -235,7 +482,7 @@ func TestPRHandler_CreatePR(t *testing.T) {
 				"author_id":         "author1",
 			},
 			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
-				m.EXPECT().CreatePR("pr1", "Fix bug", "author1").Return(nil, service.ErrInactiveReviewer)
+				m.EXPECT().CreatePR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, "Fix bug", "author1", []string(nil), domain.PRDetails{}).Return(nil, service.ErrInactiveReviewer)
 			},
 			expectedStatus: http.StatusBadRequest,
 			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -245,6 +492,257 @@ func TestPRHandler_CreatePR(t *testing.T) {
 				assert.Equal(t, service.ErrInactiveReviewer.Error(), response.Error.Message)
 			},
 		},
+		{
+			name: "error - inactive reviewer reports user id",
+			requestBody: map[string]interface{}{
+				"pull_request_id":   "pr1",
+				"pull_request_name": "Fix bug",
+				"author_id":         "author1",
+			},
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().CreatePR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, "Fix bug", "author1", []string(nil), domain.PRDetails{}).
+					Return(nil, fmt.Errorf("failed to assign: %w", &service.InactiveReviewerError{UserID: "u7"}))
+			},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Contains(t, response.Error.Message, "u7")
+				assert.Contains(t, response.Error.Message, service.ErrInactiveReviewer.Error())
+			},
+		},
+		{
+			name: "success - passes required reviewers",
+			requestBody: map[string]interface{}{
+				"pull_request_id":    "pr1",
+				"pull_request_name":  "Fix bug",
+				"author_id":          "author1",
+				"required_reviewers": []string{"owner1"},
+			},
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().CreatePR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, "Fix bug", "author1", []string{"owner1"}, domain.PRDetails{}).Return(&domain.PullRequest{
+					PullRequestID:        "pr1",
+					PullRequestName:      "Fix bug",
+					AuthorID:             "author1",
+					Status:               domain.StatusOpen,
+					AssignedReviewersIDs: []string{"owner1", "reviewer2"},
+				}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.CreatePRResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.NotNil(t, response.PR)
+				assert.Equal(t, []string{"owner1", "reviewer2"}, response.PR.AssignedReviewers)
+			},
+		},
+		{
+			name: "success - passes size hint",
+			requestBody: map[string]interface{}{
+				"pull_request_id":   "pr1",
+				"pull_request_name": "Fix bug",
+				"author_id":         "author1",
+				"size":              "XL",
+				"lines_changed":     2000,
+			},
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().CreatePR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, "Fix bug", "author1", []string(nil),
+					domain.PRDetails{Size: domain.SizeXL, LinesChanged: intPtr(2000)}).Return(&domain.PullRequest{
+					PullRequestID:        "pr1",
+					PullRequestName:      "Fix bug",
+					AuthorID:             "author1",
+					Status:               domain.StatusOpen,
+					AssignedReviewersIDs: []string{"reviewer1"},
+					Size:                 domain.SizeXL,
+					LinesChanged:         intPtr(2000),
+				}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.CreatePRResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.NotNil(t, response.PR)
+				assert.Equal(t, "XL", response.PR.Size)
+				require.NotNil(t, response.PR.LinesChanged)
+				assert.Equal(t, 2000, *response.PR.LinesChanged)
+			},
+		},
+		{
+			name: "error - unknown size",
+			requestBody: map[string]interface{}{
+				"pull_request_id":   "pr1",
+				"pull_request_name": "Fix bug",
+				"author_id":         "author1",
+				"size":              "XXL",
+			},
+			mockSetup:      func(m *handlermocks.MockPRServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Len(t, response.Error.Details, 1)
+				assert.Equal(t, "size", response.Error.Details[0].Field)
+			},
+		},
+		{
+			name: "success - passes tags",
+			requestBody: map[string]interface{}{
+				"pull_request_id":   "pr1",
+				"pull_request_name": "Fix bug",
+				"author_id":         "author1",
+				"tags":              []string{"payments", "go"},
+			},
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().CreatePR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, "Fix bug", "author1", []string(nil),
+					domain.PRDetails{Tags: []string{"payments", "go"}}).Return(&domain.PullRequest{
+					PullRequestID:        "pr1",
+					PullRequestName:      "Fix bug",
+					AuthorID:             "author1",
+					Status:               domain.StatusOpen,
+					AssignedReviewersIDs: []string{"reviewer1"},
+					Tags:                 []string{"go", "payments"},
+				}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.CreatePRResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.NotNil(t, response.PR)
+				assert.Equal(t, []string{"go", "payments"}, response.PR.Tags)
+			},
+		},
+		{
+			name: "error - invalid tag",
+			requestBody: map[string]interface{}{
+				"pull_request_id":   "pr1",
+				"pull_request_name": "Fix bug",
+				"author_id":         "author1",
+				"tags":              []string{"go", "Payments Team"},
+			},
+			mockSetup:      func(m *handlermocks.MockPRServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Len(t, response.Error.Details, 1)
+				assert.Equal(t, "tags[1]", response.Error.Details[0].Field)
+				assert.Equal(t, "tag", response.Error.Details[0].Rule)
+			},
+		},
+		{
+			name: "error - duplicate tags",
+			requestBody: map[string]interface{}{
+				"pull_request_id":   "pr1",
+				"pull_request_name": "Fix bug",
+				"author_id":         "author1",
+				"tags":              []string{"go", "go"},
+			},
+			mockSetup:      func(m *handlermocks.MockPRServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Len(t, response.Error.Details, 1)
+				assert.Equal(t, "tags", response.Error.Details[0].Field)
+				assert.Equal(t, "unique", response.Error.Details[0].Rule)
+			},
+		},
+		{
+			name: "error - empty required reviewer id",
+			requestBody: map[string]interface{}{
+				"pull_request_id":    "pr1",
+				"pull_request_name":  "Fix bug",
+				"author_id":          "author1",
+				"required_reviewers": []string{""},
+			},
+			mockSetup:      func(m *handlermocks.MockPRServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, "invalid request body", response.Error.Message)
+			},
+		},
+		{
+			name: "error - required reviewer not found",
+			requestBody: map[string]interface{}{
+				"pull_request_id":    "pr1",
+				"pull_request_name":  "Fix bug",
+				"author_id":          "author1",
+				"required_reviewers": []string{"ghost"},
+			},
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().CreatePR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, "Fix bug", "author1", []string{"ghost"}, domain.PRDetails{}).Return(nil, service.ErrRequiredReviewerNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, handler.ErrorNotFound, response.Error.Code)
+				assert.Equal(t, service.ErrRequiredReviewerNotFound.Error(), response.Error.Message)
+			},
+		},
+		{
+			name: "error - required reviewer inactive",
+			requestBody: map[string]interface{}{
+				"pull_request_id":    "pr1",
+				"pull_request_name":  "Fix bug",
+				"author_id":          "author1",
+				"required_reviewers": []string{"sleepy"},
+			},
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().CreatePR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, "Fix bug", "author1", []string{"sleepy"}, domain.PRDetails{}).Return(nil, service.ErrRequiredReviewerInactive)
+			},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, service.ErrRequiredReviewerInactive.Error(), response.Error.Message)
+			},
+		},
+		{
+			name: "error - required reviewer is the author",
+			requestBody: map[string]interface{}{
+				"pull_request_id":    "pr1",
+				"pull_request_name":  "Fix bug",
+				"author_id":          "author1",
+				"required_reviewers": []string{"author1"},
+			},
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().CreatePR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, "Fix bug", "author1", []string{"author1"}, domain.PRDetails{}).Return(nil, service.ErrRequiredReviewerIsAuthor)
+			},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, service.ErrRequiredReviewerIsAuthor.Error(), response.Error.Message)
+			},
+		},
+		{
+			name: "error - too many required reviewers",
+			requestBody: map[string]interface{}{
+				"pull_request_id":    "pr1",
+				"pull_request_name":  "Fix bug",
+				"author_id":          "author1",
+				"required_reviewers": []string{"r1", "r2", "r3"},
+			},
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().CreatePR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, "Fix bug", "author1", []string{"r1", "r2", "r3"}, domain.PRDetails{}).Return(nil, service.ErrTooManyRequiredReviewers)
+			},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, service.ErrTooManyRequiredReviewers.Error(), response.Error.Message)
+			},
+		},
 		{
 			name: "error - internal error from service",
 			requestBody: map[string]interface{}{
@@ -253,7 +751,7 @@ func TestPRHandler_CreatePR(t *testing.T) {
 				"author_id":         "author1",
 			},
 			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
-				m.EXPECT().CreatePR("pr1", "Fix bug", "author1").Return(nil, assert.AnError)
+				m.EXPECT().CreatePR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, "Fix bug", "author1", []string(nil), domain.PRDetails{}).Return(nil, assert.AnError)
 			},
 			expectedStatus: http.StatusInternalServerError,
 			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -310,13 +808,13 @@ func TestPRHandler_ReassignPR(t *testing.T) {
 				"old_user_id":     "old_reviewer",
 			},
 			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
-				m.EXPECT().ReassignPR("pr1", "old_reviewer").Return(&domain.PullRequest{
-					PullRequestID:     "pr1",
-					PullRequestName:   "Fix bug",
-					AuthorID:          "author1",
-					Status:            domain.StatusOpen,
+				m.EXPECT().ReassignPR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, "old_reviewer", service.ReassignOptions{}).Return(&domain.PullRequest{
+					PullRequestID:        "pr1",
+					PullRequestName:      "Fix bug",
+					AuthorID:             "author1",
+					Status:               domain.StatusOpen,
 					AssignedReviewersIDs: []string{"new_reviewer"},
-					CreatedAt:         &now,
+					CreatedAt:            &now,
 				}, "new_reviewer", nil)
 			},
 			expectedStatus: http.StatusOK,
@@ -352,7 +850,7 @@ func TestPRHandler_ReassignPR(t *testing.T) {
 				"old_user_id":     "reviewer1",
 			},
 			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
-				m.EXPECT().ReassignPR("nonexistent", "reviewer1").Return(nil, "", service.ErrPRNotFound)
+				m.EXPECT().ReassignPR(mock.Anything, domain.PRKey{PullRequestID: "nonexistent"}, "reviewer1", service.ReassignOptions{}).Return(nil, "", service.ErrPRNotFound)
 			},
 			expectedStatus: http.StatusNotFound,
 			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -370,7 +868,7 @@ func TestPRHandler_ReassignPR(t *testing.T) {
 				"old_user_id":     "reviewer1",
 			},
 			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
-				m.EXPECT().ReassignPR("pr1", "reviewer1").Return(nil, "", service.ErrPRAuthorNotFound)
+				m.EXPECT().ReassignPR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, "reviewer1", service.ReassignOptions{}).Return(nil, "", service.ErrPRAuthorNotFound)
 			},
 			expectedStatus: http.StatusNotFound,
 			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -388,7 +886,7 @@ func TestPRHandler_ReassignPR(t *testing.T) {
 				"old_user_id":     "reviewer1",
 			},
 			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
-				m.EXPECT().ReassignPR("merged_pr", "reviewer1").Return(nil, "", service.ErrPRMerged)
+				m.EXPECT().ReassignPR(mock.Anything, domain.PRKey{PullRequestID: "merged_pr"}, "reviewer1", service.ReassignOptions{}).Return(nil, "", service.ErrPRMerged)
 			},
 			expectedStatus: http.StatusConflict,
 			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -406,7 +904,7 @@ func TestPRHandler_ReassignPR(t *testing.T) {
 				"old_user_id":     "not_assigned",
 			},
 			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
-				m.EXPECT().ReassignPR("pr1", "not_assigned").Return(nil, "", service.ErrReviewerNotAssigned)
+				m.EXPECT().ReassignPR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, "not_assigned", service.ReassignOptions{}).Return(nil, "", service.ErrReviewerNotAssigned)
 			},
 			expectedStatus: http.StatusConflict,
 			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -417,6 +915,24 @@ func TestPRHandler_ReassignPR(t *testing.T) {
 				assert.Equal(t, "reviewer is not assigned to this PR", response.Error.Message)
 			},
 		},
+		{
+			name: "error - PR is closed",
+			requestBody: map[string]interface{}{
+				"pull_request_id": "closed_pr",
+				"old_user_id":     "reviewer1",
+			},
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().ReassignPR(mock.Anything, domain.PRKey{PullRequestID: "closed_pr"}, "reviewer1", service.ReassignOptions{}).Return(nil, "", service.ErrPRClosed)
+			},
+			expectedStatus: http.StatusConflict,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, handler.ErrorPRClosed, response.Error.Code)
+				assert.Equal(t, "cannot reassign on closed PR", response.Error.Message)
+			},
+		},
 		{
 			name: "error - no candidate available",
 			requestBody: map[string]interface{}{
@@ -424,7 +940,7 @@ func TestPRHandler_ReassignPR(t *testing.T) {
 				"old_user_id":     "reviewer1",
 			},
 			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
-				m.EXPECT().ReassignPR("pr1", "reviewer1").Return(nil, "", service.ErrNoCandidate)
+				m.EXPECT().ReassignPR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, "reviewer1", service.ReassignOptions{}).Return(nil, "", service.ErrNoCandidate)
 			},
 			expectedStatus: http.StatusConflict,
 			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -442,16 +958,87 @@ func TestPRHandler_ReassignPR(t *testing.T) {
 				"old_user_id":     "reviewer1",
 			},
 			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
-				m.EXPECT().ReassignPR("pr1", "reviewer1").Return(nil, "", service.ErrInactiveReviewer)
+				m.EXPECT().ReassignPR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, "reviewer1", service.ReassignOptions{}).Return(nil, "", service.ErrInactiveReviewer)
 			},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusConflict,
 			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
 				var response handler.ErrorResponse
 				err := json.Unmarshal(w.Body.Bytes(), &response)
 				require.NoError(t, err)
+				assert.Equal(t, handler.ErrorReviewerInactive, response.Error.Code)
 				assert.Equal(t, service.ErrInactiveReviewer.Error(), response.Error.Message)
 			},
 		},
+		{
+			name: "error - inactive replacement reports user id",
+			requestBody: map[string]interface{}{
+				"pull_request_id": "pr1",
+				"old_user_id":     "reviewer1",
+			},
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().ReassignPR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, "reviewer1", service.ReassignOptions{}).Return(nil, "", &service.InactiveReviewerError{UserID: "u8"})
+			},
+			expectedStatus: http.StatusConflict,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, handler.ErrorReviewerInactive, response.Error.Code)
+				assert.Equal(t, "reviewer is not active: u8", response.Error.Message)
+			},
+		},
+		{
+			name: "error - replacement is the author",
+			requestBody: map[string]interface{}{
+				"pull_request_id": "pr1",
+				"old_user_id":     "reviewer1",
+			},
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().ReassignPR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, "reviewer1", service.ReassignOptions{}).Return(nil, "", service.ErrReviewerIsAuthor)
+			},
+			expectedStatus: http.StatusConflict,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, handler.ErrorReviewerIsAuthor, response.Error.Code)
+			},
+		},
+		{
+			name: "error - replacement is already assigned",
+			requestBody: map[string]interface{}{
+				"pull_request_id": "pr1",
+				"old_user_id":     "reviewer1",
+			},
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().ReassignPR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, "reviewer1", service.ReassignOptions{}).Return(nil, "", service.ErrReviewerAssigned)
+			},
+			expectedStatus: http.StatusConflict,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, handler.ErrorAlreadyAssigned, response.Error.Code)
+			},
+		},
+		{
+			name: "error - replacement was deleted",
+			requestBody: map[string]interface{}{
+				"pull_request_id": "pr1",
+				"old_user_id":     "reviewer1",
+			},
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().ReassignPR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, "reviewer1", service.ReassignOptions{}).Return(nil, "", &service.ReviewerNotFoundError{UserID: "u8"})
+			},
+			expectedStatus: http.StatusNotFound,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, handler.ErrorReviewerNotFound, response.Error.Code)
+				assert.Equal(t, "reviewer not found: u8", response.Error.Message)
+			},
+		},
 		{
 			name: "error - internal error from service",
 			requestBody: map[string]interface{}{
@@ -459,7 +1046,7 @@ func TestPRHandler_ReassignPR(t *testing.T) {
 				"old_user_id":     "reviewer1",
 			},
 			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
-				m.EXPECT().ReassignPR("pr1", "reviewer1").Return(nil, "", assert.AnError)
+				m.EXPECT().ReassignPR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, "reviewer1", service.ReassignOptions{}).Return(nil, "", assert.AnError)
 			},
 			expectedStatus: http.StatusInternalServerError,
 			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
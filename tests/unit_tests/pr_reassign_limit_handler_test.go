@@ -0,0 +1,101 @@
+package unit_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func TestPRHandler_ReassignPR_Limit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name             string
+		body             string
+		admin            bool
+		mockSetup        func(*handlermocks.MockPRServiceInterface)
+		expectedStatus   int
+		validateResponse func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "error - limit reached",
+			body: `{"pull_request_id":"pr1","old_user_id":"u2"}`,
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().ReassignPR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, "u2", service.ReassignOptions{}).
+					Return(nil, "", service.ErrReassignLimit)
+			},
+			expectedStatus: http.StatusConflict,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, handler.ErrorReassignLimit, response.Error.Code)
+			},
+		},
+		{
+			name:  "success - admin forces reassign",
+			body:  `{"pull_request_id":"pr1","old_user_id":"u2","force":true}`,
+			admin: true,
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().ReassignPR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}, "u2", service.ReassignOptions{Force: true}).
+					Return(&domain.PullRequest{
+						PullRequestID:        "pr1",
+						Status:               domain.StatusOpen,
+						AssignedReviewersIDs: []string{"u3"},
+						ReassignmentCount:    11,
+					}, "u3", nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ReassignResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.NotNil(t, response.PR)
+				assert.Equal(t, "u3", response.ReplacedBy)
+				assert.Equal(t, 11, response.PR.ReassignmentCount)
+			},
+		},
+		{
+			name:           "error - force without admin key",
+			body:           `{"pull_request_id":"pr1","old_user_id":"u2","force":true}`,
+			mockSetup:      func(m *handlermocks.MockPRServiceInterface) {},
+			expectedStatus: http.StatusUnauthorized,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, handler.ErrorUnauthorized, response.Error.Code)
+				assert.Equal(t, "force reassign requires an admin API key", response.Error.Message)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockPRServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/pullRequest/reassign", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			if tt.admin {
+				c.Set(handler.AdminContextKey, true)
+			}
+
+			handler.NewPRHandler(mockService).ReassignPR(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			tt.validateResponse(t, w)
+		})
+	}
+}
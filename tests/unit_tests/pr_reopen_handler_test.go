@@ -0,0 +1,102 @@
+package unit_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func TestPRHandler_ReopenPR(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name             string
+		body             string
+		mockSetup        func(*handlermocks.MockPRServiceInterface)
+		expectedStatus   int
+		validateResponse func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "success - reopens PR",
+			body: `{"repository_name":"backend","pull_request_id":"pr1"}`,
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().ReopenPR(mock.Anything, domain.PRKey{RepositoryName: "backend", PullRequestID: "pr1"}).Return(&domain.PullRequest{
+					RepositoryName: "backend", PullRequestID: "pr1", PullRequestName: "Fix", AuthorID: "author1",
+					Status: domain.StatusOpen, AssignedReviewersIDs: []string{"reviewer1", "reviewer2"},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ReopenPRResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.NotNil(t, response.PR)
+				assert.Equal(t, "OPEN", response.PR.Status)
+				assert.Empty(t, response.PR.MergedAt)
+				assert.Empty(t, response.PR.ClosedAt)
+				assert.Equal(t, []string{"reviewer1", "reviewer2"}, response.PR.AssignedReviewers)
+			},
+		},
+		{
+			name: "error - PR not found",
+			body: `{"pull_request_id":"ghost"}`,
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().ReopenPR(mock.Anything, domain.PRKey{PullRequestID: "ghost"}).Return(nil, service.ErrPRNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "pull request not found", response.Error.Message)
+			},
+		},
+		{
+			name:           "error - invalid pull_request_id",
+			body:           `{"pull_request_id":"pr 1"}`,
+			mockSetup:      func(m *handlermocks.MockPRServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, handler.ErrorValidation, response.Error.Code)
+			},
+		},
+		{
+			name: "error - internal",
+			body: `{"pull_request_id":"pr1"}`,
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().ReopenPR(mock.Anything, domain.PRKey{PullRequestID: "pr1"}).Return(nil, assert.AnError)
+			},
+			expectedStatus:   http.StatusInternalServerError,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockPRServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/pullRequest/reopen", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler.NewPRHandler(mockService).ReopenPR(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			tt.validateResponse(t, w)
+		})
+	}
+}
@@ -29,6 +29,12 @@ func TestPRStatus_NewPRStatus(t *testing.T) {
 			want:      domain.StatusMerged,
 			wantError: false,
 		},
+		{
+			name:      "valid - CLOSED",
+			input:     "CLOSED",
+			want:      domain.StatusClosed,
+			wantError: false,
+		},
 		{
 			name:      "invalid - empty string",
 			input:     "",
@@ -80,6 +86,11 @@ func TestPRStatus_IsValid(t *testing.T) {
 			status: domain.StatusMerged,
 			want:   true,
 		},
+		{
+			name:   "valid - CLOSED",
+			status: domain.StatusClosed,
+			want:   true,
+		},
 		{
 			name:   "invalid - empty",
 			status: "",
@@ -118,6 +129,12 @@ func TestPRStatus_Scan(t *testing.T) {
 			want:      domain.StatusMerged,
 			wantError: false,
 		},
+		{
+			name:      "valid - string CLOSED",
+			input:     "CLOSED",
+			want:      domain.StatusClosed,
+			wantError: false,
+		},
 		{
 			name:      "valid - []byte OPEN",
 			input:     []byte("OPEN"),
@@ -178,6 +195,12 @@ func TestPRStatus_Value(t *testing.T) {
 			want:      "MERGED",
 			wantError: false,
 		},
+		{
+			name:      "valid - CLOSED",
+			status:    domain.StatusClosed,
+			want:      "CLOSED",
+			wantError: false,
+		},
 		{
 			name:      "invalid - empty",
 			status:    "",
@@ -0,0 +1,162 @@
+package unit_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func TestPRHandler_SuggestReviewers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name             string
+		queryParams      map[string]string
+		mockSetup        func(*handlermocks.MockPRServiceInterface)
+		expectedStatus   int
+		validateResponse func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "success - default count",
+			queryParams: map[string]string{"author_id": "author1"},
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().SuggestReviewers(mock.Anything, "author1", 2).Return(&service.ReviewerSuggestion{
+					AuthorID: "author1",
+					TeamName: "team1",
+					Strategy: service.StrategyRandom,
+					Candidates: []domain.User{
+						{UserID: "u1", Username: "Alice", OpenReviews: 1, MaxOpenReviews: intPtr(3)},
+						{UserID: "u2", Username: "Bob"},
+					},
+					Selected: []string{"u1", "u2"},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.SuggestReviewersResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, "author1", response.AuthorID)
+				assert.Equal(t, "team1", response.TeamName)
+				assert.Equal(t, "random", response.AssignmentStrategy)
+				require.Len(t, response.Candidates, 2)
+				assert.Equal(t, 1, response.Candidates[0].OpenReviews)
+				require.NotNil(t, response.Candidates[0].MaxOpenReviews)
+				assert.Equal(t, 3, *response.Candidates[0].MaxOpenReviews)
+				assert.Nil(t, response.Candidates[1].MaxOpenReviews)
+				assert.Equal(t, []string{"u1", "u2"}, response.SuggestedReviewers)
+			},
+		},
+		{
+			name:        "success - custom count",
+			queryParams: map[string]string{"author_id": "author1", "count": "1"},
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().SuggestReviewers(mock.Anything, "author1", 1).Return(&service.ReviewerSuggestion{
+					AuthorID: "author1",
+					TeamName: "team1",
+					Strategy: service.StrategyLeastLoaded,
+					Selected: []string{"u2"},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.SuggestReviewersResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, "least_loaded", response.AssignmentStrategy)
+				assert.Empty(t, response.Candidates)
+				assert.Equal(t, []string{"u2"}, response.SuggestedReviewers)
+			},
+		},
+		{
+			name:           "error - missing author_id",
+			queryParams:    map[string]string{},
+			mockSetup:      func(m *handlermocks.MockPRServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, "author_id parameter is required", response.Error.Message)
+			},
+		},
+		{
+			name:           "error - invalid count",
+			queryParams:    map[string]string{"author_id": "author1", "count": "0"},
+			mockSetup:      func(m *handlermocks.MockPRServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, "count must be a positive integer", response.Error.Message)
+			},
+		},
+		{
+			name:        "error - author not found",
+			queryParams: map[string]string{"author_id": "ghost"},
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().SuggestReviewers(mock.Anything, "ghost", 2).Return(nil, service.ErrPRAuthorNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, handler.ErrorNotFound, response.Error.Code)
+			},
+		},
+		{
+			name:        "error - internal server error",
+			queryParams: map[string]string{"author_id": "author1"},
+			mockSetup: func(m *handlermocks.MockPRServiceInterface) {
+				m.EXPECT().SuggestReviewers(mock.Anything, "author1", 2).Return(nil, assert.AnError)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Contains(t, response.Error.Message, "assert.AnError")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockPRServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			handler := handler.NewPRHandler(mockService)
+
+			req, err := http.NewRequest(http.MethodGet, "/pullRequest/suggestReviewers", nil)
+			require.NoError(t, err)
+
+			q := req.URL.Query()
+			for key, value := range tt.queryParams {
+				q.Add(key, value)
+			}
+			req.URL.RawQuery = q.Encode()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			handler.SuggestReviewers(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			tt.validateResponse(t, w)
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package unit_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/middleware"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func newRateLimitedEngine(limiter *middleware.RateLimiter) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		if key := c.GetHeader("X-Test-API-Key"); key != "" {
+			c.Set(middleware.APIKeyContextKey, key)
+		}
+	}, middleware.RateLimit(limiter))
+	r.POST("/pullRequest/create", func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+	return r
+}
+
+func sendRateLimited(r *gin.Engine, remoteAddr, apiKey string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", nil)
+	req.RemoteAddr = remoteAddr
+	if apiKey != "" {
+		req.Header.Set("X-Test-API-Key", apiKey)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimit_BurstAndRecovery(t *testing.T) {
+	clock := &tests.FakeClock{Current: time.Date(2024, 3, 20, 12, 0, 0, 0, time.UTC)}
+	r := newRateLimitedEngine(middleware.NewRateLimiter(0.5, 3, clock))
+
+	for i := range 3 {
+		assert.Equal(t, http.StatusCreated, sendRateLimited(r, "192.0.2.1:1000", "").Code, "request %d", i)
+	}
+
+	w := sendRateLimited(r, "192.0.2.1:1000", "")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "2", w.Header().Get("Retry-After"))
+	var response handler.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, handler.ErrorRateLimited, response.Error.Code)
+
+	// Another client is unaffected.
+	assert.Equal(t, http.StatusCreated, sendRateLimited(r, "192.0.2.2:1000", "").Code)
+
+	clock.Advance(time.Second)
+	w = sendRateLimited(r, "192.0.2.1:1000", "")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+
+	clock.Advance(time.Second)
+	assert.Equal(t, http.StatusCreated, sendRateLimited(r, "192.0.2.1:1000", "").Code)
+	assert.Equal(t, http.StatusTooManyRequests, sendRateLimited(r, "192.0.2.1:1000", "").Code)
+
+	// After a full refill the whole burst is available again.
+	clock.Advance(6 * time.Second)
+	for range 3 {
+		assert.Equal(t, http.StatusCreated, sendRateLimited(r, "192.0.2.1:1000", "").Code)
+	}
+	assert.Equal(t, http.StatusTooManyRequests, sendRateLimited(r, "192.0.2.1:1000", "").Code)
+}
+
+func TestRateLimit_KeyedByAPIKey(t *testing.T) {
+	clock := &tests.FakeClock{Current: time.Date(2024, 3, 20, 12, 0, 0, 0, time.UTC)}
+	r := newRateLimitedEngine(middleware.NewRateLimiter(1, 1, clock))
+
+	assert.Equal(t, http.StatusCreated, sendRateLimited(r, "192.0.2.1:1000", "ci").Code)
+	assert.Equal(t, http.StatusTooManyRequests, sendRateLimited(r, "192.0.2.2:1000", "ci").Code)
+
+	// Other keys and unauthenticated requests from the same address have their own buckets.
+	assert.Equal(t, http.StatusCreated, sendRateLimited(r, "192.0.2.1:1000", "dashboard").Code)
+	assert.Equal(t, http.StatusCreated, sendRateLimited(r, "192.0.2.1:1000", "").Code)
+}
+
+func TestRateLimit_EvictsIdleKeys(t *testing.T) {
+	clock := &tests.FakeClock{Current: time.Date(2024, 3, 20, 12, 0, 0, 0, time.UTC)}
+	limiter := middleware.NewRateLimiter(1, 2, clock)
+
+	for _, key := range []string{"a", "b", "c"} {
+		allowed, _ := limiter.Allow(key)
+		assert.True(t, allowed)
+	}
+	assert.Equal(t, 3, limiter.Len())
+
+	clock.Advance(time.Second)
+	limiter.Allow("a")
+	assert.Equal(t, 3, limiter.Len())
+
+	clock.Advance(1500 * time.Millisecond)
+	limiter.Allow("d")
+	assert.Equal(t, 2, limiter.Len(), "b and c were idle long enough to refill")
+}
+
+func TestRateLimit_Concurrent(t *testing.T) {
+	clock := &tests.FakeClock{Current: time.Date(2024, 3, 20, 12, 0, 0, 0, time.UTC)}
+	r := newRateLimitedEngine(middleware.NewRateLimiter(1, 10, clock))
+
+	var created atomic.Int32
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if sendRateLimited(r, "192.0.2.1:1000", "").Code == http.StatusCreated {
+				created.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(10), created.Load())
+}
+
+func TestRateLimit_Disabled(t *testing.T) {
+	r := newRateLimitedEngine(nil)
+
+	for range 100 {
+		assert.Equal(t, http.StatusCreated, sendRateLimited(r, "192.0.2.1:1000", "").Code)
+	}
+}
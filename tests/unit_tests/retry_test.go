@@ -0,0 +1,161 @@
+package unit_tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+)
+
+func TestRetryPolicy_RunTx(t *testing.T) {
+	serializationFailure := &pq.Error{Code: "40001", Message: "could not serialize access"}
+	deadlock := &pq.Error{Code: "40P01", Message: "deadlock detected"}
+	errFailed := errors.New("failed")
+
+	tests := []struct {
+		name          string
+		attempts      int
+		setup         func(sqlmock.Sqlmock)
+		results       []error
+		expectedCalls int
+		expectedErr   func(*testing.T, error)
+	}{
+		{
+			name:     "retries after serialization failure and succeeds",
+			attempts: 3,
+			setup: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectRollback()
+				m.ExpectBegin()
+				m.ExpectCommit()
+			},
+			results:       []error{fmt.Errorf("failed to create pull request: %w", serializationFailure), nil},
+			expectedCalls: 2,
+			expectedErr: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name:     "gives up after attempts with the original error",
+			attempts: 3,
+			setup: func(m sqlmock.Sqlmock) {
+				for range 3 {
+					m.ExpectBegin()
+					m.ExpectRollback()
+				}
+			},
+			results:       []error{deadlock, deadlock, deadlock},
+			expectedCalls: 3,
+			expectedErr: func(t *testing.T, err error) {
+				assert.ErrorIs(t, err, deadlock)
+			},
+		},
+		{
+			name:     "does not retry other errors",
+			attempts: 3,
+			setup: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectRollback()
+			},
+			results:       []error{errFailed},
+			expectedCalls: 1,
+			expectedErr: func(t *testing.T, err error) {
+				assert.ErrorIs(t, err, errFailed)
+			},
+		},
+		{
+			name:     "retries failed commit",
+			attempts: 2,
+			setup: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectCommit().WillReturnError(serializationFailure)
+				m.ExpectBegin()
+				m.ExpectCommit()
+			},
+			results:       []error{nil, nil},
+			expectedCalls: 2,
+			expectedErr: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name:     "single attempt when not configured",
+			attempts: 0,
+			setup: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectRollback()
+			},
+			results:       []error{deadlock},
+			expectedCalls: 1,
+			expectedErr: func(t *testing.T, err error) {
+				assert.True(t, repository.IsRetryable(err))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer func() { _ = db.Close() }()
+
+			tt.setup(mock)
+
+			policy := service.RetryPolicy{Attempts: tt.attempts, BaseDelay: time.Millisecond}
+			calls := 0
+			err = policy.RunTx(t.Context(), db, func(tx repository.DBTX) error {
+				result := tt.results[calls]
+				calls++
+				return result
+			})
+
+			tt.expectedErr(t, err)
+			assert.Equal(t, tt.expectedCalls, calls)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRetryPolicy_RunTx_StopsWaitingWhenContextIsDone(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	policy := service.RetryPolicy{Attempts: 2, BaseDelay: time.Hour}
+	serializationFailure := &pq.Error{Code: "40001"}
+	calls := 0
+	start := time.Now()
+	err = policy.RunTx(ctx, db, func(tx repository.DBTX) error {
+		calls++
+		cancel()
+		return serializationFailure
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, err, serializationFailure, "the database error is kept")
+	assert.True(t, repository.IsRetryable(err))
+	assert.Equal(t, 1, calls)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIsRetryable(t *testing.T) {
+	assert.True(t, repository.IsRetryable(&pq.Error{Code: "40001"}))
+	assert.True(t, repository.IsRetryable(fmt.Errorf("wrapped: %w", &pq.Error{Code: "40P01"})))
+	assert.False(t, repository.IsRetryable(&pq.Error{Code: "23505"}))
+	assert.False(t, repository.IsRetryable(errors.New("failed")))
+	assert.False(t, repository.IsRetryable(nil))
+}
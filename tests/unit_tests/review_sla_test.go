@@ -0,0 +1,114 @@
+package unit_tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestReviewerAssignment_Measure(t *testing.T) {
+	assignedAt := time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		waited        time.Duration
+		slaHours      int
+		approved      bool
+		status        domain.PRStatus
+		wantHoursOpen *int
+		wantOverdue   bool
+	}{
+		{
+			name:          "just assigned",
+			slaHours:      24,
+			status:        domain.StatusOpen,
+			wantHoursOpen: intPtr(0),
+		},
+		{
+			name:          "a minute before the SLA hour",
+			waited:        24*time.Hour - time.Minute,
+			slaHours:      24,
+			status:        domain.StatusOpen,
+			wantHoursOpen: intPtr(23),
+		},
+		{
+			name:          "exactly at the SLA hour",
+			waited:        24 * time.Hour,
+			slaHours:      24,
+			status:        domain.StatusOpen,
+			wantHoursOpen: intPtr(24),
+		},
+		{
+			name:          "a second past the SLA hour",
+			waited:        24*time.Hour + time.Second,
+			slaHours:      24,
+			status:        domain.StatusOpen,
+			wantHoursOpen: intPtr(24),
+			wantOverdue:   true,
+		},
+		{
+			name:          "team without SLA",
+			waited:        100 * time.Hour,
+			status:        domain.StatusOpen,
+			wantHoursOpen: intPtr(100),
+		},
+		{
+			name:          "approved review",
+			waited:        30 * time.Hour,
+			slaHours:      24,
+			approved:      true,
+			status:        domain.StatusOpen,
+			wantHoursOpen: intPtr(30),
+		},
+		{
+			name:     "merged pull request",
+			waited:   30 * time.Hour,
+			slaHours: 24,
+			status:   domain.StatusMerged,
+		},
+		{
+			name:     "closed pull request",
+			waited:   30 * time.Hour,
+			slaHours: 24,
+			status:   domain.StatusClosed,
+		},
+		{
+			name:          "clock behind the assignment",
+			waited:        -time.Hour,
+			slaHours:      24,
+			status:        domain.StatusOpen,
+			wantHoursOpen: intPtr(0),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := &tests.FakeClock{Current: assignedAt}
+			clock.Advance(tc.waited)
+
+			a := domain.ReviewerAssignment{UserID: "u1", AssignedAt: assignedAt, Approved: tc.approved, ReviewSLAHours: tc.slaHours}
+			a.Measure(clock.Now(), tc.status)
+
+			assert.Equal(t, tc.wantHoursOpen, a.HoursOpen)
+			assert.Equal(t, tc.wantOverdue, a.Overdue)
+		})
+	}
+}
+
+func TestReviewerAssignment_MeasureAgain(t *testing.T) {
+	assignedAt := time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC)
+	clock := &tests.FakeClock{Current: assignedAt.Add(48 * time.Hour)}
+
+	a := domain.ReviewerAssignment{AssignedAt: assignedAt, ReviewSLAHours: 24}
+	a.Measure(clock.Now(), domain.StatusOpen)
+	assert.True(t, a.Overdue)
+
+	// Measuring after the merge clears the earlier result.
+	a.Measure(clock.Now(), domain.StatusMerged)
+	assert.Nil(t, a.HoursOpen)
+	assert.False(t, a.Overdue)
+}
@@ -2,6 +2,7 @@ package unit_tests
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -110,3 +111,280 @@ func TestReviewerAssigner_SelectReassignReviewers(t *testing.T) {
 		assert.Nil(t, got)
 	})
 }
+
+func withLoad(u domain.User, open int, max *int) domain.User {
+	u.OpenReviews = open
+	u.MaxOpenReviews = max
+	return u
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func TestReviewerAssigner_Capacity(t *testing.T) {
+	t.Run("candidate exactly at capacity is skipped", func(t *testing.T) {
+		u := users("full", "free")
+		teammates := []domain.User{
+			withLoad(u[0], 2, intPtr(2)),
+			withLoad(u[1], 1, intPtr(2)),
+		}
+		got, err := service.NewReviewerAssigner().SelectReviewers(teammates)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"free"}, got)
+	})
+
+	t.Run("candidate one below capacity is eligible", func(t *testing.T) {
+		teammates := []domain.User{withLoad(users("u1")[0], 1, intPtr(2))}
+		got, err := service.NewReviewerAssigner().SelectReviewers(teammates)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u1"}, got)
+	})
+
+	t.Run("zero capacity never assigned when others are free", func(t *testing.T) {
+		u := users("off", "on")
+		teammates := []domain.User{
+			withLoad(u[0], 0, intPtr(0)),
+			withLoad(u[1], 5, nil),
+		}
+		got, err := service.NewReviewerAssigner().SelectReviewers(teammates)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"on"}, got)
+	})
+
+	t.Run("all at capacity falls back to least loaded", func(t *testing.T) {
+		u := users("a", "b", "c")
+		teammates := []domain.User{
+			withLoad(u[0], 3, intPtr(3)),
+			withLoad(u[1], 1, intPtr(1)),
+			withLoad(u[2], 2, intPtr(2)),
+		}
+		got, err := service.NewReviewerAssigner().SelectReviewers(teammates)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"b", "c"}, got)
+	})
+
+	t.Run("all at capacity without fallback returns empty", func(t *testing.T) {
+		teammates := []domain.User{withLoad(users("a")[0], 1, intPtr(1))}
+		got, err := service.NewReviewerAssigner().WithCapacityFallback(false).SelectReviewers(teammates)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("reassign never falls back to users at capacity", func(t *testing.T) {
+		u := users("author", "r1", "full")
+		teammates := []domain.User{u[0], u[1], withLoad(u[2], 1, intPtr(1))}
+		got, err := service.NewReviewerAssigner().SelectReassignReviewers(teammates, "author", []string{"r1"})
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func withWeight(u domain.User, weight float64) domain.User {
+	u.AssignmentWeight = weight
+	return u
+}
+
+func TestReviewerAssigner_Weighted(t *testing.T) {
+	assigner := service.NewReviewerAssigner().WithStrategy(service.StrategyWeighted)
+
+	t.Run("never picks the same reviewer twice", func(t *testing.T) {
+		u := users("a", "b", "c")
+		teammates := []domain.User{withWeight(u[0], 100), withWeight(u[1], 0.01), withWeight(u[2], 0.01)}
+		for range 200 {
+			got, err := assigner.SelectReviewers(teammates)
+			require.NoError(t, err)
+			require.Len(t, got, 2)
+			assert.NotEqual(t, got[0], got[1])
+		}
+	})
+
+	t.Run("distribution follows weights", func(t *testing.T) {
+		u := users("junior1", "junior2", "senior")
+		teammates := []domain.User{withWeight(u[0], 1), withWeight(u[1], 1), withWeight(u[2], 2)}
+
+		const runs = 20000
+		firstPicks := map[string]int{}
+		picked := map[string]int{}
+		for range runs {
+			got, err := assigner.SelectReviewers(teammates)
+			require.NoError(t, err)
+			require.Len(t, got, 2)
+			firstPicks[got[0]]++
+			for _, id := range got {
+				picked[id]++
+			}
+		}
+
+		// First draw is proportional to weight: 1/4, 1/4, 2/4.
+		assert.InDelta(t, 0.25, float64(firstPicks["junior1"])/runs, 0.02)
+		assert.InDelta(t, 0.25, float64(firstPicks["junior2"])/runs, 0.02)
+		assert.InDelta(t, 0.50, float64(firstPicks["senior"])/runs, 0.02)
+
+		// Senior is picked first (1/2) or second after a junior (2 * 1/4 * 2/3): 5/6 overall.
+		assert.InDelta(t, 5.0/6.0, float64(picked["senior"])/runs, 0.02)
+	})
+
+	t.Run("unset weights behave like uniform", func(t *testing.T) {
+		teammates := users("u1", "u2", "u3", "u4")
+		const runs = 8000
+		firstPicks := map[string]int{}
+		for range runs {
+			got, err := assigner.SelectReviewers(teammates)
+			require.NoError(t, err)
+			firstPicks[got[0]]++
+		}
+		for _, id := range []string{"u1", "u2", "u3", "u4"} {
+			assert.InDelta(t, 0.25, float64(firstPicks[id])/runs, 0.03, id)
+		}
+	})
+
+	t.Run("reassign respects exclusions", func(t *testing.T) {
+		u := users("author", "r1", "r2")
+		teammates := []domain.User{withWeight(u[0], 50), withWeight(u[1], 50), withWeight(u[2], 1)}
+		got, err := assigner.SelectReassignReviewers(teammates, "author", []string{"r1"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"r2"}, got)
+	})
+}
+
+func TestReviewerAssigner_LeastLoaded(t *testing.T) {
+	assigner := service.NewReviewerAssigner().WithStrategy(service.StrategyLeastLoaded)
+
+	u := users("a", "b", "c", "d")
+	teammates := []domain.User{withLoad(u[0], 3, nil), withLoad(u[1], 0, nil), withLoad(u[2], 1, nil), withLoad(u[3], 0, nil)}
+	got, err := assigner.SelectReviewers(teammates)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "d"}, got)
+
+	t.Run("open reviews are weighted by PR size", func(t *testing.T) {
+		u := users("xl", "xs")
+		oneXL := withLoad(u[0], 1, nil)
+		oneXL.OpenReviewLoad = domain.SizeXL.Weight()
+		threeXS := withLoad(u[1], 3, nil)
+		threeXS.OpenReviewLoad = 3 * domain.SizeXS.Weight()
+
+		got, err := assigner.SelectReviewersN([]domain.User{oneXL, threeXS}, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"xs"}, got)
+	})
+}
+
+func TestSizeForLines(t *testing.T) {
+	for lines, want := range map[int]domain.PRSize{
+		0: domain.SizeXS, 9: domain.SizeXS, 10: domain.SizeS, 249: domain.SizeM, 999: domain.SizeL, 1000: domain.SizeXL,
+	} {
+		assert.Equal(t, want, domain.SizeForLines(lines), "%d lines", lines)
+	}
+}
+
+func withLastAssigned(u domain.User, at time.Time) domain.User {
+	u.LastAssignedAt = &at
+	return u
+}
+
+func TestReviewerAssigner_RoundRobin(t *testing.T) {
+	assigner := service.NewReviewerAssigner().WithStrategy(service.StrategyRoundRobin)
+	now := time.Now()
+
+	t.Run("never assigned users come first", func(t *testing.T) {
+		u := users("a", "b", "c")
+		teammates := []domain.User{withLastAssigned(u[0], now.Add(-time.Hour)), u[1], withLastAssigned(u[2], now)}
+		got, err := assigner.SelectReviewers(teammates)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"b", "a"}, got)
+	})
+
+	t.Run("reassign picks least recently assigned", func(t *testing.T) {
+		u := users("author", "r1", "r2", "r3")
+		teammates := []domain.User{
+			u[0],
+			withLastAssigned(u[1], now.Add(-3*time.Hour)),
+			withLastAssigned(u[2], now.Add(-time.Hour)),
+			withLastAssigned(u[3], now.Add(-2*time.Hour)),
+		}
+		got, err := assigner.SelectReassignReviewers(teammates, "author", []string{"r1"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"r3", "r2"}, got)
+	})
+}
+
+func TestReviewerAssigner_ForStrategy(t *testing.T) {
+	base := service.NewReviewerAssigner()
+	derived := base.ForStrategy(service.StrategyRoundRobin)
+
+	assert.Equal(t, service.StrategyRandom, base.Strategy())
+	assert.Equal(t, service.StrategyRoundRobin, derived.Strategy())
+}
+
+func withTags(u domain.User, tags ...string) domain.User {
+	u.Tags = tags
+	return u
+}
+
+func TestReviewerAssigner_ForTags(t *testing.T) {
+	assigner := service.NewReviewerAssigner().ForTags([]string{"go", "payments"})
+
+	t.Run("prefers candidates sharing a tag", func(t *testing.T) {
+		u := users("a", "b", "c")
+		teammates := []domain.User{withTags(u[0], "frontend"), withTags(u[1], "payments"), u[2]}
+		for range 50 {
+			got, err := assigner.SelectReviewersN(teammates, 1)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"b"}, got)
+		}
+	})
+
+	t.Run("fills remaining slots from the others", func(t *testing.T) {
+		u := users("a", "b", "c")
+		teammates := []domain.User{u[0], withTags(u[1], "go"), u[2]}
+		got, err := assigner.SelectReviewers(teammates)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Contains(t, got, "b")
+	})
+
+	t.Run("falls back to any candidate without matches", func(t *testing.T) {
+		teammates := []domain.User{withTags(users("a")[0], "frontend")}
+		got, err := assigner.SelectReviewers(teammates)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a"}, got)
+	})
+
+	t.Run("reassign prefers candidates sharing a tag", func(t *testing.T) {
+		u := users("author", "old", "plain", "expert")
+		teammates := []domain.User{u[0], u[1], u[2], withTags(u[3], "go")}
+		for range 50 {
+			got, err := assigner.SelectReassignReviewers(teammates, "author", []string{"old"})
+			require.NoError(t, err)
+			require.NotEmpty(t, got)
+			assert.Equal(t, "expert", got[0])
+		}
+	})
+
+	t.Run("does not change the base assigner", func(t *testing.T) {
+		u := users("a", "b")
+		teammates := []domain.User{u[0], withTags(u[1], "go")}
+		seen := make(map[string]bool)
+		for range 200 {
+			got, err := service.NewReviewerAssigner().SelectReviewersN(teammates, 1)
+			require.NoError(t, err)
+			seen[got[0]] = true
+		}
+		assert.True(t, seen["a"] && seen["b"])
+	})
+}
+
+func TestParseStrategy(t *testing.T) {
+	s, err := service.ParseStrategy("weighted")
+	require.NoError(t, err)
+	assert.Equal(t, service.StrategyWeighted, s)
+
+	for _, name := range []string{"random", "least_loaded", "round_robin"} {
+		_, err := service.ParseStrategy(name)
+		assert.NoError(t, err, name)
+	}
+
+	_, err = service.ParseStrategy("fastest")
+	assert.Error(t, err)
+}
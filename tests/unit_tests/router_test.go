@@ -0,0 +1,134 @@
+package unit_tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/stats"
+	"github.com/mishasvintus/avito_backend_internship/internal/router"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func TestSetupRoutes_TrustedProxies(t *testing.T) {
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		remoteAddr     string
+		expectedIP     string
+	}{
+		{
+			name:           "forwarded header from trusted proxy",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "10.1.2.3:40000",
+			expectedIP:     "203.0.113.7",
+		},
+		{
+			name:           "forwarded header from untrusted peer",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "192.0.2.10:40000",
+			expectedIP:     "192.0.2.10",
+		},
+		{
+			name:           "no proxies configured",
+			trustedProxies: nil,
+			remoteAddr:     "10.1.2.3:40000",
+			expectedIP:     "10.1.2.3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := router.SetupRoutes(router.Options{
+				Mode:           gin.TestMode,
+				TrustedProxies: tt.trustedProxies,
+			}, nil, nil, nil, nil, nil, nil, nil, nil)
+			require.NoError(t, err)
+			r.GET("/test/ip", func(c *gin.Context) {
+				c.String(http.StatusOK, c.ClientIP())
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/test/ip", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set("X-Forwarded-For", "203.0.113.7")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.expectedIP, w.Body.String())
+		})
+	}
+}
+
+func TestSetupRoutes_Mode(t *testing.T) {
+	defer gin.SetMode(gin.TestMode)
+
+	_, err := router.SetupRoutes(router.Options{Mode: gin.ReleaseMode}, nil, nil, nil, nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, gin.ReleaseMode, gin.Mode())
+}
+
+func TestSetupRoutes_InvalidProxy(t *testing.T) {
+	_, err := router.SetupRoutes(router.Options{
+		Mode:           gin.TestMode,
+		TrustedProxies: []string{"not-an-ip"},
+	}, nil, nil, nil, nil, nil, nil, nil, nil)
+	assert.Error(t, err)
+}
+
+func TestSetupRoutes_VersionedAndLegacyPaths(t *testing.T) {
+	mockService := handlermocks.NewMockStatsServiceInterface(t)
+	mockService.EXPECT().GetStatistics(mock.Anything, stats.Period{}).Return(anonymizeTestStatistics(), nil).Times(2)
+
+	r, err := router.SetupRoutes(router.Options{Mode: gin.TestMode},
+		nil, nil, nil, handler.NewStatsHandler(mockService), nil, nil, nil, nil)
+	require.NoError(t, err)
+
+	versioned := httptest.NewRecorder()
+	r.ServeHTTP(versioned, httptest.NewRequest(http.MethodGet, "/api/v1/stats", nil))
+	legacy := httptest.NewRecorder()
+	r.ServeHTTP(legacy, httptest.NewRequest(http.MethodGet, "/stats", nil))
+
+	assert.Equal(t, http.StatusOK, versioned.Code)
+	assert.Equal(t, http.StatusOK, legacy.Code)
+	assert.JSONEq(t, versioned.Body.String(), legacy.Body.String())
+
+	assert.Empty(t, versioned.Header().Get("Deprecation"))
+	assert.Equal(t, "true", legacy.Header().Get("Deprecation"))
+	assert.Equal(t, `</api/v1/stats>; rel="successor-version"`, legacy.Header().Get("Link"))
+
+	// Every legacy route has a versioned counterpart with the same handler.
+	handlers := make(map[string]string)
+	for _, route := range r.Routes() {
+		handlers[route.Method+" "+route.Path] = route.Handler
+	}
+	for _, route := range r.Routes() {
+		if strings.HasPrefix(route.Path, router.APIPrefix) || route.Path == "/openapi.json" || route.Path == "/health" {
+			continue
+		}
+		assert.Equal(t, route.Handler, handlers[route.Method+" "+router.APIPrefix+route.Path], route.Path)
+	}
+}
+
+func TestSetupRoutes_LegacyPathsDisabled(t *testing.T) {
+	r, err := router.SetupRoutes(router.Options{Mode: gin.TestMode, DisableLegacyRoutes: true}, nil, nil, nil, nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+
+	for _, route := range r.Routes() {
+		if route.Path == "/openapi.json" || route.Path == "/health" {
+			continue
+		}
+		assert.True(t, strings.HasPrefix(route.Path, router.APIPrefix), route.Path)
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stats", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
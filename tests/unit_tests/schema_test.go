@@ -0,0 +1,33 @@
+package unit_tests
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+)
+
+// The startup schema check must be reviewed whenever a migration is added.
+func TestSchemaMigration_MatchesLatestMigration(t *testing.T) {
+	entries, err := os.ReadDir(filepath.Join("..", "..", "migrations"))
+	require.NoError(t, err)
+
+	latest := 0
+	for _, e := range entries {
+		if !strings.HasSuffix(e.Name(), ".up.sql") {
+			continue
+		}
+		number, _, _ := strings.Cut(e.Name(), "_")
+		n, err := strconv.Atoi(number)
+		require.NoError(t, err, e.Name())
+		latest = max(latest, n)
+	}
+	assert.Equal(t, latest, repository.SchemaMigration,
+		"review the expected schema in internal/repository/schema.go and bump SchemaMigration")
+}
@@ -0,0 +1,185 @@
+package unit_tests
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/server"
+)
+
+func TestServer_StopDrainsInFlightRequests(t *testing.T) {
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		close(entered)
+		<-release
+		_, _ = io.WriteString(w, "done")
+	})
+	mux.HandleFunc("/fast", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "fast")
+	})
+
+	srv := server.New("127.0.0.1:0", mux)
+	require.NoError(t, srv.Start(t.Context()))
+	base := "http://" + srv.Addr()
+
+	type result struct {
+		body string
+		err  error
+	}
+	slow := make(chan result, 1)
+	go func() {
+		resp, err := http.Get(base + "/slow")
+		if err != nil {
+			slow <- result{err: err}
+			return
+		}
+		defer func() { _ = resp.Body.Close() }()
+		body, err := io.ReadAll(resp.Body)
+		slow <- result{body: string(body), err: err}
+	}()
+	<-entered
+
+	stopDone := make(chan error, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		stopDone <- srv.Stop(ctx)
+	}()
+
+	// New connections are refused once stopping has begun.
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}, Timeout: time.Second}
+	require.Eventually(t, func() bool {
+		resp, err := client.Get(base + "/fast")
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		return err != nil
+	}, 2*time.Second, 10*time.Millisecond)
+
+	select {
+	case <-stopDone:
+		t.Fatal("stop returned before the in-flight request finished")
+	default:
+	}
+
+	close(release)
+
+	got := <-slow
+	require.NoError(t, got.err)
+	assert.Equal(t, "done", got.body)
+	require.NoError(t, <-stopDone)
+}
+
+func TestServer_StopTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	entered := make(chan struct{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/stuck", func(w http.ResponseWriter, r *http.Request) {
+		close(entered)
+		<-release
+	})
+
+	srv := server.New("127.0.0.1:0", mux)
+	require.NoError(t, srv.Start(t.Context()))
+
+	go func() {
+		resp, err := http.Get("http://" + srv.Addr() + "/stuck")
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+	}()
+	<-entered
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := srv.Stop(ctx)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestServer_TLS(t *testing.T) {
+	certFile := filepath.Join("..", "testdata", "tls", "cert.pem")
+	keyFile := filepath.Join("..", "testdata", "tls", "key.pem")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/hello", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, r.Proto)
+	})
+	srv := server.New("127.0.0.1:0", mux).
+		WithTLS(certFile, keyFile).
+		WithRedirect("127.0.0.1:0")
+	require.NoError(t, srv.Start(t.Context()))
+	defer func() { _ = srv.Stop(context.Background()) }()
+
+	pem, err := os.ReadFile(certFile)
+	require.NoError(t, err)
+	roots := x509.NewCertPool()
+	require.True(t, roots.AppendCertsFromPEM(pem))
+	client := &http.Client{
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}, ForceAttemptHTTP2: true},
+		Timeout:   5 * time.Second,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	_, port, err := net.SplitHostPort(srv.Addr())
+	require.NoError(t, err)
+	base := "https://localhost:" + port
+
+	t.Run("HTTPS over HTTP/2", func(t *testing.T) {
+		resp, err := client.Get(base + "/hello")
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "HTTP/2.0", string(body))
+		require.NotNil(t, resp.TLS)
+		assert.GreaterOrEqual(t, resp.TLS.Version, uint16(tls.VersionTLS12))
+	})
+
+	t.Run("TLS 1.1 is refused", func(t *testing.T) {
+		conn, err := tls.Dial("tcp", srv.Addr(), &tls.Config{
+			RootCAs: roots, ServerName: "localhost", MinVersion: tls.VersionTLS10, MaxVersion: tls.VersionTLS11,
+		})
+		if err == nil {
+			_ = conn.Close()
+		}
+		assert.Error(t, err)
+	})
+
+	t.Run("plain HTTP is redirected", func(t *testing.T) {
+		_, redirectPort, err := net.SplitHostPort(srv.RedirectAddr())
+		require.NoError(t, err)
+
+		resp, err := client.Get("http://localhost:" + redirectPort + "/hello?x=1")
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+
+		assert.Equal(t, http.StatusMovedPermanently, resp.StatusCode)
+		assert.Equal(t, base+"/hello?x=1", resp.Header.Get("Location"))
+	})
+}
+
+func TestServer_TLSInvalidCertificate(t *testing.T) {
+	srv := server.New("127.0.0.1:0", http.NewServeMux()).
+		WithTLS(filepath.Join("..", "testdata", "tls", "missing.pem"), filepath.Join("..", "testdata", "tls", "key.pem"))
+	err := srv.Start(t.Context())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "TLS certificate")
+}
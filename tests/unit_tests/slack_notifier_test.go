@@ -0,0 +1,194 @@
+package unit_tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+)
+
+// stubSlackTargets resolves every pull request to pullRequest and every team to the Slack webhook url.
+type stubSlackTargets struct {
+	pullRequest *domain.PullRequest
+	url         string
+	err         error
+}
+
+func (s stubSlackTargets) SlackTarget(context.Context, domain.PRKey) (*domain.PullRequest, string, error) {
+	return s.pullRequest, s.url, s.err
+}
+
+func (s stubSlackTargets) SlackWebhookURL(context.Context, string) (string, error) {
+	return s.url, s.err
+}
+
+func slackPR(externalURL *string) *domain.PullRequest {
+	return &domain.PullRequest{
+		RepositoryName:  "backend",
+		PullRequestID:   "pr-1",
+		PullRequestName: "Fix <login> & logout",
+		AuthorID:        "alice",
+		TeamName:        "payments",
+		ExternalURL:     externalURL,
+	}
+}
+
+func TestSlackNotifier_Messages(t *testing.T) {
+	url := "https://git.example.com/backend/pull/1"
+
+	tests := []struct {
+		name         string
+		event        domain.Event
+		pullRequest  *domain.PullRequest
+		expectedText string
+	}{
+		{
+			name:         "assignment links the pull request",
+			event:        domain.Event{Type: domain.EventReviewerAssigned, ReviewerID: "bob"},
+			pullRequest:  slackPR(&url),
+			expectedText: "*bob* is asked to review <" + url + "|Fix &lt;login&gt; &amp; logout> by *alice*",
+		},
+		{
+			name:         "assignment without external url",
+			event:        domain.Event{Type: domain.EventReviewerAssigned, ReviewerID: "bob"},
+			pullRequest:  slackPR(nil),
+			expectedText: "*bob* is asked to review *Fix &lt;login&gt; &amp; logout* by *alice*",
+		},
+		{
+			name:         "reassignment",
+			event:        domain.Event{Type: domain.EventReviewerReassigned, ReviewerID: "carol", ReplacedReviewerID: "bob", Reason: domain.ActionReassign},
+			pullRequest:  slackPR(&url),
+			expectedText: "*carol* replaces *bob* as reviewer of <" + url + "|Fix &lt;login&gt; &amp; logout> by *alice*",
+		},
+		{
+			name:        "review overdue past the SLA",
+			event:       domain.Event{Type: domain.EventReviewerReassigned, ReviewerID: "carol", ReplacedReviewerID: "bob", Reason: domain.ActionEscalate},
+			pullRequest: slackPR(&url),
+			expectedText: "<" + url + "|Fix &lt;login&gt; &amp; logout> by *alice* waited for *bob*'s review past the SLA; " +
+				"*carol* is asked to review it now",
+		},
+		{
+			name:         "merge releases the reviewers of the event",
+			event:        domain.Event{Type: domain.EventPRMerged, ReviewerIDs: []string{"bob", "carol"}},
+			pullRequest:  slackPR(&url),
+			expectedText: "<" + url + "|Fix &lt;login&gt; &amp; logout> by *alice* is merged; *bob*, *carol*, your review is no longer needed",
+		},
+		{
+			name: "digest lists the pending reviews by reviewer",
+			event: domain.Event{Type: domain.EventReviewDigest, Digest: &domain.ReviewDigest{
+				TeamName: "payments",
+				Reviewers: []domain.DigestReviewer{
+					{UserID: "u2", Username: "bob", Reviews: []domain.DigestReview{
+						{PullRequestName: "Fix <login> & logout", AuthorID: "alice", ExternalURL: &url, HoursOpen: 30, Overdue: true},
+						{PullRequestName: "Add refunds", AuthorID: "alice", HoursOpen: 2},
+					}},
+					{UserID: "u3", Username: "carol", Reviews: []domain.DigestReview{
+						{PullRequestName: "Add refunds", AuthorID: "alice", HoursOpen: 0},
+					}},
+				},
+			}},
+			expectedText: "Pending reviews of team *payments*" +
+				"\n*bob*:" +
+				"\n• <" + url + "|Fix &lt;login&gt; &amp; logout> by *alice*, waiting 30h, past the SLA" +
+				"\n• *Add refunds* by *alice*, waiting 2h" +
+				"\n*carol*:" +
+				"\n• *Add refunds* by *alice*, waiting 0h",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slack, srv := newWebhookReceiver(t)
+			notifier := service.NewSlackNotifier(stubSlackTargets{pullRequest: tt.pullRequest, url: srv.URL})
+
+			require.NoError(t, notifier.Publish(context.Background(), tt.event))
+
+			deliveries := slack.Deliveries()
+			require.Len(t, deliveries, 1)
+			assert.Equal(t, "application/json", deliveries[0].Header.Get("Content-Type"))
+
+			var message service.SlackMessage
+			require.NoError(t, json.Unmarshal(deliveries[0].Body, &message))
+			assert.Equal(t, tt.expectedText, message.Text)
+		})
+	}
+}
+
+func TestSlackNotifier_Skips(t *testing.T) {
+	tests := []struct {
+		name    string
+		event   domain.Event
+		targets func(url string) stubSlackTargets
+	}{
+		{
+			name:  "team without slack webhook",
+			event: domain.Event{Type: domain.EventReviewerAssigned, ReviewerID: "bob"},
+			targets: func(string) stubSlackTargets {
+				return stubSlackTargets{pullRequest: slackPR(nil)}
+			},
+		},
+		{
+			name:  "events other than assignments and merges",
+			event: domain.Event{Type: domain.EventPRCreated},
+			targets: func(url string) stubSlackTargets {
+				return stubSlackTargets{pullRequest: slackPR(nil), url: url}
+			},
+		},
+		{
+			name:  "merge without reviewers",
+			event: domain.Event{Type: domain.EventPRMerged},
+			targets: func(url string) stubSlackTargets {
+				return stubSlackTargets{pullRequest: slackPR(nil), url: url}
+			},
+		},
+		{
+			name:  "digest of a team without slack webhook",
+			event: domain.Event{Type: domain.EventReviewDigest, Digest: &domain.ReviewDigest{TeamName: "payments"}},
+			targets: func(string) stubSlackTargets {
+				return stubSlackTargets{}
+			},
+		},
+		{
+			name:  "pull request no longer exists",
+			event: domain.Event{Type: domain.EventReviewerAssigned, ReviewerID: "bob"},
+			targets: func(string) stubSlackTargets {
+				return stubSlackTargets{err: service.ErrPRNotFound}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slack, srv := newWebhookReceiver(t)
+			notifier := service.NewSlackNotifier(tt.targets(srv.URL))
+
+			require.NoError(t, notifier.Publish(context.Background(), tt.event))
+			assert.Empty(t, slack.Deliveries())
+		})
+	}
+}
+
+func TestSlackNotifier_FailuresAreReturnedForRetry(t *testing.T) {
+	t.Run("slack rejects the message", func(t *testing.T) {
+		slack, srv := newWebhookReceiver(t, http.StatusInternalServerError)
+		notifier := service.NewSlackNotifier(stubSlackTargets{pullRequest: slackPR(nil), url: srv.URL})
+
+		err := notifier.Publish(context.Background(), domain.Event{ID: "7", Type: domain.EventReviewerAssigned, ReviewerID: "bob"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "500")
+		assert.Len(t, slack.Deliveries(), 1, "retries are left to the outbox")
+	})
+
+	t.Run("target lookup fails", func(t *testing.T) {
+		notifier := service.NewSlackNotifier(stubSlackTargets{err: assert.AnError})
+
+		err := notifier.Publish(context.Background(), domain.Event{Type: domain.EventReviewerAssigned, ReviewerID: "bob"})
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
@@ -0,0 +1,195 @@
+package unit_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/stats"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+var pseudonymPattern = regexp.MustCompile(`^user-[0-9a-f]{8}$`)
+
+// realIdentities are the user ids and names returned by the mocks below; none may reach an anonymized body.
+var realIdentities = []string{"alice-id", "bob-id", "Alice", "Bob"}
+
+func anonymizeTestStatistics() *service.Statistics {
+	return &service.Statistics{
+		Overall: &stats.OverallStats{TotalPRs: 4, TotalAssignments: 5, TotalUsers: 2, TotalTeams: 1},
+		ReviewerStats: []stats.ReviewerStat{
+			{UserID: "alice-id", Username: "Alice", Count: 3, OpenCount: 1, MergedCount: 2},
+			{UserID: "bob-id", Username: "Bob", Count: 2, OpenCount: 2, MergedCount: 0},
+		},
+		AuthorStats: []stats.AuthorStat{
+			{UserID: "bob-id", Username: "Bob", Count: 3},
+			{UserID: "alice-id", Username: "Alice", Count: 1},
+		},
+		MergerStats: []stats.MergerStat{
+			{UserID: "alice-id", Username: "Alice", Count: 2},
+		},
+	}
+}
+
+func getAnonymizedStatistics(t *testing.T, h *handler.StatsHandler, query string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/stats"+query, nil)
+
+	h.GetStatistics(c)
+	return w
+}
+
+func TestStatsHandler_GetStatistics_Anonymize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := handlermocks.NewMockStatsServiceInterface(t)
+	mockService.EXPECT().GetStatistics(mock.Anything, stats.Period{}).Return(anonymizeTestStatistics(), nil)
+
+	statsHandler := handler.NewStatsHandler(mockService).WithAnonymization([]byte("secret"), false)
+	w := getAnonymizedStatistics(t, statsHandler, "?anonymize=true")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	for _, identity := range realIdentities {
+		assert.NotContains(t, w.Body.String(), identity)
+	}
+
+	var response handler.StatisticsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.ReviewerStats, 2)
+	require.Len(t, response.AuthorStats, 2)
+
+	alice, bob := response.ReviewerStats[0].UserID, response.ReviewerStats[1].UserID
+	assert.Regexp(t, pseudonymPattern, alice)
+	assert.Regexp(t, pseudonymPattern, bob)
+	assert.NotEqual(t, alice, bob)
+
+	assert.Equal(t, []handler.ReviewerStatResponse{
+		{UserID: alice, Username: alice, Count: 3, OpenCount: 1, MergedCount: 2},
+		{UserID: bob, Username: bob, Count: 2, OpenCount: 2, MergedCount: 0},
+	}, response.ReviewerStats)
+	assert.Equal(t, []handler.AuthorStatResponse{
+		{UserID: bob, Username: bob, Count: 3},
+		{UserID: alice, Username: alice, Count: 1},
+	}, response.AuthorStats)
+	assert.Equal(t, []handler.MergerStatResponse{
+		{UserID: alice, Username: alice, Count: 2},
+	}, response.MergerStats)
+	assert.Equal(t, int64(5), response.Overall.TotalAssignments)
+}
+
+func TestStatsHandler_GetStatistics_AnonymizeStable(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	pseudonymOf := func(key string) string {
+		mockService := handlermocks.NewMockStatsServiceInterface(t)
+		mockService.EXPECT().GetStatistics(mock.Anything, stats.Period{}).Return(anonymizeTestStatistics(), nil)
+
+		statsHandler := handler.NewStatsHandler(mockService).WithAnonymization([]byte(key), true)
+		w := getAnonymizedStatistics(t, statsHandler, "")
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response handler.StatisticsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.ReviewerStats[0].UserID
+	}
+
+	assert.Equal(t, pseudonymOf("secret"), pseudonymOf("secret"))
+	assert.NotEqual(t, pseudonymOf("secret"), pseudonymOf("other"))
+}
+
+func TestStatsHandler_GetStatistics_AnonymizeFlag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		byDefault      bool
+		query          string
+		expectedStatus int
+		anonymized     bool
+	}{
+		{name: "off by default", byDefault: false, query: "", expectedStatus: http.StatusOK, anonymized: false},
+		{name: "on by default", byDefault: true, query: "", expectedStatus: http.StatusOK, anonymized: true},
+		{name: "request disables default", byDefault: true, query: "?anonymize=false", expectedStatus: http.StatusOK, anonymized: false},
+		{name: "request enables", byDefault: false, query: "?anonymize=1", expectedStatus: http.StatusOK, anonymized: true},
+		{name: "invalid value", byDefault: false, query: "?anonymize=maybe", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockStatsServiceInterface(t)
+			if tt.expectedStatus == http.StatusOK {
+				mockService.EXPECT().GetStatistics(mock.Anything, stats.Period{}).Return(anonymizeTestStatistics(), nil)
+			}
+
+			statsHandler := handler.NewStatsHandler(mockService).WithAnonymization(nil, tt.byDefault)
+			w := getAnonymizedStatistics(t, statsHandler, tt.query)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				expectErrorMessage("anonymize must be true or false")(t, w)
+				return
+			}
+
+			var response handler.StatisticsResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			if tt.anonymized {
+				assert.Regexp(t, pseudonymPattern, response.ReviewerStats[0].UserID)
+			} else {
+				assert.Equal(t, "alice-id", response.ReviewerStats[0].UserID)
+				assert.Equal(t, "Alice", response.ReviewerStats[0].Username)
+			}
+		})
+	}
+}
+
+func TestStatsHandler_GetLeaderboard_Anonymize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := handlermocks.NewMockStatsServiceInterface(t)
+	mockService.EXPECT().GetStatistics(mock.Anything, stats.Period{}).Return(anonymizeTestStatistics(), nil)
+	mockService.EXPECT().GetLeaderboard(mock.Anything, service.LeaderboardMonth, 10).Return(&service.Leaderboard{
+		Period:    service.LeaderboardMonth,
+		Reviewers: []stats.RankedUser{{UserID: "alice-id", Username: "Alice", Count: 2}},
+		Authors:   []stats.RankedUser{{UserID: "bob-id", Username: "Bob", Count: 3}},
+	}, nil)
+
+	statsHandler := handler.NewStatsHandler(mockService).WithAnonymization([]byte("secret"), true)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/stats/leaderboard", nil)
+	statsHandler.GetLeaderboard(c)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	for _, identity := range realIdentities {
+		assert.NotContains(t, w.Body.String(), identity)
+	}
+
+	var leaderboard handler.LeaderboardResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &leaderboard))
+
+	var statistics handler.StatisticsResponse
+	statsRecorder := getAnonymizedStatistics(t, statsHandler, "")
+	require.NoError(t, json.Unmarshal(statsRecorder.Body.Bytes(), &statistics))
+
+	// Pseudonyms match across endpoints, so the leaderboard can be joined with /stats.
+	alice, bob := statistics.ReviewerStats[0].UserID, statistics.ReviewerStats[1].UserID
+	assert.Equal(t, []handler.RankedUserResponse{
+		{Rank: 1, UserID: alice, Username: alice, Count: 2},
+	}, leaderboard.Reviewers)
+	assert.Equal(t, []handler.RankedUserResponse{
+		{Rank: 1, UserID: bob, Username: bob, Count: 3},
+	}, leaderboard.Authors)
+}
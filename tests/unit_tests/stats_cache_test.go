@@ -0,0 +1,165 @@
+package unit_tests
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/stats"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	"github.com/mishasvintus/avito_backend_internship/tests"
+)
+
+func TestStatsCache_Get(t *testing.T) {
+	const ttl = 30 * time.Second
+
+	newCache := func() (*service.StatsCache, *tests.FakeClock, *service.DataVersion) {
+		clock := &tests.FakeClock{Current: time.Date(2024, 3, 20, 12, 0, 0, 0, time.UTC)}
+		version := &service.DataVersion{}
+		return service.NewStatsCache(ttl, clock, version), clock, version
+	}
+
+	// counting returns a compute function that counts calls and blocks until release is closed.
+	counting := func(calls *atomic.Int32, release <-chan struct{}) func() (*service.Statistics, error) {
+		return func() (*service.Statistics, error) {
+			calls.Add(1)
+			<-release
+			return &service.Statistics{Overall: &stats.OverallStats{TotalPRs: int64(calls.Load())}}, nil
+		}
+	}
+
+	// burst runs n concurrent Get calls and returns their results.
+	burst := func(cache *service.StatsCache, n int, compute func() (*service.Statistics, error), release chan struct{}) []*service.Statistics {
+		results := make([]*service.Statistics, n)
+		var started, done sync.WaitGroup
+		started.Add(n)
+		done.Add(n)
+		for i := 0; i < n; i++ {
+			go func(i int) {
+				defer done.Done()
+				started.Done()
+				st, err := cache.Get(domain.DefaultOrgID, stats.Period{}, compute)
+				assert.NoError(t, err)
+				results[i] = st
+			}(i)
+		}
+		started.Wait()
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+		done.Wait()
+		return results
+	}
+
+	t.Run("concurrent burst computes once", func(t *testing.T) {
+		cache, _, _ := newCache()
+		var calls atomic.Int32
+		release := make(chan struct{})
+
+		results := burst(cache, 50, counting(&calls, release), release)
+
+		assert.Equal(t, int32(1), calls.Load())
+		for _, st := range results {
+			require.NotNil(t, st)
+			assert.Same(t, results[0], st)
+		}
+	})
+
+	t.Run("served from cache within ttl and recomputed after", func(t *testing.T) {
+		cache, clock, _ := newCache()
+		var calls atomic.Int32
+		release := make(chan struct{})
+		close(release)
+		compute := counting(&calls, release)
+
+		first, err := cache.Get(domain.DefaultOrgID, stats.Period{}, compute)
+		require.NoError(t, err)
+
+		clock.Advance(ttl - time.Second)
+		second, err := cache.Get(domain.DefaultOrgID, stats.Period{}, compute)
+		require.NoError(t, err)
+		assert.Same(t, first, second)
+		assert.Equal(t, int32(1), calls.Load())
+
+		clock.Advance(time.Second)
+		third, err := cache.Get(domain.DefaultOrgID, stats.Period{}, compute)
+		require.NoError(t, err)
+		assert.NotSame(t, first, third)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("version bump invalidates", func(t *testing.T) {
+		cache, _, version := newCache()
+		var calls atomic.Int32
+		release := make(chan struct{})
+		close(release)
+		compute := counting(&calls, release)
+
+		_, err := cache.Get(domain.DefaultOrgID, stats.Period{}, compute)
+		require.NoError(t, err)
+
+		version.Bump()
+		_, err = cache.Get(domain.DefaultOrgID, stats.Period{}, compute)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), calls.Load())
+
+		_, err = cache.Get(domain.DefaultOrgID, stats.Period{}, compute)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("periods are cached separately", func(t *testing.T) {
+		cache, _, _ := newCache()
+		var calls atomic.Int32
+		release := make(chan struct{})
+		close(release)
+		compute := counting(&calls, release)
+
+		from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		sameFrom := from.In(time.FixedZone("UTC+3", 3*60*60))
+
+		_, err := cache.Get(domain.DefaultOrgID, stats.Period{}, compute)
+		require.NoError(t, err)
+		_, err = cache.Get(domain.DefaultOrgID, stats.Period{From: &from}, compute)
+		require.NoError(t, err)
+		_, err = cache.Get(domain.DefaultOrgID, stats.Period{From: &sameFrom}, compute)
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("organizations are cached separately", func(t *testing.T) {
+		cache, _, _ := newCache()
+		var calls atomic.Int32
+		release := make(chan struct{})
+		close(release)
+		compute := counting(&calls, release)
+
+		for _, orgID := range []string{domain.DefaultOrgID, "acme", "acme"} {
+			_, err := cache.Get(orgID, stats.Period{}, compute)
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		cache, _, _ := newCache()
+		var calls atomic.Int32
+		failing := func() (*service.Statistics, error) {
+			calls.Add(1)
+			return nil, assert.AnError
+		}
+
+		_, err := cache.Get(domain.DefaultOrgID, stats.Period{}, failing)
+		assert.ErrorIs(t, err, assert.AnError)
+		_, err = cache.Get(domain.DefaultOrgID, stats.Period{}, failing)
+		assert.ErrorIs(t, err, assert.AnError)
+
+		assert.Equal(t, int32(2), calls.Load())
+	})
+}
@@ -0,0 +1,72 @@
+package unit_tests
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func TestStatsHandler_GetCoverage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	serve := func(mockService *handlermocks.MockStatsServiceInterface) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/stats/coverage", nil)
+		handler.NewStatsHandler(mockService).GetCoverage(c)
+		return w
+	}
+
+	t.Run("totals, shortfall and teams", func(t *testing.T) {
+		mockService := handlermocks.NewMockStatsServiceInterface(t)
+		mockService.EXPECT().GetCoverage(mock.Anything).Return(&service.Coverage{
+			CoverageCounts: service.CoverageCounts{
+				OpenPRs: 3, CoveredPRs: 1, UnderCoveredPRs: 2,
+				Shortfall: []service.ShortfallCount{{Missing: 1, PRs: 1}, {Missing: 2, PRs: 1}},
+			},
+			Teams: []service.TeamCoverage{
+				{TeamName: "backend", ReviewerTarget: 2, CoverageCounts: service.CoverageCounts{
+					OpenPRs: 2, UnderCoveredPRs: 2,
+					Shortfall: []service.ShortfallCount{{Missing: 1, PRs: 1}, {Missing: 2, PRs: 1}},
+				}},
+				{TeamName: "frontend", ReviewerTarget: 1, CoverageCounts: service.CoverageCounts{OpenPRs: 1, CoveredPRs: 1}},
+			},
+		}, nil)
+
+		w := serve(mockService)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"open_prs":3,"covered_prs":1,"under_covered_prs":2,
+			"shortfall":[{"missing_reviewers":1,"prs":1},{"missing_reviewers":2,"prs":1}],
+			"teams":[
+				{"team_name":"backend","reviewer_target":2,"open_prs":2,"covered_prs":0,"under_covered_prs":2,
+				 "shortfall":[{"missing_reviewers":1,"prs":1},{"missing_reviewers":2,"prs":1}]},
+				{"team_name":"frontend","reviewer_target":1,"open_prs":1,"covered_prs":1,"under_covered_prs":0,"shortfall":[]}]}`,
+			w.Body.String())
+	})
+
+	t.Run("no open pull requests", func(t *testing.T) {
+		mockService := handlermocks.NewMockStatsServiceInterface(t)
+		mockService.EXPECT().GetCoverage(mock.Anything).Return(&service.Coverage{Teams: []service.TeamCoverage{}}, nil)
+
+		w := serve(mockService)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"open_prs":0,"covered_prs":0,"under_covered_prs":0,"shortfall":[],"teams":[]}`, w.Body.String())
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		mockService := handlermocks.NewMockStatsServiceInterface(t)
+		mockService.EXPECT().GetCoverage(mock.Anything).Return(nil, errors.New("database is down"))
+
+		w := serve(mockService)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
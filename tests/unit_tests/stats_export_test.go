@@ -0,0 +1,170 @@
+package unit_tests
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/stats"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func TestStatsHandler_ExportStatistics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	loads := []stats.UserLoad{
+		{
+			UserID:           "u1",
+			Username:         "Doe, John",
+			TeamName:         "backend",
+			IsActive:         true,
+			OpenAssignments:  2,
+			TotalAssignments: 5,
+			AuthoredPRs:      1,
+		},
+		{
+			UserID:           "u2",
+			Username:         `Jane "JJ" Roe`,
+			TeamName:         "backend",
+			IsActive:         false,
+			OpenAssignments:  0,
+			TotalAssignments: 3,
+			AuthoredPRs:      4,
+		},
+	}
+
+	tests := []struct {
+		name             string
+		url              string
+		mockSetup        func(*handlermocks.MockStatsServiceInterface)
+		expectedStatus   int
+		validateResponse func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "success - csv by default",
+			url:  "/stats/export",
+			mockSetup: func(m *handlermocks.MockStatsServiceInterface) {
+				m.EXPECT().GetUserLoad(mock.Anything).Return(loads, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Header().Get("Content-Type"), "text/csv")
+				assert.Equal(t, `attachment; filename="review_load.csv"`, w.Header().Get("Content-Disposition"))
+
+				records, err := csv.NewReader(strings.NewReader(w.Body.String())).ReadAll()
+				require.NoError(t, err)
+				require.Len(t, records, 3)
+				assert.Equal(t, []string{"user_id", "username", "team", "is_active", "open_assignments", "total_assignments", "authored_prs"}, records[0])
+				assert.Equal(t, []string{"u1", "Doe, John", "backend", "true", "2", "5", "1"}, records[1])
+				assert.Equal(t, []string{"u2", `Jane "JJ" Roe`, "backend", "false", "0", "3", "4"}, records[2])
+			},
+		},
+		{
+			name: "success - csv quotes usernames with commas",
+			url:  "/stats/export?format=csv",
+			mockSetup: func(m *handlermocks.MockStatsServiceInterface) {
+				m.EXPECT().GetUserLoad(mock.Anything).Return(loads, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				body := w.Body.String()
+				assert.Contains(t, body, "u1,\"Doe, John\",backend,true,2,5,1\n")
+				assert.Contains(t, body, "u2,\"Jane \"\"JJ\"\" Roe\",backend,false,0,3,4\n")
+			},
+		},
+		{
+			name: "success - csv with no users has only header",
+			url:  "/stats/export?format=csv",
+			mockSetup: func(m *handlermocks.MockStatsServiceInterface) {
+				m.EXPECT().GetUserLoad(mock.Anything).Return([]stats.UserLoad{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Equal(t, "user_id,username,team,is_active,open_assignments,total_assignments,authored_prs\n", w.Body.String())
+			},
+		},
+		{
+			name: "success - json",
+			url:  "/stats/export?format=json",
+			mockSetup: func(m *handlermocks.MockStatsServiceInterface) {
+				m.EXPECT().GetUserLoad(mock.Anything).Return(loads, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ExportResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Len(t, response.Users, 2)
+				assert.Equal(t, handler.UserLoadResponse{
+					UserID:           "u1",
+					Username:         "Doe, John",
+					Team:             "backend",
+					IsActive:         true,
+					OpenAssignments:  2,
+					TotalAssignments: 5,
+					AuthoredPRs:      1,
+				}, response.Users[0])
+				assert.Equal(t, "u2", response.Users[1].UserID)
+				assert.False(t, response.Users[1].IsActive)
+				assert.Equal(t, int64(4), response.Users[1].AuthoredPRs)
+			},
+		},
+		{
+			name:           "error - unknown format",
+			url:            "/stats/export?format=xml",
+			mockSetup:      func(m *handlermocks.MockStatsServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Contains(t, response.Error.Message, "format")
+			},
+		},
+		{
+			name: "error - internal error from service",
+			url:  "/stats/export?format=csv",
+			mockSetup: func(m *handlermocks.MockStatsServiceInterface) {
+				m.EXPECT().GetUserLoad(mock.Anything).Return(nil, assert.AnError)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Contains(t, response.Error.Message, "assert.AnError")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockStatsServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			statsHandler := handler.NewStatsHandler(mockService)
+
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			statsHandler.ExportStatistics(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			tt.validateResponse(t, w)
+		})
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/mishasvintus/avito_backend_internship/internal/handler"
@@ -28,7 +30,7 @@ func TestStatsHandler_GetStatistics(t *testing.T) {
 		{
 			name: "success - returns statistics",
 			mockSetup: func(m *handlermocks.MockStatsServiceInterface) {
-				m.EXPECT().GetStatistics().Return(&service.Statistics{
+				m.EXPECT().GetStatistics(mock.Anything, stats.Period{}).Return(&service.Statistics{
 					Overall: &stats.OverallStats{
 						TotalPRs:         5,
 						TotalAssignments: 10,
@@ -37,14 +39,18 @@ func TestStatsHandler_GetStatistics(t *testing.T) {
 					},
 					ReviewerStats: []stats.ReviewerStat{
 						{
-							UserID:   "user1",
-							Username: "reviewer1",
-							Count:    5,
+							UserID:      "user1",
+							Username:    "reviewer1",
+							Count:       5,
+							OpenCount:   2,
+							MergedCount: 3,
 						},
 						{
-							UserID:   "user2",
-							Username: "reviewer2",
-							Count:    3,
+							UserID:      "user2",
+							Username:    "reviewer2",
+							Count:       3,
+							OpenCount:   3,
+							MergedCount: 0,
 						},
 					},
 					AuthorStats: []stats.AuthorStat{
@@ -59,6 +65,13 @@ func TestStatsHandler_GetStatistics(t *testing.T) {
 							Count:    2,
 						},
 					},
+					MergerStats: []stats.MergerStat{
+						{
+							UserID:   "user2",
+							Username: "author2",
+							Count:    4,
+						},
+					},
 				}, nil)
 			},
 			expectedStatus: http.StatusOK,
@@ -78,9 +91,13 @@ func TestStatsHandler_GetStatistics(t *testing.T) {
 				assert.Equal(t, "user1", response.ReviewerStats[0].UserID)
 				assert.Equal(t, "reviewer1", response.ReviewerStats[0].Username)
 				assert.Equal(t, int64(5), response.ReviewerStats[0].Count)
+				assert.Equal(t, int64(2), response.ReviewerStats[0].OpenCount)
+				assert.Equal(t, int64(3), response.ReviewerStats[0].MergedCount)
 				assert.Equal(t, "user2", response.ReviewerStats[1].UserID)
 				assert.Equal(t, "reviewer2", response.ReviewerStats[1].Username)
 				assert.Equal(t, int64(3), response.ReviewerStats[1].Count)
+				assert.Equal(t, int64(3), response.ReviewerStats[1].OpenCount)
+				assert.Equal(t, int64(0), response.ReviewerStats[1].MergedCount)
 
 				// Check author stats
 				assert.Len(t, response.AuthorStats, 2)
@@ -90,12 +107,17 @@ func TestStatsHandler_GetStatistics(t *testing.T) {
 				assert.Equal(t, "user2", response.AuthorStats[1].UserID)
 				assert.Equal(t, "author2", response.AuthorStats[1].Username)
 				assert.Equal(t, int64(2), response.AuthorStats[1].Count)
+
+				// Check merger stats
+				assert.Equal(t, []handler.MergerStatResponse{
+					{UserID: "user2", Username: "author2", Count: 4},
+				}, response.MergerStats)
 			},
 		},
 		{
 			name: "success - empty statistics",
 			mockSetup: func(m *handlermocks.MockStatsServiceInterface) {
-				m.EXPECT().GetStatistics().Return(&service.Statistics{
+				m.EXPECT().GetStatistics(mock.Anything, stats.Period{}).Return(&service.Statistics{
 					Overall: &stats.OverallStats{
 						TotalPRs:         0,
 						TotalAssignments: 0,
@@ -123,7 +145,7 @@ func TestStatsHandler_GetStatistics(t *testing.T) {
 		{
 			name: "error - internal error from service",
 			mockSetup: func(m *handlermocks.MockStatsServiceInterface) {
-				m.EXPECT().GetStatistics().Return(nil, assert.AnError)
+				m.EXPECT().GetStatistics(mock.Anything, stats.Period{}).Return(nil, assert.AnError)
 			},
 			expectedStatus: http.StatusInternalServerError,
 			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -156,3 +178,197 @@ func TestStatsHandler_GetStatistics(t *testing.T) {
 		})
 	}
 }
+
+func TestStatsHandler_GetStatistics_Period(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 31, 23, 59, 59, 0, time.UTC)
+	empty := &service.Statistics{
+		Overall:       &stats.OverallStats{},
+		ReviewerStats: []stats.ReviewerStat{},
+		AuthorStats:   []stats.AuthorStat{},
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		mockSetup      func(*handlermocks.MockStatsServiceInterface)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:  "success - from and to",
+			query: "?from=2024-01-01T00:00:00Z&to=2024-01-31T23:59:59Z",
+			mockSetup: func(m *handlermocks.MockStatsServiceInterface) {
+				m.EXPECT().GetStatistics(mock.Anything, mock.MatchedBy(func(p stats.Period) bool {
+					return p.From != nil && p.From.Equal(from) && p.To != nil && p.To.Equal(to)
+				})).Return(empty, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "success - only from",
+			query: "?from=2024-01-01T03:00:00%2B03:00",
+			mockSetup: func(m *handlermocks.MockStatsServiceInterface) {
+				m.EXPECT().GetStatistics(mock.Anything, mock.MatchedBy(func(p stats.Period) bool {
+					return p.From != nil && p.From.Equal(from) && p.To == nil
+				})).Return(empty, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "success - from equals to",
+			query: "?from=2024-01-01T00:00:00Z&to=2024-01-01T00:00:00Z",
+			mockSetup: func(m *handlermocks.MockStatsServiceInterface) {
+				m.EXPECT().GetStatistics(mock.Anything, mock.Anything).Return(empty, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "error - from after to",
+			query:          "?from=2024-02-01T00:00:00Z&to=2024-01-31T23:59:59Z",
+			mockSetup:      func(m *handlermocks.MockStatsServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "from must not be after to",
+		},
+		{
+			name:           "error - invalid from",
+			query:          "?from=2024-01-01",
+			mockSetup:      func(m *handlermocks.MockStatsServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "from must be an RFC3339 timestamp",
+		},
+		{
+			name:           "error - invalid to",
+			query:          "?to=yesterday",
+			mockSetup:      func(m *handlermocks.MockStatsServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "to must be an RFC3339 timestamp",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockStatsServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			statsHandler := handler.NewStatsHandler(mockService)
+
+			req, err := http.NewRequest(http.MethodGet, "/stats"+tt.query, nil)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			statsHandler.GetStatistics(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response.Error.Message)
+			}
+		})
+	}
+}
+
+func TestStatsHandler_GetStatistics_Distribution(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := handlermocks.NewMockStatsServiceInterface(t)
+	mockService.EXPECT().GetStatistics(mock.Anything, stats.Period{}).Return(&service.Statistics{
+		Overall:       &stats.OverallStats{},
+		ReviewerStats: []stats.ReviewerStat{},
+		AuthorStats:   []stats.AuthorStat{},
+		Distribution: service.Distribution{
+			Overall: service.LoadDistribution{ActiveUsers: 10, Min: 0, Max: 5, Mean: 1, Median: 1, StdDev: 1.5},
+			Teams: []service.TeamLoadDistribution{
+				{
+					TeamName:           "backend",
+					LoadDistribution:   service.LoadDistribution{ActiveUsers: 5, Min: 0, Max: 5, Mean: 1, Median: 0, StdDev: 2},
+					OverdueAssignments: 3,
+				},
+			},
+		},
+	}, nil)
+
+	statsHandler := handler.NewStatsHandler(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/stats", nil)
+
+	statsHandler.GetStatistics(c)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, map[string]any{
+		"overall": map[string]any{
+			"active_users": 10.0, "min": 0.0, "max": 5.0, "mean": 1.0, "median": 1.0, "stddev": 1.5,
+		},
+		"teams": []any{
+			map[string]any{
+				"team_name": "backend", "active_users": 5.0, "min": 0.0, "max": 5.0, "mean": 1.0, "median": 0.0, "stddev": 2.0,
+				"overdue_assignments": 3.0,
+			},
+		},
+	}, response["distribution"])
+}
+
+func TestStatsHandler_GetStatistics_CacheControl(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		ttl      time.Duration
+		expected string
+	}{
+		{name: "ttl advertised as max-age", ttl: 30 * time.Second, expected: "max-age=30"},
+		{name: "no cache", ttl: 0, expected: "no-cache"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockStatsServiceInterface(t)
+			mockService.EXPECT().GetStatistics(mock.Anything, stats.Period{}).Return(&service.Statistics{
+				Overall: &stats.OverallStats{},
+			}, nil)
+
+			statsHandler := handler.NewStatsHandler(mockService).WithCacheTTL(tt.ttl)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/stats", nil)
+
+			statsHandler.GetStatistics(c)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.expected, w.Header().Get("Cache-Control"))
+		})
+	}
+}
+
+func TestStatsHandler_GetStatistics_Timeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := handlermocks.NewMockStatsServiceInterface(t)
+	mockService.EXPECT().GetStatistics(mock.Anything, stats.Period{}).Return(nil, service.ErrStatsTimeout)
+
+	statsHandler := handler.NewStatsHandler(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/stats", nil)
+
+	statsHandler.GetStatistics(c)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	var response handler.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, handler.ErrorTimeout, response.Error.Code)
+}
@@ -0,0 +1,167 @@
+package unit_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/stats"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func TestStatsHandler_GetLeaderboard(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	since := time.Date(2024, 3, 13, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		query            string
+		mockSetup        func(*handlermocks.MockStatsServiceInterface)
+		expectedStatus   int
+		validateResponse func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "success - ranked lists",
+			query: "?period=7d&limit=2",
+			mockSetup: func(m *handlermocks.MockStatsServiceInterface) {
+				m.EXPECT().GetLeaderboard(mock.Anything, service.LeaderboardWeek, 2).Return(&service.Leaderboard{
+					Period: service.LeaderboardWeek,
+					Since:  &since,
+					Reviewers: []stats.RankedUser{
+						{UserID: "u2", Username: "Bob", Count: 4},
+						{UserID: "u1", Username: "Alice", Count: 2},
+					},
+					Authors: []stats.RankedUser{
+						{UserID: "u1", Username: "Alice", Count: 3},
+					},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.LeaderboardResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+				assert.Equal(t, "7d", response.Period)
+				assert.Equal(t, "2024-03-13T12:00:00Z", response.Since)
+				assert.Equal(t, []handler.RankedUserResponse{
+					{Rank: 1, UserID: "u2", Username: "Bob", Count: 4},
+					{Rank: 2, UserID: "u1", Username: "Alice", Count: 2},
+				}, response.Reviewers)
+				assert.Equal(t, []handler.RankedUserResponse{
+					{Rank: 1, UserID: "u1", Username: "Alice", Count: 3},
+				}, response.Authors)
+			},
+		},
+		{
+			name:  "success - defaults to 30d and limit 10",
+			query: "",
+			mockSetup: func(m *handlermocks.MockStatsServiceInterface) {
+				m.EXPECT().GetLeaderboard(mock.Anything, service.LeaderboardMonth, 10).Return(&service.Leaderboard{
+					Period:    service.LeaderboardMonth,
+					Since:     &since,
+					Reviewers: []stats.RankedUser{},
+					Authors:   []stats.RankedUser{},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "30d", response["period"])
+				assert.Equal(t, []any{}, response["reviewers"])
+				assert.Equal(t, []any{}, response["authors"])
+			},
+		},
+		{
+			name:  "success - all-time has no since",
+			query: "?period=all",
+			mockSetup: func(m *handlermocks.MockStatsServiceInterface) {
+				m.EXPECT().GetLeaderboard(mock.Anything, service.LeaderboardAll, 10).Return(&service.Leaderboard{
+					Period:    service.LeaderboardAll,
+					Reviewers: []stats.RankedUser{},
+					Authors:   []stats.RankedUser{},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.NotContains(t, response, "since")
+			},
+		},
+		{
+			name:  "error - unknown period",
+			query: "?period=1y",
+			mockSetup: func(m *handlermocks.MockStatsServiceInterface) {
+				m.EXPECT().GetLeaderboard(mock.Anything, service.LeaderboardPeriod("1y"), 10).Return(nil, service.ErrInvalidLeaderboardPeriod)
+			},
+			expectedStatus:   http.StatusBadRequest,
+			validateResponse: expectErrorMessage(service.ErrInvalidLeaderboardPeriod.Error()),
+		},
+		{
+			name:             "error - limit zero",
+			query:            "?limit=0",
+			mockSetup:        func(m *handlermocks.MockStatsServiceInterface) {},
+			expectedStatus:   http.StatusBadRequest,
+			validateResponse: expectErrorMessage("limit must be an integer between 1 and 100"),
+		},
+		{
+			name:             "error - limit too large",
+			query:            "?limit=101",
+			mockSetup:        func(m *handlermocks.MockStatsServiceInterface) {},
+			expectedStatus:   http.StatusBadRequest,
+			validateResponse: expectErrorMessage("limit must be an integer between 1 and 100"),
+		},
+		{
+			name:             "error - limit not a number",
+			query:            "?limit=ten",
+			mockSetup:        func(m *handlermocks.MockStatsServiceInterface) {},
+			expectedStatus:   http.StatusBadRequest,
+			validateResponse: expectErrorMessage("limit must be an integer between 1 and 100"),
+		},
+		{
+			name:  "error - internal error from service",
+			query: "",
+			mockSetup: func(m *handlermocks.MockStatsServiceInterface) {
+				m.EXPECT().GetLeaderboard(mock.Anything, service.LeaderboardMonth, 10).Return(nil, assert.AnError)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Contains(t, response.Error.Message, "assert.AnError")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockStatsServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			statsHandler := handler.NewStatsHandler(mockService)
+
+			req, err := http.NewRequest(http.MethodGet, "/stats/leaderboard"+tt.query, nil)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			statsHandler.GetLeaderboard(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			tt.validateResponse(t, w)
+		})
+	}
+}
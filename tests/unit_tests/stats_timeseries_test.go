@@ -0,0 +1,185 @@
+package unit_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/stats"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func TestStatsHandler_GetThroughput(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 14, 23, 59, 59, 0, time.UTC)
+	throughput := &service.Throughput{
+		Bucket:   stats.BucketWeek,
+		From:     from,
+		To:       to,
+		TeamName: "backend",
+		Points: []stats.ThroughputPoint{
+			{BucketStart: from, Created: 3, Merged: 1},
+			{BucketStart: from.AddDate(0, 0, 7), Created: 0, Merged: 2},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		query            string
+		mockSetup        func(*handlermocks.MockStatsServiceInterface)
+		expectedStatus   int
+		validateResponse func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "success - weekly buckets for team",
+			query: "?bucket=week&from=2024-01-01T00:00:00Z&to=2024-01-14T23:59:59Z&team_name=backend",
+			mockSetup: func(m *handlermocks.MockStatsServiceInterface) {
+				m.EXPECT().GetThroughput(mock.Anything, stats.BucketWeek, mock.MatchedBy(func(p stats.Period) bool {
+					return p.From != nil && p.From.Equal(from) && p.To != nil && p.To.Equal(to)
+				}), "backend").Return(throughput, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ThroughputResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+				assert.Equal(t, "week", response.Bucket)
+				assert.Equal(t, "2024-01-01T00:00:00Z", response.From)
+				assert.Equal(t, "2024-01-14T23:59:59Z", response.To)
+				assert.Equal(t, "backend", response.TeamName)
+				assert.Equal(t, []handler.ThroughputPointResponse{
+					{Start: "2024-01-01", Created: 3, Merged: 1},
+					{Start: "2024-01-08", Created: 0, Merged: 2},
+				}, response.Buckets)
+			},
+		},
+		{
+			name:  "success - defaults to weekly buckets over all teams",
+			query: "",
+			mockSetup: func(m *handlermocks.MockStatsServiceInterface) {
+				m.EXPECT().GetThroughput(mock.Anything, stats.BucketWeek, stats.Period{}, "").Return(&service.Throughput{
+					Bucket: stats.BucketWeek,
+					From:   from,
+					To:     to,
+					Points: []stats.ThroughputPoint{},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.NotContains(t, response, "team_name")
+				assert.Equal(t, []any{}, response["buckets"])
+			},
+		},
+		{
+			name:  "success - daily buckets",
+			query: "?bucket=day",
+			mockSetup: func(m *handlermocks.MockStatsServiceInterface) {
+				m.EXPECT().GetThroughput(mock.Anything, stats.BucketDay, stats.Period{}, "").Return(&service.Throughput{
+					Bucket: stats.BucketDay,
+					From:   from,
+					To:     from,
+					Points: []stats.ThroughputPoint{{BucketStart: from, Created: 1}},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ThroughputResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "day", response.Bucket)
+				require.Len(t, response.Buckets, 1)
+				assert.Equal(t, "2024-01-01", response.Buckets[0].Start)
+			},
+		},
+		{
+			name:             "error - unknown bucket",
+			query:            "?bucket=month",
+			mockSetup:        func(m *handlermocks.MockStatsServiceInterface) {},
+			expectedStatus:   http.StatusBadRequest,
+			validateResponse: expectErrorMessage("bucket must be day or week"),
+		},
+		{
+			name:             "error - from after to",
+			query:            "?from=2024-02-01T00:00:00Z&to=2024-01-01T00:00:00Z",
+			mockSetup:        func(m *handlermocks.MockStatsServiceInterface) {},
+			expectedStatus:   http.StatusBadRequest,
+			validateResponse: expectErrorMessage("from must not be after to"),
+		},
+		{
+			name:  "error - too many buckets",
+			query: "?bucket=day&from=2020-01-01T00:00:00Z",
+			mockSetup: func(m *handlermocks.MockStatsServiceInterface) {
+				m.EXPECT().GetThroughput(mock.Anything, stats.BucketDay, mock.Anything, "").Return(nil, service.ErrTooManyBuckets)
+			},
+			expectedStatus:   http.StatusBadRequest,
+			validateResponse: expectErrorMessage(service.ErrTooManyBuckets.Error()),
+		},
+		{
+			name:  "error - team not found",
+			query: "?team_name=ghost",
+			mockSetup: func(m *handlermocks.MockStatsServiceInterface) {
+				m.EXPECT().GetThroughput(mock.Anything, stats.BucketWeek, stats.Period{}, "ghost").Return(nil, service.ErrTeamNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, handler.ErrorNotFound, response.Error.Code)
+			},
+		},
+		{
+			name:  "error - internal error from service",
+			query: "",
+			mockSetup: func(m *handlermocks.MockStatsServiceInterface) {
+				m.EXPECT().GetThroughput(mock.Anything, stats.BucketWeek, stats.Period{}, "").Return(nil, assert.AnError)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Contains(t, response.Error.Message, "assert.AnError")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockStatsServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			statsHandler := handler.NewStatsHandler(mockService)
+
+			req, err := http.NewRequest(http.MethodGet, "/stats/timeseries"+tt.query, nil)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			statsHandler.GetThroughput(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			tt.validateResponse(t, w)
+		})
+	}
+}
+
+func expectErrorMessage(message string) func(*testing.T, *httptest.ResponseRecorder) {
+	return func(t *testing.T, w *httptest.ResponseRecorder) {
+		var response handler.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, message, response.Error.Message)
+	}
+}
@@ -0,0 +1,164 @@
+package unit_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/stats"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func TestStatsHandler_GetUserStatistics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	maxOpen := 2
+	avg := 90 * time.Minute
+
+	tests := []struct {
+		name             string
+		query            string
+		mockSetup        func(*handlermocks.MockStatsServiceInterface)
+		expectedStatus   int
+		validateResponse func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "success - active reviewer",
+			query: "?user_id=u1",
+			mockSetup: func(m *handlermocks.MockStatsServiceInterface) {
+				m.EXPECT().GetUserStatistics(mock.Anything, "u1").Return(&service.UserStatistics{
+					User: domain.User{
+						UserID:         "u1",
+						Username:       "Alice",
+						TeamName:       "backend",
+						IsActive:       true,
+						MaxOpenReviews: &maxOpen,
+					},
+					Activity: stats.UserActivity{
+						OpenReviews:      2,
+						CompletedReviews: 5,
+						AuthoredOpen:     1,
+						AuthoredMerged:   3,
+						AvgTimeToMerge:   &avg,
+					},
+					AtCapacity: true,
+					CurrentAbsence: &domain.Absence{
+						UserID:   "u1",
+						FromDate: time.Date(2024, 3, 18, 0, 0, 0, 0, time.UTC),
+						ToDate:   time.Date(2024, 3, 22, 0, 0, 0, 0, time.UTC),
+					},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.UserStatisticsResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+				assert.Equal(t, "u1", response.UserID)
+				assert.Equal(t, "Alice", response.Username)
+				assert.Equal(t, "backend", response.TeamName)
+				assert.True(t, response.IsActive)
+				assert.Equal(t, int64(2), response.OpenReviews)
+				assert.Equal(t, int64(5), response.CompletedReviews)
+				assert.Equal(t, int64(1), response.AuthoredOpenPRs)
+				assert.Equal(t, int64(3), response.AuthoredMergedPRs)
+				require.NotNil(t, response.AvgTimeToMergeSeconds)
+				assert.Equal(t, 5400.0, *response.AvgTimeToMergeSeconds)
+				require.NotNil(t, response.MaxOpenReviews)
+				assert.Equal(t, 2, *response.MaxOpenReviews)
+				assert.True(t, response.AtCapacity)
+				require.NotNil(t, response.CurrentAbsence)
+				assert.Equal(t, "2024-03-18", response.CurrentAbsence.FromDate)
+				assert.Equal(t, "2024-03-22", response.CurrentAbsence.ToDate)
+			},
+		},
+		{
+			name:  "success - user with zero activity",
+			query: "?user_id=u2",
+			mockSetup: func(m *handlermocks.MockStatsServiceInterface) {
+				m.EXPECT().GetUserStatistics(mock.Anything, "u2").Return(&service.UserStatistics{
+					User: domain.User{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+				assert.Equal(t, 0.0, response["open_reviews"])
+				assert.Equal(t, 0.0, response["completed_reviews"])
+				assert.Equal(t, 0.0, response["authored_open_prs"])
+				assert.Equal(t, 0.0, response["authored_merged_prs"])
+				assert.Contains(t, response, "avg_time_to_merge_seconds")
+				assert.Nil(t, response["avg_time_to_merge_seconds"])
+				assert.Nil(t, response["max_open_reviews"])
+				assert.Equal(t, false, response["at_capacity"])
+				assert.Nil(t, response["current_absence"])
+			},
+		},
+		{
+			name:             "error - missing user_id",
+			query:            "",
+			mockSetup:        func(m *handlermocks.MockStatsServiceInterface) {},
+			expectedStatus:   http.StatusBadRequest,
+			validateResponse: expectErrorMessage("user_id parameter is required"),
+		},
+		{
+			name:  "error - user not found",
+			query: "?user_id=ghost",
+			mockSetup: func(m *handlermocks.MockStatsServiceInterface) {
+				m.EXPECT().GetUserStatistics(mock.Anything, "ghost").Return(nil, service.ErrUserNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, handler.ErrorNotFound, response.Error.Code)
+			},
+		},
+		{
+			name:  "error - internal error from service",
+			query: "?user_id=u1",
+			mockSetup: func(m *handlermocks.MockStatsServiceInterface) {
+				m.EXPECT().GetUserStatistics(mock.Anything, "u1").Return(nil, assert.AnError)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Contains(t, response.Error.Message, "assert.AnError")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockStatsServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			statsHandler := handler.NewStatsHandler(mockService)
+
+			req, err := http.NewRequest(http.MethodGet, "/stats/user"+tt.query, nil)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			statsHandler.GetUserStatistics(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			tt.validateResponse(t, w)
+		})
+	}
+}
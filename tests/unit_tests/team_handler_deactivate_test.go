@@ -9,6 +9,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/mishasvintus/avito_backend_internship/internal/handler"
@@ -32,7 +33,7 @@ func TestTeamHandler_DeactivateTeam(t *testing.T) {
 				"team_name": "test_team",
 			},
 			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
-				m.EXPECT().DeactivateTeam("test_team").Return(nil)
+				m.EXPECT().DeactivateTeam(mock.Anything, "test_team").Return(nil)
 			},
 			expectedStatus: http.StatusOK,
 			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -62,7 +63,7 @@ func TestTeamHandler_DeactivateTeam(t *testing.T) {
 				"team_name": "nonexistent_team",
 			},
 			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
-				m.EXPECT().DeactivateTeam("nonexistent_team").Return(service.ErrTeamNotFound)
+				m.EXPECT().DeactivateTeam(mock.Anything, "nonexistent_team").Return(service.ErrTeamNotFound)
 			},
 			expectedStatus: http.StatusNotFound,
 			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -79,7 +80,7 @@ func TestTeamHandler_DeactivateTeam(t *testing.T) {
 				"team_name": "error_team",
 			},
 			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
-				m.EXPECT().DeactivateTeam("error_team").Return(assert.AnError)
+				m.EXPECT().DeactivateTeam(mock.Anything, "error_team").Return(assert.AnError)
 			},
 			expectedStatus: http.StatusInternalServerError,
 			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -0,0 +1,237 @@
+package unit_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func TestTeamHandler_SetDigestSchedule(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name             string
+		body             string
+		mockSetup        func(*handlermocks.MockTeamServiceInterface)
+		expectedStatus   int
+		validateResponse func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "success - midnight",
+			body: `{"team_name":"backend","hour":0,"timezone":"Europe/Moscow"}`,
+			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
+				m.EXPECT().SetDigestSchedule(mock.Anything, "backend", 0, "Europe/Moscow").Return(&domain.DigestSchedule{
+					TeamName:   "backend",
+					Hour:       0,
+					Timezone:   "Europe/Moscow",
+					LastSentAt: time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC),
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.JSONEq(t, `{"digest":{"team_name":"backend","hour":0,"timezone":"Europe/Moscow",
+					"last_sent_at":"2026-03-02T09:30:00Z"}}`, w.Body.String())
+			},
+		},
+		{
+			name: "error - unknown timezone",
+			body: `{"team_name":"backend","hour":9,"timezone":"Mars/Olympus"}`,
+			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
+				m.EXPECT().SetDigestSchedule(mock.Anything, "backend", 9, "Mars/Olympus").Return(nil, service.ErrInvalidTimezone)
+			},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Len(t, response.Error.Details, 1)
+				assert.Equal(t, "timezone", response.Error.Details[0].Field)
+			},
+		},
+		{
+			name: "error - team not found",
+			body: `{"team_name":"ghost","hour":9,"timezone":"UTC"}`,
+			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
+				m.EXPECT().SetDigestSchedule(mock.Anything, "ghost", 9, "UTC").Return(nil, service.ErrTeamNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "team not found", response.Error.Message)
+			},
+		},
+		{
+			name:           "error - missing hour",
+			body:           `{"team_name":"backend","timezone":"UTC"}`,
+			mockSetup:      func(m *handlermocks.MockTeamServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Len(t, response.Error.Details, 1)
+				assert.Equal(t, "hour", response.Error.Details[0].Field)
+			},
+		},
+		{
+			name:           "error - hour out of range",
+			body:           `{"team_name":"backend","hour":24,"timezone":"UTC"}`,
+			mockSetup:      func(m *handlermocks.MockTeamServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Len(t, response.Error.Details, 1)
+				assert.Equal(t, "hour", response.Error.Details[0].Field)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockTeamServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/team/digest", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler.NewTeamHandler(mockService).SetDigestSchedule(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			tt.validateResponse(t, w)
+		})
+	}
+}
+
+func TestTeamHandler_GetAndDeleteDigestSchedule(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	serve := func(method string, mockService *handlermocks.MockTeamServiceInterface, target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(method, target, nil)
+		h := handler.NewTeamHandler(mockService)
+		if method == http.MethodGet {
+			h.GetDigestSchedule(c)
+		} else {
+			h.DeleteDigestSchedule(c)
+		}
+		return w
+	}
+
+	t.Run("get", func(t *testing.T) {
+		mockService := handlermocks.NewMockTeamServiceInterface(t)
+		mockService.EXPECT().GetDigestSchedule(mock.Anything, "backend").Return(&domain.DigestSchedule{
+			TeamName: "backend", Hour: 9, Timezone: "UTC", LastSentAt: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC),
+		}, nil)
+		w := serve(http.MethodGet, mockService, "/team/digest?team_name=backend")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"digest":{"team_name":"backend","hour":9,"timezone":"UTC","last_sent_at":"2026-03-02T09:00:00Z"}}`, w.Body.String())
+	})
+
+	t.Run("get without schedule", func(t *testing.T) {
+		mockService := handlermocks.NewMockTeamServiceInterface(t)
+		mockService.EXPECT().GetDigestSchedule(mock.Anything, "backend").Return(nil, service.ErrDigestNotFound)
+		w := serve(http.MethodGet, mockService, "/team/digest?team_name=backend")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		mockService := handlermocks.NewMockTeamServiceInterface(t)
+		mockService.EXPECT().DeleteDigestSchedule(mock.Anything, "backend").Return(nil)
+		w := serve(http.MethodDelete, mockService, "/team/digest?team_name=backend")
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("delete without schedule", func(t *testing.T) {
+		mockService := handlermocks.NewMockTeamServiceInterface(t)
+		mockService.EXPECT().DeleteDigestSchedule(mock.Anything, "backend").Return(service.ErrDigestNotFound)
+		w := serve(http.MethodDelete, mockService, "/team/digest?team_name=backend")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("missing team name", func(t *testing.T) {
+		w := serve(http.MethodDelete, handlermocks.NewMockTeamServiceInterface(t), "/team/digest")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestDigestSchedule_Due(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+	// 09:00 in Moscow is 06:00 UTC.
+	setAt := time.Date(2026, 3, 2, 12, 0, 0, 0, moscow)
+
+	tests := []struct {
+		name       string
+		lastSentAt time.Time
+		now        time.Time
+		want       bool
+	}{
+		{
+			name:       "set after today's hour",
+			lastSentAt: setAt,
+			now:        setAt.Add(time.Hour),
+			want:       false,
+		},
+		{
+			name:       "a second before the next hour",
+			lastSentAt: setAt,
+			now:        time.Date(2026, 3, 3, 5, 59, 59, 0, time.UTC),
+			want:       false,
+		},
+		{
+			name:       "at the next hour",
+			lastSentAt: setAt,
+			now:        time.Date(2026, 3, 3, 6, 0, 0, 0, time.UTC),
+			want:       true,
+		},
+		{
+			name:       "sent at the hour",
+			lastSentAt: time.Date(2026, 3, 3, 9, 0, 0, 0, moscow),
+			now:        time.Date(2026, 3, 3, 23, 0, 0, 0, moscow),
+			want:       false,
+		},
+		{
+			name:       "missed for days",
+			lastSentAt: setAt,
+			now:        time.Date(2026, 3, 6, 8, 0, 0, 0, moscow),
+			want:       true,
+		},
+		{
+			name:       "stored in another zone",
+			lastSentAt: time.Date(2026, 3, 3, 6, 0, 0, 0, time.UTC),
+			now:        time.Date(2026, 3, 3, 10, 0, 0, 0, moscow),
+			want:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := domain.DigestSchedule{TeamName: "backend", Hour: 9, Timezone: "Europe/Moscow", LastSentAt: tt.lastSentAt}
+			due, err := s.Due(tt.now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, due)
+		})
+	}
+
+	t.Run("unknown timezone", func(t *testing.T) {
+		s := domain.DigestSchedule{TeamName: "backend", Hour: 9, Timezone: "Mars/Olympus"}
+		_, err := s.Due(setAt)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,196 @@
+package unit_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func TestTeamHandler_SetOwnershipRule(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name             string
+		body             string
+		mockSetup        func(*handlermocks.MockTeamServiceInterface)
+		expectedStatus   int
+		validateResponse func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "success - user owner",
+			body: `{"team_name":"backend","path_prefix":"/internal/service/","owner_user_id":"u1"}`,
+			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
+				m.EXPECT().SetOwnershipRule(mock.Anything, domain.OwnershipRule{
+					TeamName: "backend", PathPrefix: "/internal/service/", OwnerUserID: "u1",
+				}).Return(&domain.OwnershipRule{TeamName: "backend", PathPrefix: "internal/service", OwnerUserID: "u1"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.JSONEq(t, `{"rule":{"path_prefix":"internal/service","owner_user_id":"u1"}}`, w.Body.String())
+			},
+		},
+		{
+			name: "error - owner team not found",
+			body: `{"team_name":"backend","path_prefix":"api","owner_team_name":"platform"}`,
+			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
+				m.EXPECT().SetOwnershipRule(mock.Anything, domain.OwnershipRule{
+					TeamName: "backend", PathPrefix: "api", OwnerTeamName: "platform",
+				}).Return(nil, service.ErrOwnerTeamNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "owner team not found", response.Error.Message)
+			},
+		},
+		{
+			name:           "error - both owners",
+			body:           `{"team_name":"backend","path_prefix":"api","owner_user_id":"u1","owner_team_name":"platform"}`,
+			mockSetup:      func(m *handlermocks.MockTeamServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Len(t, response.Error.Details, 1)
+				assert.Equal(t, "owner_user_id", response.Error.Details[0].Field)
+			},
+		},
+		{
+			name:           "error - no owner",
+			body:           `{"team_name":"backend","path_prefix":"api"}`,
+			mockSetup:      func(m *handlermocks.MockTeamServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Len(t, response.Error.Details, 1)
+				assert.Equal(t, "required_without", response.Error.Details[0].Rule)
+			},
+		},
+		{
+			name:           "error - missing path prefix",
+			body:           `{"team_name":"backend","owner_user_id":"u1"}`,
+			mockSetup:      func(m *handlermocks.MockTeamServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Len(t, response.Error.Details, 1)
+				assert.Equal(t, "path_prefix", response.Error.Details[0].Field)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockTeamServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/team/ownership", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler.NewTeamHandler(mockService).SetOwnershipRule(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			tt.validateResponse(t, w)
+		})
+	}
+}
+
+func TestTeamHandler_ListOwnershipRules(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := handlermocks.NewMockTeamServiceInterface(t)
+	mockService.EXPECT().ListOwnershipRules(mock.Anything, "backend").Return([]domain.OwnershipRule{
+		{TeamName: "backend", PathPrefix: "", OwnerTeamName: "platform"},
+		{TeamName: "backend", PathPrefix: "internal/service", OwnerUserID: "u1"},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/team/ownership?team_name=backend", nil)
+
+	handler.NewTeamHandler(mockService).ListOwnershipRules(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"team_name":"backend","rules":[
+		{"path_prefix":"","owner_team_name":"platform"},
+		{"path_prefix":"internal/service","owner_user_id":"u1"}]}`, w.Body.String())
+}
+
+func TestTeamHandler_DeleteOwnershipRule(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	serve := func(mockService *handlermocks.MockTeamServiceInterface, target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodDelete, target, nil)
+		handler.NewTeamHandler(mockService).DeleteOwnershipRule(c)
+		return w
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockService := handlermocks.NewMockTeamServiceInterface(t)
+		mockService.EXPECT().DeleteOwnershipRule(mock.Anything, "backend", "internal/service").Return(nil)
+		w := serve(mockService, "/team/ownership?team_name=backend&path_prefix=internal%2Fservice")
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("rule not found", func(t *testing.T) {
+		mockService := handlermocks.NewMockTeamServiceInterface(t)
+		mockService.EXPECT().DeleteOwnershipRule(mock.Anything, "backend", "api").Return(service.ErrOwnershipRuleNotFound)
+		w := serve(mockService, "/team/ownership?team_name=backend&path_prefix=api")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("missing path prefix", func(t *testing.T) {
+		w := serve(handlermocks.NewMockTeamServiceInterface(t), "/team/ownership?team_name=backend")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestMatchOwnershipRule(t *testing.T) {
+	rules := []domain.OwnershipRule{
+		{PathPrefix: "", OwnerTeamName: "platform"},
+		{PathPrefix: "internal", OwnerUserID: "lead"},
+		{PathPrefix: "internal/service", OwnerUserID: "svc"},
+		{PathPrefix: "internal/service/billing", OwnerTeamName: "payments"},
+	}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"internal/service/billing/invoice.go", "internal/service/billing"},
+		{"/internal/service/billing", "internal/service/billing"},
+		{"internal/service/pr_service.go", "internal/service"},
+		{"internal/services/legacy.go", "internal"},
+		{"internal/handler/team_handler.go", "internal"},
+		{"internal", "internal"},
+		{"README.md", ""},
+		{"internalize/x.go", ""},
+	}
+	for _, tt := range tests {
+		got := domain.MatchOwnershipRule(rules, tt.path)
+		require.NotNil(t, got, tt.path)
+		assert.Equal(t, tt.want, got.PathPrefix, tt.path)
+	}
+
+	assert.Nil(t, domain.MatchOwnershipRule(rules[1:], "README.md"))
+	assert.Nil(t, domain.MatchOwnershipRule(nil, "internal/x.go"))
+}
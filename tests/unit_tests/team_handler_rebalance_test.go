@@ -0,0 +1,157 @@
+package unit_tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func TestTeamHandler_RebalanceTeam(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	moves := []service.RebalanceMove{
+		{PR: domain.PRKey{RepositoryName: "backend", PullRequestID: "pr-1"}, From: "u1", To: "u3"},
+		{PR: domain.PRKey{PullRequestID: "pr-2"}, From: "u1", To: "u4"},
+	}
+
+	tests := []struct {
+		name             string
+		query            string
+		requestBody      interface{}
+		mockSetup        func(*handlermocks.MockTeamServiceInterface)
+		expectedStatus   int
+		validateResponse func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "success - moves applied",
+			requestBody: map[string]interface{}{"team_name": "backend", "max_moves": 5},
+			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
+				m.EXPECT().RebalanceTeam(mock.Anything, "backend", service.RebalanceOptions{MaxMoves: 5}).Return(moves, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.RebalanceTeamResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, handler.RebalanceTeamResponse{
+					TeamName: "backend",
+					Moves: []handler.RebalanceMoveResponse{
+						{RepositoryName: "backend", PullRequestID: "pr-1", FromUserID: "u1", ToUserID: "u3"},
+						{PullRequestID: "pr-2", FromUserID: "u1", ToUserID: "u4"},
+					},
+				}, response)
+			},
+		},
+		{
+			name:        "success - dry run in query",
+			query:       "?dry_run=true",
+			requestBody: map[string]interface{}{"team_name": "backend"},
+			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
+				m.EXPECT().RebalanceTeam(mock.Anything, "backend", service.RebalanceOptions{DryRun: true}).Return(moves, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.RebalanceTeamResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.True(t, response.DryRun)
+				assert.Len(t, response.Moves, 2)
+			},
+		},
+		{
+			name:        "success - dry run in body, already balanced",
+			requestBody: map[string]interface{}{"team_name": "backend", "dry_run": true},
+			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
+				m.EXPECT().RebalanceTeam(mock.Anything, "backend", service.RebalanceOptions{DryRun: true}).Return(nil, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.JSONEq(t, `{"team_name":"backend","dry_run":true,"moves":[]}`, w.Body.String())
+			},
+		},
+		{
+			name:           "error - invalid dry_run",
+			query:          "?dry_run=maybe",
+			requestBody:    map[string]interface{}{"team_name": "backend"},
+			mockSetup:      func(m *handlermocks.MockTeamServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "dry_run must be true or false", response.Error.Message)
+			},
+		},
+		{
+			name:           "error - max_moves not positive",
+			requestBody:    map[string]interface{}{"team_name": "backend", "max_moves": -1},
+			mockSetup:      func(m *handlermocks.MockTeamServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, handler.ErrorValidation, response.Error.Code)
+			},
+		},
+		{
+			name:        "error - team not found",
+			requestBody: map[string]interface{}{"team_name": "ghost"},
+			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
+				m.EXPECT().RebalanceTeam(mock.Anything, "ghost", service.RebalanceOptions{}).Return(nil, service.ErrTeamNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "team not found", response.Error.Message)
+			},
+		},
+		{
+			name:        "error - pull request merged concurrently",
+			requestBody: map[string]interface{}{"team_name": "backend"},
+			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
+				m.EXPECT().RebalanceTeam(mock.Anything, "backend", service.RebalanceOptions{}).Return(nil, service.ErrPRMerged)
+			},
+			expectedStatus: http.StatusConflict,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, handler.ErrorPRMerged, response.Error.Code)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockTeamServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			teamHandler := handler.NewTeamHandler(mockService)
+
+			body, err := json.Marshal(tt.requestBody)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/team/rebalance"+tt.query, bytes.NewBuffer(body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			teamHandler.RebalanceTeam(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			tt.validateResponse(t, w)
+		})
+	}
+}
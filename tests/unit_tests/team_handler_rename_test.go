@@ -0,0 +1,157 @@
+package unit_tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func TestTeamHandler_RenameTeam(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name             string
+		requestBody      interface{}
+		mockSetup        func(*handlermocks.MockTeamServiceInterface)
+		expectedStatus   int
+		validateResponse func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "success - returns renamed team",
+			requestBody: map[string]interface{}{
+				"old_name": "backend",
+				"new_name": "platform",
+			},
+			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
+				m.EXPECT().RenameTeam(mock.Anything, "backend", "platform").Return(&domain.Team{
+					TeamName: "platform",
+					Members:  []domain.TeamMember{{UserID: "u1", Username: "Alice", IsActive: true}},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.RenameTeamResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.NotNil(t, response.Team)
+				assert.Equal(t, "platform", response.Team.TeamName)
+				require.Len(t, response.Team.Members, 1)
+				assert.Equal(t, "u1", response.Team.Members[0].UserID)
+			},
+		},
+		{
+			name: "error - missing new_name",
+			requestBody: map[string]interface{}{
+				"old_name": "backend",
+			},
+			mockSetup:      func(m *handlermocks.MockTeamServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, handler.ErrorValidation, response.Error.Code)
+			},
+		},
+		{
+			name: "error - new_name equals old_name",
+			requestBody: map[string]interface{}{
+				"old_name": "backend",
+				"new_name": "backend",
+			},
+			mockSetup:      func(m *handlermocks.MockTeamServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, handler.ErrorValidation, response.Error.Code)
+				require.Len(t, response.Error.Details, 1)
+				assert.Equal(t, "new_name", response.Error.Details[0].Field)
+			},
+		},
+		{
+			name: "error - team not found",
+			requestBody: map[string]interface{}{
+				"old_name": "missing",
+				"new_name": "platform",
+			},
+			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
+				m.EXPECT().RenameTeam(mock.Anything, "missing", "platform").Return(nil, service.ErrTeamNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "NOT_FOUND", string(response.Error.Code))
+				assert.Equal(t, "team not found", response.Error.Message)
+			},
+		},
+		{
+			name: "error - new name taken",
+			requestBody: map[string]interface{}{
+				"old_name": "backend",
+				"new_name": "frontend",
+			},
+			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
+				m.EXPECT().RenameTeam(mock.Anything, "backend", "frontend").Return(nil, service.ErrTeamExists)
+			},
+			expectedStatus: http.StatusConflict,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, handler.ErrorTeamExists, response.Error.Code)
+			},
+		},
+		{
+			name: "error - internal server error",
+			requestBody: map[string]interface{}{
+				"old_name": "backend",
+				"new_name": "platform",
+			},
+			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
+				m.EXPECT().RenameTeam(mock.Anything, "backend", "platform").Return(nil, assert.AnError)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Contains(t, response.Error.Message, "assert.AnError")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockTeamServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			teamHandler := handler.NewTeamHandler(mockService)
+
+			body, err := json.Marshal(tt.requestBody)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/team/rename", bytes.NewBuffer(body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			teamHandler.RenameTeam(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			tt.validateResponse(t, w)
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package unit_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func TestTeamHandler_GetTeamSettings(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	serve := func(mockService *handlermocks.MockTeamServiceInterface, target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+		handler.NewTeamHandler(mockService).GetTeamSettings(c)
+		return w
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		mockService := handlermocks.NewMockTeamServiceInterface(t)
+		mockService.EXPECT().GetTeamSettings(mock.Anything, "backend").Return(&domain.TeamSettings{
+			AssignmentStrategy: "random",
+			ReviewerCount:      domain.DefaultReviewerCount,
+		}, nil)
+		w := serve(mockService, "/team/settings?team_name=backend")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"team_name":"backend","settings":{"assignment_strategy":"random","reviewer_count":2,
+			"require_approvals":0,"review_sla_hours":0,"slack_notifications":false}}`, w.Body.String())
+	})
+
+	t.Run("slack url is not returned", func(t *testing.T) {
+		mockService := handlermocks.NewMockTeamServiceInterface(t)
+		mockService.EXPECT().GetTeamSettings(mock.Anything, "backend").Return(&domain.TeamSettings{
+			AssignmentStrategy: "least_loaded",
+			ReviewerCount:      3,
+			RequireApprovals:   1,
+			ReviewSLAHours:     24,
+			SlackWebhookURL:    "https://hooks.slack.com/services/T0/B0/x",
+			FallbackTeamName:   "platform",
+		}, nil)
+		w := serve(mockService, "/team/settings?team_name=backend")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"team_name":"backend","settings":{"assignment_strategy":"least_loaded","reviewer_count":3,
+			"require_approvals":1,"review_sla_hours":24,"slack_notifications":true,"fallback_team_name":"platform"}}`, w.Body.String())
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		mockService := handlermocks.NewMockTeamServiceInterface(t)
+		mockService.EXPECT().GetTeamSettings(mock.Anything, "ghost").Return(nil, service.ErrTeamNotFound)
+		w := serve(mockService, "/team/settings?team_name=ghost")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("missing team name", func(t *testing.T) {
+		w := serve(handlermocks.NewMockTeamServiceInterface(t), "/team/settings")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestTeamHandler_UpdateTeamSettings(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name             string
+		body             string
+		mockSetup        func(*handlermocks.MockTeamServiceInterface)
+		expectedStatus   int
+		validateResponse func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "success - reviewer count and fallback team",
+			body: `{"team_name":"backend","reviewer_count":3,"fallback_team_name":"platform"}`,
+			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
+				m.EXPECT().UpdateTeam(mock.Anything, "backend", service.TeamUpdate{
+					ReviewerCount:    intPtr(3),
+					FallbackTeamName: stringPtr("platform"),
+				}).Return(&domain.Team{
+					TeamName:           "backend",
+					AssignmentStrategy: "random",
+					ReviewerCount:      3,
+					FallbackTeamName:   "platform",
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.JSONEq(t, `{"team_name":"backend","settings":{"assignment_strategy":"random","reviewer_count":3,
+					"require_approvals":0,"review_sla_hours":0,"slack_notifications":false,"fallback_team_name":"platform"}}`, w.Body.String())
+			},
+		},
+		{
+			name:           "error - every invalid field is reported",
+			body:           `{"team_name":"backend","assignment_strategy":"alphabetical","slack_webhook_url":"hooks.slack.com","fallback_team_name":"backend"}`,
+			mockSetup:      func(m *handlermocks.MockTeamServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				fields := make([]string, len(response.Error.Details))
+				for i, d := range response.Error.Details {
+					fields[i] = d.Field
+				}
+				assert.Equal(t, []string{"assignment_strategy", "slack_webhook_url", "fallback_team_name"}, fields)
+			},
+		},
+		{
+			name:           "error - reviewer count above the maximum",
+			body:           `{"team_name":"backend","reviewer_count":6}`,
+			mockSetup:      func(m *handlermocks.MockTeamServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Len(t, response.Error.Details, 1)
+				assert.Equal(t, "reviewer_count", response.Error.Details[0].Field)
+				assert.Equal(t, "max", response.Error.Details[0].Rule)
+			},
+		},
+		{
+			name:           "error - negative reviewer count",
+			body:           `{"team_name":"backend","reviewer_count":-1}`,
+			mockSetup:      func(m *handlermocks.MockTeamServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Len(t, response.Error.Details, 1)
+				assert.Equal(t, "reviewer_count", response.Error.Details[0].Field)
+			},
+		},
+		{
+			name: "error - fallback team not found",
+			body: `{"team_name":"backend","fallback_team_name":"ghost"}`,
+			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
+				m.EXPECT().UpdateTeam(mock.Anything, "backend", service.TeamUpdate{FallbackTeamName: stringPtr("ghost")}).
+					Return(nil, service.ErrFallbackTeamNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "fallback team not found", response.Error.Message)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockTeamServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/team/settings", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler.NewTeamHandler(mockService).UpdateTeamSettings(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			tt.validateResponse(t, w)
+		})
+	}
+}
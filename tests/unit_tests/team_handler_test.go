@@ -5,10 +5,12 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/mishasvintus/avito_backend_internship/internal/domain"
@@ -35,7 +37,7 @@ func TestTeamHandler_GetTeam(t *testing.T) {
 			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
 				isActive1 := true
 				isActive2 := false
-				m.EXPECT().GetTeam("team1").Return(&domain.Team{
+				m.EXPECT().GetTeam(mock.Anything, "team1").Return(&domain.Team{
 					TeamName: "team1",
 					Members: []domain.TeamMember{
 						{
@@ -71,7 +73,7 @@ func TestTeamHandler_GetTeam(t *testing.T) {
 				"team_name": "empty_team",
 			},
 			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
-				m.EXPECT().GetTeam("empty_team").Return(&domain.Team{
+				m.EXPECT().GetTeam(mock.Anything, "empty_team").Return(&domain.Team{
 					TeamName: "empty_team",
 					Members:  []domain.TeamMember{},
 				}, nil)
@@ -117,7 +119,7 @@ func TestTeamHandler_GetTeam(t *testing.T) {
 				"team_name": "nonexistent",
 			},
 			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
-				m.EXPECT().GetTeam("nonexistent").Return(nil, service.ErrTeamNotFound)
+				m.EXPECT().GetTeam(mock.Anything, "nonexistent").Return(nil, service.ErrTeamNotFound)
 			},
 			expectedStatus: http.StatusNotFound,
 			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -133,7 +135,7 @@ func TestTeamHandler_GetTeam(t *testing.T) {
 				"team_name": "team1",
 			},
 			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
-				m.EXPECT().GetTeam("team1").Return(nil, assert.AnError)
+				m.EXPECT().GetTeam(mock.Anything, "team1").Return(nil, assert.AnError)
 			},
 			expectedStatus: http.StatusInternalServerError,
 			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -201,22 +203,20 @@ func TestTeamHandler_AddTeam(t *testing.T) {
 				},
 			},
 			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
-				m.EXPECT().CreateTeam("team1", []domain.TeamMember{
+				m.EXPECT().CreateTeam(mock.Anything, &domain.Team{TeamName: "team1", Members: []domain.TeamMember{
 					{UserID: "user1", Username: "Alice", IsActive: true},
 					{UserID: "user2", Username: "Bob", IsActive: false},
-				}).Return(nil)
-
-				m.EXPECT().GetTeam("team1").Return(&domain.Team{
+				}}, service.CreateTeamOptions{ConflictPolicy: service.ConflictMove, IfExists: service.IfExistsFail}).Return(&domain.Team{
 					TeamName: "team1",
 					Members: []domain.TeamMember{
 						{UserID: "user1", Username: "Alice", IsActive: true},
 						{UserID: "user2", Username: "Bob", IsActive: false},
 					},
-				}, nil)
+				}, service.TeamCreated, nil)
 			},
 			expectedStatus: http.StatusCreated,
 			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
-				var response handler.SuccessResponse
+				var response handler.AddTeamResponse
 				err := json.Unmarshal(w.Body.Bytes(), &response)
 				require.NoError(t, err)
 				assert.NotNil(t, response.Team)
@@ -225,6 +225,7 @@ func TestTeamHandler_AddTeam(t *testing.T) {
 				assert.Equal(t, "user1", response.Team.Members[0].UserID)
 				assert.Equal(t, "Alice", response.Team.Members[0].Username)
 				assert.True(t, response.Team.Members[0].IsActive)
+				assert.Equal(t, "created", response.Result)
 			},
 		},
 		{
@@ -234,15 +235,14 @@ func TestTeamHandler_AddTeam(t *testing.T) {
 				"members":   []map[string]interface{}{},
 			},
 			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
-				m.EXPECT().CreateTeam("empty_team", []domain.TeamMember{}).Return(nil)
-				m.EXPECT().GetTeam("empty_team").Return(&domain.Team{
+				m.EXPECT().CreateTeam(mock.Anything, &domain.Team{TeamName: "empty_team", Members: []domain.TeamMember{}}, service.CreateTeamOptions{ConflictPolicy: service.ConflictMove, IfExists: service.IfExistsFail}).Return(&domain.Team{
 					TeamName: "empty_team",
 					Members:  []domain.TeamMember{},
-				}, nil)
+				}, service.TeamCreated, nil)
 			},
 			expectedStatus: http.StatusCreated,
 			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
-				var response handler.SuccessResponse
+				var response handler.AddTeamResponse
 				err := json.Unmarshal(w.Body.Bytes(), &response)
 				require.NoError(t, err)
 				assert.NotNil(t, response.Team)
@@ -274,11 +274,11 @@ func TestTeamHandler_AddTeam(t *testing.T) {
 				},
 			},
 			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
-				m.EXPECT().CreateTeam("existing_team", []domain.TeamMember{
+				m.EXPECT().CreateTeam(mock.Anything, &domain.Team{TeamName: "existing_team", Members: []domain.TeamMember{
 					{UserID: "user1", Username: "Alice", IsActive: true},
-				}).Return(service.ErrTeamExists)
+				}}, service.CreateTeamOptions{ConflictPolicy: service.ConflictMove, IfExists: service.IfExistsFail}).Return(nil, "", service.ErrTeamExists)
 			},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusConflict,
 			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
 				var response handler.ErrorResponse
 				err := json.Unmarshal(w.Body.Bytes(), &response)
@@ -296,9 +296,9 @@ func TestTeamHandler_AddTeam(t *testing.T) {
 				},
 			},
 			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
-				m.EXPECT().CreateTeam("team1", []domain.TeamMember{
+				m.EXPECT().CreateTeam(mock.Anything, &domain.Team{TeamName: "team1", Members: []domain.TeamMember{
 					{UserID: "user1", Username: "Alice", IsActive: true},
-				}).Return(assert.AnError)
+				}}, service.CreateTeamOptions{ConflictPolicy: service.ConflictMove, IfExists: service.IfExistsFail}).Return(nil, "", assert.AnError)
 			},
 			expectedStatus: http.StatusInternalServerError,
 			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -309,7 +309,31 @@ func TestTeamHandler_AddTeam(t *testing.T) {
 			},
 		},
 		{
-			name: "error - failed to retrieve created team",
+			name: "error - member in other team with reject policy",
+			requestBody: map[string]interface{}{
+				"team_name":       "team1",
+				"conflict_policy": "reject",
+				"members": []map[string]interface{}{
+					{"user_id": "user1", "username": "Alice", "is_active": true},
+				},
+			},
+			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
+				m.EXPECT().CreateTeam(mock.Anything, &domain.Team{TeamName: "team1", Members: []domain.TeamMember{
+					{UserID: "user1", Username: "Alice", IsActive: true},
+				}}, service.CreateTeamOptions{ConflictPolicy: service.ConflictReject, IfExists: service.IfExistsFail}).
+					Return(nil, "", &service.UserInOtherTeamError{UserID: "user1", TeamName: "team0"})
+			},
+			expectedStatus: http.StatusConflict,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, handler.ErrorUserInOtherTeam, response.Error.Code)
+				assert.Equal(t, "user user1 already belongs to team team0", response.Error.Message)
+			},
+		},
+		{
+			name: "error - member was erased",
 			requestBody: map[string]interface{}{
 				"team_name": "team1",
 				"members": []map[string]interface{}{
@@ -317,17 +341,126 @@ func TestTeamHandler_AddTeam(t *testing.T) {
 				},
 			},
 			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
-				m.EXPECT().CreateTeam("team1", []domain.TeamMember{
+				m.EXPECT().CreateTeam(mock.Anything, &domain.Team{TeamName: "team1", Members: []domain.TeamMember{
 					{UserID: "user1", Username: "Alice", IsActive: true},
-				}).Return(nil)
-				m.EXPECT().GetTeam("team1").Return(nil, assert.AnError)
+				}}, service.CreateTeamOptions{ConflictPolicy: service.ConflictMove, IfExists: service.IfExistsFail}).
+					Return(nil, "", &service.UserErasedError{UserID: "user1"})
 			},
-			expectedStatus: http.StatusInternalServerError,
+			expectedStatus: http.StatusConflict,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, handler.ErrorUserErased, response.Error.Code)
+				assert.Equal(t, "user user1 is erased", response.Error.Message)
+			},
+		},
+		{
+			name: "success - existing team ignored",
+			requestBody: map[string]interface{}{
+				"team_name": "team1",
+				"if_exists": "ignore",
+				"members":   []map[string]interface{}{},
+			},
+			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
+				m.EXPECT().CreateTeam(mock.Anything, &domain.Team{TeamName: "team1", Members: []domain.TeamMember{}},
+					service.CreateTeamOptions{ConflictPolicy: service.ConflictMove, IfExists: service.IfExistsIgnore}).
+					Return(&domain.Team{TeamName: "team1", Members: []domain.TeamMember{
+						{UserID: "user1", Username: "Alice", IsActive: true},
+					}}, service.TeamUnchanged, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.AddTeamResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, "unchanged", response.Result)
+				require.NotNil(t, response.Team)
+				assert.Len(t, response.Team.Members, 1)
+			},
+		},
+		{
+			name: "success - existing team updated",
+			requestBody: map[string]interface{}{
+				"team_name": "team1",
+				"if_exists": "update",
+				"members": []map[string]interface{}{
+					{"user_id": "user1", "username": "Alice", "is_active": false},
+				},
+			},
+			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
+				m.EXPECT().CreateTeam(mock.Anything, &domain.Team{TeamName: "team1", Members: []domain.TeamMember{
+					{UserID: "user1", Username: "Alice", IsActive: false},
+				}}, service.CreateTeamOptions{ConflictPolicy: service.ConflictMove, IfExists: service.IfExistsUpdate}).
+					Return(&domain.Team{TeamName: "team1", Members: []domain.TeamMember{
+						{UserID: "user1", Username: "Alice", IsActive: false},
+					}}, service.TeamUpdated, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.AddTeamResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, "updated", response.Result)
+			},
+		},
+		{
+			name: "error - unknown if_exists mode",
+			requestBody: map[string]interface{}{
+				"team_name": "team1",
+				"if_exists": "replace",
+				"members":   []map[string]interface{}{},
+			},
+			mockSetup:      func(m *handlermocks.MockTeamServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, []handler.FieldError{
+					{Field: "if_exists", Rule: "oneof", Message: "must be one of fail, ignore, update"},
+				}, response.Error.Details)
+			},
+		},
+		{
+			name: "error - unknown conflict policy",
+			requestBody: map[string]interface{}{
+				"team_name":       "team1",
+				"conflict_policy": "merge",
+				"members":         []map[string]interface{}{},
+			},
+			mockSetup:      func(m *handlermocks.MockTeamServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, handler.ErrorValidation, response.Error.Code)
+				assert.Equal(t, []handler.FieldError{
+					{Field: "conflict_policy", Rule: "oneof", Message: "must be one of move, reject"},
+				}, response.Error.Details)
+			},
+		},
+		{
+			name: "error - unknown assignment strategy",
+			requestBody: map[string]interface{}{
+				"team_name":           "team1",
+				"assignment_strategy": "alphabetical",
+				"members": []map[string]interface{}{
+					{"user_id": "user1", "username": "Alice", "is_active": true},
+				},
+			},
+			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
+				m.EXPECT().CreateTeam(mock.Anything, &domain.Team{TeamName: "team1", AssignmentStrategy: "alphabetical", Members: []domain.TeamMember{
+					{UserID: "user1", Username: "Alice", IsActive: true},
+				}}, service.CreateTeamOptions{ConflictPolicy: service.ConflictMove, IfExists: service.IfExistsFail}).Return(nil, "", service.ErrUnknownStrategy)
+			},
+			expectedStatus: http.StatusBadRequest,
 			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
 				var response handler.ErrorResponse
 				err := json.Unmarshal(w.Body.Bytes(), &response)
 				require.NoError(t, err)
-				assert.Equal(t, "failed to retrieve created team", response.Error.Message)
+				assert.Equal(t, "unknown assignment_strategy", response.Error.Message)
 			},
 		},
 	}
@@ -357,3 +490,73 @@ func TestTeamHandler_AddTeam(t *testing.T) {
 		})
 	}
 }
+
+func TestTeamHandler_AddTeam_IfExistsQuery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		query          string
+		body           string
+		expectedMode   service.IfExists
+		expectedStatus int
+	}{
+		{name: "query parameter", query: "?if_exists=update", body: `{"team_name":"team1","members":[]}`, expectedMode: service.IfExistsUpdate, expectedStatus: http.StatusOK},
+		{name: "body field wins", query: "?if_exists=update", body: `{"team_name":"team1","members":[],"if_exists":"ignore"}`, expectedMode: service.IfExistsIgnore, expectedStatus: http.StatusOK},
+		{name: "unknown query value", query: "?if_exists=replace", body: `{"team_name":"team1","members":[]}`, expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockTeamServiceInterface(t)
+			if tt.expectedMode != "" {
+				mockService.EXPECT().CreateTeam(mock.Anything, &domain.Team{TeamName: "team1", Members: []domain.TeamMember{}},
+					service.CreateTeamOptions{ConflictPolicy: service.ConflictMove, IfExists: tt.expectedMode}).
+					Return(&domain.Team{TeamName: "team1"}, service.TeamUnchanged, nil)
+			}
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/team/add"+tt.query, strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler.NewTeamHandler(mockService).AddTeam(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+		})
+	}
+}
+
+func TestTeamHandler_AddTeam_LegacyTeamExistsStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		legacy         bool
+		expectedStatus int
+	}{
+		{name: "conflict by default", legacy: false, expectedStatus: http.StatusConflict},
+		{name: "bad request for old clients", legacy: true, expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockTeamServiceInterface(t)
+			mockService.EXPECT().CreateTeam(mock.Anything, &domain.Team{TeamName: "team1", Members: []domain.TeamMember{}},
+				service.CreateTeamOptions{ConflictPolicy: service.ConflictMove, IfExists: service.IfExistsFail}).
+				Return(nil, "", service.ErrTeamExists)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/team/add", strings.NewReader(`{"team_name":"team1","members":[]}`))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler.NewTeamHandler(mockService).WithLegacyTeamExistsStatus(tt.legacy).AddTeam(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var response handler.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, handler.ErrorTeamExists, response.Error.Code)
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package unit_tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func TestTeamHandler_UpdateTeam(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name             string
+		requestBody      interface{}
+		mockSetup        func(*handlermocks.MockTeamServiceInterface)
+		expectedStatus   int
+		validateResponse func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "success - strategy updated",
+			requestBody: map[string]interface{}{
+				"team_name":           "team1",
+				"assignment_strategy": "round_robin",
+			},
+			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
+				m.EXPECT().UpdateTeam(mock.Anything, "team1", service.TeamUpdate{AssignmentStrategy: "round_robin"}).Return(&domain.Team{
+					TeamName:           "team1",
+					AssignmentStrategy: "round_robin",
+					Members: []domain.TeamMember{
+						{UserID: "user1", Username: "Alice", IsActive: true},
+					},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.UpdateTeamResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.NotNil(t, response.Team)
+				assert.Equal(t, "team1", response.Team.TeamName)
+				assert.Equal(t, "round_robin", response.Team.AssignmentStrategy)
+				assert.Len(t, response.Team.Members, 1)
+			},
+		},
+		{
+			name: "success - approval requirement updated",
+			requestBody: map[string]interface{}{
+				"team_name":         "team1",
+				"require_approvals": 2,
+			},
+			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
+				m.EXPECT().UpdateTeam(mock.Anything, "team1", service.TeamUpdate{RequireApprovals: intPtr(2)}).Return(&domain.Team{
+					TeamName:           "team1",
+					AssignmentStrategy: "random",
+					RequireApprovals:   2,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.UpdateTeamResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.NotNil(t, response.Team)
+				assert.Equal(t, 2, response.Team.RequireApprovals)
+				assert.Equal(t, "random", response.Team.AssignmentStrategy)
+			},
+		},
+		{
+			name: "success - review SLA updated",
+			requestBody: map[string]interface{}{
+				"team_name":        "team1",
+				"review_sla_hours": 24,
+			},
+			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
+				m.EXPECT().UpdateTeam(mock.Anything, "team1", service.TeamUpdate{ReviewSLAHours: intPtr(24)}).Return(&domain.Team{
+					TeamName:           "team1",
+					AssignmentStrategy: "random",
+					ReviewSLAHours:     24,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.UpdateTeamResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.NotNil(t, response.Team)
+				assert.Equal(t, 24, response.Team.ReviewSLAHours)
+			},
+		},
+		{
+			name: "success - slack notifications enabled",
+			requestBody: map[string]interface{}{
+				"team_name":         "team1",
+				"slack_webhook_url": "https://hooks.slack.com/services/T0/B0/x",
+			},
+			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
+				m.EXPECT().UpdateTeam(mock.Anything, "team1", service.TeamUpdate{SlackWebhookURL: stringPtr("https://hooks.slack.com/services/T0/B0/x")}).Return(&domain.Team{
+					TeamName:           "team1",
+					AssignmentStrategy: "random",
+					SlackWebhookURL:    "https://hooks.slack.com/services/T0/B0/x",
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.UpdateTeamResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.NotNil(t, response.Team)
+				assert.True(t, response.Team.SlackNotifications)
+				assert.NotContains(t, w.Body.String(), "hooks.slack.com", "the webhook url is not returned")
+			},
+		},
+		{
+			name: "success - slack notifications disabled",
+			requestBody: map[string]interface{}{
+				"team_name":         "team1",
+				"slack_webhook_url": "",
+			},
+			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
+				m.EXPECT().UpdateTeam(mock.Anything, "team1", service.TeamUpdate{SlackWebhookURL: stringPtr("")}).Return(&domain.Team{
+					TeamName:           "team1",
+					AssignmentStrategy: "random",
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.UpdateTeamResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.NotNil(t, response.Team)
+				assert.False(t, response.Team.SlackNotifications)
+			},
+		},
+		{
+			name: "error - slack webhook is not an http url",
+			requestBody: map[string]interface{}{
+				"team_name":         "team1",
+				"slack_webhook_url": "hooks.slack.com/services/T0",
+			},
+			mockSetup:      func(m *handlermocks.MockTeamServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, []handler.FieldError{
+					{Field: "slack_webhook_url", Rule: "http_url", Message: "must be an absolute http or https URL"},
+				}, response.Error.Details)
+			},
+		},
+		{
+			name: "error - negative approval requirement",
+			requestBody: map[string]interface{}{
+				"team_name":         "team1",
+				"require_approvals": -1,
+			},
+			mockSetup:      func(m *handlermocks.MockTeamServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, []handler.FieldError{
+					{Field: "require_approvals", Rule: "min", Message: "must be at least 0"},
+				}, response.Error.Details)
+			},
+		},
+		{
+			name: "error - negative review SLA",
+			requestBody: map[string]interface{}{
+				"team_name":        "team1",
+				"review_sla_hours": -1,
+			},
+			mockSetup:      func(m *handlermocks.MockTeamServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, []handler.FieldError{
+					{Field: "review_sla_hours", Rule: "min", Message: "must be at least 0"},
+				}, response.Error.Details)
+			},
+		},
+		{
+			name: "error - invalid request body (missing assignment_strategy)",
+			requestBody: map[string]interface{}{
+				"team_name": "team1",
+			},
+			mockSetup:      func(m *handlermocks.MockTeamServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, "invalid request body", response.Error.Message)
+			},
+		},
+		{
+			name: "error - unknown assignment strategy",
+			requestBody: map[string]interface{}{
+				"team_name":           "team1",
+				"assignment_strategy": "alphabetical",
+			},
+			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
+				m.EXPECT().UpdateTeam(mock.Anything, "team1", service.TeamUpdate{AssignmentStrategy: "alphabetical"}).Return(nil, service.ErrUnknownStrategy)
+			},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, "unknown assignment_strategy", response.Error.Message)
+			},
+		},
+		{
+			name: "error - team not found",
+			requestBody: map[string]interface{}{
+				"team_name":           "nonexistent_team",
+				"assignment_strategy": "random",
+			},
+			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
+				m.EXPECT().UpdateTeam(mock.Anything, "nonexistent_team", service.TeamUpdate{AssignmentStrategy: "random"}).Return(nil, service.ErrTeamNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, "NOT_FOUND", string(response.Error.Code))
+			},
+		},
+		{
+			name: "error - internal server error",
+			requestBody: map[string]interface{}{
+				"team_name":           "team1",
+				"assignment_strategy": "random",
+			},
+			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
+				m.EXPECT().UpdateTeam(mock.Anything, "team1", service.TeamUpdate{AssignmentStrategy: "random"}).Return(nil, assert.AnError)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Contains(t, response.Error.Message, "assert.AnError")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockTeamServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			handler := handler.NewTeamHandler(mockService)
+
+			body, err := json.Marshal(tt.requestBody)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/team/update", bytes.NewBuffer(body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			handler.UpdateTeam(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			tt.validateResponse(t, w)
+		})
+	}
+}
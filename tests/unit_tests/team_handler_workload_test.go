@@ -0,0 +1,75 @@
+package unit_tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func TestTeamHandler_GetTeamWorkload(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	serve := func(mockService *handlermocks.MockTeamServiceInterface, target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+		handler.NewTeamHandler(mockService).GetTeamWorkload(c)
+		return w
+	}
+
+	t.Run("members and totals", func(t *testing.T) {
+		mockService := handlermocks.NewMockTeamServiceInterface(t)
+		mockService.EXPECT().GetTeamWorkload(mock.Anything, "backend").Return(&domain.TeamWorkload{
+			TeamName: "backend",
+			Members: []domain.MemberWorkload{
+				{
+					UserID:   "u2",
+					Username: "Bob",
+					PullRequests: []domain.PullRequestShort{{
+						PullRequestID: "pr-1", PullRequestName: "Add search", AuthorID: "u1", TeamName: "backend", Status: domain.StatusOpen,
+						Assignment: &domain.ReviewerAssignment{
+							UserID: "u2", AssignedAt: time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC), HoursOpen: intPtr(26), Overdue: true,
+						},
+					}},
+				},
+				{UserID: "u3", Username: "Carol", PullRequests: []domain.PullRequestShort{}},
+			},
+			OpenPRs:          3,
+			UnassignedPRs:    1,
+			UnderAssignedPRs: 1,
+			ReviewerCount:    2,
+		}, nil)
+
+		w := serve(mockService, "/team/workload?team_name=backend")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"team_name":"backend","members":[
+			{"user_id":"u2","username":"Bob","open_assignments":1,"pull_requests":[
+				{"repository_name":"","pull_request_id":"pr-1","pull_request_name":"Add search","author_id":"u1","team_name":"backend",
+				 "status":"OPEN","assigned_at":"2026-03-02T09:30:00Z","hours_open":26,"overdue":true}]},
+			{"user_id":"u3","username":"Carol","open_assignments":0,"pull_requests":[]}],
+			"totals":{"active_members":2,"open_assignments":1,"open_prs":3,"unassigned_prs":1,"under_assigned_prs":1,"reviewer_count":2}}`,
+			w.Body.String())
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		mockService := handlermocks.NewMockTeamServiceInterface(t)
+		mockService.EXPECT().GetTeamWorkload(mock.Anything, "ghost").Return(nil, service.ErrTeamNotFound)
+		w := serve(mockService, "/team/workload?team_name=ghost")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("missing team name", func(t *testing.T) {
+		w := serve(handlermocks.NewMockTeamServiceInterface(t), "/team/workload")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,198 @@
+package unit_tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func TestParseTeamCSV(t *testing.T) {
+	t.Run("valid rows in any column order", func(t *testing.T) {
+		input := "user_id,team_name,username,is_active\n" +
+			"u1,backend,Alice,true\n" +
+			"u2,backend,\"Smith, Bob\",false\n"
+		rows, rowErrors, err := service.ParseTeamCSV(strings.NewReader(input))
+		require.NoError(t, err)
+		assert.Empty(t, rowErrors)
+		require.Len(t, rows, 2)
+		assert.Equal(t, service.ImportRow{Line: 2, TeamName: "backend", UserID: "u1", Username: "Alice", IsActive: true}, rows[0])
+		assert.Equal(t, "Smith, Bob", rows[1].Username)
+		assert.False(t, rows[1].IsActive)
+	})
+
+	t.Run("duplicate user ids are reported with line numbers", func(t *testing.T) {
+		input := "team_name,user_id,username,is_active\n" +
+			"backend,u1,Alice,true\n" +
+			"frontend,u2,Bob,true\n" +
+			"frontend,u1,Alice,true\n"
+		rows, rowErrors, err := service.ParseTeamCSV(strings.NewReader(input))
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		require.Len(t, rowErrors, 1)
+		assert.Equal(t, 4, rowErrors[0].Line)
+		assert.Contains(t, rowErrors[0].Message, "duplicate user_id u1")
+		assert.Contains(t, rowErrors[0].Message, "line 2")
+	})
+
+	t.Run("invalid rows are skipped", func(t *testing.T) {
+		input := "team_name,user_id,username,is_active\n" +
+			"backend,u1,Alice,maybe\n" +
+			"backend,,Bob,true\n" +
+			"backend,u3\n" +
+			"backend,u4,Dan,1\n"
+		rows, rowErrors, err := service.ParseTeamCSV(strings.NewReader(input))
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Equal(t, "u4", rows[0].UserID)
+		require.Len(t, rowErrors, 3)
+		assert.Equal(t, []int{2, 3, 4}, []int{rowErrors[0].Line, rowErrors[1].Line, rowErrors[2].Line})
+	})
+
+	t.Run("malformed header", func(t *testing.T) {
+		for _, header := range []string{
+			"",
+			"team_name,user_id,username\n",
+			"team_name,user_id,username,active\n",
+			"team_name,user_id,user_id,is_active\n",
+		} {
+			_, _, err := service.ParseTeamCSV(strings.NewReader(header))
+			assert.ErrorIs(t, err, service.ErrInvalidCSV, header)
+		}
+	})
+}
+
+func newImportRequest(t *testing.T, content []byte) *http.Request {
+	t.Helper()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if content != nil {
+		part, err := writer.CreateFormFile("file", "teams.csv")
+		require.NoError(t, err)
+		_, err = part.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+
+	req, err := http.NewRequest(http.MethodPost, "/team/import", &body)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestTeamHandler_ImportTeams(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name             string
+		content          []byte
+		mockSetup        func(*handlermocks.MockTeamServiceInterface)
+		expectedStatus   int
+		validateResponse func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "success - returns summary",
+			content: []byte("team_name,user_id,username,is_active\nbackend,u1,Alice,true\n"),
+			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
+				m.EXPECT().ImportTeams(mock.Anything, mock.Anything).Return(&service.ImportSummary{
+					TeamsCreated: 1,
+					UsersCreated: 1,
+					Errors:       []service.ImportRowError{{Line: 3, Message: "duplicate user_id u1 (first seen on line 2)"}},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ImportTeamsResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, 1, response.TeamsCreated)
+				assert.Equal(t, 1, response.UsersCreated)
+				require.Len(t, response.Errors, 1)
+				assert.Equal(t, 3, response.Errors[0].Line)
+			},
+		},
+		{
+			name:           "error - missing file",
+			content:        nil,
+			mockSetup:      func(m *handlermocks.MockTeamServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, "file is required", response.Error.Message)
+			},
+		},
+		{
+			name:           "error - file too large",
+			content:        bytes.Repeat([]byte("a"), 2<<20),
+			mockSetup:      func(m *handlermocks.MockTeamServiceInterface) {},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, handler.ErrorTooLarge, response.Error.Code)
+			},
+		},
+		{
+			name:    "error - malformed header",
+			content: []byte("team,user\n"),
+			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
+				m.EXPECT().ImportTeams(mock.Anything, mock.Anything).Return(nil, service.ErrInvalidCSV)
+			},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Contains(t, response.Error.Message, "invalid CSV")
+			},
+		},
+		{
+			name:    "error - internal server error",
+			content: []byte("team_name,user_id,username,is_active\n"),
+			mockSetup: func(m *handlermocks.MockTeamServiceInterface) {
+				m.EXPECT().ImportTeams(mock.Anything, mock.Anything).Return(nil, assert.AnError)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Contains(t, response.Error.Message, "assert.AnError")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockTeamServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			handler := handler.NewTeamHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = newImportRequest(t, tt.content)
+
+			handler.ImportTeams(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			tt.validateResponse(t, w)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package unit_tests
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/middleware"
+)
+
+func newTimeoutEngine(timeout time.Duration, h gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(
+		middleware.RequestID(),
+		middleware.Recovery(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		middleware.Timeout(timeout),
+	)
+	r.GET("/test/slow", h)
+	return r
+}
+
+func TestTimeout_SlowHandler(t *testing.T) {
+	lateWrite := make(chan *gin.Error, 1)
+	r := newTimeoutEngine(20*time.Millisecond, func(c *gin.Context) {
+		<-c.Request.Context().Done()
+		// Finish well after the deadline, as a handler ignoring cancellation would.
+		time.Sleep(20 * time.Millisecond)
+		c.JSON(http.StatusOK, gin.H{"late": true})
+		lateWrite <- c.Errors.Last()
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test/slow", nil))
+
+	assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+	assert.NotEmpty(t, w.Header().Get(middleware.RequestIDHeader))
+
+	var response handler.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, handler.ErrorTimeout, response.Error.Code)
+	assert.NotContains(t, w.Body.String(), "late")
+
+	err := <-lateWrite
+	require.NotNil(t, err)
+	assert.ErrorIs(t, err.Err, http.ErrHandlerTimeout)
+}
+
+func TestTimeout_FastHandler(t *testing.T) {
+	r := newTimeoutEngine(time.Second, func(c *gin.Context) {
+		_, hasDeadline := c.Request.Context().Deadline()
+		c.Header("X-Deadline", map[bool]string{true: "set", false: "unset"}[hasDeadline])
+		c.JSON(http.StatusCreated, gin.H{"ok": true})
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test/slow", nil))
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "set", w.Header().Get("X-Deadline"))
+	assert.NotEmpty(t, w.Header().Get(middleware.RequestIDHeader))
+	assert.JSONEq(t, `{"ok":true}`, w.Body.String())
+}
+
+func TestTimeout_Panic(t *testing.T) {
+	r := newTimeoutEngine(time.Second, func(c *gin.Context) {
+		panic("boom")
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test/slow", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	var response handler.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, handler.ErrorInternal, response.Error.Code)
+}
+
+func TestTimeout_Disabled(t *testing.T) {
+	r := newTimeoutEngine(0, func(c *gin.Context) {
+		_, hasDeadline := c.Request.Context().Deadline()
+		assert.False(t, hasDeadline)
+		c.Status(http.StatusNoContent)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test/slow", nil))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
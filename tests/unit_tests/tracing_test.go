@@ -0,0 +1,181 @@
+package unit_tests
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+	"github.com/mishasvintus/avito_backend_internship/internal/router"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+)
+
+func TestTracing_CreatePRSpanTree(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	mockDB, mock, err := sqlmock.NewWithDSN("tracing")
+	require.NoError(t, err)
+	defer func() { _ = mockDB.Close() }()
+	db, err := repository.OpenTraced("sqlmock", "tracing")
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	userColumns := []string{"user_id", "username", "team_name", "is_active", "max_open_reviews", "assignment_weight"}
+	prColumns := []string{"repository_name", "pull_request_id", "pull_request_name", "author_id", "team_name", "status",
+		"created_at", "merged_at", "merged_by", "closed_at", "description", "external_url", "size", "lines_changed", "reassignment_count", "tags", "review_sla_hours"}
+	expectGet := func() {
+		mock.ExpectQuery("FROM pull_requests").WillReturnRows(sqlmock.NewRows(prColumns).
+			AddRow("", "pr-1", "Add search", "u1", "backend", "OPEN", time.Now(), nil, nil, nil, "", "", nil, nil, 0, "{}", 0))
+		mock.ExpectQuery("FROM pr_reviewers").WillReturnRows(sqlmock.NewRows([]string{"user_id", "source", "assigned_at", "approved"}).AddRow("u2", "auto", time.Now(), false))
+	}
+
+	mock.ExpectQuery("FROM users").WillReturnRows(sqlmock.NewRows(append(userColumns, "erased")).AddRow("u1", "Alice", "backend", true, nil, 1, false))
+	mock.ExpectQuery("FROM teams").WillReturnRows(sqlmock.NewRows([]string{"assignment_strategy", "reviewer_count", "require_approvals",
+		"review_sla_hours", "slack_webhook_url", "fallback_team_name"}).AddRow("random", nil, 0, 0, nil, nil))
+	mock.ExpectQuery("FROM users author").WillReturnRows(sqlmock.NewRows(append(userColumns, "open_reviews", "open_review_load", "last_assigned_at", "tags")).
+		AddRow("u2", "Bob", "backend", true, nil, 1, 0, 0, nil, "{}"))
+	mock.ExpectQuery("FROM teams").WillReturnRows(sqlmock.NewRows([]string{"assignment_strategy"}).AddRow("random"))
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO pull_requests").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO pr_reviewers").WillReturnResult(sqlmock.NewResult(0, 1))
+	expectGet()
+	mock.ExpectExec("INSERT INTO event_outbox").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO event_outbox").WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectCommit()
+	expectGet()
+
+	prHandler := handler.NewPRHandler(service.NewPRService(db, service.NewReviewerAssigner()))
+	r, err := router.SetupRoutes(router.Options{
+		Mode:           gin.TestMode,
+		TracerProvider: provider,
+	}, nil, nil, prHandler, nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	body := `{"pull_request_id":"pr-1","pull_request_name":"Add search","author_id":"u1"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pullRequest/create", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	spans := recorder.Ended()
+	for _, s := range spans {
+		assert.Equal(t, traceID, s.SpanContext().TraceID().String(), "span %s continues the incoming trace", s.Name())
+	}
+	children := func(parent sdktrace.ReadOnlySpan) []string {
+		var names []string
+		for _, s := range spans {
+			if s.Parent().SpanID() == parent.SpanContext().SpanID() {
+				names = append(names, s.Name())
+			}
+		}
+		return names
+	}
+
+	var root, createPR sdktrace.ReadOnlySpan
+	for _, s := range spans {
+		switch s.Name() {
+		case "POST /api/v1/pullRequest/create":
+			root = s
+		case "PRService.CreatePR":
+			createPR = s
+		}
+	}
+	require.NotNil(t, root)
+	require.NotNil(t, createPR)
+
+	assert.Equal(t, trace.SpanKindServer, root.SpanKind())
+	assert.True(t, root.Parent().IsRemote(), "the request span is a child of the traceparent header")
+	assert.Equal(t, "00f067aa0ba902b7", root.Parent().SpanID().String())
+	assert.Equal(t, []string{"PRService.CreatePR"}, children(root))
+	assert.Equal(t, []string{
+		"user.Get", "user.Get rows",
+		"team.GetSettings", "team.GetSettings rows",
+		"user.GetActiveTeammates", "user.GetActiveTeammates rows",
+		"team.GetStrategy", "team.GetStrategy rows",
+		"pr.Create",
+		"pr.InsertActiveReviewers",
+		"pr.Get", "pr.Get rows",
+		"pr.Get", "pr.Get rows",
+		"outbox.Insert",
+		"outbox.Insert",
+		"pr.Get", "pr.Get rows",
+		"pr.Get", "pr.Get rows",
+	}, children(createPR))
+	for _, s := range spans {
+		if s.Parent().SpanID() == createPR.SpanContext().SpanID() {
+			assert.Equal(t, trace.SpanKindClient, s.SpanKind())
+			assert.Empty(t, children(s))
+		}
+	}
+}
+
+func TestTracing_StatementSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	mockDB, mock, err := sqlmock.NewWithDSN("statement_spans")
+	require.NoError(t, err)
+	defer func() { _ = mockDB.Close() }()
+	db, err := repository.OpenTraced("sqlmock", "statement_spans")
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx, parent := provider.Tracer("test").Start(t.Context(), "parent")
+	defer parent.End()
+	names := func() []string {
+		var names []string
+		for _, s := range recorder.Ended() {
+			names = append(names, s.Name())
+		}
+		return names
+	}
+
+	t.Run("rows span ends when the rows are closed", func(t *testing.T) {
+		mock.ExpectQuery("SELECT user_id").WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow("u1").AddRow("u2"))
+
+		rows, err := repository.Named(repository.WithContext(ctx, db), "user.List").Query("SELECT user_id FROM users")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"user.List"}, names())
+
+		var id string
+		require.True(t, rows.Next())
+		require.NoError(t, rows.Scan(&id))
+		assert.Equal(t, []string{"user.List"}, names())
+		require.NoError(t, rows.Close())
+		assert.Equal(t, []string{"user.List", "user.List rows"}, names())
+	})
+
+	t.Run("unnamed statements are not traced", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM users").WillReturnResult(sqlmock.NewResult(0, 1))
+
+		_, err := repository.WithContext(ctx, db).Exec("DELETE FROM users")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"user.List", "user.List rows"}, names())
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,104 @@
+package unit_tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/repository"
+)
+
+func TestWithTx(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	tests := []struct {
+		name        string
+		setup       func(sqlmock.Sqlmock)
+		fn          func(tx repository.DBTX) error
+		expectedErr func(*testing.T, error)
+	}{
+		{
+			name: "commits once on success",
+			setup: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE users").WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+			fn: func(tx repository.DBTX) error {
+				_, err := tx.Exec("UPDATE users SET is_active = false")
+				return err
+			},
+			expectedErr: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name: "rolls back on returned error",
+			setup: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectRollback()
+			},
+			fn: func(tx repository.DBTX) error {
+				return errFailed
+			},
+			expectedErr: func(t *testing.T, err error) {
+				assert.ErrorIs(t, err, errFailed)
+			},
+		},
+		{
+			name: "rolls back on panic",
+			setup: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectRollback()
+			},
+			fn: func(tx repository.DBTX) error {
+				panic("boom")
+			},
+			expectedErr: func(t *testing.T, err error) {
+				assert.ErrorContains(t, err, "boom")
+			},
+		},
+		{
+			name: "begin failure",
+			setup: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin().WillReturnError(errFailed)
+			},
+			fn: func(tx repository.DBTX) error {
+				t.Fatal("fn must not run without a transaction")
+				return nil
+			},
+			expectedErr: func(t *testing.T, err error) {
+				assert.ErrorIs(t, err, errFailed)
+			},
+		},
+		{
+			name: "commit failure",
+			setup: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectCommit().WillReturnError(errFailed)
+			},
+			fn: func(tx repository.DBTX) error {
+				return nil
+			},
+			expectedErr: func(t *testing.T, err error) {
+				assert.ErrorIs(t, err, errFailed)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer func() { _ = db.Close() }()
+
+			tt.setup(mock)
+
+			tt.expectedErr(t, repository.WithTx(t.Context(), db, tt.fn))
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package unit_tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func TestUserHandler_SetAbsence(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	from := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 7, 7, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		requestBody      interface{}
+		mockSetup        func(*handlermocks.MockUserServiceInterface)
+		expectedStatus   int
+		validateResponse func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "success - absence with reassignment",
+			requestBody: map[string]interface{}{
+				"user_id":       "user1",
+				"from_date":     "2025-07-01",
+				"to_date":       "2025-07-07",
+				"reassign_open": true,
+			},
+			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
+				m.EXPECT().SetAbsence(mock.Anything, domain.Absence{UserID: "user1", FromDate: from, ToDate: to}, true).
+					Return([]service.ReassignResult{
+						{PR: domain.PRKey{PullRequestID: "pr1"}, ReplacedBy: "user2"},
+						{PR: domain.PRKey{PullRequestID: "pr2"}},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.SetAbsenceResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, "2025-07-01", response.Absence.FromDate)
+				assert.Equal(t, "2025-07-07", response.Absence.ToDate)
+				require.Len(t, response.Reassigned, 2)
+				assert.Equal(t, "user2", response.Reassigned[0].ReplacedBy)
+				assert.Empty(t, response.Reassigned[1].ReplacedBy)
+				assert.Equal(t, handler.ErrorNoCandidate, response.Reassigned[1].Error)
+			},
+		},
+		{
+			name: "success - single day absence",
+			requestBody: map[string]interface{}{
+				"user_id":   "user1",
+				"from_date": "2025-07-01",
+				"to_date":   "2025-07-01",
+			},
+			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
+				m.EXPECT().SetAbsence(mock.Anything, domain.Absence{UserID: "user1", FromDate: from, ToDate: from}, false).
+					Return([]service.ReassignResult{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.SetAbsenceResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Empty(t, response.Reassigned)
+			},
+		},
+		{
+			name: "success - reassignment stopped midway",
+			requestBody: map[string]interface{}{
+				"user_id":       "user1",
+				"from_date":     "2025-07-01",
+				"to_date":       "2025-07-07",
+				"reassign_open": true,
+			},
+			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
+				m.EXPECT().SetAbsence(mock.Anything, domain.Absence{UserID: "user1", FromDate: from, ToDate: to}, true).
+					Return(nil, &service.ReassignIncompleteError{
+						Reassigned: []service.ReassignResult{{PR: domain.PRKey{PullRequestID: "pr1"}, ReplacedBy: "user2"}},
+						Err:        errors.New("connection reset"),
+					})
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.SetAbsenceResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, "user1", response.Absence.UserID)
+				require.Len(t, response.Reassigned, 1)
+				assert.Equal(t, "user2", response.Reassigned[0].ReplacedBy)
+				assert.Equal(t, "connection reset", response.ReassignError)
+			},
+		},
+		{
+			name: "error - malformed date",
+			requestBody: map[string]interface{}{
+				"user_id":   "user1",
+				"from_date": "01.07.2025",
+				"to_date":   "2025-07-07",
+			},
+			mockSetup:      func(m *handlermocks.MockUserServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "from_date")
+			},
+		},
+		{
+			name: "error - ends before it starts",
+			requestBody: map[string]interface{}{
+				"user_id":   "user1",
+				"from_date": "2025-07-07",
+				"to_date":   "2025-07-01",
+			},
+			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
+				m.EXPECT().SetAbsence(mock.Anything, mock.Anything, false).Return(nil, service.ErrInvalidAbsence)
+			},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "from_date must not be after to_date")
+			},
+		},
+		{
+			name: "error - user not found",
+			requestBody: map[string]interface{}{
+				"user_id":   "ghost",
+				"from_date": "2025-07-01",
+				"to_date":   "2025-07-07",
+			},
+			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
+				m.EXPECT().SetAbsence(mock.Anything, mock.Anything, false).Return(nil, service.ErrUserNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "user not found")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockUserServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			handler := handler.NewUserHandler(mockService)
+
+			body, err := json.Marshal(tt.requestBody)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/users/setAbsence", bytes.NewBuffer(body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			handler.SetAbsence(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			tt.validateResponse(t, w)
+		})
+	}
+}
+
+func TestUserHandler_RemoveAbsence(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	from := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		url            string
+		mockSetup      func(*handlermocks.MockUserServiceInterface)
+		expectedStatus int
+	}{
+		{
+			name: "success - remove one absence",
+			url:  "/users/setAbsence?user_id=user1&from_date=2025-07-01",
+			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
+				m.EXPECT().RemoveAbsence(mock.Anything, "user1", &from).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "success - remove all absences",
+			url:  "/users/setAbsence?user_id=user1",
+			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
+				m.EXPECT().RemoveAbsence(mock.Anything, "user1", (*time.Time)(nil)).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "error - missing user_id",
+			url:            "/users/setAbsence",
+			mockSetup:      func(m *handlermocks.MockUserServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error - nothing to remove",
+			url:  "/users/setAbsence?user_id=user1",
+			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
+				m.EXPECT().RemoveAbsence(mock.Anything, "user1", (*time.Time)(nil)).Return(service.ErrAbsenceNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockUserServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			handler := handler.NewUserHandler(mockService)
+
+			req, err := http.NewRequest(http.MethodDelete, tt.url, nil)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			handler.RemoveAbsence(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
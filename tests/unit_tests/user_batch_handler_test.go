@@ -0,0 +1,129 @@
+package unit_tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func TestUserHandler_SetIsActiveBatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name             string
+		requestBody      interface{}
+		mockSetup        func(*handlermocks.MockUserServiceInterface)
+		expectedStatus   int
+		validateResponse func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "success - partial failure reported per item",
+			requestBody: map[string]interface{}{
+				"users": []map[string]interface{}{
+					{"user_id": "user1", "is_active": false},
+					{"user_id": "ghost", "is_active": true},
+				},
+			},
+			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
+				m.EXPECT().SetIsActiveBatch(mock.Anything, []service.ActivityChange{
+					{UserID: "user1", IsActive: false},
+					{UserID: "ghost", IsActive: true},
+				}).Return(&service.ActivityBatchResult{
+					Updated: []domain.User{{UserID: "user1", Username: "Alice", TeamName: "team1", IsActive: false}},
+					Errors:  []service.ActivityChangeError{{UserID: "ghost", Err: service.ErrUserNotFound}},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.SetIsActiveBatchResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Len(t, response.Users, 1)
+				assert.Equal(t, "user1", response.Users[0].UserID)
+				assert.False(t, response.Users[0].IsActive)
+				require.Len(t, response.Errors, 1)
+				assert.Equal(t, "ghost", response.Errors[0].UserID)
+				assert.Equal(t, service.ErrUserNotFound.Error(), response.Errors[0].Message)
+			},
+		},
+		{
+			name:           "error - empty batch",
+			requestBody:    map[string]interface{}{"users": []map[string]interface{}{}},
+			mockSetup:      func(m *handlermocks.MockUserServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, "invalid request body", response.Error.Message)
+			},
+		},
+		{
+			name: "error - item without is_active",
+			requestBody: map[string]interface{}{
+				"users": []map[string]interface{}{{"user_id": "user1"}},
+			},
+			mockSetup:      func(m *handlermocks.MockUserServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, "invalid request body", response.Error.Message)
+			},
+		},
+		{
+			name: "error - internal server error",
+			requestBody: map[string]interface{}{
+				"users": []map[string]interface{}{{"user_id": "user1", "is_active": true}},
+			},
+			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
+				m.EXPECT().SetIsActiveBatch(mock.Anything, []service.ActivityChange{{UserID: "user1", IsActive: true}}).Return(nil, assert.AnError)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Contains(t, response.Error.Message, "assert.AnError")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockUserServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			handler := handler.NewUserHandler(mockService)
+
+			body, err := json.Marshal(tt.requestBody)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/users/setIsActiveBatch", bytes.NewBuffer(body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			handler.SetIsActiveBatch(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			tt.validateResponse(t, w)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package unit_tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func TestUserHandler_SetCapacity(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name             string
+		requestBody      interface{}
+		mockSetup        func(*handlermocks.MockUserServiceInterface)
+		expectedStatus   int
+		validateResponse func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "success - sets capacity",
+			requestBody: map[string]interface{}{
+				"user_id":          "user1",
+				"max_open_reviews": 2,
+			},
+			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
+				m.EXPECT().SetCapacity(mock.Anything, "user1", intPtr(2)).Return(&domain.User{
+					UserID:         "user1",
+					Username:       "testuser",
+					TeamName:       "team1",
+					IsActive:       true,
+					MaxOpenReviews: intPtr(2),
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.SetCapacityResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.NotNil(t, response.User)
+				require.NotNil(t, response.User.MaxOpenReviews)
+				assert.Equal(t, 2, *response.User.MaxOpenReviews)
+			},
+		},
+		{
+			name: "success - null removes limit",
+			requestBody: map[string]interface{}{
+				"user_id":          "user1",
+				"max_open_reviews": nil,
+			},
+			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
+				m.EXPECT().SetCapacity(mock.Anything, "user1", (*int)(nil)).Return(&domain.User{
+					UserID:   "user1",
+					Username: "testuser",
+					TeamName: "team1",
+					IsActive: true,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.NotContains(t, w.Body.String(), "max_open_reviews")
+			},
+		},
+		{
+			name: "error - negative capacity",
+			requestBody: map[string]interface{}{
+				"user_id":          "user1",
+				"max_open_reviews": -1,
+			},
+			mockSetup:      func(m *handlermocks.MockUserServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, "invalid request body", response.Error.Message)
+			},
+		},
+		{
+			name: "error - user not found",
+			requestBody: map[string]interface{}{
+				"user_id":          "nonexistent",
+				"max_open_reviews": 1,
+			},
+			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
+				m.EXPECT().SetCapacity(mock.Anything, "nonexistent", intPtr(1)).Return(nil, service.ErrUserNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, handler.ErrorNotFound, response.Error.Code)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockUserServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			handler := handler.NewUserHandler(mockService)
+
+			body, err := json.Marshal(tt.requestBody)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/users/setCapacity", bytes.NewBuffer(body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			handler.SetCapacity(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			tt.validateResponse(t, w)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package unit_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/middleware"
+	"github.com/mishasvintus/avito_backend_internship/internal/router"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func TestUserHandler_EraseUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name             string
+		body             string
+		mockSetup        func(*handlermocks.MockUserServiceInterface)
+		expectedStatus   int
+		validateResponse func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "success - returns tombstoned user",
+			body: `{"user_id":"u1"}`,
+			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
+				m.EXPECT().EraseUser(mock.Anything, "u1").Return(&domain.User{
+					UserID:   "u1",
+					Username: domain.ErasedUsername,
+					TeamName: "backend",
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.EraseUserResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.NotNil(t, response.User)
+				assert.Equal(t, "u1", response.User.UserID)
+				assert.Equal(t, "deleted user", response.User.Username)
+				assert.False(t, response.User.IsActive)
+			},
+		},
+		{
+			name: "error - user not found",
+			body: `{"user_id":"ghost"}`,
+			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
+				m.EXPECT().EraseUser(mock.Anything, "ghost").Return(nil, service.ErrUserNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, handler.ErrorNotFound, response.Error.Code)
+			},
+		},
+		{
+			name:           "error - missing user_id",
+			body:           `{}`,
+			mockSetup:      func(m *handlermocks.MockUserServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, handler.ErrorValidation, response.Error.Code)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockUserServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/users/erase", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler.NewUserHandler(mockService).EraseUser(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			tt.validateResponse(t, w)
+		})
+	}
+}
+
+func TestUserHandler_EraseUser_RequiresAdmin(t *testing.T) {
+	r, err := router.SetupRoutes(router.Options{Mode: gin.TestMode, AdminAPIKeys: []string{"secret"}},
+		nil, handler.NewUserHandler(handlermocks.NewMockUserServiceInterface(t)), nil, nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+
+	for _, apiKey := range []string{"", "guess"} {
+		req := httptest.NewRequest(http.MethodPost, router.APIPrefix+"/users/erase", strings.NewReader(`{"user_id":"u1"}`))
+		req.Header.Set("Content-Type", "application/json")
+		if apiKey != "" {
+			req.Header.Set(middleware.APIKeyHeader, apiKey)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnauthorized, w.Code, "api key %q", apiKey)
+	}
+}
@@ -0,0 +1,210 @@
+package unit_tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func TestUserHandler_AddExclusion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	exclusion := domain.Exclusion{ReviewerID: "rev1", AuthorID: "auth1"}
+
+	tests := []struct {
+		name             string
+		requestBody      interface{}
+		mockSetup        func(*handlermocks.MockUserServiceInterface)
+		expectedStatus   int
+		validateResponse func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "success - exclusion added",
+			requestBody: map[string]interface{}{"reviewer_id": "rev1", "author_id": "auth1"},
+			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
+				m.EXPECT().AddExclusion(mock.Anything, exclusion).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response struct {
+					Exclusion handler.ExclusionResponse `json:"exclusion"`
+				}
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, "rev1", response.Exclusion.ReviewerID)
+				assert.Equal(t, "auth1", response.Exclusion.AuthorID)
+			},
+		},
+		{
+			name:           "error - invalid request body (missing author_id)",
+			requestBody:    map[string]interface{}{"reviewer_id": "rev1"},
+			mockSetup:      func(m *handlermocks.MockUserServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, "invalid request body", response.Error.Message)
+			},
+		},
+		{
+			name:        "error - self exclusion",
+			requestBody: map[string]interface{}{"reviewer_id": "auth1", "author_id": "auth1"},
+			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
+				m.EXPECT().AddExclusion(mock.Anything, domain.Exclusion{ReviewerID: "auth1", AuthorID: "auth1"}).Return(service.ErrSelfExclusion)
+			},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, service.ErrSelfExclusion.Error(), response.Error.Message)
+			},
+		},
+		{
+			name:        "error - user not found",
+			requestBody: map[string]interface{}{"reviewer_id": "rev1", "author_id": "auth1"},
+			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
+				m.EXPECT().AddExclusion(mock.Anything, exclusion).Return(service.ErrUserNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, handler.ErrorNotFound, response.Error.Code)
+			},
+		},
+		{
+			name:        "error - internal server error",
+			requestBody: map[string]interface{}{"reviewer_id": "rev1", "author_id": "auth1"},
+			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
+				m.EXPECT().AddExclusion(mock.Anything, exclusion).Return(assert.AnError)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Contains(t, response.Error.Message, "assert.AnError")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockUserServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			handler := handler.NewUserHandler(mockService)
+
+			body, err := json.Marshal(tt.requestBody)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/users/addExclusion", bytes.NewBuffer(body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			handler.AddExclusion(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			tt.validateResponse(t, w)
+		})
+	}
+}
+
+func TestUserHandler_RemoveExclusion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	exclusion := domain.Exclusion{ReviewerID: "rev1", AuthorID: "auth1"}
+
+	tests := []struct {
+		name             string
+		requestBody      interface{}
+		mockSetup        func(*handlermocks.MockUserServiceInterface)
+		expectedStatus   int
+		validateResponse func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "success - exclusion removed",
+			requestBody: map[string]interface{}{"reviewer_id": "rev1", "author_id": "auth1"},
+			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
+				m.EXPECT().RemoveExclusion(mock.Anything, exclusion).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response map[string]string
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, "exclusion removed successfully", response["message"])
+			},
+		},
+		{
+			name:        "error - exclusion not found",
+			requestBody: map[string]interface{}{"reviewer_id": "rev1", "author_id": "auth1"},
+			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
+				m.EXPECT().RemoveExclusion(mock.Anything, exclusion).Return(service.ErrExclusionNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, "exclusion not found", response.Error.Message)
+			},
+		},
+		{
+			name:           "error - invalid request body",
+			requestBody:    map[string]interface{}{},
+			mockSetup:      func(m *handlermocks.MockUserServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, "invalid request body", response.Error.Message)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockUserServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			handler := handler.NewUserHandler(mockService)
+
+			body, err := json.Marshal(tt.requestBody)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/users/removeExclusion", bytes.NewBuffer(body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			handler.RemoveExclusion(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			tt.validateResponse(t, w)
+		})
+	}
+}
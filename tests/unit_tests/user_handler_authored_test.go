@@ -0,0 +1,204 @@
+package unit_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/pagination"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func TestUserHandler_GetAuthored(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	open := domain.StatusOpen
+	createdAt := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	next := pagination.Cursor{CreatedAt: createdAt, ID: "/pr1"}
+	prJSON := `{
+		"repository_name":"","pull_request_id":"pr1","pull_request_name":"Fix bug","author_id":"author1",
+		"team_name":"backend","status":"OPEN","assigned_reviewers":["rev1","rev2"],"approved_reviewers":["rev2"],
+		"createdAt":"2026-03-01T09:00:00Z","reassignment_count":0,"reviewers":[
+			{"user_id":"rev1","source":"required","assigned_at":"2026-03-01T09:00:00Z","approved":false},
+			{"user_id":"rev2","source":"auto","assigned_at":"2026-03-01T09:00:00Z","approved":true}],"assignments":[
+			{"reviewer_id":"rev1","assigned_at":"2026-03-01T09:00:00Z","hours_open":25,"overdue":true},
+			{"reviewer_id":"rev2","assigned_at":"2026-03-01T09:00:00Z","hours_open":25,"overdue":false}]}`
+
+	tests := []struct {
+		name             string
+		queryParams      map[string]string
+		mockSetup        func(*handlermocks.MockUserServiceInterface)
+		expectedStatus   int
+		validateResponse func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "success - open pull requests with reviewers",
+			queryParams: map[string]string{"user_id": "author1", "status": "OPEN", "limit": "1"},
+			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
+				m.EXPECT().GetAuthoredPRs(mock.Anything, "author1", pr.AuthoredFilter{Status: &open, Limit: 1}).Return(pagination.Page[domain.PullRequest]{Items: []domain.PullRequest{
+					{
+						PullRequestID:        "pr1",
+						PullRequestName:      "Fix bug",
+						AuthorID:             "author1",
+						TeamName:             "backend",
+						Status:               domain.StatusOpen,
+						AssignedReviewersIDs: []string{"rev1", "rev2"},
+						ApprovedReviewersIDs: []string{"rev2"},
+						CreatedAt:            &createdAt,
+						Assignments: []domain.ReviewerAssignment{
+							{UserID: "rev1", Source: domain.SourceRequired, AssignedAt: createdAt, HoursOpen: intPtr(25), Overdue: true},
+							{UserID: "rev2", Source: domain.SourceAuto, AssignedAt: createdAt, Approved: true, HoursOpen: intPtr(25)},
+						},
+					},
+				}, NextCursor: next.Encode(), HasMore: true}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.JSONEq(t, `{"user_id":"author1","items":[`+prJSON+`],"next_cursor":"`+next.Encode()+`","has_more":true,
+					"next_offset":1,"pull_requests":[`+prJSON+`]}`, w.Body.String())
+			},
+		},
+		{
+			name:        "success - cursor is passed on",
+			queryParams: map[string]string{"user_id": "author1", "cursor": next.Encode()},
+			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
+				m.EXPECT().GetAuthoredPRs(mock.Anything, "author1", pr.AuthoredFilter{Limit: 50, After: &next}).
+					Return(pagination.Page[domain.PullRequest]{Items: []domain.PullRequest{}, NextCursor: next.Encode(), HasMore: true}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.GetAuthoredResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.True(t, response.HasMore)
+				// Clients paging by cursor are not sent the legacy offset.
+				assert.Zero(t, response.NextOffset)
+			},
+		},
+		{
+			name:        "success - author with no pull requests",
+			queryParams: map[string]string{"user_id": "author1"},
+			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
+				m.EXPECT().GetAuthoredPRs(mock.Anything, "author1", pr.AuthoredFilter{Limit: 50}).
+					Return(pagination.Page[domain.PullRequest]{Items: []domain.PullRequest{}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.JSONEq(t, `{"user_id":"author1","items":[],"has_more":false,"pull_requests":[]}`, w.Body.String())
+			},
+		},
+		{
+			name:        "success - offset is passed on",
+			queryParams: map[string]string{"user_id": "author1", "limit": "10", "offset": "20"},
+			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
+				m.EXPECT().GetAuthoredPRs(mock.Anything, "author1", pr.AuthoredFilter{Limit: 10, Offset: 20}).
+					Return(pagination.Page[domain.PullRequest]{Items: []domain.PullRequest{}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.GetAuthoredResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Zero(t, response.NextOffset)
+			},
+		},
+		{
+			name:           "error - missing user_id parameter",
+			queryParams:    map[string]string{},
+			mockSetup:      func(m *handlermocks.MockUserServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "user_id parameter is required", response.Error.Message)
+			},
+		},
+		{
+			name:           "error - unknown status",
+			queryParams:    map[string]string{"user_id": "author1", "status": "DRAFT"},
+			mockSetup:      func(m *handlermocks.MockUserServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Contains(t, response.Error.Message, "invalid PR status")
+			},
+		},
+		{
+			name:           "error - limit out of range",
+			queryParams:    map[string]string{"user_id": "author1", "limit": "101"},
+			mockSetup:      func(m *handlermocks.MockUserServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "limit must be an integer between 1 and 100", response.Error.Message)
+			},
+		},
+		{
+			name:           "error - malformed cursor",
+			queryParams:    map[string]string{"user_id": "author1", "cursor": "not-a-cursor"},
+			mockSetup:      func(m *handlermocks.MockUserServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "cursor must be a next_cursor of a previous page", response.Error.Message)
+			},
+		},
+		{
+			name:           "error - cursor with offset",
+			queryParams:    map[string]string{"user_id": "author1", "offset": "10", "cursor": next.Encode()},
+			mockSetup:      func(m *handlermocks.MockUserServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "cursor and offset cannot be combined", response.Error.Message)
+			},
+		},
+		{
+			name:           "error - negative offset",
+			queryParams:    map[string]string{"user_id": "author1", "offset": "-1"},
+			mockSetup:      func(m *handlermocks.MockUserServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "offset must be a non-negative integer", response.Error.Message)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockUserServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			req, err := http.NewRequest(http.MethodGet, "/users/getAuthored", nil)
+			require.NoError(t, err)
+			q := req.URL.Query()
+			for key, value := range tt.queryParams {
+				q.Add(key, value)
+			}
+			req.URL.RawQuery = q.Encode()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			handler.NewUserHandler(mockService).GetAuthored(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			tt.validateResponse(t, w)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package unit_tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func TestUserHandler_ReassignAll(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	serve := func(mockService *handlermocks.MockUserServiceInterface, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/users/reassignAll", strings.NewReader(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		handler.NewUserHandler(mockService).ReassignAll(c)
+		return w
+	}
+
+	t.Run("replacements and reviews without a candidate", func(t *testing.T) {
+		mockService := handlermocks.NewMockUserServiceInterface(t)
+		mockService.EXPECT().ReassignAll(mock.Anything, "u2", true).Return([]service.ReassignResult{
+			{PR: domain.PRKey{PullRequestID: "pr-1"}, ReplacedBy: "u5"},
+			{PR: domain.PRKey{RepositoryName: "web", PullRequestID: "pr-2"}},
+		}, nil)
+
+		w := serve(mockService, `{"user_id":"u2","deactivate":true}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"user_id":"u2","deactivated":true,"reassigned":[
+			{"repository_name":"","pull_request_id":"pr-1","replaced_by":"u5"},
+			{"repository_name":"web","pull_request_id":"pr-2","error":"NO_CANDIDATE"}]}`, w.Body.String())
+	})
+
+	t.Run("no open reviews", func(t *testing.T) {
+		mockService := handlermocks.NewMockUserServiceInterface(t)
+		mockService.EXPECT().ReassignAll(mock.Anything, "u2", false).Return([]service.ReassignResult{}, nil)
+
+		w := serve(mockService, `{"user_id":"u2"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"user_id":"u2","deactivated":false,"reassigned":[]}`, w.Body.String())
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mockService := handlermocks.NewMockUserServiceInterface(t)
+		mockService.EXPECT().ReassignAll(mock.Anything, "ghost", false).Return(nil, service.ErrUserNotFound)
+
+		w := serve(mockService, `{"user_id":"ghost"}`)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("missing user id", func(t *testing.T) {
+		w := serve(handlermocks.NewMockUserServiceInterface(t), `{"deactivate":true}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
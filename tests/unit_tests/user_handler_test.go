@@ -6,9 +6,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/mishasvintus/avito_backend_internship/internal/domain"
@@ -34,7 +36,7 @@ func TestUserHandler_SetIsActive(t *testing.T) {
 				"is_active": true,
 			},
 			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
-				m.EXPECT().SetIsActive("user1", true).Return(&domain.User{
+				m.EXPECT().SetIsActive(mock.Anything, "user1", true).Return(&domain.User{
 					UserID:   "user1",
 					Username: "testuser",
 					TeamName: "team1",
@@ -43,7 +45,7 @@ func TestUserHandler_SetIsActive(t *testing.T) {
 			},
 			expectedStatus: http.StatusOK,
 			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
-				var response handler.SuccessResponse
+				var response handler.SetIsActiveResponse
 				err := json.Unmarshal(w.Body.Bytes(), &response)
 				require.NoError(t, err)
 				assert.NotNil(t, response.User)
@@ -51,6 +53,10 @@ func TestUserHandler_SetIsActive(t *testing.T) {
 				assert.Equal(t, "testuser", response.User.Username)
 				assert.Equal(t, "team1", response.User.TeamName)
 				assert.True(t, response.User.IsActive)
+
+				var envelope map[string]json.RawMessage
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &envelope))
+				assert.Len(t, envelope, 1, "the user is the only field of the envelope")
 			},
 		},
 		{
@@ -60,7 +66,7 @@ func TestUserHandler_SetIsActive(t *testing.T) {
 				"is_active": false,
 			},
 			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
-				m.EXPECT().SetIsActive("user1", false).Return(&domain.User{
+				m.EXPECT().SetIsActive(mock.Anything, "user1", false).Return(&domain.User{
 					UserID:   "user1",
 					Username: "testuser",
 					TeamName: "team1",
@@ -69,7 +75,7 @@ func TestUserHandler_SetIsActive(t *testing.T) {
 			},
 			expectedStatus: http.StatusOK,
 			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
-				var response handler.SuccessResponse
+				var response handler.SetIsActiveResponse
 				err := json.Unmarshal(w.Body.Bytes(), &response)
 				require.NoError(t, err)
 				assert.NotNil(t, response.User)
@@ -98,7 +104,7 @@ func TestUserHandler_SetIsActive(t *testing.T) {
 				"is_active": true,
 			},
 			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
-				m.EXPECT().SetIsActive("nonexistent", true).Return(nil, service.ErrUserNotFound)
+				m.EXPECT().SetIsActive(mock.Anything, "nonexistent", true).Return(nil, service.ErrUserNotFound)
 			},
 			expectedStatus: http.StatusNotFound,
 			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -115,7 +121,7 @@ func TestUserHandler_SetIsActive(t *testing.T) {
 				"is_active": true,
 			},
 			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
-				m.EXPECT().SetIsActive("user1", true).Return(nil, assert.AnError)
+				m.EXPECT().SetIsActive(mock.Anything, "user1", true).Return(nil, assert.AnError)
 			},
 			expectedStatus: http.StatusInternalServerError,
 			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -169,18 +175,25 @@ func TestUserHandler_GetReview(t *testing.T) {
 				"user_id": "user1",
 			},
 			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
-				m.EXPECT().GetUserReviews("user1").Return([]domain.PullRequestShort{
+				m.EXPECT().GetUserReviews(mock.Anything, "user1", (*string)(nil)).Return([]domain.PullRequestShort{
 					{
 						PullRequestID:   "pr1",
 						PullRequestName: "Fix bug",
 						AuthorID:        "author1",
 						Status:          domain.StatusOpen,
+						Assignment: &domain.ReviewerAssignment{
+							UserID: "user1", AssignedAt: time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC),
+							ReviewSLAHours: 24, HoursOpen: intPtr(25), Overdue: true,
+						},
 					},
 					{
 						PullRequestID:   "pr2",
 						PullRequestName: "Add feature",
 						AuthorID:        "author2",
 						Status:          domain.StatusMerged,
+						Assignment: &domain.ReviewerAssignment{
+							UserID: "user1", AssignedAt: time.Date(2026, 2, 27, 9, 0, 0, 0, time.UTC), ReviewSLAHours: 24,
+						},
 					},
 				}, nil)
 			},
@@ -195,8 +208,13 @@ func TestUserHandler_GetReview(t *testing.T) {
 				assert.Equal(t, "Fix bug", response.PullRequests[0].PullRequestName)
 				assert.Equal(t, "author1", response.PullRequests[0].AuthorID)
 				assert.Equal(t, "OPEN", response.PullRequests[0].Status)
+				assert.Equal(t, "2026-03-01T09:00:00Z", response.PullRequests[0].AssignedAt)
+				assert.Equal(t, intPtr(25), response.PullRequests[0].HoursOpen)
+				assert.True(t, response.PullRequests[0].Overdue)
 				assert.Equal(t, "pr2", response.PullRequests[1].PullRequestID)
 				assert.Equal(t, "MERGED", response.PullRequests[1].Status)
+				assert.Nil(t, response.PullRequests[1].HoursOpen)
+				assert.False(t, response.PullRequests[1].Overdue)
 			},
 		},
 		{
@@ -205,7 +223,7 @@ func TestUserHandler_GetReview(t *testing.T) {
 				"user_id": "user1",
 			},
 			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
-				m.EXPECT().GetUserReviews("user1").Return([]domain.PullRequestShort{}, nil)
+				m.EXPECT().GetUserReviews(mock.Anything, "user1", (*string)(nil)).Return([]domain.PullRequestShort{}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -216,6 +234,51 @@ func TestUserHandler_GetReview(t *testing.T) {
 				assert.Empty(t, response.PullRequests)
 			},
 		},
+		{
+			name: "success - filtered by repository",
+			queryParams: map[string]string{
+				"user_id":         "user1",
+				"repository_name": "backend",
+			},
+			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
+				repositoryName := "backend"
+				m.EXPECT().GetUserReviews(mock.Anything, "user1", &repositoryName).Return([]domain.PullRequestShort{
+					{
+						RepositoryName:  "backend",
+						PullRequestID:   "pr1",
+						PullRequestName: "Fix bug",
+						AuthorID:        "author1",
+						Status:          domain.StatusOpen,
+					},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.GetReviewResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Len(t, response.PullRequests, 1)
+				assert.Equal(t, "backend", response.PullRequests[0].RepositoryName)
+			},
+		},
+		{
+			name: "success - empty repository_name selects the default repository",
+			queryParams: map[string]string{
+				"user_id":         "user1",
+				"repository_name": "",
+			},
+			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
+				repositoryName := ""
+				m.EXPECT().GetUserReviews(mock.Anything, "user1", &repositoryName).Return([]domain.PullRequestShort{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.GetReviewResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Empty(t, response.PullRequests)
+			},
+		},
 		{
 			name:           "error - missing user_id parameter",
 			queryParams:    map[string]string{},
@@ -248,7 +311,7 @@ func TestUserHandler_GetReview(t *testing.T) {
 				"user_id": "user1",
 			},
 			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
-				m.EXPECT().GetUserReviews("user1").Return(nil, assert.AnError)
+				m.EXPECT().GetUserReviews(mock.Anything, "user1", (*string)(nil)).Return(nil, assert.AnError)
 			},
 			expectedStatus: http.StatusInternalServerError,
 			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -0,0 +1,118 @@
+package unit_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/repository/pr"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func TestUserHandler_GetReviewCount(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name             string
+		queryParams      map[string]string
+		mockSetup        func(*handlermocks.MockUserServiceInterface)
+		expectedStatus   int
+		validateResponse func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "success - returns counts",
+			queryParams: map[string]string{"user_id": "user1"},
+			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
+				m.EXPECT().GetUserReviewCount(mock.Anything, "user1", (*string)(nil)).Return(pr.ReviewCount{Open: 3, Overdue: 1}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.JSONEq(t, `{"user_id":"user1","open_count":3,"overdue_count":1}`, w.Body.String())
+			},
+		},
+		{
+			name:        "success - unknown user has zero counts",
+			queryParams: map[string]string{"user_id": "ghost"},
+			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
+				m.EXPECT().GetUserReviewCount(mock.Anything, "ghost", (*string)(nil)).Return(pr.ReviewCount{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.JSONEq(t, `{"user_id":"ghost","open_count":0,"overdue_count":0}`, w.Body.String())
+			},
+		},
+		{
+			name:        "success - filters by repository",
+			queryParams: map[string]string{"user_id": "user1", "repository_name": "backend"},
+			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
+				repositoryName := "backend"
+				m.EXPECT().GetUserReviewCount(mock.Anything, "user1", &repositoryName).Return(pr.ReviewCount{Open: 1}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.GetReviewCountResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, 1, response.OpenCount)
+				assert.Zero(t, response.OverdueCount)
+			},
+		},
+		{
+			name:           "error - missing user_id parameter",
+			queryParams:    map[string]string{},
+			mockSetup:      func(m *handlermocks.MockUserServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "user_id parameter is required", response.Error.Message)
+			},
+		},
+		{
+			name:        "error - internal error from service",
+			queryParams: map[string]string{"user_id": "user1"},
+			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
+				m.EXPECT().GetUserReviewCount(mock.Anything, "user1", (*string)(nil)).Return(pr.ReviewCount{}, assert.AnError)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Contains(t, response.Error.Message, "assert.AnError")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockUserServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			handler := handler.NewUserHandler(mockService)
+
+			req, err := http.NewRequest(http.MethodGet, "/users/getReviewCount", nil)
+			require.NoError(t, err)
+
+			q := req.URL.Query()
+			for key, value := range tt.queryParams {
+				q.Add(key, value)
+			}
+			req.URL.RawQuery = q.Encode()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			handler.GetReviewCount(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			tt.validateResponse(t, w)
+		})
+	}
+}
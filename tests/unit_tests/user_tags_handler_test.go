@@ -0,0 +1,111 @@
+package unit_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func TestUserHandler_SetTags(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name             string
+		body             string
+		mockSetup        func(*handlermocks.MockUserServiceInterface)
+		expectedStatus   int
+		validateResponse func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "success - sets tags",
+			body: `{"user_id":"user1","tags":["payments","go"]}`,
+			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
+				m.EXPECT().SetTags(mock.Anything, "user1", []string{"payments", "go"}).Return(&domain.User{
+					UserID:   "user1",
+					Username: "testuser",
+					TeamName: "team1",
+					IsActive: true,
+					Tags:     []string{"go", "payments"},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.SetTagsResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.NotNil(t, response.User)
+				assert.Equal(t, []string{"go", "payments"}, response.User.Tags)
+			},
+		},
+		{
+			name: "success - missing tags removes them",
+			body: `{"user_id":"user1"}`,
+			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
+				m.EXPECT().SetTags(mock.Anything, "user1", []string(nil)).Return(&domain.User{
+					UserID:   "user1",
+					Username: "testuser",
+					TeamName: "team1",
+					IsActive: true,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.NotContains(t, w.Body.String(), "tags")
+			},
+		},
+		{
+			name:           "error - invalid tag",
+			body:           `{"user_id":"user1","tags":["go!"]}`,
+			mockSetup:      func(m *handlermocks.MockUserServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Len(t, response.Error.Details, 1)
+				assert.Equal(t, "tags[0]", response.Error.Details[0].Field)
+				assert.Equal(t, "tag", response.Error.Details[0].Rule)
+			},
+		},
+		{
+			name: "error - user not found",
+			body: `{"user_id":"nonexistent","tags":["go"]}`,
+			mockSetup: func(m *handlermocks.MockUserServiceInterface) {
+				m.EXPECT().SetTags(mock.Anything, "nonexistent", []string{"go"}).Return(nil, service.ErrUserNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, handler.ErrorNotFound, response.Error.Code)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockUserServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/users/setTags", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler.NewUserHandler(mockService).SetTags(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			tt.validateResponse(t, w)
+		})
+	}
+}
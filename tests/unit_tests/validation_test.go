@@ -0,0 +1,203 @@
+package unit_tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func teamMembersJSON(n int) string {
+	members := make([]string, n)
+	for i := range members {
+		members[i] = fmt.Sprintf(`{"user_id":"u%d","username":"User %d","is_active":true}`, i, i)
+	}
+	return "[" + strings.Join(members, ",") + "]"
+}
+
+func TestRequestValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	longID := strings.Repeat("a", 101)
+	longName := strings.Repeat("n", 301)
+
+	tests := []struct {
+		name            string
+		handle          func(t *testing.T) gin.HandlerFunc
+		body            string
+		expectedStatus  int
+		expectedDetails []handler.FieldError
+	}{
+		{
+			name:           "pull request id with forbidden characters",
+			handle:         createPRHandler,
+			body:           `{"pull_request_id":"pr 1","pull_request_name":"Fix","author_id":"u1"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedDetails: []handler.FieldError{
+				{Field: "pull_request_id", Rule: "entity_id", Message: "must be 1-100 characters of letters, digits, '.', '_' or '-'"},
+			},
+		},
+		{
+			name:           "author id too long",
+			handle:         createPRHandler,
+			body:           `{"pull_request_id":"pr-1","pull_request_name":"Fix","author_id":"` + longID + `"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedDetails: []handler.FieldError{
+				{Field: "author_id", Rule: "entity_id", Message: "must be 1-100 characters of letters, digits, '.', '_' or '-'"},
+			},
+		},
+		{
+			name:           "pull request name too long",
+			handle:         createPRHandler,
+			body:           `{"pull_request_id":"pr-1","pull_request_name":"` + longName + `","author_id":"u1"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedDetails: []handler.FieldError{
+				{Field: "pull_request_name", Rule: "max", Message: "must be at most 300 characters"},
+			},
+		},
+		{
+			name:           "invalid required reviewer",
+			handle:         createPRHandler,
+			body:           `{"pull_request_id":"pr-1","pull_request_name":"Fix","author_id":"u1","required_reviewers":["u2","u/3"]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedDetails: []handler.FieldError{
+				{Field: "required_reviewers[1]", Rule: "entity_id", Message: "must be 1-100 characters of letters, digits, '.', '_' or '-'"},
+			},
+		},
+		{
+			name:           "several violations reported together",
+			handle:         createPRHandler,
+			body:           `{"pull_request_id":"","pull_request_name":"Fix","author_id":"u#1"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedDetails: []handler.FieldError{
+				{Field: "pull_request_id", Rule: "entity_id", Message: "must be 1-100 characters of letters, digits, '.', '_' or '-'"},
+				{Field: "author_id", Rule: "entity_id", Message: "must be 1-100 characters of letters, digits, '.', '_' or '-'"},
+			},
+		},
+		{
+			name: "ids and names at the limits",
+			handle: func(t *testing.T) gin.HandlerFunc {
+				mockService := handlermocks.NewMockPRServiceInterface(t)
+				mockService.EXPECT().CreatePR(mock.Anything, domain.PRKey{PullRequestID: strings.Repeat("a", 100)}, strings.Repeat("n", 300), "u.1_x-Y", []string(nil), domain.PRDetails{}).
+					Return(&domain.PullRequest{PullRequestID: "pr-1", Status: domain.StatusOpen}, nil)
+				return handler.NewPRHandler(mockService).CreatePR
+			},
+			body: `{"pull_request_id":"` + strings.Repeat("a", 100) + `","pull_request_name":"` +
+				strings.Repeat("n", 300) + `","author_id":"u.1_x-Y"}`,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "team with too many members",
+			handle:         addTeamHandler,
+			body:           `{"team_name":"backend","members":` + teamMembersJSON(201) + `}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedDetails: []handler.FieldError{
+				{Field: "members", Rule: "max", Message: "must have at most 200 items"},
+			},
+		},
+		{
+			name:           "duplicate member user ids",
+			handle:         addTeamHandler,
+			body:           `{"team_name":"backend","members":[{"user_id":"u1","username":"A","is_active":true},{"user_id":"u1","username":"B","is_active":true}]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedDetails: []handler.FieldError{
+				{Field: "members", Rule: "unique", Message: "must not contain duplicate user_id values"},
+			},
+		},
+		{
+			name:           "invalid member fields",
+			handle:         addTeamHandler,
+			body:           `{"team_name":"backend","members":[{"user_id":"u1","username":"A","is_active":true},{"user_id":"u 2","username":"","is_active":true}]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedDetails: []handler.FieldError{
+				{Field: "members[1].user_id", Rule: "entity_id", Message: "must be 1-100 characters of letters, digits, '.', '_' or '-'"},
+				{Field: "members[1].username", Rule: "required", Message: "is required"},
+			},
+		},
+		{
+			name:           "team name too long",
+			handle:         addTeamHandler,
+			body:           `{"team_name":"` + longName + `","members":[]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedDetails: []handler.FieldError{
+				{Field: "team_name", Rule: "max", Message: "must be at most 300 characters"},
+			},
+		},
+		{
+			name: "team with 200 members",
+			handle: func(t *testing.T) gin.HandlerFunc {
+				mockService := handlermocks.NewMockTeamServiceInterface(t)
+				mockService.EXPECT().CreateTeam(mock.Anything, mock.MatchedBy(func(team *domain.Team) bool {
+					return len(team.Members) == 200
+				}), service.CreateTeamOptions{ConflictPolicy: service.ConflictMove, IfExists: service.IfExistsFail}).Return(&domain.Team{TeamName: "backend"}, service.TeamCreated, nil)
+				return handler.NewTeamHandler(mockService).AddTeam
+			},
+			body:           `{"team_name":"backend","members":` + teamMembersJSON(200) + `}`,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "invalid user id in activity change",
+			handle: func(t *testing.T) gin.HandlerFunc {
+				return handler.NewUserHandler(handlermocks.NewMockUserServiceInterface(t)).SetIsActive
+			},
+			body:           `{"user_id":"user@example.com","is_active":false}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedDetails: []handler.FieldError{
+				{Field: "user_id", Rule: "entity_id", Message: "must be 1-100 characters of letters, digits, '.', '_' or '-'"},
+			},
+		},
+		{
+			name: "invalid reviewer id in exclusion",
+			handle: func(t *testing.T) gin.HandlerFunc {
+				return handler.NewUserHandler(handlermocks.NewMockUserServiceInterface(t)).AddExclusion
+			},
+			body:           `{"reviewer_id":"","author_id":"u1"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedDetails: []handler.FieldError{
+				{Field: "reviewer_id", Rule: "required", Message: "is required"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			tt.handle(t)(c)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			if tt.expectedDetails == nil {
+				return
+			}
+			var response handler.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, handler.ErrorValidation, response.Error.Code)
+			assert.Equal(t, tt.expectedDetails, response.Error.Details)
+		})
+	}
+}
+
+// createPRHandler returns PRHandler.CreatePR backed by a service that must not be called.
+func createPRHandler(t *testing.T) gin.HandlerFunc {
+	return handler.NewPRHandler(handlermocks.NewMockPRServiceInterface(t)).CreatePR
+}
+
+// addTeamHandler returns TeamHandler.AddTeam backed by a service that must not be called.
+func addTeamHandler(t *testing.T) gin.HandlerFunc {
+	return handler.NewTeamHandler(handlermocks.NewMockTeamServiceInterface(t)).AddTeam
+}
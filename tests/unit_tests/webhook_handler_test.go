@@ -0,0 +1,187 @@
+package unit_tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/handler"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+	handlermocks "github.com/mishasvintus/avito_backend_internship/tests/mocks"
+)
+
+func TestWebhookHandler_CreateWebhook(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	createdAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		requestBody      interface{}
+		mockSetup        func(*handlermocks.MockWebhookServiceInterface)
+		expectedStatus   int
+		validateResponse func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "success - secret is not echoed",
+			requestBody: map[string]interface{}{"url": "https://hooks.example.com/reviews", "secret": "s3cret"},
+			mockSetup: func(m *handlermocks.MockWebhookServiceInterface) {
+				m.EXPECT().CreateWebhook(mock.Anything, "https://hooks.example.com/reviews", "s3cret").
+					Return(&domain.Webhook{ID: 7, URL: "https://hooks.example.com/reviews", Secret: "s3cret", CreatedAt: createdAt}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response struct {
+					Webhook handler.WebhookResponse `json:"webhook"`
+				}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, handler.WebhookResponse{ID: 7, URL: "https://hooks.example.com/reviews", CreatedAt: "2025-03-01T12:00:00Z"}, response.Webhook)
+				assert.NotContains(t, w.Body.String(), "s3cret")
+			},
+		},
+		{
+			name:           "error - url is not http",
+			requestBody:    map[string]interface{}{"url": "ftp://hooks.example.com", "secret": "s3cret"},
+			mockSetup:      func(m *handlermocks.MockWebhookServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, handler.ErrorValidation, response.Error.Code)
+				assert.Equal(t, []handler.FieldError{
+					{Field: "url", Rule: "http_url", Message: "must be an absolute http or https URL"},
+				}, response.Error.Details)
+			},
+		},
+		{
+			name:           "error - missing secret",
+			requestBody:    map[string]interface{}{"url": "https://hooks.example.com"},
+			mockSetup:      func(m *handlermocks.MockWebhookServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, []handler.FieldError{
+					{Field: "secret", Rule: "required", Message: "is required"},
+				}, response.Error.Details)
+			},
+		},
+		{
+			name:        "error - internal server error",
+			requestBody: map[string]interface{}{"url": "https://hooks.example.com", "secret": "s3cret"},
+			mockSetup: func(m *handlermocks.MockWebhookServiceInterface) {
+				m.EXPECT().CreateWebhook(mock.Anything, "https://hooks.example.com", "s3cret").Return(nil, assert.AnError)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			validateResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response handler.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Contains(t, response.Error.Message, "assert.AnError")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockWebhookServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			handler := handler.NewWebhookHandler(mockService)
+
+			body, err := json.Marshal(tt.requestBody)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/webhooks", bytes.NewBuffer(body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			handler.CreateWebhook(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			tt.validateResponse(t, w)
+		})
+	}
+}
+
+func TestWebhookHandler_DeleteWebhook(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name            string
+		query           string
+		mockSetup       func(*handlermocks.MockWebhookServiceInterface)
+		expectedStatus  int
+		expectedMessage string
+	}{
+		{
+			name:  "success",
+			query: "?id=7",
+			mockSetup: func(m *handlermocks.MockWebhookServiceInterface) {
+				m.EXPECT().DeleteWebhook(mock.Anything, int64(7)).Return(nil)
+			},
+			expectedStatus:  http.StatusOK,
+			expectedMessage: "webhook removed successfully",
+		},
+		{
+			name:  "error - not found",
+			query: "?id=8",
+			mockSetup: func(m *handlermocks.MockWebhookServiceInterface) {
+				m.EXPECT().DeleteWebhook(mock.Anything, int64(8)).Return(service.ErrWebhookNotFound)
+			},
+			expectedStatus:  http.StatusNotFound,
+			expectedMessage: "webhook not found",
+		},
+		{
+			name:            "error - missing id",
+			query:           "",
+			mockSetup:       func(m *handlermocks.MockWebhookServiceInterface) {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: "id parameter is required",
+		},
+		{
+			name:            "error - invalid id",
+			query:           "?id=abc",
+			mockSetup:       func(m *handlermocks.MockWebhookServiceInterface) {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: "id must be a positive integer",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := handlermocks.NewMockWebhookServiceInterface(t)
+			tt.mockSetup(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodDelete, "/webhooks"+tt.query, nil)
+
+			handler.NewWebhookHandler(mockService).DeleteWebhook(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var response struct {
+				Message string            `json:"message"`
+				Error   handler.ErrorBody `json:"error"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, tt.expectedMessage, response.Message)
+			} else {
+				assert.Equal(t, tt.expectedMessage, response.Error.Message)
+			}
+		})
+	}
+}
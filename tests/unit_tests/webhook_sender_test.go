@@ -0,0 +1,224 @@
+package unit_tests
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mishasvintus/avito_backend_internship/internal/domain"
+	"github.com/mishasvintus/avito_backend_internship/internal/service"
+)
+
+// stubWebhookLister returns a fixed set of webhooks.
+type stubWebhookLister []domain.Webhook
+
+func (l stubWebhookLister) ListWebhooks(context.Context) ([]domain.Webhook, error) {
+	return l, nil
+}
+
+// webhookDelivery is a request received by webhookReceiver.
+type webhookDelivery struct {
+	Header http.Header
+	Body   []byte
+}
+
+// webhookReceiver records deliveries and answers with the given statuses in turn,
+// repeating the last one once they run out.
+type webhookReceiver struct {
+	mu         sync.Mutex
+	statuses   []int
+	deliveries []webhookDelivery
+	received   chan struct{}
+}
+
+func newWebhookReceiver(t *testing.T, statuses ...int) (*webhookReceiver, *httptest.Server) {
+	t.Helper()
+
+	rec := &webhookReceiver{statuses: statuses, received: make(chan struct{}, 100)}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		rec.mu.Lock()
+		status := http.StatusOK
+		if n := len(rec.deliveries); len(rec.statuses) > 0 {
+			status = rec.statuses[min(n, len(rec.statuses)-1)]
+		}
+		rec.deliveries = append(rec.deliveries, webhookDelivery{Header: r.Header.Clone(), Body: body})
+		rec.mu.Unlock()
+
+		w.WriteHeader(status)
+		rec.received <- struct{}{}
+	}))
+	t.Cleanup(srv.Close)
+	return rec, srv
+}
+
+func (r *webhookReceiver) Deliveries() []webhookDelivery {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]webhookDelivery(nil), r.deliveries...)
+}
+
+// waitDeliveries blocks until n deliveries arrived or the test times out.
+func (r *webhookReceiver) waitDeliveries(t *testing.T, n int) {
+	t.Helper()
+	for range n {
+		select {
+		case <-r.received:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected %d deliveries, got %d", n, len(r.Deliveries()))
+		}
+	}
+}
+
+var fastWebhookRetries = service.RetryPolicy{Attempts: 3, BaseDelay: time.Millisecond}
+
+func TestWebhookSender_DeliversSignedPayload(t *testing.T) {
+	rec, srv := newWebhookReceiver(t)
+	sender := service.NewWebhookSender(stubWebhookLister{{ID: 1, URL: srv.URL, Secret: "s3cret"}}).
+		WithRetryPolicy(fastWebhookRetries)
+
+	err := sender.Publish(context.Background(), domain.Event{
+		ID:                 "42",
+		Type:               domain.EventReviewerReassigned,
+		OccurredAt:         time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+		OrgID:              "acme",
+		RepositoryName:     "backend",
+		PullRequestID:      "pr-1",
+		ReviewerID:         "u3",
+		ReplacedReviewerID: "u2",
+		Reason:             domain.ActionReassign,
+	})
+	require.NoError(t, err)
+	require.Len(t, rec.Deliveries(), 1)
+
+	delivery := rec.Deliveries()[0]
+	assert.Equal(t, "application/json", delivery.Header.Get("Content-Type"))
+	assert.Equal(t, "reviewer.reassigned", delivery.Header.Get(service.WebhookEventHeader))
+	assert.Equal(t, "42", delivery.Header.Get(service.WebhookDeliveryHeader))
+	assert.Equal(t, service.SignWebhookPayload("s3cret", delivery.Body), delivery.Header.Get(service.WebhookSignatureHeader))
+	assert.NotEqual(t, service.SignWebhookPayload("other", delivery.Body), delivery.Header.Get(service.WebhookSignatureHeader))
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(delivery.Body, &payload))
+	assert.Equal(t, map[string]any{
+		"id":                   "42",
+		"event":                "reviewer.reassigned",
+		"occurred_at":          "2025-03-01T12:00:00Z",
+		"org_id":               "acme",
+		"repository_name":      "backend",
+		"pull_request_id":      "pr-1",
+		"reviewer_id":          "u3",
+		"replaced_reviewer_id": "u2",
+		"reason":               "REASSIGN",
+	}, payload)
+}
+
+func TestWebhookSender_NoWebhooks(t *testing.T) {
+	err := service.NewWebhookSender(stubWebhookLister{}).Publish(context.Background(), domain.Event{ID: "1", Type: domain.EventPRCreated})
+	assert.NoError(t, err)
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	// HMAC-SHA256 of "hello" keyed by "key".
+	assert.Equal(t,
+		"sha256=9307b3b915efb5171ff14d8cb55fbcc798c6c0ef1456d66ded1a6aa723a58b7b",
+		service.SignWebhookPayload("key", []byte("hello")))
+}
+
+func TestWebhookSender_Retries(t *testing.T) {
+	tests := []struct {
+		name              string
+		statuses          []int
+		expectedAttempts  int
+		expectedDelivered bool
+	}{
+		{
+			name:              "succeeds on first attempt",
+			statuses:          []int{http.StatusNoContent},
+			expectedAttempts:  1,
+			expectedDelivered: true,
+		},
+		{
+			name:              "retries after server errors",
+			statuses:          []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusOK},
+			expectedAttempts:  3,
+			expectedDelivered: true,
+		},
+		{
+			name:              "retries client errors too",
+			statuses:          []int{http.StatusNotFound, http.StatusAccepted},
+			expectedAttempts:  2,
+			expectedDelivered: true,
+		},
+		{
+			name:              "gives up after the last attempt",
+			statuses:          []int{http.StatusServiceUnavailable},
+			expectedAttempts:  3,
+			expectedDelivered: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, srv := newWebhookReceiver(t, tt.statuses...)
+			sender := service.NewWebhookSender(stubWebhookLister{{ID: 1, URL: srv.URL, Secret: "s"}}).
+				WithRetryPolicy(fastWebhookRetries)
+
+			err := sender.Publish(context.Background(), domain.Event{ID: "evt-1", Type: domain.EventPRMerged, PullRequestID: "pr-1"})
+			if tt.expectedDelivered {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, "503")
+			}
+
+			deliveries := rec.Deliveries()
+			require.Len(t, deliveries, tt.expectedAttempts)
+			for _, d := range deliveries {
+				// Every attempt carries the same delivery ID and body.
+				assert.Equal(t, "evt-1", d.Header.Get(service.WebhookDeliveryHeader))
+				assert.Equal(t, deliveries[0].Body, d.Body)
+			}
+		})
+	}
+}
+
+func TestWebhookSender_FailingWebhookDoesNotBlockOthers(t *testing.T) {
+	failing, failingSrv := newWebhookReceiver(t, http.StatusInternalServerError)
+	healthy, healthySrv := newWebhookReceiver(t)
+	sender := service.NewWebhookSender(stubWebhookLister{
+		{ID: 1, URL: failingSrv.URL, Secret: "a"},
+		{ID: 2, URL: healthySrv.URL, Secret: "b"},
+	}).WithRetryPolicy(fastWebhookRetries)
+
+	err := sender.Publish(context.Background(), domain.Event{ID: "evt-1", Type: domain.EventPRCreated})
+	assert.ErrorContains(t, err, "webhook 1")
+
+	assert.Len(t, failing.Deliveries(), 3)
+	require.Len(t, healthy.Deliveries(), 1)
+	assert.Equal(t, service.SignWebhookPayload("b", healthy.Deliveries()[0].Body), healthy.Deliveries()[0].Header.Get(service.WebhookSignatureHeader))
+}
+
+func TestWebhookSender_StopsRetryingOnCancel(t *testing.T) {
+	rec, srv := newWebhookReceiver(t, http.StatusInternalServerError)
+	sender := service.NewWebhookSender(stubWebhookLister{{ID: 1, URL: srv.URL, Secret: "s"}}).
+		WithRetryPolicy(service.RetryPolicy{Attempts: 5, BaseDelay: time.Hour})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		rec.waitDeliveries(t, 1)
+		cancel()
+	}()
+
+	err := sender.Publish(ctx, domain.Event{ID: "evt-1", Type: domain.EventPRMerged})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Len(t, rec.Deliveries(), 1)
+}